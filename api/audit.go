@@ -0,0 +1,191 @@
+// SPDX-FileCopyrightText: © 2026 Binaek Sarkar <binaek89@gmail.com>
+// SPDX-License-Identifier: Apache-2.0
+
+package api
+
+import (
+	"bufio"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/sentrie-sh/sentrie/api/middleware"
+	"github.com/sentrie-sh/sentrie/runtime"
+	"github.com/sentrie-sh/sentrie/trinary"
+)
+
+// DefaultAuditBuffer is the number of audit records held in memory while they wait to be written.
+const DefaultAuditBuffer = 4096
+
+// AuditRecord is the record of one evaluation, written as a line of JSON.
+// It never carries the facts themselves, only their hash.
+type AuditRecord struct {
+	Timestamp     time.Time      `json:"timestamp"`
+	CorrelationID string         `json:"correlation_id"`
+	Policy        string         `json:"policy"`
+	ContentHash   string         `json:"content_hash"`
+	FactsHash     string         `json:"facts_hash"`
+	Outcomes      []AuditOutcome `json:"outcomes"`
+	Error         string         `json:"error,omitempty"`
+}
+
+// AuditOutcome is the decision an evaluated rule came to, with the values it attached, such as
+// the reason for the decision, and the obligations it placed on the caller. Values derived
+// from sensitive facts are redacted.
+type AuditOutcome struct {
+	Rule        string                      `json:"rule"`
+	State       trinary.Value               `json:"state"`
+	Attachments runtime.DecisionAttachments `json:"attachments,omitempty"`
+	Obligations []runtime.Directive         `json:"obligations,omitempty"`
+}
+
+// DecisionSink receives the record of every evaluation answered by the decision endpoint.
+// Record is called on the response path, so it must not block; AuditLog, the default sink,
+// queues records and writes them in the background.
+type DecisionSink interface {
+	Record(record AuditRecord)
+}
+
+var _ DecisionSink = (*AuditLog)(nil)
+
+// AuditLog appends audit records to a writer, one JSON object per line. Records are handed
+// to a background writer through a buffer so that recording never blocks the response path;
+// when the buffer is full the record is dropped and counted.
+type AuditLog struct {
+	lock    sync.RWMutex
+	closed  bool
+	records chan AuditRecord
+	done    chan struct{}
+	dropped atomic.Uint64
+	err     error
+	logger  *slog.Logger
+}
+
+// NewAuditLog starts an audit log writing to w, holding up to buffer records in memory.
+// Close must be called to flush the records still buffered.
+func NewAuditLog(w io.Writer, buffer int) *AuditLog {
+	a := &AuditLog{
+		records: make(chan AuditRecord, buffer),
+		done:    make(chan struct{}),
+		logger:  slog.Default(),
+	}
+	go a.run(w)
+	return a
+}
+
+func (a *AuditLog) run(w io.Writer) {
+	defer close(a.done)
+
+	bw := bufio.NewWriter(w)
+	enc := json.NewEncoder(bw)
+	for record := range a.records {
+		if err := enc.Encode(record); err != nil {
+			a.fail(err)
+			continue
+		}
+		// flush once the backlog is drained, so records reach w in batches under load
+		if len(a.records) == 0 {
+			if err := bw.Flush(); err != nil {
+				a.fail(err)
+			}
+		}
+	}
+	if err := bw.Flush(); err != nil {
+		a.fail(err)
+	}
+}
+
+func (a *AuditLog) fail(err error) {
+	a.logger.Error("Error writing audit record", "error", err)
+	if a.err == nil {
+		a.err = err
+	}
+}
+
+// Record queues record to be written. It never blocks: a record arriving while the buffer
+// is full, or after Close, is dropped.
+func (a *AuditLog) Record(record AuditRecord) {
+	a.lock.RLock()
+	defer a.lock.RUnlock()
+
+	if a.closed {
+		a.dropped.Add(1)
+		return
+	}
+	select {
+	case a.records <- record:
+	default:
+		a.dropped.Add(1)
+		a.logger.Warn("Audit buffer full, dropping record", "correlation_id", record.CorrelationID)
+	}
+}
+
+// Dropped returns the number of records that could not be queued.
+func (a *AuditLog) Dropped() uint64 {
+	return a.dropped.Load()
+}
+
+// Close writes out the buffered records and stops the audit log. It returns the first
+// error met while writing.
+func (a *AuditLog) Close() error {
+	a.lock.Lock()
+	if !a.closed {
+		a.closed = true
+		close(a.records)
+	}
+	a.lock.Unlock()
+
+	<-a.done
+	return a.err
+}
+
+// hashFacts returns the hex encoded SHA-256 of the canonical JSON encoding of facts, whose map
+// keys are sorted at every level, so that identical facts hash the same however they were sent.
+func hashFacts(facts map[string]any) (string, error) {
+	raw, err := json.Marshal(facts)
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(raw)
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// recordAudit records the evaluation of policy with facts in the decision sink, if there is one,
+// under the request id held by ctx. Without a sink nothing is recorded, and the facts are not hashed.
+func (api *HTTPAPI) recordAudit(ctx context.Context, exec runtime.Executor, namespace, policy string, facts map[string]any, outputs []*runtime.ExecutorOutput, runErr error) {
+	if api.decisionSink == nil {
+		return
+	}
+
+	record := AuditRecord{
+		Timestamp:     time.Now().UTC(),
+		CorrelationID: middleware.GetRequestID(ctx),
+		Outcomes:      make([]AuditOutcome, 0, len(outputs)),
+	}
+	if p, err := exec.Index().ResolvePolicy(namespace, policy); err == nil {
+		record.Policy = p.FQN.String()
+		record.ContentHash = p.ContentHash()
+	}
+	factsHash, err := hashFacts(facts)
+	if err != nil {
+		api.logger.ErrorContext(ctx, "Error hashing facts for audit", "error", err)
+	}
+	record.FactsHash = factsHash
+	for _, output := range outputs {
+		if output == nil || output.Decision == nil {
+			continue
+		}
+		record.Outcomes = append(record.Outcomes, AuditOutcome{Rule: output.RuleName, State: output.Decision.State, Attachments: output.Attachments, Obligations: output.Obligations})
+	}
+	if runErr != nil {
+		record.Error = runErr.Error()
+	}
+
+	api.decisionSink.Record(record)
+}
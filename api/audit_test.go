@@ -0,0 +1,185 @@
+// SPDX-FileCopyrightText: © 2026 Binaek Sarkar <binaek89@gmail.com>
+// SPDX-License-Identifier: Apache-2.0
+
+package api
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/sentrie-sh/sentrie/box"
+	"github.com/sentrie-sh/sentrie/trinary"
+)
+
+func (s *APITestSuite) TestDecisionWritesAuditRecord() {
+	var buf bytes.Buffer
+	auditLog := NewAuditLog(&buf, DefaultAuditBuffer)
+	api := s.newTestHTTPAPI(WithAuditLog(auditLog))
+
+	rec := s.postDecision(api, `{"facts": {"note": "top secret"}}`)
+	s.Require().Equal(http.StatusOK, rec.Code)
+	s.Require().NoError(auditLog.Close())
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	s.Require().Len(lines, 1)
+	s.NotContains(lines[0], "top secret", "raw facts never reach the audit log")
+
+	var record map[string]any
+	s.Require().NoError(json.Unmarshal([]byte(lines[0]), &record))
+	s.ElementsMatch([]string{"timestamp", "correlation_id", "policy", "content_hash", "facts_hash", "outcomes"}, keysOf(record))
+
+	timestamp, err := time.Parse(time.RFC3339Nano, record["timestamp"].(string))
+	s.Require().NoError(err)
+	s.WithinDuration(time.Now(), timestamp, time.Minute)
+	s.NotEmpty(record["correlation_id"])
+
+	p, err := s.exec.Index().ResolvePolicy("com/example", "echo")
+	s.Require().NoError(err)
+	s.Equal("com/example/echo", record["policy"])
+	s.NotEmpty(p.ContentHash())
+	s.Equal(p.ContentHash(), record["content_hash"])
+
+	sum := sha256.Sum256([]byte(`{"note":"top secret"}`))
+	s.Equal(hex.EncodeToString(sum[:]), record["facts_hash"])
+
+	s.Equal([]any{map[string]any{"rule": "ok", "state": "true"}}, record["outcomes"])
+}
+
+func (s *APITestSuite) TestDecisionRedactsSensitiveFacts() {
+	var buf bytes.Buffer
+	auditLog := NewAuditLog(&buf, DefaultAuditBuffer)
+	api := s.newTestHTTPAPI(WithAuditLog(auditLog))
+
+	rec := s.postDecisionAt(api, "com/example/vault/ok", "/decision/com/example/vault/ok?explain=true", `{"facts": {"token": "tok_s3cr3t"}}`)
+	s.Require().Equal(http.StatusOK, rec.Code)
+	s.Contains(rec.Body.String(), `"state":"true"`, "the sensitive fact is still usable in rules")
+	s.NotContains(rec.Body.String(), "s3cr3t")
+
+	// a value of the wrong type surfaces in the error, redacted
+	rec = s.postDecisionAt(api, "com/example/vault/ok", "/decision/com/example/vault/ok", `{"facts": {"token": {"raw": "s3cr3t"}}}`)
+	s.Require().Equal(http.StatusOK, rec.Code)
+	s.Contains(rec.Body.String(), `"error":`)
+	s.NotContains(rec.Body.String(), "s3cr3t")
+
+	s.Require().NoError(auditLog.Close())
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	s.Require().Len(lines, 2)
+	s.NotContains(buf.String(), "s3cr3t")
+	s.Contains(lines[1], `"error":`)
+}
+
+func (s *APITestSuite) TestDecisionWithoutAuditLogWritesNothing() {
+	api := s.newTestHTTPAPI()
+
+	rec := s.postDecision(api, `{"facts": {}}`)
+	s.Equal(http.StatusOK, rec.Code)
+	s.Nil(api.decisionSink)
+
+	// a nil audit log is the same as none
+	var auditLog *AuditLog
+	api = s.newTestHTTPAPI(WithAuditLog(auditLog))
+	rec = s.postDecision(api, `{"facts": {}}`)
+	s.Equal(http.StatusOK, rec.Code)
+	s.True(api.decisionSink == nil, "the sink is not a nil *AuditLog")
+}
+
+// recordingSink keeps the records handed to it.
+type recordingSink struct {
+	records []AuditRecord
+}
+
+func (r *recordingSink) Record(record AuditRecord) {
+	r.records = append(r.records, record)
+}
+
+func (s *APITestSuite) TestDecisionRecordsInCustomSink() {
+	sink := &recordingSink{}
+	api := s.newTestHTTPAPI(WithDecisionSink(sink))
+
+	rec := s.postDecisionAt(api, "com/example/maybe/ok", "/decision/com/example/maybe/ok", `{"facts": {}}`)
+	s.Require().Equal(http.StatusOK, rec.Code)
+
+	s.Require().Len(sink.records, 1)
+	record := sink.records[0]
+	s.Equal("com/example/maybe", record.Policy)
+	s.NotEmpty(record.CorrelationID)
+	s.Require().Len(record.Outcomes, 1)
+	s.Equal("ok", record.Outcomes[0].Rule)
+	s.Equal(trinary.Unknown, record.Outcomes[0].State)
+	s.Equal(box.Trinary(trinary.Unknown), record.Outcomes[0].Attachments["why"])
+}
+
+func (s *APITestSuite) TestDecisionRecordsAttachments() {
+	var buf bytes.Buffer
+	auditLog := NewAuditLog(&buf, DefaultAuditBuffer)
+	api := s.newTestHTTPAPI(WithAuditLog(auditLog))
+
+	rec := s.postDecisionAt(api, "com/example/vault/ok", "/decision/com/example/vault/ok", `{"facts": {"token": "tok_s3cr3t"}}`)
+	s.Require().Equal(http.StatusOK, rec.Code)
+	s.Require().NoError(auditLog.Close())
+
+	var record map[string]any
+	s.Require().NoError(json.Unmarshal(bytes.TrimSpace(buf.Bytes()), &record))
+	s.Equal([]any{map[string]any{"rule": "ok", "state": "true", "attachments": map[string]any{"seen": "***"}}}, record["outcomes"])
+}
+
+func (s *APITestSuite) TestHashFactsIsCanonical() {
+	first, err := hashFacts(map[string]any{"b": 1.0, "a": map[string]any{"y": "x", "x": []any{true}}})
+	s.Require().NoError(err)
+	var decoded map[string]any
+	s.Require().NoError(json.Unmarshal([]byte(`{"a": {"x": [true], "y": "x"}, "b": 1}`), &decoded))
+	second, err := hashFacts(decoded)
+	s.Require().NoError(err)
+	s.Equal(first, second)
+}
+
+func (s *APITestSuite) TestAuditLogRecordDoesNotBlockOnSlowWriter() {
+	// nothing reads the pipe, so the writer stalls on the first record it flushes
+	pr, pw := io.Pipe()
+	auditLog := NewAuditLog(pw, 1)
+
+	recorded := make(chan struct{})
+	go func() {
+		defer close(recorded)
+		for range 10 {
+			auditLog.Record(AuditRecord{CorrelationID: "req"})
+		}
+	}()
+
+	select {
+	case <-recorded:
+	case <-time.After(5 * time.Second):
+		s.FailNow("Record blocked on a stalled writer")
+	}
+	s.NotZero(auditLog.Dropped())
+
+	// unblock the writer; the write error is reported by Close
+	s.Require().NoError(pr.CloseWithError(errors.New("stalled")))
+	s.Error(auditLog.Close())
+}
+
+func (s *APITestSuite) TestAuditLogDropsRecordsAfterClose() {
+	var buf bytes.Buffer
+	auditLog := NewAuditLog(&buf, DefaultAuditBuffer)
+	s.Require().NoError(auditLog.Close())
+
+	auditLog.Record(AuditRecord{CorrelationID: "late"})
+	s.Equal(uint64(1), auditLog.Dropped())
+	s.Empty(buf.String())
+	s.NoError(auditLog.Close(), "closing twice is harmless")
+}
+
+func keysOf(m map[string]any) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	return keys
+}
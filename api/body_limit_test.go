@@ -0,0 +1,133 @@
+// SPDX-FileCopyrightText: © 2026 Binaek Sarkar <binaek89@gmail.com>
+// SPDX-License-Identifier: Apache-2.0
+
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+
+	"github.com/sentrie-sh/sentrie/api/middleware"
+	"github.com/sentrie-sh/sentrie/index"
+	"github.com/sentrie-sh/sentrie/pack"
+	"github.com/sentrie-sh/sentrie/parser"
+	"github.com/sentrie-sh/sentrie/runtime"
+)
+
+const bodyLimitPolicy = `namespace com/example
+policy echo {
+  title "Echo"
+  fact note?: string
+  rule ok = default true { yield true }
+  export decision of ok
+}
+policy other {
+  fact user?: string
+  rule ok = default true { yield true }
+  export decision of ok
+}
+policy vault {
+  fact sensitive token: string
+  rule ok = default false { yield token != "nope" }
+  export decision of ok attach seen as token
+}
+policy maybe {
+  rule ok = { yield unknown }
+  export decision of ok attach why as unknown
+}
+policy combined {
+  combining "first-applicable"
+  rule undecided = { yield unknown }
+  rule no = { yield false }
+  export decision of undecided
+  export decision of no
+}`
+
+// newTestHTTPAPI builds an HTTPAPI over an executor for bodyLimitPolicy.
+func (s *APITestSuite) newTestHTTPAPI(opts ...HTTPAPIOption) *HTTPAPI {
+	if s.exec == nil {
+		ctx := context.Background()
+		program, err := parser.NewParserFromString(bodyLimitPolicy, "echo.sentrie").ParseProgram(ctx)
+		s.Require().NoError(err)
+
+		idx := index.CreateIndex()
+		s.Require().NoError(idx.SetPack(ctx, &pack.PackFile{Location: s.T().TempDir()}))
+		s.Require().NoError(idx.AddProgram(ctx, program))
+		view, err := idx.View(ctx)
+		s.Require().NoError(err)
+
+		s.exec, err = runtime.NewExecutor(view)
+		s.Require().NoError(err)
+	}
+	return NewHTTPAPI(s.exec, opts...)
+}
+
+func (s *APITestSuite) postDecision(api *HTTPAPI, body string) *httptest.ResponseRecorder {
+	return s.postDecisionTo(api, "/decision/com/example/echo/ok", body)
+}
+
+// postDecisionTo is postDecision with a target that may carry a query string.
+func (s *APITestSuite) postDecisionTo(api *HTTPAPI, target, body string) *httptest.ResponseRecorder {
+	return s.postDecisionAt(api, "com/example/echo/ok", target, body)
+}
+
+// postDecisionAt is postDecisionTo for the rule at path.
+func (s *APITestSuite) postDecisionAt(api *HTTPAPI, path, target, body string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodPost, target, strings.NewReader(body))
+	req.SetPathValue("target", path)
+	rec := httptest.NewRecorder()
+	middleware.RequestIDMiddleware(http.HandlerFunc(api.handleDecision)).ServeHTTP(rec, req)
+	return rec
+}
+
+func (s *APITestSuite) TestDecisionBodyUnderLimitSucceeds() {
+	api := s.newTestHTTPAPI(WithMaxRequestBody(256))
+
+	rec := s.postDecision(api, `{"facts": {"note": "`+strings.Repeat("a", 100)+`"}}`)
+	s.Equal(http.StatusOK, rec.Code)
+	s.Contains(rec.Body.String(), `"outcomes"`)
+}
+
+func (s *APITestSuite) TestDecisionBodyOverLimitIsRejected() {
+	api := s.newTestHTTPAPI(WithMaxRequestBody(256))
+
+	rec := s.postDecision(api, `{"facts": {"note": "`+strings.Repeat("a", 1000)+`"}}`)
+	s.Equal(http.StatusRequestEntityTooLarge, rec.Code)
+	s.Equal("application/problem+json", rec.Header().Get("Content-Type"))
+	s.Contains(rec.Body.String(), "Payload Too Large")
+	s.Contains(rec.Body.String(), "256 bytes")
+}
+
+func (s *APITestSuite) TestDecisionBodyDefaultLimit() {
+	api := s.newTestHTTPAPI()
+	s.Equal(int64(DefaultMaxRequestBody), api.maxRequestBody)
+
+	rec := s.postDecision(api, `{"facts": {"note": "`+strings.Repeat("a", DefaultMaxRequestBody)+`"}}`)
+	s.Equal(http.StatusRequestEntityTooLarge, rec.Code)
+}
+
+func (s *APITestSuite) TestDecisionMalformedBodyIsBadRequest() {
+	api := s.newTestHTTPAPI()
+
+	rec := s.postDecision(api, `{"facts": `)
+	s.Equal(http.StatusBadRequest, rec.Code)
+	s.Contains(rec.Body.String(), "Invalid JSON")
+}
+
+func (s *APITestSuite) TestDecisionWarningsAreThoseOfTheEvaluatedPolicy() {
+	api := s.newTestHTTPAPI()
+	s.Require().Len(s.exec.Index().Warnings(), 2)
+
+	rec := s.postDecision(api, `{"facts": {}}`)
+	s.Require().Equal(http.StatusOK, rec.Code)
+
+	var body struct {
+		Warnings []string `json:"warnings"`
+	}
+	s.Require().NoError(json.Unmarshal(rec.Body.Bytes(), &body))
+	s.Require().Len(body.Warnings, 1)
+	s.Contains(body.Warnings[0], "'note'")
+}
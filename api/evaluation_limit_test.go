@@ -0,0 +1,51 @@
+// SPDX-FileCopyrightText: © 2026 Binaek Sarkar <binaek89@gmail.com>
+// SPDX-License-Identifier: Apache-2.0
+
+package api
+
+import (
+	"net/http"
+	"sync"
+)
+
+func (s *APITestSuite) TestDecisionUnderEvaluationLimitSucceeds() {
+	api := s.newTestHTTPAPI(WithMaxConcurrentEvaluations(4))
+
+	var wg sync.WaitGroup
+	codes := make([]int, 4)
+	for i := range codes {
+		wg.Go(func() {
+			codes[i] = s.postDecision(api, `{"facts": {}}`).Code
+		})
+	}
+	wg.Wait()
+
+	for _, code := range codes {
+		s.Equal(http.StatusOK, code)
+	}
+	s.Empty(api.evaluations, "every evaluation slot is released")
+}
+
+func (s *APITestSuite) TestDecisionSaturatedReturnsServiceUnavailable() {
+	api := s.newTestHTTPAPI(WithMaxConcurrentEvaluations(2))
+
+	// hold every slot, as two long-running evaluations would
+	api.evaluations <- struct{}{}
+	api.evaluations <- struct{}{}
+
+	rec := s.postDecision(api, `{"facts": {}}`)
+	s.Equal(http.StatusServiceUnavailable, rec.Code)
+	s.Equal("1", rec.Header().Get("Retry-After"))
+	s.Equal("application/problem+json", rec.Header().Get("Content-Type"))
+	s.Contains(rec.Body.String(), "already running 2 evaluations")
+
+	// a freed slot admits the next request
+	<-api.evaluations
+	rec = s.postDecision(api, `{"facts": {}}`)
+	s.Equal(http.StatusOK, rec.Code)
+}
+
+func (s *APITestSuite) TestDecisionDefaultEvaluationLimit() {
+	api := s.newTestHTTPAPI()
+	s.Equal(DefaultMaxConcurrentEvaluations, cap(api.evaluations))
+}
@@ -0,0 +1,124 @@
+// SPDX-FileCopyrightText: © 2026 Binaek Sarkar <binaek89@gmail.com>
+// SPDX-License-Identifier: Apache-2.0
+
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net"
+	"time"
+
+	"github.com/sentrie-sh/sentrie/api/middleware"
+	sentriev1 "github.com/sentrie-sh/sentrie/proto/sentrie/v1"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+// grpcShutdownGrace is how long StopServer waits for the gRPC calls in flight to finish before
+// cancelling them.
+const grpcShutdownGrace = 10 * time.Second
+
+// evaluationService answers the gRPC evaluation service with the executor, evaluation slots,
+// metrics and decision sink of an HTTPAPI, so that its decisions are those of POST /decision.
+type evaluationService struct {
+	sentriev1.UnimplementedEvaluationServiceServer
+	api *HTTPAPI
+}
+
+var _ sentriev1.EvaluationServiceServer = (*evaluationService)(nil)
+
+// Evaluate evaluates the policy, or the rule, of the target of req with its facts.
+func (s *evaluationService) Evaluate(ctx context.Context, req *sentriev1.EvaluateRequest) (*sentriev1.EvaluateResponse, error) {
+	ctx = middleware.WithRequestID(ctx)
+
+	// the whole call is answered from the policies being served when it arrived
+	exec := s.api.currentExecutor()
+	if exec == nil {
+		return nil, status.Error(codes.Unavailable, errPoliciesNotLoaded.Error())
+	}
+
+	namespace, policy, rule, err := exec.Index().ResolveSegments(req.GetTarget())
+	if err != nil {
+		s.api.countRequestError(RequestErrorValidation)
+		return nil, status.Error(codes.NotFound, err.Error())
+	}
+
+	release, ok := s.api.tryAcquireEvaluation()
+	if !ok {
+		return nil, status.Errorf(codes.ResourceExhausted, "the server is already running %d evaluations, retry later", cap(s.api.evaluations))
+	}
+	defer release()
+
+	response := s.api.decide(ctx, exec, namespace, policy, rule, req.GetFacts().AsMap(), req.GetExplain(), req.GetIncludeMetadata())
+	return toEvaluateResponse(response)
+}
+
+// toEvaluateResponse converts response to its protobuf message through its JSON encoding, which
+// the message mirrors field for field, so that both transports serialize values alike. The
+// deprecated decisions have no field in the message and are not encoded.
+func toEvaluateResponse(response *DecisionResponse) (*sentriev1.EvaluateResponse, error) {
+	withoutDecisions := *response
+	withoutDecisions.Decisions = nil
+	raw, err := json.Marshal(&withoutDecisions)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "cannot encode the decision: %v", err)
+	}
+	message := &sentriev1.EvaluateResponse{}
+	if err := (protojson.UnmarshalOptions{DiscardUnknown: true}).Unmarshal(raw, message); err != nil {
+		return nil, status.Errorf(codes.Internal, "cannot encode the decision: %v", err)
+	}
+	return message, nil
+}
+
+// SetupGRPC opens the listeners of the gRPC evaluation service on port at every address of
+// listen; StartServer serves them alongside the HTTP endpoints. A call carries at most the
+// bytes of a request body, see WithMaxRequestBody.
+func (api *HTTPAPI) SetupGRPC(ctx context.Context, port int, listen []string) error {
+	bindings, err := resolveBindings(port, listen)
+	if err != nil {
+		return err
+	}
+
+	api.grpcListeners = nil
+	for _, binding := range bindings {
+		ln, err := net.Listen("tcp", binding)
+		if err != nil {
+			// Close any already opened listeners
+			for _, l := range api.grpcListeners {
+				_ = l.Close()
+			}
+			api.grpcListeners = nil
+			return fmt.Errorf("failed to listen on %s: %w", binding, err)
+		}
+		api.grpcListeners = append(api.grpcListeners, ln)
+		api.logger.DebugContext(ctx, "Listening on gRPC server", "binding", binding)
+	}
+
+	api.grpcServer = grpc.NewServer(grpc.MaxRecvMsgSize(int(api.maxRequestBody)))
+	sentriev1.RegisterEvaluationServiceServer(api.grpcServer, &evaluationService{api: api})
+	return nil
+}
+
+// stopGRPC stops the gRPC server, letting the calls in flight finish for up to
+// grpcShutdownGrace.
+func (api *HTTPAPI) stopGRPC() {
+	if api.grpcServer == nil {
+		return
+	}
+	stopped := make(chan struct{})
+	go func() {
+		api.grpcServer.GracefulStop()
+		close(stopped)
+	}()
+	select {
+	case <-stopped:
+	case <-time.After(grpcShutdownGrace):
+		api.grpcServer.Stop()
+	}
+	api.grpcServer = nil
+	api.grpcListeners = nil
+}
@@ -0,0 +1,138 @@
+// SPDX-FileCopyrightText: © 2026 Binaek Sarkar <binaek89@gmail.com>
+// SPDX-License-Identifier: Apache-2.0
+
+package api
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/sentrie-sh/sentrie/api/middleware"
+	sentriev1 "github.com/sentrie-sh/sentrie/proto/sentrie/v1"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+// dialGRPC serves the gRPC evaluation service of api on a loopback port until the test ends,
+// and returns a client of it.
+func (s *APITestSuite) dialGRPC(api *HTTPAPI) sentriev1.EvaluationServiceClient {
+	ctx := context.Background()
+	s.Require().NoError(api.SetupGRPC(ctx, 0, []string{"local4"}))
+	s.Require().Len(api.grpcListeners, 1)
+	addr := api.grpcListeners[0].Addr().String()
+	go api.StartServer(ctx, 0, nil)
+	s.T().Cleanup(func() { s.NoError(api.StopServer(ctx)) })
+
+	conn, err := grpc.NewClient(addr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	s.Require().NoError(err)
+	s.T().Cleanup(func() { _ = conn.Close() })
+	return sentriev1.NewEvaluationServiceClient(conn)
+}
+
+func (s *APITestSuite) TestGRPCEvaluateDecidesAsTheDecisionEndpoint() {
+	sink := &recordingSink{}
+	api := s.newTestHTTPAPI(WithDecisionSink(sink))
+	client := s.dialGRPC(api)
+	ctx := context.Background()
+
+	response, err := client.Evaluate(ctx, &sentriev1.EvaluateRequest{Target: "com/example/maybe/ok"})
+	s.Require().NoError(err)
+	s.EqualValues(5, response.GetSchemaVersion())
+	s.Equal("com/example/maybe", response.GetPolicy())
+	s.NotEmpty(response.GetRequestId())
+	s.Require().Len(response.GetOutcomes(), 1)
+	outcome := response.GetOutcomes()[0]
+	s.Equal("unknown", outcome.GetDecision().GetState())
+	s.Equal("unknown", outcome.GetDecision().GetValue().GetStringValue())
+	s.Equal("unknown", outcome.GetAttachments()["why"].GetStringValue())
+
+	// the combined decision of a whole policy
+	response, err = client.Evaluate(ctx, &sentriev1.EvaluateRequest{Target: "com/example/combined"})
+	s.Require().NoError(err)
+	s.Equal("unknown", response.GetDecision().GetState())
+
+	// facts are those of the request, and recorded under the id of the call
+	facts, err := structpb.NewStruct(map[string]any{"token": "tok_s3cr3t"})
+	s.Require().NoError(err)
+	response, err = client.Evaluate(ctx, &sentriev1.EvaluateRequest{Target: "com/example/vault/ok", Facts: facts})
+	s.Require().NoError(err)
+	s.Equal("true", response.GetOutcomes()[0].GetDecision().GetState())
+	s.Require().Len(sink.records, 3)
+	s.Equal(response.GetRequestId(), sink.records[2].CorrelationID)
+}
+
+func (s *APITestSuite) TestGRPCEvaluateResponseMirrorsTheDecisionResponse() {
+	api := s.newTestHTTPAPI()
+
+	// the same evaluation, explained and with metadata, encoded for each transport
+	response := api.decide(middleware.WithRequestID(context.Background()), s.exec, "com/example", "echo", "", map[string]any{"note": "hi"}, true, true)
+	raw, err := json.Marshal(response)
+	s.Require().NoError(err)
+	message, err := toEvaluateResponse(response)
+	s.Require().NoError(err)
+	s.Require().NotNil(message.GetOutcomes()[0].GetExplain())
+	s.Equal("Echo", message.GetOutcomes()[0].GetMetadata().AsMap()["title"])
+
+	mirrored, err := protojson.MarshalOptions{UseProtoNames: true}.Marshal(message)
+	s.Require().NoError(err)
+	var want, got any
+	s.Require().NoError(json.Unmarshal(raw, &want))
+	s.Require().NoError(json.Unmarshal(mirrored, &got))
+	// the deprecated "decisions" are only served over HTTP, for the clients predating "outcomes"
+	delete(want.(map[string]any), "decisions")
+	s.Equal(withoutEmpty(want), withoutEmpty(got))
+}
+
+// withoutEmpty drops the empty lists and objects of a decoded JSON value, which protobuf does not
+// tell apart from absent ones.
+func withoutEmpty(v any) any {
+	switch v := v.(type) {
+	case map[string]any:
+		out := map[string]any{}
+		for key, value := range v {
+			value = withoutEmpty(value)
+			if m, ok := value.(map[string]any); ok && len(m) == 0 {
+				continue
+			}
+			if l, ok := value.([]any); ok && len(l) == 0 {
+				continue
+			}
+			out[key] = value
+		}
+		return out
+	case []any:
+		out := make([]any, 0, len(v))
+		for _, value := range v {
+			out = append(out, withoutEmpty(value))
+		}
+		return out
+	default:
+		return v
+	}
+}
+
+func (s *APITestSuite) TestGRPCEvaluateRejectsUnresolvedTargets() {
+	client := s.dialGRPC(s.newTestHTTPAPI())
+
+	_, err := client.Evaluate(context.Background(), &sentriev1.EvaluateRequest{Target: "com/example/missing"})
+	s.Equal(codes.NotFound, status.Code(err))
+}
+
+func (s *APITestSuite) TestGRPCEvaluateSharesTheEvaluationSlots() {
+	api := s.newTestHTTPAPI(WithMaxConcurrentEvaluations(1))
+	client := s.dialGRPC(api)
+
+	// the slot is held, as by an evaluation of the decision endpoint
+	release, ok := api.tryAcquireEvaluation()
+	s.Require().True(ok)
+	_, err := client.Evaluate(context.Background(), &sentriev1.EvaluateRequest{Target: "com/example/echo"})
+	s.Equal(codes.ResourceExhausted, status.Code(err))
+
+	release()
+	_, err = client.Evaluate(context.Background(), &sentriev1.EvaluateRequest{Target: "com/example/echo"})
+	s.NoError(err)
+}
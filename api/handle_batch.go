@@ -0,0 +1,141 @@
+// SPDX-FileCopyrightText: © 2026 Binaek Sarkar <binaek89@gmail.com>
+// SPDX-License-Identifier: Apache-2.0
+
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/sentrie-sh/sentrie/api/middleware"
+	"github.com/sentrie-sh/sentrie/runtime"
+	"github.com/sentrie-sh/sentrie/trinary"
+)
+
+// DefaultBatchConcurrency is the number of inputs of a batch evaluated at once unless
+// WithBatchConcurrency says otherwise.
+const DefaultBatchConcurrency = 8
+
+// BatchRequest represents the request body for batch evaluation
+type BatchRequest struct {
+	// Inputs are the fact sets to evaluate, each on its own
+	Inputs []map[string]any `json:"inputs"`
+}
+
+// BatchResponse represents the response from batch evaluation. Its items are in the order of
+// the inputs, see runtime.BatchResult.
+type BatchResponse struct {
+	Items   []*BatchItemResponse  `json:"items"`
+	Summary *runtime.BatchSummary `json:"summary"`
+	// RequestID identifies the request, the same as in audit records and problem details
+	RequestID string `json:"request_id"`
+	// Policy is the FQN of the evaluated policy
+	Policy string `json:"policy"`
+	// DurationMs is how long the whole batch took, in milliseconds
+	DurationMs float64 `json:"duration_ms"`
+}
+
+// BatchItemResponse is the evaluation of one input of a batch, carrying its outcomes in the same
+// versioned result envelope as a decision, see runtime.Result.
+type BatchItemResponse struct {
+	*runtime.Result
+	Index    int           `json:"index"`
+	Outcome  trinary.Value `json:"outcome"`
+	TimedOut bool          `json:"timed_out,omitempty"`
+	// Invalid is set when the facts of the input were not valid; such an input is not evaluated
+	Invalid bool   `json:"invalid,omitempty"`
+	Error   string `json:"error,omitempty"`
+	// DurationMs is how long the evaluation of the input took, in milliseconds
+	DurationMs float64 `json:"duration_ms"`
+}
+
+// handleBatch handles POST /batch/{namespace...} requests. Every input is evaluated as by the
+// decision endpoint, at most batchConcurrency at once, and the whole batch takes a single
+// evaluation slot. Facts are validated for each input on its own: an input with invalid facts is
+// reported as invalid without failing the batch. As with a decision, the trace of each outcome is
+// only included with ?explain=true, and the policy metadata with ?include_metadata=true.
+func (api *HTTPAPI) handleBatch(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	path := r.PathValue("target")
+	if path == "" {
+		api.writeErrorResponse(w, r, http.StatusBadRequest, "Invalid Path", "The path parameter is required but was not provided")
+		return
+	}
+
+	// the whole batch is answered from the policies being served when it arrived
+	exec, ok := api.servingExecutor(w, r)
+	if !ok {
+		return
+	}
+
+	namespace, policy, _, err := exec.Index().ResolveSegments(path)
+	if err != nil {
+		api.countRequestError(RequestErrorValidation)
+		api.writeErrorResponse(w, r, http.StatusNotFound, "Invalid Path", err.Error())
+		return
+	}
+
+	release, ok := api.acquireEvaluation(w, r)
+	if !ok {
+		return
+	}
+	defer release()
+
+	var req BatchRequest
+	if !api.decodeBody(w, r, &req) {
+		return
+	}
+
+	withExplain := r.URL.Query().Get("explain") == "true"
+	withMetadata := r.URL.Query().Get("include_metadata") == "true"
+	// an explained batch traces every sub-expression, even of compiled rules
+	if withExplain {
+		ctx = runtime.WithFullTrace(ctx)
+	}
+
+	start := time.Now()
+	result, err := runtime.EvaluateBatch(ctx, exec, path, req.Inputs, api.batchConcurrency, runtime.WithBatchFactValidation())
+	if err != nil {
+		api.writeErrorResponse(w, r, http.StatusNotFound, "Invalid Path", err.Error())
+		return
+	}
+	duration := time.Since(start)
+
+	warnings := exec.Index().PolicyWarnings(namespace, policy)
+	items := make([]*BatchItemResponse, 0, len(result.Items))
+	for _, item := range result.Items {
+		if api.metrics != nil {
+			api.metrics.ObserveEvaluation(namespace+"/"+policy, evaluationOutcome(item.Outputs, item.Combined, item.Err), item.Duration)
+		}
+		api.recordAudit(ctx, exec, namespace, policy, req.Inputs[item.Index], item.Outputs, item.Err)
+
+		itemResult := runtime.NewResult(item.Outputs, warnings, withExplain, withMetadata)
+		itemResult.Decision = item.Combined
+		items = append(items, &BatchItemResponse{
+			Result:     itemResult,
+			Index:      item.Index,
+			Outcome:    item.Outcome,
+			TimedOut:   item.TimedOut,
+			Invalid:    item.Invalid,
+			Error:      item.Error,
+			DurationMs: float64(item.Duration.Microseconds()) / 1000,
+		})
+	}
+
+	response := BatchResponse{
+		Items:      items,
+		Summary:    result.Summary,
+		RequestID:  middleware.GetRequestIDFromRequest(r),
+		Policy:     namespace + "/" + policy,
+		DurationMs: float64(duration.Microseconds()) / 1000,
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+
+	if err := json.NewEncoder(w).Encode(response); err != nil {
+		api.logger.ErrorContext(ctx, "Error encoding batch response", "error", err)
+	}
+}
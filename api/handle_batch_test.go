@@ -0,0 +1,167 @@
+// SPDX-FileCopyrightText: © 2026 Binaek Sarkar <binaek89@gmail.com>
+// SPDX-License-Identifier: Apache-2.0
+
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+
+	"github.com/sentrie-sh/sentrie/api/middleware"
+	"github.com/sentrie-sh/sentrie/runtime"
+)
+
+// postBatch posts body to the batch endpoint of api for the policy or rule at path.
+func (s *APITestSuite) postBatch(api *HTTPAPI, path, body string) *httptest.ResponseRecorder {
+	return s.postBatchWithQuery(api, path, "", body)
+}
+
+// postBatchWithQuery is postBatch with the query string query.
+func (s *APITestSuite) postBatchWithQuery(api *HTTPAPI, path, query, body string) *httptest.ResponseRecorder {
+	target := "/batch/" + path
+	if query != "" {
+		target += "?" + query
+	}
+	req := httptest.NewRequest(http.MethodPost, target, strings.NewReader(body))
+	req.SetPathValue("target", path)
+	rec := httptest.NewRecorder()
+	middleware.RequestIDMiddleware(http.HandlerFunc(api.handleBatch)).ServeHTTP(rec, req)
+	return rec
+}
+
+type batchResponseBody struct {
+	RequestID string `json:"request_id"`
+	Policy    string `json:"policy"`
+	Items     []struct {
+		Index   int    `json:"index"`
+		Outcome string `json:"outcome"`
+		Invalid bool   `json:"invalid"`
+		Error   string `json:"error"`
+	} `json:"items"`
+	Summary struct {
+		Passed         int   `json:"passed"`
+		Failed         int   `json:"failed"`
+		Invalid        int   `json:"invalid"`
+		InvalidIndices []int `json:"invalid_indices"`
+	} `json:"summary"`
+}
+
+func (s *APITestSuite) TestBatchReportsInvalidInputsWithoutFailingTheBatch() {
+	sink := &recordingSink{}
+	api := s.newTestHTTPAPI(WithDecisionSink(sink))
+
+	rec := s.postBatch(api, "com/example/vault", `{"inputs": [{"token": "tok_s3cr3t"}, {}, {"token": 5}, {"token": "nope"}]}`)
+	s.Require().Equal(http.StatusOK, rec.Code)
+	var response batchResponseBody
+	s.Require().NoError(json.Unmarshal(rec.Body.Bytes(), &response))
+
+	s.Equal("com/example/vault", response.Policy)
+	s.Require().Len(response.Items, 4)
+	s.Equal("true", response.Items[0].Outcome)
+	s.Equal("false", response.Items[3].Outcome)
+	for _, item := range response.Items[1:3] {
+		s.True(item.Invalid)
+		s.Contains(item.Error, "token")
+	}
+	s.Equal(1, response.Summary.Passed)
+	s.Equal(1, response.Summary.Failed)
+	s.Equal(2, response.Summary.Invalid)
+	s.Equal([]int{1, 2}, response.Summary.InvalidIndices)
+
+	// every input is recorded, under the id of the request
+	s.Require().Len(sink.records, 4)
+	for _, record := range sink.records {
+		s.Equal(response.RequestID, record.CorrelationID)
+	}
+}
+
+func (s *APITestSuite) TestBatchKeepsInputOrder() {
+	api := s.newTestHTTPAPI(WithBatchConcurrency(4))
+
+	inputs := make([]string, 40)
+	for i := range inputs {
+		token := "nope"
+		if i%3 == 0 {
+			token = "tok_s3cr3t"
+		}
+		inputs[i] = fmt.Sprintf(`{"token": %q}`, token)
+	}
+	rec := s.postBatch(api, "com/example/vault/ok", `{"inputs": [`+strings.Join(inputs, ",")+`]}`)
+	s.Require().Equal(http.StatusOK, rec.Code)
+	var response batchResponseBody
+	s.Require().NoError(json.Unmarshal(rec.Body.Bytes(), &response))
+
+	s.Require().Len(response.Items, len(inputs))
+	for i, item := range response.Items {
+		s.Equal(i, item.Index)
+		want := "false"
+		if i%3 == 0 {
+			want = "true"
+		}
+		s.Equal(want, item.Outcome, "input %d", i)
+	}
+}
+
+func (s *APITestSuite) TestBatchRejectsUnresolvedTargetsAndInvalidBodies() {
+	api := s.newTestHTTPAPI()
+
+	rec := s.postBatch(api, "com/example/missing", `{"inputs": []}`)
+	s.Equal(http.StatusNotFound, rec.Code)
+
+	rec = s.postBatch(api, "com/example/vault", `{"inputs": [`)
+	s.Equal(http.StatusBadRequest, rec.Code)
+}
+
+func (s *APITestSuite) TestBatchItemsAreExplainedOnlyWhenAskedFor() {
+	api := s.newTestHTTPAPI()
+	body := `{"inputs": [{"token": "tok_s3cr3t"}, {"token": "nope"}]}`
+
+	var response struct {
+		Items []struct {
+			SchemaVersion int              `json:"schema_version"`
+			Outcomes      []map[string]any `json:"outcomes"`
+			Outputs       []map[string]any `json:"outputs"`
+		} `json:"items"`
+	}
+	rec := s.postBatch(api, "com/example/vault", body)
+	s.Require().Equal(http.StatusOK, rec.Code)
+	s.Require().NoError(json.Unmarshal(rec.Body.Bytes(), &response))
+	s.Require().Len(response.Items, 2)
+	for _, item := range response.Items {
+		s.Equal(runtime.ResultSchemaVersion, item.SchemaVersion)
+		s.Nil(item.Outputs)
+		s.Require().NotEmpty(item.Outcomes)
+		for _, outcome := range item.Outcomes {
+			s.NotContains(outcome, "explain")
+		}
+	}
+
+	response.Items = nil
+	rec = s.postBatchWithQuery(api, "com/example/vault", "explain=true", body)
+	s.Require().Equal(http.StatusOK, rec.Code)
+	s.Require().NoError(json.Unmarshal(rec.Body.Bytes(), &response))
+	s.Require().Len(response.Items, 2)
+	for _, item := range response.Items {
+		for _, outcome := range item.Outcomes {
+			s.Contains(outcome, "explain")
+		}
+	}
+}
+
+func (s *APITestSuite) TestBatchCountsEveryInput() {
+	metrics := NewMetrics()
+	api := s.newTestHTTPAPI(WithMetrics(metrics, 0))
+
+	rec := s.postBatch(api, "com/example/vault/ok", `{"inputs": [{"token": "tok_s3cr3t"}, {"token": "nope"}, {"token": "nope"}]}`)
+	s.Require().Equal(http.StatusOK, rec.Code)
+
+	rec = httptest.NewRecorder()
+	metrics.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	text := rec.Body.String()
+	s.Contains(text, "sentrie_evaluations_total{policy=\"com/example/vault\",outcome=\"permit\"} 1\n")
+	s.Contains(text, "sentrie_evaluations_total{policy=\"com/example/vault\",outcome=\"deny\"} 2\n")
+	s.Contains(text, "sentrie_evaluation_duration_seconds_count{policy=\"com/example/vault\"} 3\n")
+}
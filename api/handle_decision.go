@@ -17,10 +17,13 @@
 package api
 
 import (
+	"context"
 	"encoding/json"
 	"net/http"
 	"strings"
+	"time"
 
+	"github.com/sentrie-sh/sentrie/api/middleware"
 	"github.com/sentrie-sh/sentrie/runtime"
 )
 
@@ -29,10 +32,24 @@ type DecisionRequest struct {
 	Facts map[string]any `json:"facts"`
 }
 
-// DecisionResponse represents the response from rule execution
+// DecisionResponse represents the response from rule execution.
+// It carries the versioned result envelope, see runtime.Result.
 type DecisionResponse struct {
+	*runtime.Result
+	// Decisions holds the executor outputs the outcomes are built from, in the shape they were
+	// served as before the envelope was introduced. Their trace is only included with explain,
+	// as in the outcomes.
+	//
+	// Deprecated: read "outcomes"; "decisions" is kept for existing clients and will be dropped
+	// with a later schema version.
 	Decisions []*runtime.ExecutorOutput `json:"decisions"`
-	Error     string                    `json:"error,omitempty"`
+	// RequestID identifies the request, the same as in audit records and problem details
+	RequestID string `json:"request_id"`
+	// Policy is the FQN of the evaluated policy
+	Policy string `json:"policy"`
+	// DurationMs is how long the evaluation took, in milliseconds
+	DurationMs float64 `json:"duration_ms"`
+	Error      string  `json:"error,omitempty"`
 }
 
 // handleDecision handles POST /decision/{namespace...} requests
@@ -64,9 +81,16 @@ func (api *HTTPAPI) handleDecision(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	// the whole request is answered from the policies being served when it arrived
+	exec, ok := api.servingExecutor(w, r)
+	if !ok {
+		return
+	}
+
 	// Create span for path resolution
-	namespace, policy, rule, err := api.executor.Index().ResolveSegments(strings.TrimPrefix(path, "/decision/"))
+	namespace, policy, rule, err := exec.Index().ResolveSegments(strings.TrimPrefix(path, "/decision/"))
 	if err != nil {
+		api.countRequestError(RequestErrorValidation)
 		api.writeErrorResponse(w, r, http.StatusNotFound, "Invalid Path", err.Error())
 		return
 	}
@@ -79,34 +103,100 @@ func (api *HTTPAPI) handleDecision(w http.ResponseWriter, r *http.Request) {
 		}
 	}
 
+	// Take an evaluation slot before reading the body, so a saturated server sheds load early
+	release, ok := api.acquireEvaluation(w, r)
+	if !ok {
+		return
+	}
+	defer release()
+
 	// Parse request body
 	var req DecisionRequest
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		api.writeErrorResponse(w, r, http.StatusBadRequest, "Invalid JSON", "The request body could not be parsed as valid JSON")
+	if !api.decodeBody(w, r, &req) {
 		return
 	}
 
+	// the evaluation trace is only included when asked for with ?explain=true,
+	// and the policy metadata with ?include_metadata=true
+	response := api.decide(ctx, exec, namespace, policy, rule, req.Facts, runConfig["explain"] == "true", runConfig["include_metadata"] == "true")
+
+	// Write JSON response
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+
+	if err := json.NewEncoder(w).Encode(response); err != nil {
+		api.logger.ErrorContext(ctx, "Error encoding response", "error", err)
+	}
+}
+
+// decide evaluates a policy, or a single rule of it when rule is set, with facts, and records
+// the evaluation in the metrics and the decision sink. It is the evaluation behind every
+// transport, so that a decision is the same however it was asked for; the request id is the
+// one held by ctx. The trace of each outcome is only included withExplain, and the policy
+// metadata withMetadata.
+func (api *HTTPAPI) decide(ctx context.Context, exec runtime.Executor, namespace, policy, rule string, facts map[string]any, withExplain, withMetadata bool) *DecisionResponse {
+	// an explained decision traces every sub-expression, even of compiled rules
+	if withExplain {
+		ctx = runtime.WithFullTrace(ctx)
+	}
+
 	// Execute policy/rule
 	var outputs []*runtime.ExecutorOutput
 	var runErr error
+	start := time.Now()
 	if len(rule) == 0 {
-		outputs, runErr = api.executor.ExecPolicy(ctx, namespace, policy, req.Facts)
+		outputs, runErr = exec.ExecPolicy(ctx, namespace, policy, facts)
 	} else {
-		output, e := api.executor.ExecRule(ctx, namespace, policy, rule, req.Facts)
+		output, e := exec.ExecRule(ctx, namespace, policy, rule, facts)
 		outputs = []*runtime.ExecutorOutput{output}
 		runErr = e
 	}
+	duration := time.Since(start)
 
-	response := DecisionResponse{
-		Decisions: outputs,
-		Error:     runErr.Error(),
+	// a whole policy declaring a combining algorithm also has a combined decision
+	var combined *runtime.Decision
+	if len(rule) == 0 && runErr == nil {
+		if p, err := exec.Index().ResolvePolicy(namespace, policy); err == nil {
+			combined = runtime.CombineDecisions(p, outputs)
+		}
 	}
 
-	// Write JSON response
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(http.StatusOK)
+	if api.metrics != nil {
+		api.metrics.ObserveEvaluation(namespace+"/"+policy, evaluationOutcome(outputs, combined, runErr), duration)
+	}
 
-	if err := json.NewEncoder(w).Encode(response); err != nil {
-		api.logger.ErrorContext(ctx, "Error encoding response", "error", err)
+	api.recordAudit(ctx, exec, namespace, policy, facts, outputs, runErr)
+
+	result := runtime.NewResult(outputs, exec.Index().PolicyWarnings(namespace, policy), withExplain, withMetadata)
+	result.Decision = combined
+
+	response := &DecisionResponse{
+		Result:     result,
+		Decisions:  legacyDecisions(outputs, withExplain),
+		RequestID:  middleware.GetRequestID(ctx),
+		Policy:     namespace + "/" + policy,
+		DurationMs: float64(duration.Microseconds()) / 1000,
+	}
+	if runErr != nil {
+		response.Error = runErr.Error()
+	}
+	return response
+}
+
+// legacyDecisions returns the outputs served under the deprecated "decisions" key, without their
+// trace unless withExplain.
+func legacyDecisions(outputs []*runtime.ExecutorOutput, withExplain bool) []*runtime.ExecutorOutput {
+	if withExplain {
+		return outputs
+	}
+	decisions := make([]*runtime.ExecutorOutput, len(outputs))
+	for i, output := range outputs {
+		if output != nil {
+			withoutTrace := *output
+			withoutTrace.RuleNode = nil
+			output = &withoutTrace
+		}
+		decisions[i] = output
 	}
+	return decisions
 }
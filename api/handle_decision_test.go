@@ -0,0 +1,152 @@
+// SPDX-FileCopyrightText: © 2026 Binaek Sarkar <binaek89@gmail.com>
+// SPDX-License-Identifier: Apache-2.0
+
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"maps"
+	"net/http"
+	"slices"
+
+	"github.com/sentrie-sh/sentrie/runtime"
+)
+
+func (s *APITestSuite) TestDecisionResponseCarriesResultEnvelope() {
+	result := runtime.NewResult(nil, nil, false, false)
+	response := DecisionResponse{Result: result, Decisions: []*runtime.ExecutorOutput{}, RequestID: "req-1", Policy: "com/example/echo", DurationMs: 1.5}
+	response.Error = errors.New("boom").Error()
+
+	raw, err := json.Marshal(response)
+	s.Require().NoError(err)
+	s.Require().JSONEq(`{
+		"schema_version": 5,
+		"outcomes": [],
+		"decisions": [],
+		"warnings": [],
+		"request_id": "req-1",
+		"policy": "com/example/echo",
+		"duration_ms": 1.5,
+		"error": "boom"
+	}`, string(raw))
+}
+
+func (s *APITestSuite) TestDecisionResponseKeepsDeprecatedDecisions() {
+	api := s.newTestHTTPAPI()
+
+	rec := s.postDecision(api, `{"facts": {}}`)
+	s.Require().Equal(http.StatusOK, rec.Code)
+	var response map[string]any
+	s.Require().NoError(json.Unmarshal(rec.Body.Bytes(), &response))
+	s.Require().NotEmpty(response["outcomes"])
+	s.Require().Len(response["decisions"], len(response["outcomes"].([]any)))
+
+	// the executor output, as served before the envelope
+	decision := response["decisions"].([]any)[0].(map[string]any)
+	s.ElementsMatch([]string{"policy", "namespace", "rule", "decision", "attachments", "trace"}, slices.Collect(maps.Keys(decision)))
+	s.Equal("echo", decision["policy"])
+	s.Equal("com/example", decision["namespace"])
+}
+
+func (s *APITestSuite) TestDecisionResponseServesTraceOnlyWithExplain() {
+	api := s.newTestHTTPAPI()
+
+	servedTraces := func(target string) (outcomes, decisions []any) {
+		rec := s.postDecisionAt(api, "com/example/echo", target, `{"facts": {}}`)
+		s.Require().Equal(http.StatusOK, rec.Code)
+		var response map[string]any
+		s.Require().NoError(json.Unmarshal(rec.Body.Bytes(), &response))
+		for _, outcome := range response["outcomes"].([]any) {
+			outcomes = append(outcomes, outcome.(map[string]any)["explain"])
+		}
+		for _, decision := range response["decisions"].([]any) {
+			decisions = append(decisions, decision.(map[string]any)["trace"])
+		}
+		s.Require().NotEmpty(decisions)
+		return outcomes, decisions
+	}
+
+	outcomes, decisions := servedTraces("/decision/com/example/echo")
+	for i := range decisions {
+		s.Nil(outcomes[i])
+		s.Nil(decisions[i], "no trace is served without explain")
+	}
+
+	outcomes, decisions = servedTraces("/decision/com/example/echo?explain=true")
+	for i := range decisions {
+		s.NotNil(outcomes[i])
+		s.NotNil(decisions[i])
+	}
+}
+
+func (s *APITestSuite) TestDecisionResponseIdentifiesRequestAndPolicy() {
+	api := s.newTestHTTPAPI()
+
+	rec := s.postDecision(api, `{"facts": {}}`)
+	s.Require().Equal(http.StatusOK, rec.Code)
+	var response struct {
+		RequestID  string   `json:"request_id"`
+		Policy     string   `json:"policy"`
+		DurationMs *float64 `json:"duration_ms"`
+	}
+	s.Require().NoError(json.Unmarshal(rec.Body.Bytes(), &response))
+	s.NotEmpty(response.RequestID)
+	s.Equal("com/example/echo", response.Policy)
+	s.Require().NotNil(response.DurationMs)
+	s.GreaterOrEqual(*response.DurationMs, 0.0)
+}
+
+func (s *APITestSuite) TestDecisionSerializesUnknownDistinctly() {
+	api := s.newTestHTTPAPI()
+
+	rec := s.postDecisionAt(api, "com/example/maybe/ok", "/decision/com/example/maybe/ok", `{"facts": {}}`)
+	s.Require().Equal(http.StatusOK, rec.Code)
+	var response struct {
+		Outcomes []struct {
+			Decision    map[string]any `json:"decision"`
+			Attachments map[string]any `json:"attachments"`
+		} `json:"outcomes"`
+	}
+	s.Require().NoError(json.Unmarshal(rec.Body.Bytes(), &response))
+	s.Require().Len(response.Outcomes, 1)
+	s.Equal(map[string]any{"state": "unknown", "value": "unknown"}, response.Outcomes[0].Decision)
+	s.Equal(map[string]any{"why": "unknown"}, response.Outcomes[0].Attachments)
+}
+
+func (s *APITestSuite) TestDecisionCombinesExportedDecisions() {
+	api := s.newTestHTTPAPI()
+
+	decisionOf := func(target string) map[string]any {
+		rec := s.postDecisionAt(api, target, "/decision/"+target, `{"facts": {}}`)
+		s.Require().Equal(http.StatusOK, rec.Code)
+		var response struct {
+			Decision map[string]any `json:"decision"`
+		}
+		s.Require().NoError(json.Unmarshal(rec.Body.Bytes(), &response))
+		return response.Decision
+	}
+
+	// first-applicable stops at the unknown decision of the first export
+	s.Equal(map[string]any{"state": "unknown", "value": "unknown"}, decisionOf("com/example/combined"))
+	// a single rule and a policy without a combining algorithm have no combined decision
+	s.Nil(decisionOf("com/example/combined/undecided"))
+	s.Nil(decisionOf("com/example/maybe"))
+}
+
+func (s *APITestSuite) TestDecisionIncludesMetadataOnRequest() {
+	api := s.newTestHTTPAPI()
+
+	outcome := func(target string) map[string]any {
+		rec := s.postDecisionTo(api, target, `{"facts": {}}`)
+		s.Require().Equal(http.StatusOK, rec.Code)
+		var response map[string]any
+		s.Require().NoError(json.Unmarshal(rec.Body.Bytes(), &response))
+		outcomes := response["outcomes"].([]any)
+		s.Require().Len(outcomes, 1)
+		return outcomes[0].(map[string]any)
+	}
+
+	s.NotContains(outcome("/decision/com/example/echo/ok"), "metadata")
+	s.Equal(map[string]any{"title": "Echo"}, outcome("/decision/com/example/echo/ok?include_metadata=true")["metadata"])
+}
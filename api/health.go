@@ -0,0 +1,66 @@
+// SPDX-FileCopyrightText: © 2026 Binaek Sarkar <binaek89@gmail.com>
+// SPDX-License-Identifier: Apache-2.0
+
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"time"
+)
+
+// errPoliciesNotLoaded is why a server given no executor yet is not ready.
+var errPoliciesNotLoaded = errors.New("the policies are not loaded yet")
+
+// Readiness is the body of GET /readyz.
+type Readiness struct {
+	// Status is "ready" or "not ready"
+	Status string `json:"status"`
+	// CommittedAt is when the index being served was committed; absent before any is served
+	CommittedAt *time.Time `json:"committed_at,omitempty"`
+	// Policies is the number of policies being served
+	Policies int `json:"policies"`
+	// Error is why the server is not ready
+	Error string `json:"error,omitempty"`
+}
+
+// ReloadFailed makes the server not ready, while it keeps answering with the executor it has,
+// until it is given another one with SwapExecutor.
+func (api *HTTPAPI) ReloadFailed(err error) {
+	api.reloadError.Store(&err)
+}
+
+// readiness reports whether the server is ready: once it has been given an executor, as long as
+// the reload after it did not fail.
+func (api *HTTPAPI) readiness() Readiness {
+	exec := api.currentExecutor()
+	if exec == nil {
+		return Readiness{Status: "not ready", Error: errPoliciesNotLoaded.Error()}
+	}
+
+	committedAt := exec.Index().CommittedAt()
+	readiness := Readiness{Status: "ready", CommittedAt: &committedAt, Policies: exec.Index().PolicyCount()}
+	if err := api.reloadError.Load(); err != nil {
+		readiness.Status = "not ready"
+		readiness.Error = (*err).Error()
+	}
+	return readiness
+}
+
+// handleReadiness handles GET /readyz requests, answering 503 Service Unavailable while the
+// server is not ready, see readiness.
+func (api *HTTPAPI) handleReadiness(w http.ResponseWriter, r *http.Request) {
+	readiness := api.readiness()
+
+	w.Header().Set("Content-Type", "application/json")
+	if readiness.Status == "ready" {
+		w.WriteHeader(http.StatusOK)
+	} else {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}
+
+	if err := json.NewEncoder(w).Encode(readiness); err != nil {
+		api.logger.DebugContext(r.Context(), "Error encoding readiness response", "error", err)
+	}
+}
@@ -0,0 +1,78 @@
+// SPDX-FileCopyrightText: © 2026 Binaek Sarkar <binaek89@gmail.com>
+// SPDX-License-Identifier: Apache-2.0
+
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"time"
+)
+
+// getProbe requests the liveness or readiness endpoint of api at path.
+func (s *APITestSuite) getProbe(api *HTTPAPI, path string) *httptest.ResponseRecorder {
+	handler := api.handleHealth
+	if path == "/readyz" {
+		handler = api.handleReadiness
+	}
+	rec := httptest.NewRecorder()
+	handler(rec, httptest.NewRequest(http.MethodGet, path, nil))
+	return rec
+}
+
+func (s *APITestSuite) TestNotReadyUntilGivenAnExecutor() {
+	api := NewHTTPAPI(nil)
+
+	s.Equal(http.StatusOK, s.getProbe(api, "/healthz").Code)
+	rec := s.getProbe(api, "/readyz")
+	s.Equal(http.StatusServiceUnavailable, rec.Code)
+	var readiness Readiness
+	s.Require().NoError(json.Unmarshal(rec.Body.Bytes(), &readiness))
+	s.Equal("not ready", readiness.Status)
+	s.Nil(readiness.CommittedAt)
+	s.Zero(readiness.Policies)
+
+	// evaluations are refused rather than failing
+	rec = s.postDecision(api, `{"facts": {}}`)
+	s.Equal(http.StatusServiceUnavailable, rec.Code)
+	s.Equal("1", rec.Header().Get("Retry-After"))
+	s.Contains(rec.Body.String(), "not loaded yet")
+	s.Equal(http.StatusServiceUnavailable, s.postBatch(api, "com/example/echo", `{"inputs": [{}]}`).Code)
+
+	s.newTestHTTPAPI()
+	api.SwapExecutor(s.exec)
+	rec = s.getProbe(api, "/readyz")
+	s.Equal(http.StatusOK, rec.Code)
+	s.Require().NoError(json.Unmarshal(rec.Body.Bytes(), &readiness))
+	s.Equal("ready", readiness.Status)
+	s.Require().NotNil(readiness.CommittedAt)
+	s.WithinDuration(time.Now(), *readiness.CommittedAt, time.Hour)
+	s.Equal(5, readiness.Policies)
+	s.Equal(http.StatusOK, s.postDecision(api, `{"facts": {}}`).Code)
+}
+
+func (s *APITestSuite) TestFailedReloadIsNotReadyButStaysAlive() {
+	api := s.newTestHTTPAPI()
+	s.Equal(http.StatusOK, s.getProbe(api, "/readyz").Code)
+
+	api.ReloadFailed(errors.New("policy reload failed: parse error"))
+
+	rec := s.getProbe(api, "/readyz")
+	s.Equal(http.StatusServiceUnavailable, rec.Code)
+	var readiness Readiness
+	s.Require().NoError(json.Unmarshal(rec.Body.Bytes(), &readiness))
+	s.Equal("not ready", readiness.Status)
+	s.Equal("policy reload failed: parse error", readiness.Error)
+	// the policies still being served are reported
+	s.NotNil(readiness.CommittedAt)
+	s.Equal(5, readiness.Policies)
+
+	s.Equal(http.StatusOK, s.getProbe(api, "/healthz").Code)
+	s.Equal(http.StatusOK, s.postDecision(api, `{"facts": {}}`).Code)
+
+	// the next successful reload makes it ready again
+	api.SwapExecutor(s.exec)
+	s.Equal(http.StatusOK, s.getProbe(api, "/readyz").Code)
+}
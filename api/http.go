@@ -19,15 +19,20 @@ package api
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"log/slog"
 	"net"
 	"net/http"
+	"strconv"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/sentrie-sh/sentrie/api/middleware"
+	sentriev1 "github.com/sentrie-sh/sentrie/proto/sentrie/v1"
 	"github.com/sentrie-sh/sentrie/runtime"
+	"google.golang.org/grpc"
 )
 
 type ListenerServerPair struct {
@@ -51,21 +56,146 @@ func (p *ListenerServerPair) Close() error {
 	return nil
 }
 
+// DefaultMaxRequestBody is the largest request body, in bytes, the evaluation endpoints accept
+// unless WithMaxRequestBody says otherwise.
+const DefaultMaxRequestBody = 1 << 20 // 1 MiB
+
+// DefaultMaxConcurrentEvaluations is the number of evaluations the server runs at once
+// unless WithMaxConcurrentEvaluations says otherwise.
+const DefaultMaxConcurrentEvaluations = 256
+
+// retryAfterSeconds is the Retry-After sent with a 503 when every evaluation slot is taken
+const retryAfterSeconds = 1
+
 // HTTPAPI provides HTTP endpoints for rule execution
 type HTTPAPI struct {
-	executor  runtime.Executor
-	listeners []*ListenerServerPair
-	logger    *slog.Logger
+	// executor answers the requests; SwapExecutor replaces it while the server runs. It is nil
+	// until the policies are loaded.
+	executor atomic.Pointer[runtime.Executor]
+	// reloadError, when set, is why the last reload failed, see ReloadFailed
+	reloadError atomic.Pointer[error]
+	listeners   []*ListenerServerPair
+	logger      *slog.Logger
+	// maxRequestBody caps the bytes read from an evaluation request body
+	maxRequestBody int64
+	// evaluations holds one token per in-flight evaluation; its capacity is the limit
+	evaluations chan struct{}
+	// batchConcurrency is the number of inputs of a batch evaluated at once
+	batchConcurrency int
+	// decisionSink, when set, records every evaluation
+	decisionSink DecisionSink
+	// metrics, when set, counts every evaluation and is served on metricsPort
+	metrics     *Metrics
+	metricsPort int
+	// version is the version of the API in its OpenAPI document
+	version string
+	// grpcServer, when set up with SetupGRPC, serves the evaluation service on grpcListeners
+	grpcServer    *grpc.Server
+	grpcListeners []net.Listener
+}
+
+// HTTPAPIOption configures an HTTPAPI at construction time
+type HTTPAPIOption func(*HTTPAPI)
+
+// WithMaxRequestBody sets the largest request body, in bytes, the evaluation endpoints accept.
+// Larger bodies are answered with 413 Payload Too Large.
+func WithMaxRequestBody(limit int64) HTTPAPIOption {
+	return func(api *HTTPAPI) {
+		api.maxRequestBody = limit
+	}
+}
+
+// WithMaxConcurrentEvaluations sets how many evaluations may be in flight at once.
+// Requests arriving while all are taken are answered with 503 Service Unavailable.
+func WithMaxConcurrentEvaluations(limit int) HTTPAPIOption {
+	return func(api *HTTPAPI) {
+		api.evaluations = make(chan struct{}, limit)
+	}
 }
 
-// NewHTTPAPI creates a new HTTP API instance
-func NewHTTPAPI(executor runtime.Executor) *HTTPAPI {
-	return &HTTPAPI{
-		executor: executor,
-		logger:   slog.Default(),
+// WithBatchConcurrency sets how many inputs of a batch are evaluated at once. A batch takes a
+// single evaluation slot however many inputs it evaluates at once.
+func WithBatchConcurrency(limit int) HTTPAPIOption {
+	return func(api *HTTPAPI) {
+		api.batchConcurrency = limit
 	}
 }
 
+// WithVersion sets the version of the API its OpenAPI document declares, see OpenAPIDocument.
+func WithVersion(version string) HTTPAPIOption {
+	return func(api *HTTPAPI) {
+		api.version = version
+	}
+}
+
+// WithAuditLog records every evaluation answered by the decision endpoint in log.
+// The caller owns log and closes it once the server has stopped. A nil log records nothing.
+func WithAuditLog(log *AuditLog) HTTPAPIOption {
+	if log == nil {
+		// a nil *AuditLog would make a sink that is not nil
+		return func(*HTTPAPI) {}
+	}
+	return WithDecisionSink(log)
+}
+
+// WithDecisionSink hands the record of every evaluation answered by the decision endpoint to sink.
+// Without a sink, evaluations are not recorded at all.
+func WithDecisionSink(sink DecisionSink) HTTPAPIOption {
+	return func(api *HTTPAPI) {
+		api.decisionSink = sink
+	}
+}
+
+// WithMetrics counts the evaluations answered by the decision endpoint in metrics, and serves
+// them at GET /metrics on port, on the same addresses as the decision endpoint.
+func WithMetrics(metrics *Metrics, port int) HTTPAPIOption {
+	return func(api *HTTPAPI) {
+		api.metrics = metrics
+		api.metricsPort = port
+	}
+}
+
+// NewHTTPAPI creates a new HTTP API instance. A nil executor leaves the server not ready, and
+// answering evaluations with 503 Service Unavailable, until it is given one with SwapExecutor.
+func NewHTTPAPI(executor runtime.Executor, opts ...HTTPAPIOption) *HTTPAPI {
+	api := &HTTPAPI{
+		logger:           slog.Default(),
+		maxRequestBody:   DefaultMaxRequestBody,
+		evaluations:      make(chan struct{}, DefaultMaxConcurrentEvaluations),
+		batchConcurrency: DefaultBatchConcurrency,
+	}
+	api.executor.Store(&executor)
+	for _, opt := range opts {
+		opt(api)
+	}
+	return api
+}
+
+// SwapExecutor makes exec answer the requests arriving from now on, as when the policies are
+// loaded or reloaded, and makes the server ready. Requests already being evaluated finish with
+// the executor they started with.
+func (api *HTTPAPI) SwapExecutor(exec runtime.Executor) {
+	api.executor.Store(&exec)
+	api.reloadError.Store(nil)
+}
+
+// currentExecutor returns the executor answering requests now, nil until there is one.
+func (api *HTTPAPI) currentExecutor() runtime.Executor {
+	return *api.executor.Load()
+}
+
+// servingExecutor returns the executor answering requests now. While there is none it writes
+// a 503 and returns false.
+func (api *HTTPAPI) servingExecutor(w http.ResponseWriter, r *http.Request) (runtime.Executor, bool) {
+	exec := api.currentExecutor()
+	if exec == nil {
+		w.Header().Set("Retry-After", strconv.Itoa(retryAfterSeconds))
+		api.writeErrorResponse(w, r, http.StatusServiceUnavailable, "Service Unavailable", "The policies are not loaded yet, retry later")
+		return nil, false
+	}
+	return exec, true
+}
+
 func (api *HTTPAPI) Setup(ctx context.Context, port int, listen []string) error {
 	mux := http.NewServeMux()
 
@@ -76,16 +206,48 @@ func (api *HTTPAPI) Setup(ctx context.Context, port int, listen []string) error
 		),
 	)
 
+	// Register the batch endpoint, evaluating many fact sets against one policy
+	mux.Handle("POST /batch/{target...}",
+		middleware.RequestIDMiddleware(
+			http.HandlerFunc(api.handleBatch),
+		),
+	)
+
 	// Health check endpoint
 	mux.Handle("GET /health", http.HandlerFunc(api.handleHealth))
 
+	// Liveness and readiness endpoints, for orchestrators; the server is alive as long as it
+	// answers, whether or not it is ready
+	mux.Handle("GET /healthz", http.HandlerFunc(api.handleHealth))
+	mux.Handle("GET /readyz", http.HandlerFunc(api.handleReadiness))
+
+	// OpenAPI document of the policies being served
+	mux.Handle("GET /openapi.json", http.HandlerFunc(api.handleOpenAPI))
+
+	api.listeners = nil
+	if err := api.listen(ctx, mux, port, listen); err != nil {
+		return err
+	}
+
+	// metrics are served apart from the decisions, on their own port
+	if api.metrics != nil {
+		metricsMux := http.NewServeMux()
+		metricsMux.Handle("GET /metrics", api.metrics)
+		if err := api.listen(ctx, metricsMux, api.metricsPort, listen); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// listen opens a listener serving handler on port at every address of listen. When one cannot
+// be opened, every listener opened so far is closed.
+func (api *HTTPAPI) listen(ctx context.Context, handler http.Handler, port int, listen []string) error {
 	bindings, err := resolveBindings(port, listen)
 	if err != nil {
 		return err
 	}
 
-	// Start listeners on all addresses
-	api.listeners = make([]*ListenerServerPair, 0, len(bindings))
 	for _, binding := range bindings {
 		ln, err := net.Listen("tcp", binding)
 		if err != nil {
@@ -97,7 +259,7 @@ func (api *HTTPAPI) Setup(ctx context.Context, port int, listen []string) error
 			return fmt.Errorf("failed to listen on %s: %w", binding, err)
 		}
 		api.listeners = append(api.listeners, NewListenerServerPair(ln, &http.Server{
-			Handler:      mux,
+			Handler:      handler,
 			ReadTimeout:  30 * time.Second,
 			WriteTimeout: 30 * time.Second,
 			BaseContext: func(l net.Listener) context.Context {
@@ -135,6 +297,19 @@ func (api *HTTPAPI) StartServer(ctx context.Context, port int, listen []string)
 		})
 	}
 
+	for _, ln := range api.grpcListeners {
+		server := api.grpcServer
+		wg.Go(func() {
+			api.logger.DebugContext(ctx,
+				"gRPC evaluation service available",
+				slog.String("address", ln.Addr().String()),
+				slog.String("service", sentriev1.EvaluationService_ServiceDesc.ServiceName))
+			if err := server.Serve(ln); err != nil && err != grpc.ErrServerStopped {
+				errChan <- err
+			}
+		})
+	}
+
 	defer func() {
 		wg.Wait()
 		close(errChan)
@@ -142,8 +317,10 @@ func (api *HTTPAPI) StartServer(ctx context.Context, port int, listen []string)
 
 }
 
-// StopServer gracefully stops the HTTP server
+// StopServer gracefully stops the HTTP server, and the gRPC server once its calls in flight
+// have finished
 func (api *HTTPAPI) StopServer(ctx context.Context) error {
+	api.stopGRPC()
 	if api.listeners != nil {
 		for _, ln := range api.listeners {
 			_ = ln.Close()
@@ -154,7 +331,7 @@ func (api *HTTPAPI) StopServer(ctx context.Context) error {
 	return nil
 }
 
-// handleHealth handles GET /health requests
+// handleHealth handles GET /health and GET /healthz requests
 func (api *HTTPAPI) handleHealth(w http.ResponseWriter, r *http.Request) {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(http.StatusOK)
@@ -169,6 +346,49 @@ func (api *HTTPAPI) handleHealth(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
+// decodeBody decodes the JSON request body into v, reading at most maxRequestBody bytes.
+// On failure it writes the error response, 413 for an oversized body and 400 otherwise,
+// and returns false.
+func (api *HTTPAPI) decodeBody(w http.ResponseWriter, r *http.Request, v any) bool {
+	r.Body = http.MaxBytesReader(w, r.Body, api.maxRequestBody)
+	err := json.NewDecoder(r.Body).Decode(v)
+	if err == nil {
+		return true
+	}
+
+	api.countRequestError(RequestErrorParse)
+
+	var tooLarge *http.MaxBytesError
+	if errors.As(err, &tooLarge) {
+		api.writeErrorResponse(w, r, http.StatusRequestEntityTooLarge, "Payload Too Large", fmt.Sprintf("The request body exceeds the limit of %d bytes", tooLarge.Limit))
+		return false
+	}
+	api.writeErrorResponse(w, r, http.StatusBadRequest, "Invalid JSON", "The request body could not be parsed as valid JSON")
+	return false
+}
+
+// acquireEvaluation takes an evaluation slot, returning the func that gives it back.
+// When every slot is taken it writes a 503 with Retry-After and returns false.
+func (api *HTTPAPI) acquireEvaluation(w http.ResponseWriter, r *http.Request) (func(), bool) {
+	release, ok := api.tryAcquireEvaluation()
+	if !ok {
+		w.Header().Set("Retry-After", strconv.Itoa(retryAfterSeconds))
+		api.writeErrorResponse(w, r, http.StatusServiceUnavailable, "Service Unavailable", fmt.Sprintf("The server is already running %d evaluations, retry later", cap(api.evaluations)))
+	}
+	return release, ok
+}
+
+// tryAcquireEvaluation takes an evaluation slot, returning the func that gives it back, or false
+// when every slot is taken. The slots are shared by every transport.
+func (api *HTTPAPI) tryAcquireEvaluation() (func(), bool) {
+	select {
+	case api.evaluations <- struct{}{}:
+		return func() { <-api.evaluations }, true
+	default:
+		return nil, false
+	}
+}
+
 // writeErrorResponse writes a Problem Details error response in JSON format
 func (api *HTTPAPI) writeErrorResponse(w http.ResponseWriter, r *http.Request, statusCode int, title, detail string) {
 	w.Header().Set("Content-Type", "application/problem+json")
@@ -189,3 +409,10 @@ func (api *HTTPAPI) writeErrorResponse(w http.ResponseWriter, r *http.Request, s
 		api.logger.DebugContext(r.Context(), "Error encoding problem details response", "error", err)
 	}
 }
+
+// countRequestError counts a request rejected before evaluation, when metrics are kept.
+func (api *HTTPAPI) countRequestError(kind string) {
+	if api.metrics != nil {
+		api.metrics.CountRequestError(kind)
+	}
+}
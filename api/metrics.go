@@ -0,0 +1,182 @@
+// SPDX-FileCopyrightText: © 2026 Binaek Sarkar <binaek89@gmail.com>
+// SPDX-License-Identifier: Apache-2.0
+
+package api
+
+import (
+	"fmt"
+	"io"
+	"maps"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/sentrie-sh/sentrie/runtime"
+	"github.com/sentrie-sh/sentrie/trinary"
+)
+
+// The outcomes an evaluation is counted under.
+const (
+	OutcomePermit  = "permit"
+	OutcomeDeny    = "deny"
+	OutcomeUnknown = "unknown"
+	OutcomeError   = "error"
+)
+
+// The kinds of request errors counted.
+const (
+	// RequestErrorParse counts request bodies that are not valid JSON or exceed the size limit
+	RequestErrorParse = "parse"
+	// RequestErrorValidation counts requests for a policy or rule that does not resolve
+	RequestErrorValidation = "validation"
+)
+
+// metricsContentType is the content type of the Prometheus text exposition format.
+const metricsContentType = "text/plain; version=0.0.4; charset=utf-8"
+
+// latencyBuckets are the upper bounds, in seconds, of the evaluation latency histogram.
+var latencyBuckets = []float64{0.0005, 0.001, 0.0025, 0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5}
+
+type evaluationKey struct {
+	policy  string
+	outcome string
+}
+
+type histogram struct {
+	// counts holds, for every bucket, the observations at or below its bound
+	counts []uint64
+	count  uint64
+	sum    float64
+}
+
+// Metrics counts the evaluations answered by the decision endpoint and serves them in the
+// Prometheus text format. Series are written sorted by their labels.
+type Metrics struct {
+	lock          sync.Mutex
+	evaluations   map[evaluationKey]uint64
+	latencies     map[string]*histogram
+	requestErrors map[string]uint64
+}
+
+// NewMetrics creates metrics with no observations.
+func NewMetrics() *Metrics {
+	return &Metrics{
+		evaluations:   map[evaluationKey]uint64{},
+		latencies:     map[string]*histogram{},
+		requestErrors: map[string]uint64{RequestErrorParse: 0, RequestErrorValidation: 0},
+	}
+}
+
+// ObserveEvaluation counts an evaluation of the policy with the given FQN and records how long it took.
+func (m *Metrics) ObserveEvaluation(policy, outcome string, took time.Duration) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	m.evaluations[evaluationKey{policy: policy, outcome: outcome}]++
+
+	h, ok := m.latencies[policy]
+	if !ok {
+		h = &histogram{counts: make([]uint64, len(latencyBuckets))}
+		m.latencies[policy] = h
+	}
+	seconds := took.Seconds()
+	for i, bound := range latencyBuckets {
+		if seconds <= bound {
+			h.counts[i]++
+		}
+	}
+	h.count++
+	h.sum += seconds
+}
+
+// CountRequestError counts a request rejected before evaluation, see RequestErrorParse and
+// RequestErrorValidation.
+func (m *Metrics) CountRequestError(kind string) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	m.requestErrors[kind]++
+}
+
+// WriteTo writes the metrics in the Prometheus text format.
+func (m *Metrics) WriteTo(w io.Writer) (int64, error) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	var b strings.Builder
+
+	b.WriteString("# HELP sentrie_evaluations_total Evaluations answered by the decision endpoint, by policy and outcome.\n")
+	b.WriteString("# TYPE sentrie_evaluations_total counter\n")
+	keys := slices.SortedFunc(maps.Keys(m.evaluations), func(a, b evaluationKey) int {
+		if c := strings.Compare(a.policy, b.policy); c != 0 {
+			return c
+		}
+		return strings.Compare(a.outcome, b.outcome)
+	})
+	for _, key := range keys {
+		fmt.Fprintf(&b, "sentrie_evaluations_total{policy=%s,outcome=%s} %d\n", labelValue(key.policy), labelValue(key.outcome), m.evaluations[key])
+	}
+
+	b.WriteString("# HELP sentrie_evaluation_duration_seconds Time taken to evaluate a policy.\n")
+	b.WriteString("# TYPE sentrie_evaluation_duration_seconds histogram\n")
+	for _, policy := range slices.Sorted(maps.Keys(m.latencies)) {
+		h := m.latencies[policy]
+		for i, bound := range latencyBuckets {
+			fmt.Fprintf(&b, "sentrie_evaluation_duration_seconds_bucket{policy=%s,le=%q} %d\n", labelValue(policy), strconv.FormatFloat(bound, 'g', -1, 64), h.counts[i])
+		}
+		fmt.Fprintf(&b, "sentrie_evaluation_duration_seconds_bucket{policy=%s,le=\"+Inf\"} %d\n", labelValue(policy), h.count)
+		fmt.Fprintf(&b, "sentrie_evaluation_duration_seconds_sum{policy=%s} %s\n", labelValue(policy), strconv.FormatFloat(h.sum, 'g', -1, 64))
+		fmt.Fprintf(&b, "sentrie_evaluation_duration_seconds_count{policy=%s} %d\n", labelValue(policy), h.count)
+	}
+
+	b.WriteString("# HELP sentrie_request_errors_total Requests rejected before evaluation, by kind.\n")
+	b.WriteString("# TYPE sentrie_request_errors_total counter\n")
+	for _, kind := range slices.Sorted(maps.Keys(m.requestErrors)) {
+		fmt.Fprintf(&b, "sentrie_request_errors_total{kind=%s} %d\n", labelValue(kind), m.requestErrors[kind])
+	}
+
+	n, err := io.WriteString(w, b.String())
+	return int64(n), err
+}
+
+// ServeHTTP serves the metrics for a scrape.
+func (m *Metrics) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", metricsContentType)
+	w.WriteHeader(http.StatusOK)
+	_, _ = m.WriteTo(w)
+}
+
+// labelValue quotes a label value, escaping it as the text format requires.
+func labelValue(s string) string {
+	return `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`).Replace(s) + `"`
+}
+
+// evaluationOutcome is the outcome an evaluation is counted under: an error, the combined
+// decision when there is one, or else the decision of all its outputs together, permit only
+// when every decision permits.
+func evaluationOutcome(outputs []*runtime.ExecutorOutput, combined *runtime.Decision, err error) string {
+	if err != nil {
+		return OutcomeError
+	}
+	decision := trinary.True
+	if combined != nil {
+		decision = combined.State
+	} else {
+		for _, output := range outputs {
+			if output == nil || output.Decision == nil {
+				continue
+			}
+			decision = decision.And(output.Permits())
+		}
+	}
+	switch decision {
+	case trinary.True:
+		return OutcomePermit
+	case trinary.False:
+		return OutcomeDeny
+	default:
+		return OutcomeUnknown
+	}
+}
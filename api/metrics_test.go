@@ -0,0 +1,105 @@
+// SPDX-FileCopyrightText: © 2026 Binaek Sarkar <binaek89@gmail.com>
+// SPDX-License-Identifier: Apache-2.0
+
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"time"
+
+	"github.com/sentrie-sh/sentrie/index"
+	"github.com/sentrie-sh/sentrie/runtime"
+	"github.com/sentrie-sh/sentrie/trinary"
+)
+
+func (s *APITestSuite) TestMetricsTextFormat() {
+	m := NewMetrics()
+	m.ObserveEvaluation("com/example/b", OutcomeDeny, 3*time.Millisecond)
+	m.ObserveEvaluation("com/example/a", OutcomePermit, 200*time.Microsecond)
+	m.ObserveEvaluation("com/example/a", OutcomePermit, 2*time.Second)
+	m.CountRequestError(RequestErrorParse)
+
+	var out strings.Builder
+	_, err := m.WriteTo(&out)
+	s.Require().NoError(err)
+	text := out.String()
+
+	s.Contains(text, "# TYPE sentrie_evaluations_total counter\n"+
+		"sentrie_evaluations_total{policy=\"com/example/a\",outcome=\"permit\"} 2\n"+
+		"sentrie_evaluations_total{policy=\"com/example/b\",outcome=\"deny\"} 1\n")
+	s.Contains(text, "# TYPE sentrie_evaluation_duration_seconds histogram\n")
+	s.Contains(text, "sentrie_evaluation_duration_seconds_bucket{policy=\"com/example/a\",le=\"0.0005\"} 1\n")
+	s.Contains(text, "sentrie_evaluation_duration_seconds_bucket{policy=\"com/example/a\",le=\"2.5\"} 2\n")
+	s.Contains(text, "sentrie_evaluation_duration_seconds_bucket{policy=\"com/example/a\",le=\"+Inf\"} 2\n")
+	s.Contains(text, "sentrie_evaluation_duration_seconds_count{policy=\"com/example/a\"} 2\n")
+	s.Contains(text, "sentrie_evaluation_duration_seconds_bucket{policy=\"com/example/b\",le=\"0.0025\"} 0\n")
+	s.Contains(text, "sentrie_evaluation_duration_seconds_bucket{policy=\"com/example/b\",le=\"0.005\"} 1\n")
+	s.Contains(text, "sentrie_request_errors_total{kind=\"parse\"} 1\n")
+	s.Contains(text, "sentrie_request_errors_total{kind=\"validation\"} 0\n")
+	s.Less(strings.Index(text, `policy="com/example/a"`), strings.Index(text, `policy="com/example/b"`))
+}
+
+func (s *APITestSuite) TestMetricsEscapeLabelValues() {
+	s.Equal(`"a\\b\"c\nd"`, labelValue("a\\b\"c\nd"))
+}
+
+func (s *APITestSuite) TestEvaluationOutcome() {
+	output := func(state trinary.Value) *runtime.ExecutorOutput {
+		return &runtime.ExecutorOutput{Decision: &runtime.Decision{State: state}}
+	}
+	s.Equal(OutcomePermit, evaluationOutcome([]*runtime.ExecutorOutput{output(trinary.True), output(trinary.True)}, nil, nil))
+	s.Equal(OutcomeDeny, evaluationOutcome([]*runtime.ExecutorOutput{output(trinary.Unknown), output(trinary.False)}, nil, nil))
+	s.Equal(OutcomeUnknown, evaluationOutcome([]*runtime.ExecutorOutput{output(trinary.True), output(trinary.Unknown)}, nil, nil))
+	s.Equal(OutcomeError, evaluationOutcome(nil, nil, errors.New("boom")))
+	// a deny rule deciding true denies
+	deny := output(trinary.True)
+	deny.Effect = index.RuleEffectDeny
+	s.Equal(OutcomeDeny, evaluationOutcome([]*runtime.ExecutorOutput{output(trinary.True), deny}, nil, nil))
+	// a combined decision decides alone
+	s.Equal(OutcomePermit, evaluationOutcome([]*runtime.ExecutorOutput{output(trinary.False)}, &runtime.Decision{State: trinary.True}, nil))
+}
+
+func (s *APITestSuite) TestDecisionEndpointCountsEvaluations() {
+	metrics := NewMetrics()
+	api := s.newTestHTTPAPI(WithMetrics(metrics, 0))
+
+	s.Require().Equal(http.StatusOK, s.postDecision(api, `{"facts": {}}`).Code)
+	s.Require().Equal(http.StatusOK, s.postDecisionAt(api, "com/example/maybe/ok", "/decision/com/example/maybe/ok", `{"facts": {}}`).Code)
+	s.Require().Equal(http.StatusBadRequest, s.postDecision(api, `{"facts":`).Code)
+	s.Require().Equal(http.StatusNotFound, s.postDecisionAt(api, "com/example/missing", "/decision/com/example/missing", `{"facts": {}}`).Code)
+
+	rec := httptest.NewRecorder()
+	metrics.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	s.Equal(metricsContentType, rec.Header().Get("Content-Type"))
+	text := rec.Body.String()
+	s.Contains(text, "sentrie_evaluations_total{policy=\"com/example/echo\",outcome=\"permit\"} 1\n")
+	s.Contains(text, "sentrie_evaluations_total{policy=\"com/example/maybe\",outcome=\"unknown\"} 1\n")
+	s.Contains(text, "sentrie_evaluation_duration_seconds_count{policy=\"com/example/echo\"} 1\n")
+	s.Contains(text, "sentrie_request_errors_total{kind=\"parse\"} 1\n")
+	s.Contains(text, "sentrie_request_errors_total{kind=\"validation\"} 1\n")
+}
+
+func (s *APITestSuite) TestMetricsServedOnTheirOwnPort() {
+	api := s.newTestHTTPAPI(WithMetrics(NewMetrics(), 0))
+	s.Require().NoError(api.Setup(context.Background(), 0, []string{"local4"}))
+	defer func() { s.Require().NoError(api.StopServer(context.Background())) }()
+	s.Require().Len(api.listeners, 2)
+	go api.StartServer(context.Background(), 0, []string{"local4"})
+
+	get := func(ln *ListenerServerPair, path string) int {
+		res, err := http.Get(fmt.Sprintf("http://%s%s", ln.Listener.Addr(), path))
+		s.Require().NoError(err)
+		defer res.Body.Close()
+		_, _ = io.Copy(io.Discard, res.Body)
+		return res.StatusCode
+	}
+	s.Equal(http.StatusNotFound, get(api.listeners[0], "/metrics"))
+	s.Equal(http.StatusOK, get(api.listeners[1], "/metrics"))
+	s.Equal(http.StatusNotFound, get(api.listeners[1], "/health"))
+}
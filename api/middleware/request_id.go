@@ -28,7 +28,21 @@ type requestIdCtxKeyType struct{}
 var requestIdCtxKey = requestIdCtxKeyType{}
 
 func GetRequestIDFromRequest(req *http.Request) string {
-	return req.Context().Value(requestIdCtxKey).(string)
+	return GetRequestID(req.Context())
+}
+
+// GetRequestID returns the request id held by ctx, see WithRequestID.
+func GetRequestID(ctx context.Context) string {
+	return ctx.Value(requestIdCtxKey).(string)
+}
+
+// WithRequestID returns ctx holding a new request id, unless it already holds one. It is what
+// RequestIDMiddleware does for transports other than HTTP.
+func WithRequestID(ctx context.Context) context.Context {
+	if ctx.Value(requestIdCtxKey) != nil {
+		return ctx
+	}
+	return context.WithValue(ctx, requestIdCtxKey, uuid.New().String())
 }
 
 func HasRequestIDInRequest(req *http.Request) bool {
@@ -46,5 +60,5 @@ func ensureRequestIDInRequest(r *http.Request) *http.Request {
 	if HasRequestIDInRequest(r) {
 		return r
 	}
-	return r.WithContext(context.WithValue(r.Context(), requestIdCtxKey, uuid.New().String()))
+	return r.WithContext(WithRequestID(r.Context()))
 }
@@ -0,0 +1,367 @@
+// SPDX-FileCopyrightText: © 2026 Binaek Sarkar <binaek89@gmail.com>
+// SPDX-License-Identifier: Apache-2.0
+
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"maps"
+	"net/http"
+	"reflect"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/sentrie-sh/sentrie/box"
+	"github.com/sentrie-sh/sentrie/index"
+	"github.com/sentrie-sh/sentrie/runtime"
+	"github.com/sentrie-sh/sentrie/trinary"
+)
+
+// OpenAPIVersion is the version of the OpenAPI Specification the documents of OpenAPIDocument
+// follow. Its schemas are those of index.JSONSchemaDialect.
+const OpenAPIVersion = "3.1.0"
+
+// componentSchemas prefixes the references to the schemas of an OpenAPI document.
+const componentSchemas = "#/components/schemas/"
+
+// knownSchemas describes the types the schemas of their Go fields would not: those encoding
+// themselves as JSON, and the legacy outputs served under a deprecated key.
+var knownSchemas = map[reflect.Type]map[string]any{
+	reflect.TypeFor[trinary.Value](): {"type": "string", "enum": []any{"true", "false", "unknown"}},
+	// a boxed value is any JSON value
+	reflect.TypeFor[box.Value](): {},
+	reflect.TypeFor[time.Time](): {"type": "string", "format": "date-time"},
+	// problem details carry extension members besides their standard ones
+	reflect.TypeFor[ProblemDetails](): {
+		"type": "object",
+		"properties": map[string]any{
+			"type":     map[string]any{"type": "string", "format": "uri"},
+			"title":    map[string]any{"type": "string"},
+			"status":   map[string]any{"type": "integer"},
+			"detail":   map[string]any{"type": "string"},
+			"instance": map[string]any{"type": "string"},
+		},
+		"required": []string{"title"},
+	},
+	// the executor outputs under the deprecated "decisions" are served as they were before the
+	// envelope, null for a rule that failed and with null attachments or trace when unset
+	reflect.TypeFor[[]*runtime.ExecutorOutput](): {
+		"type":       "array",
+		"deprecated": true,
+		"items":      map[string]any{"type": []any{"object", "null"}},
+	},
+}
+
+var jsonMarshaler = reflect.TypeFor[json.Marshaler]()
+
+// OpenAPIDocument returns an OpenAPI document describing the HTTP API serving the policies of
+// view, as version of the API. Every policy, and every rule it exports, has a decision and a batch
+// operation, whose requests describe the facts of the policy, see index.IndexView.FactsJSONSchema.
+// The schemas of the responses are derived from the types the handlers encode, named after them.
+func OpenAPIDocument(view *index.IndexView, version string) (map[string]any, error) {
+	b := &openAPIBuilder{schemas: map[string]any{}, names: map[string]reflect.Type{}}
+	decision := b.schemaOf(reflect.TypeFor[DecisionResponse]())
+	batch := b.schemaOf(reflect.TypeFor[BatchResponse]())
+	problem := b.schemaOf(reflect.TypeFor[ProblemDetails]())
+
+	paths := map[string]any{}
+	for _, p := range view.Policies() {
+		fqn := p.FQN.String()
+		facts, shapes, err := view.FactsJSONSchema(p.Namespace.FQN.String(), p.Name, func(shape string) string {
+			return componentSchemas + componentName("shape", shape)
+		})
+		if err != nil {
+			return nil, fmt.Errorf("cannot describe the facts of '%s': %w", fqn, err)
+		}
+		for shape, schema := range shapes {
+			b.schemas[componentName("shape", shape)] = schema
+		}
+		b.schemas[componentName("facts", fqn)] = facts
+		factsRef := map[string]any{"$ref": componentSchemas + componentName("facts", fqn)}
+
+		targets := []string{fqn}
+		for _, rule := range slices.Sorted(maps.Keys(p.RuleExports)) {
+			targets = append(targets, fqn+"/"+rule)
+		}
+		for _, target := range targets {
+			paths["/decision/"+target] = map[string]any{"post": decisionOperation(p, target, factsRef, decision)}
+			paths["/batch/"+target] = map[string]any{"post": batchOperation(p, target, factsRef, batch)}
+		}
+	}
+
+	readiness := b.schemaOf(reflect.TypeFor[Readiness]())
+	paths["/healthz"] = map[string]any{"get": map[string]any{
+		"operationId": "liveness",
+		"summary":     "Whether the server is alive, ready or not",
+		"responses":   map[string]any{"200": jsonResponse("The server is alive", statusSchema("healthy"))},
+	}}
+	paths["/readyz"] = map[string]any{"get": map[string]any{
+		"operationId": "readiness",
+		"summary":     "Whether the server is ready to evaluate",
+		"responses": map[string]any{
+			"200": jsonResponse("The server is ready", readiness),
+			"503": jsonResponse("The policies are not loaded, or the last reload failed", readiness),
+		},
+	}}
+	paths["/openapi.json"] = map[string]any{"get": map[string]any{
+		"operationId": "openapi",
+		"summary":     "This document",
+		"responses": map[string]any{
+			"200": jsonResponse("The OpenAPI document of the policies being served", map[string]any{"type": "object"}),
+			"503": problemResponse("The policies are not loaded yet"),
+		},
+	}}
+
+	return map[string]any{
+		"openapi": OpenAPIVersion,
+		"info": map[string]any{
+			"title":   "Sentrie",
+			"version": version,
+		},
+		"jsonSchemaDialect": index.JSONSchemaDialect,
+		"paths":             paths,
+		"components": map[string]any{
+			"schemas": b.schemas,
+			"responses": map[string]any{
+				"Problem": map[string]any{
+					"description": "The request was not evaluated",
+					"content":     map[string]any{"application/problem+json": map[string]any{"schema": problem}},
+				},
+			},
+		},
+	}, nil
+}
+
+// decisionOperation describes POST /decision/{target}.
+func decisionOperation(p *index.Policy, target string, facts, response map[string]any) map[string]any {
+	operation := policyOperation(p, "decide."+strings.ReplaceAll(target, "/", "."))
+	operation["parameters"] = []any{
+		queryFlag("explain", "Include the evaluation trace of each outcome"),
+		queryFlag("include_metadata", "Include the declared metadata of the policy in each outcome"),
+	}
+	operation["requestBody"] = jsonBody(map[string]any{
+		"type":       "object",
+		"properties": map[string]any{"facts": facts},
+	})
+	operation["responses"] = evaluationResponses("The result envelope of the evaluation", response)
+	return operation
+}
+
+// batchOperation describes POST /batch/{target}.
+func batchOperation(p *index.Policy, target string, facts, response map[string]any) map[string]any {
+	operation := policyOperation(p, "batch."+strings.ReplaceAll(target, "/", "."))
+	operation["requestBody"] = jsonBody(map[string]any{
+		"type":       "object",
+		"properties": map[string]any{"inputs": map[string]any{"type": "array", "items": facts}},
+		"required":   []string{"inputs"},
+	})
+	operation["responses"] = evaluationResponses("The evaluation of every input, in input order", response)
+	return operation
+}
+
+// policyOperation is an operation evaluating p, tagged with its FQN and described by its
+// declared title and description.
+func policyOperation(p *index.Policy, operationID string) map[string]any {
+	operation := map[string]any{
+		"operationId": operationID,
+		"tags":        []string{p.FQN.String()},
+	}
+	if p.Title != nil {
+		operation["summary"] = *p.Title
+	}
+	if p.Description != nil {
+		operation["description"] = *p.Description
+	}
+	return operation
+}
+
+func evaluationResponses(description string, schema map[string]any) map[string]any {
+	return map[string]any{
+		"200": jsonResponse(description, schema),
+		"400": problemResponse("The request body is not valid JSON"),
+		"404": problemResponse("The target does not resolve"),
+		"413": problemResponse("The request body is too large"),
+		"503": problemResponse("The policies are not loaded yet, or the server is already running as many evaluations as it allows"),
+	}
+}
+
+func jsonBody(schema map[string]any) map[string]any {
+	return map[string]any{
+		"required": true,
+		"content":  map[string]any{"application/json": map[string]any{"schema": schema}},
+	}
+}
+
+func jsonResponse(description string, schema map[string]any) map[string]any {
+	return map[string]any{
+		"description": description,
+		"content":     map[string]any{"application/json": map[string]any{"schema": schema}},
+	}
+}
+
+func problemResponse(description string) map[string]any {
+	return map[string]any{"$ref": "#/components/responses/Problem", "description": description}
+}
+
+func queryFlag(name, description string) map[string]any {
+	return map[string]any{
+		"name":        name,
+		"in":          "query",
+		"description": description,
+		"schema":      map[string]any{"type": "boolean", "default": false},
+	}
+}
+
+// statusSchema describes the body of a probe answering status.
+func statusSchema(status string) map[string]any {
+	return map[string]any{
+		"type": "object",
+		"properties": map[string]any{
+			"status": map[string]any{"const": status},
+			"time":   map[string]any{"type": "string", "format": "date-time"},
+		},
+		"required": []string{"status", "time"},
+	}
+}
+
+// componentName names the component schema of the shape or the facts of the policy with the
+// given FQN; component names cannot hold slashes.
+func componentName(kind, fqn string) string {
+	return kind + "." + strings.ReplaceAll(fqn, "/", ".")
+}
+
+type openAPIBuilder struct {
+	// schemas holds the component schemas; a struct type has an entry, under its name, from the
+	// moment it is first met, so that recursive types refer to it instead of expanding
+	schemas map[string]any
+	names   map[string]reflect.Type
+}
+
+// schemaOf returns the schema of the JSON encoding of values of t. Struct types are described
+// as component schemas and referred to.
+func (b *openAPIBuilder) schemaOf(t reflect.Type) map[string]any {
+	if schema, ok := knownSchemas[t]; ok {
+		if t.Kind() != reflect.Struct || t.Name() == "" {
+			return schema
+		}
+		return b.component(t, func() map[string]any { return schema })
+	}
+
+	switch t.Kind() {
+	case reflect.Pointer:
+		return b.schemaOf(t.Elem())
+	case reflect.Interface:
+		return map[string]any{}
+	}
+	// a type encoding itself is not described by its Go fields
+	if t.Implements(jsonMarshaler) || reflect.PointerTo(t).Implements(jsonMarshaler) {
+		return map[string]any{}
+	}
+
+	switch t.Kind() {
+	case reflect.Bool:
+		return map[string]any{"type": "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return map[string]any{"type": "integer"}
+	case reflect.Float32, reflect.Float64:
+		return map[string]any{"type": "number"}
+	case reflect.String:
+		return map[string]any{"type": "string"}
+	case reflect.Slice, reflect.Array:
+		return map[string]any{"type": "array", "items": b.schemaOf(t.Elem())}
+	case reflect.Map:
+		return map[string]any{"type": "object", "additionalProperties": b.schemaOf(t.Elem())}
+	case reflect.Struct:
+		if t.Name() == "" {
+			return b.object(t)
+		}
+		return b.component(t, func() map[string]any { return b.object(t) })
+	}
+	return map[string]any{}
+}
+
+// component returns a reference to the component schema of the struct type t, describing it
+// with describe when it is first met.
+func (b *openAPIBuilder) component(t reflect.Type, describe func() map[string]any) map[string]any {
+	name := t.Name()
+	if other, ok := b.names[name]; ok && other != t {
+		// types of different packages sharing a name are told apart by their package
+		name = strings.ReplaceAll(t.PkgPath(), "/", ".") + "." + name
+	}
+	if _, ok := b.names[name]; !ok {
+		b.names[name] = t
+		b.schemas[name] = map[string]any{}
+		b.schemas[name] = describe()
+	}
+	return map[string]any{"$ref": componentSchemas + name}
+}
+
+// object describes a struct type as encoding/json encodes it: a field is named by its json tag
+// and required unless it is omitted when empty, and the fields of embedded structs are promoted.
+func (b *openAPIBuilder) object(t reflect.Type) map[string]any {
+	properties := map[string]any{}
+	required := []string{}
+	b.fields(t, properties, &required)
+	return map[string]any{
+		"type":       "object",
+		"properties": properties,
+		"required":   required,
+	}
+}
+
+func (b *openAPIBuilder) fields(t reflect.Type, properties map[string]any, required *[]string) {
+	for i := range t.NumField() {
+		field := t.Field(i)
+		tag := field.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+		name, opts, _ := strings.Cut(tag, ",")
+		if field.Anonymous && name == "" {
+			embedded := field.Type
+			if embedded.Kind() == reflect.Pointer {
+				embedded = embedded.Elem()
+			}
+			if embedded.Kind() == reflect.Struct {
+				b.fields(embedded, properties, required)
+				continue
+			}
+		}
+		if !field.IsExported() {
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+		properties[name] = b.schemaOf(field.Type)
+		options := strings.Split(opts, ",")
+		if !slices.Contains(options, "omitempty") && !slices.Contains(options, "omitzero") {
+			*required = append(*required, name)
+		}
+	}
+}
+
+// handleOpenAPI handles GET /openapi.json requests, answering the OpenAPI document of the
+// policies being served, see OpenAPIDocument.
+func (api *HTTPAPI) handleOpenAPI(w http.ResponseWriter, r *http.Request) {
+	exec, ok := api.servingExecutor(w, r)
+	if !ok {
+		return
+	}
+
+	document, err := OpenAPIDocument(exec.Index(), api.version)
+	if err != nil {
+		api.writeErrorResponse(w, r, http.StatusInternalServerError, "Internal Server Error", err.Error())
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+
+	if err := json.NewEncoder(w).Encode(document); err != nil {
+		api.logger.DebugContext(r.Context(), "Error encoding OpenAPI document", "error", err)
+	}
+}
@@ -0,0 +1,150 @@
+// SPDX-FileCopyrightText: © 2026 Binaek Sarkar <binaek89@gmail.com>
+// SPDX-License-Identifier: Apache-2.0
+
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+
+	"github.com/sentrie-sh/sentrie/api/middleware"
+	"github.com/xeipuuv/gojsonschema"
+)
+
+// openAPIDocument returns the OpenAPI document of the policies served by api, as decoded JSON.
+func (s *APITestSuite) openAPIDocument(api *HTTPAPI) map[string]any {
+	document, err := OpenAPIDocument(api.currentExecutor().Index(), "1.2.3")
+	s.Require().NoError(err)
+	raw, err := json.Marshal(document)
+	s.Require().NoError(err)
+	var decoded map[string]any
+	s.Require().NoError(json.Unmarshal(raw, &decoded))
+	return decoded
+}
+
+// getFrom requests path from handler.
+func getFrom(handler http.HandlerFunc, path string) *httptest.ResponseRecorder {
+	rec := httptest.NewRecorder()
+	middleware.RequestIDMiddleware(handler).ServeHTTP(rec, httptest.NewRequest(http.MethodGet, path, nil))
+	return rec
+}
+
+// validateAgainst validates the JSON value against the schema of document at the JSON pointer
+// segments, such as those of a request body.
+func (s *APITestSuite) validateAgainst(document map[string]any, value string, segments ...string) *gojsonschema.Result {
+	pointer := "#"
+	for _, segment := range segments {
+		pointer += "/" + strings.ReplaceAll(strings.ReplaceAll(segment, "~", "~0"), "/", "~1")
+	}
+	root := map[string]any{"$ref": pointer}
+	for k, v := range document {
+		root[k] = v
+	}
+
+	loader := gojsonschema.NewSchemaLoader()
+	loader.Draft = gojsonschema.Draft7
+	schema, err := loader.Compile(gojsonschema.NewGoLoader(root))
+	s.Require().NoError(err)
+	result, err := schema.Validate(gojsonschema.NewStringLoader(value))
+	s.Require().NoError(err)
+	return result
+}
+
+func (s *APITestSuite) TestOpenAPIDocumentDescribesEveryPolicyAndExportedRule() {
+	api := s.newTestHTTPAPI()
+	document := s.openAPIDocument(api)
+
+	s.Equal(OpenAPIVersion, document["openapi"])
+	s.Equal("1.2.3", document["info"].(map[string]any)["version"])
+
+	paths := document["paths"].(map[string]any)
+	for _, target := range []string{"echo", "echo/ok", "vault", "vault/ok", "combined", "combined/undecided", "combined/no"} {
+		s.Contains(paths, "/decision/com/example/"+target)
+		s.Contains(paths, "/batch/com/example/"+target)
+	}
+	s.Contains(paths, "/readyz")
+	s.Contains(paths, "/openapi.json")
+
+	echo := paths["/decision/com/example/echo"].(map[string]any)["post"].(map[string]any)
+	s.Equal("Echo", echo["summary"])
+	s.Equal([]any{"com/example/echo"}, echo["tags"])
+
+	schemas := document["components"].(map[string]any)["schemas"].(map[string]any)
+	vault := schemas["facts.com.example.vault"].(map[string]any)
+	s.Equal([]any{"token"}, vault["required"])
+	s.Equal(true, vault["properties"].(map[string]any)["token"].(map[string]any)["writeOnly"])
+	// the response schemas follow the types the handlers encode
+	s.Contains(schemas, "DecisionResponse")
+	s.Contains(schemas, "Outcome")
+	s.Contains(schemas, "BatchResponse")
+	s.False(s.validateAgainst(document, `{"outcomes": {}}`, "components", "schemas", "DecisionResponse").Valid())
+}
+
+func (s *APITestSuite) TestOpenAPIDocumentRoundTripsRequestsThroughTheHandlers() {
+	api := s.newTestHTTPAPI()
+	document := s.openAPIDocument(api)
+
+	for _, tc := range []struct {
+		name, endpoint, target, body string
+		// valid is whether the body conforms to the request schema; the handler evaluates a
+		// request that does not and reports why its facts are not valid
+		valid bool
+	}{
+		{"decision of a policy", "decision", "com/example/echo", `{"facts": {"note": "hi"}}`, true},
+		{"explained decision of a rule", "decision", "com/example/echo/ok?explain=true&include_metadata=true", `{"facts": {}}`, true},
+		{"combined decision", "decision", "com/example/combined", `{"facts": {}}`, true},
+		{"unknown decision", "decision", "com/example/maybe", `{"facts": {}}`, true},
+		{"sensitive fact", "decision", "com/example/vault/ok", `{"facts": {"token": "tok_s3cr3t"}}`, true},
+		{"missing fact", "decision", "com/example/vault/ok", `{"facts": {}}`, false},
+		{"mistyped fact", "decision", "com/example/vault/ok", `{"facts": {"token": 5}}`, false},
+		{"batch", "batch", "com/example/vault", `{"inputs": [{"token": "tok_s3cr3t"}, {"token": "nope"}]}`, true},
+		{"batch with an invalid input", "batch", "com/example/vault/ok", `{"inputs": [{"token": "tok_s3cr3t"}, {}]}`, false},
+	} {
+		s.Run(tc.name, func() {
+			path, _, _ := strings.Cut(tc.target, "?")
+			operation := []string{"paths", "/" + tc.endpoint + "/" + path, "post"}
+
+			request := s.validateAgainst(document, tc.body, append(operation, "requestBody", "content", "application/json", "schema")...)
+			s.Equal(tc.valid, request.Valid(), "%v", request.Errors())
+
+			var body string
+			if tc.endpoint == "batch" {
+				rec := s.postBatch(api, path, tc.body)
+				s.Require().Equal(http.StatusOK, rec.Code)
+				body = rec.Body.String()
+			} else {
+				rec := s.postDecisionAt(api, path, "/decision/"+tc.target, tc.body)
+				s.Require().Equal(http.StatusOK, rec.Code)
+				body = rec.Body.String()
+				s.Equal(tc.valid, !strings.Contains(body, `"error"`), body)
+			}
+
+			response := s.validateAgainst(document, body, append(operation, "responses", "200", "content", "application/json", "schema")...)
+			s.True(response.Valid(), "%v in %s", response.Errors(), body)
+		})
+	}
+}
+
+func (s *APITestSuite) TestOpenAPIDocumentDescribesProblemsAndProbes() {
+	api := NewHTTPAPI(nil)
+
+	// there is no document until the policies are loaded
+	rec := getFrom(api.handleOpenAPI, "/openapi.json")
+	s.Equal(http.StatusServiceUnavailable, rec.Code)
+
+	s.newTestHTTPAPI()
+	document := s.openAPIDocument(NewHTTPAPI(s.exec))
+	problem := s.validateAgainst(document, rec.Body.String(), "components", "schemas", "ProblemDetails")
+	s.True(problem.Valid(), "%v", problem.Errors())
+
+	rec = s.getProbe(api, "/readyz")
+	readiness := s.validateAgainst(document, rec.Body.String(), "paths", "/readyz", "get", "responses", "503", "content", "application/json", "schema")
+	s.True(readiness.Valid(), "%v", readiness.Errors())
+
+	api.SwapExecutor(s.exec)
+	rec = getFrom(api.handleOpenAPI, "/openapi.json")
+	s.Equal(http.StatusOK, rec.Code)
+	s.Contains(rec.Body.String(), `"/decision/com/example/echo"`)
+}
@@ -19,11 +19,14 @@ package api
 import (
 	"testing"
 
+	"github.com/sentrie-sh/sentrie/runtime"
 	"github.com/stretchr/testify/suite"
 )
 
 type APITestSuite struct {
 	suite.Suite
+	// exec is built once on first use; executors reserve their caches up front
+	exec runtime.Executor
 }
 
 func TestAPITestSuite(t *testing.T) {
@@ -19,14 +19,17 @@ package ast
 import "github.com/sentrie-sh/sentrie/tokens"
 
 type FieldAccessExpression struct {
-	*baseNode
+	*NodeBase
 	Left  Expression
 	Field string
+	// Optional marks `left?.field`, which is null when left is null or undefined and
+	// short-circuits the rest of the access chain
+	Optional bool
 }
 
 func NewFieldAccessExpression(left Expression, field string, ssp tokens.Range) *FieldAccessExpression {
 	return &FieldAccessExpression{
-		baseNode: &baseNode{
+		NodeBase: &NodeBase{
 			Rnge:  ssp,
 			Kind_: "field_access",
 		},
@@ -36,14 +39,14 @@ func NewFieldAccessExpression(left Expression, field string, ssp tokens.Range) *
 }
 
 type IndexAccessExpression struct {
-	*baseNode
+	*NodeBase
 	Left  Expression
 	Index Expression
 }
 
 func NewIndexAccessExpression(left Expression, index Expression, ssp tokens.Range) *IndexAccessExpression {
 	return &IndexAccessExpression{
-		baseNode: &baseNode{
+		NodeBase: &NodeBase{
 			Rnge:  ssp,
 			Kind_: "index_access",
 		},
@@ -52,10 +55,34 @@ func NewIndexAccessExpression(left Expression, index Expression, ssp tokens.Rang
 	}
 }
 
+// SliceExpression is `left[start:end]`. Either bound may be nil when omitted, as in
+// `left[:end]` and `left[start:]`.
+type SliceExpression struct {
+	*NodeBase
+	Left  Expression
+	Start Expression
+	End   Expression
+}
+
+func NewSliceExpression(left Expression, start Expression, end Expression, ssp tokens.Range) *SliceExpression {
+	return &SliceExpression{
+		NodeBase: &NodeBase{
+			Rnge:  ssp,
+			Kind_: "slice",
+		},
+		Left:  left,
+		Start: start,
+		End:   end,
+	}
+}
+
 var _ Expression = &FieldAccessExpression{}
 var _ Node = &FieldAccessExpression{}
 
 func (f *FieldAccessExpression) String() string {
+	if f.Optional {
+		return f.Left.String() + "?." + f.Field
+	}
 	return f.Left.String() + "." + f.Field
 }
 
@@ -63,9 +90,25 @@ func (i *IndexAccessExpression) String() string {
 	return i.Left.String() + "[" + i.Index.String() + "]"
 }
 
+func (s *SliceExpression) String() string {
+	start, end := "", ""
+	if s.Start != nil {
+		start = s.Start.String()
+	}
+	if s.End != nil {
+		end = s.End.String()
+	}
+	return s.Left.String() + "[" + start + ":" + end + "]"
+}
+
 func (f *FieldAccessExpression) expressionNode() {}
 
 func (i *IndexAccessExpression) expressionNode() {}
 
 var _ Expression = &IndexAccessExpression{}
 var _ Node = &IndexAccessExpression{}
+
+func (s *SliceExpression) expressionNode() {}
+
+var _ Expression = &SliceExpression{}
+var _ Node = &SliceExpression{}
@@ -23,14 +23,14 @@ import (
 )
 
 type BlockExpression struct {
-	*baseNode
+	*NodeBase
 	Statements []Statement
 	Yield      Expression
 }
 
 func NewBlockExpression(statements []Statement, yield Expression, ssp tokens.Range) *BlockExpression {
 	return &BlockExpression{
-		baseNode: &baseNode{
+		NodeBase: &NodeBase{
 			Rnge:  ssp,
 			Kind_: "block",
 		},
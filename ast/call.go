@@ -25,7 +25,7 @@ import (
 )
 
 type CallExpression struct {
-	*baseNode
+	*NodeBase
 	Callee     Expression
 	Arguments  []Expression
 	Memoized   bool
@@ -34,7 +34,7 @@ type CallExpression struct {
 
 func NewCallExpression(callee Expression, arguments []Expression, memoized bool, memoizeTTL *time.Duration, ssp tokens.Range) *CallExpression {
 	return &CallExpression{
-		baseNode: &baseNode{
+		NodeBase: &NodeBase{
 			Rnge:  ssp,
 			Kind_: "call",
 		},
@@ -19,14 +19,14 @@ package ast
 import "github.com/sentrie-sh/sentrie/tokens"
 
 type CastExpression struct {
-	*baseNode
+	*NodeBase
 	Expr       Expression
 	TargetType TypeRef
 }
 
 func NewCastExpression(expr Expression, targetType TypeRef, ssp tokens.Range) *CastExpression {
 	return &CastExpression{
-		baseNode: &baseNode{
+		NodeBase: &NodeBase{
 			Rnge:  ssp,
 			Kind_: "cast",
 		},
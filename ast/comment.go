@@ -23,13 +23,13 @@ import (
 )
 
 type CommentStatement struct {
-	*baseNode
+	*NodeBase
 	Content string
 }
 
 func NewCommentStatement(content string, ssp tokens.Range) *CommentStatement {
 	return &CommentStatement{
-		baseNode: &baseNode{
+		NodeBase: &NodeBase{
 			Rnge:  ssp,
 			Kind_: "comment",
 		},
@@ -38,14 +38,14 @@ func NewCommentStatement(content string, ssp tokens.Range) *CommentStatement {
 }
 
 type TrailingCommentExpression struct {
-	*baseNode
+	*NodeBase
 	CommentContent string
 	Wrap           Expression
 }
 
 func NewTrailingCommentExpression(commentContent string, wrap Expression, ssp tokens.Range) *TrailingCommentExpression {
 	return &TrailingCommentExpression{
-		baseNode: &baseNode{
+		NodeBase: &NodeBase{
 			Rnge:  ssp,
 			Kind_: "trailing_comment",
 		},
@@ -55,14 +55,14 @@ func NewTrailingCommentExpression(commentContent string, wrap Expression, ssp to
 }
 
 type PrecedingCommentExpression struct {
-	*baseNode
+	*NodeBase
 	CommentContent string
 	Wrap           Expression
 }
 
 func NewPrecedingCommentExpression(commentContent string, wrap Expression, ssp tokens.Range) *PrecedingCommentExpression {
 	return &PrecedingCommentExpression{
-		baseNode: &baseNode{
+		NodeBase: &NodeBase{
 			Rnge:  ssp,
 			Kind_: "preceding_comment",
 		},
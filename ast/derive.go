@@ -0,0 +1,48 @@
+// SPDX-FileCopyrightText: © 2026 Binaek Sarkar <binaek89@gmail.com>
+// SPDX-License-Identifier: Apache-2.0
+
+package ast
+
+import (
+	"strings"
+
+	"github.com/sentrie-sh/sentrie/tokens"
+)
+
+// DeriveStatement is a named pure expression of a policy: derive name(a, b) = expression. A
+// derive sees its parameters and the facts, lets and rules of its policy, so within one request
+// its value depends on its arguments alone.
+type DeriveStatement struct {
+	*NodeBase
+	Name   string
+	Params []string
+	Body   Expression
+}
+
+func NewDeriveStatement(name string, params []string, body Expression, ssp tokens.Range) *DeriveStatement {
+	return &DeriveStatement{
+		NodeBase: &NodeBase{
+			Rnge:  ssp,
+			Kind_: "derive",
+		},
+		Name:   name,
+		Params: params,
+		Body:   body,
+	}
+}
+
+func (d *DeriveStatement) String() string {
+	var b strings.Builder
+	b.WriteString("derive ")
+	b.WriteString(d.Name)
+	b.WriteByte('(')
+	b.WriteString(strings.Join(d.Params, ", "))
+	b.WriteString(") = ")
+	b.WriteString(d.Body.String())
+	return b.String()
+}
+
+func (d *DeriveStatement) statementNode() {}
+
+var _ Statement = (*DeriveStatement)(nil)
+var _ Node = (*DeriveStatement)(nil)
@@ -23,20 +23,36 @@ import (
 )
 
 type RuleExportStatement struct {
-	*baseNode
+	*NodeBase
 	Of          string              // Name of the exported variable or decision
 	Attachments []*AttachmentClause // Optional attachments for the export
 }
 
 type AttachmentClause struct {
-	*baseNode
-	What string     // Name of the attachment
-	As   Expression // Value of the attachment
+	*NodeBase
+	What      string     // Name of the attachment
+	As        Expression // Value of the attachment
+	Directive string     // "obligation" or "advice" for a directive, empty for a plain attachment
+	Type      TypeRef    // Optional shape the value of a directive must conform to
+}
+
+const (
+	DirectiveObligation = "obligation"
+	DirectiveAdvice     = "advice"
+)
+
+// NewDirectiveClause creates an obligation or advice of an export, an attachment the caller
+// must fulfill or may act upon.
+func NewDirectiveClause(directive, what string, typeRef TypeRef, as Expression, ssp tokens.Range) *AttachmentClause {
+	a := NewAttachmentClause(what, as, ssp)
+	a.Directive = directive
+	a.Type = typeRef
+	return a
 }
 
 func NewAttachmentClause(what string, as Expression, ssp tokens.Range) *AttachmentClause {
 	return &AttachmentClause{
-		baseNode: &baseNode{
+		NodeBase: &NodeBase{
 			Rnge:  ssp,
 			Kind_: "attachment_clause",
 		},
@@ -47,7 +63,7 @@ func NewAttachmentClause(what string, as Expression, ssp tokens.Range) *Attachme
 
 func NewRuleExportStatement(of string, attachments []*AttachmentClause, ssp tokens.Range) *RuleExportStatement {
 	return &RuleExportStatement{
-		baseNode: &baseNode{
+		NodeBase: &NodeBase{
 			Rnge:  ssp,
 			Kind_: "rule_export",
 		},
@@ -66,6 +82,12 @@ var _ Statement = &RuleExportStatement{}
 var _ Node = &RuleExportStatement{}
 
 func (a AttachmentClause) String() string {
+	if a.Directive != "" {
+		if a.Type != nil {
+			return fmt.Sprintf("%s %q: %s = %s", a.Directive, a.What, a.Type, a.As)
+		}
+		return fmt.Sprintf("%s %q = %s", a.Directive, a.What, a.As)
+	}
 	return fmt.Sprintf("attach %s as %s", a.What, a.As)
 }
 func (a *AttachmentClause) expressionNode() {}
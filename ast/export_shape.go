@@ -19,13 +19,13 @@ package ast
 import "github.com/sentrie-sh/sentrie/tokens"
 
 type ShapeExportStatement struct {
-	*baseNode
+	*NodeBase
 	Name string
 }
 
 func NewShapeExportStatement(name string, ssp tokens.Range) *ShapeExportStatement {
 	return &ShapeExportStatement{
-		baseNode: &baseNode{
+		NodeBase: &NodeBase{
 			Rnge:  ssp,
 			Kind_: "shape_export",
 		},
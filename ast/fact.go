@@ -19,17 +19,19 @@ package ast
 import "github.com/sentrie-sh/sentrie/tokens"
 
 type FactStatement struct {
-	*baseNode
+	*NodeBase
 	Name     string     // Name of the fact
 	Type     TypeRef    // Type of the fact
 	Alias    string     // Exposed name of the fact
 	Default  Expression // Default value expression (optional)
 	Optional bool       // Whether the fact is optional (default: false, i.e., required)
+	// Sensitive facts have their value redacted wherever it surfaces outside of evaluation
+	Sensitive bool
 }
 
 func NewFactStatement(name string, typeRef TypeRef, alias string, defaultExpr Expression, optional bool, ssp tokens.Range) *FactStatement {
 	return &FactStatement{
-		baseNode: &baseNode{
+		NodeBase: &NodeBase{
 			Rnge:  ssp,
 			Kind_: "fact",
 		},
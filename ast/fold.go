@@ -0,0 +1,224 @@
+// SPDX-FileCopyrightText: © 2026 Binaek Sarkar <binaek89@gmail.com>
+// SPDX-License-Identifier: Apache-2.0
+
+package ast
+
+import (
+	"math"
+)
+
+// FoldConstants replaces, in place, every infix and unary expression of program whose
+// operands are literals with the literal it evaluates to. The folded literal takes the span
+// of the outermost expression it replaces.
+//
+// Only operations whose evaluated value a literal represents exactly are folded: arithmetic
+// on numbers, concatenation of strings and the logical operators on trinaries. Comparisons,
+// which evaluate to booleans, and division or modulo by zero, which fail at evaluation, are
+// left for the runtime.
+func FoldConstants(program *Program) {
+	for _, stmt := range program.Statements {
+		Inspect(stmt, func(n Node) bool {
+			foldChildren(n)
+			return true
+		})
+	}
+}
+
+// foldChildren folds the direct child expressions of node.
+func foldChildren(node Node) {
+	switch n := node.(type) {
+	case *FactStatement:
+		n.Default = foldExpression(n.Default)
+	case *VarDeclaration:
+		n.Value = foldExpression(n.Value)
+	case *DeriveStatement:
+		n.Body = foldExpression(n.Body)
+	case *RuleStatement:
+		n.Default = foldExpression(n.Default)
+		n.When = foldExpression(n.When)
+		n.Body = foldExpression(n.Body)
+	case *AttachmentClause:
+		n.As = foldExpression(n.As)
+	case *ShapeStatement:
+		if n.Complex != nil {
+			for _, field := range n.Complex.Fields {
+				field.Default = foldExpression(field.Default)
+			}
+		}
+	case *BlockExpression:
+		n.Yield = foldExpression(n.Yield)
+	case *CallExpression:
+		foldExpressions(n.Arguments)
+	case *InfixExpression:
+		n.Left = foldExpression(n.Left)
+		n.Right = foldExpression(n.Right)
+	case *UnaryExpression:
+		n.Right = foldExpression(n.Right)
+	case *TernaryExpression:
+		n.Condition = foldExpression(n.Condition)
+		n.ThenBranch = foldExpression(n.ThenBranch)
+		n.ElseBranch = foldExpression(n.ElseBranch)
+	case *MatchExpression:
+		for i := range n.Arms {
+			n.Arms[i].Condition = foldExpression(n.Arms[i].Condition)
+			n.Arms[i].Result = foldExpression(n.Arms[i].Result)
+		}
+		n.Else = foldExpression(n.Else)
+	case *FieldAccessExpression:
+		n.Left = foldExpression(n.Left)
+	case *IndexAccessExpression:
+		n.Left = foldExpression(n.Left)
+		n.Index = foldExpression(n.Index)
+	case *SliceExpression:
+		n.Left = foldExpression(n.Left)
+		n.Start = foldExpression(n.Start)
+		n.End = foldExpression(n.End)
+	case *ListLiteral:
+		foldExpressions(n.Values)
+	case *MapLiteral:
+		for i := range n.Entries {
+			n.Entries[i].Key = foldExpression(n.Entries[i].Key)
+			n.Entries[i].Value = foldExpression(n.Entries[i].Value)
+		}
+	case *CastExpression:
+		n.Expr = foldExpression(n.Expr)
+	case *IsDefinedExpression:
+		n.Left = foldExpression(n.Left)
+	case *IsEmptyExpression:
+		n.Left = foldExpression(n.Left)
+	case *TransformExpression:
+		n.Argument = foldExpression(n.Argument)
+	case *InterpolatedString:
+		foldExpressions(n.Parts)
+	case *ProjectExpression:
+		n.Source = foldExpression(n.Source)
+		for i := range n.Entries {
+			n.Entries[i].Value = foldExpression(n.Entries[i].Value)
+		}
+	case *WithClause:
+		n.Expr = foldExpression(n.Expr)
+	case *TrailingCommentExpression:
+		n.Wrap = foldExpression(n.Wrap)
+	case *PrecedingCommentExpression:
+		n.Wrap = foldExpression(n.Wrap)
+	case TypeRef:
+		for _, constraint := range n.GetConstraints() {
+			foldExpressions(constraint.Args)
+		}
+	}
+}
+
+func foldExpressions(exprs []Expression) {
+	for i := range exprs {
+		exprs[i] = foldExpression(exprs[i])
+	}
+}
+
+// foldExpression returns the literal expr evaluates to when it can be folded, and expr
+// otherwise.
+func foldExpression(expr Expression) Expression {
+	switch e := expr.(type) {
+	case *InfixExpression:
+		e.Left = foldExpression(e.Left)
+		e.Right = foldExpression(e.Right)
+		if folded := foldInfix(e); folded != nil {
+			return folded
+		}
+	case *UnaryExpression:
+		e.Right = foldExpression(e.Right)
+		if folded := foldUnary(e); folded != nil {
+			return folded
+		}
+	}
+	return expr
+}
+
+func foldInfix(e *InfixExpression) Expression {
+	if l, ok := numberLiteralValue(e.Left); ok {
+		r, ok := numberLiteralValue(e.Right)
+		if !ok {
+			return nil
+		}
+		switch e.Operator {
+		case "+":
+			return numberLiteral(l+r, e)
+		case "-":
+			return numberLiteral(l-r, e)
+		case "*":
+			return numberLiteral(l*r, e)
+		case "/":
+			if r == 0 {
+				return nil
+			}
+			return numberLiteral(l/r, e)
+		case "%":
+			if r == 0 {
+				return nil
+			}
+			return numberLiteral(math.Mod(l, r), e)
+		}
+		return nil
+	}
+
+	if l, ok := e.Left.(*StringLiteral); ok {
+		if r, ok := e.Right.(*StringLiteral); ok && e.Operator == "+" {
+			return NewStringLiteral(l.Value+r.Value, e.Span())
+		}
+		return nil
+	}
+
+	if l, ok := e.Left.(*TrinaryLiteral); ok {
+		r, ok := e.Right.(*TrinaryLiteral)
+		if !ok {
+			return nil
+		}
+		switch e.Operator {
+		case "and":
+			return NewTrinaryLiteral(l.Value.And(r.Value), e.Span())
+		case "or":
+			return NewTrinaryLiteral(l.Value.Or(r.Value), e.Span())
+		case "xor":
+			return NewTrinaryLiteral(l.Value.Xor(r.Value), e.Span())
+		}
+	}
+	return nil
+}
+
+func foldUnary(e *UnaryExpression) Expression {
+	if v, ok := numberLiteralValue(e.Right); ok {
+		switch e.Operator {
+		case "+":
+			return numberLiteral(v, e)
+		case "-":
+			return numberLiteral(-v, e)
+		}
+		return nil
+	}
+	if t, ok := e.Right.(*TrinaryLiteral); ok && (e.Operator == "!" || e.Operator == "not") {
+		return NewTrinaryLiteral(t.Value.Not(), e.Span())
+	}
+	return nil
+}
+
+func numberLiteralValue(expr Expression) (float64, bool) {
+	switch e := expr.(type) {
+	case *IntegerLiteral:
+		return e.Value, true
+	case *FloatLiteral:
+		return e.Value, true
+	}
+	return 0, false
+}
+
+// numberLiteral is the literal of v, with the span of the expression folded into it. A
+// result that is not finite is not folded.
+func numberLiteral(v float64, folded Expression) Expression {
+	if math.IsInf(v, 0) || math.IsNaN(v) {
+		return nil
+	}
+	// an integer literal cannot hold a negative zero
+	if v == math.Trunc(v) && math.Abs(v) < 1<<53 && !(v == 0 && math.Signbit(v)) {
+		return NewIntegerLiteral(int64(v), folded.Span())
+	}
+	return NewFloatLiteral(v, folded.Span())
+}
@@ -26,7 +26,7 @@ import (
 const FQNSeparator = "/"
 
 type FQN struct {
-	*baseNode
+	*NodeBase
 	Parts []string
 }
 
@@ -36,7 +36,7 @@ func (f FQN) IsEmpty() bool {
 
 func NewFQN(parts []string, ssp tokens.Range) FQN {
 	return FQN{
-		baseNode: &baseNode{
+		NodeBase: &NodeBase{
 			Rnge:  ssp,
 			Kind_: "fqn",
 		},
@@ -55,11 +55,33 @@ func (f FQN) String() string {
 	return strings.Join(f.Parts, FQNSeparator)
 }
 
+// ParseFQN parses a slash separated name such as "com/example/Policy" into an FQN.
+// Every segment must be a valid identifier, as accepted by the lexer: an ASCII letter
+// or underscore followed by ASCII letters, digits or underscores.
+func ParseFQN(s string) (FQN, error) {
+	if s == "" {
+		return FQN{}, fmt.Errorf("invalid FQN: empty string")
+	}
+	parts := strings.Split(s, FQNSeparator)
+	for i, part := range parts {
+		if part == "" {
+			return FQN{}, fmt.Errorf("invalid FQN %q: segment %d is empty", s, i+1)
+		}
+		if !tokens.IsIdentifier(part) {
+			return FQN{}, fmt.Errorf("invalid FQN %q: segment %q is not a valid identifier", s, part)
+		}
+	}
+	return NewFQN(parts, tokens.Range{}), nil
+}
+
 func CreateFQN(base FQN, lastSegment string) FQN {
 	if len(base.Parts) == 0 {
 		return NewFQN([]string{lastSegment}, base.Rnge)
 	}
-	return NewFQN(append(base.Parts, lastSegment), base.Rnge)
+	// copy the base parts, so that siblings created from the same base never share a backing array
+	parts := make([]string, 0, len(base.Parts)+1)
+	parts = append(parts, base.Parts...)
+	return NewFQN(append(parts, lastSegment), base.Rnge)
 }
 
 // LastSegment returns the last segment of the FQN
@@ -56,6 +56,21 @@ func (s *AstTestSuite) TestCreateFQN() {
 	result = CreateFQN(base, "example")
 	expected = NewFQN([]string{"com", "example"}, tokens.Range{})
 	s.Equal(expected, result)
+
+	// Siblings created from a base with spare capacity must not share storage
+	parts := make([]string, 2, 4)
+	copy(parts, []string{"com", "example"})
+	base = NewFQN(parts, tokens.Range{})
+	first := CreateFQN(base, "first")
+	second := CreateFQN(base, "second")
+	s.Equal("com/example/first", first.String())
+	s.Equal("com/example/second", second.String())
+
+	// extending a sibling must not overwrite its siblings either
+	child := CreateFQN(first, "child")
+	s.Equal("com/example/first/child", child.String())
+	s.Equal("com/example/second", second.String())
+	s.Equal([]string{"com", "example"}, base.Parts)
 }
 
 // TestFQNIsParentOf tests the IsParentOf method
@@ -252,3 +267,31 @@ func (s *AstTestSuite) TestFQNImplementationCorrectness() {
 	expectedPrefix := parent.String() + FQNSeparator
 	s.True(child.String()[:len(expectedPrefix)] == expectedPrefix, "Child should start with parent prefix")
 }
+
+func (s *AstTestSuite) TestParseFQN() {
+	fqn, err := ParseFQN("com/example/Policy")
+	s.Require().NoError(err)
+	s.Equal([]string{"com", "example", "Policy"}, fqn.Parts)
+	s.Equal("com/example/Policy", fqn.String())
+
+	fqn, err = ParseFQN("_policy2")
+	s.Require().NoError(err)
+	s.Equal([]string{"_policy2"}, fqn.Parts)
+
+	invalid := map[string]string{
+		"":               "empty string",
+		"/com/example":   "segment 1 is empty",
+		"com/example/":   "segment 3 is empty",
+		"com//example":   "segment 2 is empty",
+		"com/2fast":      "not a valid identifier",
+		"com/ex-ample":   "not a valid identifier",
+		"com/ex ample/x": "not a valid identifier",
+		"com/é":          "not a valid identifier",
+		"com/exämple":    "not a valid identifier",
+	}
+	for input, msg := range invalid {
+		_, err := ParseFQN(input)
+		s.Require().Error(err, input)
+		s.Contains(err.Error(), msg, input)
+	}
+}
@@ -32,6 +32,7 @@ func main() {
 		{"genNumberConstraints", toAnyMap(constraints.NumberContraintCheckers)},
 		{"genStringConstraints", toAnyMap(constraints.StringContraintCheckers)},
 		{"genTrinaryConstraints", toAnyMap(constraints.TrinaryConstraintCheckers)},
+		{"genDateConstraints", toAnyMap(constraints.DateConstraintCheckers)},
 		{"genListConstraints", toAnyMap(constraints.ListContraintCheckers)},
 		{"genDictConstraints", toAnyMap(constraints.DictContraintCheckers)},
 		{"genDocumentConstraints", toAnyMap(constraints.DocumentContraintCheckers)},
@@ -68,6 +69,9 @@ func main() {
 		writeMap(&buf, sec.varName, sec.source)
 	}
 
+	buf.WriteString("// genRegexpFlags are the flag letters accepted by the regexp constraint\n")
+	buf.WriteString(fmt.Sprintf("const genRegexpFlags = %s\n", strconvQuote(constraints.RegexpFlags)))
+
 	formatted, err := format.Source(buf.Bytes())
 	if err != nil {
 		panic(err)
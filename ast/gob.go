@@ -0,0 +1,126 @@
+// SPDX-FileCopyrightText: © 2026 Binaek Sarkar <binaek89@gmail.com>
+// SPDX-License-Identifier: Apache-2.0
+
+package ast
+
+import (
+	"crypto/sha256"
+	"encoding/gob"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"reflect"
+	"sync"
+)
+
+// gobNodes are the concrete nodes, registered with gob so that programs can be encoded
+// through their Node, Statement, Expression and TypeRef interfaces. Every type embedding
+// NodeBase belongs here, as any node may be held by a Node field.
+var gobNodes = []Node{
+	&FieldAccessExpression{},
+	&IndexAccessExpression{},
+	&SliceExpression{},
+	&BlockExpression{},
+	&CallExpression{},
+	&CastExpression{},
+	&CommentStatement{},
+	&TrailingCommentExpression{},
+	&PrecedingCommentExpression{},
+	&RuleExportStatement{},
+	&AttachmentClause{},
+	&ShapeExportStatement{},
+	&FactStatement{},
+	&FQN{},
+	&Identifier{},
+	&ImportClause{},
+	&WithClause{},
+	&InfixExpression{},
+	&IsDefinedExpression{},
+	&IsEmptyExpression{},
+	&LambdaExpression{},
+	&VarDeclaration{},
+	&DeriveStatement{},
+	&FloatLiteral{},
+	&IntegerLiteral{},
+	&InterpolatedString{},
+	&ListLiteral{},
+	&MapLiteral{},
+	&NullLiteral{},
+	&StringLiteral{},
+	&TrinaryLiteral{},
+	&MatchExpression{},
+	&PipelineHoleExpression{},
+	&CombiningStatement{},
+	&DescriptionStatement{},
+	&TagStatement{},
+	&TitleStatement{},
+	&VersionStatement{},
+	&NamespaceStatement{},
+	&PolicyStatement{},
+	&ProjectExpression{},
+	&RequireStatement{},
+	&RuleStatement{},
+	&EffectClause{},
+	&ShapeStatement{},
+	&TernaryExpression{},
+	&TestStatement{},
+	&TransformExpression{},
+	&UnaryExpression{},
+	&UseStatement{},
+	&DateTypeRef{},
+	&DictTypeRef{},
+	&DocumentTypeRef{},
+	&ListTypeRef{},
+	&NullableTypeRef{},
+	&NumberTypeRef{},
+	&RecordTypeRef{},
+	&ShapeTypeRef{},
+	&StringTypeRef{},
+	&TrinaryTypeRef{},
+}
+
+func init() {
+	for _, node := range gobNodes {
+		gob.Register(node)
+	}
+}
+
+// EncodingSchema returns a hash of the layout of the nodes registered with gob: the name of
+// every node, and the name and type of every exported field reachable from it. Programs
+// encoded under one schema are only decoded faithfully under the same schema.
+var EncodingSchema = sync.OnceValue(func() string {
+	h := sha256.New()
+	seen := map[reflect.Type]bool{}
+	for _, node := range gobNodes {
+		writeTypeLayout(h, reflect.TypeOf(node), seen)
+	}
+	return hex.EncodeToString(h.Sum(nil))
+})
+
+// writeTypeLayout writes the layout of the struct behind t, and of the structs its fields
+// refer to, to w. Every struct is written once.
+func writeTypeLayout(w io.Writer, t reflect.Type, seen map[reflect.Type]bool) {
+	for kind := t.Kind(); kind == reflect.Pointer || kind == reflect.Slice || kind == reflect.Array || kind == reflect.Map; kind = t.Kind() {
+		if kind == reflect.Map {
+			writeTypeLayout(w, t.Key(), seen)
+		}
+		t = t.Elem()
+	}
+	if t.Kind() != reflect.Struct || seen[t] {
+		return
+	}
+	seen[t] = true
+
+	fmt.Fprintf(w, "%s{", t)
+	for i := range t.NumField() {
+		if field := t.Field(i); field.IsExported() {
+			fmt.Fprintf(w, "%s %s;", field.Name, field.Type)
+		}
+	}
+	fmt.Fprint(w, "}")
+	for i := range t.NumField() {
+		if field := t.Field(i); field.IsExported() {
+			writeTypeLayout(w, field.Type, seen)
+		}
+	}
+}
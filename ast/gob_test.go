@@ -0,0 +1,53 @@
+// SPDX-FileCopyrightText: © 2026 Binaek Sarkar <binaek89@gmail.com>
+// SPDX-License-Identifier: Apache-2.0
+
+package ast
+
+import (
+	goast "go/ast"
+	"go/parser"
+	"go/token"
+	iofs "io/fs"
+	"reflect"
+	"strings"
+)
+
+// TestEveryNodeIsRegisteredWithGob checks gobNodes against the types of the package that embed
+// NodeBase or TypeRefBase, any of which may be encoded through a Node field.
+func (s *AstTestSuite) TestEveryNodeIsRegisteredWithGob() {
+	// neither is a Node, so neither is held by an interface field
+	registered := map[string]bool{"TypeRefBase": true, "TypeRefConstraint": true}
+	for _, node := range gobNodes {
+		registered[reflect.TypeOf(node).Elem().Name()] = true
+	}
+
+	pkgs, err := parser.ParseDir(token.NewFileSet(), ".", func(info iofs.FileInfo) bool {
+		return !strings.HasSuffix(info.Name(), "_test.go")
+	}, 0)
+	s.Require().NoError(err)
+
+	for _, file := range pkgs["ast"].Files {
+		for _, decl := range file.Decls {
+			gen, ok := decl.(*goast.GenDecl)
+			if !ok || gen.Tok != token.TYPE {
+				continue
+			}
+			for _, spec := range gen.Specs {
+				typeSpec := spec.(*goast.TypeSpec)
+				structType, ok := typeSpec.Type.(*goast.StructType)
+				if !ok {
+					continue
+				}
+				for _, field := range structType.Fields.List {
+					star, ok := field.Type.(*goast.StarExpr)
+					if !ok || len(field.Names) != 0 {
+						continue
+					}
+					if embedded, ok := star.X.(*goast.Ident); ok && (embedded.Name == "NodeBase" || embedded.Name == "TypeRefBase") {
+						s.True(registered[typeSpec.Name.Name], "%s is not registered with gob", typeSpec.Name.Name)
+					}
+				}
+			}
+		}
+	}
+}
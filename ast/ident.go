@@ -22,7 +22,7 @@ import (
 
 // Identifier represents an identifier
 type Identifier struct {
-	*baseNode
+	*NodeBase
 	Value string
 }
 
@@ -32,7 +32,7 @@ func (i *Identifier) String() string {
 
 func NewIdentifier(value string, ssp tokens.Range) *Identifier {
 	return &Identifier{
-		baseNode: &baseNode{
+		NodeBase: &NodeBase{
 			Rnge:  ssp,
 			Kind_: "identifier",
 		},
@@ -24,7 +24,7 @@ import (
 
 // 'import value|decision @ident from @string { @WithClause }'
 type ImportClause struct {
-	*baseNode
+	*NodeBase
 	RuleToImport  string        // The name of the rule being imported
 	FromPolicyFQN *FQN          // The source identifier - segmented by '/'
 	Withs         []*WithClause // Inline with import clause
@@ -33,14 +33,14 @@ type ImportClause struct {
 // 'with @ident as @string'
 // Represents a 'with' clause in an import statement, allowing for additional context or configuration.
 type WithClause struct {
-	*baseNode
+	*NodeBase
 	Name string     // Name of the with clause - this is also the name that the target policy exposes
 	Expr Expression // Value associated with the with clause
 }
 
 func NewImportClause(ruleToImport string, fromPolicyFQN *FQN, withs []*WithClause, ssp tokens.Range) *ImportClause {
 	return &ImportClause{
-		baseNode: &baseNode{
+		NodeBase: &NodeBase{
 			Rnge:  ssp,
 			Kind_: "import",
 		},
@@ -52,7 +52,7 @@ func NewImportClause(ruleToImport string, fromPolicyFQN *FQN, withs []*WithClaus
 
 func NewWithClause(name string, expr Expression, ssp tokens.Range) *WithClause {
 	return &WithClause{
-		baseNode: &baseNode{
+		NodeBase: &NodeBase{
 			Rnge:  ssp,
 			Kind_: "with",
 		},
@@ -21,7 +21,7 @@ import (
 )
 
 type InfixExpression struct {
-	*baseNode
+	*NodeBase
 	Left     Expression
 	Operator string
 	Right    Expression
@@ -29,7 +29,7 @@ type InfixExpression struct {
 
 func NewInfixExpression(left Expression, right Expression, operator string, ssp tokens.Range) *InfixExpression {
 	return &InfixExpression{
-		baseNode: &baseNode{
+		NodeBase: &NodeBase{
 			Rnge:  ssp,
 			Kind_: "infix",
 		},
@@ -19,18 +19,18 @@ package ast
 import "github.com/sentrie-sh/sentrie/tokens"
 
 type IsDefinedExpression struct {
-	*baseNode
+	*NodeBase
 	Left Expression
 }
 
 type IsEmptyExpression struct {
-	*baseNode
+	*NodeBase
 	Left Expression
 }
 
 func NewIsDefinedExpression(left Expression, ssp tokens.Range) *IsDefinedExpression {
 	return &IsDefinedExpression{
-		baseNode: &baseNode{
+		NodeBase: &NodeBase{
 			Rnge:  ssp,
 			Kind_: "is_defined",
 		},
@@ -40,7 +40,7 @@ func NewIsDefinedExpression(left Expression, ssp tokens.Range) *IsDefinedExpress
 
 func NewIsEmptyExpression(left Expression, ssp tokens.Range) *IsEmptyExpression {
 	return &IsEmptyExpression{
-		baseNode: &baseNode{
+		NodeBase: &NodeBase{
 			Rnge:  ssp,
 			Kind_: "is_empty",
 		},
@@ -24,14 +24,14 @@ import (
 
 // LambdaExpression is an inline block-bodied lambda: (a, b) => { yield ... }
 type LambdaExpression struct {
-	*baseNode
+	*NodeBase
 	Params []string
 	Body   *BlockExpression
 }
 
 func NewLambdaExpression(params []string, body *BlockExpression, ssp tokens.Range) *LambdaExpression {
 	return &LambdaExpression{
-		baseNode: &baseNode{
+		NodeBase: &NodeBase{
 			Rnge:  ssp,
 			Kind_: "lambda",
 		},
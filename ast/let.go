@@ -23,7 +23,7 @@ import (
 )
 
 type VarDeclaration struct {
-	*baseNode
+	*NodeBase
 	Name  string
 	Type  TypeRef
 	Value Expression
@@ -31,7 +31,7 @@ type VarDeclaration struct {
 
 func NewVarDeclaration(name string, typeRef TypeRef, value Expression, ssp tokens.Range) *VarDeclaration {
 	return &VarDeclaration{
-		baseNode: &baseNode{
+		NodeBase: &NodeBase{
 			Rnge:  ssp,
 			Kind_: "let",
 		},
@@ -24,13 +24,13 @@ import (
 
 // FloatLiteral represents a float literal
 type FloatLiteral struct {
-	*baseNode
+	*NodeBase
 	Value float64
 }
 
 func NewFloatLiteral(value float64, ssp tokens.Range) *FloatLiteral {
 	return &FloatLiteral{
-		baseNode: &baseNode{
+		NodeBase: &NodeBase{
 			Rnge:  ssp,
 			Kind_: "float_literal",
 		},
@@ -24,14 +24,14 @@ import (
 
 // IntegerLiteral represents an integer literal
 type IntegerLiteral struct {
-	*baseNode
+	*NodeBase
 	// under the hood, all values are floats
 	Value float64
 }
 
 func NewIntegerLiteral(value int64, ssp tokens.Range) *IntegerLiteral {
 	return &IntegerLiteral{
-		baseNode: &baseNode{
+		NodeBase: &NodeBase{
 			Rnge:  ssp,
 			Kind_: "integer_literal",
 		},
@@ -0,0 +1,47 @@
+// SPDX-FileCopyrightText: © 2026 Binaek Sarkar <binaek89@gmail.com>
+// SPDX-License-Identifier: Apache-2.0
+
+package ast
+
+import (
+	"strings"
+
+	"github.com/sentrie-sh/sentrie/tokens"
+)
+
+// InterpolatedString is a double-quoted string with "${ ... }" interpolations, such as
+// "user ${user.name} is allowed". Parts holds, in order, a *StringLiteral for every run of text
+// and the expression of every interpolation. Its value is the concatenation of the parts.
+type InterpolatedString struct {
+	*NodeBase
+	Parts []Expression
+}
+
+func NewInterpolatedString(parts []Expression, ssp tokens.Range) *InterpolatedString {
+	return &InterpolatedString{
+		NodeBase: &NodeBase{
+			Rnge:  ssp,
+			Kind_: "interpolated_string",
+		},
+		Parts: parts,
+	}
+}
+
+func (s *InterpolatedString) String() string {
+	var sb strings.Builder
+	sb.WriteByte('"')
+	for _, part := range s.Parts {
+		if lit, ok := part.(*StringLiteral); ok {
+			sb.WriteString(strings.ReplaceAll(lit.Value, "${", `\${`))
+			continue
+		}
+		sb.WriteString("${" + part.String() + "}")
+	}
+	sb.WriteByte('"')
+	return sb.String()
+}
+
+func (s *InterpolatedString) expressionNode() {}
+
+var _ Expression = &InterpolatedString{}
+var _ Node = &InterpolatedString{}
@@ -23,13 +23,13 @@ import (
 )
 
 type ListLiteral struct {
-	*baseNode
+	*NodeBase
 	Values []Expression
 }
 
 func NewListLiteral(values []Expression, ssp tokens.Range) *ListLiteral {
 	return &ListLiteral{
-		baseNode: &baseNode{
+		NodeBase: &NodeBase{
 			Rnge:  ssp,
 			Kind_: "list_literal",
 		},
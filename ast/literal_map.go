@@ -29,13 +29,13 @@ type MapEntry struct {
 }
 
 type MapLiteral struct {
-	*baseNode
+	*NodeBase
 	Entries []MapEntry
 }
 
 func NewMapLiteral(entries []MapEntry, ssp tokens.Range) *MapLiteral {
 	return &MapLiteral{
-		baseNode: &baseNode{
+		NodeBase: &NodeBase{
 			Rnge:  ssp,
 			Kind_: "map_literal",
 		},
@@ -19,12 +19,12 @@ package ast
 import "github.com/sentrie-sh/sentrie/tokens"
 
 type NullLiteral struct {
-	*baseNode
+	*NodeBase
 }
 
 func NewNullLiteral(ssp tokens.Range) *NullLiteral {
 	return &NullLiteral{
-		baseNode: &baseNode{
+		NodeBase: &NodeBase{
 			Rnge:  ssp,
 			Kind_: "null_literal",
 		},
@@ -24,13 +24,13 @@ import (
 
 // StringLiteral represents a string literal
 type StringLiteral struct {
-	*baseNode
+	*NodeBase
 	Value string
 }
 
 func NewStringLiteral(value string, ssp tokens.Range) Expression {
 	return &StringLiteral{
-		baseNode: &baseNode{
+		NodeBase: &NodeBase{
 			Rnge:  ssp,
 			Kind_: "string_literal",
 		},
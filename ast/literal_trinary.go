@@ -23,13 +23,13 @@ import (
 
 // TrinaryLiteral represents a trinary literal
 type TrinaryLiteral struct {
-	*baseNode
+	*NodeBase
 	Value trinary.Value
 }
 
 func NewTrinaryLiteral(value trinary.Value, ssp tokens.Range) *TrinaryLiteral {
 	return &TrinaryLiteral{
-		baseNode: &baseNode{
+		NodeBase: &NodeBase{
 			Rnge:  ssp,
 			Kind_: "trinary_literal",
 		},
@@ -0,0 +1,58 @@
+// SPDX-FileCopyrightText: © 2026 Binaek Sarkar <binaek89@gmail.com>
+// SPDX-License-Identifier: Apache-2.0
+
+package ast
+
+import (
+	"strings"
+
+	"github.com/sentrie-sh/sentrie/tokens"
+)
+
+// MatchExpression picks the result of the first arm whose condition is true:
+//
+//	match {
+//	  when age >= 65 then "senior"
+//	  when age >= 18 then "adult"
+//	  else "minor"
+//	}
+//
+// Arms are tried top to bottom. A false or unknown condition falls through to the next arm,
+// and Else is the result when no arm is taken.
+type MatchExpression struct {
+	*NodeBase
+	Arms []MatchArm
+	Else Expression
+}
+
+// MatchArm is a `when <condition> then <result>` arm of a match expression.
+type MatchArm struct {
+	Condition Expression
+	Result    Expression
+}
+
+func NewMatchExpression(arms []MatchArm, elseExpr Expression, ssp tokens.Range) *MatchExpression {
+	return &MatchExpression{
+		NodeBase: &NodeBase{
+			Rnge:  ssp,
+			Kind_: "match",
+		},
+		Arms: arms,
+		Else: elseExpr,
+	}
+}
+
+func (m *MatchExpression) String() string {
+	var sb strings.Builder
+	sb.WriteString("match {")
+	for _, arm := range m.Arms {
+		sb.WriteString(" when " + arm.Condition.String() + " then " + arm.Result.String())
+	}
+	sb.WriteString(" else " + m.Else.String() + " }")
+	return sb.String()
+}
+
+func (m *MatchExpression) expressionNode() {}
+
+var _ Expression = &MatchExpression{}
+var _ Node = &MatchExpression{}
@@ -30,16 +30,18 @@ type Node interface {
 	Kind() string
 }
 
-type baseNode struct {
+// NodeBase is embedded by every node. It is exported only so that the position and kind of a
+// node are encoded with it, see gobNodes; nodes are built with their constructors.
+type NodeBase struct {
 	Rnge  tokens.Range
 	Kind_ string
 }
 
-func (n *baseNode) Span() tokens.Range {
+func (n *NodeBase) Span() tokens.Range {
 	return n.Rnge
 }
 
-func (n *baseNode) Kind() string {
+func (n *NodeBase) Kind() string {
 	return n.Kind_
 }
 
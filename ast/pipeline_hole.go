@@ -7,12 +7,12 @@ import "github.com/sentrie-sh/sentrie/tokens"
 
 // PipelineHoleExpression is a placeholder used by pipeline lowering.
 type PipelineHoleExpression struct {
-	*baseNode
+	*NodeBase
 }
 
 func NewPipelineHoleExpression(ssp tokens.Range) *PipelineHoleExpression {
 	return &PipelineHoleExpression{
-		baseNode: &baseNode{
+		NodeBase: &NodeBase{
 			Rnge:  ssp,
 			Kind_: "pipeline_hole",
 		},
@@ -0,0 +1,32 @@
+// SPDX-FileCopyrightText: © 2026 Binaek Sarkar <binaek89@gmail.com>
+// SPDX-License-Identifier: Apache-2.0
+
+package ast
+
+import (
+	"github.com/sentrie-sh/sentrie/tokens"
+)
+
+// CombiningStatement is a policy header line naming how the decisions of the exported rules
+// combine into the decision of the policy: combining "…".
+type CombiningStatement struct {
+	*NodeBase
+	Algorithm string
+}
+
+func NewCombiningStatement(algorithm string, ssp tokens.Range) *CombiningStatement {
+	return &CombiningStatement{
+		NodeBase: &NodeBase{
+			Rnge:  ssp,
+			Kind_: "combining",
+		},
+		Algorithm: algorithm,
+	}
+}
+
+func (s *CombiningStatement) String() string { return "combining " + s.Algorithm }
+
+func (s *CombiningStatement) statementNode() {}
+
+var _ Statement = (*CombiningStatement)(nil)
+var _ Node = (*CombiningStatement)(nil)
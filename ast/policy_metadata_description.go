@@ -22,13 +22,13 @@ import (
 
 // DescriptionStatement is a policy metadata line: description "…".
 type DescriptionStatement struct {
-	*baseNode
+	*NodeBase
 	Value string
 }
 
 func NewDescriptionStatement(value string, ssp tokens.Range) *DescriptionStatement {
 	return &DescriptionStatement{
-		baseNode: &baseNode{
+		NodeBase: &NodeBase{
 			Rnge:  ssp,
 			Kind_: "description",
 		},
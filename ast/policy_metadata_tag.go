@@ -22,14 +22,14 @@ import (
 
 // TagStatement is a policy metadata line: tag "key" = "value".
 type TagStatement struct {
-	*baseNode
+	*NodeBase
 	Key   string
 	Value string
 }
 
 func NewTagStatement(key, value string, ssp tokens.Range) *TagStatement {
 	return &TagStatement{
-		baseNode: &baseNode{
+		NodeBase: &NodeBase{
 			Rnge:  ssp,
 			Kind_: "tag",
 		},
@@ -22,13 +22,13 @@ import (
 
 // TitleStatement is a policy metadata line: title "…".
 type TitleStatement struct {
-	*baseNode
+	*NodeBase
 	Value string
 }
 
 func NewTitleStatement(value string, ssp tokens.Range) *TitleStatement {
 	return &TitleStatement{
-		baseNode: &baseNode{
+		NodeBase: &NodeBase{
 			Rnge:  ssp,
 			Kind_: "title",
 		},
@@ -22,13 +22,13 @@ import (
 
 // VersionStatement is a policy metadata line: version "…" (SemVer validated at index time).
 type VersionStatement struct {
-	*baseNode
+	*NodeBase
 	Literal string
 }
 
 func NewVersionStatement(literal string, ssp tokens.Range) *VersionStatement {
 	return &VersionStatement{
-		baseNode: &baseNode{
+		NodeBase: &NodeBase{
 			Rnge:  ssp,
 			Kind_: "version",
 		},
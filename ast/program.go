@@ -21,13 +21,13 @@ import (
 )
 
 type NamespaceStatement struct {
-	*baseNode
+	*NodeBase
 	Name FQN // Fully Qualified Name (FQN) of the namespace
 }
 
 func NewNamespaceStatement(name FQN, ssp tokens.Range) *NamespaceStatement {
 	return &NamespaceStatement{
-		baseNode: &baseNode{
+		NodeBase: &NodeBase{
 			Rnge:  ssp,
 			Kind_: "namespace",
 		},
@@ -44,14 +44,14 @@ var _ Statement = &NamespaceStatement{}
 var _ Node = &NamespaceStatement{}
 
 type PolicyStatement struct {
-	*baseNode
+	*NodeBase
 	Name       string
 	Statements []Statement
 }
 
 func NewPolicyStatement(name string, statements []Statement, ssp tokens.Range) *PolicyStatement {
 	return &PolicyStatement{
-		baseNode: &baseNode{
+		NodeBase: &NodeBase{
 			Rnge:  ssp,
 			Kind_: "policy",
 		},
@@ -0,0 +1,64 @@
+// SPDX-FileCopyrightText: © 2026 Binaek Sarkar <binaek89@gmail.com>
+// SPDX-License-Identifier: Apache-2.0
+
+package ast
+
+import (
+	"strings"
+
+	"github.com/sentrie-sh/sentrie/tokens"
+)
+
+// ProjectExpression builds a new dict from a source value:
+//
+//	project user as u { id: u.id, name: upper(u.name) }
+//	project user { ...user, password: "<redacted>" }
+//
+// When As is set, the source is bound to that name while the entries are evaluated.
+type ProjectExpression struct {
+	*NodeBase
+	Source  Expression
+	As      string
+	Entries []ProjectEntry
+}
+
+// ProjectEntry is a `key: value` field of a projection, or, when Spread is set, a `...value`
+// whose fields are copied in. Later entries override earlier ones.
+type ProjectEntry struct {
+	Key    string
+	Value  Expression
+	Spread bool
+}
+
+func NewProjectExpression(source Expression, as string, entries []ProjectEntry, ssp tokens.Range) *ProjectExpression {
+	return &ProjectExpression{
+		NodeBase: &NodeBase{
+			Rnge:  ssp,
+			Kind_: "project",
+		},
+		Source:  source,
+		As:      as,
+		Entries: entries,
+	}
+}
+
+func (p *ProjectExpression) String() string {
+	entries := make([]string, 0, len(p.Entries))
+	for _, entry := range p.Entries {
+		if entry.Spread {
+			entries = append(entries, "..."+entry.Value.String())
+		} else {
+			entries = append(entries, entry.Key+": "+entry.Value.String())
+		}
+	}
+	source := p.Source.String()
+	if p.As != "" {
+		source += " as " + p.As
+	}
+	return "project " + source + " {" + strings.Join(entries, ", ") + "}"
+}
+
+func (p *ProjectExpression) expressionNode() {}
+
+var _ Expression = &ProjectExpression{}
+var _ Node = &ProjectExpression{}
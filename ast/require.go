@@ -0,0 +1,38 @@
+// SPDX-FileCopyrightText: © 2026 Binaek Sarkar <binaek89@gmail.com>
+// SPDX-License-Identifier: Apache-2.0
+
+package ast
+
+import (
+	"fmt"
+
+	"github.com/sentrie-sh/sentrie/tokens"
+)
+
+// RequireStatement asserts that a fact conforms to a shape: `require user conforms UserShape`.
+// The check runs when evaluation starts, before any rule.
+type RequireStatement struct {
+	*NodeBase
+	Fact  string
+	Shape *FQN
+}
+
+func NewRequireStatement(fact string, shape *FQN, ssp tokens.Range) *RequireStatement {
+	return &RequireStatement{
+		NodeBase: &NodeBase{
+			Rnge:  ssp,
+			Kind_: "require",
+		},
+		Fact:  fact,
+		Shape: shape,
+	}
+}
+
+func (s *RequireStatement) String() string {
+	return fmt.Sprintf("require %s conforms %s", s.Fact, s.Shape.String())
+}
+
+func (s *RequireStatement) statementNode() {}
+
+var _ Statement = &RequireStatement{}
+var _ Node = &RequireStatement{}
@@ -19,16 +19,39 @@ package ast
 import "github.com/sentrie-sh/sentrie/tokens"
 
 type RuleStatement struct {
-	*baseNode
+	*NodeBase
 	RuleName string
-	Default  Expression
-	When     Expression
-	Body     Expression
+	// Effects are the effect clauses of the rule as written; the index rejects conflicting ones
+	Effects []*EffectClause
+	Default Expression
+	When    Expression
+	Body    Expression
+}
+
+// EffectClause is the 'permit' or 'deny' a rule declares after its '=', naming what the rule
+// deciding true means.
+type EffectClause struct {
+	*NodeBase
+	Effect string
+}
+
+func NewEffectClause(effect string, ssp tokens.Range) *EffectClause {
+	return &EffectClause{
+		NodeBase: &NodeBase{
+			Rnge:  ssp,
+			Kind_: "effect_clause",
+		},
+		Effect: effect,
+	}
+}
+
+func (e EffectClause) String() string {
+	return e.Effect
 }
 
 func NewRuleStatement(ruleName string, defaultExpr Expression, whenExpr Expression, bodyExpr Expression, ssp tokens.Range) *RuleStatement {
 	return &RuleStatement{
-		baseNode: &baseNode{
+		NodeBase: &NodeBase{
 			Rnge:  ssp,
 			Kind_: "rule_statement",
 		},
@@ -46,3 +69,4 @@ func (r RuleStatement) statementNode() {}
 
 var _ Statement = &RuleStatement{}
 var _ Node = &RuleStatement{}
+var _ Node = &EffectClause{}
@@ -19,7 +19,7 @@ package ast
 import "github.com/sentrie-sh/sentrie/tokens"
 
 type ShapeStatement struct {
-	*baseNode
+	*NodeBase
 	Name    string
 	Simple  TypeRef
 	Complex *Cmplx
@@ -30,19 +30,25 @@ type Cmplx struct {
 	With   *FQN
 	Node   Node
 	Fields map[string]*ShapeField
+	// Closed shapes reject values that carry fields they do not declare
+	Closed bool
 }
 
 type ShapeField struct {
 	Range    tokens.Range
 	Name     string
 	Optional bool
+	// Override marks a field that deliberately redefines a field of the composed shape
+	Override bool
 	Type     TypeRef
-	Node     Node
+	// Default supplies the value of an optional field missing from a value of the shape
+	Default Expression
+	Node    Node
 }
 
 func NewShapeStatement(name string, simple TypeRef, complex *Cmplx, ssp tokens.Range) *ShapeStatement {
 	return &ShapeStatement{
-		baseNode: &baseNode{
+		NodeBase: &NodeBase{
 			Rnge:  ssp,
 			Kind_: "shape",
 		},
@@ -23,7 +23,7 @@ import (
 )
 
 type TernaryExpression struct {
-	*baseNode
+	*NodeBase
 	Condition  Expression
 	ThenBranch Expression
 	ElseBranch Expression
@@ -31,7 +31,7 @@ type TernaryExpression struct {
 
 func NewTernaryExpression(condition Expression, thenBranch Expression, elseBranch Expression, ssp tokens.Range) *TernaryExpression {
 	return &TernaryExpression{
-		baseNode: &baseNode{
+		NodeBase: &NodeBase{
 			Rnge:  ssp,
 			Kind_: "ternary",
 		},
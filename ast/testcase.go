@@ -0,0 +1,54 @@
+// SPDX-FileCopyrightText: © 2026 Binaek Sarkar <binaek89@gmail.com>
+// SPDX-License-Identifier: Apache-2.0
+
+package ast
+
+import "github.com/sentrie-sh/sentrie/tokens"
+
+// TestStatement is a test case of a policy in the namespace it is declared in: the facts the
+// policy is evaluated with, and what its rules are expected to decide and attach.
+type TestStatement struct {
+	*NodeBase
+	Name         string
+	Policy       string
+	Facts        []*TestFact
+	Expectations []*TestExpectation
+}
+
+// TestFact is a fact supplied to the policy under test.
+type TestFact struct {
+	Range tokens.Range
+	Name  string
+	Value Expression
+}
+
+// TestExpectation is the expected outcome of a rule or, when Attachment is set, the expected
+// value of one of its attachments.
+type TestExpectation struct {
+	Range      tokens.Range
+	Rule       string
+	Outcome    *TrinaryLiteral
+	Attachment *AttachmentClause
+}
+
+func NewTestStatement(name, policy string, facts []*TestFact, expectations []*TestExpectation, ssp tokens.Range) *TestStatement {
+	return &TestStatement{
+		NodeBase: &NodeBase{
+			Rnge:  ssp,
+			Kind_: "test",
+		},
+		Name:         name,
+		Policy:       policy,
+		Facts:        facts,
+		Expectations: expectations,
+	}
+}
+
+func (t *TestStatement) String() string {
+	return t.Name
+}
+
+func (t *TestStatement) statementNode() {}
+
+var _ Statement = &TestStatement{}
+var _ Node = &TestStatement{}
@@ -19,14 +19,14 @@ package ast
 import "github.com/sentrie-sh/sentrie/tokens"
 
 type TransformExpression struct {
-	*baseNode
+	*NodeBase
 	Argument    Expression
 	Transformer string
 }
 
 func NewTransformExpression(argument Expression, transformer string, ssp tokens.Range) *TransformExpression {
 	return &TransformExpression{
-		baseNode: &baseNode{
+		NodeBase: &NodeBase{
 			Rnge:  ssp,
 			Kind_: "transform",
 		},
@@ -18,6 +18,8 @@ package ast
 
 import (
 	"fmt"
+	"slices"
+	"strings"
 
 	"github.com/sentrie-sh/sentrie/tokens"
 	"github.com/sentrie-sh/sentrie/xerr"
@@ -31,14 +33,14 @@ type TypeRef interface {
 }
 
 type TypeRefConstraint struct {
-	*baseNode
+	*NodeBase
 	Name string
 	Args []Expression
 }
 
 func NewTypeRefConstraint(name string, args []Expression, ssp tokens.Range) *TypeRefConstraint {
 	return &TypeRefConstraint{
-		baseNode: &baseNode{
+		NodeBase: &NodeBase{
 			Rnge:  ssp,
 			Kind_: "typeref_constraint",
 		},
@@ -47,25 +49,34 @@ func NewTypeRefConstraint(name string, args []Expression, ssp tokens.Range) *Typ
 	}
 }
 
-type baseTypeRef struct {
-	*baseNode
-	constraints      []*TypeRefConstraint
+// TypeRefBase is embedded by every type reference. It is exported, as NodeBase is, so that the
+// constraints of a type reference are encoded with it; the constraints it accepts are not, as
+// they are only needed while parsing.
+type TypeRefBase struct {
+	*NodeBase
+	Constraints      []*TypeRefConstraint
 	validConstraints map[string]int
 }
 
-func (b *baseTypeRef) typeref() {}
+func (b *TypeRefBase) typeref() {}
 
-func (b *baseTypeRef) AddConstraint(constraint *TypeRefConstraint) error {
+func (b *TypeRefBase) AddConstraint(constraint *TypeRefConstraint) error {
 	if err := validateConstraint(constraint, b.validConstraints); err != nil {
 		return err
 	}
-	b.constraints = append(b.constraints, constraint)
+	if err := validateEnumConstraintArgs(constraint, b.Kind_); err != nil {
+		return err
+	}
+	if err := validateRegexpConstraintArgs(constraint); err != nil {
+		return err
+	}
+	b.Constraints = append(b.Constraints, constraint)
 	b.Rnge.To = constraint.Rnge.To
 	return nil
 }
 
-func (b *baseTypeRef) GetConstraints() []*TypeRefConstraint {
-	return b.constraints
+func (b *TypeRefBase) GetConstraints() []*TypeRefConstraint {
+	return b.Constraints
 }
 
 // validateConstraint checks if a constraint is valid for the given type
@@ -84,3 +95,53 @@ func validateConstraint(constraint *TypeRefConstraint, constraints map[string]in
 	}
 	return nil
 }
+
+// enumConstraintArgKinds lists, per typeref kind, the literal kinds accepted by the
+// enum-style constraints (one_of / not_one_of)
+var enumConstraintArgKinds = map[string][]string{
+	"string_typeref":  {"string_literal"},
+	"number_typeref":  {"integer_literal", "float_literal"},
+	"trinary_typeref": {"trinary_literal"},
+}
+
+// validateEnumConstraintArgs rejects enum-style constraints whose arguments are not all
+// literals of the kind matching the typeref they are attached to
+func validateEnumConstraintArgs(constraint *TypeRefConstraint, typeRefKind string) error {
+	if constraint.Name != "one_of" && constraint.Name != "not_one_of" {
+		return nil
+	}
+	allowed, ok := enumConstraintArgKinds[typeRefKind]
+	if !ok {
+		return nil
+	}
+	for i, arg := range constraint.Args {
+		if !slices.Contains(allowed, arg.Kind()) {
+			return fmt.Errorf("constraint %s on %s expects %s arguments, got %s at position %d", constraint.Name, strings.TrimSuffix(typeRefKind, "_typeref"), strings.Join(allowed, " or "), arg.Kind(), i+1)
+		}
+	}
+	return nil
+}
+
+// validateRegexpConstraintArgs checks the regexp constraint takes a pattern and, optionally,
+// a string literal of flags drawn from genRegexpFlags
+func validateRegexpConstraintArgs(constraint *TypeRefConstraint) error {
+	if constraint.Name != "regexp" {
+		return nil
+	}
+	if len(constraint.Args) > 2 {
+		return fmt.Errorf("constraint regexp accepts a pattern and optional flags, got %d arguments", len(constraint.Args))
+	}
+	if len(constraint.Args) < 2 {
+		return nil
+	}
+	flags, ok := constraint.Args[1].(*StringLiteral)
+	if !ok {
+		return fmt.Errorf("constraint regexp expects flags as a string literal, got %s", constraint.Args[1].Kind())
+	}
+	for _, f := range flags.Value {
+		if !strings.ContainsRune(genRegexpFlags, f) {
+			return fmt.Errorf("unknown regexp flag %q: supported flags are %q", f, genRegexpFlags)
+		}
+	}
+	return nil
+}
@@ -27,6 +27,7 @@ var genNumberConstraints = map[string]int{
 	"non_positive": 0,
 	"not_in":       1,
 	"odd":          0,
+	"one_of":       -1,
 	"positive":     0,
 	"range":        2,
 }
@@ -34,9 +35,12 @@ var genNumberConstraints = map[string]int{
 var genStringConstraints = map[string]int{
 	"alpha":             0,
 	"alphanumeric":      0,
+	"cidr":              0,
 	"email":             0,
 	"ends_with":         1,
 	"has_substring":     1,
+	"ipv4":              0,
+	"ipv6":              0,
 	"length":            1,
 	"lowercase":         0,
 	"maxlength":         1,
@@ -46,7 +50,8 @@ var genStringConstraints = map[string]int{
 	"not_one_of":        -1,
 	"numeric":           0,
 	"one_of":            -1,
-	"regexp":            1,
+	"regexp":            -1,
+	"semver":            0,
 	"starts_with":       1,
 	"trimmed":           0,
 	"uppercase":         0,
@@ -60,10 +65,20 @@ var genTrinaryConstraints = map[string]int{
 	"is_true":     0,
 	"neq":         1,
 	"not_unknown": 0,
+	"one_of":      -1,
+}
+
+var genDateConstraints = map[string]int{
+	"after":  1,
+	"before": 1,
 }
 
 var genListConstraints = map[string]int{
+	"length":    1,
+	"maxlength": 1,
+	"minlength": 1,
 	"not_empty": 0,
+	"unique":    0,
 }
 
 var genDictConstraints = map[string]int{}
@@ -73,3 +88,6 @@ var genDocumentConstraints = map[string]int{}
 var genRecordConstraints = map[string]int{}
 
 var genShapeConstraints = map[string]int{}
+
+// genRegexpFlags are the flag letters accepted by the regexp constraint
+const genRegexpFlags = "ims"
@@ -0,0 +1,27 @@
+// SPDX-FileCopyrightText: © 2026 Binaek Sarkar <binaek89@gmail.com>
+// SPDX-License-Identifier: Apache-2.0
+
+package ast
+
+import "github.com/sentrie-sh/sentrie/tokens"
+
+type DateTypeRef struct {
+	*TypeRefBase
+}
+
+func NewDateTypeRef(ssp tokens.Range) *DateTypeRef {
+	return &DateTypeRef{
+		TypeRefBase: &TypeRefBase{
+			NodeBase: &NodeBase{
+				Rnge:  ssp,
+				Kind_: "date_typeref",
+			},
+			validConstraints: genDateConstraints,
+		},
+	}
+}
+
+var _ TypeRef = &DateTypeRef{}
+var _ Node = &DateTypeRef{}
+
+func (b *DateTypeRef) String() string { return "date" }
@@ -19,14 +19,14 @@ package ast
 import "github.com/sentrie-sh/sentrie/tokens"
 
 type DictTypeRef struct {
-	*baseTypeRef
+	*TypeRefBase
 	ValueType TypeRef
 }
 
 func NewDictTypeRef(valueType TypeRef, ssp tokens.Range) *DictTypeRef {
 	return &DictTypeRef{
-		baseTypeRef: &baseTypeRef{
-			baseNode: &baseNode{
+		TypeRefBase: &TypeRefBase{
+			NodeBase: &NodeBase{
 				Rnge:  ssp,
 				Kind_: "dict_typeref",
 			},
@@ -19,7 +19,7 @@ package ast
 import "github.com/sentrie-sh/sentrie/tokens"
 
 type DocumentTypeRef struct {
-	*baseTypeRef
+	*TypeRefBase
 }
 
 var _ TypeRef = &DocumentTypeRef{}
@@ -27,8 +27,8 @@ var _ Node = &DocumentTypeRef{}
 
 func NewDocumentTypeRef(ssp tokens.Range) *DocumentTypeRef {
 	return &DocumentTypeRef{
-		baseTypeRef: &baseTypeRef{
-			baseNode: &baseNode{
+		TypeRefBase: &TypeRefBase{
+			NodeBase: &NodeBase{
 				Rnge:  ssp,
 				Kind_: "document_typeref",
 			},
@@ -19,7 +19,7 @@ package ast
 import "github.com/sentrie-sh/sentrie/tokens"
 
 type ListTypeRef struct {
-	*baseTypeRef
+	*TypeRefBase
 	ElemType TypeRef
 }
 
@@ -29,8 +29,8 @@ var _ Node = &ListTypeRef{}
 func (l *ListTypeRef) String() string { return "list[" + l.ElemType.String() + "]" }
 func NewListTypeRef(elemType TypeRef, ssp tokens.Range) *ListTypeRef {
 	return &ListTypeRef{
-		baseTypeRef: &baseTypeRef{
-			baseNode: &baseNode{
+		TypeRefBase: &TypeRefBase{
+			NodeBase: &NodeBase{
 				Rnge:  ssp,
 				Kind_: "list_typeref",
 			},
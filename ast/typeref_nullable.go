@@ -6,14 +6,14 @@ package ast
 import "github.com/sentrie-sh/sentrie/tokens"
 
 type NullableTypeRef struct {
-	*baseTypeRef
+	*TypeRefBase
 	Inner TypeRef
 }
 
 func NewNullableTypeRef(inner TypeRef, ssp tokens.Range) *NullableTypeRef {
 	return &NullableTypeRef{
-		baseTypeRef: &baseTypeRef{
-			baseNode: &baseNode{
+		TypeRefBase: &TypeRefBase{
+			NodeBase: &NodeBase{
 				Rnge:  ssp,
 				Kind_: "nullable_typeref",
 			},
@@ -19,13 +19,13 @@ package ast
 import "github.com/sentrie-sh/sentrie/tokens"
 
 type NumberTypeRef struct {
-	*baseTypeRef
+	*TypeRefBase
 }
 
 func NewNumberTypeRef(ssp tokens.Range) *NumberTypeRef {
 	return &NumberTypeRef{
-		baseTypeRef: &baseTypeRef{
-			baseNode: &baseNode{
+		TypeRefBase: &TypeRefBase{
+			NodeBase: &NodeBase{
 				Rnge:  ssp,
 				Kind_: "number_typeref",
 			},
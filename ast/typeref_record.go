@@ -24,14 +24,14 @@ import (
 )
 
 type RecordTypeRef struct {
-	*baseTypeRef
+	*TypeRefBase
 	Fields []TypeRef
 }
 
 func NewRecordTypeRef(fields []TypeRef, ssp tokens.Range) *RecordTypeRef {
 	return &RecordTypeRef{
-		baseTypeRef: &baseTypeRef{
-			baseNode: &baseNode{
+		TypeRefBase: &TypeRefBase{
+			NodeBase: &NodeBase{
 				Rnge:  ssp,
 				Kind_: "record_typeref",
 			},
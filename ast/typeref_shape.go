@@ -19,14 +19,14 @@ package ast
 import "github.com/sentrie-sh/sentrie/tokens"
 
 type ShapeTypeRef struct {
-	*baseTypeRef
+	*TypeRefBase
 	Ref *FQN // Fully Qualified Name (FQN) of the shape
 }
 
 func NewShapeTypeRef(ref *FQN, ssp tokens.Range) *ShapeTypeRef {
 	return &ShapeTypeRef{
-		baseTypeRef: &baseTypeRef{
-			baseNode: &baseNode{
+		TypeRefBase: &TypeRefBase{
+			NodeBase: &NodeBase{
 				Rnge:  ssp,
 				Kind_: "shape_typeref",
 			},
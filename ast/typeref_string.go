@@ -19,7 +19,7 @@ package ast
 import "github.com/sentrie-sh/sentrie/tokens"
 
 type StringTypeRef struct {
-	*baseTypeRef
+	*TypeRefBase
 }
 
 var _ TypeRef = &StringTypeRef{}
@@ -27,8 +27,8 @@ var _ Node = &StringTypeRef{}
 
 func NewStringTypeRef(ssp tokens.Range) *StringTypeRef {
 	return &StringTypeRef{
-		baseTypeRef: &baseTypeRef{
-			baseNode: &baseNode{
+		TypeRefBase: &TypeRefBase{
+			NodeBase: &NodeBase{
 				Rnge:  ssp,
 				Kind_: "string_typeref",
 			},
@@ -19,13 +19,13 @@ package ast
 import "github.com/sentrie-sh/sentrie/tokens"
 
 type TrinaryTypeRef struct {
-	*baseTypeRef
+	*TypeRefBase
 }
 
 func NewTrinaryTypeRef(ssp tokens.Range) *TrinaryTypeRef {
 	return &TrinaryTypeRef{
-		baseTypeRef: &baseTypeRef{
-			baseNode: &baseNode{
+		TypeRefBase: &TypeRefBase{
+			NodeBase: &NodeBase{
 				Rnge:  ssp,
 				Kind_: "trinary_typeref",
 			},
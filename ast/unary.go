@@ -19,14 +19,14 @@ package ast
 import "github.com/sentrie-sh/sentrie/tokens"
 
 type UnaryExpression struct {
-	*baseNode
+	*NodeBase
 	Operator string
 	Right    Expression
 }
 
 func NewUnaryExpression(operator string, right Expression, ssp tokens.Range) *UnaryExpression {
 	return &UnaryExpression{
-		baseNode: &baseNode{
+		NodeBase: &NodeBase{
 			Rnge:  ssp,
 			Kind_: "unary",
 		},
@@ -24,16 +24,19 @@ import (
 )
 
 type UseStatement struct {
-	*baseNode
+	*NodeBase
 	Modules      []string // List of modules to use
 	RelativeFrom string   //
 	LibFrom      []string // Optional library information
 	As           string
+	// ShapeFrom is the namespace of the shape imported by a shape import, which names the shape
+	// as its only module. It is nil for a module import.
+	ShapeFrom *FQN
 }
 
 func NewUseStatement(modules []string, relativeFrom string, libFrom []string, as string, ssp tokens.Range) *UseStatement {
 	return &UseStatement{
-		baseNode: &baseNode{
+		NodeBase: &NodeBase{
 			Rnge:  ssp,
 			Kind_: "use",
 		},
@@ -44,7 +47,28 @@ func NewUseStatement(modules []string, relativeFrom string, libFrom []string, as
 	}
 }
 
+// NewShapeUseStatement creates the import of the exported shape of namespace from under alias as.
+func NewShapeUseStatement(shape string, from *FQN, as string, ssp tokens.Range) *UseStatement {
+	return &UseStatement{
+		NodeBase: &NodeBase{
+			Rnge:  ssp,
+			Kind_: "use",
+		},
+		Modules:   []string{shape},
+		As:        as,
+		ShapeFrom: from,
+	}
+}
+
+// IsShapeImport reports whether the statement imports a shape rather than a module.
+func (s *UseStatement) IsShapeImport() bool {
+	return s.ShapeFrom != nil
+}
+
 func (s *UseStatement) String() string {
+	if s.IsShapeImport() {
+		return fmt.Sprintf("use %s from %s as %s", s.Modules[0], s.ShapeFrom, s.As)
+	}
 	from := s.RelativeFrom
 	if len(s.LibFrom) > 0 {
 		from = "@" + strings.Join(s.LibFrom, "/")
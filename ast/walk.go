@@ -0,0 +1,169 @@
+// SPDX-FileCopyrightText: © 2026 Binaek Sarkar <binaek89@gmail.com>
+// SPDX-License-Identifier: Apache-2.0
+
+package ast
+
+import "reflect"
+
+// Inspect traverses the tree rooted at node in depth-first order. It calls fn for each
+// node; if fn returns false, the children of that node are not visited.
+func Inspect(node Node, fn func(Node) bool) {
+	if isNilNode(node) || !fn(node) {
+		return
+	}
+
+	for _, child := range children(node) {
+		Inspect(child, fn)
+	}
+}
+
+// children returns the direct child nodes of node, in source order
+func children(node Node) []Node {
+	switch n := node.(type) {
+	case *PolicyStatement:
+		return statementNodes(n.Statements)
+	case *FactStatement:
+		return []Node{n.Type, n.Default}
+	case *VarDeclaration:
+		return []Node{n.Type, n.Value}
+	case *DeriveStatement:
+		return []Node{n.Body}
+	case *RuleStatement:
+		return []Node{n.Default, n.When, n.Body}
+	case *RuleExportStatement:
+		nodes := make([]Node, 0, len(n.Attachments))
+		for _, a := range n.Attachments {
+			nodes = append(nodes, a)
+		}
+		return nodes
+	case *AttachmentClause:
+		return []Node{n.As}
+	case *ShapeStatement:
+		nodes := []Node{n.Simple}
+		if n.Complex != nil {
+			for _, field := range n.Complex.Fields {
+				nodes = append(nodes, field.Type)
+				if field.Default != nil {
+					nodes = append(nodes, field.Default)
+				}
+			}
+		}
+		return nodes
+	case *TestStatement:
+		nodes := make([]Node, 0, len(n.Facts)+len(n.Expectations))
+		for _, fact := range n.Facts {
+			nodes = append(nodes, fact.Value)
+		}
+		for _, expectation := range n.Expectations {
+			if expectation.Attachment != nil {
+				nodes = append(nodes, expectation.Attachment)
+			} else {
+				nodes = append(nodes, expectation.Outcome)
+			}
+		}
+		return nodes
+	case *BlockExpression:
+		nodes := statementNodes(n.Statements)
+		return append(nodes, n.Yield)
+	case *LambdaExpression:
+		if n.Body == nil {
+			return nil
+		}
+		return []Node{n.Body}
+	case *CallExpression:
+		nodes := []Node{n.Callee}
+		return append(nodes, expressionNodes(n.Arguments)...)
+	case *InfixExpression:
+		return []Node{n.Left, n.Right}
+	case *UnaryExpression:
+		return []Node{n.Right}
+	case *TernaryExpression:
+		return []Node{n.Condition, n.ThenBranch, n.ElseBranch}
+	case *MatchExpression:
+		nodes := make([]Node, 0, 2*len(n.Arms)+1)
+		for _, arm := range n.Arms {
+			nodes = append(nodes, arm.Condition, arm.Result)
+		}
+		return append(nodes, n.Else)
+	case *FieldAccessExpression:
+		return []Node{n.Left}
+	case *IndexAccessExpression:
+		return []Node{n.Left, n.Index}
+	case *SliceExpression:
+		return []Node{n.Left, n.Start, n.End}
+	case *ListLiteral:
+		return expressionNodes(n.Values)
+	case *MapLiteral:
+		nodes := make([]Node, 0, 2*len(n.Entries))
+		for _, e := range n.Entries {
+			nodes = append(nodes, e.Key, e.Value)
+		}
+		return nodes
+	case *CastExpression:
+		return []Node{n.Expr, n.TargetType}
+	case *IsDefinedExpression:
+		return []Node{n.Left}
+	case *IsEmptyExpression:
+		return []Node{n.Left}
+	case *TransformExpression:
+		return []Node{n.Argument}
+	case *InterpolatedString:
+		return expressionNodes(n.Parts)
+	case *ProjectExpression:
+		nodes := []Node{n.Source}
+		for _, e := range n.Entries {
+			nodes = append(nodes, e.Value)
+		}
+		return nodes
+	case *ImportClause:
+		nodes := make([]Node, 0, len(n.Withs))
+		for _, w := range n.Withs {
+			nodes = append(nodes, w)
+		}
+		return nodes
+	case *WithClause:
+		return []Node{n.Expr}
+	case *TrailingCommentExpression:
+		return []Node{n.Wrap}
+	case *PrecedingCommentExpression:
+		return []Node{n.Wrap}
+	case *NullableTypeRef:
+		return []Node{n.Inner}
+	case *ListTypeRef:
+		return []Node{n.ElemType}
+	case *DictTypeRef:
+		return []Node{n.ValueType}
+	case *RecordTypeRef:
+		nodes := make([]Node, 0, len(n.Fields))
+		for _, f := range n.Fields {
+			nodes = append(nodes, f)
+		}
+		return nodes
+	}
+	return nil
+}
+
+func statementNodes(stmts []Statement) []Node {
+	nodes := make([]Node, 0, len(stmts))
+	for _, s := range stmts {
+		nodes = append(nodes, s)
+	}
+	return nodes
+}
+
+func expressionNodes(exprs []Expression) []Node {
+	nodes := make([]Node, 0, len(exprs))
+	for _, e := range exprs {
+		nodes = append(nodes, e)
+	}
+	return nodes
+}
+
+// isNilNode reports whether node is nil, including typed nil pointers held in the interface
+func isNilNode(node Node) bool {
+	if node == nil {
+		return true
+	}
+	v := reflect.ValueOf(node)
+	return v.Kind() == reflect.Pointer && v.IsNil()
+}
@@ -0,0 +1,48 @@
+// SPDX-FileCopyrightText: © 2026 Binaek Sarkar <binaek89@gmail.com>
+// SPDX-License-Identifier: Apache-2.0
+
+package ast
+
+import "github.com/sentrie-sh/sentrie/tokens"
+
+func (s *AstTestSuite) TestInspectVisitsNestedIdentifiers() {
+	r := tokens.Range{File: "test.sentra"}
+	lambda := NewLambdaExpression([]string{"x"}, NewBlockExpression(nil, NewIdentifier("x", r), r), r)
+	expr := NewTernaryExpression(
+		NewInfixExpression(NewIdentifier("a", r), NewFieldAccessExpression(NewIdentifier("b", r), "c", r), "and", r),
+		NewCallExpression(NewIdentifier("filter", r), []Expression{NewListLiteral([]Expression{NewIdentifier("d", r)}, r), lambda}, false, nil, r),
+		nil,
+		r,
+	)
+
+	var seen []string
+	Inspect(expr, func(n Node) bool {
+		if id, ok := n.(*Identifier); ok {
+			seen = append(seen, id.Value)
+		}
+		return true
+	})
+	s.Equal([]string{"a", "b", "filter", "d", "x"}, seen)
+}
+
+func (s *AstTestSuite) TestInspectSkipsChildrenWhenFnReturnsFalse() {
+	r := tokens.Range{File: "test.sentra"}
+	expr := NewInfixExpression(NewIdentifier("a", r), NewUnaryExpression("not", NewIdentifier("b", r), r), "or", r)
+
+	var seen []string
+	Inspect(expr, func(n Node) bool {
+		if id, ok := n.(*Identifier); ok {
+			seen = append(seen, id.Value)
+		}
+		_, isUnary := n.(*UnaryExpression)
+		return !isUnary
+	})
+	s.Equal([]string{"a"}, seen)
+}
+
+func (s *AstTestSuite) TestInspectToleratesNilNodes() {
+	s.NotPanics(func() {
+		Inspect(nil, func(Node) bool { return true })
+		Inspect(NewLambdaExpression(nil, nil, tokens.Range{}), func(Node) bool { return true })
+	})
+}
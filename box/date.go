@@ -0,0 +1,47 @@
+// SPDX-FileCopyrightText: © 2026 Binaek Sarkar <binaek89@gmail.com>
+// SPDX-License-Identifier: Apache-2.0
+
+package box
+
+import "time"
+
+// dateLayouts are the ISO-8601 forms accepted for dates, tried in order. Layouts without a
+// zone are read as UTC.
+var dateLayouts = []string{
+	time.RFC3339Nano,
+	"2006-01-02T15:04:05",
+	time.DateOnly,
+}
+
+// ParseDate parses an RFC3339 / ISO-8601 date or timestamp.
+func ParseDate(s string) (time.Time, bool) {
+	for _, layout := range dateLayouts {
+		if t, err := time.Parse(layout, s); err == nil {
+			return t, true
+		}
+	}
+	return time.Time{}, false
+}
+
+// DateValue returns the value as a time if it is a string holding an ISO-8601 date.
+func (v Value) DateValue() (time.Time, bool) {
+	s, ok := v.StringValue()
+	if !ok {
+		return time.Time{}, false
+	}
+	return ParseDate(s)
+}
+
+// CompareDates orders two values holding ISO-8601 dates, returning -1, 0 or +1.
+// The boolean is false unless both operands are dates.
+func CompareDates(lhs, rhs Value) (int, bool) {
+	l, ok := lhs.DateValue()
+	if !ok {
+		return 0, false
+	}
+	r, ok := rhs.DateValue()
+	if !ok {
+		return 0, false
+	}
+	return l.Compare(r), true
+}
@@ -0,0 +1,44 @@
+// SPDX-FileCopyrightText: © 2026 Binaek Sarkar <binaek89@gmail.com>
+// SPDX-License-Identifier: Apache-2.0
+
+package box
+
+func (s *BoxTestSuite) TestParseDate() {
+	s.Run("accepts RFC3339 with offsets and fractions", func() {
+		a, ok := ParseDate("2024-05-01T10:00:00+02:00")
+		s.Require().True(ok)
+		b, ok := ParseDate("2024-05-01T08:00:00.000Z")
+		s.Require().True(ok)
+		s.True(a.Equal(b))
+	})
+	s.Run("accepts a zoneless timestamp and a bare date as UTC", func() {
+		a, ok := ParseDate("2024-05-01T00:00:00")
+		s.Require().True(ok)
+		b, ok := ParseDate("2024-05-01")
+		s.Require().True(ok)
+		s.True(a.Equal(b))
+	})
+	s.Run("rejects other strings", func() {
+		for _, in := range []string{"", "yesterday", "2024-13-01", "01/05/2024"} {
+			_, ok := ParseDate(in)
+			s.False(ok, in)
+		}
+	})
+}
+
+func (s *BoxTestSuite) TestCompareDates() {
+	s.Run("orders two dates", func() {
+		c, ok := CompareDates(String("2020-01-01"), String("2020-01-01T00:00:01Z"))
+		s.Require().True(ok)
+		s.Equal(-1, c)
+		c, ok = CompareDates(String("2020-01-01T02:00:00+02:00"), String("2020-01-01"))
+		s.Require().True(ok)
+		s.Equal(0, c)
+	})
+	s.Run("needs both operands to be dates", func() {
+		_, ok := CompareDates(String("2020-01-01"), String("soon"))
+		s.False(ok)
+		_, ok = CompareDates(Number(1), String("2020-01-01"))
+		s.False(ok)
+	})
+}
@@ -35,6 +35,36 @@ func MustNumbers(lhs, rhs Value) (float64, float64, error) {
 	return l, r, nil
 }
 
+// ScalarKey returns a stable key identifying a scalar value, for deduplication. Two scalars
+// are duplicates when their keys are equal: numbers are keyed by value, so -0 and 0 share a
+// key, and all NaNs share one too; callers for which NaN equals nothing check for it first.
+// Lists and dicts have no key.
+func ScalarKey(v Value) (string, bool) {
+	switch v.Kind() {
+	case ValueUndefined:
+		return "undef:", true
+	case ValueNull:
+		return "null:", true
+	case ValueBool:
+		b, _ := v.BoolValue()
+		return fmt.Sprintf("bool:%v", b), true
+	case ValueNumber:
+		n, _ := v.NumberValue()
+		if n == 0 {
+			n = 0 // -0 and 0 share a key
+		}
+		return fmt.Sprintf("num:%.17g", n), true
+	case ValueString:
+		s, _ := v.StringValue()
+		return "str:" + s, true
+	case ValueTrinary:
+		t, _ := v.TrinaryValue()
+		return fmt.Sprintf("tri:%d", t), true
+	default:
+		return "", false
+	}
+}
+
 // EqualValues compares two boxed values for semantic equality (including cross-kind number equality).
 func EqualValues(a, b Value) bool {
 	if a.Kind() != b.Kind() {
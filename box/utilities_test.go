@@ -16,6 +16,8 @@
 
 package box
 
+import "math"
+
 func (s *BoxTestSuite) TestMustNumbers() {
 	s.Run("returns both numbers when operands are numeric", func() {
 		lhs, rhs, err := MustNumbers(Number(12), Number(2.5))
@@ -37,6 +39,32 @@ func (s *BoxTestSuite) TestMustNumbers() {
 	})
 }
 
+func (s *BoxTestSuite) TestScalarKey() {
+	s.Run("keys scalars by kind and value", func() {
+		num, ok := ScalarKey(Number(1))
+		s.Require().True(ok)
+		str, ok := ScalarKey(String("1"))
+		s.Require().True(ok)
+		s.NotEqual(num, str)
+	})
+	s.Run("all NaNs share a key", func() {
+		a, _ := ScalarKey(Number(math.NaN()))
+		b, _ := ScalarKey(Number(math.NaN()))
+		s.Equal(a, b)
+	})
+	s.Run("negative zero shares the key of zero", func() {
+		a, _ := ScalarKey(Number(math.Copysign(0, -1)))
+		b, _ := ScalarKey(Number(0))
+		s.Equal(a, b)
+	})
+	s.Run("lists and dicts have no key", func() {
+		_, ok := ScalarKey(List(nil))
+		s.False(ok)
+		_, ok = ScalarKey(FromAny(map[string]any{}))
+		s.False(ok)
+	})
+}
+
 func (s *BoxTestSuite) TestEqualValues() {
 	s.Run("supports cross kind numeric equality only for numbers", func() {
 		s.True(EqualValues(Number(42), Number(42.0)))
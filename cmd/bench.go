@@ -0,0 +1,234 @@
+// SPDX-FileCopyrightText: © 2026 Binaek Sarkar <binaek89@gmail.com>
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/binaek/cling"
+	"github.com/sentrie-sh/sentrie/runtime"
+)
+
+func addBenchCmd(cli *cling.CLI) {
+	cli.WithCommand(
+		withIndexSourceFlags(cling.NewCommand("bench", benchCmd).
+			WithFlag(cling.
+				NewStringCmdInput("policy").
+				WithDescription("Policy or rule to evaluate, e.g. com/example/auth or com/example/auth/allow").
+				Required().
+				AsFlag(),
+			).
+			WithFlag(cling.
+				NewStringCmdInput("pack-location").
+				WithDefault(".").
+				WithDescription("Pack directory to load").
+				AsFlag(),
+			).
+			WithFlag(cling.
+				NewStringCmdInput("fact-file").
+				WithDefault("").
+				WithDescription("File to load facts from").
+				AsFlag(),
+			).
+			WithFlag(cling.
+				NewStringCmdInput("facts").
+				WithDefault("{}").
+				WithDescription("Facts to evaluate with").
+				AsFlag(),
+			).
+			WithFlag(cling.
+				NewStringCmdInput("duration").
+				WithDefault("10s").
+				WithDescription("How long to keep evaluating, e.g. 500ms or 10s").
+				AsFlag(),
+			).
+			WithFlag(cling.
+				NewIntCmdInput("concurrency").
+				WithDefault(1).
+				WithDescription("Number of concurrent evaluators").
+				AsFlag(),
+			).
+			WithFlag(cling.
+				NewStringCmdInput("output").
+				WithDefault("table").
+				WithValidator(cling.NewEnumValidator("table", "json")).
+				WithDescription("Output format to use. One of: table, json").
+				AsFlag(),
+			)),
+	)
+}
+
+type benchCmdArgs struct {
+	Policy       string   `cling-name:"policy"`
+	PackLocation string   `cling-name:"pack-location"`
+	PolicyRoots  []string `cling-name:"policy-root"`
+	NoOverride   bool     `cling-name:"no-override"`
+	FactFile     string   `cling-name:"fact-file"`
+	Facts        string   `cling-name:"facts"`
+	Duration     string   `cling-name:"duration"`
+	Concurrency  int      `cling-name:"concurrency"`
+	Output       string   `cling-name:"output"`
+}
+
+// benchReport summarizes a bench run. Latencies are reported in microseconds.
+type benchReport struct {
+	Target       string  `json:"target"`
+	Concurrency  int     `json:"concurrency"`
+	DurationMs   int64   `json:"duration_ms"`
+	Evaluations  int     `json:"evaluations"`
+	Throughput   float64 `json:"throughput_per_sec"`
+	LatencyP50Us int64   `json:"latency_p50_us"`
+	LatencyP90Us int64   `json:"latency_p90_us"`
+	LatencyP99Us int64   `json:"latency_p99_us"`
+	LatencyMaxUs int64   `json:"latency_max_us"`
+}
+
+func benchCmd(ctx context.Context, args []string) error {
+	input := benchCmdArgs{}
+	if err := cling.Hydrate(ctx, args, &input); err != nil {
+		return err
+	}
+
+	duration, err := time.ParseDuration(input.Duration)
+	if err != nil {
+		return fmt.Errorf("invalid --duration: %w", err)
+	}
+	if duration <= 0 {
+		return errors.New("--duration must be positive")
+	}
+	if input.Concurrency < 1 {
+		return errors.New("--concurrency must be at least 1")
+	}
+
+	facts, err := loadFacts(input.FactFile, input.Facts)
+	if err != nil {
+		return err
+	}
+
+	src := indexSource{
+		PackLocation:    input.PackLocation,
+		PolicyRoots:     input.PolicyRoots,
+		NoOverride:      input.NoOverride,
+		ExecutorOptions: executorOptionsOf(ctx),
+	}
+	idx, err := loadIndex(ctx, src)
+	if err != nil {
+		return err
+	}
+
+	view, err := idx.View(ctx)
+	if err != nil {
+		return err
+	}
+
+	exec, err := src.newExecutor(view)
+	if err != nil {
+		return err
+	}
+
+	report, err := runBench(ctx, exec, input.Policy, facts, duration, input.Concurrency)
+	if err != nil {
+		return err
+	}
+
+	if input.Output == "json" {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		return enc.Encode(report)
+	}
+	formatBenchTable(os.Stdout, report)
+	return nil
+}
+
+// runBench evaluates the target repeatedly from concurrency workers until the duration elapses.
+// The first evaluation error stops the run.
+func runBench(ctx context.Context, exec runtime.Executor, target string, facts map[string]any, duration time.Duration, concurrency int) (*benchReport, error) {
+	namespace, policy, rule, err := exec.Index().ResolveSegments(target)
+	if err != nil {
+		return nil, err
+	}
+
+	evaluate := func(ctx context.Context) error {
+		if len(rule) == 0 {
+			_, err := exec.ExecPolicy(ctx, namespace, policy, facts)
+			return err
+		}
+		_, err := exec.ExecRule(ctx, namespace, policy, rule, facts)
+		return err
+	}
+
+	ctx, cancel := context.WithCancelCause(ctx)
+	defer cancel(nil)
+
+	latencies := make([][]time.Duration, concurrency)
+	var wg sync.WaitGroup
+	start := time.Now()
+	deadline := start.Add(duration)
+	for worker := range concurrency {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for ctx.Err() == nil && time.Now().Before(deadline) {
+				began := time.Now()
+				if err := evaluate(ctx); err != nil {
+					cancel(err)
+					return
+				}
+				latencies[worker] = append(latencies[worker], time.Since(began))
+			}
+		}()
+	}
+	wg.Wait()
+	elapsed := time.Since(start)
+
+	if err := context.Cause(ctx); err != nil {
+		return nil, err
+	}
+
+	all := slices.Concat(latencies...)
+	slices.Sort(all)
+
+	report := &benchReport{
+		Target:      target,
+		Concurrency: concurrency,
+		DurationMs:  elapsed.Milliseconds(),
+		Evaluations: len(all),
+		Throughput:  float64(len(all)) / elapsed.Seconds(),
+	}
+	if len(all) > 0 {
+		report.LatencyP50Us = percentile(all, 50).Microseconds()
+		report.LatencyP90Us = percentile(all, 90).Microseconds()
+		report.LatencyP99Us = percentile(all, 99).Microseconds()
+		report.LatencyMaxUs = all[len(all)-1].Microseconds()
+	}
+	return report, nil
+}
+
+// percentile returns the nearest-rank percentile of the sorted latencies.
+func percentile(sorted []time.Duration, p int) time.Duration {
+	rank := (p*len(sorted) + 99) / 100
+	return sorted[max(rank, 1)-1]
+}
+
+func formatBenchTable(w io.Writer, r *benchReport) {
+	fmt.Fprintf(w, "Target:      %s\n", r.Target)
+	fmt.Fprintf(w, "Concurrency: %d\n", r.Concurrency)
+	fmt.Fprintf(w, "Duration:    %s\n", time.Duration(r.DurationMs)*time.Millisecond)
+	fmt.Fprintf(w, "Evaluations: %d\n", r.Evaluations)
+	fmt.Fprintf(w, "Throughput:  %.1f/s\n", r.Throughput)
+	fmt.Fprintln(w)
+	fmt.Fprintf(w, "Latency:     \n")
+	fmt.Fprintf(w, "  p50: %s\n", time.Duration(r.LatencyP50Us)*time.Microsecond)
+	fmt.Fprintf(w, "  p90: %s\n", time.Duration(r.LatencyP90Us)*time.Microsecond)
+	fmt.Fprintf(w, "  p99: %s\n", time.Duration(r.LatencyP99Us)*time.Microsecond)
+	fmt.Fprintf(w, "  max: %s\n", time.Duration(r.LatencyMaxUs)*time.Microsecond)
+}
@@ -0,0 +1,52 @@
+// SPDX-FileCopyrightText: © 2026 Binaek Sarkar <binaek89@gmail.com>
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+)
+
+const benchPolicy = `namespace com/example
+policy auth {
+  fact user: string
+  rule allow = default false { yield user == "alice" }
+  export decision of allow
+}
+`
+
+func (s *CmdTestSuite) TestBenchCmdReportsEvaluations() {
+	dir := s.writeTestPack(map[string]string{"policy.sentrie": benchPolicy})
+	args := []string{"sentrie", "bench", "--pack-location", dir, "--policy", "com/example/auth/allow",
+		"--facts", `{"user":"alice"}`, "--duration", "50ms", "--concurrency", "2", "--output", "json"}
+
+	var runErr error
+	out := s.captureStdout(func() {
+		runErr = Execute(s.ctx(), Setup(context.Background(), "test"), args)
+	})
+	s.Require().NoError(runErr)
+
+	var report benchReport
+	s.Require().NoError(json.Unmarshal([]byte(out), &report))
+	s.Equal("com/example/auth/allow", report.Target)
+	s.Equal(2, report.Concurrency)
+	s.Positive(report.Evaluations)
+	s.Positive(report.Throughput)
+	s.LessOrEqual(report.LatencyP50Us, report.LatencyMaxUs)
+}
+
+func (s *CmdTestSuite) TestBenchCmdRejectsMissingPolicy() {
+	err := Execute(s.ctx(), Setup(context.Background(), "test"), []string{"sentrie", "bench", "--duration", "1ms"})
+	s.Require().Error(err)
+	s.Contains(err.Error(), "policy")
+}
+
+func (s *CmdTestSuite) TestPercentile() {
+	sorted := []time.Duration{1, 2, 3, 4, 5, 6, 7, 8, 9, 10}
+	s.Equal(time.Duration(5), percentile(sorted, 50))
+	s.Equal(time.Duration(9), percentile(sorted, 90))
+	s.Equal(time.Duration(10), percentile(sorted, 99))
+	s.Equal(time.Duration(1), percentile(sorted[:1], 50))
+}
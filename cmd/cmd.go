@@ -18,6 +18,8 @@ package cmd
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"log/slog"
 
 	"github.com/binaek/cling"
@@ -35,10 +37,21 @@ func Setup(ctx context.Context, version string) *cling.CLI {
 			return nil
 		})
 
-	addServeCmd(cli)
+	addServeCmd(cli, version)
 	addInitCmd(cli)
 	addExecCmd(cli)
 	addValidateCmd(cli)
+	addBenchCmd(cli)
+	addValidateFactsCmd(cli)
+	addScaffoldFactsCmd(cli)
+	addCompileCmd(cli)
+	addShapeCmd(cli)
+	addEvalCmd(cli)
+	addTestCmd(cli)
+	addDescribeCmd(cli)
+	addExplainCmd(cli)
+	addOpenAPICmd(cli, version)
+	addReplCmd(cli)
 
 	return cli
 }
@@ -49,3 +62,28 @@ func Execute(ctx context.Context, cli *cling.CLI, args []string) error {
 	}
 	return cli.Run(ctx, args)
 }
+
+// ExitCode returns the exit code the process should end with after err: the code carried by err
+// when it is a cling.ExitCoder, and 1 otherwise.
+func ExitCode(err error) int {
+	var coder cling.ExitCoder
+	if errors.As(err, &coder) {
+		return coder.ExitCode()
+	}
+	return 1
+}
+
+// decisionExit ends the process with the exit code of a decision. The decision has already been
+// written out, so it is not a failure to report, see Silent.
+type decisionExit int
+
+func (d decisionExit) Error() string { return fmt.Sprintf("exit code %d", int(d)) }
+
+func (d decisionExit) ExitCode() int { return int(d) }
+
+// Silent reports whether err only carries the exit code the process should end with, and has
+// nothing to print.
+func Silent(err error) bool {
+	var exit decisionExit
+	return errors.As(err, &exit)
+}
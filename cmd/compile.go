@@ -0,0 +1,72 @@
+// SPDX-FileCopyrightText: © 2026 Binaek Sarkar <binaek89@gmail.com>
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"os"
+
+	"github.com/binaek/cling"
+	"github.com/sentrie-sh/sentrie/loader"
+)
+
+func addCompileCmd(cli *cling.CLI) {
+	cli.WithCommand(
+		withIndexSourceFlags(cling.NewCommand("compile", compileCmd).
+			WithDescription("Parse and validate a pack into a plans file, which serve can load with --plans instead of parsing the policies").
+			WithFlag(cling.
+				NewStringCmdInput("pack-location").
+				WithDefault("./").
+				WithDescription("Pack directory to compile").
+				AsFlag(),
+			).
+			WithFlag(cling.
+				NewStringCmdInput("output").
+				WithDefault("plans.bin").
+				WithDescription("File to write the plans to").
+				AsFlag(),
+			)),
+	)
+}
+
+type compileCmdArgs struct {
+	PackLocation string   `cling-name:"pack-location"`
+	PolicyRoots  []string `cling-name:"policy-root"`
+	NoOverride   bool     `cling-name:"no-override"`
+	Output       string   `cling-name:"output"`
+}
+
+func compileCmd(ctx context.Context, args []string) error {
+	input := compileCmdArgs{}
+	if err := cling.Hydrate(ctx, args, &input); err != nil {
+		return err
+	}
+
+	src := indexSource{
+		PackLocation: input.PackLocation,
+		PolicyRoots:  input.PolicyRoots,
+		NoOverride:   input.NoOverride,
+	}
+	pack, programs, err := loadPrograms(ctx, src)
+	if err != nil {
+		return err
+	}
+
+	// encode the programs as parsed, before indexing works on them
+	var plans bytes.Buffer
+	if err := loader.WritePlans(&plans, pack, programs); err != nil {
+		return err
+	}
+
+	idx, err := indexPrograms(ctx, src, pack, programs)
+	if err != nil {
+		return err
+	}
+	if err := reportWarnings(os.Stderr, idx.Warnings(), false); err != nil {
+		return err
+	}
+
+	return os.WriteFile(input.Output, plans.Bytes(), 0o644)
+}
@@ -0,0 +1,77 @@
+// SPDX-FileCopyrightText: © 2026 Binaek Sarkar <binaek89@gmail.com>
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+
+	"github.com/sentrie-sh/sentrie/loader"
+	"github.com/sentrie-sh/sentrie/trinary"
+)
+
+const compilePolicy = `namespace com/example
+shape User {
+  role: string
+}
+policy auth {
+  fact user: User
+  rule allow = default false { yield user.role == "admin" }
+  export decision of allow
+}
+`
+
+func (s *CmdTestSuite) compilePlans() (dir, plans string) {
+	dir = s.writeTestPack(map[string]string{"auth.sentrie": compilePolicy})
+	plans = filepath.Join(s.T().TempDir(), "plans.bin")
+	args := []string{"sentrie", "compile", "--pack-location", dir, "--output", plans}
+	s.Require().NoError(Execute(s.ctx(), Setup(context.Background(), "test"), args))
+	return dir, plans
+}
+
+func (s *CmdTestSuite) TestCompileCmdPlansEvaluate() {
+	ctx := context.Background()
+	dir, plans := s.compilePlans()
+
+	src := indexSource{PackLocation: dir, Plans: plans, ExecutorOptions: testExecutorOptions}
+	idx, err := loadIndex(ctx, src)
+	s.Require().NoError(err)
+	view, err := idx.View(ctx)
+	s.Require().NoError(err)
+	exec, err := src.newExecutor(view)
+	s.Require().NoError(err)
+
+	output, err := exec.ExecRule(ctx, "com/example", "auth", "allow", map[string]any{"user": map[string]any{"role": "admin"}})
+	s.Require().NoError(err)
+	s.Equal(trinary.True, output.Decision.State)
+
+	output, err = exec.ExecRule(ctx, "com/example", "auth", "allow", map[string]any{"user": map[string]any{"role": "guest"}})
+	s.Require().NoError(err)
+	s.Equal(trinary.False, output.Decision.State)
+}
+
+func (s *CmdTestSuite) TestCompileCmdPlansRejectOtherPacks() {
+	_, plans := s.compilePlans()
+	other := s.writeTestPack(map[string]string{"auth.sentrie": compilePolicy})
+
+	_, err := loadIndex(context.Background(), indexSource{PackLocation: other, Plans: plans})
+	s.Require().Error(err)
+	s.True(errors.Is(err, loader.ErrIncompatiblePlans))
+}
+
+func (s *CmdTestSuite) TestCompileCmdRejectsInvalidPack() {
+	dir := s.writeTestPack(map[string]string{"auth.sentrie": "namespace com/example\npolicy auth {\n  rule allow = default false { yield true }\n  export decision of missing\n}\n"})
+	plans := filepath.Join(s.T().TempDir(), "plans.bin")
+
+	err := Execute(s.ctx(), Setup(context.Background(), "test"), []string{"sentrie", "compile", "--pack-location", dir, "--output", plans})
+	s.Require().Error(err)
+	s.NoFileExists(plans)
+}
+
+func (s *CmdTestSuite) TestServeCmdRejectsPlansWithPolicyRoots() {
+	err := runServeCLI(context.Background(), []string{"--plans", "plans.bin", "--policy-root", "extra"})
+	s.Require().Error(err)
+	s.Contains(err.Error(), "--plans cannot be combined with --policy-root")
+}
@@ -0,0 +1,87 @@
+// SPDX-FileCopyrightText: © 2026 Binaek Sarkar <binaek89@gmail.com>
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/binaek/cling"
+)
+
+func addDescribeCmd(cli *cling.CLI) {
+	cli.WithCommand(
+		withIndexSourceFlags(cling.NewCommand("describe", describeCmd).
+			WithDescription("Describe the facts, rules, exports and metadata of a policy").
+			WithArgument(cling.NewStringCmdInput("policy").
+				WithDescription("Policy to describe, e.g. com/example/auth").
+				AsArgument(),
+			).
+			WithFlag(cling.
+				NewStringCmdInput("pack-location").
+				WithDefault(".").
+				WithDescription("Pack directory to load").
+				AsFlag(),
+			).
+			WithFlag(cling.
+				NewBoolCmdInput("warn-shadowed-fields").
+				WithDefault(false).
+				WithDescription("Report a shape field redefining a composed field without 'override' as a warning instead of an error").
+				AsFlag(),
+			).
+			WithFlag(cling.
+				NewStringCmdInput("format").
+				WithDefault("text").
+				WithValidator(cling.NewEnumValidator("text", "markdown", "json")).
+				WithDescription("Format of the description. One of: text, markdown, json").
+				AsFlag(),
+			)),
+	)
+}
+
+type describeCmdArgs struct {
+	Policy       string   `cling-name:"policy"`
+	PackLocation string   `cling-name:"pack-location"`
+	PolicyRoots  []string `cling-name:"policy-root"`
+	NoOverride   bool     `cling-name:"no-override"`
+	WarnShadowed bool     `cling-name:"warn-shadowed-fields"`
+	Format       string   `cling-name:"format"`
+}
+
+// describeCmd writes the description of a policy to stdout. See index.Index.Explain.
+func describeCmd(ctx context.Context, args []string) error {
+	input := describeCmdArgs{}
+	if err := cling.Hydrate(ctx, args, &input); err != nil {
+		return err
+	}
+
+	idx, err := loadIndex(ctx, indexSource{
+		PackLocation: input.PackLocation,
+		PolicyRoots:  input.PolicyRoots,
+		NoOverride:   input.NoOverride,
+		WarnShadowed: input.WarnShadowed,
+	})
+	if err != nil {
+		return err
+	}
+
+	description, err := idx.Explain(input.Policy)
+	if err != nil {
+		return err
+	}
+
+	switch input.Format {
+	case "json":
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		return enc.Encode(description)
+	case "markdown":
+		fmt.Print(description.Markdown())
+	default:
+		fmt.Print(description.String())
+	}
+	return nil
+}
@@ -0,0 +1,108 @@
+// SPDX-FileCopyrightText: © 2026 Binaek Sarkar <binaek89@gmail.com>
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+func (s *CmdTestSuite) TestDescribeCmdText() {
+	dir := s.writeTestPack(map[string]string{"policy.sentrie": factContractPolicy})
+	args := []string{"sentrie", "describe", "com/example/onboarding", "--pack-location", dir}
+
+	var runErr error
+	out := s.captureStdout(func() {
+		runErr = Execute(s.ctx(), Setup(context.Background(), "test"), args)
+	})
+	s.Require().NoError(runErr)
+	s.Contains(out, "policy com/example/onboarding\n")
+	s.Contains(out, "  age: number @min(18)\n")
+	s.Contains(out, "  rule allow = default false {\n    yield age >= 21\n  }\n")
+}
+
+func (s *CmdTestSuite) TestDescribeCmdJSON() {
+	dir := s.writeTestPack(map[string]string{"policy.sentrie": factContractPolicy})
+	args := []string{"sentrie", "describe", "com/example/onboarding", "--pack-location", dir, "--format", "json"}
+
+	var runErr error
+	out := s.captureStdout(func() {
+		runErr = Execute(s.ctx(), Setup(context.Background(), "test"), args)
+	})
+	s.Require().NoError(runErr)
+
+	var description struct {
+		Facts []struct {
+			Name        string   `json:"name"`
+			Constraints []string `json:"constraints"`
+		} `json:"facts"`
+		Exports []struct {
+			Rule string `json:"rule"`
+		} `json:"exports"`
+		Example map[string]any `json:"example"`
+	}
+	s.Require().NoError(json.Unmarshal([]byte(out), &description))
+	s.Require().Len(description.Facts, 3)
+	s.Equal("region", description.Facts[2].Name)
+	s.Equal([]string{`@one_of("eu", "us")`}, description.Facts[2].Constraints)
+	s.Require().Len(description.Exports, 1)
+	s.Equal("allow", description.Exports[0].Rule)
+	s.Equal(map[string]any{"email": "user@example.com", "age": float64(18), "region": "eu"}, description.Example)
+}
+
+const describedPolicy = `namespace com/example
+shape Address {
+  city: string
+  zip: string @length(5)
+}
+policy onboarding {
+  title "Onboarding"
+  description "Decides who may open an account."
+  version "1.2.0"
+  tag "owner" = "iam"
+  fact email: string @email() as contact
+  fact age?: number @min(18) default 21
+  fact sensitive address: Address
+  fact tags: list[string @minlength(1)]
+  rule adult = default false when age > 0 {
+    let years: number = age
+    yield years >= 18
+  }
+  rule allow = default false {
+    yield adult and contact != ""
+  }
+  export decision of allow attach reason as "adult with contact"
+}
+`
+
+func (s *CmdTestSuite) TestDescribeCmdMarkdownGolden() {
+	dir := s.writeTestPack(map[string]string{"policy.sentrie": describedPolicy})
+	args := []string{"sentrie", "describe", "com/example/onboarding", "--pack-location", dir, "--format", "markdown"}
+
+	var runErr error
+	out := s.captureStdout(func() {
+		runErr = Execute(s.ctx(), Setup(context.Background(), "test"), args)
+	})
+	s.Require().NoError(runErr)
+
+	golden, err := os.ReadFile(filepath.Join("testdata", "describe.md.golden"))
+	s.Require().NoError(err)
+	s.Equal(string(golden), out)
+}
+
+func (s *CmdTestSuite) TestDescribeCmdMarkdownWithoutMetadata() {
+	dir := s.writeTestPack(map[string]string{"policy.sentrie": factContractPolicy})
+	args := []string{"sentrie", "describe", "com/example/onboarding", "--pack-location", dir, "--format", "markdown"}
+
+	var runErr error
+	out := s.captureStdout(func() {
+		runErr = Execute(s.ctx(), Setup(context.Background(), "test"), args)
+	})
+	s.Require().NoError(runErr)
+	s.True(strings.HasPrefix(out, "# com/example/onboarding\n\n- **Policy:** `com/example/onboarding`\n\n## Facts\n"), out)
+	s.NotContains(out, "Version")
+}
@@ -0,0 +1,79 @@
+// SPDX-FileCopyrightText: © 2026 Binaek Sarkar <binaek89@gmail.com>
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"slices"
+
+	"github.com/sentrie-sh/sentrie/index"
+)
+
+// reportWarnings writes every warning diagnostic to w. Warnings do not fail a command
+// unless failOnWarning is set, in which case any warning makes it return an error.
+func reportWarnings(w io.Writer, diagnostics []index.Diagnostic, failOnWarning bool) error {
+	warnings := 0
+	for _, diagnostic := range diagnostics {
+		if diagnostic.Severity != index.SeverityWarning {
+			continue
+		}
+		warnings++
+		fmt.Fprintln(w, diagnostic.String())
+	}
+	if failOnWarning && warnings > 0 {
+		return fmt.Errorf("%d warning(s) reported with --fail-on-warning set", warnings)
+	}
+	return nil
+}
+
+// reportDiagnostics writes the index warnings, and the errors that stopped validation if any,
+// with write. The error is still returned so that the command fails.
+func reportDiagnostics(idx *index.Index, err error, failOnWarning bool, write func([]index.Diagnostic) error) error {
+	var diagnostics []index.Diagnostic
+	if idx != nil {
+		diagnostics = idx.Warnings()
+	}
+	warnings := diagnostics
+	if err != nil {
+		diagnostics = append(slices.Clip(diagnostics), errorDiagnostics(err)...)
+	}
+	if werr := write(diagnostics); werr != nil {
+		return werr
+	}
+	if err != nil {
+		return err
+	}
+	return reportWarnings(io.Discard, warnings, failOnWarning)
+}
+
+// errorDiagnostics turns a fatal load or validation error into a diagnostic for each of the
+// errors it joins, such as the syntax errors of several files.
+func errorDiagnostics(err error) []index.Diagnostic {
+	var diagnostics []index.Diagnostic
+	for _, joined := range joinedErrors(err) {
+		diagnostics = append(diagnostics, errorDiagnostic(joined))
+	}
+	return diagnostics
+}
+
+// joinedErrors returns the errors joined by err, or err itself when it joins none. An error
+// wrapping a join, such as a "validation error", is replaced by the errors of the join.
+func joinedErrors(err error) []error {
+	switch e := err.(type) {
+	case interface{ Unwrap() []error }:
+		var errs []error
+		for _, inner := range e.Unwrap() {
+			errs = append(errs, joinedErrors(inner)...)
+		}
+		return errs
+	case interface{ Unwrap() error }:
+		if inner := e.Unwrap(); inner != nil {
+			if errs := joinedErrors(inner); len(errs) > 1 {
+				return errs
+			}
+		}
+	}
+	return []error{err}
+}
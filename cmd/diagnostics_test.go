@@ -0,0 +1,82 @@
+// SPDX-FileCopyrightText: © 2026 Binaek Sarkar <binaek89@gmail.com>
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+
+	"github.com/sentrie-sh/sentrie/index"
+)
+
+const unusedFactPolicy = `namespace com/example
+policy auth {
+  fact user: string
+  rule allow = default true { yield true }
+  export decision of allow
+}
+`
+
+func runValidateCLI(ctx context.Context, args []string) error {
+	cli := Setup(ctx, "test")
+	return Execute(ctx, cli, append([]string{"sentrie", "validate"}, args...))
+}
+
+func (s *CmdTestSuite) TestReportWarnings() {
+	diagnostics := []index.Diagnostic{{Severity: index.SeverityWarning, Message: "fact 'user' is never referenced"}}
+
+	var out bytes.Buffer
+	s.Require().NoError(reportWarnings(&out, diagnostics, false))
+	s.Contains(out.String(), "warning: fact 'user' is never referenced")
+
+	out.Reset()
+	err := reportWarnings(&out, diagnostics, true)
+	s.Require().Error(err)
+	s.Contains(err.Error(), "1 warning(s)")
+	s.Contains(out.String(), "fact 'user' is never referenced")
+
+	s.Require().NoError(reportWarnings(&out, nil, true))
+}
+
+func (s *CmdTestSuite) TestValidateCmdPassesWithWarnings() {
+	dir := s.writeTestPack(map[string]string{"policy.sentrie": unusedFactPolicy})
+	s.Require().NoError(runValidateCLI(s.ctx(), []string{"--pack-location", dir}))
+}
+
+func (s *CmdTestSuite) TestValidateCmdFailsOnWarning() {
+	dir := s.writeTestPack(map[string]string{"policy.sentrie": unusedFactPolicy})
+	err := runValidateCLI(s.ctx(), []string{"--pack-location", dir, "--fail-on-warning"})
+	s.Require().Error(err)
+	s.Contains(err.Error(), "--fail-on-warning")
+}
+
+func (s *CmdTestSuite) TestExecCmdFailsOnWarning() {
+	dir := s.writeTestPack(map[string]string{"policy.sentrie": unusedFactPolicy})
+	args := []string{"sentrie", "exec", "--pack-location", dir, "--facts", `{"user":"alice"}`, "com/example/auth/allow"}
+
+	s.captureStdout(func() {
+		s.Require().NoError(Execute(s.ctx(), Setup(context.Background(), "test"), args))
+	})
+
+	err := Execute(s.ctx(), Setup(context.Background(), "test"), append(args[:2:2], append([]string{"--fail-on-warning"}, args[2:]...)...))
+	s.Require().Error(err)
+	s.Contains(err.Error(), "--fail-on-warning")
+}
+
+func (s *CmdTestSuite) TestEvalCmdFailsOnWarning() {
+	dir := s.writeTestPack(map[string]string{"policy.sentrie": unusedFactPolicy})
+	input := filepath.Join(s.T().TempDir(), "facts.json")
+	s.Require().NoError(os.WriteFile(input, []byte(`{"user":"alice"}`), 0o600))
+	args := []string{"sentrie", "eval", "--pack-location", dir, "--input", input, "--policy", "com/example/auth"}
+
+	s.captureStdout(func() {
+		s.Require().NoError(Execute(s.ctx(), Setup(context.Background(), "test"), args))
+	})
+
+	err := Execute(s.ctx(), Setup(context.Background(), "test"), append(args, "--fail-on-warning"))
+	s.Require().Error(err)
+	s.Contains(err.Error(), "--fail-on-warning")
+}
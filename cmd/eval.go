@@ -0,0 +1,153 @@
+// SPDX-FileCopyrightText: © 2026 Binaek Sarkar <binaek89@gmail.com>
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/binaek/cling"
+	"github.com/sentrie-sh/sentrie/trinary"
+)
+
+// The exit codes of eval besides 0, for a decision that is true, and 1, for an error.
+const (
+	// EvalExitDeny is the exit code of an evaluation deciding false
+	EvalExitDeny = 2
+	// EvalExitUnknown is the exit code of an evaluation whose decision is unknown
+	EvalExitUnknown = 3
+)
+
+func addEvalCmd(cli *cling.CLI) {
+	cli.WithCommand(
+		withIndexSourceFlags(cling.NewCommand("eval", evalCmd).
+			WithDescription("Evaluate a policy or rule once against JSON facts, exiting with a code for the decision").
+			WithFlag(cling.
+				NewStringCmdInput("policy").
+				WithDescription("Policy or rule to evaluate, e.g. com/example/auth or com/example/auth/allow").
+				Required().
+				AsFlag(),
+			).
+			WithFlag(cling.
+				NewStringCmdInput("input").
+				WithDescription("JSON file holding the facts, or - to read them from stdin").
+				Required().
+				AsFlag(),
+			).
+			WithFlag(cling.
+				NewStringCmdInput("pack-location").
+				WithDefault(".").
+				WithDescription("Pack directory to load").
+				AsFlag(),
+			).
+			WithFlag(cling.
+				NewBoolCmdInput("warn-shadowed-fields").
+				WithDefault(false).
+				WithDescription("Report a shape field redefining a composed field without 'override' as a warning instead of an error").
+				AsFlag(),
+			).
+			WithFlag(cling.
+				NewStringCmdInput("output").
+				WithDefault("table").
+				WithValidator(cling.NewEnumValidator("table", "json")).
+				WithDescription("Output format to use. One of: table, json").
+				AsFlag(),
+			).
+			WithFlag(cling.
+				NewBoolCmdInput("fail-on-warning").
+				WithDefault(false).
+				WithDescription("Exit with an error when validation reports any warning").
+				AsFlag(),
+			)),
+	)
+}
+
+type evalCmdArgs struct {
+	Policy        string   `cling-name:"policy"`
+	Input         string   `cling-name:"input"`
+	PackLocation  string   `cling-name:"pack-location"`
+	PolicyRoots   []string `cling-name:"policy-root"`
+	NoOverride    bool     `cling-name:"no-override"`
+	WarnShadowed  bool     `cling-name:"warn-shadowed-fields"`
+	Output        string   `cling-name:"output"`
+	FailOnWarning bool     `cling-name:"fail-on-warning"`
+}
+
+// evalCmd validates the input facts against the policy and evaluates its exported rules, or the
+// one rule named, printing the decisions and their attachments. The decision is true when every
+// exported decision is true, or is the combined decision when the policy declares a combining
+// algorithm; a false decision exits with EvalExitDeny and an unknown one with
+// EvalExitUnknown, so that scripts can branch on the exit code. Neither is reported as an error.
+func evalCmd(ctx context.Context, args []string) error {
+	input := evalCmdArgs{}
+	if err := cling.Hydrate(ctx, args, &input); err != nil {
+		return err
+	}
+
+	facts, err := readEvalInput(input.Input)
+	if err != nil {
+		return err
+	}
+
+	target, err := loadExecTarget(ctx, indexSource{
+		PackLocation:    input.PackLocation,
+		PolicyRoots:     input.PolicyRoots,
+		NoOverride:      input.NoOverride,
+		WarnShadowed:    input.WarnShadowed,
+		ExecutorOptions: executorOptionsOf(ctx),
+	}, input.FailOnWarning, input.Policy)
+	if err != nil {
+		return err
+	}
+
+	if err := target.exec.ValidateFacts(ctx, target.namespace, target.policy, facts); err != nil {
+		return fmt.Errorf("facts are not valid for %s: %w", input.Policy, err)
+	}
+
+	outputs, err := target.run(ctx, facts)
+	if err != nil {
+		return err
+	}
+
+	target.print(outputs, input.Output, false, false)
+
+	decision := trinary.True
+	if combined := target.combined(outputs); combined != nil {
+		decision = combined.State
+	} else {
+		for _, output := range outputs {
+			decision = decision.And(output.Permits())
+		}
+	}
+	switch decision {
+	case trinary.True:
+		return nil
+	case trinary.False:
+		return decisionExit(EvalExitDeny)
+	default:
+		return decisionExit(EvalExitUnknown)
+	}
+}
+
+// readEvalInput decodes the facts held by the file at path, or by stdin when path is -.
+func readEvalInput(path string) (map[string]any, error) {
+	var r io.Reader = os.Stdin
+	if path != "-" {
+		f, err := os.Open(path)
+		if err != nil {
+			return nil, err
+		}
+		defer f.Close()
+		r = f
+	}
+
+	facts := map[string]any{}
+	if err := json.NewDecoder(r).Decode(&facts); err != nil {
+		return nil, fmt.Errorf("failed to decode the facts in %s: %w", path, err)
+	}
+	return facts, nil
+}
@@ -0,0 +1,94 @@
+// SPDX-FileCopyrightText: © 2026 Binaek Sarkar <binaek89@gmail.com>
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+)
+
+const evalPolicy = `namespace com/example
+policy auth {
+  fact role: string
+  fact age?: number
+  rule admin = default false { yield role == "admin" }
+  rule adult = default false { yield age >= 18 }
+  export decision of admin attach reason as "role must be admin"
+  export decision of adult
+}
+policy either {
+  combining "permit-overrides"
+  fact role: string
+  fact age?: number
+  rule admin = default false { yield role == "admin" }
+  rule adult = default false { yield age >= 18 }
+  export decision of admin
+  export decision of adult
+}
+`
+
+func (s *CmdTestSuite) runEval(facts string, extra ...string) (string, error) {
+	s.T().Helper()
+	dir := s.writeTestPack(map[string]string{"policy.sentrie": evalPolicy})
+	input := filepath.Join(s.T().TempDir(), "facts.json")
+	s.Require().NoError(os.WriteFile(input, []byte(facts), 0o600))
+
+	args := append([]string{"sentrie", "eval", "--pack-location", dir, "--input", input}, extra...)
+	var runErr error
+	out := s.captureStdout(func() {
+		runErr = Execute(s.ctx(), Setup(context.Background(), "test"), args)
+	})
+	return out, runErr
+}
+
+func (s *CmdTestSuite) TestEvalCmdPermits() {
+	out, err := s.runEval(`{"role": "admin", "age": 30}`, "--policy", "com/example/auth")
+	s.Require().NoError(err)
+	s.Contains(out, "admin: ✓ True")
+	s.Contains(out, "reason: role must be admin")
+}
+
+func (s *CmdTestSuite) TestEvalCmdDenyExitsWithTheDenyCode() {
+	out, err := s.runEval(`{"role": "user", "age": 30}`, "--policy", "com/example/auth")
+	s.Require().Error(err)
+	s.Equal(EvalExitDeny, ExitCode(err))
+	s.True(Silent(err))
+	s.Contains(out, "admin: ⨯ False")
+
+	// a single rule decides alone
+	_, err = s.runEval(`{"role": "user", "age": 30}`, "--policy", "com/example/auth/adult")
+	s.NoError(err)
+}
+
+func (s *CmdTestSuite) TestEvalCmdUnknownExitsWithTheUnknownCode() {
+	out, err := s.runEval(`{"role": "admin"}`, "--policy", "com/example/auth", "--output", "json")
+	s.Require().Error(err)
+	s.Equal(EvalExitUnknown, ExitCode(err))
+	s.Contains(out, `"adult"`)
+}
+
+func (s *CmdTestSuite) TestEvalCmdExitsWithTheCombinedDecision() {
+	out, err := s.runEval(`{"role": "user", "age": 30}`, "--policy", "com/example/either", "--output", "json")
+	s.Require().NoError(err)
+	s.Contains(out, `"schema_version": 5`)
+	s.Contains(out, "\n  \"decision\": {\n    \"state\": \"true\"")
+
+	_, err = s.runEval(`{"role": "user", "age": 12}`, "--policy", "com/example/either")
+	s.Require().Error(err)
+	s.Equal(EvalExitDeny, ExitCode(err))
+}
+
+func (s *CmdTestSuite) TestEvalCmdRejectsInvalidFacts() {
+	_, err := s.runEval(`{"age": "old"}`, "--policy", "com/example/auth")
+	s.Require().Error(err)
+	s.Equal(1, ExitCode(err))
+	s.False(Silent(err))
+	s.Contains(err.Error(), "facts are not valid for com/example/auth")
+	s.Contains(err.Error(), "role")
+
+	_, err = s.runEval(`[1]`, "--policy", "com/example/auth")
+	s.Require().Error(err)
+	s.Contains(err.Error(), "failed to decode the facts")
+}
@@ -28,14 +28,13 @@ import (
 	"github.com/binaek/cling"
 	"github.com/sentrie-sh/sentrie/box"
 	"github.com/sentrie-sh/sentrie/index"
-	"github.com/sentrie-sh/sentrie/loader"
 	"github.com/sentrie-sh/sentrie/runtime"
 	"github.com/sentrie-sh/sentrie/trinary"
 )
 
 func addExecCmd(cli *cling.CLI) {
 	cli.WithCommand(
-		cling.NewCommand("exec", execCmd).
+		withIndexSourceFlags(cling.NewCommand("exec", execCmd).
 			WithArgument(cling.NewStringCmdInput("rule").
 				WithDescription("Rule to execute").
 				AsArgument(),
@@ -46,11 +45,17 @@ func addExecCmd(cli *cling.CLI) {
 				WithDescription("Pack directory to load").
 				AsFlag(),
 			).
+			WithFlag(cling.
+				NewBoolCmdInput("warn-shadowed-fields").
+				WithDefault(false).
+				WithDescription("Report a shape field redefining a composed field as a warning instead of an error").
+				AsFlag(),
+			).
 			WithFlag(cling.
 				NewStringCmdInput("output").
 				WithDefault("table").
-				WithValidator(cling.NewEnumValidator("table", "json")).
-				WithDescription("Output format to use. One of: table, json").
+				WithValidator(cling.NewEnumValidator("table", "json", "json-legacy")).
+				WithDescription("Output format to use. One of: table, json, json-legacy. json-legacy is the array of outputs printed by json before the versioned envelope, and will be removed").
 				AsFlag(),
 			).
 			WithFlag(cling.
@@ -64,16 +69,40 @@ func addExecCmd(cli *cling.CLI) {
 				WithDefault("{}").
 				WithDescription("Facts to execute the rule with").
 				AsFlag(),
-			),
+			).
+			WithFlag(cling.
+				NewBoolCmdInput("explain").
+				WithDefault(false).
+				WithDescription("Include the evaluation trace of every outcome in the json output").
+				AsFlag(),
+			).
+			WithFlag(cling.
+				NewBoolCmdInput("include-metadata").
+				WithDefault(false).
+				WithDescription("Include the declared metadata of every outcome's policy in the json output").
+				AsFlag(),
+			).
+			WithFlag(cling.
+				NewBoolCmdInput("fail-on-warning").
+				WithDefault(false).
+				WithDescription("Exit with an error when validation reports any warning").
+				AsFlag(),
+			)),
 	)
 }
 
 type execCmdArgs struct {
-	PackLocation string `cling-name:"pack-location"`
-	Rule         string `cling-name:"rule"`
-	Facts        string `cling-name:"facts"`
-	FactFile     string `cling-name:"fact-file"`
-	Output       string `cling-name:"output"`
+	PackLocation  string   `cling-name:"pack-location"`
+	PolicyRoots   []string `cling-name:"policy-root"`
+	NoOverride    bool     `cling-name:"no-override"`
+	WarnShadowed  bool     `cling-name:"warn-shadowed-fields"`
+	Rule          string   `cling-name:"rule"`
+	Facts         string   `cling-name:"facts"`
+	FactFile      string   `cling-name:"fact-file"`
+	Output        string   `cling-name:"output"`
+	Explain       bool     `cling-name:"explain"`
+	WithMetadata  bool     `cling-name:"include-metadata"`
+	FailOnWarning bool     `cling-name:"fail-on-warning"`
 }
 
 func execCmd(ctx context.Context, args []string) error {
@@ -82,89 +111,129 @@ func execCmd(ctx context.Context, args []string) error {
 		return err
 	}
 
-	factFileMap := make(map[string]any)
-	// if the fact file is provided, load the facts from the file
-	if input.FactFile != "" {
-		content, err := os.ReadFile(input.FactFile)
-		if err != nil {
-			return err
-		}
-		decoder := json.NewDecoder(bytes.NewReader(content))
-		if err := decoder.Decode(&factFileMap); err != nil {
-			return err
-		}
+	facts, err := loadFacts(input.FactFile, input.Facts)
+	if err != nil {
+		return err
 	}
 
-	pack, err := loader.LoadPack(ctx, input.PackLocation)
+	target, err := loadExecTarget(ctx, indexSource{
+		PackLocation:    input.PackLocation,
+		PolicyRoots:     input.PolicyRoots,
+		NoOverride:      input.NoOverride,
+		WarnShadowed:    input.WarnShadowed,
+		ExecutorOptions: executorOptionsOf(ctx),
+	}, input.FailOnWarning, input.Rule)
 	if err != nil {
 		return err
 	}
 
-	idx := index.CreateIndex()
-
-	if err := idx.SetPack(ctx, pack); err != nil {
-		return err
+	// an explained evaluation traces every sub-expression, and the ones it skipped
+	if input.Explain {
+		ctx = runtime.WithFullTrace(ctx)
 	}
 
-	programs, err := loader.LoadPrograms(ctx, pack)
+	outputs, err := target.run(ctx, facts)
 	if err != nil {
 		return err
 	}
 
-	for _, program := range programs {
-		if err := idx.AddProgram(ctx, program); err != nil {
-			return err
-		}
+	target.print(outputs, input.Output, input.Explain, input.WithMetadata)
+	return nil
+}
+
+// execTarget is the policy, or the one rule of it, that exec and eval evaluate.
+type execTarget struct {
+	exec                    runtime.Executor
+	namespace, policy, rule string
+}
+
+// loadExecTarget indexes src, reporting its warnings, and resolves the policy or rule named by
+// fqn in it.
+func loadExecTarget(ctx context.Context, src indexSource, failOnWarning bool, fqn string) (*execTarget, error) {
+	idx, err := loadIndex(ctx, src)
+	if err != nil {
+		return nil, err
 	}
 
-	if err := idx.Validate(ctx); err != nil {
-		return err
+	if err := reportWarnings(os.Stderr, idx.Warnings(), failOnWarning); err != nil {
+		return nil, err
 	}
 
-	exec, err := runtime.NewExecutor(idx)
+	view, err := idx.View(ctx)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	var factFlagMap map[string]any
-	decoder := json.NewDecoder(bytes.NewReader([]byte(input.Facts)))
-	if err := decoder.Decode(&factFlagMap); err != nil {
-		return err
+	exec, err := src.newExecutor(view)
+	if err != nil {
+		return nil, err
 	}
 
-	facts := make(map[string]any)
-
-	// merge in the values from the different sources
-	maps.Copy(facts, factFileMap)
-	maps.Copy(facts, factFlagMap)
+	namespace, policy, rule, err := exec.Index().ResolveSegments(fqn)
+	if err != nil {
+		return nil, err
+	}
+	return &execTarget{exec: exec, namespace: namespace, policy: policy, rule: rule}, nil
+}
 
-	namespace, policy, rule, err := exec.Index().ResolveSegments(input.Rule)
+// run evaluates the exported rules of the policy, or the one rule, with facts.
+func (t *execTarget) run(ctx context.Context, facts map[string]any) ([]*runtime.ExecutorOutput, error) {
+	if len(t.rule) == 0 {
+		return t.exec.ExecPolicy(ctx, t.namespace, t.policy, facts)
+	}
+	output, err := t.exec.ExecRule(ctx, t.namespace, t.policy, t.rule, facts)
 	if err != nil {
-		return err
+		return nil, err
 	}
+	return []*runtime.ExecutorOutput{output}, nil
+}
 
-	var outputs []*runtime.ExecutorOutput
-	var runErr error
-	if len(rule) == 0 {
-		outputs, runErr = exec.ExecPolicy(ctx, namespace, policy, facts)
-	} else {
-		output, err := exec.ExecRule(ctx, namespace, policy, rule, facts)
-		outputs = []*runtime.ExecutorOutput{output}
-		runErr = err
+// combined is the combined decision of outputs, see combinedDecision.
+func (t *execTarget) combined(outputs []*runtime.ExecutorOutput) *runtime.Decision {
+	return combinedDecision(t.exec, t.namespace, t.policy, t.rule, outputs)
+}
+
+// print writes outputs to stdout in format, table, json or json-legacy.
+func (t *execTarget) print(outputs []*runtime.ExecutorOutput, format string, withExplain, withMetadata bool) {
+	switch format {
+	case "json":
+		formatOutputJSON(outputs, t.exec.Index().PolicyWarnings(t.namespace, t.policy), t.combined(outputs), withExplain, withMetadata)
+	case "json-legacy":
+		formatOutputLegacyJSON(outputs)
+	default:
+		formatOutputTable(outputs)
 	}
+}
 
-	// now that we have the outputs, lets map it by namespace and policy
-	if runErr != nil {
-		return runErr
+// loadFacts reads the facts from the fact file, if one is given, and merges the inline JSON facts
+// over them.
+func loadFacts(factFile string, inline string) (map[string]any, error) {
+	factFileMap := make(map[string]any)
+	// if the fact file is provided, load the facts from the file
+	if factFile != "" {
+		content, err := os.ReadFile(factFile)
+		if err != nil {
+			return nil, err
+		}
+		decoder := json.NewDecoder(bytes.NewReader(content))
+		if err := decoder.Decode(&factFileMap); err != nil {
+			return nil, err
+		}
 	}
 
-	if input.Output == "json" {
-		formatOutputJSON(outputs)
-	} else {
-		formatOutputTable(outputs)
+	var factFlagMap map[string]any
+	decoder := json.NewDecoder(bytes.NewReader([]byte(inline)))
+	if err := decoder.Decode(&factFlagMap); err != nil {
+		return nil, err
 	}
 
-	return nil
+	facts := make(map[string]any)
+
+	// merge in the values from the different sources
+	maps.Copy(facts, factFileMap)
+	maps.Copy(facts, factFlagMap)
+
+	return facts, nil
 }
 
 type ExecutorOutputMap map[string]map[string]map[string]*runtime.ExecutorOutput
@@ -189,12 +258,37 @@ func sortOutputs(outputs []*runtime.ExecutorOutput) ExecutorOutputMap {
 	return m
 }
 
-func formatOutputJSON(m []*runtime.ExecutorOutput) {
+func formatOutputJSON(m []*runtime.ExecutorOutput, warnings []index.Diagnostic, combined *runtime.Decision, withExplain, withMetadata bool) {
+	result := runtime.NewResult(m, warnings, withExplain, withMetadata)
+	result.Decision = combined
+	enc := json.NewEncoder(os.Stdout)
+	enc.SetIndent("", "  ")
+	_ = enc.Encode(result)
+}
+
+// formatOutputLegacyJSON prints the executor outputs as the array json printed before the
+// versioned envelope.
+//
+// Deprecated: kept for scripts reading the array; use formatOutputJSON.
+func formatOutputLegacyJSON(m []*runtime.ExecutorOutput) {
 	enc := json.NewEncoder(os.Stdout)
 	enc.SetIndent("", "  ")
 	_ = enc.Encode(m)
 }
 
+// combinedDecision is the combined decision of the policy when the whole policy was evaluated
+// and it declares a combining algorithm, nil otherwise.
+func combinedDecision(exec runtime.Executor, namespace, policy, rule string, outputs []*runtime.ExecutorOutput) *runtime.Decision {
+	if len(rule) > 0 {
+		return nil
+	}
+	p, err := exec.Index().ResolvePolicy(namespace, policy)
+	if err != nil {
+		return nil
+	}
+	return runtime.CombineDecisions(p, outputs)
+}
+
 // formatOutputTable formats the decision output in the specified format
 //
 // Examples:
@@ -260,8 +354,34 @@ func formatOutputTable(x []*runtime.ExecutorOutput) {
 				fmt.Println()
 			}
 
+			formatDirectivesTable("Obligations", policyData, func(o *runtime.ExecutorOutput) []runtime.Directive { return o.Obligations })
+			formatDirectivesTable("Advice", policyData, func(o *runtime.ExecutorOutput) []runtime.Directive { return o.Advice })
+		}
+	}
+}
+
+// formatDirectivesTable prints the obligations or the advice of the rules of a policy, under
+// title, when any rule has some.
+func formatDirectivesTable(title string, policyData map[string]*runtime.ExecutorOutput, directivesOf func(*runtime.ExecutorOutput) []runtime.Directive) {
+	count := 0
+	for _, ruleData := range policyData {
+		count += len(directivesOf(ruleData))
+	}
+	if count == 0 {
+		return
+	}
+	fmt.Printf("%s: \n", title)
+	for ruleName, ruleData := range policyData {
+		directives := directivesOf(ruleData)
+		if len(directives) == 0 {
+			continue
+		}
+		fmt.Printf("  ✓ %s:\n", ruleName)
+		for _, directive := range directives {
+			formatAttachment(directive.Name, directive.Value, 0)
 		}
 	}
+	fmt.Println()
 }
 
 // formatDecision formats the decision state with appropriate symbols
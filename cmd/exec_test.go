@@ -16,7 +16,14 @@
 
 package cmd
 
-import "github.com/sentrie-sh/sentrie/box"
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+
+	"github.com/sentrie-sh/sentrie/box"
+)
 
 func (s *CmdTestSuite) TestFormatAttachmentRecursesBoxedContainers() {
 	value := box.Dict(map[string]box.Value{
@@ -30,3 +37,54 @@ func (s *CmdTestSuite) TestFormatAttachmentRecursesBoxedContainers() {
 	s.Contains(out, "- 1")
 	s.Contains(out, "- 2")
 }
+
+func (s *CmdTestSuite) TestExecCmdPolicyRootOverridesPack() {
+	policy := func(value string) string {
+		return "namespace com/example\npolicy auth {\n  rule allow = default false { yield " + value + " }\n  export decision of allow\n}\n"
+	}
+	dir := s.writeTestPack(map[string]string{"auth.sentrie": policy("false")})
+	overrides := s.T().TempDir()
+	s.Require().NoError(os.WriteFile(filepath.Join(overrides, "auth.sentrie"), []byte(policy("true")), 0o600))
+
+	args := []string{"sentrie", "exec", "--pack-location", dir, "--policy-root", overrides, "--output", "json", "com/example/auth/allow"}
+	out := s.captureStdout(func() {
+		s.Require().NoError(Execute(s.ctx(), Setup(context.Background(), "test"), args))
+	})
+	s.Contains(out, `"state": "true"`)
+
+	args = append(args[:2:2], append([]string{"--no-override"}, args[2:]...)...)
+	err := Execute(s.ctx(), Setup(context.Background(), "test"), args)
+	s.Require().Error(err)
+	s.Contains(err.Error(), "conflict")
+}
+
+func (s *CmdTestSuite) TestExecCmdIncludeMetadata() {
+	dir := s.writeTestPack(map[string]string{"auth.sentrie": "namespace com/example\npolicy auth {\n  title \"Auth\"\n  rule allow = default true { yield true }\n  export decision of allow\n}\n"})
+
+	args := []string{"sentrie", "exec", "--pack-location", dir, "--output", "json", "com/example/auth/allow"}
+	out := s.captureStdout(func() {
+		s.Require().NoError(Execute(s.ctx(), Setup(context.Background(), "test"), args))
+	})
+	s.NotContains(out, `"metadata"`)
+
+	args = append(args[:2:2], append([]string{"--include-metadata"}, args[2:]...)...)
+	out = s.captureStdout(func() {
+		s.Require().NoError(Execute(s.ctx(), Setup(context.Background(), "test"), args))
+	})
+	s.Contains(out, `"title": "Auth"`)
+}
+
+func (s *CmdTestSuite) TestExecCmdLegacyJSONPrintsOutputArray() {
+	dir := s.writeTestPack(map[string]string{"auth.sentrie": "namespace com/example\npolicy auth {\n  rule allow = default true { yield true }\n  export decision of allow\n}\n"})
+
+	args := []string{"sentrie", "exec", "--pack-location", dir, "--output", "json-legacy", "com/example/auth/allow"}
+	out := s.captureStdout(func() {
+		s.Require().NoError(Execute(s.ctx(), Setup(context.Background(), "test"), args))
+	})
+	var outputs []map[string]any
+	s.Require().NoError(json.Unmarshal([]byte(out), &outputs))
+	s.Require().Len(outputs, 1)
+	s.Equal("auth", outputs[0]["policy"])
+	s.Equal("allow", outputs[0]["rule"])
+	s.NotContains(out, `"schema_version"`)
+}
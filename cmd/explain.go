@@ -0,0 +1,98 @@
+// SPDX-FileCopyrightText: © 2026 Binaek Sarkar <binaek89@gmail.com>
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/binaek/cling"
+	"github.com/sentrie-sh/sentrie/runtime"
+)
+
+func addExplainCmd(cli *cling.CLI) {
+	cli.WithCommand(
+		withIndexSourceFlags(cling.NewCommand("explain", explainCmd).
+			WithDescription("Evaluate a policy or rule and print the trace of how each decision was reached").
+			WithArgument(cling.NewStringCmdInput("rule").
+				WithDescription("Policy or rule to explain, e.g. com/example/auth or com/example/auth/allow").
+				AsArgument(),
+			).
+			WithFlag(cling.
+				NewStringCmdInput("pack-location").
+				WithDefault(".").
+				WithDescription("Pack directory to load").
+				AsFlag(),
+			).
+			WithFlag(cling.
+				NewBoolCmdInput("warn-shadowed-fields").
+				WithDefault(false).
+				WithDescription("Report a shape field redefining a composed field without 'override' as a warning instead of an error").
+				AsFlag(),
+			).
+			WithFlag(cling.
+				NewStringCmdInput("fact-file").
+				WithDefault("").
+				WithDescription("File to load facts from").
+				AsFlag(),
+			).
+			WithFlag(cling.
+				NewStringCmdInput("facts").
+				WithDefault("{}").
+				WithDescription("Facts to evaluate with").
+				AsFlag(),
+			)),
+	)
+}
+
+type explainCmdArgs struct {
+	Rule         string   `cling-name:"rule"`
+	PackLocation string   `cling-name:"pack-location"`
+	PolicyRoots  []string `cling-name:"policy-root"`
+	NoOverride   bool     `cling-name:"no-override"`
+	WarnShadowed bool     `cling-name:"warn-shadowed-fields"`
+	Facts        string   `cling-name:"facts"`
+	FactFile     string   `cling-name:"fact-file"`
+}
+
+// explainCmd evaluates the exported rules of a policy, or the one rule named, and prints every
+// decision followed by its evaluation trace as an indented tree. Each line of the tree is a
+// sub-expression with the value it produced; branches that were skipped are marked as not
+// evaluated. See trace.Node.Render.
+func explainCmd(ctx context.Context, args []string) error {
+	input := explainCmdArgs{}
+	if err := cling.Hydrate(ctx, args, &input); err != nil {
+		return err
+	}
+
+	facts, err := loadFacts(input.FactFile, input.Facts)
+	if err != nil {
+		return err
+	}
+
+	target, err := loadExecTarget(ctx, indexSource{
+		PackLocation:    input.PackLocation,
+		PolicyRoots:     input.PolicyRoots,
+		NoOverride:      input.NoOverride,
+		WarnShadowed:    input.WarnShadowed,
+		ExecutorOptions: executorOptionsOf(ctx),
+	}, false, input.Rule)
+	if err != nil {
+		return err
+	}
+
+	outputs, err := target.run(runtime.WithFullTrace(ctx), facts)
+	if err != nil {
+		return err
+	}
+
+	for i, output := range outputs {
+		if i > 0 {
+			fmt.Println()
+		}
+		fmt.Printf("%s/%s/%s: %s\n", output.Namespace, output.PolicyName, output.RuleName, formatDecision(output.Decision))
+		fmt.Print(output.RuleNode.Render())
+	}
+	return nil
+}
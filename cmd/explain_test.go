@@ -0,0 +1,54 @@
+// SPDX-FileCopyrightText: © 2026 Binaek Sarkar <binaek89@gmail.com>
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"context"
+)
+
+const explainedPolicy = `namespace com/example
+policy access {
+  fact role: string
+  fact age: number
+  rule adult = default false when age > 0 {
+    yield age >= 18
+  }
+  rule allow = default false {
+    yield role == "admin" ? true : adult
+  }
+  export decision of allow
+  export decision of adult
+}
+`
+
+func (s *CmdTestSuite) TestExplainCmdPrintsTraceTree() {
+	dir := s.writeTestPack(map[string]string{"policy.sentrie": explainedPolicy})
+	args := []string{"sentrie", "explain", "com/example/access", "--pack-location", dir, "--facts", `{"role": "user", "age": -1}`}
+
+	var runErr error
+	out := s.captureStdout(func() {
+		runErr = Execute(s.ctx(), Setup(context.Background(), "test"), args)
+	})
+	s.Require().NoError(runErr)
+
+	s.Contains(out, "com/example/access/allow: ⨯ False\n")
+	s.Contains(out, "\n          infix (role == \"admin\") [")
+	s.Contains(out, "\n            identifier role [")
+	s.Regexp(`\n {10}not-evaluated true \[[^\n]*\]\n`, out)
+	s.Regexp(`\n {16}not-evaluated \{yield \(age >= 18\)\} \[[^\n]*\]\n`, out)
+}
+
+func (s *CmdTestSuite) TestExplainCmdRule() {
+	dir := s.writeTestPack(map[string]string{"policy.sentrie": explainedPolicy})
+	args := []string{"sentrie", "explain", "com/example/access/adult", "--pack-location", dir, "--facts", `{"role": "user", "age": 30}`}
+
+	var runErr error
+	out := s.captureStdout(func() {
+		runErr = Execute(s.ctx(), Setup(context.Background(), "test"), args)
+	})
+	s.Require().NoError(runErr)
+	s.Contains(out, "com/example/access/adult: ✓ True\n")
+	s.Contains(out, "not-evaluated false [")
+	s.Contains(out, " => 30\n")
+}
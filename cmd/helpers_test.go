@@ -19,8 +19,22 @@ package cmd
 import (
 	"bytes"
 	"os"
+	"path/filepath"
 )
 
+// writeTestPack writes a minimal pack file plus the given policy sources into a fresh
+// directory and returns its path.
+func (s *CmdTestSuite) writeTestPack(sources map[string]string) string {
+	s.T().Helper()
+	dir := s.T().TempDir()
+	packFile := "[schema]\nversion = 1\n\n[pack]\nname = \"test.pack\"\nversion = \"0.0.1\"\n"
+	s.Require().NoError(os.WriteFile(filepath.Join(dir, "sentrie.pack.toml"), []byte(packFile), 0o600))
+	for name, src := range sources {
+		s.Require().NoError(os.WriteFile(filepath.Join(dir, name), []byte(src), 0o600))
+	}
+	return dir
+}
+
 func (s *CmdTestSuite) captureStdout(fn func()) string {
 	s.T().Helper()
 	oldStdout := os.Stdout
@@ -0,0 +1,129 @@
+// SPDX-FileCopyrightText: © 2026 Binaek Sarkar <binaek89@gmail.com>
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"context"
+	"os"
+	"slices"
+
+	"github.com/binaek/cling"
+	"github.com/sentrie-sh/sentrie/ast"
+	"github.com/sentrie-sh/sentrie/index"
+	"github.com/sentrie-sh/sentrie/loader"
+	"github.com/sentrie-sh/sentrie/pack"
+	"github.com/sentrie-sh/sentrie/runtime"
+)
+
+// executorOptionsKey is the context key of the executor options a command is run with.
+type executorOptionsKey struct{}
+
+// withExecutorOptions returns a context running commands with opts applied to every executor
+// they build, ahead of the command's own options.
+func withExecutorOptions(ctx context.Context, opts ...runtime.NewExecutorOption) context.Context {
+	return context.WithValue(ctx, executorOptionsKey{}, opts)
+}
+
+// executorOptionsOf returns the executor options ctx runs commands with, see withExecutorOptions.
+func executorOptionsOf(ctx context.Context) []runtime.NewExecutorOption {
+	opts, _ := ctx.Value(executorOptionsKey{}).([]runtime.NewExecutorOption)
+	return slices.Clone(opts)
+}
+
+// indexSource is the pack and policy roots a command indexes.
+type indexSource struct {
+	PackLocation string
+	PolicyRoots  []string
+	NoOverride   bool
+	WarnShadowed bool
+
+	// Plans, when set, is a plans file to read the programs from instead of parsing the
+	// policy roots.
+	Plans string
+
+	// ExecutorOptions apply to every executor built over the index, see newExecutor.
+	ExecutorOptions []runtime.NewExecutorOption
+}
+
+// newExecutor builds an executor over view, a view of the index of src, with the
+// ExecutorOptions of src.
+func (src indexSource) newExecutor(view *index.IndexView) (runtime.Executor, error) {
+	return runtime.NewExecutor(view, src.ExecutorOptions...)
+}
+
+// withIndexSourceFlags adds the flags that layer policy roots over the pack, which hydrate the
+// PolicyRoots and NoOverride of an indexSource.
+func withIndexSourceFlags(cmd *cling.Command) *cling.Command {
+	return cmd.
+		WithFlag(cling.
+			NewCmdSliceInput[string]("policy-root").
+			WithDefault([]string{}).
+			WithDescription("Additional policy directories layered over the pack, in order. Later roots override policies with the same FQN").
+			AsFlag(),
+		).
+		WithFlag(cling.
+			NewBoolCmdInput("no-override").
+			WithDefault(false).
+			WithDescription("Report a policy redeclared by a --policy-root as a conflict instead of overriding it").
+			AsFlag(),
+		)
+}
+
+// loadIndex loads the pack and its policy roots into a validated index.
+func loadIndex(ctx context.Context, src indexSource) (*index.Index, error) {
+	pack, programs, err := loadPrograms(ctx, src)
+	if err != nil {
+		return nil, err
+	}
+	return indexPrograms(ctx, src, pack, programs)
+}
+
+// loadPrograms loads the pack and the programs of its policy roots, or those of its plans file.
+func loadPrograms(ctx context.Context, src indexSource) (*pack.PackFile, []*ast.Program, error) {
+	pack, err := loader.LoadPack(ctx, src.PackLocation)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if src.Plans == "" {
+		programs, err := loader.LoadPolicyRoots(ctx, pack, src.PolicyRoots, !src.NoOverride)
+		return pack, programs, err
+	}
+
+	f, err := os.Open(src.Plans)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer f.Close()
+
+	programs, err := loader.ReadPlans(f, pack)
+	return pack, programs, err
+}
+
+// indexPrograms indexes the programs of pack, with the index flags of src, and validates the index.
+func indexPrograms(ctx context.Context, src indexSource, pack *pack.PackFile, programs []*ast.Program) (*index.Index, error) {
+	var opts []index.IndexOption
+	if src.WarnShadowed {
+		opts = append(opts, index.WithShadowedFieldWarnings())
+	}
+	idx := index.CreateIndex(opts...)
+
+	if err := idx.SetPack(ctx, pack); err != nil {
+		return nil, err
+	}
+
+	for _, program := range programs {
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+		if err := idx.AddProgram(ctx, program); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := idx.Validate(ctx); err != nil {
+		return nil, err
+	}
+	return idx, nil
+}
@@ -0,0 +1,164 @@
+// SPDX-FileCopyrightText: © 2026 Binaek Sarkar <binaek89@gmail.com>
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"cmp"
+	"encoding/json"
+	"io"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+	"unicode/utf16"
+	"unicode/utf8"
+
+	"github.com/sentrie-sh/sentrie/index"
+	"github.com/sentrie-sh/sentrie/tokens"
+)
+
+// LSP diagnostic severities
+const (
+	lspSeverityError   = 1
+	lspSeverityWarning = 2
+)
+
+// lspPublishDiagnostics is the params of a textDocument/publishDiagnostics notification: the
+// diagnostics of a single document.
+type lspPublishDiagnostics struct {
+	URI         string          `json:"uri"`
+	Diagnostics []lspDiagnostic `json:"diagnostics"`
+}
+
+type lspDiagnostic struct {
+	Range    lspRange `json:"range"`
+	Severity int      `json:"severity"`
+	Code     string   `json:"code"`
+	Source   string   `json:"source"`
+	Message  string   `json:"message"`
+	// Data carries the range as the parser reports it, with byte offsets
+	Data lspDiagnosticData `json:"data"`
+}
+
+// lspRange is a range of a document; its end is exclusive.
+type lspRange struct {
+	Start lspPosition `json:"start"`
+	End   lspPosition `json:"end"`
+}
+
+// lspPosition is a zero-based line, and a zero-based character counted in UTF-16 code units.
+type lspPosition struct {
+	Line      int `json:"line"`
+	Character int `json:"character"`
+}
+
+type lspDiagnosticData struct {
+	From *sourcePosition `json:"from,omitempty"`
+	To   *sourcePosition `json:"to,omitempty"`
+}
+
+type sourcePosition struct {
+	Line   int `json:"line"`
+	Column int `json:"column"`
+	Offset int `json:"offset"`
+}
+
+// reportLSP writes the index warnings, and the errors that stopped validation if any, as LSP
+// diagnostics. The error is still returned so that the command fails.
+func reportLSP(w io.Writer, packRoot string, idx *index.Index, err error, failOnWarning bool) error {
+	return reportDiagnostics(idx, err, failOnWarning, func(diagnostics []index.Diagnostic) error {
+		return writeLSP(w, packRoot, diagnostics)
+	})
+}
+
+// writeLSP writes the diagnostics as a list of textDocument/publishDiagnostics params, one for
+// every document with diagnostics, ordered by URI. A diagnostic without a file is reported on
+// the pack root.
+func writeLSP(w io.Writer, packRoot string, diagnostics []index.Diagnostic) error {
+	root, err := filepath.Abs(packRoot)
+	if err != nil {
+		return err
+	}
+
+	sources := map[string][]byte{}
+	byURI := map[string][]lspDiagnostic{}
+	for _, diagnostic := range diagnostics {
+		uri := fileURI(root + string(filepath.Separator))
+		var source []byte
+		if file := diagnostic.Range.File; file != "" {
+			abs, err := filepath.Abs(file)
+			if err != nil {
+				return err
+			}
+			uri = fileURI(abs)
+			if _, ok := sources[abs]; !ok {
+				// a file that cannot be read is located by the lines and columns of its range alone
+				sources[abs], _ = os.ReadFile(abs)
+			}
+			source = sources[abs]
+		}
+		byURI[uri] = append(byURI[uri], lspDiagnosticOf(diagnostic, source))
+	}
+
+	params := make([]lspPublishDiagnostics, 0, len(byURI))
+	for _, uri := range slices.Sorted(maps.Keys(byURI)) {
+		diagnostics := byURI[uri]
+		slices.SortStableFunc(diagnostics, func(a, b lspDiagnostic) int {
+			return cmp.Or(cmp.Compare(a.Range.Start.Line, b.Range.Start.Line), cmp.Compare(a.Range.Start.Character, b.Range.Start.Character))
+		})
+		params = append(params, lspPublishDiagnostics{URI: uri, Diagnostics: diagnostics})
+	}
+
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(params)
+}
+
+func lspDiagnosticOf(diagnostic index.Diagnostic, source []byte) lspDiagnostic {
+	severity := lspSeverityWarning
+	if diagnostic.Severity == index.SeverityError {
+		severity = lspSeverityError
+	}
+	out := lspDiagnostic{
+		Severity: severity,
+		Code:     diagnostic.Code,
+		Source:   "sentrie",
+		Message:  diagnostic.Message,
+	}
+
+	rng := diagnostic.Range
+	if rng.From.IsBadPos() {
+		return out
+	}
+	out.Data.From = &sourcePosition{Line: rng.From.Line, Column: rng.From.Column, Offset: rng.From.Offset}
+	out.Range.Start = lspPositionOf(rng.From, source)
+	out.Range.End = out.Range.Start
+	if !rng.To.IsBadPos() {
+		out.Data.To = &sourcePosition{Line: rng.To.Line, Column: rng.To.Column, Offset: rng.To.Offset}
+		if end := lspPositionOf(rng.To, source); end.Line > out.Range.Start.Line || (end.Line == out.Range.Start.Line && end.Character > out.Range.Start.Character) {
+			out.Range.End = end
+		}
+	}
+	return out
+}
+
+// lspPositionOf locates pos in source by its byte offset. Without the source, or when the offset
+// lies outside of it, pos is located by its zero-based line and one-based column.
+func lspPositionOf(pos tokens.Pos, source []byte) lspPosition {
+	if source == nil || pos.Offset < 0 || pos.Offset > len(source) {
+		return lspPosition{Line: max(pos.Line, 0), Character: max(pos.Column-1, 0)}
+	}
+	position := lspPosition{}
+	for i := 0; i < pos.Offset; {
+		r, size := utf8.DecodeRune(source[i:])
+		i += size
+		if r == '\n' {
+			position.Line++
+			position.Character = 0
+			continue
+		}
+		position.Character += utf16.RuneLen(r)
+	}
+	return position
+}
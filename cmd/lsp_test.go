@@ -0,0 +1,94 @@
+// SPDX-FileCopyrightText: © 2026 Binaek Sarkar <binaek89@gmail.com>
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"path/filepath"
+
+	"github.com/sentrie-sh/sentrie/index"
+	"github.com/sentrie-sh/sentrie/tokens"
+	"github.com/sentrie-sh/sentrie/xerr"
+)
+
+func (s *CmdTestSuite) TestValidateCmdWritesEveryParseErrorAsLSPDiagnostics() {
+	dir := s.writeTestPack(map[string]string{
+		"a.sentrie": "namespace com/example\npolicy a {\n  rule r = { yield ) }\n}\npolicy b {\n  fact : string\n}\n",
+		"b.sentrie": "namespace com/example\n-- é\npolicy c { rule r = { yield 1 + } }\n",
+	})
+
+	var runErr error
+	out := s.captureStdout(func() {
+		runErr = runValidateCLI(s.ctx(), []string{"--pack-location", dir, "--format", "json"})
+	})
+	s.Require().Error(runErr)
+
+	var params []lspPublishDiagnostics
+	s.Require().NoError(json.Unmarshal([]byte(out), &params), out)
+	s.Require().Len(params, 2)
+	s.Equal(fileURI(filepath.Join(dir, "a.sentrie")), params[0].URI)
+	s.Equal(fileURI(filepath.Join(dir, "b.sentrie")), params[1].URI)
+
+	a := params[0].Diagnostics
+	s.Require().Len(a, 2)
+	s.Equal(lspRange{Start: lspPosition{Line: 2, Character: 19}, End: lspPosition{Line: 2, Character: 20}}, a[0].Range)
+	s.Equal(lspSeverityError, a[0].Severity)
+	s.Equal(index.CodeParseError, a[0].Code)
+	s.Equal("sentrie", a[0].Source)
+	s.Contains(a[0].Message, "no prefix parse function found for ')'")
+	s.Require().NotNil(a[0].Data.From)
+	s.Equal(len("namespace com/example\npolicy a {\n  rule r = { yield "), a[0].Data.From.Offset)
+	s.Equal(5, a[1].Range.Start.Line)
+
+	b := params[1].Diagnostics
+	s.Require().Len(b, 1)
+	s.Equal(lspPosition{Line: 2, Character: 32}, b[0].Range.Start)
+}
+
+func (s *CmdTestSuite) TestWriteLSPReportsUnlocatedDiagnosticsOnThePackRoot() {
+	dir := s.T().TempDir()
+	where := tokens.NewRange(filepath.Join(dir, "missing.sentrie"), tokens.Pos{Line: 3, Column: 3, Offset: 40}, tokens.Pos{Line: 3, Column: 9, Offset: 46})
+	diagnostics := []index.Diagnostic{
+		{Severity: index.SeverityWarning, Code: index.CodeUnusedFact, Message: "fact 'user' is never referenced", Range: where},
+		errorDiagnostic(errors.New("boom")),
+	}
+
+	var out bytes.Buffer
+	s.Require().NoError(writeLSP(&out, dir, diagnostics))
+
+	var params []lspPublishDiagnostics
+	s.Require().NoError(json.Unmarshal(out.Bytes(), &params))
+	s.Require().Len(params, 2)
+	s.Equal(fileURI(dir+string(filepath.Separator)), params[0].URI)
+	s.Equal(lspRange{}, params[0].Diagnostics[0].Range)
+	s.Nil(params[0].Diagnostics[0].Data.From)
+
+	// a file that cannot be read is located by the lines and columns of the range
+	warning := params[1].Diagnostics[0]
+	s.Equal(lspSeverityWarning, warning.Severity)
+	s.Equal(lspRange{Start: lspPosition{Line: 3, Character: 2}, End: lspPosition{Line: 3, Character: 8}}, warning.Range)
+}
+
+func (s *CmdTestSuite) TestErrorDiagnosticsSplitJoinedErrors() {
+	where := tokens.NewRange("policy.sentrie", tokens.Pos{Line: 4, Column: 3}, tokens.Pos{Line: 4, Column: 9})
+	err := fmt.Errorf("validation error: %w", errors.Join(
+		xerr.ErrAt(where, errors.New("cannot resolve shape 'User'")),
+		errors.New("boom"),
+	))
+
+	diagnostics := errorDiagnostics(err)
+	s.Require().Len(diagnostics, 2)
+	s.Equal(where, diagnostics[0].Range)
+	s.Equal(index.CodeValidationError, diagnostics[0].Code)
+	s.Equal("cannot resolve shape 'User'", diagnostics[0].Message)
+	s.Equal("boom", diagnostics[1].Message)
+
+	// a wrapped single error keeps its wrapping message
+	diagnostics = errorDiagnostics(fmt.Errorf("validation error: %w", errors.New("boom")))
+	s.Require().Len(diagnostics, 1)
+	s.Equal("validation error: boom", diagnostics[0].Message)
+}
@@ -0,0 +1,73 @@
+// SPDX-FileCopyrightText: © 2026 Binaek Sarkar <binaek89@gmail.com>
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+
+	"github.com/binaek/cling"
+	"github.com/sentrie-sh/sentrie/api"
+)
+
+func addOpenAPICmd(cli *cling.CLI, version string) {
+	cli.WithCommand(
+		withIndexSourceFlags(cling.NewCommand("openapi", func(ctx context.Context, args []string) error {
+			return openAPICmd(ctx, args, version)
+		}).
+			WithDescription("Print the OpenAPI document of the HTTP API serving a pack").
+			WithFlag(cling.
+				NewStringCmdInput("pack-location").
+				WithDefault(".").
+				WithDescription("Pack directory to load").
+				AsFlag(),
+			).
+			WithFlag(cling.
+				NewBoolCmdInput("warn-shadowed-fields").
+				WithDefault(false).
+				WithDescription("Report a shape field redefining a composed field without 'override' as a warning instead of an error").
+				AsFlag(),
+			)),
+	)
+}
+
+type openAPICmdArgs struct {
+	PackLocation string   `cling-name:"pack-location"`
+	PolicyRoots  []string `cling-name:"policy-root"`
+	NoOverride   bool     `cling-name:"no-override"`
+	WarnShadowed bool     `cling-name:"warn-shadowed-fields"`
+}
+
+// openAPICmd writes the OpenAPI document of the HTTP API serving the pack to stdout, the same
+// as GET /openapi.json of serve. See api.OpenAPIDocument.
+func openAPICmd(ctx context.Context, args []string, version string) error {
+	input := openAPICmdArgs{}
+	if err := cling.Hydrate(ctx, args, &input); err != nil {
+		return err
+	}
+
+	idx, err := loadIndex(ctx, indexSource{
+		PackLocation: input.PackLocation,
+		PolicyRoots:  input.PolicyRoots,
+		NoOverride:   input.NoOverride,
+		WarnShadowed: input.WarnShadowed,
+	})
+	if err != nil {
+		return err
+	}
+	view, err := idx.View(ctx)
+	if err != nil {
+		return err
+	}
+
+	document, err := api.OpenAPIDocument(view, version)
+	if err != nil {
+		return err
+	}
+
+	enc := json.NewEncoder(os.Stdout)
+	enc.SetIndent("", "  ")
+	return enc.Encode(document)
+}
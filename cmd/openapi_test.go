@@ -0,0 +1,42 @@
+// SPDX-FileCopyrightText: © 2026 Binaek Sarkar <binaek89@gmail.com>
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+)
+
+func (s *CmdTestSuite) TestOpenAPICmdDescribesThePack() {
+	dir := s.writeTestPack(map[string]string{"policy.sentrie": factContractPolicy})
+	args := []string{"sentrie", "openapi", "--pack-location", dir}
+
+	var runErr error
+	out := s.captureStdout(func() {
+		runErr = Execute(s.ctx(), Setup(context.Background(), "test"), args)
+	})
+	s.Require().NoError(runErr)
+
+	var document struct {
+		OpenAPI    string         `json:"openapi"`
+		Info       map[string]any `json:"info"`
+		Paths      map[string]any `json:"paths"`
+		Components struct {
+			Schemas map[string]struct {
+				Required   []string                  `json:"required"`
+				Properties map[string]map[string]any `json:"properties"`
+			} `json:"schemas"`
+		} `json:"components"`
+	}
+	s.Require().NoError(json.Unmarshal([]byte(out), &document))
+	s.Equal("3.1.0", document.OpenAPI)
+	s.Equal("test", document.Info["version"])
+	s.Contains(document.Paths, "/decision/com/example/onboarding")
+	s.Contains(document.Paths, "/batch/com/example/onboarding/allow")
+
+	facts := document.Components.Schemas["facts.com.example.onboarding"]
+	s.Equal([]string{"age", "email", "region"}, facts.Required)
+	s.Equal(map[string]any{"type": "number", "minimum": float64(18)}, facts.Properties["age"])
+	s.Equal([]any{"eu", "us"}, facts.Properties["region"]["enum"])
+}
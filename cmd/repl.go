@@ -0,0 +1,406 @@
+// SPDX-FileCopyrightText: © 2026 Binaek Sarkar <binaek89@gmail.com>
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"maps"
+	"os"
+	"slices"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/binaek/cling"
+	"github.com/sentrie-sh/sentrie/ast"
+	"github.com/sentrie-sh/sentrie/box"
+	"github.com/sentrie-sh/sentrie/parser"
+	"github.com/sentrie-sh/sentrie/runtime"
+)
+
+const (
+	replPrompt             = "> "
+	replContinuationPrompt = "... "
+)
+
+const replHelp = `Enter an expression to evaluate it in the scope of the policy, or a use statement
+to bring the functions of a module into scope. An expression spans several lines
+until its brackets are balanced.
+
+  :fact <name> = <json>  set the value of a fact
+  :unset <name>          remove the value of a fact
+  :facts                 print the values of the facts
+  :policy <ns/policy>    evaluate in the scope of another policy
+  :trace on|off          print the evaluation trace of each expression
+  :history               print the inputs evaluated so far
+  :help                  print this help
+  :quit                  leave the REPL
+`
+
+func addReplCmd(cli *cling.CLI) {
+	cli.WithCommand(
+		withIndexSourceFlags(cling.NewCommand("repl", replCmd).
+			WithDescription("Evaluate expressions interactively in the scope of a policy").
+			WithArgument(cling.NewStringCmdInput("policy").
+				WithDescription("Policy to evaluate in, e.g. com/example/auth").
+				AsArgument(),
+			).
+			WithFlag(cling.
+				NewStringCmdInput("pack-location").
+				WithDefault(".").
+				WithDescription("Pack directory to load").
+				AsFlag(),
+			).
+			WithFlag(cling.
+				NewBoolCmdInput("warn-shadowed-fields").
+				WithDefault(false).
+				WithDescription("Report a shape field redefining a composed field without 'override' as a warning instead of an error").
+				AsFlag(),
+			).
+			WithFlag(cling.
+				NewStringCmdInput("fact-file").
+				WithDefault("").
+				WithDescription("File to load the initial facts from").
+				AsFlag(),
+			).
+			WithFlag(cling.
+				NewStringCmdInput("facts").
+				WithDefault("{}").
+				WithDescription("Initial facts to evaluate with").
+				AsFlag(),
+			).
+			WithFlag(cling.
+				NewBoolCmdInput("trace").
+				WithDefault(false).
+				WithDescription("Print the evaluation trace of each expression").
+				AsFlag(),
+			)),
+	)
+}
+
+type replCmdArgs struct {
+	Policy       string   `cling-name:"policy"`
+	PackLocation string   `cling-name:"pack-location"`
+	PolicyRoots  []string `cling-name:"policy-root"`
+	NoOverride   bool     `cling-name:"no-override"`
+	WarnShadowed bool     `cling-name:"warn-shadowed-fields"`
+	Facts        string   `cling-name:"facts"`
+	FactFile     string   `cling-name:"fact-file"`
+	Trace        bool     `cling-name:"trace"`
+}
+
+// replCmd reads expressions from stdin and prints what they evaluate to in the scope of a
+// policy, until stdin ends or :quit is entered. Errors are printed and do not end the session.
+func replCmd(ctx context.Context, args []string) error {
+	input := replCmdArgs{}
+	if err := cling.Hydrate(ctx, args, &input); err != nil {
+		return err
+	}
+
+	facts, err := loadFacts(input.FactFile, input.Facts)
+	if err != nil {
+		return err
+	}
+
+	src := indexSource{
+		PackLocation:    input.PackLocation,
+		PolicyRoots:     input.PolicyRoots,
+		NoOverride:      input.NoOverride,
+		WarnShadowed:    input.WarnShadowed,
+		ExecutorOptions: executorOptionsOf(ctx),
+	}
+	idx, err := loadIndex(ctx, src)
+	if err != nil {
+		return err
+	}
+
+	if err := reportWarnings(os.Stderr, idx.Warnings(), false); err != nil {
+		return err
+	}
+
+	view, err := idx.View(ctx)
+	if err != nil {
+		return err
+	}
+
+	exec, err := src.newExecutor(view)
+	if err != nil {
+		return err
+	}
+
+	evaluator, ok := exec.(replExecutor)
+	if !ok {
+		return fmt.Errorf("the executor cannot evaluate expressions")
+	}
+
+	session := &replSession{exec: evaluator, facts: facts, trace: input.Trace, out: os.Stdout}
+	if err := session.setPolicy(input.Policy); err != nil {
+		return err
+	}
+	return session.run(ctx, os.Stdin)
+}
+
+// replExecutor is an executor that also evaluates expressions in the scope of a policy.
+type replExecutor interface {
+	runtime.Executor
+	runtime.ExpressionEvaluator
+}
+
+// replSession is the state of a REPL: the policy expressions are evaluated in, the facts they
+// are evaluated with and the modules they may call.
+type replSession struct {
+	exec      replExecutor
+	namespace string
+	policy    string
+	facts     map[string]any
+	uses      []*ast.UseStatement
+	trace     bool
+	history   []string
+	out       io.Writer
+}
+
+// run evaluates every input read from in. An input continues over the following lines while
+// its brackets are not balanced.
+func (s *replSession) run(ctx context.Context, in io.Reader) error {
+	scanner := bufio.NewScanner(in)
+	var pending []string
+	fmt.Fprint(s.out, replPrompt)
+	for scanner.Scan() {
+		pending = append(pending, scanner.Text())
+		input := strings.Join(pending, "\n")
+		if bracketDepth(input) > 0 {
+			fmt.Fprint(s.out, replContinuationPrompt)
+			continue
+		}
+		pending = nil
+
+		if quit := s.handle(ctx, input); quit {
+			return nil
+		}
+		fmt.Fprint(s.out, replPrompt)
+	}
+	fmt.Fprintln(s.out)
+	return scanner.Err()
+}
+
+// handle processes a complete input, and reports whether the session should end.
+func (s *replSession) handle(ctx context.Context, input string) bool {
+	trimmed := strings.TrimSpace(input)
+	switch {
+	case trimmed == "":
+		return false
+	case trimmed == ":quit" || trimmed == ":exit":
+		return true
+	case strings.HasPrefix(trimmed, ":"):
+		if err := s.command(trimmed); err != nil {
+			fmt.Fprintf(s.out, "error: %s\n", err)
+		}
+		return false
+	}
+
+	s.history = append(s.history, input)
+	if isUseStatement(trimmed) {
+		s.use(ctx, input)
+		return false
+	}
+	s.eval(ctx, input)
+	return false
+}
+
+// command runs a REPL command, such as :fact.
+func (s *replSession) command(line string) error {
+	name, rest, _ := strings.Cut(line, " ")
+	rest = strings.TrimSpace(rest)
+	switch name {
+	case ":help":
+		fmt.Fprint(s.out, replHelp)
+	case ":fact":
+		fact, raw, ok := strings.Cut(rest, "=")
+		fact = strings.TrimSpace(fact)
+		if !ok || fact == "" {
+			return errors.New("usage: :fact <name> = <json>")
+		}
+		if err := s.requireFact(fact); err != nil {
+			return err
+		}
+		var value any
+		if err := json.Unmarshal([]byte(raw), &value); err != nil {
+			return fmt.Errorf("value of fact '%s' is not valid JSON: %w", fact, err)
+		}
+		s.facts[fact] = value
+	case ":unset":
+		if err := s.requireFact(rest); err != nil {
+			return err
+		}
+		delete(s.facts, rest)
+	case ":facts":
+		return s.printFacts()
+	case ":policy":
+		return s.setPolicy(rest)
+	case ":trace":
+		switch rest {
+		case "on":
+			s.trace = true
+		case "off":
+			s.trace = false
+		default:
+			return errors.New("usage: :trace on|off")
+		}
+	case ":history":
+		for i, input := range s.history {
+			fmt.Fprintf(s.out, "%4d  %s\n", i+1, strings.ReplaceAll(input, "\n", "\n      "))
+		}
+	default:
+		return fmt.Errorf("unknown command %s, enter :help for the list of commands", name)
+	}
+	return nil
+}
+
+// setPolicy makes expressions evaluate in the scope of the policy at path.
+func (s *replSession) setPolicy(path string) error {
+	namespace, policy, rule, err := s.exec.Index().ResolveSegments(path)
+	if err != nil {
+		return err
+	}
+	if rule != "" {
+		return fmt.Errorf("%s is a rule, expected a policy", path)
+	}
+	if _, err := s.exec.Index().ResolvePolicy(namespace, policy); err != nil {
+		return err
+	}
+	s.namespace, s.policy = namespace, policy
+	return nil
+}
+
+// requireFact reports an error when the policy declares no fact named name.
+func (s *replSession) requireFact(name string) error {
+	p, err := s.exec.Index().ResolvePolicy(s.namespace, s.policy)
+	if err != nil {
+		return err
+	}
+	if _, ok := p.Facts[name]; !ok {
+		return fmt.Errorf("policy %s/%s declares no fact '%s'", s.namespace, s.policy, name)
+	}
+	return nil
+}
+
+// printFacts prints the values of the facts as JSON, with those of sensitive facts redacted.
+func (s *replSession) printFacts() error {
+	p, err := s.exec.Index().ResolvePolicy(s.namespace, s.policy)
+	if err != nil {
+		return err
+	}
+	for _, name := range slices.Sorted(maps.Keys(s.facts)) {
+		value := s.facts[name]
+		if stmt, ok := p.Facts[name]; ok && stmt.Sensitive {
+			value = runtime.Redacted
+		}
+		encoded, err := json.Marshal(value)
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(s.out, "%s = %s\n", name, encoded)
+	}
+	return nil
+}
+
+// use parses a use statement and brings its module into scope, in place of one used as the
+// same alias.
+func (s *replSession) use(ctx context.Context, input string) {
+	stmt, err := parser.NewParserFromString(input, "repl").ParseUse(ctx)
+	if err != nil {
+		s.printError(input, err)
+		return
+	}
+	s.uses = slices.DeleteFunc(s.uses, func(u *ast.UseStatement) bool { return u.As == stmt.As })
+	s.uses = append(s.uses, stmt)
+}
+
+// eval parses and evaluates an expression, and prints its value and, if asked for, its trace.
+func (s *replSession) eval(ctx context.Context, input string) {
+	expr, err := parser.NewParserFromString(input, "repl").ParseExpression(ctx)
+	if err != nil {
+		s.printError(input, err)
+		return
+	}
+
+	v, node, err := s.exec.EvalExpression(ctx, s.namespace, s.policy, s.facts, s.uses, expr)
+	if s.trace && node != nil {
+		fmt.Fprint(s.out, node.Render())
+	}
+	if err != nil {
+		s.printError(input, err)
+		return
+	}
+	fmt.Fprintln(s.out, formatReplValue(v))
+}
+
+// printError prints err. A parse error is preceded by the line of input it is at, with a caret
+// under where it starts.
+func (s *replSession) printError(input string, err error) {
+	var parseErr *parser.ParseError
+	if errors.As(err, &parseErr) {
+		offset := min(max(parseErr.Range.From.Offset, 0), len(input))
+		lineStart := strings.LastIndex(input[:offset], "\n") + 1
+		lineEnd := len(input)
+		if i := strings.Index(input[offset:], "\n"); i >= 0 {
+			lineEnd = offset + i
+		}
+		fmt.Fprintf(s.out, "  %s\n  %s^\n", input[lineStart:lineEnd], strings.Repeat(" ", utf8.RuneCountInString(input[lineStart:offset])))
+	}
+	fmt.Fprintf(s.out, "error: %s\n", err)
+}
+
+// formatReplValue renders v as JSON. Trinaries, undefined and callables, which JSON cannot tell
+// apart from other values, are rendered as their string form.
+func formatReplValue(v box.Value) string {
+	switch v.Kind() {
+	case box.ValueTrinary, box.ValueUndefined, box.ValueCallable:
+		return v.String()
+	}
+	encoded, err := json.Marshal(v)
+	if err != nil {
+		return v.String()
+	}
+	return string(encoded)
+}
+
+// isUseStatement reports whether input starts with the use keyword.
+func isUseStatement(input string) bool {
+	rest, ok := strings.CutPrefix(input, "use")
+	return ok && (rest == "" || strings.IndexAny(rest[:1], " \t\n{") == 0)
+}
+
+// bracketDepth returns how many of the brackets opened in input are not closed, ignoring those
+// in strings and comments.
+func bracketDepth(input string) int {
+	depth := 0
+	inString := false
+	for i := 0; i < len(input); i++ {
+		c := input[i]
+		switch {
+		case inString && c == '\\':
+			i++
+		case c == '"':
+			inString = !inString
+		case inString:
+		case c == '-' && strings.HasPrefix(input[i:], "--"):
+			// a line comment runs to the end of its line
+			end := strings.IndexByte(input[i:], '\n')
+			if end < 0 {
+				return depth
+			}
+			i += end
+		case c == '(' || c == '[' || c == '{':
+			depth++
+		case c == ')' || c == ']' || c == '}':
+			depth--
+		}
+	}
+	return depth
+}
@@ -0,0 +1,84 @@
+// SPDX-FileCopyrightText: © 2026 Binaek Sarkar <binaek89@gmail.com>
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+const replPolicy = `namespace com/example
+policy access {
+  fact user: dict[any]
+  fact sensitive token?: string
+  let adult = user.age >= 18
+  rule allow = default false { yield adult }
+  export decision of allow
+}
+`
+
+// withStdin runs fn with stdin reading input.
+func (s *CmdTestSuite) withStdin(input string, fn func()) {
+	s.T().Helper()
+	path := filepath.Join(s.T().TempDir(), "stdin")
+	s.Require().NoError(os.WriteFile(path, []byte(input), 0o600))
+	f, err := os.Open(path)
+	s.Require().NoError(err)
+	defer f.Close()
+
+	oldStdin := os.Stdin
+	os.Stdin = f
+	defer func() { os.Stdin = oldStdin }()
+	fn()
+}
+
+func (s *CmdTestSuite) TestReplCmdEvaluatesExpressions() {
+	dir := s.writeTestPack(map[string]string{"policy.sentrie": replPolicy})
+	args := []string{"sentrie", "repl", "com/example/access", "--pack-location", dir, "--facts", `{"user": {"age": 30}}`}
+	script := `user.age * 2
+adult and allow
+1 + * 2
+missing + 1
+:fact user = {"age": 12, "roles": ["admin"]}
+:fact nope = 1
+:fact token = "s3cr3t"
+:facts
+[
+  user.age,
+  token
+]
+use { trim, to_upper } from @sentrie/std as std
+std.to_upper(std.trim("  hi  "))
+:trace on
+user.age
+:history
+:quit
+user.age
+`
+
+	var runErr error
+	out := s.captureStdout(func() {
+		s.withStdin(script, func() {
+			runErr = Execute(s.ctx(), Setup(context.Background(), "test"), args)
+		})
+	})
+	s.Require().NoError(runErr)
+
+	s.Contains(out, "> 60\n")
+	s.Contains(out, "> true\n")
+	s.Contains(out, "  1 + * 2\n      ^\nerror: parsing error at ")
+	s.Contains(out, "> error: identifier not found: missing\n")
+	s.Contains(out, "error: policy com/example/access declares no fact 'nope'\n")
+	s.Contains(out, "token = \"***\"\nuser = {\"age\":12,\"roles\":[\"admin\"]}\n")
+	// a value computed from the sensitive fact is hidden whole
+	s.Contains(out, "> ... ... ... \"***\"\n")
+	s.Contains(out, "> \"HI\"\n")
+	s.Contains(out, "> field_access user.age [repl:1:4-7] => 12\n  identifier user [repl:1:0-4] => ")
+	s.Contains(out, "   1  user.age * 2\n")
+	s.Contains(out, "   5  [\n        user.age,\n        token\n      ]\n")
+	s.NotContains(out, "s3cr3t")
+	s.Equal(1, strings.Count(out, "\n12\n"), out)
+}
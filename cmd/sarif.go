@@ -0,0 +1,235 @@
+// SPDX-FileCopyrightText: © 2026 Binaek Sarkar <binaek89@gmail.com>
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/url"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/sentrie-sh/sentrie/index"
+	"github.com/sentrie-sh/sentrie/parser"
+	"github.com/sentrie-sh/sentrie/tokens"
+	"github.com/sentrie-sh/sentrie/xerr"
+)
+
+const (
+	sarifVersion = "2.1.0"
+	sarifSchema  = "https://json.schemastore.org/sarif-2.1.0.json"
+	// sarifPackRoot is the uriBaseId that artifact locations inside the pack are relative to
+	sarifPackRoot = "PACKROOT"
+)
+
+// sarifRules describes every diagnostic code the validator can report.
+var sarifRules = map[string]string{
+	index.CodeUnusedFact:      "A fact is declared but never referenced in its policy",
+	index.CodeConflict:        "Two declarations conflict with each other",
+	index.CodeShadowedField:   "A shape field redefines a composed field without 'override'",
+	index.CodeValidationError: "The pack failed to load or validate",
+	index.CodeParseError:      "A policy file is not valid Sentrie",
+}
+
+type sarifLog struct {
+	Version string     `json:"version"`
+	Schema  string     `json:"$schema"`
+	Runs    []sarifRun `json:"runs"`
+}
+
+type sarifRun struct {
+	Tool               sarifTool                        `json:"tool"`
+	OriginalURIBaseIDs map[string]sarifArtifactLocation `json:"originalUriBaseIds,omitempty"`
+	Results            []sarifResult                    `json:"results"`
+}
+
+type sarifTool struct {
+	Driver sarifDriver `json:"driver"`
+}
+
+type sarifDriver struct {
+	Name           string      `json:"name"`
+	InformationURI string      `json:"informationUri,omitempty"`
+	Rules          []sarifRule `json:"rules"`
+}
+
+type sarifRule struct {
+	ID               string       `json:"id"`
+	ShortDescription sarifMessage `json:"shortDescription"`
+}
+
+type sarifResult struct {
+	RuleID    string          `json:"ruleId"`
+	RuleIndex int             `json:"ruleIndex"`
+	Level     string          `json:"level"`
+	Message   sarifMessage    `json:"message"`
+	Locations []sarifLocation `json:"locations,omitempty"`
+}
+
+type sarifMessage struct {
+	Text string `json:"text"`
+}
+
+type sarifLocation struct {
+	PhysicalLocation sarifPhysicalLocation `json:"physicalLocation"`
+}
+
+type sarifPhysicalLocation struct {
+	ArtifactLocation sarifArtifactLocation `json:"artifactLocation"`
+	Region           *sarifRegion          `json:"region,omitempty"`
+}
+
+type sarifArtifactLocation struct {
+	URI       string `json:"uri"`
+	URIBaseID string `json:"uriBaseId,omitempty"`
+}
+
+type sarifRegion struct {
+	StartLine   int `json:"startLine"`
+	StartColumn int `json:"startColumn,omitempty"`
+	EndLine     int `json:"endLine,omitempty"`
+	EndColumn   int `json:"endColumn,omitempty"`
+}
+
+// errorDiagnostic turns a fatal load or validation error into a diagnostic, locating it
+// when the error carries where it was raised: a conflict, a syntax error or a located index
+// error carries its range. Other load and validation errors only name their position in the
+// message, so they are reported without a location.
+func errorDiagnostic(err error) index.Diagnostic {
+	diagnostic := index.Diagnostic{
+		Severity: index.SeverityError,
+		Code:     index.CodeValidationError,
+		Message:  err.Error(),
+		Range:    tokens.BadRange(""),
+	}
+	var conflict xerr.ConflictError
+	var parseErr *parser.ParseError
+	var located xerr.LocatedError
+	switch {
+	case errors.As(err, &conflict):
+		diagnostic.Code = index.CodeConflict
+		diagnostic.Range = conflict.Where()
+	case errors.As(err, &parseErr):
+		diagnostic.Code = index.CodeParseError
+		diagnostic.Range = parseErr.Range
+	case errors.As(err, &located):
+		diagnostic.Range = located.Where()
+	}
+	return diagnostic
+}
+
+// sarifArtifactOf locates file relative to the pack root when it lies inside the pack, and
+// by its absolute file URI otherwise.
+func sarifArtifactOf(packRoot, file string) sarifArtifactLocation {
+	abs, err := filepath.Abs(file)
+	if err != nil {
+		return sarifArtifactLocation{URI: filepath.ToSlash(file)}
+	}
+	if rel, err := filepath.Rel(packRoot, abs); err == nil && rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return sarifArtifactLocation{URI: filepath.ToSlash(rel), URIBaseID: sarifPackRoot}
+	}
+	return sarifArtifactLocation{URI: fileURI(abs)}
+}
+
+// fileURI returns the file URI of the absolute path abs. A directory's URI ends with a slash.
+func fileURI(abs string) string {
+	path := filepath.ToSlash(abs)
+	if !strings.HasPrefix(path, "/") {
+		// a Windows drive path
+		path = "/" + path
+	}
+	return (&url.URL{Scheme: "file", Path: path}).String()
+}
+
+// sarifRegionOf maps a zero-based line range onto the one-based lines and columns SARIF expects.
+func sarifRegionOf(rng tokens.Range) *sarifRegion {
+	if rng.From.IsBadPos() || rng.From.Line < 0 {
+		return nil
+	}
+	region := &sarifRegion{
+		StartLine:   rng.From.Line + 1,
+		StartColumn: max(rng.From.Column, 1),
+	}
+	if !rng.To.IsBadPos() && rng.To.Column > 0 && rng.To.Line >= rng.From.Line {
+		region.EndLine = rng.To.Line + 1
+		region.EndColumn = rng.To.Column
+	}
+	return region
+}
+
+func sarifLevel(severity index.Severity) string {
+	if severity == index.SeverityError {
+		return "error"
+	}
+	return "warning"
+}
+
+// reportSARIF writes the index warnings, and the errors that stopped validation if any, as
+// SARIF. The error is still returned so that the command fails.
+func reportSARIF(w io.Writer, packRoot string, idx *index.Index, err error, failOnWarning bool) error {
+	return reportDiagnostics(idx, err, failOnWarning, func(diagnostics []index.Diagnostic) error {
+		return writeSARIF(w, packRoot, diagnostics)
+	})
+}
+
+// writeSARIF writes the diagnostics as a SARIF 2.1.0 log with a single run. Files inside
+// packRoot are located relative to it, through the PACKROOT base.
+func writeSARIF(w io.Writer, packRoot string, diagnostics []index.Diagnostic) error {
+	root, err := filepath.Abs(packRoot)
+	if err != nil {
+		return err
+	}
+
+	ruleIDs := make([]string, 0, len(sarifRules))
+	for id := range sarifRules {
+		ruleIDs = append(ruleIDs, id)
+	}
+	slices.Sort(ruleIDs)
+
+	rules := make([]sarifRule, 0, len(ruleIDs))
+	for _, id := range ruleIDs {
+		rules = append(rules, sarifRule{ID: id, ShortDescription: sarifMessage{Text: sarifRules[id]}})
+	}
+
+	results := make([]sarifResult, 0, len(diagnostics))
+	for _, diagnostic := range diagnostics {
+		result := sarifResult{
+			RuleID:    diagnostic.Code,
+			RuleIndex: slices.Index(ruleIDs, diagnostic.Code),
+			Level:     sarifLevel(diagnostic.Severity),
+			Message:   sarifMessage{Text: diagnostic.Message},
+		}
+		if diagnostic.Range.File != "" {
+			result.Locations = []sarifLocation{{
+				PhysicalLocation: sarifPhysicalLocation{
+					ArtifactLocation: sarifArtifactOf(root, diagnostic.Range.File),
+					Region:           sarifRegionOf(diagnostic.Range),
+				},
+			}}
+		}
+		results = append(results, result)
+	}
+
+	log := sarifLog{
+		Version: sarifVersion,
+		Schema:  sarifSchema,
+		Runs: []sarifRun{{
+			Tool: sarifTool{Driver: sarifDriver{
+				Name:           "sentrie",
+				InformationURI: "https://sentrie.sh",
+				Rules:          rules,
+			}},
+			OriginalURIBaseIDs: map[string]sarifArtifactLocation{
+				sarifPackRoot: {URI: fileURI(root + string(filepath.Separator))},
+			},
+			Results: results,
+		}},
+	}
+
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(log)
+}
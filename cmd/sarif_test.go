@@ -0,0 +1,157 @@
+// SPDX-FileCopyrightText: © 2026 Binaek Sarkar <binaek89@gmail.com>
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"path/filepath"
+	"strings"
+
+	"github.com/sentrie-sh/sentrie/index"
+	"github.com/sentrie-sh/sentrie/tokens"
+	"github.com/sentrie-sh/sentrie/xerr"
+)
+
+const duplicateFactPolicy = `namespace com/example
+policy auth {
+  fact user: string
+  fact user: string
+  rule allow = default true { yield user == "alice" }
+  export decision of allow
+}
+`
+
+func (s *CmdTestSuite) TestWriteSARIFMapsDiagnostics() {
+	where := tokens.NewRange("policy.sentrie", tokens.Pos{Line: 3, Column: 3}, tokens.Pos{Line: 3, Column: 19})
+	diagnostics := []index.Diagnostic{
+		{Severity: index.SeverityWarning, Code: index.CodeUnusedFact, Message: "fact 'user' is never referenced", Range: where},
+		errorDiagnostic(errors.New("boom")),
+	}
+
+	var out bytes.Buffer
+	s.Require().NoError(writeSARIF(&out, ".", diagnostics))
+
+	var log sarifLog
+	s.Require().NoError(json.Unmarshal(out.Bytes(), &log))
+	s.Equal("2.1.0", log.Version)
+	s.Require().Len(log.Runs, 1)
+	run := log.Runs[0]
+	s.Len(run.Tool.Driver.Rules, len(sarifRules))
+	s.Require().Len(run.Results, 2)
+
+	warning := run.Results[0]
+	s.Equal(index.CodeUnusedFact, warning.RuleID)
+	s.Equal(index.CodeUnusedFact, run.Tool.Driver.Rules[warning.RuleIndex].ID)
+	s.Equal("warning", warning.Level)
+	s.Require().Len(warning.Locations, 1)
+	s.Equal(sarifArtifactLocation{URI: "policy.sentrie", URIBaseID: sarifPackRoot}, warning.Locations[0].PhysicalLocation.ArtifactLocation)
+	s.Equal(&sarifRegion{StartLine: 4, StartColumn: 3, EndLine: 4, EndColumn: 19}, warning.Locations[0].PhysicalLocation.Region)
+
+	failure := run.Results[1]
+	s.Equal(index.CodeValidationError, failure.RuleID)
+	s.Equal("error", failure.Level)
+	s.Empty(failure.Locations)
+}
+
+func (s *CmdTestSuite) TestErrorDiagnosticLocatesConflicts() {
+	where := tokens.NewRange("policy.sentrie", tokens.Pos{Line: 3, Column: 3}, tokens.Pos{Line: 3, Column: 19})
+	err := xerr.ErrConflict("fact declaration", where, tokens.NewRange("policy.sentrie", tokens.Pos{Line: 2, Column: 3}, tokens.Pos{Line: 2, Column: 19}))
+
+	diagnostic := errorDiagnostic(err)
+	s.Equal(index.CodeConflict, diagnostic.Code)
+	s.Equal(index.SeverityError, diagnostic.Severity)
+	s.Equal(where, diagnostic.Range)
+}
+
+func (s *CmdTestSuite) TestValidateCmdWritesSARIF() {
+	dir := s.writeTestPack(map[string]string{"policy.sentrie": duplicateFactPolicy})
+
+	var runErr error
+	out := s.captureStdout(func() {
+		runErr = runValidateCLI(s.ctx(), []string{"--pack-location", dir, "--format", "sarif"})
+	})
+	s.Require().Error(runErr)
+
+	var log sarifLog
+	s.Require().NoError(json.Unmarshal([]byte(out), &log))
+	s.Require().Len(log.Runs, 1)
+	s.Require().Len(log.Runs[0].Results, 1)
+
+	result := log.Runs[0].Results[0]
+	s.Equal(index.CodeConflict, result.RuleID)
+	s.Equal("error", result.Level)
+	s.Require().Len(result.Locations, 1)
+	s.Equal(sarifArtifactLocation{URI: "policy.sentrie", URIBaseID: sarifPackRoot}, result.Locations[0].PhysicalLocation.ArtifactLocation)
+	region := result.Locations[0].PhysicalLocation.Region
+	s.Require().NotNil(region)
+	s.Equal(4, region.StartLine)
+
+	root := log.Runs[0].OriginalURIBaseIDs[sarifPackRoot].URI
+	s.True(strings.HasPrefix(root, "file:///"), root)
+	s.True(strings.HasSuffix(root, "/"), root)
+}
+
+func (s *CmdTestSuite) TestSARIFLocatesFilesOutsideThePackByFileURI() {
+	outside := filepath.Join(s.T().TempDir(), "shared.sentrie")
+	location := sarifArtifactOf(s.T().TempDir(), outside)
+	s.Empty(location.URIBaseID)
+	s.Equal("file://"+filepath.ToSlash(outside), location.URI)
+}
+
+func (s *CmdTestSuite) TestValidateCmdLocatesParseErrorsInSARIF() {
+	dir := s.writeTestPack(map[string]string{"policy.sentrie": "namespace com/example\npolicy auth {\n  rule allow = default true { yield ) }\n}\n"})
+
+	var runErr error
+	out := s.captureStdout(func() {
+		runErr = runValidateCLI(s.ctx(), []string{"--pack-location", dir, "--format", "sarif"})
+	})
+	s.Require().Error(runErr)
+
+	var log sarifLog
+	s.Require().NoError(json.Unmarshal([]byte(out), &log))
+	s.Require().Len(log.Runs[0].Results, 1)
+	result := log.Runs[0].Results[0]
+	s.Equal(index.CodeParseError, result.RuleID)
+	s.Require().Len(result.Locations, 1)
+	s.Equal(sarifArtifactLocation{URI: "policy.sentrie", URIBaseID: sarifPackRoot}, result.Locations[0].PhysicalLocation.ArtifactLocation)
+	s.Require().NotNil(result.Locations[0].PhysicalLocation.Region)
+	s.Equal(3, result.Locations[0].PhysicalLocation.Region.StartLine)
+}
+
+const shadowedFieldPolicy = `namespace com/example
+shape Account {
+  id: string
+}
+shape ServiceAccount with Account {
+  id: number
+}
+policy access {
+  fact account: ServiceAccount
+  rule allow = default false { yield account.id > 0 }
+  export decision of allow
+}
+`
+
+func (s *CmdTestSuite) TestValidateCmdWritesShadowedFieldsAsSARIF() {
+	dir := s.writeTestPack(map[string]string{"policy.sentrie": shadowedFieldPolicy})
+
+	var runErr error
+	out := s.captureStdout(func() {
+		runErr = runValidateCLI(s.ctx(), []string{"--pack-location", dir, "--format", "sarif", "--warn-shadowed-fields"})
+	})
+	s.Require().NoError(runErr)
+
+	var log sarifLog
+	s.Require().NoError(json.Unmarshal([]byte(out), &log))
+	s.Require().Len(log.Runs, 1)
+	s.Require().Len(log.Runs[0].Results, 1)
+
+	result := log.Runs[0].Results[0]
+	s.Equal(index.CodeShadowedField, result.RuleID)
+	s.Equal("warning", result.Level)
+	s.Require().GreaterOrEqual(result.RuleIndex, 0)
+	s.Equal(index.CodeShadowedField, log.Runs[0].Tool.Driver.Rules[result.RuleIndex].ID)
+}
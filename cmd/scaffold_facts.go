@@ -0,0 +1,83 @@
+// SPDX-FileCopyrightText: © 2026 Binaek Sarkar <binaek89@gmail.com>
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+
+	"github.com/binaek/cling"
+	"github.com/sentrie-sh/sentrie/index"
+	"github.com/sentrie-sh/sentrie/loader"
+)
+
+func addScaffoldFactsCmd(cli *cling.CLI) {
+	cli.WithCommand(
+		withIndexSourceFlags(cling.NewCommand("scaffold-facts", scaffoldFactsCmd).
+			WithFlag(cling.
+				NewStringCmdInput("policy").
+				WithDescription("Policy to write example facts for, e.g. com/example/auth").
+				Required().
+				AsFlag(),
+			).
+			WithFlag(cling.
+				NewStringCmdInput("pack-location").
+				WithDefault(".").
+				WithDescription("Pack directory to load").
+				AsFlag(),
+			)),
+	)
+}
+
+type scaffoldFactsCmdArgs struct {
+	Policy       string   `cling-name:"policy"`
+	PackLocation string   `cling-name:"pack-location"`
+	PolicyRoots  []string `cling-name:"policy-root"`
+	NoOverride   bool     `cling-name:"no-override"`
+}
+
+// scaffoldFactsCmd writes a JSON object with a placeholder for every fact of a policy to stdout.
+// See index.Index.ExampleFacts.
+func scaffoldFactsCmd(ctx context.Context, args []string) error {
+	input := scaffoldFactsCmdArgs{}
+	if err := cling.Hydrate(ctx, args, &input); err != nil {
+		return err
+	}
+
+	pack, err := loader.LoadPack(ctx, input.PackLocation)
+	if err != nil {
+		return err
+	}
+
+	idx := index.CreateIndex()
+
+	if err := idx.SetPack(ctx, pack); err != nil {
+		return err
+	}
+
+	programs, err := loader.LoadPolicyRoots(ctx, pack, input.PolicyRoots, !input.NoOverride)
+	if err != nil {
+		return err
+	}
+
+	for _, program := range programs {
+		if err := idx.AddProgram(ctx, program); err != nil {
+			return err
+		}
+	}
+
+	if err := idx.Validate(ctx); err != nil {
+		return err
+	}
+
+	facts, err := idx.ExampleFacts(input.Policy)
+	if err != nil {
+		return err
+	}
+
+	enc := json.NewEncoder(os.Stdout)
+	enc.SetIndent("", "  ")
+	return enc.Encode(facts)
+}
@@ -0,0 +1,27 @@
+// SPDX-FileCopyrightText: © 2026 Binaek Sarkar <binaek89@gmail.com>
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+)
+
+func (s *CmdTestSuite) TestScaffoldFactsCmdOutputPassesValidateFacts() {
+	dir := s.writeTestPack(map[string]string{"policy.sentrie": factContractPolicy})
+	args := []string{"sentrie", "scaffold-facts", "--pack-location", dir, "--policy", "com/example/onboarding"}
+
+	var runErr error
+	out := s.captureStdout(func() {
+		runErr = Execute(s.ctx(), Setup(context.Background(), "test"), args)
+	})
+	s.Require().NoError(runErr)
+
+	var facts map[string]any
+	s.Require().NoError(json.Unmarshal([]byte(out), &facts))
+	s.Equal(map[string]any{"email": "user@example.com", "age": float64(18), "region": "eu"}, facts)
+
+	s.NoError(Execute(s.ctx(), Setup(context.Background(), "test"),
+		[]string{"sentrie", "validate-facts", "--pack-location", dir, "--policy", "com/example/onboarding", "--facts", out}))
+}
@@ -18,94 +18,298 @@ package cmd
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"time"
 
 	"github.com/binaek/cling"
 	"github.com/sentrie-sh/sentrie/api"
 	"github.com/sentrie-sh/sentrie/index"
-	"github.com/sentrie-sh/sentrie/loader"
 	"github.com/sentrie-sh/sentrie/runtime"
 )
 
-func addServeCmd(cli *cling.CLI) {
+func addServeCmd(cli *cling.CLI, version string) {
 	cli.WithCommand(
-		cling.NewCommand("serve", serveCmd).
+		withIndexSourceFlags(cling.NewCommand("serve", func(ctx context.Context, args []string) error {
+			return serveCmd(ctx, args, version)
+		}).
 			WithFlag(cling.
 				NewIntCmdInput("http-port").
 				WithDefault(7529 /* PLCY - keypad */).
 				WithDescription("HTTP port to listen on").
 				AsFlag(),
 			).
+			WithFlag(cling.
+				NewIntCmdInput("metrics-port").
+				WithDefault(0).
+				WithDescription("Port to serve Prometheus metrics at /metrics on, on the --http-listen addresses; 0 disables metrics").
+				AsFlag(),
+			).
 			WithFlag(cling.
 				NewStringCmdInput("pack-location").
 				WithDefault("./").
 				WithDescription("Pack directory to serve").
 				AsFlag(),
 			).
+			WithFlag(cling.
+				NewStringCmdInput("plans").
+				WithDefault("").
+				WithDescription("Plans file built by 'sentrie compile' to read the policies from instead of parsing them; they are still validated").
+				AsFlag(),
+			).
+			WithFlag(cling.
+				NewBoolCmdInput("warn-shadowed-fields").
+				WithDefault(false).
+				WithDescription("Report a shape field redefining a composed field as a warning instead of an error").
+				AsFlag(),
+			).
 			WithFlag(cling.
 				NewCmdSliceInput[string]("http-listen").
 				WithDefault([]string{"local"}).
 				WithDescription("HTTP address(es) to listen on").
 				AsFlag(),
-			),
+			).
+			WithFlag(cling.
+				NewIntCmdInput("grpc-port").
+				WithDefault(0).
+				WithDescription("Port to serve the gRPC evaluation service on, on the --grpc-listen addresses; 0 disables gRPC").
+				AsFlag(),
+			).
+			WithFlag(cling.
+				NewCmdSliceInput[string]("grpc-listen").
+				WithDefault([]string{"local"}).
+				WithDescription("gRPC address(es) to listen on").
+				AsFlag(),
+			).
+			WithFlag(cling.
+				NewIntCmdInput("max-request-body").
+				WithDefault(api.DefaultMaxRequestBody).
+				WithDescription("Largest request body, in bytes, accepted by the decision endpoint; larger bodies get 413 Payload Too Large").
+				AsFlag(),
+			).
+			WithFlag(cling.
+				NewIntCmdInput("max-concurrent-evaluations").
+				WithDefault(api.DefaultMaxConcurrentEvaluations).
+				WithDescription("Evaluations run at once; further requests get 503 Service Unavailable with Retry-After").
+				AsFlag(),
+			).
+			WithFlag(cling.
+				NewIntCmdInput("batch-concurrency").
+				WithDefault(api.DefaultBatchConcurrency).
+				WithDescription("Inputs of a batch evaluated at once by the batch endpoint").
+				AsFlag(),
+			).
+			WithFlag(cling.
+				NewIntCmdInput("result-cache-size").
+				WithDefault(0).
+				WithDescription("Megabytes of memory for caching decisions by policy and facts; 0 disables the cache").
+				AsFlag(),
+			).
+			WithFlag(cling.
+				NewStringCmdInput("result-cache-ttl").
+				WithDefault("1m").
+				WithDescription("How long a cached decision is served, e.g. 30s or 5m; 0 keeps it until evicted").
+				AsFlag(),
+			).
+			WithFlag(cling.
+				NewStringCmdInput("audit-log").
+				WithDefault("").
+				WithDescription("File to append a JSON audit record of every evaluation to").
+				AsFlag(),
+			).
+			WithFlag(cling.
+				NewIntCmdInput("compile-after").
+				WithDefault(0).
+				WithDescription("Compile the rules of a policy once it has been evaluated this many times; 0 never compiles").
+				AsFlag(),
+			).
+			WithFlag(cling.
+				NewIntCmdInput("max-parallel-rules").
+				WithDefault(0).
+				WithDescription("Maximum exported rules of a policy evaluated at once; 0 evaluates all of them at once").
+				AsFlag(),
+			).
+			WithFlag(cling.
+				NewStringCmdInput("watch").
+				WithDefault("").
+				WithDescription("Directory to watch, reloading the policies when a file in it changes; a reload that fails keeps the policies being served. It must be the pack directory, a policy root, or a directory below one, as the policies are reloaded from those").
+				AsFlag(),
+			)),
 	)
 }
 
 type serveCmdArgs struct {
 	Port         int      `cling-name:"http-port"`
+	MetricsPort  int      `cling-name:"metrics-port"`
 	PackLocation string   `cling-name:"pack-location"`
+	PolicyRoots  []string `cling-name:"policy-root"`
+	NoOverride   bool     `cling-name:"no-override"`
+	WarnShadowed bool     `cling-name:"warn-shadowed-fields"`
+	Plans        string   `cling-name:"plans"`
 	Listen       []string `cling-name:"http-listen"`
+	GRPCPort     int      `cling-name:"grpc-port"`
+	GRPCListen   []string `cling-name:"grpc-listen"`
+	MaxBody      int      `cling-name:"max-request-body"`
+	MaxInFlight  int      `cling-name:"max-concurrent-evaluations"`
+	BatchWorkers int      `cling-name:"batch-concurrency"`
+	CacheSize    int      `cling-name:"result-cache-size"`
+	CacheTTL     string   `cling-name:"result-cache-ttl"`
+	AuditLog     string   `cling-name:"audit-log"`
+	CompileAfter int      `cling-name:"compile-after"`
+	MaxParallel  int      `cling-name:"max-parallel-rules"`
+	Watch        string   `cling-name:"watch"`
 }
 
-func serveCmd(ctx context.Context, args []string) error {
+func serveCmd(ctx context.Context, args []string, version string) error {
 	input := serveCmdArgs{}
 	if err := cling.Hydrate(ctx, args, &input); err != nil {
 		return err
 	}
-
-	pack, err := loader.LoadPack(ctx, input.PackLocation)
-	if err != nil {
-		return err
+	if input.MaxBody <= 0 {
+		return errors.New("--max-request-body must be positive")
 	}
-
-	idx := index.CreateIndex()
-
-	if err := idx.SetPack(ctx, pack); err != nil {
-		return err
+	if input.MaxInFlight <= 0 {
+		return errors.New("--max-concurrent-evaluations must be positive")
 	}
-
-	programs, err := loader.LoadPrograms(ctx, pack)
+	if input.BatchWorkers <= 0 {
+		return errors.New("--batch-concurrency must be positive")
+	}
+	if input.CacheSize < 0 {
+		return errors.New("--result-cache-size must not be negative")
+	}
+	if input.MaxParallel < 0 {
+		return errors.New("--max-parallel-rules must not be negative")
+	}
+	if input.CompileAfter < 0 {
+		return errors.New("--compile-after must not be negative")
+	}
+	cacheTTL, err := time.ParseDuration(input.CacheTTL)
 	if err != nil {
-		return err
+		return fmt.Errorf("invalid --result-cache-ttl: %w", err)
 	}
-
-	for _, program := range programs {
-		if ctx.Err() != nil {
-			return ctx.Err()
-		}
-		if err := idx.AddProgram(ctx, program); err != nil {
+	if input.MetricsPort < 0 {
+		return errors.New("--metrics-port must not be negative")
+	}
+	if input.MetricsPort != 0 && input.MetricsPort == input.Port {
+		return errors.New("--metrics-port must differ from --http-port")
+	}
+	if input.GRPCPort < 0 {
+		return errors.New("--grpc-port must not be negative")
+	}
+	if input.GRPCPort != 0 && (input.GRPCPort == input.Port || input.GRPCPort == input.MetricsPort) {
+		return errors.New("--grpc-port must differ from --http-port and --metrics-port")
+	}
+	if input.Plans != "" && len(input.PolicyRoots) > 0 {
+		return errors.New("--plans cannot be combined with --policy-root; compile the policy roots into the plans instead")
+	}
+	if input.Plans != "" && input.Watch != "" {
+		return errors.New("--plans cannot be combined with --watch; plans are not reloaded")
+	}
+	if input.Watch != "" {
+		if err := checkWatchedDir(input.Watch, input.PackLocation, input.PolicyRoots); err != nil {
 			return err
 		}
 	}
 
-	if err := idx.Validate(ctx); err != nil {
-		return err
+	src := indexSource{
+		PackLocation: input.PackLocation,
+		PolicyRoots:  input.PolicyRoots,
+		NoOverride:   input.NoOverride,
+		WarnShadowed: input.WarnShadowed,
+		Plans:        input.Plans,
+
+		ExecutorOptions: executorOptionsOf(ctx),
+	}
+	if input.CacheSize > 0 {
+		src.ExecutorOptions = append(src.ExecutorOptions, runtime.WithResultCache(runtime.NewResultCache(input.CacheSize, cacheTTL)))
+	}
+	if input.CompileAfter > 0 {
+		src.ExecutorOptions = append(src.ExecutorOptions, runtime.WithCompileAfter(input.CompileAfter))
+	}
+	if input.MaxParallel > 0 {
+		src.ExecutorOptions = append(src.ExecutorOptions, runtime.WithRuleParallelism(input.MaxParallel))
 	}
 
-	exec, err := runtime.NewExecutor(idx)
-	if err != nil {
-		return err
+	apiOpts := []api.HTTPAPIOption{
+		api.WithMaxRequestBody(int64(input.MaxBody)),
+		api.WithMaxConcurrentEvaluations(input.MaxInFlight),
+		api.WithBatchConcurrency(input.BatchWorkers),
+		api.WithVersion(version),
+	}
+	if input.MetricsPort > 0 {
+		apiOpts = append(apiOpts, api.WithMetrics(api.NewMetrics(), input.MetricsPort))
+	}
+	var auditLog *api.AuditLog
+	if input.AuditLog != "" {
+		f, err := os.OpenFile(input.AuditLog, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o600)
+		if err != nil {
+			return fmt.Errorf("cannot open audit log: %w", err)
+		}
+		defer f.Close()
+		auditLog = api.NewAuditLog(f, api.DefaultAuditBuffer)
+		apiOpts = append(apiOpts, api.WithAuditLog(auditLog))
 	}
 
-	server := api.NewHTTPAPI(exec)
+	// the server answers liveness probes while the policies load, and is ready once they are
+	server := api.NewHTTPAPI(nil, apiOpts...)
 	if err := server.Setup(ctx, input.Port, input.Listen); err != nil {
-		return err
+		return errors.Join(err, closeAuditLog(auditLog))
+	}
+	if input.GRPCPort > 0 {
+		if err := server.SetupGRPC(ctx, input.GRPCPort, input.GRPCListen); err != nil {
+			return errors.Join(err, server.StopServer(ctx), closeAuditLog(auditLog))
+		}
 	}
 
 	go func() {
 		server.StartServer(ctx, input.Port, input.Listen)
 	}()
 
+	idx, err := loadExecutor(ctx, src, server)
+	if err != nil {
+		return errors.Join(err, server.StopServer(ctx), closeAuditLog(auditLog))
+	}
+
+	if input.Watch != "" {
+		watcher := &policyWatcher{dir: input.Watch, src: src, server: server, idx: idx, debounce: reloadDebounce}
+		go func() {
+			if err := watcher.watch(ctx); err != nil {
+				slog.ErrorContext(ctx, "policies are not reloaded", slog.Any("error", err))
+			}
+		}()
+	}
+
 	<-ctx.Done()
 
-	return server.StopServer(ctx)
+	return errors.Join(server.StopServer(ctx), closeAuditLog(auditLog))
+}
+
+// loadExecutor loads the index and gives the server an executor over it, making it ready.
+func loadExecutor(ctx context.Context, src indexSource, server *api.HTTPAPI) (*index.Index, error) {
+	idx, err := loadIndex(ctx, src)
+	if err != nil {
+		return nil, err
+	}
+
+	view, err := idx.View(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	exec, err := src.newExecutor(view)
+	if err != nil {
+		return nil, err
+	}
+	server.SwapExecutor(exec)
+	return idx, nil
+}
+
+// closeAuditLog flushes the audit log, if one is kept.
+func closeAuditLog(auditLog *api.AuditLog) error {
+	if auditLog == nil {
+		return nil
+	}
+	return auditLog.Close()
 }
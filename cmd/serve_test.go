@@ -46,3 +46,92 @@ func (s *CmdTestSuite) TestServeCmdRejectsLegacyListenFlag() {
 	s.Require().Error(err)
 	s.Contains(err.Error(), "flag '--listen' is not defined for command 'serve'")
 }
+
+func (s *CmdTestSuite) TestServeCmdHelpListsMaxRequestBody() {
+	out := s.captureStdout(func() {
+		err := runServeCLI(context.Background(), []string{"--help"})
+		s.Require().NoError(err)
+	})
+
+	s.Contains(out, "--max-request-body")
+}
+
+func (s *CmdTestSuite) TestServeCmdRejectsNonPositiveMaxRequestBody() {
+	err := runServeCLI(context.Background(), []string{"--max-request-body", "0"})
+	s.Require().Error(err)
+	s.Contains(err.Error(), "--max-request-body must be positive")
+}
+
+func (s *CmdTestSuite) TestServeCmdRejectsNonPositiveMaxConcurrentEvaluations() {
+	err := runServeCLI(context.Background(), []string{"--max-concurrent-evaluations", "0"})
+	s.Require().Error(err)
+	s.Contains(err.Error(), "--max-concurrent-evaluations must be positive")
+}
+
+func (s *CmdTestSuite) TestServeCmdRejectsNonPositiveBatchConcurrency() {
+	err := runServeCLI(context.Background(), []string{"--batch-concurrency", "0"})
+	s.Require().Error(err)
+	s.Contains(err.Error(), "--batch-concurrency must be positive")
+}
+
+func (s *CmdTestSuite) TestServeCmdHelpListsResultCache() {
+	out := s.captureStdout(func() {
+		err := runServeCLI(context.Background(), []string{"--help"})
+		s.Require().NoError(err)
+	})
+
+	s.Contains(out, "--result-cache-size")
+	s.Contains(out, "--result-cache-ttl")
+}
+
+func (s *CmdTestSuite) TestServeCmdRejectsInvalidResultCacheFlags() {
+	err := runServeCLI(context.Background(), []string{"--result-cache-size", "-1"})
+	s.Require().Error(err)
+	s.Contains(err.Error(), "--result-cache-size must not be negative")
+
+	err = runServeCLI(context.Background(), []string{"--result-cache-ttl", "soon"})
+	s.Require().Error(err)
+	s.Contains(err.Error(), "invalid --result-cache-ttl")
+}
+
+func (s *CmdTestSuite) TestServeCmdHelpListsAuditLog() {
+	out := s.captureStdout(func() {
+		err := runServeCLI(context.Background(), []string{"--help"})
+		s.Require().NoError(err)
+	})
+
+	s.Contains(out, "--audit-log")
+}
+
+func (s *CmdTestSuite) TestServeCmdHelpListsMetricsPort() {
+	out := s.captureStdout(func() {
+		err := runServeCLI(context.Background(), []string{"--help"})
+		s.Require().NoError(err)
+	})
+
+	s.Contains(out, "--metrics-port")
+}
+
+func (s *CmdTestSuite) TestServeCmdRejectsMetricsPortOfHTTPPort() {
+	err := runServeCLI(context.Background(), []string{"--http-port", "9999", "--metrics-port", "9999"})
+	s.Require().Error(err)
+	s.Contains(err.Error(), "--metrics-port must differ from --http-port")
+
+	err = runServeCLI(context.Background(), []string{"--metrics-port", "-1"})
+	s.Require().Error(err)
+	s.Contains(err.Error(), "--metrics-port must not be negative")
+}
+
+func (s *CmdTestSuite) TestServeCmdRejectsGRPCPortOfAnotherPort() {
+	err := runServeCLI(context.Background(), []string{"--http-port", "9999", "--grpc-port", "9999"})
+	s.Require().Error(err)
+	s.Contains(err.Error(), "--grpc-port must differ from --http-port and --metrics-port")
+
+	err = runServeCLI(context.Background(), []string{"--metrics-port", "9998", "--grpc-port", "9998"})
+	s.Require().Error(err)
+	s.Contains(err.Error(), "--grpc-port must differ from --http-port and --metrics-port")
+
+	err = runServeCLI(context.Background(), []string{"--grpc-port", "-1"})
+	s.Require().Error(err)
+	s.Contains(err.Error(), "--grpc-port must not be negative")
+}
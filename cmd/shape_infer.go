@@ -0,0 +1,286 @@
+// SPDX-FileCopyrightText: © 2026 Binaek Sarkar <binaek89@gmail.com>
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"unicode"
+
+	"github.com/binaek/cling"
+	"github.com/sentrie-sh/sentrie/tokens"
+)
+
+func addShapeCmd(cli *cling.CLI) {
+	cli.WithCommand(
+		cling.NewCommand("shape", nil).
+			WithDescription("Work with shape definitions").
+			WithChildCommand(cling.NewCommand("infer", shapeInferCmd).
+				WithDescription("Infer shape definitions from a sample JSON document").
+				WithArgument(cling.NewStringCmdInput("file").
+					WithDescription("JSON document to infer the shapes from").
+					AsArgument(),
+				).
+				WithFlag(cling.
+					NewStringCmdInput("name").
+					WithDefault("").
+					WithDescription("Name of the inferred shape. Defaults to the file name").
+					AsFlag(),
+				),
+			),
+	)
+}
+
+type shapeInferCmdArgs struct {
+	File string `cling-name:"file"`
+	Name string `cling-name:"name"`
+}
+
+// shapeInferCmd writes the shapes inferred from a JSON sample to stdout. See inferShapes.
+func shapeInferCmd(ctx context.Context, args []string) error {
+	input := shapeInferCmdArgs{}
+	if err := cling.Hydrate(ctx, args, &input); err != nil {
+		return err
+	}
+
+	raw, err := os.ReadFile(input.File)
+	if err != nil {
+		return fmt.Errorf("failed to read sample %s: %w", input.File, err)
+	}
+	var sample any
+	if err := json.Unmarshal(raw, &sample); err != nil {
+		return fmt.Errorf("failed to parse sample %s: %w", input.File, err)
+	}
+
+	name := input.Name
+	if name == "" {
+		name = shapeName(strings.TrimSuffix(filepath.Base(input.File), filepath.Ext(input.File)))
+	}
+	if _, keyword := tokens.IsKeyword(name); keyword || !tokens.IsIdentifier(name) {
+		return fmt.Errorf("shape name %q is not an identifier", name)
+	}
+
+	src, left, err := inferShapes(name, sample)
+	if err != nil {
+		return fmt.Errorf("failed to infer a shape from %s: %w", input.File, err)
+	}
+	for _, key := range left {
+		fmt.Fprintf(os.Stderr, "warning: key %s is left out: it is not a valid field name\n", key)
+	}
+	_, err = fmt.Fprint(os.Stdout, src)
+	return err
+}
+
+// inferredType is the type inferred for the values seen at one place in a sample.
+type inferredType struct {
+	// kind is a type keyword, "null" while only nulls have been seen, or "object"
+	kind     string
+	nullable bool
+	// elem is the element type of a list, nil while only empty lists have been seen
+	elem   *inferredType
+	fields map[string]*inferredField
+}
+
+type inferredField struct {
+	typ      *inferredType
+	optional bool
+}
+
+// inferType returns the type of a decoded JSON value.
+func inferType(v any) *inferredType {
+	switch v := v.(type) {
+	case nil:
+		return &inferredType{kind: "null", nullable: true}
+	case string:
+		return &inferredType{kind: "string"}
+	case float64:
+		return &inferredType{kind: "number"}
+	case bool:
+		return &inferredType{kind: "boolean"}
+	case []any:
+		t := &inferredType{kind: "list"}
+		for _, item := range v {
+			t.elem = mergeInferred(t.elem, inferType(item))
+		}
+		return t
+	case map[string]any:
+		t := &inferredType{kind: "object", fields: make(map[string]*inferredField, len(v))}
+		for name, value := range v {
+			t.fields[name] = &inferredField{typ: inferType(value)}
+		}
+		return t
+	default:
+		return &inferredType{kind: "document"}
+	}
+}
+
+// mergeInferred combines the types seen for the same place in different samples, such as the
+// elements of one list. A null makes the type nullable, an object field missing from one sample
+// is optional, and values of different kinds fall back to a document.
+func mergeInferred(a, b *inferredType) *inferredType {
+	switch {
+	case a == nil:
+		return b
+	case b == nil:
+		return a
+	case a.kind == "null":
+		return withNullable(b, true)
+	case b.kind == "null":
+		return withNullable(a, true)
+	case a.kind != b.kind:
+		return &inferredType{kind: "document", nullable: a.nullable || b.nullable}
+	}
+
+	merged := &inferredType{kind: a.kind, nullable: a.nullable || b.nullable}
+	switch a.kind {
+	case "list":
+		merged.elem = mergeInferred(a.elem, b.elem)
+	case "object":
+		merged.fields = make(map[string]*inferredField)
+		for name, fa := range a.fields {
+			fb, ok := b.fields[name]
+			if !ok {
+				merged.fields[name] = &inferredField{typ: fa.typ, optional: true}
+				continue
+			}
+			merged.fields[name] = &inferredField{typ: mergeInferred(fa.typ, fb.typ), optional: fa.optional || fb.optional}
+		}
+		for name, fb := range b.fields {
+			if _, ok := a.fields[name]; !ok {
+				merged.fields[name] = &inferredField{typ: fb.typ, optional: true}
+			}
+		}
+	}
+	return merged
+}
+
+func withNullable(t *inferredType, nullable bool) *inferredType {
+	out := *t
+	out.nullable = out.nullable || nullable
+	return &out
+}
+
+// inferShapes renders the shapes describing sample, which is an object or a list of objects, as
+// source. The root shape is called name. Every nested object becomes a shape of its own, named
+// after the shape and field it is found in, and fields are written in name order, so the output
+// is stable for a given sample. Keys that are not identifiers cannot be declared, so they are
+// left out with a comment in their place and returned, as shape.key, for the caller to report.
+func inferShapes(name string, sample any) (string, []string, error) {
+	root := inferType(sample)
+	if root.kind == "list" && root.elem != nil {
+		root = root.elem
+	}
+	if root.kind != "object" {
+		return "", nil, fmt.Errorf("sample must be an object or a list of objects")
+	}
+
+	w := &shapeWriter{taken: map[string]bool{}}
+	if _, err := w.shape(name, root); err != nil {
+		return "", nil, err
+	}
+	return strings.Join(w.blocks, "\n"), w.left, nil
+}
+
+type shapeWriter struct {
+	blocks []string
+	taken  map[string]bool
+	// left holds the keys left out of the shapes, as shape.key
+	left []string
+}
+
+// shape writes the shape of an object type and returns the name it was given.
+func (w *shapeWriter) shape(name string, t *inferredType) (string, error) {
+	for i, base := 2, name; w.taken[name]; i++ {
+		name = fmt.Sprintf("%s%d", base, i)
+	}
+	w.taken[name] = true
+
+	// reserve the position of this shape ahead of the shapes of its fields
+	at := len(w.blocks)
+	w.blocks = append(w.blocks, "")
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "shape %s {\n", name)
+	for _, field := range slices.Sorted(maps.Keys(t.fields)) {
+		// keywords are declared as they are; shapes are open, so a key that cannot be declared as
+		// a field is only left undescribed
+		if !tokens.IsIdentifier(field) {
+			fmt.Fprintf(&b, "  -- %q is left out: it is not a valid field name\n", field)
+			w.left = append(w.left, fmt.Sprintf("%s.%q", name, field))
+			continue
+		}
+		f := t.fields[field]
+		typeRef, err := w.typeRef(name+shapeName(field), f.typ)
+		if err != nil {
+			return "", err
+		}
+		optional := ""
+		if f.optional {
+			optional = "?"
+		}
+		fmt.Fprintf(&b, "  %s%s: %s\n", field, optional, typeRef)
+	}
+	b.WriteString("}\n")
+
+	w.blocks[at] = b.String()
+	return name, nil
+}
+
+// typeRef renders the type ref of t, writing the shapes of the objects in it named after name.
+func (w *shapeWriter) typeRef(name string, t *inferredType) (string, error) {
+	var ref string
+	switch t.kind {
+	case "null":
+		ref = "document"
+	case "list":
+		elem := "document"
+		if t.elem != nil {
+			var err error
+			if elem, err = w.typeRef(name, t.elem); err != nil {
+				return "", err
+			}
+		}
+		ref = fmt.Sprintf("list[%s]", elem)
+	case "object":
+		shape, err := w.shape(name, t)
+		if err != nil {
+			return "", err
+		}
+		ref = shape
+	default:
+		ref = t.kind
+	}
+	if t.nullable {
+		ref += "?"
+	}
+	return ref, nil
+}
+
+// shapeName turns a file or field name into a shape name: its words capitalised and joined.
+func shapeName(s string) string {
+	var b strings.Builder
+	upper := true
+	for _, r := range s {
+		if !(r < unicode.MaxASCII && (unicode.IsLetter(r) || unicode.IsDigit(r))) {
+			upper = true
+			continue
+		}
+		if upper {
+			r = unicode.ToUpper(r)
+			upper = false
+		}
+		b.WriteRune(r)
+	}
+	name := b.String()
+	if name == "" || unicode.IsDigit(rune(name[0])) {
+		name = "Shape" + name
+	}
+	return name
+}
@@ -0,0 +1,124 @@
+// SPDX-FileCopyrightText: © 2026 Binaek Sarkar <binaek89@gmail.com>
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+
+	"github.com/sentrie-sh/sentrie/ast"
+	"github.com/sentrie-sh/sentrie/index"
+	"github.com/sentrie-sh/sentrie/parser"
+)
+
+const shapeInferSample = `{
+  "id": "u1",
+  "age": 31,
+  "active": true,
+  "nickname": null,
+  "version": 2,
+  "first-name": "Ann",
+  "address": {"city": "Oslo", "zip": "0150"},
+  "tags": ["a", "b"],
+  "roles": [{"name": "admin", "scope": "org"}, {"name": "viewer", "expires": null}]
+}`
+
+func (s *CmdTestSuite) inferShapeFrom(name, sample string, extra ...string) (string, error) {
+	s.T().Helper()
+	file := filepath.Join(s.T().TempDir(), name)
+	s.Require().NoError(os.WriteFile(file, []byte(sample), 0o600))
+
+	var runErr error
+	out := s.captureStdout(func() {
+		runErr = Execute(s.ctx(), Setup(context.Background(), "test"), append([]string{"sentrie", "shape", "infer", file}, extra...))
+	})
+	return out, runErr
+}
+
+func (s *CmdTestSuite) TestShapeInferCmdInfersTypesNullabilityAndNestedShapes() {
+	out, err := s.inferShapeFrom("user-profile.json", shapeInferSample)
+	s.Require().NoError(err)
+	s.Equal(`shape UserProfile {
+  active: boolean
+  address: UserProfileAddress
+  age: number
+  -- "first-name" is left out: it is not a valid field name
+  id: string
+  nickname: document?
+  roles: list[UserProfileRoles]
+  tags: list[string]
+  version: number
+}
+
+shape UserProfileAddress {
+  city: string
+  zip: string
+}
+
+shape UserProfileRoles {
+  expires?: document?
+  name: string
+  scope?: string
+}
+`, out)
+}
+
+func (s *CmdTestSuite) TestShapeInferCmdOutputRoundTrips() {
+	out, err := s.inferShapeFrom("sample.json", shapeInferSample, "--name", "Account")
+	s.Require().NoError(err)
+
+	ctx := context.Background()
+	program, err := parser.NewParserFromString("namespace com/example\n"+out, "inferred.sentrie").ParseProgram(ctx)
+	s.Require().NoError(err)
+
+	idx := index.CreateIndex()
+	s.Require().NoError(idx.AddProgram(ctx, program))
+	s.Require().NoError(idx.Validate(ctx))
+	account, err := idx.ResolveShape("com/example", "Account")
+	s.Require().NoError(err)
+	s.Len(account.Model.Fields, 8)
+	s.Contains(account.Model.Fields, "version")
+	s.True(ast.IsNullableTypeRef(account.Model.Fields["nickname"].TypeRef))
+	s.True(idx.Namespaces["com/example"].Shapes["AccountRoles"].Model.Fields["scope"].Optional)
+}
+
+func (s *CmdTestSuite) TestShapeInferCmdListOfObjectsAndMixedTypes() {
+	out, err := s.inferShapeFrom("events.json", `[{"at": "2026-01-01", "value": 1}, {"at": "2026-01-02", "value": "x", "meta": {"k": 1}}, {"at": null, "value": 2}]`)
+	s.Require().NoError(err)
+	s.Equal(`shape Events {
+  at: string?
+  meta?: EventsMeta
+  value: document
+}
+
+shape EventsMeta {
+  k: number
+}
+`, out)
+}
+
+func (s *CmdTestSuite) TestShapeInferCmdRejectsSamplesThatAreNotObjects() {
+	_, err := s.inferShapeFrom("scalar.json", `42`)
+	s.Require().Error(err)
+	s.Contains(err.Error(), "sample must be an object or a list of objects")
+
+	_, err = s.inferShapeFrom("broken.json", `{"a":`)
+	s.Require().Error(err)
+	s.Contains(err.Error(), "failed to parse sample")
+
+	_, err = s.inferShapeFrom("ok.json", `{"a": 1}`, "--name", "not-a-name")
+	s.Require().Error(err)
+	s.Contains(err.Error(), `shape name "not-a-name" is not an identifier`)
+}
+
+func (s *CmdTestSuite) TestInferShapesReturnsTheKeysLeftOut() {
+	_, left, err := inferShapes("Sample", map[string]any{
+		"first-name": "Ann",
+		"list":       []any{1.0},
+		"address":    map[string]any{"post code": "0150"},
+	})
+	s.Require().NoError(err)
+	s.Equal([]string{`SampleAddress."post code"`, `Sample."first-name"`}, left)
+}
@@ -17,8 +17,10 @@
 package cmd
 
 import (
+	"context"
 	"testing"
 
+	"github.com/sentrie-sh/sentrie/runtime"
 	"github.com/stretchr/testify/suite"
 )
 
@@ -26,6 +28,15 @@ type CmdTestSuite struct {
 	suite.Suite
 }
 
+// testExecutorOptions keep the caches of the executors the commands build small, as a test
+// builds many.
+var testExecutorOptions = []runtime.NewExecutorOption{runtime.WithModuleBindingCacheSize(1), runtime.WithCallMemoizeCacheSize(1)}
+
+// ctx is the context commands are run with in tests, building executors with testExecutorOptions.
+func (s *CmdTestSuite) ctx() context.Context {
+	return withExecutorOptions(context.Background(), testExecutorOptions...)
+}
+
 func TestCmdTestSuite(t *testing.T) {
 	suite.Run(t, new(CmdTestSuite))
 }
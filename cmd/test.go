@@ -0,0 +1,183 @@
+// SPDX-FileCopyrightText: © 2026 Binaek Sarkar <binaek89@gmail.com>
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/binaek/cling"
+	"github.com/sentrie-sh/sentrie/box"
+	"github.com/sentrie-sh/sentrie/loader"
+	"github.com/sentrie-sh/sentrie/runtime"
+)
+
+func addTestCmd(cli *cling.CLI) {
+	cli.WithCommand(
+		withIndexSourceFlags(cling.NewCommand("test", testCmd).
+			WithDescription("Run the tests declared in the .test.sentrie files of a pack").
+			WithFlag(cling.
+				NewStringCmdInput("pack-location").
+				WithDefault(".").
+				WithDescription("Pack directory to load").
+				AsFlag(),
+			).
+			WithFlag(cling.
+				NewBoolCmdInput("warn-shadowed-fields").
+				WithDefault(false).
+				WithDescription("Report a shape field redefining a composed field without 'override' as a warning instead of an error").
+				AsFlag(),
+			).
+			WithFlag(cling.
+				NewStringCmdInput("output").
+				WithDefault("table").
+				WithValidator(cling.NewEnumValidator("table", "json")).
+				WithDescription("Output format to use. One of: table, json").
+				AsFlag(),
+			)),
+	)
+}
+
+type testCmdArgs struct {
+	PackLocation string   `cling-name:"pack-location"`
+	PolicyRoots  []string `cling-name:"policy-root"`
+	NoOverride   bool     `cling-name:"no-override"`
+	WarnShadowed bool     `cling-name:"warn-shadowed-fields"`
+	Output       string   `cling-name:"output"`
+}
+
+// testReport is the JSON output of the test command.
+type testReport struct {
+	Total   int                   `json:"total"`
+	Passed  int                   `json:"passed"`
+	Failed  int                   `json:"failed"`
+	Results []*runtime.TestResult `json:"results"`
+}
+
+// testCmd runs the tests of the pack against its policies and reports every test, with the
+// expectations that did not hold. It fails when any test fails.
+func testCmd(ctx context.Context, args []string) error {
+	input := testCmdArgs{}
+	if err := cling.Hydrate(ctx, args, &input); err != nil {
+		return err
+	}
+
+	src := indexSource{
+		PackLocation:    input.PackLocation,
+		PolicyRoots:     input.PolicyRoots,
+		NoOverride:      input.NoOverride,
+		WarnShadowed:    input.WarnShadowed,
+		ExecutorOptions: executorOptionsOf(ctx),
+	}
+	pack, programs, err := loadPrograms(ctx, src)
+	if err != nil {
+		return err
+	}
+
+	tests, err := loader.LoadTests(ctx, pack)
+	if err != nil {
+		return err
+	}
+
+	idx, err := indexPrograms(ctx, src, pack, programs)
+	if err != nil {
+		return err
+	}
+
+	view, err := idx.View(ctx)
+	if err != nil {
+		return err
+	}
+
+	exec, err := src.newExecutor(view)
+	if err != nil {
+		return err
+	}
+
+	runner, ok := exec.(runtime.TestRunner)
+	if !ok {
+		return fmt.Errorf("the executor cannot run tests")
+	}
+
+	results := runner.RunTests(ctx, tests)
+	report := &testReport{Total: len(results), Results: results}
+	for _, result := range results {
+		if result.Passed {
+			report.Passed++
+		} else {
+			report.Failed++
+		}
+	}
+	if report.Results == nil {
+		report.Results = []*runtime.TestResult{}
+	}
+
+	if input.Output == "json" {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		if err := enc.Encode(report); err != nil {
+			return err
+		}
+	} else {
+		formatTestTable(os.Stdout, report)
+	}
+
+	if report.Failed > 0 {
+		return fmt.Errorf("%d of %d tests failed", report.Failed, report.Total)
+	}
+	return nil
+}
+
+// formatTestTable writes a line for each test and, under a failed test, a line for each
+// expectation that did not hold.
+//
+// Examples:
+//
+//	PASS com/example/auth: admins are allowed
+//	FAIL com/example/auth: users are denied (auth.test.sentrie:8:1-11:1)
+//	  com/example/auth/allow: expected false, got true (auth.test.sentrie:10:3-23)
+//
+//	1 passed, 1 failed
+func formatTestTable(w io.Writer, r *testReport) {
+	for _, result := range r.Results {
+		if result.Passed {
+			fmt.Fprintf(w, "PASS %s: %s\n", result.Policy, result.Name)
+			continue
+		}
+		fmt.Fprintf(w, "FAIL %s: %s (%s)\n", result.Policy, result.Name, result.Span)
+		if result.Error != "" {
+			fmt.Fprintf(w, "  error: %s\n", result.Error)
+		}
+		for _, failure := range result.Failures {
+			target := failure.Rule
+			if failure.Attachment != "" {
+				target += " attachment " + failure.Attachment
+			}
+			if failure.Error != "" {
+				fmt.Fprintf(w, "  %s: error: %s (%s)\n", target, failure.Error, failure.Span)
+				continue
+			}
+			fmt.Fprintf(w, "  %s: expected %s, got %s (%s)\n", target, testValueString(failure.Expected), testValueString(failure.Actual), failure.Span)
+		}
+	}
+	fmt.Fprintln(w)
+	fmt.Fprintf(w, "%d passed, %d failed\n", r.Passed, r.Failed)
+}
+
+// testValueString formats an expected or actual value of a test, writing boxed values as JSON so
+// that a string is told apart from a number or a trinary.
+func testValueString(v any) string {
+	if bv, ok := v.(box.Value); ok {
+		if bv.IsUndefined() {
+			return "undefined"
+		}
+		if b, err := json.Marshal(bv); err == nil {
+			return string(b)
+		}
+	}
+	return fmt.Sprintf("%v", v)
+}
@@ -0,0 +1,109 @@
+// SPDX-FileCopyrightText: © 2026 Binaek Sarkar <binaek89@gmail.com>
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+)
+
+const testCases = `namespace com/example
+
+test "admins are allowed" of auth {
+  fact role = "admin"
+  fact age = 30
+  expect admin is true
+  expect adult is true
+  expect admin attach reason as "role must be admin"
+}
+`
+
+const failingTestCases = `namespace com/example
+
+test "users are admins" of auth {
+  fact role = "user"
+  expect admin is true
+  expect admin attach reason as "role is admin"
+}
+
+test "missing policy" of nope {
+  expect allow is true
+}
+`
+
+func (s *CmdTestSuite) runTests(sources map[string]string, extra ...string) (string, error) {
+	s.T().Helper()
+	sources["policy.sentrie"] = evalPolicy
+	dir := s.writeTestPack(sources)
+
+	args := append([]string{"sentrie", "test", "--pack-location", dir}, extra...)
+	var runErr error
+	out := s.captureStdout(func() {
+		runErr = Execute(s.ctx(), Setup(context.Background(), "test"), args)
+	})
+	return out, runErr
+}
+
+func (s *CmdTestSuite) TestTestCmdPasses() {
+	out, err := s.runTests(map[string]string{"auth.test.sentrie": testCases})
+	s.Require().NoError(err)
+	s.Contains(out, "PASS com/example/auth: admins are allowed")
+	s.Contains(out, "1 passed, 0 failed")
+}
+
+func (s *CmdTestSuite) TestTestCmdReportsFailures() {
+	out, err := s.runTests(map[string]string{"auth.test.sentrie": testCases, "failing.test.sentrie": failingTestCases})
+	s.Require().Error(err)
+	s.Contains(err.Error(), "2 of 3 tests failed")
+	s.Contains(out, "FAIL com/example/auth: users are admins (")
+	s.Contains(out, "  com/example/auth/admin: expected true, got false (")
+	s.Contains(out, `  com/example/auth/admin attachment reason: expected "role is admin", got "role must be admin" (`)
+	s.Contains(out, "failing.test.sentrie:5:")
+	s.Contains(out, "FAIL com/example/nope: missing policy")
+	s.Contains(out, "1 passed, 2 failed")
+}
+
+func (s *CmdTestSuite) TestTestCmdJSONReport() {
+	out, err := s.runTests(map[string]string{"failing.test.sentrie": failingTestCases}, "--output", "json")
+	s.Require().Error(err)
+
+	var report struct {
+		Total   int `json:"total"`
+		Passed  int `json:"passed"`
+		Failed  int `json:"failed"`
+		Results []struct {
+			Name     string `json:"name"`
+			Passed   bool   `json:"passed"`
+			Error    string `json:"error"`
+			Failures []struct {
+				Rule       string `json:"rule"`
+				Attachment string `json:"attachment"`
+				Expected   any    `json:"expected"`
+				Actual     any    `json:"actual"`
+				Span       string `json:"span"`
+			} `json:"failures"`
+		} `json:"results"`
+	}
+	s.Require().NoError(json.Unmarshal([]byte(out), &report))
+	s.Equal(2, report.Total)
+	s.Equal(2, report.Failed)
+	s.Require().Len(report.Results, 2)
+
+	failures := report.Results[0].Failures
+	s.Require().Len(failures, 2)
+	s.Equal("com/example/auth/admin", failures[0].Rule)
+	s.Equal("true", failures[0].Expected)
+	s.Equal("false", failures[0].Actual)
+	s.Contains(failures[0].Span, "failing.test.sentrie:5:")
+	s.Equal("reason", failures[1].Attachment)
+	s.Equal("role is admin", failures[1].Expected)
+
+	s.NotEmpty(report.Results[1].Error)
+}
+
+func (s *CmdTestSuite) TestTestCmdRejectsTestsInPolicyFiles() {
+	_, err := s.runTests(map[string]string{"extra.sentrie": "namespace com/other\ntest \"t\" of auth { expect admin is true }\n"})
+	s.Require().Error(err)
+	s.Contains(err.Error(), "must be declared in a .test.sentrie test file")
+}
@@ -18,6 +18,8 @@ package cmd
 
 import (
 	"context"
+	"errors"
+	"os"
 
 	"github.com/binaek/cling"
 	"github.com/sentrie-sh/sentrie/index"
@@ -27,7 +29,7 @@ import (
 
 func addValidateCmd(cli *cling.CLI) {
 	cli.WithCommand(
-		cling.NewCommand("validate", validateCmd).
+		withIndexSourceFlags(cling.NewCommand("validate", validateCmd).
 			WithArgument(cling.NewStringCmdInput("rule").
 				WithDescription("Rule to execute").
 				AsArgument(),
@@ -38,19 +40,50 @@ func addValidateCmd(cli *cling.CLI) {
 				WithDescription("Pack directory to load").
 				AsFlag(),
 			).
+			WithFlag(cling.
+				NewBoolCmdInput("warn-shadowed-fields").
+				WithDefault(false).
+				WithDescription("Report a shape field redefining a composed field as a warning instead of an error").
+				AsFlag(),
+			).
 			WithFlag(cling.
 				NewStringCmdInput("facts").
 				WithDefault("{}").
 				WithDescription("Facts to execute the rule with").
 				AsFlag(),
-			),
+			).
+			WithFlag(cling.
+				NewBoolCmdInput("require-facts").
+				WithDefault(false).
+				WithDescription("Fail when a policy declares no facts but references unresolved identifiers").
+				AsFlag(),
+			).
+			WithFlag(cling.
+				NewBoolCmdInput("fail-on-warning").
+				WithDefault(false).
+				WithDescription("Exit with an error when validation reports any warning").
+				AsFlag(),
+			).
+			WithFlag(cling.
+				NewStringCmdInput("format").
+				WithDefault("text").
+				WithValidator(cling.NewEnumValidator("text", "sarif", "json")).
+				WithDescription("Diagnostics format to use. One of: text, sarif, json (LSP publishDiagnostics params)").
+				AsFlag(),
+			)),
 	)
 }
 
 type validateCmdArgs struct {
-	PackLocation string `cling-name:"pack-location"`
-	Rule         string `cling-name:"rule"`
-	Facts        string `cling-name:"facts"`
+	PackLocation  string   `cling-name:"pack-location"`
+	PolicyRoots   []string `cling-name:"policy-root"`
+	NoOverride    bool     `cling-name:"no-override"`
+	WarnShadowed  bool     `cling-name:"warn-shadowed-fields"`
+	Rule          string   `cling-name:"rule"`
+	Facts         string   `cling-name:"facts"`
+	RequireFacts  bool     `cling-name:"require-facts"`
+	FailOnWarning bool     `cling-name:"fail-on-warning"`
+	Format        string   `cling-name:"format"`
 }
 
 func validateCmd(ctx context.Context, args []string) error {
@@ -59,32 +92,67 @@ func validateCmd(ctx context.Context, args []string) error {
 		return err
 	}
 
-	pack, err := loader.LoadPack(ctx, input.PackLocation)
+	idx, err := loadValidatedIndex(ctx, input)
+	switch input.Format {
+	case "sarif":
+		return reportSARIF(os.Stdout, input.PackLocation, idx, err, input.FailOnWarning)
+	case "json":
+		return reportLSP(os.Stdout, input.PackLocation, idx, err, input.FailOnWarning)
+	}
 	if err != nil {
 		return err
 	}
 
-	idx := index.CreateIndex()
+	return reportWarnings(os.Stderr, idx.Warnings(), input.FailOnWarning)
+}
+
+// loadValidatedIndex loads and validates the pack, and checks that an executor can be built
+// over it. The index is returned alongside any error so that its warnings can still be reported.
+func loadValidatedIndex(ctx context.Context, input validateCmdArgs) (*index.Index, error) {
+	pack, err := loader.LoadPack(ctx, input.PackLocation)
+	if err != nil {
+		return nil, err
+	}
+
+	var opts []index.IndexOption
+	if input.RequireFacts {
+		opts = append(opts, index.WithRequireFacts())
+	}
+	if input.WarnShadowed {
+		opts = append(opts, index.WithShadowedFieldWarnings())
+	}
+	idx := index.CreateIndex(opts...)
 
 	if err := idx.SetPack(ctx, pack); err != nil {
-		return err
+		return idx, err
 	}
 
-	programs, err := loader.LoadPrograms(ctx, pack)
+	programs, err := loader.LoadPolicyRoots(ctx, pack, input.PolicyRoots, !input.NoOverride)
 	if err != nil {
-		return err
+		return idx, err
 	}
 
+	// a program that fails to index does not stop the others from being added, so that every
+	// conflict is reported at once
+	var errs []error
 	for _, program := range programs {
 		if err := idx.AddProgram(ctx, program); err != nil {
-			return err
+			errs = append(errs, err)
 		}
 	}
+	if len(errs) > 0 {
+		return idx, errors.Join(errs...)
+	}
 
 	if err := idx.Validate(ctx); err != nil {
-		return err
+		return idx, err
+	}
+
+	view, err := idx.View(ctx)
+	if err != nil {
+		return idx, err
 	}
 
-	_, err = runtime.NewExecutor(idx)
-	return err
+	_, err = runtime.NewExecutor(view, executorOptionsOf(ctx)...)
+	return idx, err
 }
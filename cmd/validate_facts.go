@@ -0,0 +1,106 @@
+// SPDX-FileCopyrightText: © 2026 Binaek Sarkar <binaek89@gmail.com>
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/binaek/cling"
+)
+
+func addValidateFactsCmd(cli *cling.CLI) {
+	cli.WithCommand(
+		withIndexSourceFlags(cling.NewCommand("validate-facts", validateFactsCmd).
+			WithFlag(cling.
+				NewStringCmdInput("policy").
+				WithDescription("Policy whose fact declarations to check against, e.g. com/example/auth").
+				Required().
+				AsFlag(),
+			).
+			WithFlag(cling.
+				NewStringCmdInput("pack-location").
+				WithDefault(".").
+				WithDescription("Pack directory to load").
+				AsFlag(),
+			).
+			WithFlag(cling.
+				NewStringCmdInput("fact-file").
+				WithDefault("").
+				WithDescription("File to load facts from").
+				AsFlag(),
+			).
+			WithFlag(cling.
+				NewStringCmdInput("facts").
+				WithDefault("{}").
+				WithDescription("Facts to validate").
+				AsFlag(),
+			)),
+	)
+}
+
+type validateFactsCmdArgs struct {
+	Policy       string   `cling-name:"policy"`
+	PackLocation string   `cling-name:"pack-location"`
+	PolicyRoots  []string `cling-name:"policy-root"`
+	NoOverride   bool     `cling-name:"no-override"`
+	FactFile     string   `cling-name:"fact-file"`
+	Facts        string   `cling-name:"facts"`
+}
+
+// validateFactsCmd checks the facts against the fact declarations of a policy without evaluating
+// any rule. Every violation is written to stderr, and any violation fails the command.
+func validateFactsCmd(ctx context.Context, args []string) error {
+	input := validateFactsCmdArgs{}
+	if err := cling.Hydrate(ctx, args, &input); err != nil {
+		return err
+	}
+
+	facts, err := loadFacts(input.FactFile, input.Facts)
+	if err != nil {
+		return err
+	}
+
+	src := indexSource{
+		PackLocation:    input.PackLocation,
+		PolicyRoots:     input.PolicyRoots,
+		NoOverride:      input.NoOverride,
+		ExecutorOptions: executorOptionsOf(ctx),
+	}
+	idx, err := loadIndex(ctx, src)
+	if err != nil {
+		return err
+	}
+
+	view, err := idx.View(ctx)
+	if err != nil {
+		return err
+	}
+
+	exec, err := src.newExecutor(view)
+	if err != nil {
+		return err
+	}
+
+	// facts are declared per policy, so a rule resolves to the policy declaring it
+	namespace, policy, _, err := exec.Index().ResolveSegments(input.Policy)
+	if err != nil {
+		return err
+	}
+
+	err = exec.ValidateFacts(ctx, namespace, policy, facts)
+	if err == nil {
+		return nil
+	}
+
+	violations := []error{err}
+	if joined, ok := err.(interface{ Unwrap() []error }); ok {
+		violations = joined.Unwrap()
+	}
+	for _, violation := range violations {
+		fmt.Fprintln(os.Stderr, violation)
+	}
+	return fmt.Errorf("%d fact violation(s) found for %s", len(violations), input.Policy)
+}
@@ -0,0 +1,70 @@
+// SPDX-FileCopyrightText: © 2026 Binaek Sarkar <binaek89@gmail.com>
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+)
+
+const factContractPolicy = `namespace com/example
+policy onboarding {
+  fact email: string @email()
+  fact age: number @min(18)
+  fact region: string @one_of("eu", "us")
+  rule allow = default false { yield age >= 21 }
+  export decision of allow
+}
+`
+
+func (s *CmdTestSuite) runValidateFacts(facts string) error {
+	dir := s.writeTestPack(map[string]string{"policy.sentrie": factContractPolicy})
+	factFile := filepath.Join(s.T().TempDir(), "facts.json")
+	s.Require().NoError(os.WriteFile(factFile, []byte(facts), 0o600))
+
+	args := []string{"sentrie", "validate-facts", "--pack-location", dir, "--policy", "com/example/onboarding", "--fact-file", factFile}
+	var runErr error
+	out := s.captureStdout(func() {
+		runErr = Execute(s.ctx(), Setup(context.Background(), "test"), args)
+	})
+	s.Empty(out, "validate-facts must not evaluate or print decisions")
+	return runErr
+}
+
+func (s *CmdTestSuite) TestValidateFactsCmdAcceptsValidFacts() {
+	s.NoError(s.runValidateFacts(`{"email": "a@example.com", "age": 30, "region": "eu"}`))
+}
+
+func (s *CmdTestSuite) TestValidateFactsCmdReportsEveryViolation() {
+	err := s.runValidateFacts(`{"email": "not-an-email", "age": 12}`)
+	s.Require().Error(err)
+	s.Equal("3 fact violation(s) found for com/example/onboarding", err.Error())
+}
+
+func (s *CmdTestSuite) TestValidateFactsCmdRequiresPolicy() {
+	err := Execute(s.ctx(), Setup(context.Background(), "test"), []string{"sentrie", "validate-facts"})
+	s.Require().Error(err)
+	s.Contains(err.Error(), "policy")
+}
+
+func (s *CmdTestSuite) TestValidateFactsCmdCountsEveryShapeField() {
+	dir := s.writeTestPack(map[string]string{"policy.sentrie": `namespace com/example
+shape Applicant {
+  email: string @email()
+  age: number @min(18)
+  region: string @one_of("eu", "us")
+}
+policy onboarding {
+  fact applicant: Applicant
+  rule allow = default false { yield applicant.age >= 21 }
+  export decision of allow
+}
+`})
+	args := []string{"sentrie", "validate-facts", "--pack-location", dir, "--policy", "com/example/onboarding",
+		"--facts", `{"applicant": {"email": "not-an-email", "age": 12, "region": "apac"}}`}
+	err := Execute(s.ctx(), Setup(context.Background(), "test"), args)
+	s.Require().Error(err)
+	s.Equal("3 fact violation(s) found for com/example/onboarding", err.Error())
+}
@@ -0,0 +1,229 @@
+// SPDX-FileCopyrightText: © 2026 Binaek Sarkar <binaek89@gmail.com>
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io/fs"
+	"log/slog"
+	"maps"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+	"github.com/sentrie-sh/sentrie/constants"
+	"github.com/sentrie-sh/sentrie/index"
+	"github.com/sentrie-sh/sentrie/loader"
+	"github.com/sentrie-sh/sentrie/parser"
+	"github.com/sentrie-sh/sentrie/runtime"
+)
+
+// reloadDebounce is how long the watcher waits for changes to settle before reloading, so that
+// an editor saving several files, or writing one in several steps, causes a single reload.
+const reloadDebounce = 250 * time.Millisecond
+
+// executorSwapper is the server answering requests with the executor it was last given. It is
+// told when a reload fails, and is not ready until the next one succeeds.
+type executorSwapper interface {
+	SwapExecutor(exec runtime.Executor)
+	ReloadFailed(err error)
+}
+
+// policyWatcher reloads the policies served by a server when files under a directory change.
+// The directory lies within the pack or a policy root, see checkWatchedDir.
+// A reload that fails, to parse or to validate, is logged and the server keeps the policies it
+// has; only a committed index is ever served.
+type policyWatcher struct {
+	dir    string
+	src    indexSource
+	server executorSwapper
+	// idx is the index being served, which a single changed policy file is replaced in
+	idx      *index.Index
+	debounce time.Duration
+}
+
+// watch reloads the policies on every change under the directory of w, until ctx is done.
+func (w *policyWatcher) watch(ctx context.Context) error {
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return fmt.Errorf("cannot watch %s: %w", w.dir, err)
+	}
+	defer watcher.Close()
+
+	// fsnotify does not watch subdirectories, so every one is added, as are those created later
+	if err := addWatchedDirs(watcher, w.dir); err != nil {
+		return fmt.Errorf("cannot watch %s: %w", w.dir, err)
+	}
+	slog.InfoContext(ctx, "watching policies", slog.String("dir", w.dir))
+
+	changed := map[string]fsnotify.Op{}
+	timer := time.NewTimer(w.debounce)
+	timer.Stop()
+	defer timer.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case event, ok := <-watcher.Events:
+			if !ok {
+				return nil
+			}
+			if event.Has(fsnotify.Create) {
+				if info, err := os.Stat(event.Name); err == nil && info.IsDir() {
+					if err := addWatchedDirs(watcher, event.Name); err != nil {
+						slog.WarnContext(ctx, "cannot watch directory", slog.String("dir", event.Name), slog.Any("error", err))
+					}
+				}
+			}
+			if event.Op == fsnotify.Chmod {
+				continue
+			}
+			changed[event.Name] |= event.Op
+			timer.Reset(w.debounce)
+		case err, ok := <-watcher.Errors:
+			if !ok {
+				return nil
+			}
+			slog.WarnContext(ctx, "error watching policies", slog.Any("error", err))
+		case <-timer.C:
+			w.reload(ctx, changed)
+			changed = map[string]fsnotify.Op{}
+		}
+	}
+}
+
+// checkWatchedDir checks that dir is the pack directory, one of the policy roots, or a directory
+// below one of them. Reloads load the policies from those, so a change anywhere else would
+// reload without picking it up.
+func checkWatchedDir(dir, packLocation string, policyRoots []string) error {
+	watched, err := filepath.Abs(dir)
+	if err != nil {
+		return fmt.Errorf("invalid --watch: %w", err)
+	}
+	for _, root := range append([]string{packLocation}, policyRoots...) {
+		root, err := filepath.Abs(root)
+		if err != nil {
+			continue
+		}
+		if rel, err := filepath.Rel(root, watched); err == nil && filepath.IsLocal(rel) {
+			return nil
+		}
+	}
+	return fmt.Errorf("--watch %s is outside the pack and the policy roots, which the policies are reloaded from", dir)
+}
+
+// addWatchedDirs adds dir and every directory below it to watcher.
+func addWatchedDirs(watcher *fsnotify.Watcher, dir string) error {
+	return filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if !d.IsDir() {
+			return nil
+		}
+		return watcher.Add(path)
+	})
+}
+
+// reload builds an index with the changes and, once it is committed, serves it. A reload that
+// fails, or panics, leaves the server with the policies it has. Tests are not served, so
+// changes to test files are dropped from changed, and cause no reload on their own.
+func (w *policyWatcher) reload(ctx context.Context, changed map[string]fsnotify.Op) {
+	maps.DeleteFunc(changed, func(name string, _ fsnotify.Op) bool {
+		return loader.FileExtension(name) == constants.TestFileExtension
+	})
+	if len(changed) == 0 {
+		return
+	}
+
+	start := time.Now()
+	defer func() {
+		if r := recover(); r != nil {
+			w.failed(ctx, fmt.Errorf("panic: %v", r))
+		}
+	}()
+
+	next, mode, err := w.reindex(ctx, changed)
+	if err == nil {
+		err = w.serve(ctx, next)
+	}
+	if err != nil {
+		w.failed(ctx, err)
+		return
+	}
+	slog.InfoContext(ctx, "policies reloaded",
+		slog.String("mode", mode),
+		slog.Int("changes", len(changed)),
+		slog.Duration("duration", time.Since(start)),
+	)
+}
+
+// failed logs a failed reload and tells the server, which keeps serving the previous policies.
+func (w *policyWatcher) failed(ctx context.Context, err error) {
+	slog.ErrorContext(ctx, "policy reload failed; serving the previous policies", slog.Any("error", err))
+	w.server.ReloadFailed(fmt.Errorf("policy reload failed: %w", err))
+}
+
+// reindex builds the index of the policies after the changes. A single policy file of the pack
+// that was written is replaced in the index being served; any other change reloads everything.
+// The returned mode tells which happened.
+func (w *policyWatcher) reindex(ctx context.Context, changed map[string]fsnotify.Op) (*index.Index, string, error) {
+	if reference, ok := w.replaceable(changed); ok {
+		f, err := os.Open(reference)
+		if err != nil {
+			return nil, "", err
+		}
+		defer f.Close()
+		program, err := parser.NewParser(f, reference).ParseProgram(ctx)
+		if err != nil {
+			return nil, "", err
+		}
+		next, err := w.idx.ReplaceProgram(ctx, program)
+		return next, "incremental", err
+	}
+
+	next, err := loadIndex(ctx, w.src)
+	return next, "full", err
+}
+
+// replaceable returns the reference the loader gives the single changed file, when that file is
+// a policy file of the pack which was written, and the pack has no other policy roots.
+func (w *policyWatcher) replaceable(changed map[string]fsnotify.Op) (string, bool) {
+	if len(changed) != 1 || len(w.src.PolicyRoots) > 0 || w.src.Plans != "" || w.idx == nil {
+		return "", false
+	}
+	for name, op := range changed {
+		if op.Has(fsnotify.Remove) || op.Has(fsnotify.Rename) {
+			return "", false
+		}
+		if loader.FileExtension(name) != constants.PolicyFileExtension {
+			return "", false
+		}
+		root := w.idx.Pack.Location
+		rel, err := filepath.Rel(root, name)
+		if err != nil || !filepath.IsLocal(rel) {
+			return "", false
+		}
+		return filepath.Join(root, rel), true
+	}
+	return "", false
+}
+
+// serve makes the server answer with an executor of idx, once idx is committed.
+func (w *policyWatcher) serve(ctx context.Context, idx *index.Index) error {
+	view, err := idx.View(ctx)
+	if err != nil {
+		return err
+	}
+	exec, err := w.src.newExecutor(view)
+	if err != nil {
+		return err
+	}
+	w.server.SwapExecutor(exec)
+	w.idx = idx
+	return nil
+}
@@ -0,0 +1,230 @@
+// SPDX-FileCopyrightText: © 2026 Binaek Sarkar <binaek89@gmail.com>
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+	"github.com/sentrie-sh/sentrie/index"
+	"github.com/sentrie-sh/sentrie/runtime"
+	"github.com/sentrie-sh/sentrie/trinary"
+)
+
+func watchedPolicy(role string) string {
+	return `namespace com/example
+policy access {
+  fact role: string
+  rule allow = default false { yield role == "` + role + `" }
+  export decision of allow
+}`
+}
+
+// recordingServer records, of every executor it is given, the index and which roles the access
+// policy allows. The executors themselves are not kept, each reserves its caches up front.
+type recordingServer struct {
+	mu      sync.Mutex
+	swaps   int
+	view    *index.IndexView
+	allowed map[string]bool
+	// failure is the error of the last failed reload, cleared by a swap
+	failure error
+}
+
+func (r *recordingServer) SwapExecutor(exec runtime.Executor) {
+	allowed := map[string]bool{}
+	for _, role := range []string{"admin", "root"} {
+		output, err := exec.ExecRule(context.Background(), "com/example", "access", "allow", map[string]any{"role": role})
+		allowed[role] = err == nil && output.ToTrinary() == trinary.True
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.swaps++
+	r.view = exec.Index()
+	r.allowed = allowed
+	r.failure = nil
+}
+
+func (r *recordingServer) ReloadFailed(err error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.failure = err
+}
+
+// failed returns the error of the last failed reload, unless a swap followed it.
+func (r *recordingServer) failed() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.failure
+}
+
+// current returns the index of the executor given last, which roles it allows, and how many
+// executors were given.
+func (r *recordingServer) current() (*index.IndexView, map[string]bool, int) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.view, r.allowed, r.swaps
+}
+
+// newPolicyWatcher writes a pack with the access policy allowing role, and returns a watcher of
+// it serving its index to a recordingServer.
+func (s *CmdTestSuite) newPolicyWatcher(role string) (*policyWatcher, *recordingServer) {
+	dir := s.writeTestPack(map[string]string{"access.sentrie": watchedPolicy(role)})
+	src := indexSource{PackLocation: dir, ExecutorOptions: testExecutorOptions}
+	idx, err := loadIndex(context.Background(), src)
+	s.Require().NoError(err)
+	server := &recordingServer{}
+	return &policyWatcher{dir: dir, src: src, server: server, idx: idx, debounce: 10 * time.Millisecond}, server
+}
+
+// captureLogs records what is logged until the test ends.
+func (s *CmdTestSuite) captureLogs() func() string {
+	var mu sync.Mutex
+	var buf bytes.Buffer
+	original := slog.Default()
+	slog.SetDefault(slog.New(slog.NewTextHandler(writerFunc(func(p []byte) (int, error) {
+		mu.Lock()
+		defer mu.Unlock()
+		return buf.Write(p)
+	}), nil)))
+	s.T().Cleanup(func() { slog.SetDefault(original) })
+	return func() string {
+		mu.Lock()
+		defer mu.Unlock()
+		return buf.String()
+	}
+}
+
+type writerFunc func(p []byte) (int, error)
+
+func (f writerFunc) Write(p []byte) (int, error) { return f(p) }
+
+func (s *CmdTestSuite) TestPolicyWatcherReloadsOnChange() {
+	w, server := s.newPolicyWatcher("admin")
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	done := make(chan error, 1)
+	go func() { done <- w.watch(ctx) }()
+
+	path := filepath.Join(w.dir, "access.sentrie")
+	s.Eventually(func() bool {
+		// written until the watcher, which starts in the background, picks it up
+		_, allowed, swaps := server.current()
+		if swaps == 0 {
+			s.Require().NoError(os.WriteFile(path, []byte(watchedPolicy("root")), 0o600))
+			return false
+		}
+		return allowed["root"] && !allowed["admin"]
+	}, time.Minute, 50*time.Millisecond)
+
+	cancel()
+	s.NoError(<-done)
+}
+
+func (s *CmdTestSuite) TestPolicyWatcherKeepsServingWhenAReloadFails() {
+	logs := s.captureLogs()
+	w, server := s.newPolicyWatcher("admin")
+	served := w.idx
+	path := filepath.Join(w.dir, "access.sentrie")
+	ctx := context.Background()
+
+	// a file that does not parse
+	s.Require().NoError(os.WriteFile(path, []byte("namespace com/example\npolicy access {"), 0o600))
+	w.reload(ctx, map[string]fsnotify.Op{path: fsnotify.Write})
+	// one that parses but does not validate
+	s.Require().NoError(os.WriteFile(path, []byte(`namespace com/example
+policy access {
+  rule allow = default false { yield allow }
+  export decision of allow
+}`), 0o600))
+	w.reload(ctx, map[string]fsnotify.Op{path: fsnotify.Write})
+	_, _, swaps := server.current()
+	s.Zero(swaps)
+	s.Same(served, w.idx)
+	s.Equal(2, bytes.Count([]byte(logs()), []byte("policy reload failed; serving the previous policies")))
+	s.ErrorContains(server.failed(), "policy reload failed")
+
+	// a fixed file replaces the policy in the index being served
+	s.Require().NoError(os.WriteFile(path, []byte(watchedPolicy("root")), 0o600))
+	w.reload(ctx, map[string]fsnotify.Op{path: fsnotify.Write})
+	_, allowed, swaps := server.current()
+	s.Equal(1, swaps)
+	s.True(allowed["root"])
+	s.NoError(server.failed())
+	s.Contains(logs(), "mode=incremental")
+
+	// any other change reloads everything
+	s.Require().NoError(os.WriteFile(filepath.Join(w.dir, "other.sentrie"), []byte(`namespace com/other
+policy misc {
+  rule answer = default 0 { yield 1 }
+  export decision of answer
+}`), 0o600))
+	s.Require().NoError(os.Remove(path))
+	w.reload(ctx, map[string]fsnotify.Op{path: fsnotify.Remove, filepath.Join(w.dir, "other.sentrie"): fsnotify.Create})
+	view, _, swaps := server.current()
+	s.Equal(2, swaps)
+	s.Contains(logs(), "mode=full")
+	_, err := view.ResolvePolicy("com/example", "access")
+	s.Error(err)
+	_, err = view.ResolvePolicy("com/other", "misc")
+	s.NoError(err)
+}
+
+func (s *CmdTestSuite) TestPolicyWatcherIgnoresTestFiles() {
+	w, server := s.newPolicyWatcher("admin")
+	served := w.idx
+	path := filepath.Join(w.dir, "access.test.sentrie")
+	ctx := context.Background()
+
+	// a test file is not a policy, so it is neither replaced in the index nor reloaded
+	s.Require().NoError(os.WriteFile(path, []byte("namespace com/example\ntest broken {"), 0o600))
+	w.reload(ctx, map[string]fsnotify.Op{path: fsnotify.Write})
+	_, _, swaps := server.current()
+	s.Zero(swaps)
+	s.Same(served, w.idx)
+	s.NoError(server.failed())
+
+	// next to a policy file, the policy file alone is replaced
+	policy := filepath.Join(w.dir, "access.sentrie")
+	s.Require().NoError(os.WriteFile(policy, []byte(watchedPolicy("root")), 0o600))
+	logs := s.captureLogs()
+	w.reload(ctx, map[string]fsnotify.Op{path: fsnotify.Write, policy: fsnotify.Write})
+	_, allowed, swaps := server.current()
+	s.Equal(1, swaps)
+	s.True(allowed["root"])
+	s.Contains(logs(), "mode=incremental")
+}
+
+func (s *CmdTestSuite) TestCheckWatchedDir() {
+	pack := s.T().TempDir()
+	root := s.T().TempDir()
+	s.Require().NoError(os.Mkdir(filepath.Join(pack, "policies"), 0o700))
+
+	s.NoError(checkWatchedDir(pack, pack, nil))
+	s.NoError(checkWatchedDir(filepath.Join(pack, "policies"), pack, nil))
+	s.NoError(checkWatchedDir(root, pack, []string{root}))
+
+	err := checkWatchedDir(root, pack, nil)
+	s.Require().Error(err)
+	s.Contains(err.Error(), "is outside the pack and the policy roots")
+	s.Error(checkWatchedDir(filepath.Dir(pack), pack, nil), "a directory above the pack")
+}
+
+func (s *CmdTestSuite) TestServeCmdRejectsWatchOutsideThePack() {
+	err := runServeCLI(context.Background(), []string{"--pack-location", s.T().TempDir(), "--watch", s.T().TempDir()})
+	s.Require().Error(err)
+	s.Contains(err.Error(), "is outside the pack and the policy roots")
+}
+
+func (s *CmdTestSuite) TestServeCmdRejectsWatchWithPlans() {
+	err := runServeCLI(context.Background(), []string{"--plans", "plans.bin", "--watch", "."})
+	s.Require().Error(err)
+	s.Contains(err.Error(), "--plans cannot be combined with --watch")
+}
@@ -21,4 +21,5 @@ const (
 
 	PackFileExtension   = "pack.toml"
 	PolicyFileExtension = APPNAME
+	TestFileExtension   = "test." + APPNAME
 )
@@ -0,0 +1,61 @@
+// SPDX-FileCopyrightText: © 2026 Binaek Sarkar <binaek89@gmail.com>
+// SPDX-License-Identifier: Apache-2.0
+
+package constraints
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/sentrie-sh/sentrie/box"
+	"github.com/sentrie-sh/sentrie/index"
+)
+
+// DateConstraintCheckers contains supported date constraint validators.
+// Both the value and the arguments are ISO-8601 date strings.
+var DateConstraintCheckers map[string]ConstraintDefinition = map[string]ConstraintDefinition{
+	"after": {
+		Name:    "after",
+		NumArgs: 1,
+		Checker: func(ctx context.Context, p *index.Policy, val box.Value, args []box.Value) error {
+			c, err := compareDateArg("after", val, args)
+			if err != nil {
+				return err
+			}
+			if c <= 0 {
+				return fmt.Errorf("date %s is not after %s", val, args[0])
+			}
+			return nil
+		},
+	},
+	"before": {
+		Name:    "before",
+		NumArgs: 1,
+		Checker: func(ctx context.Context, p *index.Policy, val box.Value, args []box.Value) error {
+			c, err := compareDateArg("before", val, args)
+			if err != nil {
+				return err
+			}
+			if c >= 0 {
+				return fmt.Errorf("date %s is not before %s", val, args[0])
+			}
+			return nil
+		},
+	},
+}
+
+// compareDateArg orders val against the single date argument of the named constraint
+func compareDateArg(name string, val box.Value, args []box.Value) (int, error) {
+	t, ok := val.DateValue()
+	if !ok {
+		return 0, fmt.Errorf("expected date, got %s", val)
+	}
+	if len(args) != 1 {
+		return 0, fmt.Errorf("%s constraint requires 1 argument", name)
+	}
+	bound, ok := args[0].DateValue()
+	if !ok {
+		return 0, fmt.Errorf("%s constraint expects a date argument, got %s", name, args[0])
+	}
+	return t.Compare(bound), nil
+}
@@ -0,0 +1,27 @@
+// SPDX-FileCopyrightText: © 2026 Binaek Sarkar <binaek89@gmail.com>
+// SPDX-License-Identifier: Apache-2.0
+
+package constraints_test
+
+import (
+	"github.com/sentrie-sh/sentrie/box"
+	"github.com/sentrie-sh/sentrie/constraints"
+)
+
+func (s *ConstraintsTestSuite) TestDateAfter() {
+	c := constraints.DateConstraintCheckers["after"]
+	s.runChecker(c, box.String("2021-06-01T12:00:00Z"), []box.Value{box.String("2020-01-01")}, false)
+	s.runChecker(c, box.String("2020-01-01"), []box.Value{box.String("2020-01-01")}, true)
+	s.runChecker(c, box.String("2019-12-31"), []box.Value{box.String("2020-01-01")}, true)
+	s.runChecker(c, box.String("not a date"), []box.Value{box.String("2020-01-01")}, true)
+	s.runChecker(c, box.String("2021-01-01"), []box.Value{box.Number(2020)}, true)
+	s.runChecker(c, box.String("2021-01-01"), nil, true)
+}
+
+func (s *ConstraintsTestSuite) TestDateBefore() {
+	c := constraints.DateConstraintCheckers["before"]
+	s.runChecker(c, box.String("2019-12-31T23:59:59Z"), []box.Value{box.String("2020-01-01")}, false)
+	s.runChecker(c, box.String("2020-01-01T00:00:00Z"), []box.Value{box.String("2020-01-01")}, true)
+	s.runChecker(c, box.String("2021-01-01"), []box.Value{box.String("2020-01-01")}, true)
+	s.runChecker(c, box.String("2019-01-01"), []box.Value{box.String("tomorrow")}, true)
+}
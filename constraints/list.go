@@ -19,6 +19,7 @@ package constraints
 import (
 	"context"
 	"fmt"
+	"math"
 
 	"github.com/sentrie-sh/sentrie/box"
 	"github.com/sentrie-sh/sentrie/index"
@@ -38,4 +39,99 @@ var ListContraintCheckers map[string]ConstraintDefinition = map[string]Constrain
 			return nil
 		},
 	},
+	"length": {
+		Name:    "length",
+		NumArgs: 1,
+		Checker: func(ctx context.Context, p *index.Policy, val box.Value, args []box.Value) error {
+			lst, ok := val.ListValue()
+			if !ok {
+				return fmt.Errorf("expected list, got %s", val.Kind())
+			}
+			if len(args) != 1 {
+				return fmt.Errorf("length constraint requires 1 argument")
+			}
+			expectedLen, okn := args[0].NumberValue()
+			if !okn {
+				return fmt.Errorf("expected number, got %s", args[0].Kind())
+			}
+			if len(lst) != int(expectedLen) {
+				return fmt.Errorf("list length %d is not equal to %g", len(lst), expectedLen)
+			}
+			return nil
+		},
+	},
+	"minlength": {
+		Name:    "minlength",
+		NumArgs: 1,
+		Checker: func(ctx context.Context, p *index.Policy, val box.Value, args []box.Value) error {
+			lst, ok := val.ListValue()
+			if !ok {
+				return fmt.Errorf("expected list, got %s", val.Kind())
+			}
+			if len(args) != 1 {
+				return fmt.Errorf("minlength constraint requires 1 argument")
+			}
+			expectedLen, okn := args[0].NumberValue()
+			if !okn {
+				return fmt.Errorf("expected number, got %s", args[0].Kind())
+			}
+			if len(lst) < int(expectedLen) {
+				return fmt.Errorf("list length %d is not greater than or equal to %g", len(lst), expectedLen)
+			}
+			return nil
+		},
+	},
+	"maxlength": {
+		Name:    "maxlength",
+		NumArgs: 1,
+		Checker: func(ctx context.Context, p *index.Policy, val box.Value, args []box.Value) error {
+			lst, ok := val.ListValue()
+			if !ok {
+				return fmt.Errorf("expected list, got %s", val.Kind())
+			}
+			if len(args) != 1 {
+				return fmt.Errorf("maxlength constraint requires 1 argument")
+			}
+			expectedLen, okn := args[0].NumberValue()
+			if !okn {
+				return fmt.Errorf("expected number, got %s", args[0].Kind())
+			}
+			if len(lst) > int(expectedLen) {
+				return fmt.Errorf("list length %d is not less than or equal to %g", len(lst), expectedLen)
+			}
+			return nil
+		},
+	},
+	"unique": {
+		Name:    "unique",
+		NumArgs: 0,
+		Checker: func(ctx context.Context, p *index.Policy, val box.Value, args []box.Value) error {
+			lst, ok := val.ListValue()
+			if !ok {
+				return fmt.Errorf("expected list, got %s", val.Kind())
+			}
+			// scalars are compared by box.ScalarKey, exactly like the distinct builtin does, NaN
+			// included: it duplicates nothing. Lists and dicts, which distinct rejects, are
+			// compared structurally
+			firstSeen := make(map[string]int, len(lst))
+			for i, item := range lst {
+				if n, ok := item.NumberValue(); ok && math.IsNaN(n) {
+					continue
+				}
+				if key, ok := box.ScalarKey(item); ok {
+					if j, dup := firstSeen[key]; dup {
+						return fmt.Errorf("list contains duplicate value %s at index %d (first seen at index %d)", item, i, j)
+					}
+					firstSeen[key] = i
+					continue
+				}
+				for j := 0; j < i; j++ {
+					if _, scalar := box.ScalarKey(lst[j]); !scalar && box.EqualValues(item, lst[j]) {
+						return fmt.Errorf("list contains duplicate value %s at index %d (first seen at index %d)", item, i, j)
+					}
+				}
+			}
+			return nil
+		},
+	},
 }
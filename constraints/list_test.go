@@ -17,6 +17,8 @@
 package constraints_test
 
 import (
+	"math"
+
 	"github.com/sentrie-sh/sentrie/box"
 	"github.com/sentrie-sh/sentrie/constraints"
 )
@@ -28,3 +30,55 @@ func (s *ConstraintsTestSuite) TestListNotEmpty() {
 	s.runChecker(c, box.List([]box.Value{}), nil, true)
 	s.runChecker(c, box.List([]box.Value{box.Number(1)}), nil, false)
 }
+
+func (s *ConstraintsTestSuite) TestListLength() {
+	c := constraints.ListContraintCheckers["length"]
+	two := box.List([]box.Value{box.Number(1), box.Number(2)})
+	s.runChecker(c, box.String("ab"), []box.Value{box.Number(2)}, true)
+	s.runChecker(c, two, []box.Value{box.String("2")}, true)
+	s.runChecker(c, two, []box.Value{box.Number(3)}, true)
+	s.runChecker(c, two, []box.Value{box.Number(2)}, false)
+}
+
+func (s *ConstraintsTestSuite) TestListMinLength() {
+	c := constraints.ListContraintCheckers["minlength"]
+	two := box.List([]box.Value{box.Number(1), box.Number(2)})
+	s.runChecker(c, box.String("ab"), []box.Value{box.Number(1)}, true)
+	s.runChecker(c, box.List([]box.Value{}), []box.Value{box.Number(1)}, true)
+	s.runChecker(c, two, []box.Value{box.Number(2)}, false)
+	s.runChecker(c, two, []box.Value{box.Number(1)}, false)
+}
+
+func (s *ConstraintsTestSuite) TestListMaxLength() {
+	c := constraints.ListContraintCheckers["maxlength"]
+	two := box.List([]box.Value{box.Number(1), box.Number(2)})
+	s.runChecker(c, box.String("ab"), []box.Value{box.Number(10)}, true)
+	s.runChecker(c, two, []box.Value{box.Number(1)}, true)
+	s.runChecker(c, two, []box.Value{box.Number(2)}, false)
+	s.runChecker(c, box.List([]box.Value{}), []box.Value{box.Number(10)}, false)
+}
+
+func (s *ConstraintsTestSuite) TestListUnique() {
+	c := constraints.ListContraintCheckers["unique"]
+	s.runChecker(c, box.String("ab"), nil, true)
+	s.runChecker(c, box.List([]box.Value{}), nil, false)
+	s.runChecker(c, box.List([]box.Value{box.Number(1), box.String("1"), box.Bool(true)}), nil, false)
+	s.runChecker(c, box.List([]box.Value{box.String("a"), box.String("b"), box.String("a")}), nil, true)
+	s.runChecker(c, box.FromAny([]any{map[string]any{"id": 1.0}, map[string]any{"id": 2.0}}), nil, false)
+	s.runChecker(c, box.FromAny([]any{map[string]any{"id": 1.0}, map[string]any{"id": 1.0}}), nil, true)
+	s.runChecker(c, box.FromAny([]any{[]any{1.0, 2.0}, []any{1.0, 2.0}}), nil, true)
+}
+
+// unique must agree with the distinct builtin, which keys scalars with box.ScalarKey
+func (s *ConstraintsTestSuite) TestListUniqueMatchesDistinctEquality() {
+	c := constraints.ListContraintCheckers["unique"]
+	s.runChecker(c, box.List([]box.Value{box.Number(math.NaN()), box.Number(math.NaN())}), nil, false)
+	s.runChecker(c, box.List([]box.Value{box.Number(math.Copysign(0, -1)), box.Number(0)}), nil, true)
+}
+
+func (s *ConstraintsTestSuite) TestListUniqueReportsFirstDuplicate() {
+	c := constraints.ListContraintCheckers["unique"]
+	err := c.Checker(s.T().Context(), nil, box.List([]box.Value{box.String("a"), box.String("b"), box.String("b"), box.String("a")}), nil)
+	s.Require().Error(err)
+	s.Contains(err.Error(), "duplicate value b at index 2")
+}
@@ -197,6 +197,29 @@ var NumberContraintCheckers map[string]ConstraintDefinition = map[string]Constra
 			return nil
 		},
 	},
+	"one_of": {
+		Name:    "one_of",
+		NumArgs: -1,
+		Checker: func(ctx context.Context, p *index.Policy, val box.Value, args []box.Value) error {
+			valNum, ok := val.NumberValue()
+			if !ok {
+				return fmt.Errorf("expected number, got %s", val.Kind())
+			}
+			if len(args) < 1 {
+				return fmt.Errorf("one_of constraint requires at least 1 argument")
+			}
+			for _, arg := range args {
+				argNum, oka := arg.NumberValue()
+				if !oka {
+					return fmt.Errorf("expected number, got %s", arg.Kind())
+				}
+				if valNum == argNum {
+					return nil
+				}
+			}
+			return fmt.Errorf("value %v is not one of the allowed values", val)
+		},
+	},
 	"range": {
 		Name:    "range",
 		NumArgs: 2,
@@ -103,6 +103,15 @@ func (s *ConstraintsTestSuite) TestNumberInAndNotIn() {
 	})
 }
 
+func (s *ConstraintsTestSuite) TestNumberOneOf() {
+	c := constraints.NumberContraintCheckers["one_of"]
+	s.runChecker(c, box.Number(2), []box.Value{box.Number(1), box.Number(2), box.Number(3)}, false)
+	s.runChecker(c, box.Number(4), []box.Value{box.Number(1), box.Number(2), box.Number(3)}, true)
+	s.runChecker(c, box.Number(1), []box.Value{}, true)
+	s.runChecker(c, box.String("1"), []box.Value{box.Number(1)}, true)
+	s.runChecker(c, box.Number(1), []box.Value{box.String("1")}, true)
+}
+
 func (s *ConstraintsTestSuite) TestNumberRange() {
 	c := constraints.NumberContraintCheckers["range"]
 	s.runChecker(c, box.Number(5), []box.Value{box.Number(1), box.Number(10)}, false)
@@ -19,11 +19,13 @@ package constraints
 import (
 	"context"
 	"fmt"
+	"net"
 	"regexp"
 	"strconv"
 	"strings"
 	"unicode"
 
+	"github.com/binaek/perch"
 	"github.com/google/uuid"
 	"github.com/sentrie-sh/sentrie/box"
 	"github.com/sentrie-sh/sentrie/index"
@@ -95,24 +97,32 @@ var StringContraintCheckers map[string]ConstraintDefinition = map[string]Constra
 	},
 	"regexp": {
 		Name:    "regexp",
-		NumArgs: 1,
+		NumArgs: -1, // pattern, with optional flags
 		Checker: func(ctx context.Context, p *index.Policy, val box.Value, args []box.Value) error {
 			s, ok := val.StringValue()
 			if !ok {
 				return fmt.Errorf("expected string, got %s", val.Kind())
 			}
-			if len(args) != 1 {
-				return fmt.Errorf("regexp constraint requires 1 argument")
+			if len(args) != 1 && len(args) != 2 {
+				return fmt.Errorf("regexp constraint requires 1 or 2 arguments")
 			}
 			pattern, okp := args[0].StringValue()
 			if !okp {
 				return fmt.Errorf("expected string, got %s", args[0].Kind())
 			}
-			matched, err := regexp.MatchString(pattern, s)
+			flags := ""
+			if len(args) == 2 {
+				f, okf := args[1].StringValue()
+				if !okf {
+					return fmt.Errorf("expected string, got %s", args[1].Kind())
+				}
+				flags = f
+			}
+			re, err := compileCachedRegexp(pattern, flags)
 			if err != nil {
-				return fmt.Errorf("invalid regexp pattern: %v", err)
+				return err
 			}
-			if !matched {
+			if !re.MatchString(s) {
 				return fmt.Errorf("string %q does not match pattern %q", s, pattern)
 			}
 			return nil
@@ -247,6 +257,66 @@ var StringContraintCheckers map[string]ConstraintDefinition = map[string]Constra
 			return nil
 		},
 	},
+	"semver": {
+		Name:    "semver",
+		NumArgs: 0,
+		Checker: func(ctx context.Context, p *index.Policy, val box.Value, args []box.Value) error {
+			s, ok := val.StringValue()
+			if !ok {
+				return fmt.Errorf("expected string, got %s", val.Kind())
+			}
+			// parsed like a policy's `version` header, so that the two agree on every version
+			if _, err := index.ParseVersion(s); err != nil {
+				return fmt.Errorf("string %q is not a valid semantic version: %v", s, err)
+			}
+			return nil
+		},
+	},
+	"ipv4": {
+		Name:    "ipv4",
+		NumArgs: 0,
+		Checker: func(ctx context.Context, p *index.Policy, val box.Value, args []box.Value) error {
+			s, ok := val.StringValue()
+			if !ok {
+				return fmt.Errorf("expected string, got %s", val.Kind())
+			}
+			ip := net.ParseIP(s)
+			if ip == nil || ip.To4() == nil || strings.Contains(s, ":") {
+				return fmt.Errorf("string %q is not a valid IPv4 address", s)
+			}
+			return nil
+		},
+	},
+	"ipv6": {
+		Name:    "ipv6",
+		NumArgs: 0,
+		Checker: func(ctx context.Context, p *index.Policy, val box.Value, args []box.Value) error {
+			s, ok := val.StringValue()
+			if !ok {
+				return fmt.Errorf("expected string, got %s", val.Kind())
+			}
+			// an IPv4-mapped IPv6 address (::ffff:a.b.c.d) is still written in IPv6 form
+			ip := net.ParseIP(s)
+			if ip == nil || !strings.Contains(s, ":") {
+				return fmt.Errorf("string %q is not a valid IPv6 address", s)
+			}
+			return nil
+		},
+	},
+	"cidr": {
+		Name:    "cidr",
+		NumArgs: 0,
+		Checker: func(ctx context.Context, p *index.Policy, val box.Value, args []box.Value) error {
+			s, ok := val.StringValue()
+			if !ok {
+				return fmt.Errorf("expected string, got %s", val.Kind())
+			}
+			if _, _, err := net.ParseCIDR(s); err != nil {
+				return fmt.Errorf("string %q is not a valid CIDR block: %v", s, err)
+			}
+			return nil
+		},
+	},
 	"alphanumeric": {
 		Name:    "alphanumeric",
 		NumArgs: 0,
@@ -391,7 +461,7 @@ var StringContraintCheckers map[string]ConstraintDefinition = map[string]Constra
 					return fmt.Errorf("expected string, got %s", arg.Kind())
 				}
 				if s == argString {
-					return fmt.Errorf("string %q is one of the allowed values", s)
+					return fmt.Errorf("string %q is in the list of disallowed values", s)
 				}
 			}
 
@@ -399,3 +469,53 @@ var StringContraintCheckers map[string]ConstraintDefinition = map[string]Constra
 		},
 	},
 }
+
+// RegexpFlags are the flag letters accepted by the regexp constraint. The ast package
+// receives them through go generate to validate flags at parse time.
+const RegexpFlags = "ims"
+
+// RegexpFlagsPrefix maps the flag letters accepted by the regexp constraint (see RegexpFlags)
+// to Go's inline flag syntax, e.g. "is" becomes "(?is)". An empty flag string yields no prefix.
+func RegexpFlagsPrefix(flags string) (string, error) {
+	if flags == "" {
+		return "", nil
+	}
+	for _, f := range flags {
+		if !strings.ContainsRune(RegexpFlags, f) {
+			return "", fmt.Errorf("unknown regexp flag %q: supported flags are %q", f, RegexpFlags)
+		}
+	}
+	return "(?" + flags + ")", nil
+}
+
+type regexpCacheEntry struct {
+	re  *regexp.Regexp
+	err error
+}
+
+// regexpCacheEntries is the most patterns regexpCache holds; the least recently used pattern
+// is evicted to make room for another.
+const regexpCacheEntries = 1024
+
+// regexpCache holds compiled patterns (and compilation errors) for the regexp constraint,
+// so a pattern is compiled once no matter how many values are validated against it. Patterns
+// come from constraint arguments, which need not be literals, so the cache is bounded.
+var regexpCache = perch.New[*regexpCacheEntry](regexpCacheEntries << 3 /* a slot holds a pointer */)
+
+func compileCachedRegexp(pattern, flags string) (*regexp.Regexp, error) {
+	// the flags are quoted, so that no flags and pattern share a key with others
+	key := strconv.Quote(flags) + pattern
+	entry, _, _ := regexpCache.Get(context.Background(), key, 0, func(context.Context, string) (*regexpCacheEntry, error) {
+		entry := &regexpCacheEntry{}
+		prefix, err := RegexpFlagsPrefix(flags)
+		if err != nil {
+			entry.err = err
+		} else if re, err := regexp.Compile(prefix + pattern); err != nil {
+			entry.err = fmt.Errorf("invalid regexp pattern: %v", err)
+		} else {
+			entry.re = re
+		}
+		return entry, nil
+	})
+	return entry.re, entry.err
+}
@@ -0,0 +1,58 @@
+// SPDX-FileCopyrightText: © 2026 Binaek Sarkar <binaek89@gmail.com>
+// SPDX-License-Identifier: Apache-2.0
+
+package constraints
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func regexpCacheLen() int {
+	return regexpCache.Stats().Size
+}
+
+func TestCompileCachedRegexpReusesCompiledPattern(t *testing.T) {
+	pattern := `^cached-[a-z]+$`
+
+	first, err := compileCachedRegexp(pattern, "")
+	require.NoError(t, err)
+	entries := regexpCacheLen()
+
+	second, err := compileCachedRegexp(pattern, "")
+	require.NoError(t, err)
+	require.Same(t, first, second)
+	require.Equal(t, entries, regexpCacheLen())
+
+	// the same pattern with different flags is a different cache entry
+	withFlags, err := compileCachedRegexp(pattern, "i")
+	require.NoError(t, err)
+	require.NotSame(t, first, withFlags)
+	require.Equal(t, entries+1, regexpCacheLen())
+}
+
+func TestCompileCachedRegexpCachesErrors(t *testing.T) {
+	_, err := compileCachedRegexp(`(cached`, "")
+	require.Error(t, err)
+	entries := regexpCacheLen()
+
+	_, again := compileCachedRegexp(`(cached`, "")
+	require.Same(t, err, again)
+	require.Equal(t, entries, regexpCacheLen())
+}
+
+func TestCompileCachedRegexpEvictsLeastRecentlyUsedPattern(t *testing.T) {
+	first, err := compileCachedRegexp(`^evicted$`, "")
+	require.NoError(t, err)
+	for i := range regexpCacheEntries {
+		_, err := compileCachedRegexp(fmt.Sprintf(`^filler-%d$`, i), "")
+		require.NoError(t, err)
+	}
+	require.Equal(t, regexpCacheEntries, regexpCacheLen())
+
+	again, err := compileCachedRegexp(`^evicted$`, "")
+	require.NoError(t, err)
+	require.NotSame(t, first, again)
+}
@@ -17,6 +17,10 @@
 package constraints_test
 
 import (
+	"context"
+	"regexp"
+	"testing"
+
 	"github.com/sentrie-sh/sentrie/box"
 	"github.com/sentrie-sh/sentrie/constraints"
 )
@@ -51,6 +55,43 @@ func (s *ConstraintsTestSuite) TestStringRegexp() {
 	s.runChecker(c, box.String("x"), []box.Value{box.String(`(`)}, true)
 	s.runChecker(c, box.Number(1), []box.Value{box.String(`.*`)}, true)
 	s.runChecker(c, box.String("x"), []box.Value{box.Number(1)}, true)
+
+	s.Run("with flags", func() {
+		s.runChecker(c, box.String("FOO"), []box.Value{box.String(`^foo$`), box.String("i")}, false)
+		s.runChecker(c, box.String("FOO"), []box.Value{box.String(`^foo$`)}, true)
+		s.runChecker(c, box.String("a\nfoo"), []box.Value{box.String(`^foo$`), box.String("m")}, false)
+		s.runChecker(c, box.String("a\nb"), []box.Value{box.String(`a.b`), box.String("s")}, false)
+		s.runChecker(c, box.String("foo"), []box.Value{box.String(`^foo$`), box.String("")}, false)
+		s.runChecker(c, box.String("foo"), []box.Value{box.String(`^foo$`), box.String("x")}, true)
+		s.runChecker(c, box.String("foo"), []box.Value{box.String(`^foo$`), box.Number(1)}, true)
+		s.runChecker(c, box.String("foo"), []box.Value{box.String(`^foo$`), box.String("i"), box.String("i")}, true)
+	})
+}
+
+func (s *ConstraintsTestSuite) TestStringRegexpCachedCompilation() {
+	c := constraints.StringContraintCheckers["regexp"]
+	for range 3 {
+		s.runChecker(c, box.String("abc"), []box.Value{box.String(`^a.c$`)}, false)
+		s.runChecker(c, box.String("xyz"), []box.Value{box.String(`^a.c$`)}, true)
+		// compilation errors are cached too and keep surfacing
+		s.runChecker(c, box.String("abc"), []box.Value{box.String(`(`)}, true)
+	}
+	// the same pattern with different flags is a different cache entry
+	s.runChecker(c, box.String("ABC"), []box.Value{box.String(`^a.c$`)}, true)
+	s.runChecker(c, box.String("ABC"), []box.Value{box.String(`^a.c$`), box.String("i")}, false)
+}
+
+func (s *ConstraintsTestSuite) TestRegexpFlagsPrefix() {
+	prefix, err := constraints.RegexpFlagsPrefix("ims")
+	s.Require().NoError(err)
+	s.Equal("(?ims)", prefix)
+
+	prefix, err = constraints.RegexpFlagsPrefix("")
+	s.Require().NoError(err)
+	s.Empty(prefix)
+
+	_, err = constraints.RegexpFlagsPrefix("ix")
+	s.Error(err)
 }
 
 func (s *ConstraintsTestSuite) TestStringPrefixSuffixSubstring() {
@@ -95,6 +136,45 @@ func (s *ConstraintsTestSuite) TestStringEmailURLUUID() {
 		s.runChecker(c, box.String("550e8400-e29b-41d4-a716-446655440000"), nil, false)
 		s.runChecker(c, box.String("not-a-uuid"), nil, true)
 	})
+	s.Run("semver", func() {
+		c := constraints.StringContraintCheckers["semver"]
+		s.runChecker(c, box.String("1.2.0"), nil, false)
+		s.runChecker(c, box.String("1.2.0-rc.1+build.5"), nil, false)
+		// like a policy's version header, partial versions and a "v" prefix are accepted
+		s.runChecker(c, box.String("1.2"), nil, false)
+		s.runChecker(c, box.String("v1.2"), nil, false)
+		s.runChecker(c, box.String("v1.2.3"), nil, false)
+		s.runChecker(c, box.String("v1.2.3.4"), nil, true)
+		s.runChecker(c, box.String("not-a-version"), nil, true)
+		s.runChecker(c, box.Number(1), nil, true)
+	})
+}
+
+func (s *ConstraintsTestSuite) TestStringIPAndCIDR() {
+	s.Run("ipv4", func() {
+		c := constraints.StringContraintCheckers["ipv4"]
+		s.runChecker(c, box.String("192.168.1.1"), nil, false)
+		s.runChecker(c, box.String("::1"), nil, true)
+		s.runChecker(c, box.String("::ffff:192.168.1.1"), nil, true)
+		s.runChecker(c, box.String("256.1.1.1"), nil, true)
+		s.runChecker(c, box.Number(1), nil, true)
+	})
+	s.Run("ipv6", func() {
+		c := constraints.StringContraintCheckers["ipv6"]
+		s.runChecker(c, box.String("2001:db8::1"), nil, false)
+		s.runChecker(c, box.String("::1"), nil, false)
+		s.runChecker(c, box.String("192.168.1.1"), nil, true)
+		s.runChecker(c, box.String("not-an-ip"), nil, true)
+		s.runChecker(c, box.Number(1), nil, true)
+	})
+	s.Run("cidr", func() {
+		c := constraints.StringContraintCheckers["cidr"]
+		s.runChecker(c, box.String("10.0.0.0/8"), nil, false)
+		s.runChecker(c, box.String("::/0"), nil, false)
+		s.runChecker(c, box.String("10.0.0.0"), nil, true)
+		s.runChecker(c, box.String("10.0.0.0/33"), nil, true)
+		s.runChecker(c, box.Number(1), nil, true)
+	})
 }
 
 func (s *ConstraintsTestSuite) TestStringAlphaNumericCase() {
@@ -155,3 +235,30 @@ func (s *ConstraintsTestSuite) TestStringNotEmptyOneOf() {
 		s.runChecker(c, box.String("z"), []box.Value{box.Number(1)}, true)
 	})
 }
+
+func BenchmarkStringRegexpRepeatedValidation(b *testing.B) {
+	c := constraints.StringContraintCheckers["regexp"]
+	args := []box.Value{box.String(`^[a-z0-9._%+-]+@[a-z0-9.-]+\.[a-z]{2,}$`), box.String("i")}
+	val := box.String("someone@example.com")
+	ctx := context.Background()
+
+	b.ReportAllocs()
+	for b.Loop() {
+		if err := c.Checker(ctx, nil, val, args); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+// BenchmarkStringRegexpUncompiledBaseline compiles the pattern on every call, which is what
+// the regexp constraint did before compiled patterns were cached.
+func BenchmarkStringRegexpUncompiledBaseline(b *testing.B) {
+	pattern := `(?i)^[a-z0-9._%+-]+@[a-z0-9.-]+\.[a-z]{2,}$`
+
+	b.ReportAllocs()
+	for b.Loop() {
+		if _, err := regexp.MatchString(pattern, "someone@example.com"); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
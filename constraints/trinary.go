@@ -85,6 +85,29 @@ var TrinaryConstraintCheckers map[string]ConstraintDefinition = map[string]Const
 			return nil
 		},
 	},
+	"one_of": {
+		Name:    "one_of",
+		NumArgs: -1,
+		Checker: func(ctx context.Context, p *index.Policy, val box.Value, args []box.Value) error {
+			tv, ok := val.TrinaryValue()
+			if !ok {
+				return fmt.Errorf("expected trinary, got %s", val.Kind())
+			}
+			if len(args) < 1 {
+				return fmt.Errorf("one_of constraint requires at least 1 argument")
+			}
+			for _, arg := range args {
+				expected, oka := arg.TrinaryValue()
+				if !oka {
+					return fmt.Errorf("one_of constraint expects boolean arguments, got %s", arg.Kind())
+				}
+				if tv == expected {
+					return nil
+				}
+			}
+			return fmt.Errorf("value %v is not one of the allowed values", tv)
+		},
+	},
 	"is_true": {
 		Name:    "is_true",
 		NumArgs: 0,
@@ -46,6 +46,15 @@ func (s *ConstraintsTestSuite) TestTrinaryEqNeq() {
 	})
 }
 
+func (s *ConstraintsTestSuite) TestTrinaryOneOf() {
+	c := constraints.TrinaryConstraintCheckers["one_of"]
+	s.runChecker(c, box.Trinary(trinary.False), []box.Value{box.Trinary(trinary.True), box.Trinary(trinary.False)}, false)
+	s.runChecker(c, box.Trinary(trinary.Unknown), []box.Value{box.Trinary(trinary.True), box.Trinary(trinary.False)}, true)
+	s.runChecker(c, box.Trinary(trinary.True), []box.Value{}, true)
+	s.runChecker(c, box.String("x"), []box.Value{box.Trinary(trinary.True)}, true)
+	s.runChecker(c, box.Trinary(trinary.True), []box.Value{box.String("x")}, true)
+}
+
 func (s *ConstraintsTestSuite) TestTrinaryIsTrueIsFalse() {
 	s.Run("is_true", func() {
 		c := constraints.TrinaryConstraintCheckers["is_true"]
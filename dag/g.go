@@ -19,6 +19,7 @@ package dag
 import (
 	"errors"
 	"fmt"
+	"maps"
 	"slices"
 	"strings"
 	"sync"
@@ -100,11 +101,9 @@ func (d *gImpl[T]) TopoSort() ([]T, error) {
 
 	var dfs func(node string) error
 	dfs = func(node string) error {
-		if slices.Contains(visiting, node) {
+		if idx := slices.Index(visiting, node); idx >= 0 {
 			// if we are already visiting this node, we have a cycle
-			idx := slices.Index(visiting, node)
-			path := append(visiting[idx:], node)
-			return ErrCycle{Path: path}
+			return ErrCycle{Path: cyclePath(visiting[idx:])}
 		}
 		if _, ok := visited[node]; ok {
 			return nil
@@ -115,7 +114,7 @@ func (d *gImpl[T]) TopoSort() ([]T, error) {
 		}()
 
 		visited[node] = struct{}{}
-		for neighbor := range d.edges[node] {
+		for _, neighbor := range slices.Sorted(maps.Keys(d.edges[node])) {
 			if err := dfs(neighbor); err != nil {
 				return err
 			}
@@ -125,7 +124,7 @@ func (d *gImpl[T]) TopoSort() ([]T, error) {
 		return nil
 	}
 
-	for node := range d.nodes {
+	for _, node := range slices.Sorted(maps.Keys(d.nodes)) {
 		if err := dfs(node); err != nil {
 			return nil, err
 		}
@@ -141,6 +140,8 @@ func (d *gImpl[T]) TopoSort() ([]T, error) {
 	return nodes, nil
 }
 
+// DetectFirstCycle returns the path around the first cycle found, see cyclePath, or an empty
+// slice when the graph has none. Nodes and edges are visited in order, so the result is stable.
 func (d *gImpl[T]) DetectFirstCycle() []T {
 	d.lock.RLock()
 	defer d.lock.RUnlock()
@@ -151,11 +152,9 @@ func (d *gImpl[T]) DetectFirstCycle() []T {
 
 	var dfs func(node string) []string
 	dfs = func(node string) []string {
-		if slices.Contains(visiting, node) {
+		if idx := slices.Index(visiting, node); idx >= 0 {
 			// if we are already visiting this node, we have a cycle
-			idx := slices.Index(visiting, node)
-			path := append(visiting[idx:], node)
-			return path
+			return cyclePath(visiting[idx:])
 		}
 		if _, ok := visited[node]; ok {
 			return nil
@@ -167,7 +166,7 @@ func (d *gImpl[T]) DetectFirstCycle() []T {
 		}()
 
 		visited[node] = struct{}{}
-		for neighbor := range d.edges[node] {
+		for _, neighbor := range slices.Sorted(maps.Keys(d.edges[node])) {
 			if err := dfs(neighbor); err != nil {
 				return err
 			}
@@ -175,7 +174,7 @@ func (d *gImpl[T]) DetectFirstCycle() []T {
 		return nil
 	}
 
-	for node := range d.nodes {
+	for _, node := range slices.Sorted(maps.Keys(d.nodes)) {
 		if cycle := dfs(node); len(cycle) > 0 {
 			// Convert cycle path to []T
 			result := make([]T, len(cycle))
@@ -188,3 +187,14 @@ func (d *gImpl[T]) DetectFirstCycle() []T {
 
 	return []T{}
 }
+
+// cyclePath returns the path around the cycle formed by the nodes on the DFS stack, in edge
+// order. The path starts, and ends, at the least node, so that a cycle reads the same whichever
+// of its nodes the search entered it from.
+func cyclePath(cycle []string) []string {
+	start := slices.Index(cycle, slices.Min(cycle))
+	path := make([]string, 0, len(cycle)+1)
+	path = append(path, cycle[start:]...)
+	path = append(path, cycle[:start]...)
+	return append(path, cycle[start])
+}
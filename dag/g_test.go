@@ -288,6 +288,25 @@ func (s *DagTestSuite) TestTopoSortWithCycle() {
 	s.Contains(cycleErr.Path, "C")
 }
 
+// TestCyclePathFollowsEdges tests that a cycle is reported in edge order from its least node
+func (s *DagTestSuite) TestCyclePathFollowsEdges() {
+	// the search enters the cycle C -> A -> B -> C through its lead-in node 0
+	graph := New[TestNode]()
+	for _, id := range []string{"0", "A", "B", "C"} {
+		graph.AddNode(TestNode{ID: id})
+	}
+	s.Require().NoError(graph.AddEdge(TestNode{ID: "0"}, TestNode{ID: "C"}))
+	s.Require().NoError(graph.AddEdge(TestNode{ID: "C"}, TestNode{ID: "A"}))
+	s.Require().NoError(graph.AddEdge(TestNode{ID: "A"}, TestNode{ID: "B"}))
+	s.Require().NoError(graph.AddEdge(TestNode{ID: "B"}, TestNode{ID: "C"}))
+
+	s.Equal([]TestNode{{ID: "A"}, {ID: "B"}, {ID: "C"}, {ID: "A"}}, graph.DetectFirstCycle())
+
+	_, err := graph.TopoSort()
+	s.Equal(ErrCycle{Path: []string{"A", "B", "C", "A"}}, err)
+	s.EqualError(err, "cycle detected: A -> B -> C -> A")
+}
+
 // TestDetectAllCycles tests the DetectAllCycles() method
 func (s *DagTestSuite) TestDetectAllCycles() {
 	// Test empty graph
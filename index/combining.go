@@ -0,0 +1,31 @@
+// SPDX-FileCopyrightText: © 2026 Binaek Sarkar <binaek89@gmail.com>
+// SPDX-License-Identifier: Apache-2.0
+
+package index
+
+// CombiningAlgorithm names how the decisions of the exported rules of a policy combine into
+// the decision of the policy, as declared by its combining statement.
+type CombiningAlgorithm string
+
+const (
+	// CombiningNone leaves the decisions of the exported rules uncombined.
+	CombiningNone CombiningAlgorithm = ""
+	// CombiningDenyOverrides is false when any decision is false, else unknown when any is
+	// unknown, else true.
+	CombiningDenyOverrides CombiningAlgorithm = "deny-overrides"
+	// CombiningPermitOverrides is true when any decision is true, else unknown when any is
+	// unknown, else false.
+	CombiningPermitOverrides CombiningAlgorithm = "permit-overrides"
+	// CombiningFirstApplicable is the first decision that is not unknown, in export declaration
+	// order, else unknown.
+	CombiningFirstApplicable CombiningAlgorithm = "first-applicable"
+)
+
+// IsValid reports whether a is an algorithm a combining statement may declare.
+func (a CombiningAlgorithm) IsValid() bool {
+	switch a {
+	case CombiningDenyOverrides, CombiningPermitOverrides, CombiningFirstApplicable:
+		return true
+	}
+	return false
+}
@@ -19,26 +19,51 @@ package index
 import (
 	"context"
 	"fmt"
+	"slices"
+	"sync/atomic"
+	"time"
 )
 
+// Commit validates the index, if that has not happened yet, and finalizes the
+// derived structures needed at runtime. It is safe to call repeatedly: every
+// call returns the result of the first one. If validation failed, the
+// validation error is returned and nothing is committed.
 func (idx *Index) Commit(ctx context.Context) error {
+	if err := idx.runValidation(ctx); err != nil {
+		// we couldn't validate the index, so we can't commit
+		return err
+	}
+
 	idx.commitOnce.Do(func() {
 		idx.commitError = idx.commit(ctx)
 		if idx.commitError != nil {
 			idx.commitError = fmt.Errorf("commit error: %w", idx.commitError)
+		} else {
+			idx.committedAt = time.Now()
 		}
-		idx.committed = 1
+		atomic.StoreUint32(&idx.committed, 1)
 	})
 	return idx.commitError
 }
 
+// CommittedAt returns when the index was committed successfully, or the zero time when it has
+// not been.
+func (idx *Index) CommittedAt() time.Time {
+	if atomic.LoadUint32(&idx.committed) == 0 {
+		return time.Time{}
+	}
+	return idx.committedAt
+}
+
 func (idx *Index) commit(ctx context.Context) error {
 	traversal, err := idx.shapeDag.TopoSort()
 	if err != nil {
 		return err
 	}
 
-	for _, shape := range traversal {
+	// edges run from a shape to the one it composes, so hydrate from the back of the
+	// order: a shape's composition is then hydrated before the shape itself
+	for _, shape := range slices.Backward(traversal) {
 		if ctx.Err() != nil {
 			return ctx.Err()
 		}
@@ -47,5 +72,5 @@ func (idx *Index) commit(ctx context.Context) error {
 		}
 	}
 
-	return nil
+	return idx.hashPolicies()
 }
@@ -0,0 +1,73 @@
+// SPDX-FileCopyrightText: © 2026 Binaek Sarkar <binaek89@gmail.com>
+// SPDX-License-Identifier: Apache-2.0
+
+package index
+
+import (
+	"time"
+
+	"github.com/sentrie-sh/sentrie/xerr"
+)
+
+func (suite *IndexTestSuite) TestCommitSucceeds() {
+	idx := suite.indexFromSource(nil, `namespace com/example
+shape User { name: string }
+policy a {
+  rule x = default true { yield true }
+  export decision of x
+}`)
+
+	suite.Zero(idx.CommittedAt())
+	suite.Require().NoError(idx.Commit(suite.ctx))
+	suite.Equal(uint32(1), idx.validated)
+	suite.Equal(uint32(1), idx.committed)
+	suite.NotNil(idx.shapeDag)
+	suite.NotNil(idx.ruleDag)
+	suite.WithinDuration(time.Now(), idx.CommittedAt(), time.Minute)
+}
+
+func (suite *IndexTestSuite) TestCommitIsIdempotent() {
+	idx := suite.indexFromSource(nil, `namespace com/example
+policy a {
+  rule x = default true { yield true }
+  export decision of x
+}`)
+
+	suite.Require().NoError(idx.Commit(suite.ctx))
+	suite.Require().NoError(idx.Commit(suite.ctx))
+	suite.Require().NoError(idx.Validate(suite.ctx))
+}
+
+func (suite *IndexTestSuite) TestCommitSurfacesValidationError() {
+	idx := suite.indexFromSource(nil, `namespace com/example
+policy a {
+  rule x = import decision x from a
+  export decision of x
+}`)
+
+	err := idx.Commit(suite.ctx)
+	suite.Require().Error(err)
+	suite.ErrorIs(err, xerr.ErrIndex)
+	suite.Contains(err.Error(), "validation error")
+	suite.Equal(uint32(0), idx.committed)
+
+	// the cached result is returned on every call, from either entry point
+	suite.Equal(err, idx.Commit(suite.ctx))
+	suite.Equal(err, idx.Validate(suite.ctx))
+}
+
+func (suite *IndexTestSuite) TestCommitSurfacesCommitError() {
+	idx := suite.indexFromSource(nil, `namespace com/example
+shape Base string
+shape User with Base { id: string }`)
+
+	// validation passes; composing an alias is only detected while committing
+	suite.Require().NoError(idx.runValidation(suite.ctx))
+
+	err := idx.Validate(suite.ctx)
+	suite.Require().Error(err)
+	suite.Contains(err.Error(), "commit error")
+	suite.Contains(err.Error(), "cannot compose 'com/example/User' with alias of shape 'com/example/Base'")
+	suite.Equal(err, idx.Commit(suite.ctx))
+	suite.Zero(idx.CommittedAt())
+}
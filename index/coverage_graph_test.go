@@ -63,6 +63,72 @@ func (suite *IndexTestSuite) TestValidate_ReferenceCycleSelfIdentifier() {
 	suite.Contains(err.Error(), "infinite recursion")
 }
 
+// validateLets validates a policy declaring lets, with a trivial exported rule.
+func (suite *IndexTestSuite) validateLets(lets string) error {
+	return suite.indexFromSource(nil, `namespace com/example
+policy p {
+  `+lets+`
+  rule r = default true { yield true }
+  export decision of r
+}`).Validate(suite.ctx)
+}
+
+func (suite *IndexTestSuite) TestValidate_ReferenceCycleThroughProjection() {
+	err := suite.validateLets(`let x = project y { a: 1 }
+  let y = project x as v { a: v.a }`)
+	suite.Require().Error(err)
+	suite.Contains(err.Error(), "infinite recursion")
+}
+
+func (suite *IndexTestSuite) TestValidate_ProjectionBindingIsNotSelfReference() {
+	suite.NoError(suite.validateLets(`let y = {"a": 1}
+  let x = project y as x { a: x.a }`))
+}
+
+func (suite *IndexTestSuite) TestValidate_ReferenceCycleDirectSelfReference() {
+	err := suite.validateLets(`let x = "a" + x`)
+	suite.Require().Error(err)
+	suite.Contains(err.Error(), "infinite recursion: x -> x")
+}
+
+func (suite *IndexTestSuite) TestValidate_ReferenceCycleThroughInterpolatedString() {
+	err := suite.validateLets(`let x = "a${x}"`)
+	suite.Require().Error(err)
+	suite.Contains(err.Error(), "infinite recursion: x -> x")
+
+	err = suite.validateLets(`let x = "a${y}"
+  let y = "b${x}"`)
+	suite.Require().Error(err)
+	suite.Contains(err.Error(), "infinite recursion")
+}
+
+func (suite *IndexTestSuite) TestValidate_BlockLocalLetShadowingIsNotSelfReference() {
+	suite.NoError(suite.validateLets(`let x = {
+    let x = 1
+    yield x
+  }`))
+}
+
+func (suite *IndexTestSuite) TestValidate_ReferenceCycleThroughShadowingBlock() {
+	// the yield refers to the policy let b, not to the block-local a
+	err := suite.validateLets(`let a = {
+    let a = 1
+    yield b
+  }
+  let b = a`)
+	suite.Require().Error(err)
+	suite.Contains(err.Error(), "infinite recursion")
+
+	// the value of the block-local a refers to the policy let b
+	err = suite.validateLets(`let a = {
+    let a = b
+    yield a
+  }
+  let b = a`)
+	suite.Require().Error(err)
+	suite.Contains(err.Error(), "infinite recursion")
+}
+
 func (suite *IndexTestSuite) TestValidate_RuleImportCycleTwoPolicies() {
 	ctx := context.Background()
 	suite.Require().NoError(suite.idx.AddProgram(ctx, programWithCyclicRuleImports()))
@@ -480,6 +546,8 @@ func programParentShapeOnly() *ast.Program {
 		Statements: []ast.Statement{
 			ast.NewNamespaceStatement(ast.NewFQN([]string{"com", "ex"}, r), r),
 			parentShape,
+			// exported, so the child namespace may compose it
+			ast.NewShapeExportStatement("ParentS", pr(4)),
 			ast.NewPolicyStatement(
 				"PP",
 				[]ast.Statement{
@@ -573,3 +641,32 @@ func TestProgramWithRichRuleGraphBuilds(t *testing.T) {
 	p := programWithRichRuleGraph(false)
 	require.NotNil(t, p)
 }
+
+func (suite *IndexTestSuite) TestValidate_BlockLocalLetMayRebindItself() {
+	suite.NoError(suite.validateLets(`let y = 1
+  let x = {
+    let max = y + 1
+    let max = max + 10
+    let y = y + 1
+    yield max + y
+  }`))
+}
+
+func (suite *IndexTestSuite) TestValidate_SelfReferenceThroughProjection() {
+	err := suite.validateLets(`let x = project x { a: 1 }`)
+	suite.Require().Error(err)
+	suite.Contains(err.Error(), "infinite recursion: x -> x")
+
+	err = suite.validateLets(`let x = project y { a: x }
+  let y = {"a": 1}`)
+	suite.Require().Error(err)
+	suite.Contains(err.Error(), "infinite recursion: x -> x")
+}
+
+func (suite *IndexTestSuite) TestValidate_ReferenceCycleThroughSlice() {
+	for _, let := range []string{`let x = x[1:]`, `let x = [1, 2][x:]`, `let x = [1, 2][:x]`} {
+		err := suite.validateLets(let)
+		suite.Require().Error(err, let)
+		suite.Contains(err.Error(), "infinite recursion: x -> x", let)
+	}
+}
@@ -0,0 +1,85 @@
+// SPDX-FileCopyrightText: © 2026 Binaek Sarkar <binaek89@gmail.com>
+// SPDX-License-Identifier: Apache-2.0
+
+package index
+
+import (
+	"fmt"
+
+	"github.com/sentrie-sh/sentrie/tokens"
+)
+
+type Severity string
+
+const (
+	SeverityWarning Severity = "warning"
+	SeverityError   Severity = "error"
+)
+
+// Diagnostic codes are stable identifiers for the kinds of findings reported by the index.
+const (
+	CodeUnusedFact      = "unused-fact"
+	CodeConflict        = "conflict"
+	CodeValidationError = "validation-error"
+	CodeShadowedField   = "shadowed-field"
+	CodeParseError      = "parse-error"
+)
+
+// Diagnostic is a finding reported while loading or validating an index.
+type Diagnostic struct {
+	Severity Severity
+	Code     string
+	Message  string
+	Range    tokens.Range
+}
+
+func (d Diagnostic) String() string {
+	return fmt.Sprintf("%s: %s at %s", d.Severity, d.Message, d.Range)
+}
+
+// Warnings returns the warnings collected by Validate.
+func (idx *Index) Warnings() []Diagnostic {
+	idx.theLock.RLock()
+	defer idx.theLock.RUnlock()
+	return append([]Diagnostic(nil), idx.warnings...)
+}
+
+// PolicyWarnings returns the warnings raised within the declaration of the policy ns/policy,
+// in the order they were collected. It is empty when the policy does not resolve.
+func (idx *Index) PolicyWarnings(ns, policy string) []Diagnostic {
+	p, err := idx.ResolvePolicy(ns, policy)
+	if err != nil || p.Statement == nil {
+		return nil
+	}
+	return warningsWithin(idx.Warnings(), p.Statement.Span())
+}
+
+// warningsWithin returns the warnings whose range lies within span.
+func warningsWithin(warnings []Diagnostic, span tokens.Range) []Diagnostic {
+	var within []Diagnostic
+	for _, warning := range warnings {
+		if rangeWithin(warning.Range, span) {
+			within = append(within, warning)
+		}
+	}
+	return within
+}
+
+// rangeWithin reports whether inner lies in the same file as outer and between its ends.
+func rangeWithin(inner, outer tokens.Range) bool {
+	before := func(a, b tokens.Pos) bool {
+		return a.Line < b.Line || (a.Line == b.Line && a.Column <= b.Column)
+	}
+	return inner.File == outer.File && before(outer.From, inner.From) && before(inner.To, outer.To)
+}
+
+func (idx *Index) addWarning(code string, rng tokens.Range, format string, args ...any) {
+	idx.theLock.Lock()
+	defer idx.theLock.Unlock()
+	idx.warnings = append(idx.warnings, Diagnostic{
+		Severity: SeverityWarning,
+		Code:     code,
+		Message:  fmt.Sprintf(format, args...),
+		Range:    rng,
+	})
+}
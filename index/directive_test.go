@@ -0,0 +1,61 @@
+// SPDX-FileCopyrightText: © 2026 Binaek Sarkar <binaek89@gmail.com>
+// SPDX-License-Identifier: Apache-2.0
+
+package index
+
+import (
+	"github.com/sentrie-sh/sentrie/ast"
+	"github.com/sentrie-sh/sentrie/parser"
+)
+
+func (suite *IndexTestSuite) TestExportRecordsObligationsAndAdvice() {
+	idx := suite.indexFromSource(nil, `namespace com/example
+policy auth {
+  fact user: document
+  shape LogEntry {
+    action: string
+  }
+  rule allow = default false { yield true }
+  export decision of allow
+    attach reason as "admins only"
+    obligation "log": LogEntry = { "action": "access" }
+    advice "hint" = "prefer read-only access"
+}`)
+	suite.Require().NoError(idx.Validate(suite.ctx))
+
+	export := idx.Namespaces["com/example"].Policies["auth"].RuleExports["allow"]
+	suite.Require().Len(export.Attachments, 3)
+	suite.Empty(export.Attachments[0].Directive)
+	suite.Equal(ast.DirectiveObligation, export.Attachments[1].Directive)
+	suite.NotNil(export.Attachments[1].Type)
+	suite.Equal(ast.DirectiveAdvice, export.Attachments[2].Directive)
+	suite.Nil(export.Attachments[2].Type)
+}
+
+func (suite *IndexTestSuite) TestObligationOfUnresolvedShapeFailsValidation() {
+	idx := suite.indexFromSource(nil, `namespace com/example
+policy auth {
+  fact user: document
+  rule allow = default false { yield true }
+  export decision of allow obligation "log": Missing = { "action": "access" }
+}`)
+
+	err := idx.Validate(suite.ctx)
+	suite.Require().Error(err)
+	suite.Contains(err.Error(), "cannot resolve shape 'Missing' of obligation 'log'")
+}
+
+func (suite *IndexTestSuite) TestDirectiveNameConflictsWithAttachment() {
+	idx := CreateIndex()
+	program, err := parser.NewParserFromString(`namespace com/example
+policy auth {
+  fact user: document
+  rule allow = default false { yield true }
+  export decision of allow attach log as "plain" obligation "log" = "typed"
+}`, "test.sentrie").ParseProgram(suite.ctx)
+	suite.Require().NoError(err)
+
+	err = idx.AddProgram(suite.ctx, program)
+	suite.Require().Error(err)
+	suite.Contains(err.Error(), "conflict: rule export attachment")
+}
@@ -0,0 +1,394 @@
+// SPDX-FileCopyrightText: © 2026 Binaek Sarkar <binaek89@gmail.com>
+// SPDX-License-Identifier: Apache-2.0
+
+package index
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/sentrie-sh/sentrie/ast"
+	"github.com/sentrie-sh/sentrie/box"
+	"github.com/sentrie-sh/sentrie/trinary"
+)
+
+// ExampleFacts returns a placeholder for every fact of the policy named by fqn, keyed by fact
+// name, for use as a starting point when writing an input. Placeholders follow the declared type
+// and the constraints whose arguments are literals - an @email string becomes "user@example.com"
+// and a @one_of the first option. Shapes are expanded field by field, optional fields included,
+// and a fact that a require statement ties to a shape is built from that shape.
+// The result holds only JSON types. Constraints that cannot be satisfied by construction, such as
+// @regexp, are ignored, so the placeholder is not guaranteed to pass validation.
+func (idx *Index) ExampleFacts(fqn string) (map[string]any, error) {
+	ns, policy, _, err := idx.ResolveSegments(fqn)
+	if err != nil {
+		return nil, err
+	}
+	p, err := idx.ResolvePolicy(ns, policy)
+	if err != nil {
+		return nil, err
+	}
+
+	gen := &exampleGenerator{namespaces: idx.Namespaces, policy: p, expanding: map[string]bool{}}
+	facts := make(map[string]any, len(p.Facts))
+	for name, fact := range p.Facts {
+		typeRef := fact.Type
+		// a fact that is required to conform to a shape is built from the shape
+		for _, req := range p.Requires {
+			if req.Fact == name {
+				typeRef = ast.NewShapeTypeRef(req.Shape, req.Span())
+				break
+			}
+		}
+		v, err := gen.value(typeRef)
+		if err != nil {
+			return nil, fmt.Errorf("fact '%s': %w", name, err)
+		}
+		facts[name] = v
+	}
+	return facts, nil
+}
+
+type exampleGenerator struct {
+	namespaces map[string]*Namespace
+	policy     *Policy
+	// expanding holds the shapes being expanded, so that a recursive shape ends in null, or in
+	// an omitted field when the field is optional
+	expanding map[string]bool
+}
+
+// exampleDate is the placeholder for dates that are not otherwise constrained.
+var exampleDate = time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+func (g *exampleGenerator) value(typeRef ast.TypeRef) (any, error) {
+	if ast.IsNullableTypeRef(typeRef) {
+		typeRef = ast.UnwrapNullableTypeRef(typeRef)
+	}
+
+	switch t := typeRef.(type) {
+	case nil:
+		return nil, nil
+	case *ast.StringTypeRef:
+		return exampleString(t.GetConstraints()), nil
+	case *ast.NumberTypeRef:
+		return exampleNumber(t.GetConstraints()), nil
+	case *ast.TrinaryTypeRef:
+		return exampleTrinary(t.GetConstraints()), nil
+	case *ast.DateTypeRef:
+		return exampleDateValue(t.GetConstraints()), nil
+	case *ast.DocumentTypeRef:
+		return map[string]any{}, nil
+	case *ast.ListTypeRef:
+		return g.list(t)
+	case *ast.DictTypeRef:
+		v, err := g.value(t.ValueType)
+		if err != nil {
+			return nil, err
+		}
+		return map[string]any{"key": v}, nil
+	case *ast.RecordTypeRef:
+		out := make([]any, 0, len(t.Fields))
+		for _, field := range t.Fields {
+			v, err := g.value(field)
+			if err != nil {
+				return nil, err
+			}
+			out = append(out, v)
+		}
+		return out, nil
+	case *ast.ShapeTypeRef:
+		return g.shape(t)
+	}
+	return nil, nil
+}
+
+func (g *exampleGenerator) list(t *ast.ListTypeRef) (any, error) {
+	lo, hi := 1, math.MaxInt
+	unique := false
+	for _, c := range t.GetConstraints() {
+		v, ok := literalNumber(c, 0)
+		switch c.Name {
+		case "length":
+			if ok {
+				lo, hi = int(v), int(v)
+			}
+		case "minlength":
+			if ok {
+				lo = max(lo, int(v))
+			}
+		case "maxlength":
+			if ok {
+				hi = int(v)
+			}
+		case "unique":
+			unique = true
+		}
+	}
+	n := max(min(lo, hi), 0)
+
+	out := make([]any, 0, n)
+	for i := range n {
+		v, err := g.value(t.ElemType)
+		if err != nil {
+			return nil, err
+		}
+		// make repeated placeholders distinct where the element type allows it
+		if unique && i > 0 {
+			switch e := v.(type) {
+			case string:
+				v = fmt.Sprintf("%s%d", e, i)
+			case float64:
+				v = e + float64(i)
+			}
+		}
+		out = append(out, v)
+	}
+	return out, nil
+}
+
+func (g *exampleGenerator) shape(t *ast.ShapeTypeRef) (any, error) {
+	ns, shape, err := resolveShapeRef(g.namespaces, g.policy.Namespace, g.policy, *t.Ref)
+	if err != nil {
+		return nil, err
+	}
+	if shape.AliasOf != nil {
+		return g.value(shape.AliasOf)
+	}
+
+	key := ShapeFQN(ns.FQN.String(), shape.Name)
+	if g.expanding[key] {
+		return nil, nil
+	}
+	g.expanding[key] = true
+	defer delete(g.expanding, key)
+
+	out := make(map[string]any, len(shape.Model.Fields))
+	for name, field := range shape.Model.Fields {
+		v, err := g.value(field.TypeRef)
+		if err != nil {
+			return nil, fmt.Errorf("field '%s': %w", name, err)
+		}
+		// an optional field cut short by recursion is left out rather than set to null
+		if v == nil && field.Optional {
+			continue
+		}
+		out[name] = v
+	}
+	return out, nil
+}
+
+func exampleString(constraints []*ast.TypeRefConstraint) any {
+	s, pad := "example", 'x'
+	for _, c := range constraints {
+		switch c.Name {
+		case "one_of":
+			if v, ok := literalString(c, 0); ok {
+				return v
+			}
+		case "email":
+			s = "user@example.com"
+		case "url":
+			s = "https://example.com"
+		case "uuid":
+			s = "00000000-0000-4000-8000-000000000000"
+		case "semver":
+			s = "1.0.0"
+		case "ipv4":
+			s = "192.0.2.1"
+		case "ipv6":
+			s = "2001:db8::1"
+		case "cidr":
+			s = "192.0.2.0/24"
+		case "numeric":
+			s, pad = "0", '0'
+		}
+	}
+
+	for _, c := range constraints {
+		switch c.Name {
+		case "starts_with":
+			if v, ok := literalString(c, 0); ok && !strings.HasPrefix(s, v) {
+				s = v + s
+			}
+		case "ends_with":
+			if v, ok := literalString(c, 0); ok && !strings.HasSuffix(s, v) {
+				s += v
+			}
+		case "has_substring":
+			if v, ok := literalString(c, 0); ok && !strings.Contains(s, v) {
+				s += v
+			}
+		case "uppercase":
+			s = strings.ToUpper(s)
+		case "lowercase":
+			s = strings.ToLower(s)
+		}
+	}
+
+	for _, c := range constraints {
+		v, ok := literalNumber(c, 0)
+		if !ok {
+			continue
+		}
+		switch c.Name {
+		case "length":
+			s = fitString(s, pad, int(v), int(v))
+		case "minlength":
+			s = fitString(s, pad, int(v), math.MaxInt)
+		case "maxlength":
+			s = fitString(s, pad, 0, int(v))
+		}
+	}
+
+	for _, c := range constraints {
+		if c.Name != "not_one_of" {
+			continue
+		}
+		for i := range c.Args {
+			if v, ok := literalString(c, i); ok && v == s {
+				s += "_"
+			}
+		}
+	}
+	return s
+}
+
+// fitString pads s with pad or truncates it so that its length is within [lo, hi].
+func fitString(s string, pad rune, lo, hi int) string {
+	runes := []rune(s)
+	if len(runes) > hi {
+		runes = runes[:max(hi, 0)]
+	}
+	for len(runes) < lo {
+		runes = append(runes, pad)
+	}
+	return string(runes)
+}
+
+func exampleNumber(constraints []*ast.TypeRefConstraint) any {
+	n := 0.0
+	for _, c := range constraints {
+		v, ok := literalNumber(c, 0)
+		switch c.Name {
+		case "one_of", "in", "eq", "range":
+			if ok {
+				return v
+			}
+		case "min":
+			if ok {
+				n = max(n, v)
+			}
+		case "gt":
+			if ok && n <= v {
+				n = v + 1
+			}
+		case "positive":
+			n = max(n, 1)
+		case "negative":
+			n = min(n, -1)
+		case "multiple_of":
+			if ok && v != 0 {
+				n = math.Ceil(n/v) * v
+			}
+		case "odd":
+			if math.Mod(n, 2) == 0 {
+				n++
+			}
+		}
+	}
+	for _, c := range constraints {
+		v, ok := literalNumber(c, 0)
+		switch c.Name {
+		case "max":
+			if ok {
+				n = min(n, v)
+			}
+		case "lt":
+			if ok && n >= v {
+				n = v - 1
+			}
+		}
+	}
+	return n
+}
+
+func exampleTrinary(constraints []*ast.TypeRefConstraint) any {
+	for _, c := range constraints {
+		switch c.Name {
+		case "is_false":
+			return false
+		case "eq", "one_of":
+			if len(c.Args) > 0 {
+				if lit, ok := c.Args[0].(*ast.TrinaryLiteral); ok && lit.Value != trinary.Unknown {
+					return lit.Value == trinary.True
+				}
+			}
+		case "neq":
+			if len(c.Args) > 0 {
+				if lit, ok := c.Args[0].(*ast.TrinaryLiteral); ok && lit.Value == trinary.True {
+					return false
+				}
+			}
+		}
+	}
+	return true
+}
+
+func exampleDateValue(constraints []*ast.TypeRefConstraint) any {
+	d := exampleDate
+	for _, c := range constraints {
+		s, ok := literalString(c, 0)
+		if !ok {
+			continue
+		}
+		bound, ok := box.ParseDate(s)
+		if !ok {
+			continue
+		}
+		switch c.Name {
+		case "after":
+			if !d.After(bound) {
+				d = bound.AddDate(0, 0, 1)
+			}
+		case "before":
+			if !d.Before(bound) {
+				d = bound.AddDate(0, 0, -1)
+			}
+		}
+	}
+	return d.UTC().Format(time.RFC3339)
+}
+
+// literalString returns the i-th argument of c when it is a string literal.
+func literalString(c *ast.TypeRefConstraint, i int) (string, bool) {
+	if i >= len(c.Args) {
+		return "", false
+	}
+	lit, ok := c.Args[i].(*ast.StringLiteral)
+	if !ok {
+		return "", false
+	}
+	return lit.Value, true
+}
+
+// literalNumber returns the i-th argument of c when it is a number literal.
+func literalNumber(c *ast.TypeRefConstraint, i int) (float64, bool) {
+	if i >= len(c.Args) {
+		return 0, false
+	}
+	switch lit := c.Args[i].(type) {
+	case *ast.IntegerLiteral:
+		return lit.Value, true
+	case *ast.FloatLiteral:
+		return lit.Value, true
+	case *ast.UnaryExpression:
+		if lit.Operator != "-" {
+			return 0, false
+		}
+		v, ok := literalNumber(ast.NewTypeRefConstraint(c.Name, []ast.Expression{lit.Right}, c.Rnge), 0)
+		return -v, ok
+	}
+	return 0, false
+}
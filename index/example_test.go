@@ -0,0 +1,102 @@
+// SPDX-FileCopyrightText: © 2026 Binaek Sarkar <binaek89@gmail.com>
+// SPDX-License-Identifier: Apache-2.0
+
+package index
+
+import (
+	"github.com/sentrie-sh/sentrie/xerr"
+)
+
+func (suite *IndexTestSuite) TestExampleFactsFollowTypesAndConstraints() {
+	idx := suite.indexFromSource(nil, `namespace com/example
+shape Address {
+  city: string
+  zip: string @length(5) @numeric()
+}
+shape User {
+  email: string @email()
+  role: string @one_of("admin", "viewer")
+  age: number @min(18) @max(120)
+  address: Address
+  manager?: User
+}
+policy onboarding {
+  fact user: User
+  fact tags: list[string] @minlength(2) @unique()
+  fact since: date @after("2025-06-01")
+  fact active: trinary
+  fact limits: dict[number @positive()]
+  rule allow = default false { yield user.age >= 21 and active and count(tags) > 0 and since != "" and limits != {} }
+  export decision of allow
+}`)
+	suite.Require().NoError(idx.Validate(suite.ctx))
+
+	facts, err := idx.ExampleFacts("com/example/onboarding")
+	suite.Require().NoError(err)
+	suite.Equal(map[string]any{
+		"user": map[string]any{
+			"email":   "user@example.com",
+			"role":    "admin",
+			"age":     float64(18),
+			"address": map[string]any{"city": "example", "zip": "00000"},
+		},
+		"tags":   []any{"example", "example1"},
+		"since":  "2025-06-02T00:00:00Z",
+		"active": true,
+		"limits": map[string]any{"key": float64(1)},
+	}, facts)
+}
+
+func (suite *IndexTestSuite) TestExampleFactsExpandComposedShapes() {
+	idx := suite.indexFromSource(nil, `namespace com/example
+shape Base {
+  id: string @uuid()
+}
+shape Admin with Base {
+  level: number @gt(2)
+}
+policy admin {
+  fact who: Admin
+  rule allow = default false { yield who.level > 3 }
+  export decision of allow
+}`)
+	suite.Require().NoError(idx.Validate(suite.ctx))
+
+	facts, err := idx.ExampleFacts("com/example/admin/allow")
+	suite.Require().NoError(err)
+	suite.Equal(map[string]any{
+		"who": map[string]any{"id": "00000000-0000-4000-8000-000000000000", "level": float64(3)},
+	}, facts)
+}
+
+func (suite *IndexTestSuite) TestExampleFactsFollowRequiredShapes() {
+	idx := suite.indexFromSource(nil, `namespace com/example
+shape User {
+  name: string
+}
+policy auth {
+  fact user: document
+  require user conforms User
+  rule allow = default false { yield user.name == "root" }
+  export decision of allow
+}`)
+	suite.Require().NoError(idx.Validate(suite.ctx))
+
+	facts, err := idx.ExampleFacts("com/example/auth")
+	suite.Require().NoError(err)
+	suite.Equal(map[string]any{"user": map[string]any{"name": "example"}}, facts)
+}
+
+func (suite *IndexTestSuite) TestExampleFactsUnknownPolicy() {
+	idx := suite.indexFromSource(nil, `namespace com/example
+policy admin {
+  fact who: string
+  rule allow = default false { yield who == "root" }
+  export decision of allow
+}`)
+	suite.Require().NoError(idx.Validate(suite.ctx))
+
+	_, err := idx.ExampleFacts("com/example/missing")
+	suite.Require().Error(err)
+	suite.ErrorIs(err, xerr.NotFoundError{})
+}
@@ -0,0 +1,308 @@
+// SPDX-FileCopyrightText: © 2026 Binaek Sarkar <binaek89@gmail.com>
+// SPDX-License-Identifier: Apache-2.0
+
+package index
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/sentrie-sh/sentrie/ast"
+)
+
+// PolicyDescription is a human-readable description of a policy, for documentation. Facts, rules
+// and exports are listed in declaration order, and expressions are printed in canonical form, so
+// that the description of a policy does not change unless its source does. Example holds facts
+// the policy accepts, see Index.ExampleFacts.
+type PolicyDescription struct {
+	FQN      string               `json:"fqn"`
+	Metadata PolicyMetadata       `json:"metadata"`
+	Facts    []*FactDescription   `json:"facts"`
+	Rules    []*RuleDescription   `json:"rules"`
+	Exports  []*ExportDescription `json:"exports"`
+	Example  map[string]any       `json:"example"`
+}
+
+// FactDescription describes a fact. Type holds the declared type without the constraints on it,
+// which are listed in Constraints.
+type FactDescription struct {
+	Name        string   `json:"name"`
+	Alias       string   `json:"alias,omitempty"`
+	Type        string   `json:"type"`
+	Constraints []string `json:"constraints,omitempty"`
+	Optional    bool     `json:"optional,omitempty"`
+	Sensitive   bool     `json:"sensitive,omitempty"`
+	Default     string   `json:"default,omitempty"`
+}
+
+// RuleDescription describes a rule. Body is printed over several lines when it is a block.
+type RuleDescription struct {
+	Name     string `json:"name"`
+	Effect   string `json:"effect,omitempty"`
+	Default  string `json:"default,omitempty"`
+	When     string `json:"when,omitempty"`
+	Body     string `json:"body"`
+	Exported bool   `json:"exported"`
+}
+
+// ExportDescription describes an exported decision and its attachments.
+type ExportDescription struct {
+	Rule        string                   `json:"rule"`
+	Attachments []*AttachmentDescription `json:"attachments,omitempty"`
+}
+
+// AttachmentDescription describes an attachment, obligation or advice of an exported decision.
+type AttachmentDescription struct {
+	Name      string `json:"name"`
+	Directive string `json:"directive,omitempty"`
+	Type      string `json:"type,omitempty"`
+	Value     string `json:"value"`
+}
+
+// Explain describes the policy named by policyFQN.
+func (idx *Index) Explain(policyFQN string) (*PolicyDescription, error) {
+	ns, policy, _, err := idx.ResolveSegments(policyFQN)
+	if err != nil {
+		return nil, err
+	}
+	p, err := idx.ResolvePolicy(ns, policy)
+	if err != nil {
+		return nil, err
+	}
+
+	d := &PolicyDescription{
+		FQN:      p.FQN.String(),
+		Metadata: p.Metadata(),
+		Facts:    []*FactDescription{},
+		Rules:    []*RuleDescription{},
+		Exports:  []*ExportDescription{},
+	}
+
+	for _, stmt := range p.Statements {
+		switch stmt := stmt.(type) {
+		case *ast.FactStatement:
+			d.Facts = append(d.Facts, describeFact(stmt))
+		case *ast.RuleStatement:
+			_, exported := p.RuleExports[stmt.RuleName]
+			d.Rules = append(d.Rules, describeRule(stmt, exported))
+		case *ast.RuleExportStatement:
+			export := &ExportDescription{Rule: stmt.Of}
+			for _, attachment := range stmt.Attachments {
+				description := &AttachmentDescription{
+					Name:      attachment.What,
+					Directive: attachment.Directive,
+					Value:     describeExpression(attachment.As, ""),
+				}
+				if attachment.Type != nil {
+					description.Type = describeTypeRef(attachment.Type)
+				}
+				export.Attachments = append(export.Attachments, description)
+			}
+			d.Exports = append(d.Exports, export)
+		}
+	}
+
+	if d.Example, err = idx.ExampleFacts(policyFQN); err != nil {
+		return nil, err
+	}
+
+	return d, nil
+}
+
+func describeFact(stmt *ast.FactStatement) *FactDescription {
+	fact := &FactDescription{
+		Name:      stmt.Name,
+		Type:      describeBareTypeRef(stmt.Type),
+		Optional:  stmt.Optional,
+		Sensitive: stmt.Sensitive,
+	}
+	if stmt.Alias != stmt.Name {
+		fact.Alias = stmt.Alias
+	}
+	for _, constraint := range stmt.Type.GetConstraints() {
+		fact.Constraints = append(fact.Constraints, describeConstraint(constraint))
+	}
+	if stmt.Default != nil {
+		fact.Default = describeExpression(stmt.Default, "")
+	}
+	return fact
+}
+
+func describeRule(stmt *ast.RuleStatement, exported bool) *RuleDescription {
+	rule := &RuleDescription{
+		Name:     stmt.RuleName,
+		Body:     describeExpression(stmt.Body, ""),
+		Exported: exported,
+	}
+	if len(stmt.Effects) > 0 {
+		rule.Effect = stmt.Effects[0].Effect
+	}
+	if stmt.Default != nil {
+		rule.Default = describeExpression(stmt.Default, "")
+	}
+	if stmt.When != nil {
+		rule.When = describeExpression(stmt.When, "")
+	}
+	return rule
+}
+
+// describeTypeRef prints a type with the constraints on it, and on the types within it.
+func describeTypeRef(t ast.TypeRef) string {
+	parts := []string{describeBareTypeRef(t)}
+	for _, constraint := range t.GetConstraints() {
+		parts = append(parts, describeConstraint(constraint))
+	}
+	return strings.Join(parts, " ")
+}
+
+// describeBareTypeRef prints a type without the constraints on it, but with those on the types within it.
+func describeBareTypeRef(t ast.TypeRef) string {
+	switch t := t.(type) {
+	case *ast.NullableTypeRef:
+		return describeBareTypeRef(t.Inner) + "?"
+	case *ast.ListTypeRef:
+		return "list[" + describeTypeRef(t.ElemType) + "]"
+	case *ast.DictTypeRef:
+		return "dict[" + describeTypeRef(t.ValueType) + "]"
+	case *ast.RecordTypeRef:
+		fields := make([]string, len(t.Fields))
+		for i, field := range t.Fields {
+			fields[i] = describeTypeRef(field)
+		}
+		return "record[" + strings.Join(fields, ", ") + "]"
+	default:
+		return t.String()
+	}
+}
+
+func describeConstraint(c *ast.TypeRefConstraint) string {
+	args := make([]string, len(c.Args))
+	for i, arg := range c.Args {
+		args[i] = describeExpression(arg, "")
+	}
+	return "@" + c.Name + "(" + strings.Join(args, ", ") + ")"
+}
+
+// describeExpression prints an expression in canonical form. Blocks are printed one statement
+// per line, each indented by two spaces more than indent, which the closing brace is indented by.
+func describeExpression(e ast.Expression, indent string) string {
+	switch e := e.(type) {
+	case *ast.PrecedingCommentExpression:
+		return describeExpression(e.Wrap, indent)
+	case *ast.TrailingCommentExpression:
+		return describeExpression(e.Wrap, indent)
+	case *ast.InfixExpression:
+		// the outermost parentheses are implied
+		return strings.TrimSuffix(strings.TrimPrefix(e.String(), "("), ")")
+	case *ast.ImportClause:
+		var b strings.Builder
+		fmt.Fprintf(&b, "import decision %s from %s", e.RuleToImport, e.FromPolicyFQN)
+		for _, with := range e.Withs {
+			fmt.Fprintf(&b, " with %s as %s", with.Name, describeExpression(with.Expr, indent))
+		}
+		return b.String()
+	case *ast.BlockExpression:
+		inner := indent + "  "
+		var b strings.Builder
+		b.WriteString("{\n")
+		for _, stmt := range e.Statements {
+			let, ok := stmt.(*ast.VarDeclaration)
+			if !ok {
+				continue // comments
+			}
+			b.WriteString(inner + "let " + let.Name)
+			if let.Type != nil {
+				b.WriteString(": " + describeTypeRef(let.Type))
+			}
+			b.WriteString(" = " + describeExpression(let.Value, inner) + "\n")
+		}
+		b.WriteString(inner + "yield " + describeExpression(e.Yield, inner) + "\n")
+		b.WriteString(indent + "}")
+		return b.String()
+	default:
+		return e.String()
+	}
+}
+
+// declaration prints the rule as it would be declared.
+func (r *RuleDescription) declaration() string {
+	var b strings.Builder
+	b.WriteString("rule " + r.Name + " =")
+	if r.Effect != "" {
+		b.WriteString(" " + r.Effect)
+	}
+	if r.Default != "" {
+		b.WriteString(" default " + r.Default)
+	}
+	if r.When != "" {
+		b.WriteString(" when " + r.When)
+	}
+	b.WriteString(" " + r.Body)
+	return b.String()
+}
+
+// String prints the description as text.
+func (d *PolicyDescription) String() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "policy %s\n", d.FQN)
+	if d.Metadata.Title != "" {
+		fmt.Fprintf(&b, "  title: %s\n", d.Metadata.Title)
+	}
+	if d.Metadata.Description != "" {
+		fmt.Fprintf(&b, "  description: %s\n", d.Metadata.Description)
+	}
+	if d.Metadata.Version != "" {
+		fmt.Fprintf(&b, "  version: %s\n", d.Metadata.Version)
+	}
+	for _, tag := range d.Metadata.Tags {
+		fmt.Fprintf(&b, "  tag: %s = %s\n", tag.Key, tag.Value)
+	}
+
+	if len(d.Facts) > 0 {
+		b.WriteString("\nfacts:\n")
+		for _, fact := range d.Facts {
+			b.WriteString("  ")
+			if fact.Sensitive {
+				b.WriteString("sensitive ")
+			}
+			b.WriteString(fact.Name)
+			if fact.Optional {
+				b.WriteString("?")
+			}
+			b.WriteString(": " + strings.Join(append([]string{fact.Type}, fact.Constraints...), " "))
+			if fact.Alias != "" {
+				b.WriteString(" as " + fact.Alias)
+			}
+			if fact.Default != "" {
+				b.WriteString(" default " + fact.Default)
+			}
+			b.WriteString("\n")
+		}
+	}
+
+	if len(d.Rules) > 0 {
+		b.WriteString("\nrules:\n")
+		for _, rule := range d.Rules {
+			b.WriteString("  " + strings.ReplaceAll(rule.declaration(), "\n", "\n  ") + "\n")
+		}
+	}
+
+	if len(d.Exports) > 0 {
+		b.WriteString("\nexports:\n")
+		for _, export := range d.Exports {
+			b.WriteString("  " + export.Rule + "\n")
+			for _, attachment := range export.Attachments {
+				switch {
+				case attachment.Directive == "":
+					fmt.Fprintf(&b, "    attach %s as %s\n", attachment.Name, attachment.Value)
+				case attachment.Type != "":
+					fmt.Fprintf(&b, "    %s %q: %s = %s\n", attachment.Directive, attachment.Name, attachment.Type, attachment.Value)
+				default:
+					fmt.Fprintf(&b, "    %s %q = %s\n", attachment.Directive, attachment.Name, attachment.Value)
+				}
+			}
+		}
+	}
+
+	return b.String()
+}
@@ -0,0 +1,108 @@
+// SPDX-FileCopyrightText: © 2026 Binaek Sarkar <binaek89@gmail.com>
+// SPDX-License-Identifier: Apache-2.0
+
+package index
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// Markdown renders the description as a Markdown page for a documentation site. The page is
+// titled with the policy's title, or its FQN when it has none, and metadata that is not declared
+// is left out.
+func (d *PolicyDescription) Markdown() string {
+	var b strings.Builder
+
+	title := d.Metadata.Title
+	if title == "" {
+		title = d.FQN
+	}
+	fmt.Fprintf(&b, "# %s\n\n", title)
+
+	fmt.Fprintf(&b, "- **Policy:** `%s`\n", d.FQN)
+	if d.Metadata.Version != "" {
+		fmt.Fprintf(&b, "- **Version:** %s\n", d.Metadata.Version)
+	}
+	for _, tag := range d.Metadata.Tags {
+		fmt.Fprintf(&b, "- **%s:** %s\n", tag.Key, tag.Value)
+	}
+
+	if d.Metadata.Description != "" {
+		fmt.Fprintf(&b, "\n%s\n", d.Metadata.Description)
+	}
+
+	if len(d.Facts) > 0 {
+		b.WriteString("\n## Facts\n\n")
+		b.WriteString("| Fact | Type | Constraints | Required | Default |\n")
+		b.WriteString("| --- | --- | --- | --- | --- |\n")
+		for _, fact := range d.Facts {
+			name := markdownCode(fact.Name)
+			if fact.Alias != "" {
+				name += " as " + markdownCode(fact.Alias)
+			}
+			if fact.Sensitive {
+				name += " (sensitive)"
+			}
+			constraints := make([]string, len(fact.Constraints))
+			for i, constraint := range fact.Constraints {
+				constraints[i] = markdownCode(constraint)
+			}
+			required := "yes"
+			if fact.Optional {
+				required = "no"
+			}
+			def := ""
+			if fact.Default != "" {
+				def = markdownCode(fact.Default)
+			}
+			fmt.Fprintf(&b, "| %s | %s | %s | %s | %s |\n", name, markdownCode(fact.Type), strings.Join(constraints, " "), required, def)
+		}
+	}
+
+	if len(d.Rules) > 0 {
+		b.WriteString("\n## Rules\n")
+		for _, rule := range d.Rules {
+			fmt.Fprintf(&b, "\n### %s\n\n", markdownCode(rule.Name))
+			if rule.Exported {
+				b.WriteString("Exported as a decision.\n\n")
+			}
+			b.WriteString("```sentrie\n" + rule.declaration() + "\n```\n")
+		}
+	}
+
+	if len(d.Exports) > 0 {
+		b.WriteString("\n## Exports\n\n")
+		for _, export := range d.Exports {
+			fmt.Fprintf(&b, "- %s\n", markdownCode(export.Rule))
+			for _, attachment := range export.Attachments {
+				switch {
+				case attachment.Directive == "":
+					fmt.Fprintf(&b, "  - attaches %s as %s\n", markdownCode(attachment.Name), markdownCode(attachment.Value))
+				case attachment.Type != "":
+					fmt.Fprintf(&b, "  - %s %s of %s as %s\n", attachment.Directive, markdownCode(attachment.Name), markdownCode(attachment.Type), markdownCode(attachment.Value))
+				default:
+					fmt.Fprintf(&b, "  - %s %s as %s\n", attachment.Directive, markdownCode(attachment.Name), markdownCode(attachment.Value))
+				}
+			}
+		}
+	}
+
+	if len(d.Example) > 0 {
+		// a map of JSON values always marshals, with its keys sorted
+		example, _ := json.MarshalIndent(d.Example, "", "  ")
+		b.WriteString("\n## Example facts\n\n```json\n" + string(example) + "\n```\n")
+	}
+
+	return b.String()
+}
+
+// markdownCode formats s as inline code that can be placed in a table cell.
+func markdownCode(s string) string {
+	s = strings.ReplaceAll(strings.ReplaceAll(s, "\n", " "), "|", `\|`)
+	if strings.Contains(s, "`") {
+		return "`` " + s + " ``"
+	}
+	return "`" + s + "`"
+}
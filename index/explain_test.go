@@ -0,0 +1,108 @@
+// SPDX-FileCopyrightText: © 2026 Binaek Sarkar <binaek89@gmail.com>
+// SPDX-License-Identifier: Apache-2.0
+
+package index
+
+import (
+	"github.com/sentrie-sh/sentrie/xerr"
+)
+
+const explainPolicySource = `namespace com/example
+policy onboarding {
+  title "Onboarding"
+  tag "owner" = "iam"
+  fact user: string @minlength(3) as name
+  fact age?: number @min(18) default 21
+  fact limits: dict[number @positive()]
+  rule adult = default false when age > 0 {
+    let years: number = age
+    yield years >= 18 and name != ""
+  }
+  rule allow = adult
+  export decision of allow attach reason as "adult"
+}`
+
+func (suite *IndexTestSuite) TestExplainDescribesFactsRulesAndExports() {
+	idx := suite.indexFromSource(nil, explainPolicySource)
+	suite.Require().NoError(idx.Validate(suite.ctx))
+
+	d, err := idx.Explain("com/example/onboarding")
+	suite.Require().NoError(err)
+
+	suite.Equal("com/example/onboarding", d.FQN)
+	suite.Equal("Onboarding", d.Metadata.Title)
+	suite.Require().Len(d.Facts, 3)
+	suite.Equal(&FactDescription{Name: "user", Alias: "name", Type: "string", Constraints: []string{"@minlength(3)"}}, d.Facts[0])
+	suite.Equal(&FactDescription{Name: "age", Type: "number", Constraints: []string{"@min(18)"}, Optional: true, Default: "21"}, d.Facts[1])
+	suite.Equal("dict[number @positive()]", d.Facts[2].Type)
+
+	suite.Require().Len(d.Rules, 2)
+	suite.Equal("adult", d.Rules[0].Name)
+	suite.Equal("false", d.Rules[0].Default)
+	suite.Equal("age > 0", d.Rules[0].When)
+	suite.Equal("{\n  let years: number = age\n  yield (years >= 18) and (name != \"\")\n}", d.Rules[0].Body)
+	suite.False(d.Rules[0].Exported)
+	suite.True(d.Rules[1].Exported)
+
+	suite.Equal([]*ExportDescription{{Rule: "allow", Attachments: []*AttachmentDescription{{Name: "reason", Value: `"adult"`}}}}, d.Exports)
+
+	text := d.String()
+	suite.Contains(text, "  user: string @minlength(3) as name\n")
+	suite.Contains(text, "  rule adult = default false when age > 0 {\n    let years: number = age\n")
+	suite.Contains(text, "    attach reason as \"adult\"\n")
+}
+
+func (suite *IndexTestSuite) TestExplainDescribesRuleEffect() {
+	idx := suite.indexFromSource(nil, `namespace com/example
+policy guarded {
+  fact blocked: boolean
+  rule block = deny default false when blocked { yield true }
+  export decision of block
+}`)
+	suite.Require().NoError(idx.Validate(suite.ctx))
+
+	d, err := idx.Explain("com/example/guarded")
+	suite.Require().NoError(err)
+	suite.Require().Len(d.Rules, 1)
+	suite.Equal("deny", d.Rules[0].Effect)
+	suite.Contains(d.String(), "  rule block = deny default false when blocked {\n")
+}
+
+func (suite *IndexTestSuite) TestExplainDescribesDirectives() {
+	idx := suite.indexFromSource(nil, `namespace com/example
+policy audited {
+  fact user: document
+  shape LogEntry {
+    action: string
+  }
+  rule allow = default false { yield true }
+  export decision of allow
+    obligation "log": LogEntry = { "action": "access" }
+    advice "hint" = "read-only"
+}`)
+	suite.Require().NoError(idx.Validate(suite.ctx))
+
+	d, err := idx.Explain("com/example/audited")
+	suite.Require().NoError(err)
+	suite.Require().Len(d.Exports, 1)
+	suite.Require().Len(d.Exports[0].Attachments, 2)
+	suite.Equal("obligation", d.Exports[0].Attachments[0].Directive)
+	suite.Equal("LogEntry", d.Exports[0].Attachments[0].Type)
+	suite.Contains(d.String(), `    advice "hint" = "read-only"`)
+	suite.Contains(d.Markdown(), "  - obligation `log` of `LogEntry` as ")
+}
+
+func (suite *IndexTestSuite) TestExplainIsDeterministic() {
+	first, err := suite.indexFromSource(nil, explainPolicySource).Explain("com/example/onboarding")
+	suite.Require().NoError(err)
+	second, err := suite.indexFromSource(nil, explainPolicySource).Explain("com/example/onboarding")
+	suite.Require().NoError(err)
+	suite.Equal(first.String(), second.String())
+}
+
+func (suite *IndexTestSuite) TestExplainUnknownPolicy() {
+	idx := suite.indexFromSource(nil, explainPolicySource)
+	_, err := idx.Explain("com/example/missing")
+	suite.Require().Error(err)
+	suite.ErrorIs(err, xerr.ErrPolicyNotFound("com/example/missing"))
+}
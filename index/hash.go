@@ -0,0 +1,191 @@
+// SPDX-FileCopyrightText: © 2026 Binaek Sarkar <binaek89@gmail.com>
+// SPDX-License-Identifier: Apache-2.0
+
+package index
+
+import (
+	"cmp"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"hash"
+	"maps"
+	"reflect"
+	"slices"
+	"strconv"
+
+	"github.com/sentrie-sh/sentrie/ast"
+	"github.com/sentrie-sh/sentrie/tokens"
+)
+
+// ContentHash identifies what the policy evaluates: its own statements, the shapes it
+// refers to, and, through their content hashes, the policies it imports decisions from.
+// Source positions are not part of it, so moving a policy within its file or reformatting
+// it keeps the hash. The sources of the JavaScript modules the policy uses are not covered.
+//
+// The hash is computed when the index is committed and is empty before that.
+func (p *Policy) ContentHash() string {
+	return p.contentHash
+}
+
+// hashPolicies computes the content hash of every policy. Import cycles are rejected by
+// validation, so following imports terminates.
+func (idx *Index) hashPolicies() error {
+	for _, ns := range idx.Namespaces {
+		for _, policy := range ns.Policies {
+			if _, err := idx.hashPolicy(policy); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (idx *Index) hashPolicy(policy *Policy) (string, error) {
+	if policy.contentHash != "" {
+		return policy.contentHash, nil
+	}
+
+	h := sha256.New()
+	writeCanonical(h, reflect.ValueOf(policy.Statement), map[uintptr]struct{}{})
+
+	shapes := map[string]*Shape{}
+	idx.collectShapes(policy.Namespace, policy, policy.Statement, shapes)
+	for _, fqn := range slices.Sorted(maps.Keys(shapes)) {
+		fmt.Fprintf(h, "shape %s:", fqn)
+		writeCanonical(h, reflect.ValueOf(shapes[fqn].Statement), map[uintptr]struct{}{})
+	}
+
+	imports := []string{}
+	for _, rule := range policy.Rules {
+		importClause, ok := rule.Body.(*ast.ImportClause)
+		if !ok {
+			continue
+		}
+		target, err := idx.ResolvePolicy(importTarget(policy, importClause))
+		if err != nil {
+			return "", err
+		}
+		targetHash, err := idx.hashPolicy(target)
+		if err != nil {
+			return "", err
+		}
+		imports = append(imports, target.FQN.String()+"="+targetHash)
+	}
+	slices.Sort(imports)
+	for _, imp := range imports {
+		fmt.Fprintf(h, "import %s;", imp)
+	}
+
+	policy.contentHash = hex.EncodeToString(h.Sum(nil))
+	return policy.contentHash, nil
+}
+
+// collectShapes adds the shapes that node refers to, and the shapes those refer to, to shapes
+// keyed by their FQN. References that do not resolve are left to evaluation to report; their
+// spelling is already part of the hash.
+func (idx *Index) collectShapes(ns *Namespace, policy *Policy, node ast.Node, shapes map[string]*Shape) {
+	refs := []ast.FQN{}
+	ast.Inspect(node, func(n ast.Node) bool {
+		switch n := n.(type) {
+		case *ast.ShapeTypeRef:
+			refs = append(refs, *n.Ref)
+		case *ast.RequireStatement:
+			refs = append(refs, *n.Shape)
+		case *ast.ShapeStatement:
+			if n.Complex != nil && n.Complex.With != nil {
+				refs = append(refs, *n.Complex.With)
+			}
+		}
+		return true
+	})
+
+	for _, ref := range refs {
+		_, shape, err := idx.ResolveShapeRef(ns, policy, ref)
+		if err != nil {
+			continue
+		}
+		fqn := shape.FQN.String()
+		if _, seen := shapes[fqn]; seen {
+			continue
+		}
+		shapes[fqn] = shape
+		idx.collectShapes(shape.Namespace, shape.Policy, shape.Statement, shapes)
+	}
+}
+
+var (
+	rangeType = reflect.TypeFor[tokens.Range]()
+	posType   = reflect.TypeFor[tokens.Pos]()
+)
+
+// writeCanonical writes a representation of v that depends only on its content: struct fields,
+// exported or not, in declaration order, map entries in key order, and the dynamic type behind
+// every interface. Source ranges are left out. A pointer met again while writing its own
+// content is written as a back reference.
+func writeCanonical(h hash.Hash, v reflect.Value, visiting map[uintptr]struct{}) {
+	switch v.Kind() {
+	case reflect.Invalid:
+		h.Write([]byte("nil"))
+	case reflect.Pointer:
+		if v.IsNil() {
+			h.Write([]byte("nil"))
+			return
+		}
+		if _, ok := visiting[v.Pointer()]; ok {
+			h.Write([]byte("^"))
+			return
+		}
+		visiting[v.Pointer()] = struct{}{}
+		writeCanonical(h, v.Elem(), visiting)
+		delete(visiting, v.Pointer())
+	case reflect.Interface:
+		if v.IsNil() {
+			h.Write([]byte("nil"))
+			return
+		}
+		h.Write([]byte(v.Elem().Type().String()))
+		writeCanonical(h, v.Elem(), visiting)
+	case reflect.Struct:
+		if v.Type() == rangeType || v.Type() == posType {
+			return
+		}
+		h.Write([]byte(v.Type().String() + "{"))
+		for i := range v.NumField() {
+			h.Write([]byte(v.Type().Field(i).Name + ":"))
+			writeCanonical(h, v.Field(i), visiting)
+			h.Write([]byte(";"))
+		}
+		h.Write([]byte("}"))
+	case reflect.Slice, reflect.Array:
+		h.Write([]byte("["))
+		for i := range v.Len() {
+			writeCanonical(h, v.Index(i), visiting)
+			h.Write([]byte(","))
+		}
+		h.Write([]byte("]"))
+	case reflect.Map:
+		keys := v.MapKeys()
+		slices.SortFunc(keys, func(a, b reflect.Value) int {
+			return cmp.Compare(fmt.Sprint(a), fmt.Sprint(b))
+		})
+		h.Write([]byte("map{"))
+		for _, k := range keys {
+			writeCanonical(h, k, visiting)
+			h.Write([]byte(":"))
+			writeCanonical(h, v.MapIndex(k), visiting)
+			h.Write([]byte(";"))
+		}
+		h.Write([]byte("}"))
+	case reflect.String:
+		h.Write([]byte(strconv.Quote(v.String())))
+	case reflect.Bool:
+		h.Write([]byte(strconv.FormatBool(v.Bool())))
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		h.Write([]byte(strconv.FormatInt(v.Int(), 10)))
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		h.Write([]byte(strconv.FormatUint(v.Uint(), 10)))
+	case reflect.Float32, reflect.Float64:
+		h.Write([]byte(strconv.FormatFloat(v.Float(), 'g', -1, 64)))
+	}
+}
@@ -0,0 +1,123 @@
+// SPDX-FileCopyrightText: © 2026 Binaek Sarkar <binaek89@gmail.com>
+// SPDX-License-Identifier: Apache-2.0
+
+package index
+
+// contentHashOf commits an index built from sources and returns the content hash of ns/policy.
+func (suite *IndexTestSuite) contentHashOf(ns, policy string, sources ...string) string {
+	suite.T().Helper()
+	idx := suite.indexFromSource(nil, sources...)
+	suite.Require().NoError(idx.Commit(suite.ctx))
+	p, err := idx.ResolvePolicy(ns, policy)
+	suite.Require().NoError(err)
+	suite.Require().NotEmpty(p.ContentHash())
+	return p.ContentHash()
+}
+
+const hashedPolicy = `namespace com/example
+shape User { role: string }
+policy auth {
+  fact user: User
+  rule allow = default false { yield user.role == "admin" }
+  export decision of allow
+}`
+
+func (suite *IndexTestSuite) TestContentHashIgnoresLayout() {
+	reformatted := `namespace com/example
+
+-- the same policy, spread out
+shape User {
+  role: string
+}
+
+policy auth {
+  fact user: User
+
+  rule allow = default false {
+    yield user.role == "admin"
+  }
+
+  export decision of allow
+}`
+	suite.Equal(
+		suite.contentHashOf("com/example", "auth", hashedPolicy),
+		suite.contentHashOf("com/example", "auth", reformatted),
+	)
+}
+
+func (suite *IndexTestSuite) TestContentHashChangesWithThePolicy() {
+	changed := `namespace com/example
+shape User { role: string }
+policy auth {
+  fact user: User
+  rule allow = default false { yield user.role == "root" }
+  export decision of allow
+}`
+	suite.NotEqual(
+		suite.contentHashOf("com/example", "auth", hashedPolicy),
+		suite.contentHashOf("com/example", "auth", changed),
+	)
+}
+
+func (suite *IndexTestSuite) TestContentHashDistinguishesLiteralsFromIdentifiers() {
+	literal := `namespace com/example
+policy p {
+  fact role: string
+  let admin = "role"
+  rule allow = default false { yield admin }
+  export decision of allow
+}`
+	identifier := `namespace com/example
+policy p {
+  fact role: string
+  let admin = role
+  rule allow = default false { yield admin }
+  export decision of allow
+}`
+	suite.NotEqual(
+		suite.contentHashOf("com/example", "p", literal),
+		suite.contentHashOf("com/example", "p", identifier),
+	)
+}
+
+func (suite *IndexTestSuite) TestContentHashChangesWithReferencedShape() {
+	changed := `namespace com/example
+shape User { role: string team?: string }
+policy auth {
+  fact user: User
+  rule allow = default false { yield user.role == "admin" }
+  export decision of allow
+}`
+	suite.NotEqual(
+		suite.contentHashOf("com/example", "auth", hashedPolicy),
+		suite.contentHashOf("com/example", "auth", changed),
+	)
+}
+
+func (suite *IndexTestSuite) TestContentHashFollowsImports() {
+	consumer := `namespace com/example/app
+policy gate {
+  fact user: document
+  rule allow = import decision allow from com/example/auth with user as user
+  export decision of allow
+}`
+	source := func(role string) string {
+		return `namespace com/example
+policy auth {
+  fact user: document
+  rule allow = default false { yield user.role == "` + role + `" }
+  export decision of allow
+}`
+	}
+	unrelated := func(n string) string {
+		return `namespace com/example/other
+policy misc {
+  rule answer = default 0 { yield ` + n + ` }
+  export decision of answer
+}`
+	}
+
+	base := suite.contentHashOf("com/example/app", "gate", consumer, source("admin"), unrelated("1"))
+	suite.NotEqual(base, suite.contentHashOf("com/example/app", "gate", consumer, source("root"), unrelated("1")))
+	suite.Equal(base, suite.contentHashOf("com/example/app", "gate", consumer, source("admin"), unrelated("2")))
+}
@@ -0,0 +1,234 @@
+// SPDX-FileCopyrightText: © 2026 Binaek Sarkar <binaek89@gmail.com>
+// SPDX-License-Identifier: Apache-2.0
+
+package index
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/sentrie-sh/sentrie/ast"
+	"github.com/sentrie-sh/sentrie/xerr"
+)
+
+// detectFactlessReferences fails for every policy that declares no facts but references
+// identifiers which cannot be resolved within the policy - these are almost always facts
+// that were never declared. Policies are reported in order of their FQN.
+func (idx *Index) detectFactlessReferences(ctx context.Context) error {
+	var failures []string
+	for _, nsName := range slices.Sorted(maps.Keys(idx.Namespaces)) {
+		ns := idx.Namespaces[nsName]
+		if !idx.inScope(ns) {
+			continue
+		}
+		for _, policyName := range slices.Sorted(maps.Keys(ns.Policies)) {
+			policy := ns.Policies[policyName]
+			if ctx.Err() != nil {
+				return fmt.Errorf("validation cancelled: %w", xerr.ErrIndex)
+			}
+			if len(policy.Facts) > 0 {
+				continue
+			}
+			unresolved := policy.UnresolvedIdentifiers()
+			if len(unresolved) == 0 {
+				continue
+			}
+			names := make([]string, 0, len(unresolved))
+			for _, ident := range unresolved {
+				names = append(names, fmt.Sprintf("'%s' at %s", ident.Value, ident.Span()))
+			}
+			failures = append(failures, fmt.Sprintf("policy '%s' declares no facts but references unresolved identifiers: %s", policy.FQN.String(), strings.Join(names, ", ")))
+		}
+	}
+	if len(failures) > 0 {
+		return fmt.Errorf("%s: %w", strings.Join(failures, "; "), xerr.ErrIndex)
+	}
+	return nil
+}
+
+// detectUnusedFacts warns about facts that are never referenced by any rule, let,
+// export attachment, require statement or other fact's default within their policy.
+// Policies are reported in order of their FQN, and the facts of a policy in source order.
+func (idx *Index) detectUnusedFacts(ctx context.Context) {
+	var policies []*Policy
+	for _, ns := range idx.Namespaces {
+		if idx.inScope(ns) {
+			policies = slices.AppendSeq(policies, maps.Values(ns.Policies))
+		}
+	}
+	slices.SortFunc(policies, func(a, b *Policy) int {
+		return strings.Compare(a.FQN.String(), b.FQN.String())
+	})
+
+	for _, policy := range policies {
+		if ctx.Err() != nil {
+			return
+		}
+		unused := policy.UnusedFacts()
+		slices.SortStableFunc(unused, func(a, b *ast.FactStatement) int {
+			return cmp.Or(
+				strings.Compare(a.Span().File, b.Span().File),
+				cmp.Compare(a.Span().From.Line, b.Span().From.Line),
+				cmp.Compare(a.Span().From.Column, b.Span().From.Column),
+			)
+		})
+		for _, fact := range unused {
+			idx.addWarning(CodeUnusedFact, fact.Span(), "fact '%s' in policy '%s' is never referenced", fact.Alias, policy.FQN.String())
+		}
+	}
+}
+
+// UnusedFacts returns the facts whose alias is not referenced anywhere in the policy,
+// sorted by alias.
+func (p *Policy) UnusedFacts() []*ast.FactStatement {
+	referenced := map[string]struct{}{}
+	for _, root := range p.expressionRoots() {
+		ast.Inspect(root, func(n ast.Node) bool {
+			if ident, ok := n.(*ast.Identifier); ok {
+				referenced[ident.Value] = struct{}{}
+			}
+			return true
+		})
+	}
+	// a fact asserted by a require statement is checked on every evaluation
+	for _, req := range p.Requires {
+		referenced[req.Fact] = struct{}{}
+	}
+
+	var unused []*ast.FactStatement
+	for alias, fact := range p.Facts {
+		if _, ok := referenced[alias]; !ok {
+			unused = append(unused, fact)
+		}
+	}
+	slices.SortFunc(unused, func(a, b *ast.FactStatement) int {
+		return strings.Compare(a.Alias, b.Alias)
+	})
+	return unused
+}
+
+// UnresolvedIdentifiers returns the identifiers referenced by the policy's rules, lets,
+// derives, fact defaults and export attachments that do not resolve to a fact, let, rule or use
+// alias, or to a lambda or derive parameter, block-local let or projection binding in scope
+// where they are referenced. Identifiers used as call targets are skipped since they may name builtins. Each
+// name is reported once; the result is sorted by name.
+func (p *Policy) UnresolvedIdentifiers() []*ast.Identifier {
+	bound := map[string]struct{}{}
+	for name := range p.Facts {
+		bound[name] = struct{}{}
+	}
+	for name := range p.Lets {
+		bound[name] = struct{}{}
+	}
+	for name := range p.Rules {
+		bound[name] = struct{}{}
+	}
+	for name := range p.Uses {
+		bound[name] = struct{}{}
+	}
+
+	var unresolved []*ast.Identifier
+	reported := map[string]struct{}{}
+	for _, root := range p.expressionRoots() {
+		walkUnresolved(root, bound, map[*ast.Identifier]struct{}{}, func(ident *ast.Identifier) {
+			if _, ok := reported[ident.Value]; ok {
+				return
+			}
+			reported[ident.Value] = struct{}{}
+			unresolved = append(unresolved, ident)
+		})
+	}
+
+	slices.SortStableFunc(unresolved, func(a, b *ast.Identifier) int {
+		return strings.Compare(a.Value, b.Value)
+	})
+	return unresolved
+}
+
+// walkUnresolved calls report for every identifier below root that is not bound in scope.
+// Lambda and derive parameters, block-local lets and projection bindings only bind inside the
+// lambda, derive, block or projection declaring them.
+func walkUnresolved(root ast.Node, scope map[string]struct{}, callees map[*ast.Identifier]struct{}, report func(*ast.Identifier)) {
+	ast.Inspect(root, func(n ast.Node) bool {
+		switch n := n.(type) {
+		case *ast.LambdaExpression:
+			inner := maps.Clone(scope)
+			for _, param := range n.Params {
+				inner[param] = struct{}{}
+			}
+			if n.Body != nil {
+				walkUnresolved(n.Body, inner, callees, report)
+			}
+			return false
+		case *ast.DeriveStatement:
+			inner := maps.Clone(scope)
+			for _, param := range n.Params {
+				inner[param] = struct{}{}
+			}
+			walkUnresolved(n.Body, inner, callees, report)
+			return false
+		case *ast.BlockExpression:
+			inner := maps.Clone(scope)
+			for _, stmt := range n.Statements {
+				if let, ok := stmt.(*ast.VarDeclaration); ok {
+					inner[let.Name] = struct{}{}
+				}
+			}
+			for _, stmt := range n.Statements {
+				walkUnresolved(stmt, inner, callees, report)
+			}
+			walkUnresolved(n.Yield, inner, callees, report)
+			return false
+		case *ast.ProjectExpression:
+			walkUnresolved(n.Source, scope, callees, report)
+			inner := scope
+			if n.As != "" {
+				inner = maps.Clone(scope)
+				inner[n.As] = struct{}{}
+			}
+			for _, entry := range n.Entries {
+				walkUnresolved(entry.Value, inner, callees, report)
+			}
+			return false
+		case *ast.CallExpression:
+			if ident, ok := n.Callee.(*ast.Identifier); ok {
+				callees[ident] = struct{}{}
+			}
+		case *ast.Identifier:
+			if _, ok := callees[n]; ok {
+				return true
+			}
+			if _, ok := scope[n.Value]; !ok {
+				report(n)
+			}
+		}
+		return true
+	})
+}
+
+// expressionRoots returns the top-level expressions evaluated on behalf of the policy
+func (p *Policy) expressionRoots() []ast.Node {
+	var roots []ast.Node
+	for _, fact := range p.Facts {
+		roots = append(roots, fact.Default)
+	}
+	for _, let := range p.Lets {
+		roots = append(roots, let.Value)
+	}
+	for _, derive := range p.Derives {
+		roots = append(roots, derive)
+	}
+	for _, rule := range p.Rules {
+		roots = append(roots, rule.Default, rule.When, rule.Body)
+	}
+	for _, export := range p.RuleExports {
+		for _, attachment := range export.Attachments {
+			roots = append(roots, attachment.Value)
+		}
+	}
+	return roots
+}
@@ -0,0 +1,288 @@
+// SPDX-FileCopyrightText: © 2026 Binaek Sarkar <binaek89@gmail.com>
+// SPDX-License-Identifier: Apache-2.0
+
+package index
+
+import (
+	"strings"
+
+	"github.com/sentrie-sh/sentrie/parser"
+	"github.com/sentrie-sh/sentrie/xerr"
+)
+
+// indexFromSource parses each source as a program and adds it to a fresh index created with opts.
+func (suite *IndexTestSuite) indexFromSource(opts []IndexOption, sources ...string) *Index {
+	suite.T().Helper()
+	idx := CreateIndex(opts...)
+	for i, src := range sources {
+		program, err := parser.NewParserFromString(src, "test"+string(rune('a'+i))+".sentrie").ParseProgram(suite.ctx)
+		suite.Require().NoError(err)
+		suite.Require().NoError(idx.AddProgram(suite.ctx, program))
+	}
+	return idx
+}
+
+func (suite *IndexTestSuite) TestRequireFactsRejectsFactlessPolicyWithUnresolvedIdentifiers() {
+	idx := suite.indexFromSource([]IndexOption{WithRequireFacts()}, `namespace com/example
+policy auth {
+  rule allow = default false when user.role == "admin" { yield account.active }
+  export decision of allow
+}`)
+
+	err := idx.Validate(suite.ctx)
+	suite.Require().Error(err)
+	suite.ErrorIs(err, xerr.ErrIndex)
+	suite.Contains(err.Error(), "declares no facts")
+	suite.Contains(err.Error(), "'account'")
+	suite.Contains(err.Error(), "'user'")
+}
+
+func (suite *IndexTestSuite) TestRequireFactsAllowsConstantOnlyPolicy() {
+	idx := suite.indexFromSource([]IndexOption{WithRequireFacts()}, `namespace com/example
+policy constants {
+  let limit = 10
+  let items = [1, 2, 3]
+  rule allow = default false { yield count(filter(items, (x) => { yield x < limit })) > 0 }
+  export decision of allow
+}`)
+
+	suite.NoError(idx.Validate(suite.ctx))
+}
+
+func (suite *IndexTestSuite) TestRequireFactsReportsEveryFailingPolicyInOrder() {
+	idx := suite.indexFromSource([]IndexOption{WithRequireFacts()}, `namespace com/example
+policy zeta {
+  rule allow = default false { yield account.active }
+  export decision of allow
+}
+policy alpha {
+  rule allow = default false { yield user.active }
+  export decision of allow
+}`)
+
+	err := idx.Validate(suite.ctx)
+	suite.Require().Error(err)
+	alpha := strings.Index(err.Error(), "policy 'com/example/alpha'")
+	zeta := strings.Index(err.Error(), "policy 'com/example/zeta'")
+	suite.Require().GreaterOrEqual(alpha, 0)
+	suite.Require().GreaterOrEqual(zeta, 0)
+	suite.Less(alpha, zeta)
+}
+
+func (suite *IndexTestSuite) TestRequireFactsScopesLambdaParamsAndBlockLets() {
+	idx := suite.indexFromSource([]IndexOption{WithRequireFacts()}, `namespace com/example
+policy auth {
+  let items = [1, 2, 3]
+  rule mapped = default false { yield count(filter(items, (user) => { let limit = 2 yield user < limit })) > 0 }
+  rule allow = default false { yield user.role == "admin" and limit > 1 }
+  export decision of allow
+  export decision of mapped
+}`)
+
+	err := idx.Validate(suite.ctx)
+	suite.Require().Error(err)
+	suite.Contains(err.Error(), "'user'")
+	suite.Contains(err.Error(), "'limit'")
+}
+
+func (suite *IndexTestSuite) TestRequireFactsScopesDeriveParams() {
+	idx := suite.indexFromSource([]IndexOption{WithRequireFacts()}, `namespace com/example
+policy auth {
+  let admins = ["root"]
+  derive privileged(role) = role in admins
+  rule allow = default false { yield privileged("root") and role == "admin" }
+  export decision of allow
+}`)
+
+	err := idx.Validate(suite.ctx)
+	suite.Require().Error(err)
+	suite.Contains(err.Error(), "'role' at")
+	suite.NotContains(err.Error(), "'admins'")
+}
+
+func (suite *IndexTestSuite) TestFactUsedOnlyInDeriveIsNotReported() {
+	idx := suite.indexFromSource(nil, `namespace com/example
+policy auth {
+  fact admins: list[string]
+  derive privileged(role) = role in admins
+  rule allow = default false { yield privileged("root") }
+  export decision of allow
+}`)
+
+	suite.Require().NoError(idx.Validate(suite.ctx))
+	suite.Empty(idx.Warnings())
+}
+
+func (suite *IndexTestSuite) TestDeriveCyclesAreRejected() {
+	for name, body := range map[string]string{
+		"derive to derive": `derive a(x) = b(x)
+  derive b(x) = a(x) + 1`,
+		"derive to let": `let limit = scaled(2)
+  derive scaled(x) = x * limit`,
+		"derive to itself": `derive a(x) = a(x - 1)`,
+	} {
+		idx := suite.indexFromSource(nil, `namespace com/example
+policy auth {
+  `+body+`
+  rule allow = default false { yield true }
+  export decision of allow
+}`)
+
+		err := idx.Validate(suite.ctx)
+		suite.Require().Error(err, name)
+		suite.Contains(err.Error(), "infinite recursion", name)
+	}
+}
+
+func (suite *IndexTestSuite) TestDeriveParamsDoNotFormCycles() {
+	idx := suite.indexFromSource(nil, `namespace com/example
+policy auth {
+  let limit = scaled(2)
+  derive scaled(limit) = limit * 10
+  rule allow = default false { yield limit > 0 }
+  export decision of allow
+}`)
+
+	suite.Require().NoError(idx.Validate(suite.ctx))
+}
+
+func (suite *IndexTestSuite) TestDeriveSharesThePolicyIdentifierSpace() {
+	idx := CreateIndex()
+	program, err := parser.NewParserFromString(`namespace com/example
+policy auth {
+  let privileged = true
+  derive privileged(role) = role == "admin"
+  rule allow = default false { yield privileged }
+  export decision of allow
+}`, "test.sentrie").ParseProgram(suite.ctx)
+	suite.Require().NoError(err)
+
+	err = idx.AddProgram(suite.ctx, program)
+	suite.Require().Error(err)
+	suite.Contains(err.Error(), "conflict: derive declaration")
+}
+
+func (suite *IndexTestSuite) TestFactlessPolicyAllowedWithoutRequireFacts() {
+	idx := suite.indexFromSource(nil, `namespace com/example
+policy auth {
+  rule allow = default false { yield user.role == "admin" }
+  export decision of allow
+}`)
+
+	suite.NoError(idx.Validate(suite.ctx))
+}
+
+func (suite *IndexTestSuite) TestUnusedFactIsReportedAsWarning() {
+	idx := suite.indexFromSource(nil, `namespace com/example
+policy auth {
+  fact user: document
+  fact region: string
+  rule allow = default false { yield user.role == "admin" }
+  export decision of allow
+}`)
+
+	suite.Require().NoError(idx.Validate(suite.ctx))
+	warnings := idx.Warnings()
+	suite.Require().Len(warnings, 1)
+	suite.Equal(SeverityWarning, warnings[0].Severity)
+	suite.Contains(warnings[0].Message, "fact 'region'")
+}
+
+func (suite *IndexTestSuite) TestUnusedFactsAreReportedInPolicyAndSourceOrder() {
+	idx := suite.indexFromSource(nil, `namespace com/example/zeta
+policy b {
+  fact zone: string
+  fact account: string
+  rule y = default true { yield true }
+  export decision of y
+}
+policy a {
+  fact user: string
+  rule x = default true { yield true }
+  export decision of x
+}`, `namespace com/example
+policy z {
+  fact tenant: string
+  rule w = default true { yield true }
+  export decision of w
+}`)
+
+	suite.Require().NoError(idx.Validate(suite.ctx))
+	var messages []string
+	for _, warning := range idx.Warnings() {
+		messages = append(messages, warning.Message)
+	}
+	suite.Equal([]string{
+		"fact 'tenant' in policy 'com/example/z' is never referenced",
+		"fact 'user' in policy 'com/example/zeta/a' is never referenced",
+		"fact 'zone' in policy 'com/example/zeta/b' is never referenced",
+		"fact 'account' in policy 'com/example/zeta/b' is never referenced",
+	}, messages)
+}
+
+func (suite *IndexTestSuite) TestFactUsedOnlyInWhenClauseIsNotReported() {
+	idx := suite.indexFromSource(nil, `namespace com/example
+policy auth {
+  fact user: document
+  fact enabled: boolean
+  rule allow = default false when enabled { yield user.role == "admin" }
+  export decision of allow
+}`)
+
+	suite.Require().NoError(idx.Validate(suite.ctx))
+	suite.Empty(idx.Warnings())
+}
+
+func (suite *IndexTestSuite) TestFactUsedOnlyInAnotherFactDefaultIsNotReported() {
+	idx := suite.indexFromSource(nil, `namespace com/example
+policy auth {
+  fact fallback?: string default "eu"
+  fact region?: string default fallback
+  rule allow = default false { yield region == "eu" }
+  export decision of allow
+}`)
+
+	suite.Require().NoError(idx.Validate(suite.ctx))
+	suite.Empty(idx.Warnings())
+}
+
+func (suite *IndexTestSuite) TestPolicyWarningsAreScopedToThePolicy() {
+	idx := suite.indexFromSource(nil, `namespace com/example
+policy a {
+  fact user: string
+  rule x = default true { yield true }
+  export decision of x
+}
+policy b {
+  fact account: string
+  rule y = default true { yield true }
+  export decision of y
+}`)
+	suite.Require().NoError(idx.Validate(suite.ctx))
+	suite.Require().Len(idx.Warnings(), 2)
+
+	warnings := idx.PolicyWarnings("com/example", "a")
+	suite.Require().Len(warnings, 1)
+	suite.Contains(warnings[0].Message, "'user'")
+
+	warnings = idx.PolicyWarnings("com/example", "b")
+	suite.Require().Len(warnings, 1)
+	suite.Contains(warnings[0].Message, "'account'")
+
+	suite.Empty(idx.PolicyWarnings("com/example", "missing"))
+}
+
+func (suite *IndexTestSuite) TestFactUsedOnlyInRequireIsNotReported() {
+	idx := suite.indexFromSource(nil, `namespace com/example
+shape Address { city: string }
+policy checkout {
+  fact customer: document
+  fact billing?: document
+  require billing conforms Address
+  rule allow = default false { yield customer.city == "Paris" }
+  export decision of allow
+}`)
+
+	suite.Require().NoError(idx.Validate(suite.ctx))
+	suite.Empty(idx.Warnings())
+}
@@ -0,0 +1,63 @@
+// SPDX-FileCopyrightText: © 2026 Binaek Sarkar <binaek89@gmail.com>
+// SPDX-License-Identifier: Apache-2.0
+
+package index
+
+import "github.com/sentrie-sh/sentrie/xerr"
+
+func (suite *IndexTestSuite) TestPolicyImportCycleBetweenTwoPolicies() {
+	// rule-level the graph is acyclic (a.x -> b.y, b.z -> a.w) but the policies import each other
+	idx := suite.indexFromSource(nil, `namespace com/example
+policy a {
+  rule w = default true { yield true }
+  rule x = import decision y from com/example/b
+  export decision of x
+  export decision of w
+}
+policy b {
+  rule y = default true { yield true }
+  rule z = import decision w from com/example/a
+  export decision of y
+  export decision of z
+}`)
+
+	err := idx.Validate(suite.ctx)
+	suite.Require().Error(err)
+	suite.ErrorIs(err, xerr.ErrIndex)
+	suite.Contains(err.Error(), "detected cyclic import in policies")
+	suite.Contains(err.Error(), "com/example/a (")
+	suite.Contains(err.Error(), "com/example/b (")
+	suite.Contains(err.Error(), "testa.sentrie")
+}
+
+func (suite *IndexTestSuite) TestPolicyImportCycleSelfImport() {
+	idx := suite.indexFromSource(nil, `namespace com/example
+policy a {
+  rule w = default true { yield true }
+  rule x = import decision w from a
+  export decision of x
+}`)
+
+	err := idx.Validate(suite.ctx)
+	suite.Require().Error(err)
+	suite.ErrorIs(err, xerr.ErrIndex)
+	suite.Contains(err.Error(), "com/example/a imports from itself")
+}
+
+func (suite *IndexTestSuite) TestPolicyImportAcyclicChain() {
+	idx := suite.indexFromSource(nil, `namespace com/example
+policy a {
+  rule x = import decision y from b
+  export decision of x
+}
+policy b {
+  rule y = import decision z from c
+  export decision of y
+}
+policy c {
+  rule z = default true { yield true }
+  export decision of z
+}`)
+
+	suite.NoError(idx.Validate(suite.ctx))
+}
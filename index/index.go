@@ -18,11 +18,16 @@ package index
 
 import (
 	"context"
+	"fmt"
 	"sync"
+	"sync/atomic"
+	"time"
 
 	"github.com/sentrie-sh/sentrie/ast"
+	"github.com/sentrie-sh/sentrie/constants"
 	"github.com/sentrie-sh/sentrie/dag"
 	"github.com/sentrie-sh/sentrie/pack"
+	"github.com/sentrie-sh/sentrie/xerr"
 )
 
 type Index struct {
@@ -41,10 +46,43 @@ type Index struct {
 	committed   uint32 // 0 = not committed, 1 = committed
 	commitError error
 	commitOnce  *sync.Once
+	// committedAt is when the index was committed successfully, zero until then
+	committedAt time.Time
+
+	// warnings collected during validation
+	warnings []Diagnostic
+
+	// requireFacts fails validation for policies that declare no facts but reference unresolved identifiers
+	requireFacts bool
+
+	// shadowedFieldWarnings reports a shape field shadowing a composed one as a warning instead of failing validation
+	shadowedFieldWarnings bool
+
+	// scope holds the namespaces rebuilt by ReplaceProgram, which are the only ones checked on
+	// their own when validating; nil for every namespace
+	scope map[string]struct{}
+}
+
+type IndexOption func(*Index)
+
+// WithRequireFacts makes validation fail for a policy that declares no facts yet
+// references identifiers that are not resolvable within the policy.
+func WithRequireFacts() IndexOption {
+	return func(idx *Index) {
+		idx.requireFacts = true
+	}
+}
+
+// WithShadowedFieldWarnings downgrades a field of a composed shape that redefines a
+// field of its base without `override` from a validation failure to a warning.
+func WithShadowedFieldWarnings() IndexOption {
+	return func(idx *Index) {
+		idx.shadowedFieldWarnings = true
+	}
 }
 
-func CreateIndex() *Index {
-	return &Index{
+func CreateIndex(opts ...IndexOption) *Index {
+	idx := &Index{
 		theLock:        &sync.RWMutex{},
 		Namespaces:     make(map[string]*Namespace),
 		Programs:       make(map[string]*Program),
@@ -53,6 +91,10 @@ func CreateIndex() *Index {
 		committed:      0,
 		commitOnce:     &sync.Once{},
 	}
+	for _, opt := range opts {
+		opt(idx)
+	}
+	return idx
 }
 
 func (idx *Index) SetPack(ctx context.Context, p *pack.PackFile) error {
@@ -72,8 +114,32 @@ func (idx *Index) AddProgram(ctx context.Context, astProgram *ast.Program) error
 		return ctx.Err()
 	}
 
-	program := createProgram(astProgram)
+	// views of a committed index share its policies and shapes, so it must not change anymore
+	if atomic.LoadUint32(&idx.committed) == 1 {
+		return fmt.Errorf("cannot add program %s to a committed index: %w", astProgram.Reference, xerr.ErrIndex)
+	}
+
+	if err := prepareProgram(astProgram); err != nil {
+		return err
+	}
+	return idx.addProgram(ctx, createProgram(astProgram))
+}
+
+// prepareProgram checks that astProgram may be added to an index, and folds its constants.
+func prepareProgram(astProgram *ast.Program) error {
+	for _, stmt := range astProgram.Statements {
+		if test, ok := stmt.(*ast.TestStatement); ok {
+			return fmt.Errorf("test '%s' at %s must be declared in a .%s test file: %w", test.Name, test.Span(), constants.TestFileExtension, xerr.ErrIndex)
+		}
+	}
+
+	// literal sub-expressions are computed once here rather than on every evaluation
+	ast.FoldConstants(astProgram)
+	return nil
+}
 
+// addProgram adds the namespace, shapes, policies and shape exports of program.
+func (idx *Index) addProgram(ctx context.Context, program *Program) error {
 	ns, err := idx.ensureNamespace(ctx, program.Namespace)
 	if err != nil {
 		return err
@@ -91,7 +157,7 @@ func (idx *Index) AddProgram(ctx context.Context, astProgram *ast.Program) error
 	}
 
 	for _, policy := range program.Policies {
-		p, err := createPolicy(ns, policy, astProgram)
+		p, err := createPolicy(ns, policy, program.Reference)
 		if err != nil {
 			return err
 		}
@@ -107,7 +173,7 @@ func (idx *Index) AddProgram(ctx context.Context, astProgram *ast.Program) error
 		}
 	}
 
-	idx.Programs[astProgram.Reference] = program
+	idx.Programs[program.Reference.Reference] = program
 
 	return nil
 }
@@ -21,8 +21,10 @@ import (
 
 	"github.com/sentrie-sh/sentrie/ast"
 	"github.com/sentrie-sh/sentrie/pack"
+	"github.com/sentrie-sh/sentrie/parser"
 	"github.com/sentrie-sh/sentrie/tokens"
 	"github.com/sentrie-sh/sentrie/trinary"
+	"github.com/sentrie-sh/sentrie/xerr"
 )
 
 func (suite *IndexTestSuite) TestCreateIndex() {
@@ -513,3 +515,16 @@ func (suite *IndexTestSuite) TestAddProgramWithInvalidFactStatementPosition() {
 	suite.Error(err)
 	suite.Contains(err.Error(), "'fact' must appear before rules, exports, lets, and shapes")
 }
+
+func (suite *IndexTestSuite) TestAddProgramRejectsTests() {
+	program, err := parser.NewParserFromString(`namespace com/example
+policy auth {}
+test "admins are allowed" of auth { expect allow is true }`, "auth.sentrie").ParseProgram(suite.ctx)
+	suite.Require().NoError(err)
+
+	err = suite.idx.AddProgram(suite.ctx, program)
+	suite.Require().Error(err)
+	suite.ErrorIs(err, xerr.ErrIndex)
+	suite.ErrorContains(err, "test 'admins are allowed'")
+	suite.ErrorContains(err, ".test.sentrie test file")
+}
@@ -0,0 +1,515 @@
+// SPDX-FileCopyrightText: © 2026 Binaek Sarkar <binaek89@gmail.com>
+// SPDX-License-Identifier: Apache-2.0
+
+package index
+
+import (
+	"fmt"
+	"maps"
+	"regexp"
+	"slices"
+
+	"github.com/sentrie-sh/sentrie/ast"
+	"github.com/sentrie-sh/sentrie/trinary"
+)
+
+// JSONSchemaDialect is the JSON Schema draft the documents of ShapeJSONSchema follow.
+const JSONSchemaDialect = "https://json-schema.org/draft/2020-12/schema"
+
+// jsonSchemaExtension prefixes the keywords carrying constraints JSON Schema cannot express.
+const jsonSchemaExtension = "x-sentrie-"
+
+// ShapeJSONSchema returns a JSON Schema document describing the values the shape named shape in
+// namespace ns accepts. Fields include those composed from `with` ancestors, optional fields are
+// left out of "required", nullable types also accept null, and a closed shape admits no other
+// properties. Shapes the fields refer to are described under "$defs", keyed by their FQN.
+//
+// Constraints map to their JSON Schema keywords where one exists - @minlength to minLength,
+// @regexp to pattern, @one_of to enum and so on. Any other constraint, or one whose arguments are
+// not literals, is kept as an "x-sentrie-<constraint>" keyword holding its arguments.
+func (idx *Index) ShapeJSONSchema(ns, shape string) (map[string]any, error) {
+	s, err := idx.ResolveShape(ns, shape)
+	if err != nil {
+		return nil, err
+	}
+
+	gen := newJSONSchemaGenerator(idx.Namespaces, func(fqn string) string { return "#/$defs/" + fqn })
+	gen.root = s
+	schema, err := gen.shape(s)
+	if err != nil {
+		return nil, err
+	}
+	schema["$schema"] = JSONSchemaDialect
+	schema["title"] = s.FQN.String()
+	if len(gen.defs) > 0 {
+		defs := make(map[string]any, len(gen.defs))
+		for k, v := range gen.defs {
+			defs[k] = v
+		}
+		schema["$defs"] = defs
+	}
+	return schema, nil
+}
+
+// FactsJSONSchema returns a JSON Schema describing the facts the policy named policy in namespace
+// ns accepts, as the object of facts of an evaluation. Every fact is a property, keyed by the name
+// it is exposed as, and facts that are not optional are required. A fact a require statement ties
+// to a shape must also conform to that shape, and a sensitive fact is marked writeOnly. Types and
+// constraints are described as by ShapeJSONSchema, except that the shapes the facts refer to are
+// returned apart, keyed by FQN, and referred to as ref(fqn), for the caller to place them among
+// its own definitions.
+func (v *IndexView) FactsJSONSchema(ns, policy string, ref func(fqn string) string) (map[string]any, map[string]map[string]any, error) {
+	p, err := v.ResolvePolicy(ns, policy)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	gen := newJSONSchemaGenerator(v.namespaces, ref)
+	properties := make(map[string]any, len(p.Facts))
+	required := []string{}
+	for _, name := range slices.Sorted(maps.Keys(p.Facts)) {
+		fact := p.Facts[name]
+		schema, err := gen.typeRef(p.Namespace, p, fact.Type)
+		if err != nil {
+			return nil, nil, fmt.Errorf("fact '%s': %w", name, err)
+		}
+		for _, req := range p.Requires {
+			if req.Fact != name {
+				continue
+			}
+			shape, err := gen.ref(p.Namespace, p, ast.NewShapeTypeRef(req.Shape, req.Span()))
+			if err != nil {
+				return nil, nil, fmt.Errorf("fact '%s': %w", name, err)
+			}
+			schema = map[string]any{"allOf": []any{schema, shape}}
+		}
+		if fact.Default != nil {
+			if value, ok := literalValue(fact.Default); ok {
+				schema["default"] = value
+			}
+		}
+		if fact.Sensitive {
+			schema["writeOnly"] = true
+		}
+		properties[name] = schema
+		if !fact.Optional {
+			required = append(required, name)
+		}
+	}
+
+	schema := map[string]any{
+		"type":       "object",
+		"title":      p.FQN.String(),
+		"properties": properties,
+		"required":   required,
+	}
+	return schema, gen.defs, nil
+}
+
+type jsonSchemaGenerator struct {
+	namespaces map[string]*Namespace
+	// root is the shape the document describes, which refers to itself as "#"
+	root *Shape
+	// refTo returns the reference to the schema of the shape with the given FQN
+	refTo func(fqn string) string
+	// defs holds the schemas of the shapes referred to, by FQN; an entry exists from the
+	// moment a shape is first met, so that recursive shapes refer to it instead of expanding
+	defs     map[string]map[string]any
+	composed map[*Shape]map[string]*ShapeModelField
+}
+
+func newJSONSchemaGenerator(namespaces map[string]*Namespace, refTo func(fqn string) string) *jsonSchemaGenerator {
+	return &jsonSchemaGenerator{namespaces: namespaces, refTo: refTo, defs: map[string]map[string]any{}, composed: map[*Shape]map[string]*ShapeModelField{}}
+}
+
+// shape returns the schema of s itself.
+func (g *jsonSchemaGenerator) shape(s *Shape) (map[string]any, error) {
+	if s.AliasOf != nil {
+		return g.typeRef(s.Namespace, s.Policy, s.AliasOf)
+	}
+
+	fields := composedFields(g.namespaces, s, g.composed)
+	properties := make(map[string]any, len(fields))
+	required := []string{}
+	for _, name := range slices.Sorted(maps.Keys(fields)) {
+		field := fields[name]
+		// a composed field is written in its own shape, and resolves from there
+		owner := s
+		if o := g.owner(s, field); o != nil {
+			owner = o
+		}
+		schema, err := g.typeRef(owner.Namespace, owner.Policy, field.TypeRef)
+		if err != nil {
+			return nil, err
+		}
+		// a default that is not a literal depends on evaluation, so only literals are described
+		if field.Default != nil {
+			if v, ok := literalValue(field.Default); ok {
+				schema["default"] = v
+			}
+		}
+		properties[name] = schema
+		if !field.Optional {
+			required = append(required, name)
+		}
+	}
+
+	schema := map[string]any{
+		"type":       "object",
+		"properties": properties,
+		"required":   required,
+	}
+	if s.Model != nil && s.Model.Closed {
+		schema["additionalProperties"] = false
+	}
+	return schema, nil
+}
+
+// owner returns the shape along the composition chain of s that declares field.
+func (g *jsonSchemaGenerator) owner(s *Shape, field *ShapeModelField) *Shape {
+	if field.Node == nil {
+		return nil
+	}
+	for shape := s; shape != nil; shape = composedWith(g.namespaces, shape) {
+		if shape.Statement != nil && shape.Statement.Complex != nil && shape.Statement.Complex.Fields[field.Name] == field.Node {
+			return shape
+		}
+	}
+	return nil
+}
+
+// ref returns a reference to the schema of the shape t names, written in ns and policy.
+func (g *jsonSchemaGenerator) ref(ns *Namespace, policy *Policy, t *ast.ShapeTypeRef) (map[string]any, error) {
+	_, s, err := resolveShapeRef(g.namespaces, ns, policy, *t.Ref)
+	if err != nil {
+		return nil, err
+	}
+	if s == g.root {
+		return map[string]any{"$ref": "#"}, nil
+	}
+
+	key := s.FQN.String()
+	if _, ok := g.defs[key]; !ok {
+		g.defs[key] = map[string]any{}
+		schema, err := g.shape(s)
+		if err != nil {
+			return nil, err
+		}
+		g.defs[key] = schema
+	}
+	return map[string]any{"$ref": g.refTo(key)}, nil
+}
+
+// typeRef returns the schema of typeRef, written in ns and policy; policy is nil for a type
+// written outside of a policy.
+func (g *jsonSchemaGenerator) typeRef(ns *Namespace, policy *Policy, typeRef ast.TypeRef) (map[string]any, error) {
+	if ast.IsNullableTypeRef(typeRef) {
+		schema, err := g.typeRef(ns, policy, ast.UnwrapNullableTypeRef(typeRef))
+		if err != nil {
+			return nil, err
+		}
+		return map[string]any{"anyOf": []any{schema, map[string]any{"type": "null"}}}, nil
+	}
+
+	var schema map[string]any
+	switch t := typeRef.(type) {
+	case nil:
+		return map[string]any{}, nil
+	case *ast.StringTypeRef:
+		schema = map[string]any{"type": "string"}
+		applyStringConstraints(schema, t.GetConstraints())
+	case *ast.NumberTypeRef:
+		schema = map[string]any{"type": "number"}
+		applyNumberConstraints(schema, t.GetConstraints())
+	case *ast.TrinaryTypeRef:
+		schema = map[string]any{"type": "boolean"}
+		applyTrinaryConstraints(schema, t.GetConstraints())
+	case *ast.DateTypeRef:
+		schema = map[string]any{"type": "string", "format": "date-time"}
+		applyExtensions(schema, t.GetConstraints())
+	case *ast.DocumentTypeRef:
+		schema = map[string]any{"type": "object"}
+		applyExtensions(schema, t.GetConstraints())
+	case *ast.ListTypeRef:
+		items, err := g.typeRef(ns, policy, t.ElemType)
+		if err != nil {
+			return nil, err
+		}
+		schema = map[string]any{"type": "array", "items": items}
+		applyListConstraints(schema, t.GetConstraints())
+	case *ast.DictTypeRef:
+		values, err := g.typeRef(ns, policy, t.ValueType)
+		if err != nil {
+			return nil, err
+		}
+		schema = map[string]any{"type": "object", "additionalProperties": values}
+		applyExtensions(schema, t.GetConstraints())
+	case *ast.RecordTypeRef:
+		items := make([]any, 0, len(t.Fields))
+		for _, field := range t.Fields {
+			item, err := g.typeRef(ns, policy, field)
+			if err != nil {
+				return nil, err
+			}
+			items = append(items, item)
+		}
+		schema = map[string]any{"type": "array", "prefixItems": items, "items": false, "minItems": len(items), "maxItems": len(items)}
+		applyExtensions(schema, t.GetConstraints())
+	case *ast.ShapeTypeRef:
+		ref, err := g.ref(ns, policy, t)
+		if err != nil {
+			return nil, err
+		}
+		if len(t.GetConstraints()) == 0 {
+			return ref, nil
+		}
+		schema = map[string]any{"allOf": []any{ref}}
+		applyExtensions(schema, t.GetConstraints())
+	default:
+		schema = map[string]any{}
+	}
+	return schema, nil
+}
+
+func applyStringConstraints(schema map[string]any, constraints []*ast.TypeRefConstraint) {
+	formats := map[string]string{"email": "email", "url": "uri", "uuid": "uuid", "ipv4": "ipv4", "ipv6": "ipv6"}
+	patterns := map[string]string{
+		"alphanumeric": "^[A-Za-z0-9]*$",
+		"alpha":        "^[A-Za-z]*$",
+		"numeric":      "^[0-9]*$",
+	}
+	for _, c := range constraints {
+		if format, ok := formats[c.Name]; ok && len(c.Args) == 0 {
+			setOrExtend(schema, "format", format, c)
+			continue
+		}
+		if pattern, ok := patterns[c.Name]; ok && len(c.Args) == 0 {
+			addPattern(schema, pattern)
+			continue
+		}
+
+		switch c.Name {
+		case "length", "minlength", "maxlength":
+			n, ok := literalNumber(c, 0)
+			if !ok || len(c.Args) != 1 {
+				break
+			}
+			if c.Name != "maxlength" {
+				schema["minLength"] = int(n)
+			}
+			if c.Name != "minlength" {
+				schema["maxLength"] = int(n)
+			}
+			continue
+		case "not_empty":
+			if len(c.Args) == 0 {
+				schema["minLength"] = max(1, asInt(schema["minLength"]))
+				continue
+			}
+		case "regexp":
+			if s, ok := literalString(c, 0); ok && len(c.Args) == 1 {
+				addPattern(schema, s)
+				continue
+			}
+		case "starts_with", "ends_with", "has_substring":
+			if s, ok := literalString(c, 0); ok && len(c.Args) == 1 {
+				pattern := regexp.QuoteMeta(s)
+				switch c.Name {
+				case "starts_with":
+					pattern = "^" + pattern
+				case "ends_with":
+					pattern += "$"
+				}
+				addPattern(schema, pattern)
+				continue
+			}
+		case "one_of", "not_one_of":
+			if values, ok := literalArgs(c); ok {
+				if c.Name == "one_of" {
+					schema["enum"] = values
+				} else {
+					schema["not"] = map[string]any{"enum": values}
+				}
+				continue
+			}
+		}
+		addExtension(schema, c)
+	}
+}
+
+func applyNumberConstraints(schema map[string]any, constraints []*ast.TypeRefConstraint) {
+	bounds := map[string]string{"min": "minimum", "max": "maximum", "gt": "exclusiveMinimum", "lt": "exclusiveMaximum", "multiple_of": "multipleOf", "eq": "const"}
+	signs := map[string][2]any{
+		"positive":     {"exclusiveMinimum", 0},
+		"negative":     {"exclusiveMaximum", 0},
+		"non_negative": {"minimum", 0},
+		"non_positive": {"maximum", 0},
+	}
+	for _, c := range constraints {
+		if keyword, ok := bounds[c.Name]; ok {
+			if n, ok := literalNumber(c, 0); ok && len(c.Args) == 1 {
+				setOrExtend(schema, keyword, n, c)
+				continue
+			}
+		}
+		if sign, ok := signs[c.Name]; ok && len(c.Args) == 0 {
+			setOrExtend(schema, sign[0].(string), sign[1], c)
+			continue
+		}
+
+		switch c.Name {
+		case "range":
+			lo, okLo := literalNumber(c, 0)
+			hi, okHi := literalNumber(c, 1)
+			if okLo && okHi && len(c.Args) == 2 {
+				schema["minimum"], schema["maximum"] = lo, hi
+				continue
+			}
+		case "in", "one_of", "not_in":
+			if values, ok := literalArgs(c); ok {
+				if c.Name == "not_in" {
+					schema["not"] = map[string]any{"enum": values}
+				} else {
+					schema["enum"] = values
+				}
+				continue
+			}
+		case "even":
+			if len(c.Args) == 0 {
+				setOrExtend(schema, "multipleOf", 2, c)
+				continue
+			}
+		}
+		addExtension(schema, c)
+	}
+}
+
+func applyTrinaryConstraints(schema map[string]any, constraints []*ast.TypeRefConstraint) {
+	for _, c := range constraints {
+		switch c.Name {
+		case "is_true":
+			schema["const"] = true
+			continue
+		case "is_false":
+			schema["const"] = false
+			continue
+		case "not_unknown":
+			// JSON has no unknown, so every JSON boolean satisfies it
+			continue
+		}
+		addExtension(schema, c)
+	}
+}
+
+func applyListConstraints(schema map[string]any, constraints []*ast.TypeRefConstraint) {
+	for _, c := range constraints {
+		switch c.Name {
+		case "length", "minlength", "maxlength":
+			n, ok := literalNumber(c, 0)
+			if !ok || len(c.Args) != 1 {
+				break
+			}
+			if c.Name != "maxlength" {
+				schema["minItems"] = int(n)
+			}
+			if c.Name != "minlength" {
+				schema["maxItems"] = int(n)
+			}
+			continue
+		case "not_empty":
+			if len(c.Args) == 0 {
+				schema["minItems"] = max(1, asInt(schema["minItems"]))
+				continue
+			}
+		case "unique":
+			schema["uniqueItems"] = true
+			continue
+		}
+		addExtension(schema, c)
+	}
+}
+
+func applyExtensions(schema map[string]any, constraints []*ast.TypeRefConstraint) {
+	for _, c := range constraints {
+		addExtension(schema, c)
+	}
+}
+
+// setOrExtend sets keyword to value unless an earlier constraint already set it, in which case
+// c is kept as an extension rather than silently overriding the first.
+func setOrExtend(schema map[string]any, keyword string, value any, c *ast.TypeRefConstraint) {
+	if _, ok := schema[keyword]; ok {
+		addExtension(schema, c)
+		return
+	}
+	schema[keyword] = value
+}
+
+// addPattern adds a pattern the string must match; a schema holds one pattern, so further
+// patterns are required through allOf.
+func addPattern(schema map[string]any, pattern string) {
+	if _, ok := schema["pattern"]; !ok {
+		schema["pattern"] = pattern
+		return
+	}
+	allOf, _ := schema["allOf"].([]any)
+	schema["allOf"] = append(allOf, map[string]any{"pattern": pattern})
+}
+
+// addExtension keeps c as an "x-sentrie-<name>" keyword holding its arguments: their literal
+// values, or their source when they are not literals. A constraint used more than once holds
+// the arguments of each use.
+func addExtension(schema map[string]any, c *ast.TypeRefConstraint) {
+	args := make([]any, 0, len(c.Args))
+	for _, arg := range c.Args {
+		if v, ok := literalValue(arg); ok {
+			args = append(args, v)
+		} else {
+			args = append(args, arg.String())
+		}
+	}
+
+	key := jsonSchemaExtension + c.Name
+	existing, ok := schema[key]
+	if !ok {
+		schema[key] = args
+		return
+	}
+	uses, ok := existing.([][]any)
+	if !ok {
+		uses = [][]any{existing.([]any)}
+	}
+	schema[key] = append(uses, args)
+}
+
+// literalArgs returns the values of the arguments of c when they are all literals.
+func literalArgs(c *ast.TypeRefConstraint) ([]any, bool) {
+	values := make([]any, 0, len(c.Args))
+	for _, arg := range c.Args {
+		v, ok := literalValue(arg)
+		if !ok {
+			return nil, false
+		}
+		values = append(values, v)
+	}
+	return values, len(values) > 0
+}
+
+// literalValue returns the JSON value of a string, number or known trinary literal.
+func literalValue(expr ast.Expression) (any, bool) {
+	switch lit := expr.(type) {
+	case *ast.StringLiteral:
+		return lit.Value, true
+	case *ast.TrinaryLiteral:
+		if lit.Value == trinary.Unknown {
+			return nil, false
+		}
+		return lit.Value == trinary.True, true
+	}
+	return literalNumber(ast.NewTypeRefConstraint("", []ast.Expression{expr}, expr.Span()), 0)
+}
+
+func asInt(v any) int {
+	n, _ := v.(int)
+	return n
+}
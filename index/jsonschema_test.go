@@ -0,0 +1,176 @@
+// SPDX-FileCopyrightText: © 2026 Binaek Sarkar <binaek89@gmail.com>
+// SPDX-License-Identifier: Apache-2.0
+
+package index
+
+import (
+	"encoding/json"
+
+	"github.com/sentrie-sh/sentrie/xerr"
+)
+
+func (suite *IndexTestSuite) TestShapeJSONSchemaMapsFieldsAndConstraints() {
+	idx := suite.indexFromSource(nil, `namespace com/example
+shape Entity {
+  id: string @uuid()
+  created?: date
+}
+shape Address closed {
+  city: string @not_empty()
+  zip: string @length(5) @numeric()
+}
+shape User with Entity {
+  email: string @email() @maxlength(254)
+  handle: string @regexp("^[a-z]+$") @starts_with("u.")
+  role: string @one_of("admin", "viewer")
+  nick?: string? @trimmed()
+  age: number @min(18) @lt(130) @even()
+  address: Address
+  tags: list[string] @unique() @not_empty()
+  scores: dict[number]
+  point: record[number, number]
+  manager?: User
+}`)
+	suite.Require().NoError(idx.Validate(suite.ctx))
+
+	schema, err := idx.ShapeJSONSchema("com/example", "User")
+	suite.Require().NoError(err)
+	raw, err := json.Marshal(schema)
+	suite.Require().NoError(err)
+
+	suite.JSONEq(`{
+		"$schema": "https://json-schema.org/draft/2020-12/schema",
+		"title": "com/example/User",
+		"type": "object",
+		"required": ["address", "age", "email", "handle", "id", "point", "role", "scores", "tags"],
+		"properties": {
+			"id": {"type": "string", "format": "uuid"},
+			"created": {"type": "string", "format": "date-time"},
+			"email": {"type": "string", "format": "email", "maxLength": 254},
+			"handle": {"type": "string", "pattern": "^[a-z]+$", "allOf": [{"pattern": "^u\\."}]},
+			"role": {"type": "string", "enum": ["admin", "viewer"]},
+			"nick": {"anyOf": [{"type": "string", "x-sentrie-trimmed": []}, {"type": "null"}]},
+			"age": {"type": "number", "minimum": 18, "exclusiveMaximum": 130, "multipleOf": 2},
+			"address": {"$ref": "#/$defs/com/example/Address"},
+			"tags": {"type": "array", "items": {"type": "string"}, "uniqueItems": true, "minItems": 1},
+			"scores": {"type": "object", "additionalProperties": {"type": "number"}},
+			"point": {"type": "array", "prefixItems": [{"type": "number"}, {"type": "number"}], "items": false, "minItems": 2, "maxItems": 2},
+			"manager": {"$ref": "#"}
+		},
+		"$defs": {
+			"com/example/Address": {
+				"type": "object",
+				"required": ["city", "zip"],
+				"additionalProperties": false,
+				"properties": {
+					"city": {"type": "string", "minLength": 1},
+					"zip": {"type": "string", "minLength": 5, "maxLength": 5, "pattern": "^[0-9]*$"}
+				}
+			}
+		}
+	}`, string(raw))
+}
+
+func (suite *IndexTestSuite) TestShapeJSONSchemaOfAnAlias() {
+	idx := suite.indexFromSource(nil, `namespace com/example
+shape Code string @length(3) @uppercase()`)
+	suite.Require().NoError(idx.Validate(suite.ctx))
+
+	schema, err := idx.ShapeJSONSchema("com/example", "Code")
+	suite.Require().NoError(err)
+	raw, err := json.Marshal(schema)
+	suite.Require().NoError(err)
+	suite.JSONEq(`{
+		"$schema": "https://json-schema.org/draft/2020-12/schema",
+		"title": "com/example/Code",
+		"type": "string",
+		"minLength": 3,
+		"maxLength": 3,
+		"x-sentrie-uppercase": []
+	}`, string(raw))
+}
+
+func (suite *IndexTestSuite) TestShapeJSONSchemaDescribesLiteralDefaults() {
+	idx := suite.indexFromSource(nil, `namespace com/example
+shape User {
+  role?: string default "guest"
+  level?: number default 1 + 1
+  score?: number default count(["a"])
+}`)
+	suite.Require().NoError(idx.Validate(suite.ctx))
+
+	schema, err := idx.ShapeJSONSchema("com/example", "User")
+	suite.Require().NoError(err)
+	properties := schema["properties"].(map[string]any)
+	suite.Equal("guest", properties["role"].(map[string]any)["default"])
+	// a default computed from literals is folded into one
+	suite.EqualValues(2, properties["level"].(map[string]any)["default"])
+	suite.NotContains(properties["score"].(map[string]any), "default")
+}
+
+func (suite *IndexTestSuite) TestShapeJSONSchemaUnknownShape() {
+	idx := suite.indexFromSource(nil, `namespace com/example
+shape Code string`)
+
+	_, err := idx.ShapeJSONSchema("com/example", "Missing")
+	suite.Require().Error(err)
+	suite.ErrorIs(err, xerr.NotFoundError{})
+}
+
+func (suite *IndexTestSuite) TestFactsJSONSchemaDescribesTheFactsOfAPolicy() {
+	idx := suite.indexFromSource(nil, `namespace com/example
+shape Entity {
+  id: string @uuid()
+}
+shape User with Entity {
+  role: string @one_of("admin", "viewer")
+}
+policy access {
+  fact user: User
+  fact subject: document
+  fact sensitive token: string @minlength(8) as apiToken
+  fact tries?: number @non_negative() default 0
+  require subject conforms Entity
+  rule allow = default false { yield user.role == "admin" and apiToken != "" and tries >= 0 and subject.id != "" }
+  export decision of allow
+}`)
+	view, err := idx.View(suite.ctx)
+	suite.Require().NoError(err)
+
+	schema, defs, err := view.FactsJSONSchema("com/example", "access", func(fqn string) string { return "#/components/schemas/" + fqn })
+	suite.Require().NoError(err)
+	raw, err := json.Marshal(schema)
+	suite.Require().NoError(err)
+	suite.JSONEq(`{
+		"type": "object",
+		"title": "com/example/access",
+		"required": ["apiToken", "subject", "user"],
+		"properties": {
+			"user": {"$ref": "#/components/schemas/com/example/User"},
+			"subject": {"allOf": [{"type": "object"}, {"$ref": "#/components/schemas/com/example/Entity"}]},
+			"apiToken": {"type": "string", "minLength": 8, "writeOnly": true},
+			"tries": {"type": "number", "minimum": 0, "default": 0}
+		}
+	}`, string(raw))
+
+	suite.Len(defs, 2)
+	raw, err = json.Marshal(defs["com/example/User"])
+	suite.Require().NoError(err)
+	suite.JSONEq(`{
+		"type": "object",
+		"required": ["id", "role"],
+		"properties": {
+			"id": {"type": "string", "format": "uuid"},
+			"role": {"type": "string", "enum": ["admin", "viewer"]}
+		}
+	}`, string(raw))
+}
+
+func (suite *IndexTestSuite) TestFactsJSONSchemaUnknownPolicy() {
+	idx := suite.indexFromSource(nil, viewTestSource)
+	view, err := idx.View(suite.ctx)
+	suite.Require().NoError(err)
+
+	_, _, err = view.FactsJSONSchema("com/example", "missing", func(fqn string) string { return fqn })
+	suite.ErrorIs(err, xerr.NotFoundError{})
+}
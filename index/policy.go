@@ -20,6 +20,8 @@ import (
 	"fmt"
 	"slices"
 	"strings"
+	"sync"
+	"sync/atomic"
 
 	"github.com/Masterminds/semver/v3"
 	"github.com/sentrie-sh/sentrie/ast"
@@ -27,8 +29,10 @@ import (
 )
 
 type RuleExportAttachment struct {
-	Name  string
-	Value ast.Expression
+	Name      string
+	Value     ast.Expression
+	Directive string      // ast.DirectiveObligation or ast.DirectiveAdvice, empty for a plain attachment
+	Type      ast.TypeRef // shape the value of a directive must conform to, if declared
 }
 
 // ExportedRule captures an exported rule's name and its attachment names.
@@ -39,8 +43,17 @@ type ExportedRule struct {
 
 // PolicyTagPair is one key/value from policy `tag` statements (order preserved in Policy.TagPairs).
 type PolicyTagPair struct {
-	Key   string
-	Value string
+	Key   string `json:"key"`
+	Value string `json:"value"`
+}
+
+// PolicyMetadata is the declared title, description, version and tags of a policy, as
+// attached to evaluation results for provenance. Undeclared fields are empty.
+type PolicyMetadata struct {
+	Title       string          `json:"title,omitempty"`
+	Description string          `json:"description,omitempty"`
+	Version     string          `json:"version,omitempty"`
+	Tags        []PolicyTagPair `json:"tags,omitempty"`
 }
 
 // Policy holds the AST statements and exports.
@@ -59,21 +72,69 @@ type Policy struct {
 	TagPairs       []PolicyTagPair
 	// TagsByKey is derived from TagPairs for query ergonomics; map iteration order is not stable.
 	TagsByKey map[string][]string
+	// Combining is how the decisions of the exported rules combine into one; empty when undeclared.
+	Combining CombiningAlgorithm
 
 	Lets        map[string]*ast.VarDeclaration
+	Derives     map[string]*ast.DeriveStatement
 	Facts       map[string]*ast.FactStatement
 	Rules       map[string]*Rule
 	RuleExports map[string]*ExportedRule
 	Uses        map[string]*ast.UseStatement // alias -> use statement
 	Shapes      map[string]*Shape            // policy-local shapes
+	// ShapeImports are the shapes imported from other namespaces by use statements, by alias
+	ShapeImports map[string]*ast.UseStatement
+	// Requires are the shape assertions on facts, checked in declaration order when evaluation starts.
+	Requires []*ast.RequireStatement
 
 	seenIdentifiers map[string]ast.Positionable
+	contentHash     string
+
+	// evaluations counts the evaluations of the policy, and compiled holds the forms of its
+	// expressions compiled by the runtime, by expression. Both live as long as the policy.
+	evaluations atomic.Int64
+	compiled    sync.Map
 }
 
 func (p *Policy) String() string {
 	return p.FQN.String()
 }
 
+// CountEvaluation records an evaluation of the policy and returns how many there have been.
+func (p *Policy) CountEvaluation() int64 {
+	return p.evaluations.Add(1)
+}
+
+// Evaluations returns how many evaluations of the policy have been recorded.
+func (p *Policy) Evaluations() int64 {
+	return p.evaluations.Load()
+}
+
+// Compiled returns the compiled form of expr, an expression of the policy, calling compile to
+// build it on first use. Concurrent first uses may each call compile; one result is kept.
+func (p *Policy) Compiled(expr ast.Expression, compile func() any) any {
+	if compiled, ok := p.compiled.Load(expr); ok {
+		return compiled
+	}
+	compiled, _ := p.compiled.LoadOrStore(expr, compile())
+	return compiled
+}
+
+// Metadata returns the declared metadata of the policy.
+func (p *Policy) Metadata() PolicyMetadata {
+	md := PolicyMetadata{
+		Version: strings.TrimSpace(p.VersionLiteral),
+		Tags:    slices.Clone(p.TagPairs),
+	}
+	if p.Title != nil {
+		md.Title = *p.Title
+	}
+	if p.Description != nil {
+		md.Description = *p.Description
+	}
+	return md
+}
+
 // latePolicyHeaderErr reports metadata, fact, or use after the policy body has started.
 func latePolicyHeaderErr(keyword, at string) error {
 	return fmt.Errorf("'%s' must appear before rules, exports, lets, and shapes at %s: %w", keyword, at, xerr.ErrIndex)
@@ -97,16 +158,18 @@ func createPolicy(ns *Namespace, policy *ast.PolicyStatement, program *ast.Progr
 		FilePath:        program.Reference,
 		Statements:      policy.Statements,
 		Lets:            make(map[string]*ast.VarDeclaration),
+		Derives:         make(map[string]*ast.DeriveStatement),
 		Facts:           make(map[string]*ast.FactStatement),
 		Rules:           make(map[string]*Rule),
 		RuleExports:     make(map[string]*ExportedRule),
 		Uses:            make(map[string]*ast.UseStatement),
 		Shapes:          make(map[string]*Shape),
+		ShapeImports:    make(map[string]*ast.UseStatement),
 		seenIdentifiers: make(map[string]ast.Positionable),
 	}
 
 	phase := policyPhaseMeta
-	var titleAt, descriptionAt, versionAt ast.Positionable
+	var titleAt, descriptionAt, versionAt, combiningAt ast.Positionable
 
 	for _, stmt := range policy.Statements {
 		if policyStmtKindOf(stmt) == policyStmtComment {
@@ -157,8 +220,8 @@ func createPolicy(ns *Namespace, policy *ast.PolicyStatement, program *ast.Progr
 				return nil, xerr.ErrConflict("policy version", stmt.Span(), versionAt.Span())
 			}
 			p.VersionLiteral = stmt.Literal
-			// SemVer is validated on TrimSpace(literal); VersionLiteral stays verbatim for display/diagnostics.
-			ver, err := semver.NewVersion(strings.TrimSpace(stmt.Literal))
+			// VersionLiteral stays verbatim for display/diagnostics.
+			ver, err := ParseVersion(stmt.Literal)
 			if err != nil {
 				return nil, fmt.Errorf("at %s: %w", stmt.Span(), xerr.ErrPolicyInvalidVersion)
 			}
@@ -178,6 +241,23 @@ func createPolicy(ns *Namespace, policy *ast.PolicyStatement, program *ast.Progr
 			}
 			p.TagPairs = append(p.TagPairs, PolicyTagPair{Key: key, Value: stmt.Value})
 
+		case *ast.CombiningStatement:
+			if phase != policyPhaseMeta {
+				if phase == policyPhaseBody {
+					return nil, latePolicyHeaderErr("combining", stmt.Span().String())
+				}
+				return nil, fmt.Errorf("at %s: %w", stmt.Span(), xerr.ErrPolicyMetadataContiguous)
+			}
+			if combiningAt != nil {
+				return nil, xerr.ErrConflict("policy combining", stmt.Span(), combiningAt.Span())
+			}
+			algorithm := CombiningAlgorithm(strings.TrimSpace(stmt.Algorithm))
+			if !algorithm.IsValid() {
+				return nil, fmt.Errorf("at %s: %w", stmt.Span(), xerr.ErrPolicyUnknownCombining)
+			}
+			p.Combining = algorithm
+			combiningAt = stmt
+
 		case *ast.FactStatement:
 			switch phase {
 			case policyPhaseMeta:
@@ -202,10 +282,9 @@ func createPolicy(ns *Namespace, policy *ast.PolicyStatement, program *ast.Progr
 			case policyPhaseBody:
 				return nil, latePolicyHeaderErr("use", stmt.Span().String())
 			}
-			if _, ok := p.Uses[stmt.As]; ok {
-				return nil, fmt.Errorf("cannot rebind to existing alias '%s' at %s: %w", stmt.As, stmt.Span(), xerr.ErrIndex)
+			if err := p.AddUse(stmt); err != nil {
+				return nil, err
 			}
-			p.Uses[stmt.As] = stmt
 
 		case *ast.VarDeclaration:
 			if phase != policyPhaseBody {
@@ -215,6 +294,14 @@ func createPolicy(ns *Namespace, policy *ast.PolicyStatement, program *ast.Progr
 				return nil, err
 			}
 
+		case *ast.DeriveStatement:
+			if phase != policyPhaseBody {
+				phase = policyPhaseBody
+			}
+			if err := p.AddDerive(stmt); err != nil {
+				return nil, err
+			}
+
 		case *ast.RuleStatement:
 			if phase != policyPhaseBody {
 				phase = policyPhaseBody
@@ -245,7 +332,7 @@ func createPolicy(ns *Namespace, policy *ast.PolicyStatement, program *ast.Progr
 					return nil, xerr.ErrConflict("rule export attachment", a.Span(), att[exists].Value.Span())
 				}
 
-				att = append(att, &RuleExportAttachment{Name: a.What, Value: a.As})
+				att = append(att, &RuleExportAttachment{Name: a.What, Value: a.As, Directive: a.Directive, Type: a.Type})
 			}
 
 			p.RuleExports[stmt.Of] = &ExportedRule{RuleName: stmt.Of, Attachments: att}
@@ -258,6 +345,14 @@ func createPolicy(ns *Namespace, policy *ast.PolicyStatement, program *ast.Progr
 				return nil, err
 			}
 
+		case *ast.RequireStatement:
+			if phase != policyPhaseBody {
+				phase = policyPhaseBody
+			}
+			if err := p.AddRequire(stmt); err != nil {
+				return nil, err
+			}
+
 		default:
 			return nil, fmt.Errorf("unsupported statement in policy at %s: %w", stmt.Span(), xerr.ErrIndex)
 		}
@@ -282,6 +377,17 @@ func (p *Policy) AddLet(let *ast.VarDeclaration) error {
 	return nil
 }
 
+// AddDerive binds a derive to its name, which shares the policy's identifier space.
+func (p *Policy) AddDerive(derive *ast.DeriveStatement) error {
+	if seen, ok := p.seenIdentifiers[derive.Name]; ok {
+		return xerr.ErrConflict("derive declaration", derive.Span(), seen.Span())
+	}
+
+	p.Derives[derive.Name] = derive
+	p.seenIdentifiers[derive.Name] = derive
+	return nil
+}
+
 func (p *Policy) AddRule(rule *ast.RuleStatement) error {
 	r, err := createRule(p, rule)
 	if err != nil {
@@ -298,10 +404,46 @@ func (p *Policy) AddRule(rule *ast.RuleStatement) error {
 	return nil
 }
 
+// AddUse binds a use statement to its alias. Importing the same module under
+// different aliases is allowed; reusing an alias is not. The alias of a module shares the
+// policy's identifier space, so it may not also name a fact, let, rule or shape. The alias
+// of a shape import is a shape name, so it may not also name a shape.
+func (p *Policy) AddUse(use *ast.UseStatement) error {
+	if seen, ok := p.Uses[use.As]; ok {
+		return xerr.ErrConflict("use alias", use.Span(), seen.Span())
+	}
+	if seen, ok := p.ShapeImports[use.As]; ok {
+		return xerr.ErrConflict("use alias", use.Span(), seen.Span())
+	}
+	if use.IsShapeImport() {
+		if seen, ok := p.Shapes[use.As]; ok {
+			return xerr.ErrConflict("use alias", use.Span(), seen.Span())
+		}
+		p.ShapeImports[use.As] = use
+		return nil
+	}
+	if seen, ok := p.seenIdentifiers[use.As]; ok {
+		return xerr.ErrConflict("use alias", use.Span(), seen.Span())
+	}
+	if seen, ok := p.Shapes[use.As]; ok {
+		return xerr.ErrConflict("use alias", use.Span(), seen.Span())
+	}
+
+	p.Uses[use.As] = use
+	p.seenIdentifiers[use.As] = use
+	return nil
+}
+
 func (p *Policy) AddShape(shape *ast.ShapeStatement) error {
 	if seen, ok := p.Shapes[shape.Name]; ok {
 		return xerr.ErrConflict("shape declaration", shape.Span(), seen.Span())
 	}
+	if seen, ok := p.Uses[shape.Name]; ok {
+		return xerr.ErrConflict("shape declaration", shape.Span(), seen.Span())
+	}
+	if seen, ok := p.ShapeImports[shape.Name]; ok {
+		return xerr.ErrConflict("shape declaration", shape.Span(), seen.Span())
+	}
 
 	s, err := createShape(p.Namespace, p, shape)
 	if err != nil {
@@ -312,6 +454,16 @@ func (p *Policy) AddShape(shape *ast.ShapeStatement) error {
 	return nil
 }
 
+// AddRequire records a shape assertion. The fact must be declared by the policy; the shape is
+// resolved when the index is validated.
+func (p *Policy) AddRequire(req *ast.RequireStatement) error {
+	if _, ok := p.Facts[req.Fact]; !ok {
+		return fmt.Errorf("require of undeclared fact '%s' at %s: %w", req.Fact, req.Span(), xerr.ErrIndex)
+	}
+	p.Requires = append(p.Requires, req)
+	return nil
+}
+
 func (p *Policy) AddFact(fact *ast.FactStatement) error {
 	if seen, ok := p.seenIdentifiers[fact.Alias]; ok {
 		return xerr.ErrConflict("fact declaration", fact.Span(), seen.Span())
@@ -326,3 +478,10 @@ func (p *Policy) AddFact(fact *ast.FactStatement) error {
 	p.seenIdentifiers[fact.Alias] = fact
 	return nil
 }
+
+// ParseVersion parses s as a semantic version, as the version header of a policy and the
+// @semver() string constraint do. Parsing is lenient: surrounding spaces are ignored, and a "v"
+// prefix and the partial "1.2" are accepted.
+func ParseVersion(s string) (*semver.Version, error) {
+	return semver.NewVersion(strings.TrimSpace(s))
+}
@@ -38,13 +38,13 @@ func policyStmtKindOf(stmt ast.Statement) policyStmtKind {
 	switch stmt.(type) {
 	case *ast.CommentStatement:
 		return policyStmtComment
-	case *ast.TitleStatement, *ast.DescriptionStatement, *ast.VersionStatement, *ast.TagStatement:
+	case *ast.TitleStatement, *ast.DescriptionStatement, *ast.VersionStatement, *ast.TagStatement, *ast.CombiningStatement:
 		return policyStmtMetadata
 	case *ast.FactStatement:
 		return policyStmtFact
 	case *ast.UseStatement:
 		return policyStmtUse
-	case *ast.VarDeclaration, *ast.RuleStatement, *ast.RuleExportStatement, *ast.ShapeStatement:
+	case *ast.VarDeclaration, *ast.DeriveStatement, *ast.RuleStatement, *ast.RuleExportStatement, *ast.ShapeStatement, *ast.RequireStatement:
 		return policyStmtBody
 	default:
 		return policyStmtUnknown
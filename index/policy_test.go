@@ -18,8 +18,10 @@ package index
 
 import (
 	"github.com/sentrie-sh/sentrie/ast"
+	"github.com/sentrie-sh/sentrie/parser"
 	"github.com/sentrie-sh/sentrie/tokens"
 	"github.com/sentrie-sh/sentrie/trinary"
+	"github.com/sentrie-sh/sentrie/xerr"
 	"github.com/stretchr/testify/require"
 )
 
@@ -716,6 +718,37 @@ func (suite *IndexTestSuite) TestCreatePolicyWithComments() {
 	// Comments should be ignored
 }
 
+func (suite *IndexTestSuite) TestCreatePolicyWithBlockComments() {
+	src := `namespace com/example
+policy testPolicy {
+  /* facts */
+  fact user: string
+  /*
+  rule deny = default false { /* nested */ yield true }
+  */
+  rule allow = default true { yield true }
+  export decision of allow
+}
+`
+	program, err := parser.NewParserFromString(src, "test.sentra").ParseProgram(suite.ctx)
+	suite.Require().NoError(err)
+
+	var policyStmt *ast.PolicyStatement
+	for _, stmt := range program.Statements {
+		if ps, ok := stmt.(*ast.PolicyStatement); ok {
+			policyStmt = ps
+		}
+	}
+	suite.Require().NotNil(policyStmt)
+
+	policy, err := createPolicy(suite.policyNs, policyStmt, program)
+	suite.NoError(err)
+	suite.Len(policy.Facts, 1)
+	suite.Len(policy.Rules, 1)
+	suite.Contains(policy.Rules, "allow")
+	suite.Len(policy.RuleExports, 1)
+}
+
 func (suite *IndexTestSuite) TestCreatePolicyWithValidUseStatement() {
 	policyStmt := ast.NewPolicyStatement(
 		"testPolicy",
@@ -790,6 +823,55 @@ func (suite *IndexTestSuite) TestCreatePolicyWithMetadataAndPhases() {
 	suite.Equal([]string{"  \t  "}, policy.TagsByKey["b"])
 }
 
+func (suite *IndexTestSuite) TestPolicyMetadataReturnsDeclaredMetadata() {
+	r := func(line int) tokens.Range {
+		return tokens.Range{File: "test.sentra", From: tokens.Pos{Line: line, Column: 0, Offset: 0}, To: tokens.Pos{Line: line, Column: 1, Offset: 1}}
+	}
+	policyStmt := ast.NewPolicyStatement(
+		"p",
+		[]ast.Statement{
+			ast.NewTitleStatement("  Access  ", r(3)),
+			ast.NewDescriptionStatement("Who may read", r(4)),
+			ast.NewVersionStatement(" 2.0.1 ", r(5)),
+			ast.NewTagStatement("owner", "iam", r(6)),
+			ast.NewTagStatement("tier", "1", r(7)),
+			ast.NewRuleStatement("allow", nil, ast.NewTrinaryLiteral(trinary.True, r(8)), nil, r(8)),
+			ast.NewRuleExportStatement("allow", []*ast.AttachmentClause{}, r(9)),
+		},
+		r(2),
+	)
+	program := &ast.Program{Reference: "test.sentra", Statements: []ast.Statement{policyStmt}}
+	policy, err := createPolicy(suite.policyNs, policyStmt, program)
+	suite.Require().NoError(err)
+
+	suite.Equal(PolicyMetadata{
+		Title:       "Access",
+		Description: "Who may read",
+		Version:     "2.0.1",
+		Tags:        []PolicyTagPair{{Key: "owner", Value: "iam"}, {Key: "tier", Value: "1"}},
+	}, policy.Metadata())
+
+	// the accessor hands out a copy of the tags
+	md := policy.Metadata()
+	md.Tags[0].Value = "changed"
+	suite.Equal("iam", policy.TagPairs[0].Value)
+}
+
+func (suite *IndexTestSuite) TestPolicyMetadataEmptyWhenUndeclared() {
+	r := tokens.Range{File: "test.sentra"}
+	policyStmt := ast.NewPolicyStatement(
+		"p",
+		[]ast.Statement{
+			ast.NewRuleStatement("allow", nil, ast.NewTrinaryLiteral(trinary.True, r), nil, r),
+			ast.NewRuleExportStatement("allow", []*ast.AttachmentClause{}, r),
+		},
+		r,
+	)
+	policy, err := createPolicy(suite.policyNs, policyStmt, &ast.Program{Reference: "test.sentra"})
+	suite.Require().NoError(err)
+	suite.Equal(PolicyMetadata{}, policy.Metadata())
+}
+
 func (suite *IndexTestSuite) TestCreatePolicyUseWithoutFacts() {
 	r := func(line int) tokens.Range {
 		return tokens.Range{File: "test.sentra", From: tokens.Pos{Line: line, Column: 0, Offset: 0}, To: tokens.Pos{Line: line, Column: 1, Offset: 1}}
@@ -868,6 +950,34 @@ func (suite *IndexTestSuite) TestCreatePolicyVersionVPrefixAccepted() {
 	suite.NotNil(policy.Version)
 }
 
+// unlike the @semver() string constraint, a version header accepts a partial version
+func (suite *IndexTestSuite) TestCreatePolicyVersionPartialAccepted() {
+	r := func(line int) tokens.Range {
+		return tokens.Range{File: "test.sentra", From: tokens.Pos{Line: line, Column: 0, Offset: 0}, To: tokens.Pos{Line: line, Column: 1, Offset: 1}}
+	}
+	policyStmt := ast.NewPolicyStatement(
+		"p",
+		[]ast.Statement{
+			ast.NewVersionStatement("1.2", r(3)),
+			ast.NewFactStatement("user", ast.NewStringTypeRef(r(4)), "user", nil, true, r(4)),
+			ast.NewRuleStatement("allow", nil, ast.NewTrinaryLiteral(trinary.True, r(5)), nil, r(5)),
+			ast.NewRuleExportStatement("allow", []*ast.AttachmentClause{}, r(6)),
+		},
+		r(2),
+	)
+	program := &ast.Program{
+		Reference: "test.sentra",
+		Statements: []ast.Statement{
+			ast.NewNamespaceStatement(ast.NewFQN([]string{"com", "example"}, r(1)), r(1)),
+			policyStmt,
+		},
+	}
+	policy, err := createPolicy(suite.policyNs, policyStmt, program)
+	suite.Require().NoError(err)
+	suite.Equal("1.2", policy.VersionLiteral)
+	suite.Equal("1.2.0", policy.Version.String())
+}
+
 func (suite *IndexTestSuite) TestCreatePolicyVersionWhitespacePaddedLiteralAccepted() {
 	r := func(line int) tokens.Range {
 		return tokens.Range{File: "test.sentra", From: tokens.Pos{Line: line, Column: 0, Offset: 0}, To: tokens.Pos{Line: line, Column: 1, Offset: 1}}
@@ -1139,7 +1249,118 @@ func (suite *IndexTestSuite) TestCreatePolicyDuplicateUseAliasRebind() {
 	}
 	_, err := createPolicy(suite.policyNs, policyStmt, program)
 	suite.Error(err)
-	suite.Contains(err.Error(), "cannot rebind to existing alias")
+	suite.ErrorAs(err, &xerr.ConflictError{})
+	suite.Contains(err.Error(), "conflict: use alias at "+u2.Span().String()+" with "+u1.Span().String())
+}
+
+func (suite *IndexTestSuite) TestCreatePolicySameUseTargetDifferentAliases() {
+	r := func(line int) tokens.Range {
+		return tokens.Range{File: "test.sentra", From: tokens.Pos{Line: line, Column: 0, Offset: 0}, To: tokens.Pos{Line: line, Column: 1, Offset: 1}}
+	}
+	policyStmt := ast.NewPolicyStatement(
+		"p",
+		[]ast.Statement{
+			ast.NewFactStatement("user", ast.NewStringTypeRef(r(3)), "user", nil, true, r(3)),
+			ast.NewUseStatement([]string{"a"}, "./a.ts", nil, "lib", r(4)),
+			ast.NewUseStatement([]string{"a"}, "./a.ts", nil, "other", r(5)),
+			ast.NewRuleStatement("allow", nil, ast.NewTrinaryLiteral(trinary.True, r(6)), nil, r(6)),
+			ast.NewRuleExportStatement("allow", []*ast.AttachmentClause{}, r(7)),
+		},
+		r(2),
+	)
+	program := &ast.Program{
+		Reference: "test.sentra",
+		Statements: []ast.Statement{
+			ast.NewNamespaceStatement(ast.NewFQN([]string{"com", "example"}, r(1)), r(1)),
+			policyStmt,
+		},
+	}
+	p, err := createPolicy(suite.policyNs, policyStmt, program)
+	suite.Require().NoError(err)
+	suite.Len(p.Uses, 2)
+	suite.Contains(p.Uses, "lib")
+	suite.Contains(p.Uses, "other")
+}
+
+func (suite *IndexTestSuite) TestCreatePolicyFactAliasCollidesWithUseAlias() {
+	r := func(line int) tokens.Range {
+		return tokens.Range{File: "test.sentra", From: tokens.Pos{Line: line, Column: 0, Offset: 0}, To: tokens.Pos{Line: line, Column: 1, Offset: 1}}
+	}
+	fact := ast.NewFactStatement("lib", ast.NewStringTypeRef(r(3)), "lib", nil, true, r(3))
+	use := ast.NewUseStatement([]string{"a"}, "./a.ts", nil, "lib", r(4))
+	policyStmt := ast.NewPolicyStatement(
+		"p",
+		[]ast.Statement{
+			fact,
+			use,
+			ast.NewRuleStatement("allow", nil, ast.NewTrinaryLiteral(trinary.True, r(6)), nil, r(6)),
+			ast.NewRuleExportStatement("allow", []*ast.AttachmentClause{}, r(7)),
+		},
+		r(2),
+	)
+	program := &ast.Program{
+		Reference: "test.sentra",
+		Statements: []ast.Statement{
+			ast.NewNamespaceStatement(ast.NewFQN([]string{"com", "example"}, r(1)), r(1)),
+			policyStmt,
+		},
+	}
+	_, err := createPolicy(suite.policyNs, policyStmt, program)
+	suite.Error(err)
+	suite.ErrorAs(err, &xerr.ConflictError{})
+	suite.Contains(err.Error(), "conflict: use alias at "+use.Span().String()+" with "+fact.Span().String())
+}
+
+func (suite *IndexTestSuite) TestCreatePolicyLetAndShapeCollideWithUseAlias() {
+	r := func(line int) tokens.Range {
+		return tokens.Range{File: "test.sentra", From: tokens.Pos{Line: line, Column: 0, Offset: 0}, To: tokens.Pos{Line: line, Column: 1, Offset: 1}}
+	}
+	build := func(decl ast.Statement) *ast.PolicyStatement {
+		return ast.NewPolicyStatement(
+			"p",
+			[]ast.Statement{
+				ast.NewUseStatement([]string{"a"}, "./a.ts", nil, "lib", r(4)),
+				decl,
+				ast.NewRuleStatement("allow", nil, ast.NewTrinaryLiteral(trinary.True, r(6)), nil, r(6)),
+				ast.NewRuleExportStatement("allow", []*ast.AttachmentClause{}, r(7)),
+			},
+			r(2),
+		)
+	}
+	program := &ast.Program{Reference: "test.sentra"}
+
+	let := ast.NewVarDeclaration("lib", nil, ast.NewIntegerLiteral(1, r(5)), r(5))
+	_, err := createPolicy(suite.policyNs, build(let), program)
+	suite.ErrorAs(err, &xerr.ConflictError{})
+	suite.Contains(err.Error(), "conflict: let declaration at "+let.Span().String()+" with "+r(4).String())
+
+	shape := ast.NewShapeStatement("lib", ast.NewStringTypeRef(r(5)), nil, r(5))
+	_, err = createPolicy(suite.policyNs, build(shape), program)
+	suite.ErrorAs(err, &xerr.ConflictError{})
+	suite.Contains(err.Error(), "conflict: shape declaration at "+shape.Span().String()+" with "+r(4).String())
+}
+
+func (suite *IndexTestSuite) TestCreatePolicyUseAliasDistinctFromFacts() {
+	r := func(line int) tokens.Range {
+		return tokens.Range{File: "test.sentra", From: tokens.Pos{Line: line, Column: 0, Offset: 0}, To: tokens.Pos{Line: line, Column: 1, Offset: 1}}
+	}
+	policyStmt := ast.NewPolicyStatement(
+		"p",
+		[]ast.Statement{
+			ast.NewFactStatement("user", ast.NewStringTypeRef(r(3)), "user", nil, true, r(3)),
+			ast.NewUseStatement([]string{"a"}, "./a.ts", nil, "lib", r(4)),
+			ast.NewVarDeclaration("limit", nil, ast.NewIntegerLiteral(1, r(5)), r(5)),
+			ast.NewRuleStatement("allow", nil, ast.NewTrinaryLiteral(trinary.True, r(6)), nil, r(6)),
+			ast.NewRuleExportStatement("allow", []*ast.AttachmentClause{}, r(7)),
+		},
+		r(2),
+	)
+	program := &ast.Program{Reference: "test.sentra"}
+	p, err := createPolicy(suite.policyNs, policyStmt, program)
+	suite.Require().NoError(err)
+	suite.Contains(p.Uses, "lib")
+	suite.Contains(p.Facts, "user")
+	suite.Contains(p.Lets, "limit")
 }
 
 func (suite *IndexTestSuite) TestCreatePolicyDuplicateRuleExportAttachmentName() {
@@ -1618,3 +1839,121 @@ func (suite *IndexTestSuite) TestCreatePolicyDuplicateRuleName() {
 	suite.Error(err)
 	suite.Contains(err.Error(), "conflict: rule declaration")
 }
+
+func (suite *IndexTestSuite) createPolicyWithHeader(header ...ast.Statement) (*Policy, error) {
+	r := func(line int) tokens.Range {
+		return tokens.Range{File: "test.sentra", From: tokens.Pos{Line: line, Column: 0, Offset: 0}, To: tokens.Pos{Line: line, Column: 1, Offset: 1}}
+	}
+	policyStmt := ast.NewPolicyStatement(
+		"p",
+		append(header,
+			ast.NewFactStatement("user", ast.NewStringTypeRef(r(10)), "user", nil, true, r(10)),
+			ast.NewRuleStatement("allow", nil, ast.NewTrinaryLiteral(trinary.True, r(11)), nil, r(11)),
+			ast.NewRuleExportStatement("allow", []*ast.AttachmentClause{}, r(12)),
+		),
+		r(2),
+	)
+	program := &ast.Program{
+		Reference: "test.sentra",
+		Statements: []ast.Statement{
+			ast.NewNamespaceStatement(ast.NewFQN([]string{"com", "example"}, r(1)), r(1)),
+			policyStmt,
+		},
+	}
+	return createPolicy(suite.policyNs, policyStmt, program)
+}
+
+func (suite *IndexTestSuite) TestCreatePolicyCombining() {
+	r := tokens.Range{File: "test.sentra", From: tokens.Pos{Line: 3}, To: tokens.Pos{Line: 3, Column: 1, Offset: 1}}
+	for _, algorithm := range []CombiningAlgorithm{CombiningDenyOverrides, CombiningPermitOverrides, CombiningFirstApplicable} {
+		p, err := suite.createPolicyWithHeader(
+			ast.NewTitleStatement("Access", r),
+			ast.NewCombiningStatement(string(algorithm), r),
+		)
+		suite.Require().NoError(err)
+		suite.Equal(algorithm, p.Combining)
+	}
+
+	p, err := suite.createPolicyWithHeader()
+	suite.Require().NoError(err)
+	suite.Equal(CombiningNone, p.Combining)
+}
+
+func (suite *IndexTestSuite) TestCreatePolicyUnknownCombining() {
+	r := tokens.Range{File: "test.sentra", From: tokens.Pos{Line: 3}, To: tokens.Pos{Line: 3, Column: 1, Offset: 1}}
+	_, err := suite.createPolicyWithHeader(ast.NewCombiningStatement("majority", r))
+	suite.Require().Error(err)
+	suite.ErrorIs(err, xerr.ErrPolicyUnknownCombining)
+}
+
+func (suite *IndexTestSuite) TestCreatePolicyDuplicateCombining() {
+	r := func(line int) tokens.Range {
+		return tokens.Range{File: "test.sentra", From: tokens.Pos{Line: line, Column: 0, Offset: 0}, To: tokens.Pos{Line: line, Column: 1, Offset: 1}}
+	}
+	_, err := suite.createPolicyWithHeader(
+		ast.NewCombiningStatement("deny-overrides", r(3)),
+		ast.NewCombiningStatement("permit-overrides", r(4)),
+	)
+	suite.Require().Error(err)
+	suite.Contains(err.Error(), "conflict: policy combining")
+}
+
+func (suite *IndexTestSuite) TestCreatePolicyCombiningAfterFact() {
+	src := `namespace com/example
+policy p {
+  fact user: string
+  combining "deny-overrides"
+  rule allow = { yield true }
+  export decision of allow
+}`
+	program, err := parser.NewParserFromString(src, "test.sentra").ParseProgram(suite.ctx)
+	suite.Require().NoError(err)
+	err = CreateIndex().AddProgram(suite.ctx, program)
+	suite.Require().Error(err)
+	suite.ErrorIs(err, xerr.ErrPolicyMetadataContiguous)
+}
+
+func (suite *IndexTestSuite) TestCreatePolicyRuleEffects() {
+	idx := suite.indexFromSource(nil, `namespace com/example
+policy p {
+  fact user: string
+  rule allow = permit { yield user == "admin" }
+  rule block = deny default false when user == "mallory" { yield true }
+  rule plain = { yield true }
+  export decision of allow
+  export decision of block
+  export decision of plain
+}`)
+	p, err := idx.ResolvePolicy("com/example", "p")
+	suite.Require().NoError(err)
+	suite.Equal(RuleEffectPermit, p.Rules["allow"].Effect)
+	suite.Equal(RuleEffectDeny, p.Rules["block"].Effect)
+	suite.Equal(RuleEffectNone, p.Rules["plain"].Effect)
+}
+
+func (suite *IndexTestSuite) TestCreatePolicyRuleConflictingEffects() {
+	src := `namespace com/example
+policy p {
+  rule allow = permit deny { yield true }
+  export decision of allow
+}`
+	program, err := parser.NewParserFromString(src, "test.sentra").ParseProgram(suite.ctx)
+	suite.Require().NoError(err)
+	err = CreateIndex().AddProgram(suite.ctx, program)
+	suite.Require().Error(err)
+	suite.Contains(err.Error(), "conflict: rule effect")
+}
+
+func (suite *IndexTestSuite) TestCreatePolicyRuleRepeatedEffect() {
+	src := `namespace com/example
+policy p {
+  rule allow = permit permit { yield true }
+  export decision of allow
+}`
+	program, err := parser.NewParserFromString(src, "test.sentra").ParseProgram(suite.ctx)
+	suite.Require().NoError(err)
+	err = CreateIndex().AddProgram(suite.ctx, program)
+	suite.Require().Error(err)
+	suite.ErrorAs(err, &xerr.ConflictError{})
+	suite.Contains(err.Error(), "conflict: rule effect at test.sentra:3:")
+}
@@ -0,0 +1,211 @@
+// SPDX-FileCopyrightText: © 2026 Binaek Sarkar <binaek89@gmail.com>
+// SPDX-License-Identifier: Apache-2.0
+
+package index
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+	"sync"
+	"sync/atomic"
+
+	"github.com/sentrie-sh/sentrie/ast"
+	"github.com/sentrie-sh/sentrie/xerr"
+)
+
+// ReplaceProgram returns a new committed index in which astProgram takes the place of the
+// program with the same reference, or is added when there is none, as when one file of a
+// watched pack changes. idx must be committed and is left as it is, so that views of it keep
+// serving while the new index is built.
+//
+// Only the namespaces of the replaced and the new program are rebuilt, together with the
+// namespaces depending on them: those referring to or composing their shapes, or importing
+// decisions from their policies. The policies and shapes of every other namespace are carried
+// over as they are, already validated, hydrated and hashed. Checks spanning namespaces, such as
+// import and composition cycles, still run over the whole index.
+func (idx *Index) ReplaceProgram(ctx context.Context, astProgram *ast.Program) (*Index, error) {
+	if atomic.LoadUint32(&idx.committed) == 0 || idx.commitError != nil {
+		return nil, fmt.Errorf("cannot replace program %s in an index that is not committed: %w", astProgram.Reference, xerr.ErrIndex)
+	}
+
+	idx.theLock.RLock()
+	defer idx.theLock.RUnlock()
+
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+	if err := prepareProgram(astProgram); err != nil {
+		return nil, err
+	}
+	program := createProgram(astProgram)
+
+	next := &Index{
+		theLock:               &sync.RWMutex{},
+		Pack:                  idx.Pack,
+		Namespaces:            snapshotNamespaces(idx.Namespaces),
+		Programs:              maps.Clone(idx.Programs),
+		validationOnce:        &sync.Once{},
+		commitOnce:            &sync.Once{},
+		requireFacts:          idx.requireFacts,
+		shadowedFieldWarnings: idx.shadowedFieldWarnings,
+	}
+	next.Programs[astProgram.Reference] = program
+
+	changed := map[string]struct{}{program.Namespace.String(): {}}
+	if old, ok := idx.Programs[astProgram.Reference]; ok {
+		changed[old.Namespace.String()] = struct{}{}
+	}
+	next.scope = idx.dependentNamespaces(changed, program)
+
+	for name := range next.scope {
+		next.dropNamespace(name)
+	}
+	for _, reference := range slices.Sorted(maps.Keys(next.Programs)) {
+		p := next.Programs[reference]
+		if _, ok := next.scope[p.Namespace.String()]; !ok {
+			continue
+		}
+		if err := next.addProgram(ctx, p); err != nil {
+			return nil, err
+		}
+	}
+
+	// the warnings of the namespaces carried over are not collected again
+	for _, warning := range idx.warnings {
+		if p, ok := next.Programs[warning.Range.File]; ok {
+			if _, rebuilt := next.scope[p.Namespace.String()]; rebuilt {
+				continue
+			}
+		}
+		next.warnings = append(next.warnings, warning)
+	}
+
+	if err := next.Commit(ctx); err != nil {
+		return nil, err
+	}
+	return next, nil
+}
+
+// inScope reports whether ns is checked on its own when the index is validated: every
+// namespace, except those an index built by ReplaceProgram carries over.
+func (idx *Index) inScope(ns *Namespace) bool {
+	if idx.scope == nil {
+		return true
+	}
+	_, ok := idx.scope[ns.FQN.String()]
+	return ok
+}
+
+// dropNamespace removes the namespace called name, and its links to its parent and children.
+// The namespaces must be a snapshot of those of another index, which are not changed.
+func (idx *Index) dropNamespace(name string) {
+	ns, ok := idx.Namespaces[name]
+	if !ok {
+		return
+	}
+	if ns.Parent != nil {
+		ns.Parent.Children = slices.DeleteFunc(ns.Parent.Children, func(child *Namespace) bool { return child == ns })
+	}
+	for _, child := range ns.Children {
+		child.Parent = nil
+	}
+	delete(idx.Namespaces, name)
+}
+
+// dependentNamespaces returns the namespaces called names, together with every namespace of
+// idx whose shapes or policies depend on one of them, directly or through other namespaces.
+// Besides the shapes that resolve, a shape composing one by a name that the namespaces or
+// program declare is taken to depend on them, as a composition that does not resolve in its
+// own namespace falls back to the shapes exported by the others.
+func (idx *Index) dependentNamespaces(names map[string]struct{}, program *Program) map[string]struct{} {
+	dependents := maps.Clone(names)
+
+	declared := map[string]struct{}{}
+	for _, shape := range program.Shapes {
+		declared[shape.Name] = struct{}{}
+	}
+	for name := range names {
+		if ns, ok := idx.Namespaces[name]; ok {
+			for shapeName := range ns.Shapes {
+				declared[shapeName] = struct{}{}
+			}
+		}
+	}
+
+	for grown := true; grown; {
+		grown = false
+		for name, ns := range idx.Namespaces {
+			if _, ok := dependents[name]; ok {
+				continue
+			}
+			if idx.namespaceDependsOn(ns, dependents, declared) {
+				dependents[name] = struct{}{}
+				grown = true
+			}
+		}
+	}
+	return dependents
+}
+
+// namespaceDependsOn reports whether a shape or policy of ns depends on one of the namespaces
+// called names, or composes a shape by one of the declared names.
+func (idx *Index) namespaceDependsOn(ns *Namespace, names, declared map[string]struct{}) bool {
+	in := func(shapes map[string]*Shape) bool {
+		for _, shape := range shapes {
+			if _, ok := names[shape.Namespace.FQN.String()]; ok {
+				return true
+			}
+		}
+		return false
+	}
+	composesDeclared := func(shapes map[string]*Shape) bool {
+		for _, shape := range shapes {
+			if shape.Model != nil && shape.Model.WithFQN != nil && !shape.Model.WithFQN.IsEmpty() {
+				if _, ok := declared[shape.Model.WithFQN.LastSegment()]; ok {
+					return true
+				}
+			}
+		}
+		return false
+	}
+
+	if composesDeclared(ns.Shapes) {
+		return true
+	}
+	for _, shape := range ns.Shapes {
+		referred := map[string]*Shape{}
+		idx.collectShapes(ns, nil, shape.Statement, referred)
+		if in(referred) {
+			return true
+		}
+	}
+
+	for _, policy := range ns.Policies {
+		if composesDeclared(policy.Shapes) {
+			return true
+		}
+		referred := map[string]*Shape{}
+		idx.collectShapes(ns, policy, policy.Statement, referred)
+		if in(referred) {
+			return true
+		}
+		for _, use := range policy.ShapeImports {
+			if _, ok := names[use.ShapeFrom.String()]; ok {
+				return true
+			}
+		}
+		for _, rule := range policy.Rules {
+			importClause, ok := rule.Body.(*ast.ImportClause)
+			if !ok {
+				continue
+			}
+			target, _ := importTarget(policy, importClause)
+			if _, ok := names[target]; ok {
+				return true
+			}
+		}
+	}
+	return false
+}
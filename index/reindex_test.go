@@ -0,0 +1,196 @@
+// SPDX-FileCopyrightText: © 2026 Binaek Sarkar <binaek89@gmail.com>
+// SPDX-License-Identifier: Apache-2.0
+
+package index
+
+import (
+	"github.com/sentrie-sh/sentrie/parser"
+	"github.com/sentrie-sh/sentrie/xerr"
+)
+
+// reindexSources are the programs of the index TestReplaceProgram* replace one of: a namespace
+// of shapes, a policy requiring one of them, a policy importing a decision of that one, and an
+// unrelated namespace.
+var reindexSources = []string{
+	`namespace com/example/shared
+shape UserShape { name: string role: string }
+export shape UserShape
+`,
+	`namespace com/example/app
+policy auth {
+  fact user: document
+  use UserShape from com/example/shared as U
+  require user conforms U
+  rule allow = default false { yield user.role == "admin" }
+  export decision of allow
+}`,
+	`namespace com/example/app/gate
+policy gate {
+  fact user: document
+  rule allow = import decision allow from com/example/app/auth with user as user
+  export decision of allow
+}`,
+	`namespace com/other
+shape Item { id: number }
+policy misc {
+  fact item: Item
+  rule answer = default 0 { yield item.id }
+  export decision of answer
+}`,
+}
+
+// replaceProgram parses src as the program called reference and replaces it in idx.
+func (suite *IndexTestSuite) replaceProgram(idx *Index, reference, src string) (*Index, error) {
+	program, err := parser.NewParserFromString(src, reference).ParseProgram(suite.ctx)
+	suite.Require().NoError(err)
+	return idx.ReplaceProgram(suite.ctx, program)
+}
+
+func (suite *IndexTestSuite) TestReplaceProgramLeavesUnrelatedNamespacesUntouched() {
+	idx := suite.indexFromSource(nil, reindexSources...)
+	suite.Require().NoError(idx.Commit(suite.ctx))
+	before, err := idx.View(suite.ctx)
+	suite.Require().NoError(err)
+
+	misc := idx.Namespaces["com/other"].Policies["misc"]
+	item := idx.Namespaces["com/other"].Shapes["Item"]
+	gate := idx.Namespaces["com/example/app/gate"].Policies["gate"]
+	hashes := map[string]string{"misc": misc.ContentHash(), "gate": gate.ContentHash()}
+
+	next, err := suite.replaceProgram(idx, "testb.sentrie", `namespace com/example/app
+policy auth {
+  fact user: document
+  use UserShape from com/example/shared as U
+  require user conforms U
+  rule allow = default false { yield user.role == "root" }
+  export decision of allow
+}`)
+	suite.Require().NoError(err)
+
+	// the unrelated namespace is carried over as it was committed
+	suite.Same(misc, next.Namespaces["com/other"].Policies["misc"])
+	suite.Same(item, next.Namespaces["com/other"].Shapes["Item"])
+	suite.Equal(hashes["misc"], misc.ContentHash())
+
+	// the replaced namespace and the one importing from it are rebuilt
+	auth := next.Namespaces["com/example/app"].Policies["auth"]
+	suite.Contains(auth.Rules["allow"].Body.String(), `"root"`)
+	rebuiltGate := next.Namespaces["com/example/app/gate"].Policies["gate"]
+	suite.NotSame(gate, rebuiltGate)
+	suite.NotEqual(hashes["gate"], rebuiltGate.ContentHash())
+	suite.Equal(hashes["gate"], gate.ContentHash())
+	suite.Same(next.Namespaces["com/example/app"], next.Namespaces["com/example/app/gate"].Parent)
+
+	// the replaced index, and its views, still hold the old program
+	old, err := before.ResolvePolicy("com/example/app", "auth")
+	suite.Require().NoError(err)
+	suite.Contains(old.Rules["allow"].Body.String(), `"admin"`)
+	suite.Same(idx.Namespaces["com/example/app"].Policies["auth"], old)
+
+	after, err := next.View(suite.ctx)
+	suite.Require().NoError(err)
+	resolved, err := after.ResolvePolicy("com/example/app", "auth")
+	suite.Require().NoError(err)
+	suite.Same(auth, resolved)
+}
+
+func (suite *IndexTestSuite) TestReplaceProgramRevalidatesDependentsOfAnExportedShape() {
+	idx := suite.indexFromSource(nil, reindexSources...)
+	suite.Require().NoError(idx.Commit(suite.ctx))
+	auth := idx.Namespaces["com/example/app"].Policies["auth"]
+	misc := idx.Namespaces["com/other"].Policies["misc"]
+
+	// a change to the shape changes the policies requiring it
+	next, err := suite.replaceProgram(idx, "testa.sentrie", `namespace com/example/shared
+shape UserShape { name: string role: string level: number }
+export shape UserShape
+`)
+	suite.Require().NoError(err)
+	rebuilt := next.Namespaces["com/example/app"].Policies["auth"]
+	suite.NotSame(auth, rebuilt)
+	suite.NotEqual(auth.ContentHash(), rebuilt.ContentHash())
+	suite.Same(misc, next.Namespaces["com/other"].Policies["misc"])
+
+	// and no longer exporting it fails the policy importing it
+	_, err = suite.replaceProgram(idx, "testa.sentrie", `namespace com/example/shared
+shape UserShape { name: string role: string }
+`)
+	suite.Require().Error(err)
+	suite.ErrorIs(err, xerr.NotExportedError{})
+	suite.Contains(err.Error(), "cannot import shape 'UserShape' from 'com/example/shared'")
+
+	// which leaves the index it was replaced in as it was
+	view, err := idx.View(suite.ctx)
+	suite.Require().NoError(err)
+	resolved, err := view.ResolvePolicy("com/example/app", "auth")
+	suite.Require().NoError(err)
+	suite.Same(auth, resolved)
+}
+
+func (suite *IndexTestSuite) TestReplaceProgramMovesAProgramToAnotherNamespace() {
+	idx := suite.indexFromSource(nil, reindexSources...)
+	suite.Require().NoError(idx.Commit(suite.ctx))
+
+	next, err := suite.replaceProgram(idx, "testd.sentrie", `namespace com/elsewhere
+policy misc {
+  rule answer = default 0 { yield 1 }
+  export decision of answer
+}`)
+	suite.Require().NoError(err)
+	suite.NotContains(next.Namespaces, "com/other")
+	suite.Contains(next.Namespaces["com/elsewhere"].Policies, "misc")
+	suite.Contains(idx.Namespaces, "com/other")
+
+	// a new program is added
+	next, err = suite.replaceProgram(next, "teste.sentrie", `namespace com/other
+policy extra {
+  rule answer = default 0 { yield 2 }
+  export decision of answer
+}`)
+	suite.Require().NoError(err)
+	suite.Contains(next.Namespaces["com/other"].Policies, "extra")
+	suite.Len(next.Programs, 5)
+}
+
+func (suite *IndexTestSuite) TestReplaceProgramRequiresACommittedIndex() {
+	idx := suite.indexFromSource(nil, reindexSources...)
+
+	_, err := suite.replaceProgram(idx, "testb.sentrie", reindexSources[1])
+	suite.Require().Error(err)
+	suite.ErrorIs(err, xerr.ErrIndex)
+	suite.Contains(err.Error(), "not committed")
+}
+
+func (suite *IndexTestSuite) TestReplaceProgramReportsANewlyAmbiguousShapeReference() {
+	idx := suite.indexFromSource(nil, `namespace com/left
+shape Account { id: number }
+export shape Account
+policy users {
+  rule allow = default true { yield true }
+  export decision of allow
+}`, `namespace com/right
+policy users {
+  rule allow = default true { yield true }
+  export decision of allow
+}`, `namespace com/example/app
+policy auth {
+  fact account: Account
+  rule left = import decision allow from com/left/users
+  rule right = import decision allow from com/right/users
+  rule allow = default false { yield account.id > 0 }
+  export decision of allow
+}`)
+	suite.Require().NoError(idx.Commit(suite.ctx))
+
+	// a shape of the same name in the other imported namespace makes the reference ambiguous
+	_, err := suite.replaceProgram(idx, "testb.sentrie", `namespace com/right
+shape Account { id: number }
+export shape Account
+policy users {
+  rule allow = default true { yield true }
+  export decision of allow
+}`)
+	suite.Require().Error(err)
+	suite.ErrorIs(err, xerr.AmbiguousReferenceError{})
+	suite.Contains(err.Error(), "Account matches com/left/Account, com/right/Account")
+}
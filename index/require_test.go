@@ -0,0 +1,78 @@
+// SPDX-FileCopyrightText: © 2026 Binaek Sarkar <binaek89@gmail.com>
+// SPDX-License-Identifier: Apache-2.0
+
+package index
+
+import (
+	"github.com/sentrie-sh/sentrie/parser"
+)
+
+func (suite *IndexTestSuite) TestRequireResolvesShapes() {
+	idx := suite.indexFromSource(nil, `namespace com/example
+shape User {
+  name: string
+}
+policy auth {
+  fact user: document
+  fact admin?: document
+  shape Admin {
+    level: number
+  }
+  require user conforms User
+  require admin conforms Admin
+  rule allow = default false { yield true }
+  export decision of allow
+}`)
+	suite.Require().NoError(idx.Validate(suite.ctx))
+
+	p := idx.Namespaces["com/example"].Policies["auth"]
+	suite.Require().Len(p.Requires, 2)
+	suite.Equal("user", p.Requires[0].Fact)
+	suite.Equal("Admin", p.Requires[1].Shape.String())
+}
+
+func (suite *IndexTestSuite) TestRequireOfUnresolvedShapeFailsValidation() {
+	idx := suite.indexFromSource(nil, `namespace com/example
+policy auth {
+  fact user: document
+  require user conforms Missing
+  rule allow = default false { yield true }
+  export decision of allow
+}`)
+
+	err := idx.Validate(suite.ctx)
+	suite.Require().Error(err)
+	suite.Contains(err.Error(), "cannot resolve shape 'Missing' required of fact 'user'")
+}
+
+func (suite *IndexTestSuite) TestRequireOfUnexportedShapeFailsValidation() {
+	idx := suite.indexFromSource(nil, `namespace com/shared
+shape User {
+  name: string
+}`, `namespace com/example
+policy auth {
+  fact user: document
+  require user conforms com/shared/User
+  rule allow = default false { yield true }
+  export decision of allow
+}`)
+
+	err := idx.Validate(suite.ctx)
+	suite.Require().Error(err)
+	suite.Contains(err.Error(), "required of fact 'user'")
+}
+
+func (suite *IndexTestSuite) TestRequireOfUndeclaredFactFails() {
+	idx := CreateIndex()
+	program, err := parser.NewParserFromString(`namespace com/example
+policy auth {
+  require user conforms User
+  rule allow = default false { yield true }
+  export decision of allow
+}`, "test.sentrie").ParseProgram(suite.ctx)
+	suite.Require().NoError(err)
+
+	err = idx.AddProgram(suite.ctx, program)
+	suite.Require().Error(err)
+	suite.Contains(err.Error(), "require of undeclared fact 'user'")
+}
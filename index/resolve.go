@@ -17,7 +17,9 @@
 package index
 
 import (
+	"maps"
 	"path/filepath"
+	"slices"
 	"strings"
 
 	"github.com/sentrie-sh/sentrie/ast"
@@ -25,16 +27,34 @@ import (
 )
 
 func (idx *Index) ResolveNamespace(ns string) (*Namespace, error) {
-	n := idx.Namespaces[ns]
+	return resolveNamespace(idx.Namespaces, ns)
+}
+
+// ResolvePolicy tries exact namespace match; it does not traverse parents.
+func (idx *Index) ResolvePolicy(ns, policy string) (*Policy, error) {
+	return resolvePolicy(idx.Namespaces, ns, policy)
+}
+
+func (idx *Index) ResolveShape(ns, shape string) (*Shape, error) {
+	return resolveShape(idx.Namespaces, ns, shape)
+}
+
+// ResolveShapeRef resolves a shape reference written in ns, and in policy when it is not nil.
+// See resolveShapeRef for the precedence rules.
+func (idx *Index) ResolveShapeRef(ns *Namespace, policy *Policy, ref ast.FQN) (*Namespace, *Shape, error) {
+	return resolveShapeRef(idx.Namespaces, ns, policy, ref)
+}
+
+func resolveNamespace(namespaces map[string]*Namespace, ns string) (*Namespace, error) {
+	n := namespaces[ns]
 	if n == nil {
 		return nil, xerr.ErrNamespaceNotFound(ns)
 	}
 	return n, nil
 }
 
-// ResolvePolicy tries exact namespace match; it does not traverse parents.
-func (idx *Index) ResolvePolicy(ns, policy string) (*Policy, error) {
-	n, err := idx.ResolveNamespace(ns)
+func resolvePolicy(namespaces map[string]*Namespace, ns, policy string) (*Policy, error) {
+	n, err := resolveNamespace(namespaces, ns)
 	if err != nil {
 		return nil, err
 	}
@@ -48,8 +68,8 @@ func (idx *Index) ResolvePolicy(ns, policy string) (*Policy, error) {
 	return p, nil
 }
 
-func (idx *Index) ResolveShape(ns, shape string) (*Shape, error) {
-	n, err := idx.ResolveNamespace(ns)
+func resolveShape(namespaces map[string]*Namespace, ns, shape string) (*Shape, error) {
+	n, err := resolveNamespace(namespaces, ns)
 	if err != nil {
 		return nil, err
 	}
@@ -63,8 +83,114 @@ func (idx *Index) ResolveShape(ns, shape string) (*Shape, error) {
 	return s, nil
 }
 
+// resolveShapeRef resolves a possibly relative shape reference. The reference is tried, in order:
+//
+//  1. against the current scope: a shape local to policy or imported by it, then ref below the namespace ns
+//  2. against the namespaces that policy imports rules from
+//  3. as an absolute FQN
+//
+// The first step that matches wins, so a sibling shadows an absolute name of the same spelling.
+// More than one match within the same step is an ambiguous reference.
+// Whether the resolved shape is visible from ns is left to the caller, which is why the
+// namespace the shape was found in is returned alongside it.
+func resolveShapeRef(namespaces map[string]*Namespace, ns *Namespace, policy *Policy, ref ast.FQN) (*Namespace, *Shape, error) {
+	if ref.IsEmpty() {
+		return nil, nil, xerr.ErrShapeNotFound(ref.String())
+	}
+
+	type match struct {
+		ns    *Namespace
+		shape *Shape
+	}
+
+	// lookup resolves ref below base - a miss is not an error
+	lookup := func(base ast.FQN) *match {
+		parts := append(slices.Clip(base.Parts), ref.Parts...)
+		n := namespaces[strings.Join(parts[:len(parts)-1], ast.FQNSeparator)]
+		if n == nil {
+			return nil
+		}
+		shape, ok := n.Shapes[parts[len(parts)-1]]
+		if !ok {
+			return nil
+		}
+		return &match{ns: n, shape: shape}
+	}
+
+	// 1. the current scope
+	if policy != nil && len(ref.Parts) == 1 {
+		if shape, ok := policy.Shapes[ref.Parts[0]]; ok {
+			return policy.Namespace, shape, nil
+		}
+		if use, ok := policy.ShapeImports[ref.Parts[0]]; ok {
+			return resolveShapeImport(namespaces, use)
+		}
+	}
+	if ns != nil {
+		if m := lookup(ns.FQN); m != nil {
+			return m.ns, m.shape, nil
+		}
+	}
+
+	// 2. the namespaces of imported policies
+	if policy != nil {
+		seen := map[string]bool{}
+		if ns != nil {
+			seen[ns.FQN.String()] = true
+		}
+		matches := map[string]*match{}
+		for _, rule := range policy.Rules {
+			importClause, ok := rule.Body.(*ast.ImportClause)
+			if !ok || importClause.FromPolicyFQN == nil {
+				continue
+			}
+			importedNs, _ := importTarget(policy, importClause)
+			if seen[importedNs] {
+				continue
+			}
+			seen[importedNs] = true
+
+			if m := lookup(ast.NewFQN(strings.Split(importedNs, ast.FQNSeparator), ref.Rnge)); m != nil {
+				matches[ShapeFQN(m.ns.FQN.String(), m.shape.Name)] = m
+			}
+		}
+		if len(matches) > 1 {
+			return nil, nil, xerr.ErrAmbiguousReference(ref.String(), slices.Sorted(maps.Keys(matches)))
+		}
+		for _, m := range matches {
+			return m.ns, m.shape, nil
+		}
+	}
+
+	// 3. an absolute FQN
+	if len(ref.Parts) > 1 {
+		if m := lookup(ast.FQN{}); m != nil {
+			return m.ns, m.shape, nil
+		}
+	}
+
+	return nil, nil, xerr.ErrShapeNotFound(ref.String())
+}
+
+// resolveShapeImport resolves the shape named by a shape import, which its namespace must export.
+func resolveShapeImport(namespaces map[string]*Namespace, use *ast.UseStatement) (*Namespace, *Shape, error) {
+	name := use.Modules[0]
+	n := namespaces[use.ShapeFrom.String()]
+	if n == nil {
+		return nil, nil, xerr.ErrShapeNotFound(ShapeFQN(use.ShapeFrom.String(), name))
+	}
+	shape, ok := n.Shapes[name]
+	if !ok {
+		return nil, nil, xerr.ErrShapeNotFound(ShapeFQN(use.ShapeFrom.String(), name))
+	}
+	if err := n.VerifyShapeExported(name); err != nil {
+		return nil, nil, err
+	}
+	return n, shape, nil
+}
+
 // VerifyRuleExported verifies that a rule is exported in its policy. Returns an error if the rule is not exported.
-func (p Policy) VerifyRuleExported(rule string) error {
+func (p *Policy) VerifyRuleExported(rule string) error {
 	if _, ok := p.RuleExports[rule]; !ok {
 		return xerr.ErrNotExported(RuleFQN(p.Namespace.FQN.String(), p.Name, rule))
 	}
@@ -284,3 +284,125 @@ func TestRuleFQN(t *testing.T) {
 func TestShapeFQN(t *testing.T) {
 	require.Equal(t, "com/example/User", ShapeFQN("com/example", "User"))
 }
+
+func resolveRefNamespace(idx *Index, parts ...string) *Namespace {
+	ns := createNamespace(ast.NewNamespaceStatement(testFQN(parts...), testRange()))
+	idx.Namespaces[ns.FQN.String()] = ns
+	return ns
+}
+
+func resolveRefImport(p *Policy, name string, from ...string) {
+	p.Rules[name] = testRule(p, name, ast.NewImportClause("allow", testFQN(from...).Ptr(), nil, testRange()))
+}
+
+func TestResolveShapeRef_SiblingIsRelative(t *testing.T) {
+	idx := CreateIndex()
+	current := resolveRefNamespace(idx, "com", "example")
+	sub := resolveRefNamespace(idx, "com", "example", "models")
+	other := resolveRefNamespace(idx, "models")
+	current.Shapes["User"] = testShape(current, nil, "User", nil)
+	sub.Shapes["Account"] = testShape(sub, nil, "Account", nil)
+	other.Shapes["Account"] = testShape(other, nil, "Account", nil)
+
+	ns, shape, err := idx.ResolveShapeRef(current, nil, testFQN("User"))
+	require.NoError(t, err)
+	require.Same(t, current, ns)
+	require.Same(t, current.Shapes["User"], shape)
+
+	// the relative reading shadows the absolute "models/Account"
+	ns, shape, err = idx.ResolveShapeRef(current, nil, testFQN("models", "Account"))
+	require.NoError(t, err)
+	require.Same(t, sub, ns)
+	require.Same(t, sub.Shapes["Account"], shape)
+}
+
+func TestResolveShapeRef_PolicyShapeShadowsNamespace(t *testing.T) {
+	idx := CreateIndex()
+	current := resolveRefNamespace(idx, "com", "example")
+	p := testPolicy(current, "auth")
+	current.Shapes["User"] = testShape(current, nil, "User", nil)
+	p.Shapes["User"] = testShape(current, p, "User", nil)
+
+	_, shape, err := idx.ResolveShapeRef(current, p, testFQN("User"))
+	require.NoError(t, err)
+	require.Same(t, p.Shapes["User"], shape)
+}
+
+func TestResolveShapeRef_Imported(t *testing.T) {
+	idx := CreateIndex()
+	current := resolveRefNamespace(idx, "com", "example")
+	shared := resolveRefNamespace(idx, "com", "shared")
+	shared.Shapes["User"] = testShape(shared, nil, "User", nil)
+	p := testPolicy(current, "auth")
+	resolveRefImport(p, "base", "com", "shared", "users")
+
+	ns, shape, err := idx.ResolveShapeRef(current, p, testFQN("User"))
+	require.NoError(t, err)
+	require.Same(t, shared, ns)
+	require.Same(t, shared.Shapes["User"], shape)
+}
+
+func TestResolveShapeRef_Absolute(t *testing.T) {
+	idx := CreateIndex()
+	current := resolveRefNamespace(idx, "com", "example")
+	shared := resolveRefNamespace(idx, "com", "shared")
+	shared.Shapes["User"] = testShape(shared, nil, "User", nil)
+
+	ns, shape, err := idx.ResolveShapeRef(current, nil, testFQN("com", "shared", "User"))
+	require.NoError(t, err)
+	require.Same(t, shared, ns)
+	require.Same(t, shared.Shapes["User"], shape)
+
+	view := &IndexView{namespaces: idx.Namespaces}
+	_, viewShape, err := view.ResolveShapeRef(current, nil, testFQN("com", "shared", "User"))
+	require.NoError(t, err)
+	require.Same(t, shape, viewShape)
+}
+
+func TestResolveShapeRef_Ambiguous(t *testing.T) {
+	idx := CreateIndex()
+	current := resolveRefNamespace(idx, "com", "example")
+	left := resolveRefNamespace(idx, "com", "left")
+	right := resolveRefNamespace(idx, "com", "right")
+	left.Shapes["User"] = testShape(left, nil, "User", nil)
+	right.Shapes["User"] = testShape(right, nil, "User", nil)
+	p := testPolicy(current, "auth")
+	resolveRefImport(p, "l", "com", "left", "users")
+	resolveRefImport(p, "r", "com", "right", "users")
+
+	ns, shape, err := idx.ResolveShapeRef(current, p, testFQN("User"))
+	require.Nil(t, ns)
+	require.Nil(t, shape)
+	require.True(t, errors.Is(err, xerr.AmbiguousReferenceError{}), "got %v", err)
+	require.Contains(t, err.Error(), "User matches com/left/User, com/right/User")
+
+	// a sibling takes precedence over the imports, so there is nothing to disambiguate
+	current.Shapes["User"] = testShape(current, nil, "User", nil)
+	_, shape, err = idx.ResolveShapeRef(current, p, testFQN("User"))
+	require.NoError(t, err)
+	require.Same(t, current.Shapes["User"], shape)
+}
+
+func TestResolveShapeRef_SiblingShadowsImported(t *testing.T) {
+	idx := CreateIndex()
+	current := resolveRefNamespace(idx, "com", "example")
+	shared := resolveRefNamespace(idx, "com", "shared")
+	current.Shapes["Foo"] = testShape(current, nil, "Foo", nil)
+	shared.Shapes["Foo"] = testShape(shared, nil, "Foo", nil)
+	p := testPolicy(current, "auth")
+	resolveRefImport(p, "base", "com", "shared", "users")
+
+	ns, shape, err := idx.ResolveShapeRef(current, p, testFQN("Foo"))
+	require.NoError(t, err)
+	require.Same(t, current, ns)
+	require.Same(t, current.Shapes["Foo"], shape)
+}
+
+func TestResolveShapeRef_NotFound(t *testing.T) {
+	idx := CreateIndex()
+	current := resolveRefNamespace(idx, "com", "example")
+
+	_, shape, err := idx.ResolveShapeRef(current, nil, testFQN("com", "missing", "User"))
+	require.Nil(t, shape)
+	require.True(t, errors.Is(err, xerr.NotFoundError{}), "got %v", err)
+}
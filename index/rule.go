@@ -19,6 +19,20 @@ package index
 import (
 	"github.com/sentrie-sh/sentrie/ast"
 	"github.com/sentrie-sh/sentrie/tokens"
+	"github.com/sentrie-sh/sentrie/xerr"
+)
+
+// RuleEffect is what a rule deciding true means, as declared by its effect clause.
+type RuleEffect string
+
+const (
+	// RuleEffectNone is a rule without an effect clause, which permits when it decides true and
+	// denies when it decides false.
+	RuleEffectNone RuleEffect = ""
+	// RuleEffectPermit is a rule that permits when it decides true and does not apply otherwise.
+	RuleEffectPermit RuleEffect = "permit"
+	// RuleEffectDeny is a rule that denies when it decides true and does not apply otherwise.
+	RuleEffectDeny RuleEffect = "deny"
 )
 
 type Rule struct {
@@ -26,6 +40,7 @@ type Rule struct {
 	Policy  *Policy
 	Name    string
 	FQN     ast.FQN
+	Effect  RuleEffect
 	Default ast.Expression
 	When    ast.Expression
 	Body    ast.Expression
@@ -40,11 +55,26 @@ func (r *Rule) Span() tokens.Range {
 }
 
 func createRule(p *Policy, stmt *ast.RuleStatement) (*Rule, error) {
+	var effectAt *ast.EffectClause
+	for _, clause := range stmt.Effects {
+		// a rule declares at most one effect, so a repeated one is rejected like a conflicting one
+		if effectAt != nil {
+			return nil, xerr.ErrConflict("rule effect", clause.Span(), effectAt.Span())
+		}
+		effectAt = clause
+	}
+
+	effect := RuleEffectNone
+	if effectAt != nil {
+		effect = RuleEffect(effectAt.Effect)
+	}
+
 	return &Rule{
 		Node:    stmt,
 		Policy:  p,
 		Name:    stmt.RuleName,
 		FQN:     ast.CreateFQN(p.FQN, stmt.RuleName),
+		Effect:  effect,
 		Default: stmt.Default,
 		When:    stmt.When,
 		Body:    stmt.Body,
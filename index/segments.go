@@ -24,6 +24,10 @@ import (
 )
 
 func (idx *Index) ResolveSegments(path string) (ns, policy, rule string, err error) {
+	return resolveSegments(idx.Namespaces, path)
+}
+
+func resolveSegments(namespaces map[string]*Namespace, path string) (ns, policy, rule string, err error) {
 	// split by .
 	parts := strings.Split(path, "/")
 	// start joining the parts, until we have a namespace, or we run out of parts
@@ -59,7 +63,7 @@ func (idx *Index) ResolveSegments(path string) (ns, policy, rule string, err err
 			nsName = strings.Join([]string{nsName, nextPart}, ast.FQNSeparator)
 		}
 
-		n, err := idx.ResolveNamespace(nsName)
+		n, err := resolveNamespace(namespaces, nsName)
 		if err == nil && n != nil {
 			// Found a namespace, remember it but continue to see if we can find a longer one
 			foundNamespace = true
@@ -83,7 +87,7 @@ func (idx *Index) ResolveSegments(path string) (ns, policy, rule string, err err
 
 	// we have a namespace, the next segment is the policy name
 	policyName, parts := parts[0], parts[1:]
-	_, err = idx.ResolvePolicy(nsName, policyName)
+	_, err = resolvePolicy(namespaces, nsName, policyName)
 	if err != nil {
 		return "", "", "", err
 	}
@@ -17,6 +17,7 @@
 package index
 
 import (
+	"cmp"
 	"errors"
 	"fmt"
 	"sync/atomic"
@@ -42,6 +43,8 @@ type Shape struct {
 type ShapeModel struct {
 	WithFQN *ast.FQN
 	Fields  map[string]*ShapeModelField
+	// Closed shapes reject values carrying fields outside of Fields
+	Closed bool
 }
 
 type ExportedShape struct {
@@ -54,12 +57,25 @@ type ShapeModelField struct {
 	Name     string
 	Optional bool
 	TypeRef  ast.TypeRef
+	Default  ast.Expression
 }
 
 func (s *Shape) String() string {
 	return s.FQN.String()
 }
 
+// verifyComposable checks that s may compose withShape, found in withNs: a shape from
+// another namespace must be exported by it.
+func (s *Shape) verifyComposable(withNs *Namespace, withShape *Shape) error {
+	if withNs == nil || withNs.FQN.String() == s.Namespace.FQN.String() {
+		return nil
+	}
+	if err := withNs.VerifyShapeExported(withShape.Name); err != nil {
+		return fmt.Errorf("cannot compose '%s' with shape '%s' at %s: shape '%s' is not exported: %w", s.FQN.String(), s.Model.WithFQN.String(), s.Statement.Span(), withShape.FQN.String(), xerr.ErrIndex)
+	}
+	return nil
+}
+
 func (s *Shape) resolveDependency(idx *Index, inPolicy *Policy) error {
 	if atomic.LoadUint32(&s.hydrated) == 1 {
 		return nil
@@ -79,23 +95,20 @@ func (s *Shape) resolveDependency(idx *Index, inPolicy *Policy) error {
 		return nil
 	}
 
-	var withShape *Shape
-	withName := s.Model.WithFQN.LastSegment()
-
-	// if we have a policy, look for it in the policy's shapes
-	if inPolicy != nil {
-		// check the policy's shapes
-		if shape, ok := inPolicy.Shapes[withName]; ok {
-			withShape = shape
-		}
+	// a policy-local shape resolves against its own policy, whatever scope it is hydrated from
+	policy := cmp.Or(s.Policy, inPolicy)
+	withNs, withShape, err := idx.ResolveShapeRef(s.Namespace, policy, *s.Model.WithFQN)
+	if err != nil && !isShapeDependencyNamespaceMiss(err) {
+		return fmt.Errorf("shape '%s' not resolved at %s: %s: %w", s.Model.WithFQN.String(), s.Statement.Span(), err, xerr.ErrIndex)
 	}
-
-	// check if we have the shape in the containing namespace
-	if shape, ok := s.Namespace.Shapes[withName]; ok {
-		withShape = shape
+	if withShape != nil {
+		if err := s.verifyComposable(withNs, withShape); err != nil {
+			return err
+		}
 	}
 
 	if withShape == nil {
+		withName := s.Model.WithFQN.LastSegment()
 		// now we need to check whether this is exported by some other namespaces in the index
 		for _, ns := range idx.Namespaces {
 			// check in exported shapes
@@ -133,10 +146,13 @@ func (s *Shape) resolveDependency(idx *Index, inPolicy *Policy) error {
 	// at this point we have the shape, we are going to assume it's hydrated
 	// the assumption is not unfounded, since we traverse the shapes in a topological order
 
-	// now we bring in the fields
+	// now we bring in the fields - a field the shape redefines shadows the composed one
 	for name, field := range withShape.Model.Fields {
-		if _, ok := s.Model.Fields[name]; ok {
-			return fmt.Errorf("cannot compose with duplicate shape field '%s' at %s and %s: %w", name, field.Node.Range, s.Model.Fields[name].Node.Range, xerr.ErrIndex)
+		if own, ok := s.Model.Fields[name]; ok {
+			if !own.Node.Override && !idx.shadowedFieldWarnings {
+				return fmt.Errorf("cannot compose with duplicate shape field '%s' at %s and %s: %w", name, field.Node.Range, own.Node.Range, xerr.ErrIndex)
+			}
+			continue
 		}
 		s.Model.Fields[name] = field
 	}
@@ -170,7 +186,7 @@ func createShape(ns *Namespace, p *Policy, stmt *ast.ShapeStatement) (*Shape, er
 	}
 
 	if stmt.Complex != nil {
-		shape.Model = &ShapeModel{Fields: make(map[string]*ShapeModelField)}
+		shape.Model = &ShapeModel{Fields: make(map[string]*ShapeModelField), Closed: stmt.Complex.Closed}
 		if stmt.Complex.With != nil {
 			shape.Model.WithFQN = stmt.Complex.With
 		}
@@ -184,11 +200,17 @@ func createShape(ns *Namespace, p *Policy, stmt *ast.ShapeStatement) (*Shape, er
 				return nil, fmt.Errorf("duplicate shape field '%s' at %s", field.Name, field.Range)
 			}
 
+			// a required field is always present, so its default could never apply
+			if field.Default != nil && !field.Optional {
+				return nil, fmt.Errorf("required shape field '%s' cannot have a default at %s - mark it optional with '%s?'", field.Name, field.Range, field.Name)
+			}
+
 			shape.Model.Fields[field.Name] = &ShapeModelField{
 				Node:     field,
 				Name:     field.Name,
 				Optional: field.Optional,
 				TypeRef:  field.Type,
+				Default:  field.Default,
 			}
 		}
 	} else {
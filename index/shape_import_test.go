@@ -0,0 +1,85 @@
+// SPDX-FileCopyrightText: © 2026 Binaek Sarkar <binaek89@gmail.com>
+// SPDX-License-Identifier: Apache-2.0
+
+package index
+
+import (
+	"github.com/sentrie-sh/sentrie/ast"
+	"github.com/sentrie-sh/sentrie/parser"
+	"github.com/sentrie-sh/sentrie/xerr"
+)
+
+const sharedShapesSource = `namespace com/example/shared
+shape UserShape { name: string role: string }
+shape Internal { secret: string }
+export shape UserShape
+`
+
+func (suite *IndexTestSuite) TestShapeImportIsUsableAsAType() {
+	idx := suite.indexFromSource(nil, sharedShapesSource, `namespace com/example/app
+policy auth {
+  fact user: U
+  fact request: document
+  use UserShape from com/example/shared as U
+  shape Request { owner: U }
+  shape Admin with U { level: number }
+  require request conforms Request
+  require user conforms U
+  rule allow = default false { yield user.role == "admin" }
+  export decision of allow
+}`)
+	suite.Require().NoError(idx.Validate(suite.ctx))
+
+	p := idx.Namespaces["com/example/app"].Policies["auth"]
+	suite.Contains(p.ShapeImports, "U")
+	suite.NotContains(p.Uses, "U")
+
+	ns, shape, err := idx.ResolveShapeRef(p.Namespace, p, ast.NewFQN([]string{"U"}, p.Statement.Span()))
+	suite.Require().NoError(err)
+	suite.Equal("com/example/shared", ns.FQN.String())
+	suite.Equal("UserShape", shape.Name)
+}
+
+func (suite *IndexTestSuite) TestShapeImportOfUnexportedShapeFailsValidation() {
+	idx := suite.indexFromSource(nil, sharedShapesSource, `namespace com/example/app
+policy auth {
+  fact user: I
+  use Internal from com/example/shared as I
+  rule allow = default false { yield true }
+  export decision of allow
+}`)
+
+	err := idx.Validate(suite.ctx)
+	suite.Require().Error(err)
+	suite.ErrorIs(err, xerr.NotExportedError{})
+	suite.Contains(err.Error(), "cannot import shape 'Internal' from 'com/example/shared'")
+}
+
+func (suite *IndexTestSuite) TestShapeImportOfMissingShapeFailsValidation() {
+	idx := suite.indexFromSource(nil, sharedShapesSource, `namespace com/example/app
+policy auth {
+  use Missing from com/example/shared
+  rule allow = default false { yield true }
+  export decision of allow
+}`)
+
+	err := idx.Validate(suite.ctx)
+	suite.Require().Error(err)
+	suite.ErrorIs(err, xerr.NotFoundError{})
+}
+
+func (suite *IndexTestSuite) TestShapeImportAliasConflictsWithLocalShape() {
+	program, err := parser.NewParserFromString(`namespace com/example/app
+policy auth {
+  use UserShape from com/example/shared as U
+  shape U { name: string }
+  rule allow = default false { yield true }
+  export decision of allow
+}`, "app.sentrie").ParseProgram(suite.ctx)
+	suite.Require().NoError(err)
+
+	err = CreateIndex().AddProgram(suite.ctx, program)
+	suite.Require().Error(err)
+	var conflict xerr.ConflictError
+	suite.ErrorAs(err, &conflict)
+}
@@ -21,6 +21,7 @@ import (
 	"errors"
 
 	"github.com/sentrie-sh/sentrie/ast"
+	"github.com/sentrie-sh/sentrie/parser"
 	"github.com/sentrie-sh/sentrie/tokens"
 	"github.com/sentrie-sh/sentrie/xerr"
 )
@@ -97,10 +98,10 @@ func (s *IndexTestSuite) TestShapeDependency_ResolveDependency_UsesPolicyShape()
 			Range: tokens.Range{File: "app.sentra", From: tokens.Pos{Line: 2, Column: 0, Offset: 0}, To: tokens.Pos{Line: 2, Column: 0, Offset: 0}},
 			Fields: map[string]*ast.ShapeField{
 				"id": {
-					Range:       tokens.Range{File: "app.sentra", From: tokens.Pos{Line: 3, Column: 2, Offset: 0}, To: tokens.Pos{Line: 3, Column: 2, Offset: 0}},
-					Name:        "id",
+					Range:    tokens.Range{File: "app.sentra", From: tokens.Pos{Line: 3, Column: 2, Offset: 0}, To: tokens.Pos{Line: 3, Column: 2, Offset: 0}},
+					Name:     "id",
 					Optional: false,
-					Type:        ast.NewStringTypeRef(tokens.Range{File: "app.sentra", From: tokens.Pos{Line: 3, Column: 6, Offset: 0}, To: tokens.Pos{Line: 3, Column: 6, Offset: 0}}),
+					Type:     ast.NewStringTypeRef(tokens.Range{File: "app.sentra", From: tokens.Pos{Line: 3, Column: 6, Offset: 0}, To: tokens.Pos{Line: 3, Column: 6, Offset: 0}}),
 				},
 			},
 		},
@@ -118,10 +119,10 @@ func (s *IndexTestSuite) TestShapeDependency_ResolveDependency_UsesPolicyShape()
 			With:  &withBase,
 			Fields: map[string]*ast.ShapeField{
 				"name": {
-					Range:       tokens.Range{File: "app.sentra", From: tokens.Pos{Line: 6, Column: 2, Offset: 0}, To: tokens.Pos{Line: 6, Column: 2, Offset: 0}},
-					Name:        "name",
+					Range:    tokens.Range{File: "app.sentra", From: tokens.Pos{Line: 6, Column: 2, Offset: 0}, To: tokens.Pos{Line: 6, Column: 2, Offset: 0}},
+					Name:     "name",
 					Optional: false,
-					Type:        ast.NewStringTypeRef(tokens.Range{File: "app.sentra", From: tokens.Pos{Line: 6, Column: 8, Offset: 0}, To: tokens.Pos{Line: 6, Column: 8, Offset: 0}}),
+					Type:     ast.NewStringTypeRef(tokens.Range{File: "app.sentra", From: tokens.Pos{Line: 6, Column: 8, Offset: 0}, To: tokens.Pos{Line: 6, Column: 8, Offset: 0}}),
 				},
 			},
 		},
@@ -165,10 +166,10 @@ func (s *IndexTestSuite) TestShapeDependency_ShapeWithMissingDependency() {
 			With:  &wfMissing,
 			Fields: map[string]*ast.ShapeField{
 				"field": {
-					Range:       tokens.Range{File: "test.sentra", From: tokens.Pos{Line: 2, Column: 4, Offset: 4}, To: tokens.Pos{Line: 2, Column: 4, Offset: 4}},
-					Name:        "field",
+					Range:    tokens.Range{File: "test.sentra", From: tokens.Pos{Line: 2, Column: 4, Offset: 4}, To: tokens.Pos{Line: 2, Column: 4, Offset: 4}},
+					Name:     "field",
 					Optional: false,
-					Type:        ast.NewStringTypeRef(tokens.Range{File: "test.sentra", From: tokens.Pos{Line: 2, Column: 10, Offset: 10}, To: tokens.Pos{Line: 2, Column: 10, Offset: 10}}),
+					Type:     ast.NewStringTypeRef(tokens.Range{File: "test.sentra", From: tokens.Pos{Line: 2, Column: 10, Offset: 10}, To: tokens.Pos{Line: 2, Column: 10, Offset: 10}}),
 				},
 			},
 		},
@@ -225,10 +226,10 @@ func (s *IndexTestSuite) TestShapeDependency_MissingDependencyAcrossNamespaces()
 			With:  &withMissing,
 			Fields: map[string]*ast.ShapeField{
 				"name": {
-					Range:       tokens.Range{File: "app.sentra", From: tokens.Pos{Line: 2, Column: 4, Offset: 4}, To: tokens.Pos{Line: 2, Column: 4, Offset: 4}},
-					Name:        "name",
+					Range:    tokens.Range{File: "app.sentra", From: tokens.Pos{Line: 2, Column: 4, Offset: 4}, To: tokens.Pos{Line: 2, Column: 4, Offset: 4}},
+					Name:     "name",
 					Optional: false,
-					Type:        ast.NewStringTypeRef(tokens.Range{File: "app.sentra", From: tokens.Pos{Line: 2, Column: 10, Offset: 10}, To: tokens.Pos{Line: 2, Column: 10, Offset: 10}}),
+					Type:     ast.NewStringTypeRef(tokens.Range{File: "app.sentra", From: tokens.Pos{Line: 2, Column: 10, Offset: 10}, To: tokens.Pos{Line: 2, Column: 10, Offset: 10}}),
 				},
 			},
 		},
@@ -269,10 +270,10 @@ func (s *IndexTestSuite) TestShapeDependency_ShapeWithCircularDependency() {
 			With:  &wfA,
 			Fields: map[string]*ast.ShapeField{
 				"fieldA": {
-					Range:       tokens.Range{File: "test.sentra", From: tokens.Pos{Line: 2, Column: 4, Offset: 4}, To: tokens.Pos{Line: 2, Column: 4, Offset: 4}},
-					Name:        "fieldA",
+					Range:    tokens.Range{File: "test.sentra", From: tokens.Pos{Line: 2, Column: 4, Offset: 4}, To: tokens.Pos{Line: 2, Column: 4, Offset: 4}},
+					Name:     "fieldA",
 					Optional: false,
-					Type:        ast.NewStringTypeRef(tokens.Range{File: "test.sentra", From: tokens.Pos{Line: 2, Column: 10, Offset: 10}, To: tokens.Pos{Line: 2, Column: 10, Offset: 10}}),
+					Type:     ast.NewStringTypeRef(tokens.Range{File: "test.sentra", From: tokens.Pos{Line: 2, Column: 10, Offset: 10}, To: tokens.Pos{Line: 2, Column: 10, Offset: 10}}),
 				},
 			},
 		},
@@ -289,10 +290,10 @@ func (s *IndexTestSuite) TestShapeDependency_ShapeWithCircularDependency() {
 			With:  &wfB,
 			Fields: map[string]*ast.ShapeField{
 				"fieldB": {
-					Range:       tokens.Range{File: "test.sentra", From: tokens.Pos{Line: 6, Column: 4, Offset: 4}, To: tokens.Pos{Line: 6, Column: 4, Offset: 4}},
-					Name:        "fieldB",
+					Range:    tokens.Range{File: "test.sentra", From: tokens.Pos{Line: 6, Column: 4, Offset: 4}, To: tokens.Pos{Line: 6, Column: 4, Offset: 4}},
+					Name:     "fieldB",
 					Optional: false,
-					Type:        ast.NewStringTypeRef(tokens.Range{File: "test.sentra", From: tokens.Pos{Line: 6, Column: 10, Offset: 10}, To: tokens.Pos{Line: 6, Column: 10, Offset: 10}}),
+					Type:     ast.NewStringTypeRef(tokens.Range{File: "test.sentra", From: tokens.Pos{Line: 6, Column: 10, Offset: 10}, To: tokens.Pos{Line: 6, Column: 10, Offset: 10}}),
 				},
 			},
 		},
@@ -318,6 +319,18 @@ func (s *IndexTestSuite) TestShapeDependency_ShapeWithCircularDependency() {
 	s.Contains(err.Error(), "ShapeB")
 }
 
+func (s *IndexTestSuite) TestShapeDependency_CycleErrorCarriesThePath() {
+	idx := s.indexFromSource(nil, `namespace com/example
+shape Lead with Beta { lead: string }
+shape Gamma with Alpha { gamma: string }
+shape Beta with Gamma { beta: string }
+shape Alpha with Beta { alpha: string }`)
+
+	err := idx.Validate(s.ctx)
+	s.Require().Error(err)
+	s.Contains(err.Error(), "detected cyclic dependencies in shapes: com/example/Alpha -> com/example/Beta -> com/example/Gamma -> com/example/Alpha")
+}
+
 // Shape with complex dependency chain - verify complex dependency chains work
 func (s *IndexTestSuite) TestShapeDependency_ShapeWithComplexDependencyChain() {
 	ctx := context.Background()
@@ -340,10 +353,10 @@ func (s *IndexTestSuite) TestShapeDependency_ShapeWithComplexDependencyChain() {
 			With:  nil,
 			Fields: map[string]*ast.ShapeField{
 				"id": {
-					Range:       tokens.Range{File: "test.sentra", From: tokens.Pos{Line: 2, Column: 4, Offset: 4}, To: tokens.Pos{Line: 2, Column: 4, Offset: 4}},
-					Name:        "id",
+					Range:    tokens.Range{File: "test.sentra", From: tokens.Pos{Line: 2, Column: 4, Offset: 4}, To: tokens.Pos{Line: 2, Column: 4, Offset: 4}},
+					Name:     "id",
 					Optional: false,
-					Type:        ast.NewStringTypeRef(tokens.Range{File: "test.sentra", From: tokens.Pos{Line: 2, Column: 8, Offset: 8}, To: tokens.Pos{Line: 2, Column: 8, Offset: 8}}),
+					Type:     ast.NewStringTypeRef(tokens.Range{File: "test.sentra", From: tokens.Pos{Line: 2, Column: 8, Offset: 8}, To: tokens.Pos{Line: 2, Column: 8, Offset: 8}}),
 				},
 			},
 		},
@@ -359,10 +372,10 @@ func (s *IndexTestSuite) TestShapeDependency_ShapeWithComplexDependencyChain() {
 			With:  ast.NewFQN([]string{"BaseEntity"}, tokens.Range{File: "test.sentra", From: tokens.Pos{Line: 5, Column: 10, Offset: 10}, To: tokens.Pos{Line: 5, Column: 10, Offset: 10}}).Ptr(),
 			Fields: map[string]*ast.ShapeField{
 				"name": {
-					Range:       tokens.Range{File: "test.sentra", From: tokens.Pos{Line: 6, Column: 4, Offset: 4}, To: tokens.Pos{Line: 6, Column: 4, Offset: 4}},
-					Name:        "name",
+					Range:    tokens.Range{File: "test.sentra", From: tokens.Pos{Line: 6, Column: 4, Offset: 4}, To: tokens.Pos{Line: 6, Column: 4, Offset: 4}},
+					Name:     "name",
 					Optional: false,
-					Type:        ast.NewStringTypeRef(tokens.Range{File: "test.sentra", From: tokens.Pos{Line: 6, Column: 10, Offset: 10}, To: tokens.Pos{Line: 6, Column: 10, Offset: 10}}),
+					Type:     ast.NewStringTypeRef(tokens.Range{File: "test.sentra", From: tokens.Pos{Line: 6, Column: 10, Offset: 10}, To: tokens.Pos{Line: 6, Column: 10, Offset: 10}}),
 				},
 			},
 		},
@@ -378,10 +391,10 @@ func (s *IndexTestSuite) TestShapeDependency_ShapeWithComplexDependencyChain() {
 			With:  ast.NewFQN([]string{"User"}, tokens.Range{File: "test.sentra", From: tokens.Pos{Line: 9, Column: 10, Offset: 10}, To: tokens.Pos{Line: 9, Column: 10, Offset: 10}}).Ptr(),
 			Fields: map[string]*ast.ShapeField{
 				"role": {
-					Range:       tokens.Range{File: "test.sentra", From: tokens.Pos{Line: 10, Column: 4, Offset: 4}, To: tokens.Pos{Line: 10, Column: 4, Offset: 4}},
-					Name:        "role",
+					Range:    tokens.Range{File: "test.sentra", From: tokens.Pos{Line: 10, Column: 4, Offset: 4}, To: tokens.Pos{Line: 10, Column: 4, Offset: 4}},
+					Name:     "role",
 					Optional: false,
-					Type:        ast.NewStringTypeRef(tokens.Range{File: "test.sentra", From: tokens.Pos{Line: 10, Column: 10, Offset: 10}, To: tokens.Pos{Line: 10, Column: 10, Offset: 10}}),
+					Type:     ast.NewStringTypeRef(tokens.Range{File: "test.sentra", From: tokens.Pos{Line: 10, Column: 10, Offset: 10}, To: tokens.Pos{Line: 10, Column: 10, Offset: 10}}),
 				},
 			},
 		},
@@ -444,10 +457,10 @@ func (s *IndexTestSuite) TestShapeDependency_ShapeWithSelfDependency() {
 			With:  ast.NewFQN([]string{"SelfReferencingShape"}, tokens.Range{File: "test.sentra", From: tokens.Pos{Line: 1, Column: 10, Offset: 10}, To: tokens.Pos{Line: 1, Column: 10, Offset: 10}}).Ptr(), // depends on itself
 			Fields: map[string]*ast.ShapeField{
 				"field": {
-					Range:       tokens.Range{File: "test.sentra", From: tokens.Pos{Line: 2, Column: 4, Offset: 4}, To: tokens.Pos{Line: 2, Column: 4, Offset: 4}},
-					Name:        "field",
+					Range:    tokens.Range{File: "test.sentra", From: tokens.Pos{Line: 2, Column: 4, Offset: 4}, To: tokens.Pos{Line: 2, Column: 4, Offset: 4}},
+					Name:     "field",
 					Optional: false,
-					Type:        ast.NewStringTypeRef(tokens.Range{File: "test.sentra", From: tokens.Pos{Line: 2, Column: 10, Offset: 10}, To: tokens.Pos{Line: 2, Column: 10, Offset: 10}}),
+					Type:     ast.NewStringTypeRef(tokens.Range{File: "test.sentra", From: tokens.Pos{Line: 2, Column: 10, Offset: 10}, To: tokens.Pos{Line: 2, Column: 10, Offset: 10}}),
 				},
 			},
 		},
@@ -487,10 +500,10 @@ func (s *IndexTestSuite) TestShapeDependency_MultipleShapesDependingOnSameBase()
 			With:  nil,
 			Fields: map[string]*ast.ShapeField{
 				"id": {
-					Range:       tokens.Range{File: "test.sentra", From: tokens.Pos{Line: 2, Column: 4, Offset: 4}, To: tokens.Pos{Line: 2, Column: 4, Offset: 4}},
-					Name:        "id",
+					Range:    tokens.Range{File: "test.sentra", From: tokens.Pos{Line: 2, Column: 4, Offset: 4}, To: tokens.Pos{Line: 2, Column: 4, Offset: 4}},
+					Name:     "id",
 					Optional: false,
-					Type:        ast.NewStringTypeRef(tokens.Range{File: "test.sentra", From: tokens.Pos{Line: 2, Column: 8, Offset: 8}, To: tokens.Pos{Line: 2, Column: 8, Offset: 8}}),
+					Type:     ast.NewStringTypeRef(tokens.Range{File: "test.sentra", From: tokens.Pos{Line: 2, Column: 8, Offset: 8}, To: tokens.Pos{Line: 2, Column: 8, Offset: 8}}),
 				},
 			},
 		},
@@ -506,10 +519,10 @@ func (s *IndexTestSuite) TestShapeDependency_MultipleShapesDependingOnSameBase()
 			With:  ast.NewFQN([]string{"BaseEntity"}, tokens.Range{File: "test.sentra", From: tokens.Pos{Line: 5, Column: 10, Offset: 10}, To: tokens.Pos{Line: 5, Column: 10, Offset: 10}}).Ptr(),
 			Fields: map[string]*ast.ShapeField{
 				"name": {
-					Range:       tokens.Range{File: "test.sentra", From: tokens.Pos{Line: 6, Column: 4, Offset: 4}, To: tokens.Pos{Line: 6, Column: 4, Offset: 4}},
-					Name:        "name",
+					Range:    tokens.Range{File: "test.sentra", From: tokens.Pos{Line: 6, Column: 4, Offset: 4}, To: tokens.Pos{Line: 6, Column: 4, Offset: 4}},
+					Name:     "name",
 					Optional: false,
-					Type:        ast.NewStringTypeRef(tokens.Range{File: "test.sentra", From: tokens.Pos{Line: 6, Column: 10, Offset: 10}, To: tokens.Pos{Line: 6, Column: 10, Offset: 10}}),
+					Type:     ast.NewStringTypeRef(tokens.Range{File: "test.sentra", From: tokens.Pos{Line: 6, Column: 10, Offset: 10}, To: tokens.Pos{Line: 6, Column: 10, Offset: 10}}),
 				},
 			},
 		},
@@ -525,10 +538,10 @@ func (s *IndexTestSuite) TestShapeDependency_MultipleShapesDependingOnSameBase()
 			With:  ast.NewFQN([]string{"BaseEntity"}, tokens.Range{File: "test.sentra", From: tokens.Pos{Line: 9, Column: 10, Offset: 10}, To: tokens.Pos{Line: 9, Column: 10, Offset: 10}}).Ptr(),
 			Fields: map[string]*ast.ShapeField{
 				"title": {
-					Range:       tokens.Range{File: "test.sentra", From: tokens.Pos{Line: 10, Column: 4, Offset: 4}, To: tokens.Pos{Line: 10, Column: 4, Offset: 4}},
-					Name:        "title",
+					Range:    tokens.Range{File: "test.sentra", From: tokens.Pos{Line: 10, Column: 4, Offset: 4}, To: tokens.Pos{Line: 10, Column: 4, Offset: 4}},
+					Name:     "title",
 					Optional: false,
-					Type:        ast.NewStringTypeRef(tokens.Range{File: "test.sentra", From: tokens.Pos{Line: 10, Column: 10, Offset: 10}, To: tokens.Pos{Line: 10, Column: 10, Offset: 10}}),
+					Type:     ast.NewStringTypeRef(tokens.Range{File: "test.sentra", From: tokens.Pos{Line: 10, Column: 10, Offset: 10}, To: tokens.Pos{Line: 10, Column: 10, Offset: 10}}),
 				},
 			},
 		},
@@ -612,10 +625,10 @@ func (s *IndexTestSuite) TestShapeDependency_DeepDependencyChain() {
 				With:  withFQN,
 				Fields: map[string]*ast.ShapeField{
 					shapeInfo.field: {
-						Range:       tokens.Range{File: "test.sentra", From: tokens.Pos{Line: shapeInfo.line + 1, Column: 4, Offset: 4}, To: tokens.Pos{Line: shapeInfo.line + 1, Column: 14, Offset: 14}},
-						Name:        shapeInfo.field,
-						Optional:    false,
-						Type:        ast.NewStringTypeRef(tokens.Range{File: "test.sentra", From: tokens.Pos{Line: shapeInfo.line + 1, Column: 10, Offset: 10}, To: tokens.Pos{Line: shapeInfo.line + 1, Column: 20, Offset: 20}}),
+						Range:    tokens.Range{File: "test.sentra", From: tokens.Pos{Line: shapeInfo.line + 1, Column: 4, Offset: 4}, To: tokens.Pos{Line: shapeInfo.line + 1, Column: 14, Offset: 14}},
+						Name:     shapeInfo.field,
+						Optional: false,
+						Type:     ast.NewStringTypeRef(tokens.Range{File: "test.sentra", From: tokens.Pos{Line: shapeInfo.line + 1, Column: 10, Offset: 10}, To: tokens.Pos{Line: shapeInfo.line + 1, Column: 20, Offset: 20}}),
 					},
 				},
 			},
@@ -663,10 +676,10 @@ func (s *IndexTestSuite) TestShapeDependency_ShapeWithEmptyWithFQN() {
 			With:  nil, // Empty FQN
 			Fields: map[string]*ast.ShapeField{
 				"field": {
-					Range:       tokens.Range{File: "test.sentra", From: tokens.Pos{Line: 2, Column: 4, Offset: 4}, To: tokens.Pos{Line: 2, Column: 4, Offset: 4}},
-					Name:        "field",
+					Range:    tokens.Range{File: "test.sentra", From: tokens.Pos{Line: 2, Column: 4, Offset: 4}, To: tokens.Pos{Line: 2, Column: 4, Offset: 4}},
+					Name:     "field",
 					Optional: false,
-					Type:        ast.NewStringTypeRef(tokens.Range{File: "test.sentra", From: tokens.Pos{Line: 2, Column: 10, Offset: 10}, To: tokens.Pos{Line: 2, Column: 10, Offset: 10}}),
+					Type:     ast.NewStringTypeRef(tokens.Range{File: "test.sentra", From: tokens.Pos{Line: 2, Column: 10, Offset: 10}, To: tokens.Pos{Line: 2, Column: 10, Offset: 10}}),
 				},
 			},
 		},
@@ -751,10 +764,10 @@ func (s *IndexTestSuite) TestShapeDependency_ShapeWithDuplicateFieldNames() {
 			With:  nil,
 			Fields: map[string]*ast.ShapeField{
 				"id": {
-					Range:       tokens.Range{File: "test.sentra", From: tokens.Pos{Line: 2, Column: 4, Offset: 4}, To: tokens.Pos{Line: 2, Column: 4, Offset: 4}},
-					Name:        "id",
+					Range:    tokens.Range{File: "test.sentra", From: tokens.Pos{Line: 2, Column: 4, Offset: 4}, To: tokens.Pos{Line: 2, Column: 4, Offset: 4}},
+					Name:     "id",
 					Optional: false,
-					Type:        ast.NewStringTypeRef(tokens.Range{File: "test.sentra", From: tokens.Pos{Line: 2, Column: 8, Offset: 8}, To: tokens.Pos{Line: 2, Column: 8, Offset: 8}}),
+					Type:     ast.NewStringTypeRef(tokens.Range{File: "test.sentra", From: tokens.Pos{Line: 2, Column: 8, Offset: 8}, To: tokens.Pos{Line: 2, Column: 8, Offset: 8}}),
 				},
 			},
 		},
@@ -770,10 +783,10 @@ func (s *IndexTestSuite) TestShapeDependency_ShapeWithDuplicateFieldNames() {
 			With:  ast.NewFQN([]string{"BaseEntity"}, tokens.Range{File: "test.sentra", From: tokens.Pos{Line: 5, Column: 10, Offset: 10}, To: tokens.Pos{Line: 5, Column: 10, Offset: 10}}).Ptr(),
 			Fields: map[string]*ast.ShapeField{
 				"id": { // This will conflict with the base shape's "id" field
-					Range:       tokens.Range{File: "test.sentra", From: tokens.Pos{Line: 6, Column: 4, Offset: 4}, To: tokens.Pos{Line: 6, Column: 4, Offset: 4}},
-					Name:        "id",
+					Range:    tokens.Range{File: "test.sentra", From: tokens.Pos{Line: 6, Column: 4, Offset: 4}, To: tokens.Pos{Line: 6, Column: 4, Offset: 4}},
+					Name:     "id",
 					Optional: false,
-					Type:        ast.NewStringTypeRef(tokens.Range{File: "test.sentra", From: tokens.Pos{Line: 6, Column: 8, Offset: 8}, To: tokens.Pos{Line: 6, Column: 8, Offset: 8}}),
+					Type:     ast.NewStringTypeRef(tokens.Range{File: "test.sentra", From: tokens.Pos{Line: 6, Column: 8, Offset: 8}, To: tokens.Pos{Line: 6, Column: 8, Offset: 8}}),
 				},
 			},
 		},
@@ -792,16 +805,107 @@ func (s *IndexTestSuite) TestShapeDependency_ShapeWithDuplicateFieldNames() {
 	err = ns.addShape(dependentShape)
 	s.Require().NoError(err)
 
-	// Validate the index - should pass (duplicate field names are handled during hydration, not validation)
+	// Validate the index - should fail, redefining a base field requires `override`
 	err = idx.Validate(ctx)
+	s.Require().Error(err)
+	s.Contains(err.Error(), "shape field 'id' of 'com/example/UserWithDuplicateField' redefines a field of 'com/example/BaseEntity' without 'override'")
+
+	// the conflict carries the redefinition, and the field it shadows
+	var conflict xerr.ConflictError
+	s.Require().ErrorAs(err, &conflict)
+	s.Equal(dependentShapeStmt.Complex.Fields["id"].Range, conflict.Where())
+	s.Contains(err.Error(), baseShapeStmt.Complex.Fields["id"].Range.String())
+}
+
+func (s *IndexTestSuite) TestShapeDependency_OverrideFieldShadowsComposedField() {
+	idx := s.indexFromSource(nil, `namespace com/example
+shape Base { id: string
+  name: string }
+shape Mid with Base { label: string }
+shape User with Mid { override id: number }`)
+
+	s.Require().NoError(idx.Commit(s.ctx))
+	s.Empty(idx.Warnings())
+
+	// the override wins over the field composed from Base through Mid
+	user := idx.Namespaces["com/example"].Shapes["User"]
+	s.Require().Contains(user.Model.Fields, "id")
+	s.True(user.Model.Fields["id"].Node.Override)
+	s.Contains(user.Model.Fields, "name")
+	s.Contains(user.Model.Fields, "label")
+}
+
+func (s *IndexTestSuite) TestShapeDependency_OverrideReplacesTheComposedTypeRef() {
+	idx := s.indexFromSource(nil, `namespace com/example
+shape Base { id: string }
+shape Derived with Base { override id: number }`)
+
+	s.Require().NoError(idx.Commit(s.ctx))
+
+	base := idx.Namespaces["com/example"].Shapes["Base"]
+	s.IsType(&ast.StringTypeRef{}, base.Model.Fields["id"].TypeRef)
+	derived := idx.Namespaces["com/example"].Shapes["Derived"]
+	s.IsType(&ast.NumberTypeRef{}, derived.Model.Fields["id"].TypeRef)
+}
+
+func (s *IndexTestSuite) TestShapeDependency_OverrideOfAnUndeclaredFieldFails() {
+	idx := s.indexFromSource(nil, `namespace com/example
+shape Base { id: string }
+shape Mid with Base { label: string }
+shape User with Mid { override name: string }`)
+
+	err := idx.Validate(s.ctx)
+	s.Require().Error(err)
+	s.Contains(err.Error(), "shape field 'name' of 'com/example/User' is marked 'override' at")
+	s.Contains(err.Error(), "but no shape it is composed with declares it")
+}
+
+func (s *IndexTestSuite) TestShapeDependency_OverrideWithoutCompositionFails() {
+	idx := s.indexFromSource(nil, `namespace com/example
+shape User { override id: string }`)
+
+	err := idx.Validate(s.ctx)
+	s.Require().Error(err)
+	s.Contains(err.Error(), "shape field 'id' of 'com/example/User' is marked 'override'")
+}
+
+func (s *IndexTestSuite) TestShapeDependency_DefaultOnRequiredFieldFails() {
+	program, err := parser.NewParserFromString(`namespace com/example
+shape User { role: string default "guest" }`, "test.sentrie").ParseProgram(s.ctx)
 	s.Require().NoError(err)
 
-	// Verify both shapes are properly indexed
-	s.Contains(ns.Shapes, "BaseEntity")
-	s.Contains(ns.Shapes, "UserWithDuplicateField")
+	err = CreateIndex().AddProgram(s.ctx, program)
+	s.Require().Error(err)
+	s.Contains(err.Error(), "required shape field 'role' cannot have a default")
+}
 
-	// Verify dependency relationship
-	s.Equal("BaseEntity", dependentShape.Model.WithFQN.String())
+func (s *IndexTestSuite) TestShapeDependency_ShadowedFieldAcrossCompositionChain() {
+	idx := s.indexFromSource(nil, `namespace com/example
+shape Base { id: string }
+shape Mid with Base { label: string }
+shape User with Mid { id: number }`)
+
+	err := idx.Validate(s.ctx)
+	s.Require().Error(err)
+	s.Contains(err.Error(), "shape field 'id' of 'com/example/User' redefines a field of 'com/example/Mid' without 'override'")
+}
+
+func (s *IndexTestSuite) TestShapeDependency_ShadowedFieldDowngradedToWarning() {
+	idx := s.indexFromSource([]IndexOption{WithShadowedFieldWarnings()}, `namespace com/example
+shape Base { id: string }
+shape User with Base { id: number }`)
+
+	s.Require().NoError(idx.Commit(s.ctx))
+
+	warnings := idx.Warnings()
+	s.Require().Len(warnings, 1)
+	s.Equal(SeverityWarning, warnings[0].Severity)
+	s.Equal(CodeShadowedField, warnings[0].Code)
+	s.Contains(warnings[0].Message, "shape field 'id' of 'com/example/User' shadows the field of 'com/example/Base'")
+
+	// the redefinition shadows the composed field
+	user := idx.Namespaces["com/example"].Shapes["User"]
+	s.IsType(&ast.NumberTypeRef{}, user.Model.Fields["id"].TypeRef)
 }
 
 // Shape with very long FQN - verify shapes with long names work correctly
@@ -904,22 +1008,22 @@ func (s *IndexTestSuite) TestShapeDependency_ShapeWithMultipleFields() {
 			With:  nil,
 			Fields: map[string]*ast.ShapeField{
 				"id": {
-					Range:       tokens.Range{File: "test.sentra", From: tokens.Pos{Line: 2, Column: 4, Offset: 4}, To: tokens.Pos{Line: 2, Column: 4, Offset: 4}},
-					Name:        "id",
+					Range:    tokens.Range{File: "test.sentra", From: tokens.Pos{Line: 2, Column: 4, Offset: 4}, To: tokens.Pos{Line: 2, Column: 4, Offset: 4}},
+					Name:     "id",
 					Optional: false,
-					Type:        ast.NewStringTypeRef(tokens.Range{File: "test.sentra", From: tokens.Pos{Line: 2, Column: 8, Offset: 8}, To: tokens.Pos{Line: 2, Column: 8, Offset: 8}}),
+					Type:     ast.NewStringTypeRef(tokens.Range{File: "test.sentra", From: tokens.Pos{Line: 2, Column: 8, Offset: 8}, To: tokens.Pos{Line: 2, Column: 8, Offset: 8}}),
 				},
 				"name": {
-					Range:       tokens.Range{File: "test.sentra", From: tokens.Pos{Line: 3, Column: 4, Offset: 4}, To: tokens.Pos{Line: 3, Column: 4, Offset: 4}},
-					Name:        "name",
+					Range:    tokens.Range{File: "test.sentra", From: tokens.Pos{Line: 3, Column: 4, Offset: 4}, To: tokens.Pos{Line: 3, Column: 4, Offset: 4}},
+					Name:     "name",
 					Optional: false,
-					Type:        ast.NewStringTypeRef(tokens.Range{File: "test.sentra", From: tokens.Pos{Line: 3, Column: 10, Offset: 10}, To: tokens.Pos{Line: 3, Column: 10, Offset: 10}}),
+					Type:     ast.NewStringTypeRef(tokens.Range{File: "test.sentra", From: tokens.Pos{Line: 3, Column: 10, Offset: 10}, To: tokens.Pos{Line: 3, Column: 10, Offset: 10}}),
 				},
 				"email": {
-					Range:       tokens.Range{File: "test.sentra", From: tokens.Pos{Line: 4, Column: 4, Offset: 4}, To: tokens.Pos{Line: 4, Column: 4, Offset: 4}},
-					Name:        "email",
+					Range:    tokens.Range{File: "test.sentra", From: tokens.Pos{Line: 4, Column: 4, Offset: 4}, To: tokens.Pos{Line: 4, Column: 4, Offset: 4}},
+					Name:     "email",
 					Optional: false,
-					Type:        ast.NewStringTypeRef(tokens.Range{File: "test.sentra", From: tokens.Pos{Line: 4, Column: 10, Offset: 10}, To: tokens.Pos{Line: 4, Column: 10, Offset: 10}}),
+					Type:     ast.NewStringTypeRef(tokens.Range{File: "test.sentra", From: tokens.Pos{Line: 4, Column: 10, Offset: 10}, To: tokens.Pos{Line: 4, Column: 10, Offset: 10}}),
 				},
 			},
 		},
@@ -972,10 +1076,10 @@ func (s *IndexTestSuite) TestShapeDependency_ComplexNestedDependency() {
 			With:  nil,
 			Fields: map[string]*ast.ShapeField{
 				"id": {
-					Range:       tokens.Range{File: "test.sentra", From: tokens.Pos{Line: 2, Column: 4, Offset: 4}, To: tokens.Pos{Line: 2, Column: 4, Offset: 4}},
-					Name:        "id",
+					Range:    tokens.Range{File: "test.sentra", From: tokens.Pos{Line: 2, Column: 4, Offset: 4}, To: tokens.Pos{Line: 2, Column: 4, Offset: 4}},
+					Name:     "id",
 					Optional: false,
-					Type:        ast.NewStringTypeRef(tokens.Range{File: "test.sentra", From: tokens.Pos{Line: 2, Column: 8, Offset: 8}, To: tokens.Pos{Line: 2, Column: 8, Offset: 8}}),
+					Type:     ast.NewStringTypeRef(tokens.Range{File: "test.sentra", From: tokens.Pos{Line: 2, Column: 8, Offset: 8}, To: tokens.Pos{Line: 2, Column: 8, Offset: 8}}),
 				},
 			},
 		},
@@ -991,10 +1095,10 @@ func (s *IndexTestSuite) TestShapeDependency_ComplexNestedDependency() {
 			With:  ast.NewFQN([]string{"BaseEntity"}, tokens.Range{File: "test.sentra", From: tokens.Pos{Line: 5, Column: 10, Offset: 10}, To: tokens.Pos{Line: 5, Column: 10, Offset: 10}}).Ptr(),
 			Fields: map[string]*ast.ShapeField{
 				"name": {
-					Range:       tokens.Range{File: "test.sentra", From: tokens.Pos{Line: 6, Column: 4, Offset: 4}, To: tokens.Pos{Line: 6, Column: 4, Offset: 4}},
-					Name:        "name",
+					Range:    tokens.Range{File: "test.sentra", From: tokens.Pos{Line: 6, Column: 4, Offset: 4}, To: tokens.Pos{Line: 6, Column: 4, Offset: 4}},
+					Name:     "name",
 					Optional: false,
-					Type:        ast.NewStringTypeRef(tokens.Range{File: "test.sentra", From: tokens.Pos{Line: 6, Column: 10, Offset: 10}, To: tokens.Pos{Line: 6, Column: 10, Offset: 10}}),
+					Type:     ast.NewStringTypeRef(tokens.Range{File: "test.sentra", From: tokens.Pos{Line: 6, Column: 10, Offset: 10}, To: tokens.Pos{Line: 6, Column: 10, Offset: 10}}),
 				},
 			},
 		},
@@ -1010,10 +1114,10 @@ func (s *IndexTestSuite) TestShapeDependency_ComplexNestedDependency() {
 			With:  ast.NewFQN([]string{"IntermediateEntity"}, tokens.Range{File: "test.sentra", From: tokens.Pos{Line: 9, Column: 10, Offset: 10}, To: tokens.Pos{Line: 9, Column: 10, Offset: 10}}).Ptr(),
 			Fields: map[string]*ast.ShapeField{
 				"description": {
-					Range:       tokens.Range{File: "test.sentra", From: tokens.Pos{Line: 10, Column: 4, Offset: 4}, To: tokens.Pos{Line: 10, Column: 4, Offset: 4}},
-					Name:        "description",
+					Range:    tokens.Range{File: "test.sentra", From: tokens.Pos{Line: 10, Column: 4, Offset: 4}, To: tokens.Pos{Line: 10, Column: 4, Offset: 4}},
+					Name:     "description",
 					Optional: false,
-					Type:        ast.NewStringTypeRef(tokens.Range{File: "test.sentra", From: tokens.Pos{Line: 10, Column: 15, Offset: 15}, To: tokens.Pos{Line: 10, Column: 15, Offset: 15}}),
+					Type:     ast.NewStringTypeRef(tokens.Range{File: "test.sentra", From: tokens.Pos{Line: 10, Column: 15, Offset: 15}, To: tokens.Pos{Line: 10, Column: 15, Offset: 15}}),
 				},
 			},
 		},
@@ -1084,10 +1188,10 @@ func (s *IndexTestSuite) TestShapeDependency_CompositionWithUnexportedShapeCross
 			With:  nil,
 			Fields: map[string]*ast.ShapeField{
 				"id": {
-					Range:       tokens.Range{File: "test1.sentra", From: tokens.Pos{Line: 2, Column: 4, Offset: 4}, To: tokens.Pos{Line: 2, Column: 4, Offset: 4}},
-					Name:        "id",
+					Range:    tokens.Range{File: "test1.sentra", From: tokens.Pos{Line: 2, Column: 4, Offset: 4}, To: tokens.Pos{Line: 2, Column: 4, Offset: 4}},
+					Name:     "id",
 					Optional: false,
-					Type:        ast.NewStringTypeRef(tokens.Range{File: "test1.sentra", From: tokens.Pos{Line: 2, Column: 8, Offset: 8}, To: tokens.Pos{Line: 2, Column: 8, Offset: 8}}),
+					Type:     ast.NewStringTypeRef(tokens.Range{File: "test1.sentra", From: tokens.Pos{Line: 2, Column: 8, Offset: 8}, To: tokens.Pos{Line: 2, Column: 8, Offset: 8}}),
 				},
 			},
 		},
@@ -1103,10 +1207,10 @@ func (s *IndexTestSuite) TestShapeDependency_CompositionWithUnexportedShapeCross
 			With:  ast.NewFQN([]string{"com", "example", "shared", "UnexportedShape"}, tokens.Range{File: "test2.sentra", From: tokens.Pos{Line: 1, Column: 10, Offset: 10}, To: tokens.Pos{Line: 1, Column: 10, Offset: 10}}).Ptr(), // tries to compose with unexported shape
 			Fields: map[string]*ast.ShapeField{
 				"name": {
-					Range:       tokens.Range{File: "test2.sentra", From: tokens.Pos{Line: 2, Column: 4, Offset: 4}, To: tokens.Pos{Line: 2, Column: 4, Offset: 4}},
-					Name:        "name",
+					Range:    tokens.Range{File: "test2.sentra", From: tokens.Pos{Line: 2, Column: 4, Offset: 4}, To: tokens.Pos{Line: 2, Column: 4, Offset: 4}},
+					Name:     "name",
 					Optional: false,
-					Type:        ast.NewStringTypeRef(tokens.Range{File: "test2.sentra", From: tokens.Pos{Line: 2, Column: 10, Offset: 10}, To: tokens.Pos{Line: 2, Column: 10, Offset: 10}}),
+					Type:     ast.NewStringTypeRef(tokens.Range{File: "test2.sentra", From: tokens.Pos{Line: 2, Column: 10, Offset: 10}, To: tokens.Pos{Line: 2, Column: 10, Offset: 10}}),
 				},
 			},
 		},
@@ -1124,10 +1228,11 @@ func (s *IndexTestSuite) TestShapeDependency_CompositionWithUnexportedShapeCross
 	err = ns2.addShape(dependentShape)
 	s.Require().NoError(err)
 
-	// Validate the index - currently passes but should fail because unexported shapes cannot be accessed cross-namespace
-	// NOTE: This is a bug in the current implementation - ResolveShape doesn't check if shapes are exported
+	// Validate the index - unexported shapes cannot be composed from another namespace
 	err = idx.Validate(ctx)
-	s.Require().NoError(err) // Current implementation incorrectly allows this
+	s.Require().Error(err)
+	s.Contains(err.Error(), "shape 'com/example/shared/UnexportedShape' is not exported")
+	s.ErrorIs(err, xerr.ErrIndex)
 
 	// Verify both shapes are properly indexed
 	s.Contains(ns1.Shapes, "UnexportedShape")
@@ -1135,9 +1240,6 @@ func (s *IndexTestSuite) TestShapeDependency_CompositionWithUnexportedShapeCross
 
 	// Verify dependency relationship
 	s.Equal("com/example/shared/UnexportedShape", dependentShape.Model.WithFQN.String())
-
-	// Verify shape DAG is created correctly
-	s.NotNil(idx.shapeDag)
 }
 
 // Shape composition with exported shape cross-namespace - verify we can compose with exported shapes
@@ -1170,10 +1272,10 @@ func (s *IndexTestSuite) TestShapeDependency_CompositionWithExportedShapeCrossNa
 			With:  nil,
 			Fields: map[string]*ast.ShapeField{
 				"id": {
-					Range:       tokens.Range{File: "test1.sentra", From: tokens.Pos{Line: 2, Column: 4, Offset: 4}, To: tokens.Pos{Line: 2, Column: 4, Offset: 4}},
-					Name:        "id",
+					Range:    tokens.Range{File: "test1.sentra", From: tokens.Pos{Line: 2, Column: 4, Offset: 4}, To: tokens.Pos{Line: 2, Column: 4, Offset: 4}},
+					Name:     "id",
 					Optional: false,
-					Type:        ast.NewStringTypeRef(tokens.Range{File: "test1.sentra", From: tokens.Pos{Line: 2, Column: 8, Offset: 8}, To: tokens.Pos{Line: 2, Column: 8, Offset: 8}}),
+					Type:     ast.NewStringTypeRef(tokens.Range{File: "test1.sentra", From: tokens.Pos{Line: 2, Column: 8, Offset: 8}, To: tokens.Pos{Line: 2, Column: 8, Offset: 8}}),
 				},
 			},
 		},
@@ -1195,10 +1297,10 @@ func (s *IndexTestSuite) TestShapeDependency_CompositionWithExportedShapeCrossNa
 			With:  ast.NewFQN([]string{"com", "example", "shared", "ExportedShape"}, tokens.Range{File: "test2.sentra", From: tokens.Pos{Line: 1, Column: 10, Offset: 10}, To: tokens.Pos{Line: 1, Column: 10, Offset: 10}}).Ptr(), // tries to compose with exported shape
 			Fields: map[string]*ast.ShapeField{
 				"name": {
-					Range:       tokens.Range{File: "test2.sentra", From: tokens.Pos{Line: 2, Column: 4, Offset: 4}, To: tokens.Pos{Line: 2, Column: 4, Offset: 4}},
-					Name:        "name",
+					Range:    tokens.Range{File: "test2.sentra", From: tokens.Pos{Line: 2, Column: 4, Offset: 4}, To: tokens.Pos{Line: 2, Column: 4, Offset: 4}},
+					Name:     "name",
 					Optional: false,
-					Type:        ast.NewStringTypeRef(tokens.Range{File: "test2.sentra", From: tokens.Pos{Line: 2, Column: 10, Offset: 10}, To: tokens.Pos{Line: 2, Column: 10, Offset: 10}}),
+					Type:     ast.NewStringTypeRef(tokens.Range{File: "test2.sentra", From: tokens.Pos{Line: 2, Column: 10, Offset: 10}, To: tokens.Pos{Line: 2, Column: 10, Offset: 10}}),
 				},
 			},
 		},
@@ -1266,10 +1368,10 @@ func (s *IndexTestSuite) TestShapeDependency_CompositionWithNonExistentShapeCros
 			With:  nil,
 			Fields: map[string]*ast.ShapeField{
 				"id": {
-					Range:       tokens.Range{File: "test1.sentra", From: tokens.Pos{Line: 2, Column: 4, Offset: 4}, To: tokens.Pos{Line: 2, Column: 4, Offset: 4}},
-					Name:        "id",
+					Range:    tokens.Range{File: "test1.sentra", From: tokens.Pos{Line: 2, Column: 4, Offset: 4}, To: tokens.Pos{Line: 2, Column: 4, Offset: 4}},
+					Name:     "id",
 					Optional: false,
-					Type:        ast.NewStringTypeRef(tokens.Range{File: "test1.sentra", From: tokens.Pos{Line: 2, Column: 8, Offset: 8}, To: tokens.Pos{Line: 2, Column: 8, Offset: 8}}),
+					Type:     ast.NewStringTypeRef(tokens.Range{File: "test1.sentra", From: tokens.Pos{Line: 2, Column: 8, Offset: 8}, To: tokens.Pos{Line: 2, Column: 8, Offset: 8}}),
 				},
 			},
 		},
@@ -1285,10 +1387,10 @@ func (s *IndexTestSuite) TestShapeDependency_CompositionWithNonExistentShapeCros
 			With:  ast.NewFQN([]string{"com", "example", "shared", "NonExistentShape"}, tokens.Range{File: "test2.sentra", From: tokens.Pos{Line: 1, Column: 10, Offset: 10}, To: tokens.Pos{Line: 1, Column: 10, Offset: 10}}).Ptr(), // tries to compose with non-existent shape
 			Fields: map[string]*ast.ShapeField{
 				"name": {
-					Range:       tokens.Range{File: "test2.sentra", From: tokens.Pos{Line: 2, Column: 4, Offset: 4}, To: tokens.Pos{Line: 2, Column: 4, Offset: 4}},
-					Name:        "name",
+					Range:    tokens.Range{File: "test2.sentra", From: tokens.Pos{Line: 2, Column: 4, Offset: 4}, To: tokens.Pos{Line: 2, Column: 4, Offset: 4}},
+					Name:     "name",
 					Optional: false,
-					Type:        ast.NewStringTypeRef(tokens.Range{File: "test2.sentra", From: tokens.Pos{Line: 2, Column: 10, Offset: 10}, To: tokens.Pos{Line: 2, Column: 10, Offset: 10}}),
+					Type:     ast.NewStringTypeRef(tokens.Range{File: "test2.sentra", From: tokens.Pos{Line: 2, Column: 10, Offset: 10}, To: tokens.Pos{Line: 2, Column: 10, Offset: 10}}),
 				},
 			},
 		},
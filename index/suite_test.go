@@ -106,11 +106,15 @@ var policyFixtureTests = map[string]bool{
 	"TestAddFactWithNameConflict":                            true,
 	"TestPolicyString":                                       true,
 	"TestCreatePolicyWithComments":                           true,
+	"TestCreatePolicyWithBlockComments":                      true,
 	"TestCreatePolicyWithValidUseStatement":                  true,
 	"TestCreatePolicyWithMetadataAndPhases":                  true,
 	"TestCreatePolicyUseWithoutFacts":                        true,
+	"TestPolicyMetadataReturnsDeclaredMetadata":              true,
+	"TestPolicyMetadataEmptyWhenUndeclared":                  true,
 	"TestCreatePolicyMetadataThenUseWithoutFacts":            true,
 	"TestCreatePolicyVersionVPrefixAccepted":                 true,
+	"TestCreatePolicyVersionPartialAccepted":                 true,
 	"TestCreatePolicyFactAfterUseErrors":                     true,
 	"TestCreatePolicyMetadataAfterFactErrors":                true,
 	"TestCreatePolicyShapeBeforeFactErrors":                  true,
@@ -121,6 +125,10 @@ var policyFixtureTests = map[string]bool{
 	"TestCreatePolicyVersionWhitespacePaddedLiteralAccepted": true,
 	"TestCreatePolicyUnsupportedShapeExportInBody":           true,
 	"TestCreatePolicyDuplicateUseAliasRebind":                true,
+	"TestCreatePolicyFactAliasCollidesWithUseAlias":          true,
+	"TestCreatePolicyLetAndShapeCollideWithUseAlias":         true,
+	"TestCreatePolicyUseAliasDistinctFromFacts":              true,
+	"TestCreatePolicySameUseTargetDifferentAliases":          true,
 	"TestCreatePolicyDuplicateRuleExportAttachmentName":      true,
 	"TestPolicyAddShapeDuplicateInnerFieldName":              true,
 	"TestCreatePolicyTitleAfterRuleLateHeader":               true,
@@ -138,6 +146,9 @@ var policyFixtureTests = map[string]bool{
 	"TestCreatePolicyShapeBodyDuplicateFieldErrors":          true,
 	"TestCreatePolicyDescriptionAfterFactContiguousMetadata": true,
 	"TestCreatePolicyVersionAfterFactContiguousMetadata":     true,
+	"TestCreatePolicyCombining":                              true,
+	"TestCreatePolicyUnknownCombining":                       true,
+	"TestCreatePolicyDuplicateCombining":                     true,
 }
 
 var namespaceFixtureTests = map[string]bool{
@@ -17,9 +17,11 @@
 package index
 
 import (
-	"cmp"
 	"context"
+	"errors"
 	"fmt"
+	"maps"
+	"slices"
 	"strings"
 
 	"github.com/sentrie-sh/sentrie/ast"
@@ -27,25 +29,25 @@ import (
 	"github.com/sentrie-sh/sentrie/xerr"
 )
 
-// Validate the index for consistency and correctness.
+// Validate the index for consistency and correctness and, if it is valid,
+// commit it. Both validation and commit errors are returned.
 // Checks for:
 // - Cyclic dependencies
 func (idx *Index) Validate(ctx context.Context) error {
+	if err := idx.runValidation(ctx); err != nil {
+		return err
+	}
+	return idx.Commit(ctx)
+}
+
+// runValidation validates the index exactly once and returns the cached result.
+func (idx *Index) runValidation(ctx context.Context) error {
 	idx.validationOnce.Do(func() {
 		idx.validationError = idx.validate(ctx)
 		if idx.validationError != nil {
 			idx.validationError = fmt.Errorf("validation error: %w", idx.validationError)
 		}
 		idx.validated = 1
-
-		if idx.validationError != nil {
-			// we couldn't validate the index, so we can't commit
-			return
-		}
-
-		if err := idx.Commit(ctx); err != nil {
-			return
-		}
 	})
 	return idx.validationError
 }
@@ -63,6 +65,9 @@ func (idx *Index) validate(ctx context.Context) error {
 	if err != nil {
 		return err
 	}
+	if err := idx.detectPolicyImportCycle(ctx); err != nil {
+		return err
+	}
 	sg, err := idx.detectShapeCycle(ctx)
 	if err != nil {
 		return err
@@ -71,9 +76,142 @@ func (idx *Index) validate(ctx context.Context) error {
 	idx.ruleDag = rg
 	idx.shapeDag = sg
 
+	// the remaining checks are independent of each other once there are no cycles, so all of
+	// them run and every error they find is reported
+	errs := []error{
+		idx.detectShadowedFields(ctx),
+		idx.validateShapeImports(ctx),
+		idx.validateRequires(ctx),
+		idx.validateDirectiveShapes(ctx),
+		idx.detectAmbiguousShapeRefs(ctx),
+	}
+	if idx.requireFacts {
+		errs = append(errs, idx.detectFactlessReferences(ctx))
+	}
+	if err := errors.Join(errs...); err != nil {
+		return err
+	}
+
+	idx.detectUnusedFacts(ctx)
+
 	return nil
 }
 
+// validateRequires checks that the shape of every require statement resolves, and is exported
+// when it belongs to another namespace.
+func (idx *Index) validateRequires(ctx context.Context) error {
+	var errs []error
+	for _, ns := range idx.Namespaces {
+		if !idx.inScope(ns) {
+			continue
+		}
+		for _, policy := range ns.Policies {
+			if ctx.Err() != nil {
+				return fmt.Errorf("validation cancelled: %w", xerr.ErrIndex)
+			}
+			for _, req := range policy.Requires {
+				shapeNs, shape, err := idx.ResolveShapeRef(ns, policy, *req.Shape)
+				if err != nil {
+					errs = append(errs, xerr.ErrAt(req.Span(), fmt.Errorf("cannot resolve shape '%s' required of fact '%s' at %s: %w", req.Shape, req.Fact, req.Span(), err)))
+					continue
+				}
+				if shapeNs.FQN.String() != ns.FQN.String() {
+					if err := shapeNs.VerifyShapeExported(shape.Name); err != nil {
+						errs = append(errs, xerr.ErrAt(req.Span(), fmt.Errorf("shape '%s' required of fact '%s' at %s: %w", req.Shape, req.Fact, req.Span(), err)))
+					}
+				}
+			}
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// validateDirectiveShapes checks that the shape an obligation or advice declares its value
+// conforms to resolves, and is exported when it belongs to another namespace.
+func (idx *Index) validateDirectiveShapes(ctx context.Context) error {
+	var errs []error
+	for _, ns := range idx.Namespaces {
+		if !idx.inScope(ns) {
+			continue
+		}
+		for _, policy := range ns.Policies {
+			if ctx.Err() != nil {
+				return fmt.Errorf("validation cancelled: %w", xerr.ErrIndex)
+			}
+			for _, export := range policy.RuleExports {
+				for _, a := range export.Attachments {
+					shapeRef, ok := ast.UnwrapNullableTypeRef(a.Type).(*ast.ShapeTypeRef)
+					if !ok {
+						continue
+					}
+					shapeNs, shape, err := idx.ResolveShapeRef(ns, policy, *shapeRef.Ref)
+					if err != nil {
+						errs = append(errs, xerr.ErrAt(a.Type.Span(), fmt.Errorf("cannot resolve shape '%s' of %s '%s' at %s: %w", shapeRef.Ref, a.Directive, a.Name, a.Type.Span(), err)))
+						continue
+					}
+					if shapeNs.FQN.String() != ns.FQN.String() {
+						if err := shapeNs.VerifyShapeExported(shape.Name); err != nil {
+							errs = append(errs, xerr.ErrAt(a.Type.Span(), fmt.Errorf("shape '%s' of %s '%s' at %s: %w", shapeRef.Ref, a.Directive, a.Name, a.Type.Span(), err)))
+						}
+					}
+				}
+			}
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// detectAmbiguousShapeRefs checks that no shape type written in a policy, such as the type of a
+// fact, matches more than one shape. Whether it resolves at all is left to evaluation, but an
+// ambiguity is reported here, so that it also surfaces when a shape added to another namespace
+// causes it.
+func (idx *Index) detectAmbiguousShapeRefs(ctx context.Context) error {
+	var errs []error
+	for _, ns := range idx.Namespaces {
+		if !idx.inScope(ns) {
+			continue
+		}
+		for _, policy := range ns.Policies {
+			if ctx.Err() != nil {
+				return fmt.Errorf("validation cancelled: %w", xerr.ErrIndex)
+			}
+			ast.Inspect(policy.Statement, func(n ast.Node) bool {
+				t, ok := n.(*ast.ShapeTypeRef)
+				if !ok {
+					return true
+				}
+				if _, _, err := idx.ResolveShapeRef(ns, policy, *t.Ref); errors.Is(err, xerr.AmbiguousReferenceError{}) {
+					errs = append(errs, xerr.ErrAt(t.Span(), fmt.Errorf("cannot resolve shape '%s' at %s: %w", t.Ref, t.Span(), err)))
+				}
+				return true
+			})
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// validateShapeImports checks that every shape imported by a use statement exists and is
+// exported by its namespace.
+func (idx *Index) validateShapeImports(ctx context.Context) error {
+	var errs []error
+	for _, ns := range idx.Namespaces {
+		if !idx.inScope(ns) {
+			continue
+		}
+		for _, policy := range ns.Policies {
+			if ctx.Err() != nil {
+				return fmt.Errorf("validation cancelled: %w", xerr.ErrIndex)
+			}
+			for _, use := range policy.ShapeImports {
+				if _, _, err := resolveShapeImport(idx.Namespaces, use); err != nil {
+					errs = append(errs, xerr.ErrAt(use.Span(), fmt.Errorf("cannot import shape '%s' from '%s' at %s: %w", use.Modules[0], use.ShapeFrom, use.Span(), err)))
+				}
+			}
+		}
+	}
+	return errors.Join(errs...)
+}
+
 type String string
 
 func (s String) String() string {
@@ -87,6 +225,9 @@ func (idx *Index) detectReferenceCycle(ctx context.Context) error {
 			return fmt.Errorf("validation cancelled: %w", xerr.ErrIndex)
 		default:
 		}
+		if !idx.inScope(ns) {
+			continue
+		}
 
 		for _, policy := range ns.Policies {
 			g := dag.New[String]()
@@ -99,12 +240,26 @@ func (idx *Index) detectReferenceCycle(ctx context.Context) error {
 					return fmt.Errorf("validation cancelled: %w", xerr.ErrIndex)
 				}
 				g.AddNode(String(rule.Name))
-				addNodes(g, []ast.Node{rule.Default, rule.When, rule.Body}, String(rule.Name), policy)
+				if err := addNodes(g, []ast.Node{rule.Default, rule.When, rule.Body}, String(rule.Name), policy); err != nil {
+					return err
+				}
 			}
 
 			for _, let := range policy.Lets {
 				g.AddNode(String(let.Name))
-				addNodes(g, []ast.Node{let.Value}, String(let.Name), policy)
+				if err := addNodes(g, []ast.Node{let.Value}, String(let.Name), policy); err != nil {
+					return err
+				}
+			}
+
+			// derives are evaluated once per arguments and cached like lets, so a cycle through
+			// them would wait on itself. Their parameters shadow the names of the policy
+			for _, derive := range policy.Derives {
+				g.AddNode(String(derive.Name))
+				params := shadowingGraph{G: g, names: derive.Params}
+				if err := addNodes(params, []ast.Node{derive.Body}, String(derive.Name), policy); err != nil {
+					return err
+				}
 			}
 
 			cycles := g.DetectFirstCycle()
@@ -120,56 +275,139 @@ func (idx *Index) detectReferenceCycle(ctx context.Context) error {
 	return nil
 }
 
-func addNodes(g dag.G[String], nodes []ast.Node, referedBy String, policy *Policy) {
+// addNodes adds an edge from referedBy to every let or rule referenced below nodes. A direct
+// self-reference of a policy let or rule cannot be represented in the graph, so it is reported
+// as an error instead. References to block-local lets, derive parameters and projection
+// bindings are not edges, as they do not refer to the policy names they shadow.
+func addNodes(g dag.G[String], nodes []ast.Node, referedBy String, policy *Policy) error {
 	for _, node := range nodes {
 		if node == nil {
 			continue
 		}
 
+		var err error
 		switch n := node.(type) {
 		case *ast.Identifier:
-			_ = g.AddEdge(String(referedBy.String()), String(n.Value))
+			if err := g.AddEdge(String(referedBy.String()), String(n.Value)); errors.Is(err, dag.ErrSelfLoop) && declaresTopLevel(policy, n.Value) {
+				return xerr.ErrInfiniteRecursion([]string{referedBy.String(), n.Value})
+			}
 		case *ast.RuleStatement:
 			g.AddNode(String(n.RuleName))
 			_ = g.AddEdge(String(referedBy.String()), String(n.RuleName))
-			addNodes(g, []ast.Node{n.Body}, String(n.RuleName), policy)
+			err = addNodes(g, []ast.Node{n.Body}, String(n.RuleName), policy)
 		case *ast.VarDeclaration:
-			g.AddNode(String(n.Name))
-			_ = g.AddEdge(String(referedBy.String()), String(n.Name))
-			addNodes(g, []ast.Node{n.Value}, String(n.Name), policy)
+			// a block-local let is evaluated on behalf of whatever references the block
+			err = addNodes(g, []ast.Node{n.Value}, referedBy, policy)
 		case *ast.CallExpression:
-			addNodes(g, []ast.Node{n.Callee}, referedBy, policy)
-			for _, arg := range n.Arguments {
-				addNodes(g, []ast.Node{arg}, referedBy, policy)
-			}
+			err = addNodes(g, append([]ast.Node{n.Callee}, expressionNodes(n.Arguments)...), referedBy, policy)
 		case *ast.InfixExpression:
-			addNodes(g, []ast.Node{n.Left, n.Right}, referedBy, policy)
+			err = addNodes(g, []ast.Node{n.Left, n.Right}, referedBy, policy)
 		case *ast.UnaryExpression:
-			addNodes(g, []ast.Node{n.Right}, referedBy, policy)
+			err = addNodes(g, []ast.Node{n.Right}, referedBy, policy)
 		case *ast.TernaryExpression:
-			addNodes(g, []ast.Node{n.Condition, n.ThenBranch, n.ElseBranch}, referedBy, policy)
+			err = addNodes(g, []ast.Node{n.Condition, n.ThenBranch, n.ElseBranch}, referedBy, policy)
+		case *ast.MatchExpression:
+			for _, arm := range n.Arms {
+				if err := addNodes(g, []ast.Node{arm.Condition, arm.Result}, referedBy, policy); err != nil {
+					return err
+				}
+			}
+			err = addNodes(g, []ast.Node{n.Else}, referedBy, policy)
+		case *ast.InterpolatedString:
+			err = addNodes(g, expressionNodes(n.Parts), referedBy, policy)
 		case *ast.BlockExpression:
+			// the lets of a block are visible to all of its statements and its yield, and
+			// shadow the lets, rules and derives of the same name there
+			locals := shadowingGraph{G: g, names: blockLets(n)}
 			for _, stmt := range n.Statements {
-				addNodes(g, []ast.Node{stmt}, referedBy, policy)
+				if err := addNodes(locals, []ast.Node{stmt}, referedBy, policy); err != nil {
+					return err
+				}
 			}
 			// Also check the yield expression
-			addNodes(g, []ast.Node{n.Yield}, referedBy, policy)
+			err = addNodes(locals, []ast.Node{n.Yield}, referedBy, policy)
 		case *ast.ListLiteral:
-			for _, elem := range n.Values {
-				addNodes(g, []ast.Node{elem}, referedBy, policy)
-			}
+			err = addNodes(g, expressionNodes(n.Values), referedBy, policy)
 		case *ast.MapLiteral:
 			for _, entry := range n.Entries {
-				addNodes(g, []ast.Node{entry.Value}, referedBy, policy)
+				if err := addNodes(g, []ast.Node{entry.Value}, referedBy, policy); err != nil {
+					return err
+				}
 			}
 		case *ast.FieldAccessExpression:
-			addNodes(g, []ast.Node{n.Left}, referedBy, policy)
+			err = addNodes(g, []ast.Node{n.Left}, referedBy, policy)
+		case *ast.SliceExpression:
+			err = addNodes(g, []ast.Node{n.Left, n.Start, n.End}, referedBy, policy)
+		case *ast.ProjectExpression:
+			if err := addNodes(g, []ast.Node{n.Source}, referedBy, policy); err != nil {
+				return err
+			}
+			// the projection binding shadows the names of the policy within the entries
+			binding := shadowingGraph{G: g, names: []string{n.As}}
+			for _, entry := range n.Entries {
+				if err := addNodes(binding, []ast.Node{entry.Value}, referedBy, policy); err != nil {
+					return err
+				}
+			}
 		case *ast.ImportClause:
 			// Import clauses don't contain self-references
 		default:
 			// For any other node types, we don't need to check them
 		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// declaresTopLevel reports whether policy declares a let, rule or derive called name.
+func declaresTopLevel(policy *Policy, name string) bool {
+	if _, ok := policy.Lets[name]; ok {
+		return true
+	}
+	if _, ok := policy.Derives[name]; ok {
+		return true
 	}
+	_, ok := policy.Rules[name]
+	return ok
+}
+
+// shadowingGraph drops the edges to locally bound names: the parameters of a derive, the lets
+// of a block and the binding of a projection, which refer to local values rather than to the
+// lets, rules and derives of the same name.
+type shadowingGraph struct {
+	dag.G[String]
+	names []string
+}
+
+func (g shadowingGraph) AddEdge(from, to String) error {
+	if slices.Contains(g.names, to.String()) {
+		return nil
+	}
+	return g.G.AddEdge(from, to)
+}
+
+// blockLets returns the names of the lets block declares.
+func blockLets(block *ast.BlockExpression) []string {
+	var names []string
+	for _, stmt := range block.Statements {
+		if let, ok := stmt.(*ast.VarDeclaration); ok {
+			names = append(names, let.Name)
+		}
+	}
+	return names
+}
+
+// expressionNodes widens expressions to nodes, for addNodes.
+func expressionNodes(exprs []ast.Expression) []ast.Node {
+	nodes := make([]ast.Node, 0, len(exprs))
+	for _, expr := range exprs {
+		if expr != nil {
+			nodes = append(nodes, expr)
+		}
+	}
+	return nodes
 }
 
 // containsSelfReference recursively checks if an AST node contains a self-reference
@@ -264,16 +502,7 @@ func (idx *Index) detectRuleCycle(ctx context.Context) (dag.G[*Rule], error) {
 					return nil, fmt.Errorf("validation cancelled: %w", xerr.ErrIndex)
 				}
 				if importClause, ok := rule.Body.(*ast.ImportClause); ok {
-					var ns, pol string
-					if len(importClause.FromPolicyFQN.Parts) == 1 {
-						// we only have a policy name - the namespace is the current policy's namespace
-						ns = policy.Namespace.FQN.String()
-						pol = importClause.FromPolicyFQN.Parts[0]
-					} else {
-						// we have a namespace and policy name
-						ns = strings.Join(importClause.FromPolicyFQN.Parts[:len(importClause.FromPolicyFQN.Parts)-1], ast.FQNSeparator)
-						pol = importClause.FromPolicyFQN.Parts[len(importClause.FromPolicyFQN.Parts)-1]
-					}
+					ns, pol := importTarget(policy, importClause)
 
 					p, err := idx.ResolvePolicy(ns, pol)
 					if err != nil {
@@ -299,6 +528,63 @@ func (idx *Index) detectRuleCycle(ctx context.Context) (dag.G[*Rule], error) {
 	return ruleDag, nil
 }
 
+// importTarget returns the namespace and policy name an import clause in policy refers to
+func importTarget(policy *Policy, importClause *ast.ImportClause) (string, string) {
+	parts := importClause.FromPolicyFQN.Parts
+	if len(parts) == 1 {
+		// we only have a policy name - the namespace is the current policy's namespace
+		return policy.Namespace.FQN.String(), parts[0]
+	}
+	// we have a namespace and policy name
+	return strings.Join(parts[:len(parts)-1], ast.FQNSeparator), parts[len(parts)-1]
+}
+
+// detectPolicyImportCycle checks the graph of policies importing each other's rules for cycles.
+// A policy importing a rule from itself is reported as a trivial cycle.
+func (idx *Index) detectPolicyImportCycle(ctx context.Context) error {
+	policyDag := dag.New[*Policy]()
+
+	for _, ns := range idx.Namespaces {
+		for _, policy := range ns.Policies {
+			policyDag.AddNode(policy)
+		}
+	}
+
+	for _, ns := range idx.Namespaces {
+		for _, policy := range ns.Policies {
+			if ctx.Err() != nil {
+				return fmt.Errorf("validation cancelled: %w", xerr.ErrIndex)
+			}
+			for _, rule := range policy.Rules {
+				importClause, ok := rule.Body.(*ast.ImportClause)
+				if !ok {
+					continue
+				}
+				target, err := idx.ResolvePolicy(importTarget(policy, importClause))
+				if err != nil {
+					return fmt.Errorf("error resolving policy: %s: %w", err, xerr.ErrIndex)
+				}
+				if target == policy {
+					return fmt.Errorf("detected cyclic import in policies: %s imports from itself at %s: %w", policy.FQN.String(), importClause.Span(), xerr.ErrIndex)
+				}
+				if err := policyDag.AddEdge(policy, target); err != nil {
+					return fmt.Errorf("error adding edge: %s: %w", err, xerr.ErrIndex)
+				}
+			}
+		}
+	}
+
+	if paths := policyDag.DetectFirstCycle(); len(paths) > 0 {
+		pathStr := make([]string, 0, len(paths))
+		for _, policy := range paths {
+			pathStr = append(pathStr, fmt.Sprintf("%s (%s)", policy.FQN.String(), policy.Statement.Span()))
+		}
+		return fmt.Errorf("detected cyclic import in policies: %s: %w", strings.Join(pathStr, " -> "), xerr.ErrIndex)
+	}
+
+	return nil
+}
+
 func (idx *Index) detectShapeCycle(ctx context.Context) (dag.G[*Shape], error) {
 	shapeDag := dag.New[*Shape]()
 
@@ -341,15 +627,13 @@ func (idx *Index) detectShapeCycle(ctx context.Context) (dag.G[*Shape], error) {
 				continue
 			}
 
-			withShape, err := idx.ResolveShape(
-				cmp.Or( // if there's no parent namespace, use the namespace FQN
-					shape.Model.WithFQN.Parent().String(),
-					shape.Namespace.FQN.String(),
-				),
-				shape.Model.WithFQN.LastSegment())
+			withNs, withShape, err := idx.ResolveShapeRef(shape.Namespace, shape.Policy, *shape.Model.WithFQN)
 			if err != nil {
 				return nil, fmt.Errorf("error resolving shape: %s: %w", err, xerr.ErrIndex)
 			}
+			if err := shape.verifyComposable(withNs, withShape); err != nil {
+				return nil, err
+			}
 			// find the shape with the FQN
 			if err := shapeDag.AddEdge(shape, withShape); err != nil {
 				return nil, fmt.Errorf("error adding edge: %s: %w", err, xerr.ErrIndex)
@@ -364,9 +648,12 @@ func (idx *Index) detectShapeCycle(ctx context.Context) (dag.G[*Shape], error) {
 			for _, shape := range policy.Shapes {
 				if shape.Model != nil && shape.Model.WithFQN != nil && !shape.Model.WithFQN.IsEmpty() {
 					// find the shape with the FQN
-					withShape, ok := ns.Shapes[shape.Model.WithFQN.String()]
-					if !ok {
-						return nil, fmt.Errorf("shape not found: %s at %s: %w", shape.Model.WithFQN.String(), shape.Statement.Span().String(), xerr.ErrIndex)
+					withNs, withShape, err := idx.ResolveShapeRef(ns, policy, *shape.Model.WithFQN)
+					if err != nil {
+						return nil, fmt.Errorf("shape not found: %s at %s: %s: %w", shape.Model.WithFQN.String(), shape.Statement.Span().String(), err, xerr.ErrIndex)
+					}
+					if err := shape.verifyComposable(withNs, withShape); err != nil {
+						return nil, err
 					}
 					if err := shapeDag.AddEdge(shape, withShape); err != nil {
 						return nil, fmt.Errorf("error adding edge: %s: %w", err, xerr.ErrIndex)
@@ -387,3 +674,87 @@ func (idx *Index) detectShapeCycle(ctx context.Context) (dag.G[*Shape], error) {
 
 	return shapeDag, nil
 }
+
+// detectShadowedFields reports fields of a composed shape that redefine a field of the shape it
+// is composed with, without being marked `override`. Such a field is a conflict carrying both
+// declarations, or a warning when the index was created WithShadowedFieldWarnings. A field marked
+// `override` must redefine a field of one of the shapes it is composed with.
+func (idx *Index) detectShadowedFields(ctx context.Context) error {
+	// the fields every shape ends up with, once its composition is brought in
+	composed := make(map[*Shape]map[string]*ShapeModelField)
+	var errs []error
+
+	for _, ns := range idx.Namespaces {
+		if !idx.inScope(ns) {
+			continue
+		}
+		shapes := slices.Collect(maps.Values(ns.Shapes))
+		for _, policy := range ns.Policies {
+			shapes = append(shapes, slices.Collect(maps.Values(policy.Shapes))...)
+		}
+
+		for _, shape := range shapes {
+			if ctx.Err() != nil {
+				return fmt.Errorf("validation cancelled: %w", xerr.ErrIndex)
+			}
+			if shape.Model == nil {
+				continue
+			}
+			base := composedWith(idx.Namespaces, shape)
+			var inherited map[string]*ShapeModelField
+			if base != nil {
+				inherited = composedFields(idx.Namespaces, base, composed)
+			}
+
+			for _, name := range slices.Sorted(maps.Keys(shape.Model.Fields)) {
+				field := shape.Model.Fields[name]
+				shadowed, ok := inherited[name]
+				override := field.Node != nil && field.Node.Override
+				if !ok && override {
+					errs = append(errs, xerr.ErrAt(field.Node.Range, fmt.Errorf("shape field '%s' of '%s' is marked 'override' at %s but no shape it is composed with declares it: %w", name, shape.FQN.String(), field.Node.Range, xerr.ErrIndex)))
+					continue
+				}
+				if !ok || override {
+					continue
+				}
+				if idx.shadowedFieldWarnings {
+					idx.addWarning(CodeShadowedField, field.Node.Range, "shape field '%s' of '%s' shadows the field of '%s' declared at %s", name, shape.FQN.String(), base.FQN.String(), shadowed.Node.Range)
+					continue
+				}
+				errs = append(errs, xerr.ErrConflict(fmt.Sprintf("shape field '%s' of '%s' redefines a field of '%s' without 'override'", name, shape.FQN.String(), base.FQN.String()), field.Node.Range, shadowed.Node.Range))
+			}
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// composedWith returns the shape that shape is composed with, if any. Unresolvable
+// compositions have already been reported by detectShapeCycle.
+func composedWith(namespaces map[string]*Namespace, shape *Shape) *Shape {
+	if shape.Model == nil || shape.Model.WithFQN == nil || shape.Model.WithFQN.IsEmpty() {
+		return nil
+	}
+	_, withShape, err := resolveShapeRef(namespaces, shape.Namespace, shape.Policy, *shape.Model.WithFQN)
+	if err != nil {
+		return nil
+	}
+	return withShape
+}
+
+// composedFields returns the fields of shape including those it composes, its own taking
+// precedence. Shape cycles have already been rejected, so the recursion terminates.
+func composedFields(namespaces map[string]*Namespace, shape *Shape, memo map[*Shape]map[string]*ShapeModelField) map[string]*ShapeModelField {
+	if fields, ok := memo[shape]; ok {
+		return fields
+	}
+	fields := make(map[string]*ShapeModelField)
+	if shape.Model != nil {
+		if base := composedWith(namespaces, shape); base != nil {
+			maps.Copy(fields, composedFields(namespaces, base, memo))
+		}
+		maps.Copy(fields, shape.Model.Fields)
+	}
+	memo[shape] = fields
+	return fields
+}
@@ -13,6 +13,7 @@ import (
 
 	"github.com/sentrie-sh/sentrie/ast"
 	"github.com/sentrie-sh/sentrie/tokens"
+	"github.com/sentrie-sh/sentrie/xerr"
 )
 
 func testRange() tokens.Range {
@@ -271,3 +272,26 @@ func TestDetectShapeCyclePolicyBranchAddEdgeErrorDuplicateFQN(t *testing.T) {
 		t.Fatalf("unexpected error: %v", err)
 	}
 }
+
+func (suite *IndexTestSuite) TestValidateReportsEveryIndependentError() {
+	idx := suite.indexFromSource(nil, `namespace com/example
+policy auth {
+  fact user: document
+  fact admin: document
+  require user conforms Missing
+  require admin conforms AlsoMissing
+  rule allow = default false { yield true }
+  export decision of allow obligation "log": Absent = user
+}`)
+
+	err := idx.Validate(suite.ctx)
+	suite.Require().Error(err)
+	suite.ErrorIs(err, xerr.NotFoundError{})
+	suite.Contains(err.Error(), "cannot resolve shape 'Missing' required of fact 'user'")
+	suite.Contains(err.Error(), "cannot resolve shape 'AlsoMissing' required of fact 'admin'")
+	suite.Contains(err.Error(), "cannot resolve shape 'Absent' of obligation 'log'")
+
+	var located xerr.LocatedError
+	suite.Require().ErrorAs(err, &located)
+	suite.Equal(4, located.Where().From.Line)
+}
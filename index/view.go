@@ -0,0 +1,143 @@
+// SPDX-FileCopyrightText: © 2026 Binaek Sarkar <binaek89@gmail.com>
+// SPDX-License-Identifier: Apache-2.0
+
+package index
+
+import (
+	"cmp"
+	"context"
+	"maps"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/sentrie-sh/sentrie/ast"
+	"github.com/sentrie-sh/sentrie/pack"
+)
+
+// IndexView is a read-only view of a committed Index. It only exposes lookups,
+// so evaluation can share it between goroutines without holding the index lock.
+//
+// The view holds its own copy of the namespace table and of every namespace, so
+// namespaces, policies and shapes added to or removed from the index afterwards
+// are not visible through it. The *Policy, *Shape and *Rule values and the AST
+// behind them are shared with the index; they are immutable once it is committed.
+type IndexView struct {
+	pack        *pack.PackFile
+	namespaces  map[string]*Namespace
+	warnings    []Diagnostic
+	committedAt time.Time
+}
+
+// View commits the index, if that has not happened yet, and returns a read-only
+// view of it. The commit error, if any, is returned instead of a view.
+func (idx *Index) View(ctx context.Context) (*IndexView, error) {
+	if err := idx.Commit(ctx); err != nil {
+		return nil, err
+	}
+
+	idx.theLock.RLock()
+	defer idx.theLock.RUnlock()
+
+	return &IndexView{
+		pack:        idx.Pack,
+		namespaces:  snapshotNamespaces(idx.Namespaces),
+		warnings:    slices.Clone(idx.warnings),
+		committedAt: idx.committedAt,
+	}, nil
+}
+
+// snapshotNamespaces copies the namespace table and the lookup maps of every namespace.
+// Parent and child links are rewired to the copies so that walking them stays inside the snapshot.
+func snapshotNamespaces(namespaces map[string]*Namespace) map[string]*Namespace {
+	copies := make(map[string]*Namespace, len(namespaces))
+	originals := make(map[*Namespace]*Namespace, len(namespaces))
+	for name, ns := range namespaces {
+		c := *ns
+		c.Policies = maps.Clone(ns.Policies)
+		c.Shapes = maps.Clone(ns.Shapes)
+		c.ShapeExports = maps.Clone(ns.ShapeExports)
+		copies[name] = &c
+		originals[ns] = &c
+	}
+	for _, c := range copies {
+		if parent, ok := originals[c.Parent]; ok {
+			c.Parent = parent
+		}
+		children := make([]*Namespace, 0, len(c.Children))
+		for _, child := range c.Children {
+			children = append(children, cmp.Or(originals[child], child))
+		}
+		c.Children = children
+	}
+	return copies
+}
+
+// Pack returns the pack the index was built from.
+func (v *IndexView) Pack() *pack.PackFile {
+	return v.pack
+}
+
+// CommittedAt returns when the index of the view was committed.
+func (v *IndexView) CommittedAt() time.Time {
+	return v.committedAt
+}
+
+// PolicyCount returns the number of policies in the view, across every namespace.
+func (v *IndexView) PolicyCount() int {
+	count := 0
+	for _, ns := range v.namespaces {
+		count += len(ns.Policies)
+	}
+	return count
+}
+
+// Policies returns the policies in the view, across every namespace, ordered by FQN.
+func (v *IndexView) Policies() []*Policy {
+	policies := make([]*Policy, 0, v.PolicyCount())
+	for _, ns := range v.namespaces {
+		policies = slices.AppendSeq(policies, maps.Values(ns.Policies))
+	}
+	slices.SortFunc(policies, func(a, b *Policy) int {
+		return strings.Compare(a.FQN.String(), b.FQN.String())
+	})
+	return policies
+}
+
+// Warnings returns the warnings collected when the index was validated.
+func (v *IndexView) Warnings() []Diagnostic {
+	return slices.Clone(v.warnings)
+}
+
+// PolicyWarnings returns the warnings raised within the declaration of the policy ns/policy,
+// in the order they were collected. It is empty when the policy does not resolve.
+func (v *IndexView) PolicyWarnings(ns, policy string) []Diagnostic {
+	p, err := v.ResolvePolicy(ns, policy)
+	if err != nil || p.Statement == nil {
+		return nil
+	}
+	return warningsWithin(v.warnings, p.Statement.Span())
+}
+
+func (v *IndexView) ResolveNamespace(ns string) (*Namespace, error) {
+	return resolveNamespace(v.namespaces, ns)
+}
+
+// ResolvePolicy tries exact namespace match; it does not traverse parents.
+func (v *IndexView) ResolvePolicy(ns, policy string) (*Policy, error) {
+	return resolvePolicy(v.namespaces, ns, policy)
+}
+
+func (v *IndexView) ResolveShape(ns, shape string) (*Shape, error) {
+	return resolveShape(v.namespaces, ns, shape)
+}
+
+// ResolveShapeRef resolves a shape reference written in ns, and in policy when it is not nil.
+// It follows the same precedence as Index.ResolveShapeRef.
+func (v *IndexView) ResolveShapeRef(ns *Namespace, policy *Policy, ref ast.FQN) (*Namespace, *Shape, error) {
+	return resolveShapeRef(v.namespaces, ns, policy, ref)
+}
+
+func (v *IndexView) ResolveSegments(path string) (ns, policy, rule string, err error) {
+	return resolveSegments(v.namespaces, path)
+}
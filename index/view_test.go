@@ -0,0 +1,148 @@
+// SPDX-FileCopyrightText: © 2026 Binaek Sarkar <binaek89@gmail.com>
+// SPDX-License-Identifier: Apache-2.0
+
+package index
+
+import (
+	"sync"
+
+	"github.com/sentrie-sh/sentrie/ast"
+	"github.com/sentrie-sh/sentrie/xerr"
+)
+
+const viewTestSource = `namespace com/example
+shape User { name: string }
+export shape User
+policy a {
+  rule x = default true { yield true }
+  export decision of x
+}`
+
+func (suite *IndexTestSuite) TestViewResolvesPoliciesAndShapes() {
+	idx := suite.indexFromSource(nil, viewTestSource)
+
+	view, err := idx.View(suite.ctx)
+	suite.Require().NoError(err)
+
+	ns, err := view.ResolveNamespace("com/example")
+	suite.Require().NoError(err)
+	suite.Equal("com/example", ns.FQN.String())
+
+	p, err := view.ResolvePolicy("com/example", "a")
+	suite.Require().NoError(err)
+	suite.Equal("a", p.Name)
+
+	s, err := view.ResolveShape("com/example", "User")
+	suite.Require().NoError(err)
+	suite.Equal("User", s.Name)
+
+	nsName, policy, rule, err := view.ResolveSegments("com/example/a/x")
+	suite.Require().NoError(err)
+	suite.Equal([]string{"com/example", "a", "x"}, []string{nsName, policy, rule})
+
+	_, err = view.ResolvePolicy("com/example", "missing")
+	suite.ErrorIs(err, xerr.NotFoundError{})
+
+	suite.Equal(1, view.PolicyCount())
+	suite.Require().Len(view.Policies(), 1)
+	suite.Equal("com/example/a", view.Policies()[0].FQN.String())
+	suite.Equal(idx.CommittedAt(), view.CommittedAt())
+	suite.False(view.CommittedAt().IsZero())
+}
+
+func (suite *IndexTestSuite) TestViewSurfacesValidationError() {
+	idx := suite.indexFromSource(nil, `namespace com/example
+policy a {
+  rule x = import decision x from a
+  export decision of x
+}`)
+
+	view, err := idx.View(suite.ctx)
+	suite.Require().Error(err)
+	suite.Nil(view)
+	suite.ErrorIs(err, xerr.ErrIndex)
+}
+
+func (suite *IndexTestSuite) TestCommittedIndexRejectsPrograms() {
+	idx := suite.indexFromSource(nil, viewTestSource)
+	_, err := idx.View(suite.ctx)
+	suite.Require().NoError(err)
+
+	err = idx.AddProgram(suite.ctx, &ast.Program{Reference: "late.sentrie"})
+	suite.Require().Error(err)
+	suite.ErrorIs(err, xerr.ErrIndex)
+	suite.Contains(err.Error(), "committed index")
+}
+
+func (suite *IndexTestSuite) TestViewConcurrentReads() {
+	idx := suite.indexFromSource(nil, viewTestSource)
+	view, err := idx.View(suite.ctx)
+	suite.Require().NoError(err)
+
+	var wg sync.WaitGroup
+	errs := make(chan error, 64)
+	for range 16 {
+		wg.Go(func() {
+			for range 100 {
+				if _, err := view.ResolvePolicy("com/example", "a"); err != nil {
+					errs <- err
+					return
+				}
+				if _, err := view.ResolveShape("com/example", "User"); err != nil {
+					errs <- err
+					return
+				}
+				if _, _, _, err := view.ResolveSegments("com/example/a/x"); err != nil {
+					errs <- err
+					return
+				}
+			}
+		})
+	}
+	wg.Wait()
+	close(errs)
+
+	for err := range errs {
+		suite.NoError(err)
+	}
+}
+
+func (suite *IndexTestSuite) TestViewIsASnapshotOfNamespaces() {
+	idx := suite.indexFromSource(nil, viewTestSource)
+	view, err := idx.View(suite.ctx)
+	suite.Require().NoError(err)
+
+	ns := idx.Namespaces["com/example"]
+	delete(ns.Policies, "a")
+	delete(ns.Shapes, "User")
+	delete(idx.Namespaces, "com/example")
+
+	_, err = view.ResolveNamespace("com/example")
+	suite.Require().NoError(err)
+	_, err = view.ResolvePolicy("com/example", "a")
+	suite.Require().NoError(err)
+	_, err = view.ResolveShape("com/example", "User")
+	suite.Require().NoError(err)
+}
+
+func (suite *IndexTestSuite) TestViewCarriesPolicyWarnings() {
+	idx := suite.indexFromSource(nil, `namespace com/example
+policy a {
+  fact user: string
+  rule x = default true { yield true }
+  export decision of x
+}
+policy b {
+  rule y = default true { yield true }
+  export decision of y
+}`)
+	view, err := idx.View(suite.ctx)
+	suite.Require().NoError(err)
+
+	suite.Len(view.Warnings(), 1)
+	warnings := view.PolicyWarnings("com/example", "a")
+	suite.Require().Len(warnings, 1)
+	suite.Contains(warnings[0].Message, "'user'")
+	suite.Empty(view.PolicyWarnings("com/example", "b"))
+	suite.Empty(view.PolicyWarnings("com/example", "missing"))
+}
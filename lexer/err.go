@@ -27,8 +27,9 @@ type LexerError struct {
 	Position tokens.Pos
 }
 
+// Error reports the position with a 1-based line, as tokens.Range does.
 func (e *LexerError) Error() string {
-	return fmt.Sprintf("at %s:%d:%d", e.Filename, e.Position.Line, e.Position.Column)
+	return fmt.Sprintf("at %s:%d:%d", e.Filename, e.Position.Line+1, e.Position.Column)
 }
 
 func UnterminatedStringError(filename string, pos tokens.Pos) error {
@@ -38,3 +39,19 @@ func UnterminatedStringError(filename string, pos tokens.Pos) error {
 func InvalidHereDocSyntaxError(filename string, pos tokens.Pos) error {
 	return fmt.Errorf("invalid heredoc syntax: %w", &LexerError{Filename: filename, Position: pos})
 }
+
+func IdentifierTooLongError(filename string, pos tokens.Pos, limit int) error {
+	return fmt.Errorf("identifier exceeds the maximum length of %d: %w", limit, &LexerError{Filename: filename, Position: pos})
+}
+
+func StringTooLongError(filename string, pos tokens.Pos, limit int) error {
+	return fmt.Errorf("string literal exceeds the maximum length of %d: %w", limit, &LexerError{Filename: filename, Position: pos})
+}
+
+func MalformedNumberError(filename string, pos tokens.Pos, reason string) error {
+	return fmt.Errorf("malformed number literal, %s: %w", reason, &LexerError{Filename: filename, Position: pos})
+}
+
+func UnterminatedCommentError(filename string, pos tokens.Pos) error {
+	return fmt.Errorf("unterminated block comment opened %w", &LexerError{Filename: filename, Position: pos})
+}
@@ -8,7 +8,6 @@ import (
 	"bytes"
 	"fmt"
 	"io"
-	"regexp"
 	"slices"
 	"strings"
 	"unicode"
@@ -31,18 +30,59 @@ type Lexer struct {
 	currentWidth int
 	atEOF        bool
 
-	identRegex *regexp.Regexp
-
 	// pushBack is a LIFO stack: NextToken pops from here before lexing more input.
 	pushBack []tokens.Instance
+
+	// limits, in characters, on the tokens read from untrusted input
+	maxIdentifierLength int
+	maxStringLength     int
+}
+
+const (
+	// DefaultMaxIdentifierLength is the longest identifier the lexer accepts by default.
+	DefaultMaxIdentifierLength = 1024
+
+	// DefaultMaxStringLength is the longest string literal, heredocs included, the lexer accepts by default.
+	DefaultMaxStringLength = 1 << 20
+)
+
+type Option func(*Lexer)
+
+// WithMaxIdentifierLength limits identifiers to n characters.
+func WithMaxIdentifierLength(n int) Option {
+	return func(l *Lexer) {
+		l.maxIdentifierLength = n
+	}
+}
+
+// WithMaxStringLength limits string literals and heredocs to n characters.
+func WithMaxStringLength(n int) Option {
+	return func(l *Lexer) {
+		l.maxStringLength = n
+	}
+}
+
+// WithStartPosition makes the lexer report positions as if its input started at pos of its file.
+// It is used to lex source embedded in another token, such as the interpolations of a template
+// string.
+func WithStartPosition(pos tokens.Pos) Option {
+	return func(l *Lexer) {
+		l.line = pos.Line
+		l.column = pos.Column
+		l.offset = pos.Offset
+	}
 }
 
-func NewLexer(reader io.Reader, filename string) *Lexer {
+func NewLexer(reader io.Reader, filename string, opts ...Option) *Lexer {
 	l := &Lexer{
-		reader:      bufio.NewReader(reader),
-		filename:    filename,
-		currentLine: []rune{},
-		identRegex:  regexp.MustCompile(`^[a-zA-Z_][a-zA-Z0-9_]*$`),
+		reader:              bufio.NewReader(reader),
+		filename:            filename,
+		currentLine:         []rune{},
+		maxIdentifierLength: DefaultMaxIdentifierLength,
+		maxStringLength:     DefaultMaxStringLength,
+	}
+	for _, opt := range opts {
+		opt(l)
 	}
 	l.readRune() // Initialize the first rune
 	return l
@@ -151,6 +191,14 @@ func (l *Lexer) NextToken() tokens.Instance {
 			endPos := l.currentPosition()
 			return tokens.New(tokens.TokenMul, "*", tokens.NewRange(l.filename, startPos, endPos))
 		case '/':
+			if l.peekAhead() == '*' {
+				commentKind, value, err := l.readBlockComment()
+				endPos := l.currentPosition()
+				if err != nil {
+					return tokens.New(tokens.Error, err.Error(), tokens.NewRange(l.filename, startPos, endPos))
+				}
+				return tokens.New(commentKind, value, tokens.NewRange(l.filename, startPos, endPos))
+			}
 			l.readRune()
 			endPos := l.currentPosition()
 			return tokens.New(tokens.TokenDiv, "/", tokens.NewRange(l.filename, startPos, endPos))
@@ -159,6 +207,12 @@ func (l *Lexer) NextToken() tokens.Instance {
 			endPos := l.currentPosition()
 			return tokens.New(tokens.TokenMod, "%", tokens.NewRange(l.filename, startPos, endPos))
 		case '?':
+			if l.peekAhead() == '.' {
+				l.readRune()
+				l.readRune()
+				endPos := l.currentPosition()
+				return tokens.New(tokens.TokenQuestionDot, "?.", tokens.NewRange(l.filename, startPos, endPos))
+			}
 			l.readRune()
 			endPos := l.currentPosition()
 			return tokens.New(tokens.TokenQuestion, "?", tokens.NewRange(l.filename, startPos, endPos))
@@ -229,18 +283,34 @@ func (l *Lexer) NextToken() tokens.Instance {
 			return tokens.New(tokens.PunctRightBracket, "]", tokens.NewRange(l.filename, startPos, endPos))
 
 		case '"':
-			value, err := l.readString()
+			if l.peekString(2) == `""` {
+				value, err := l.readRawString()
+				if err != nil {
+					endPos := l.currentPosition()
+					return tokens.New(tokens.Error, err.Error(), tokens.NewRange(l.filename, startPos, endPos))
+				}
+				endPos := l.currentPosition()
+				return tokens.New(tokens.String, value, tokens.NewRange(l.filename, startPos, endPos))
+			}
+			value, template, err := l.readString()
 			if err != nil {
 				endPos := l.currentPosition()
 				return tokens.New(tokens.Error, err.Error(), tokens.NewRange(l.filename, startPos, endPos))
 			}
 			endPos := l.currentPosition()
+			if template {
+				return tokens.New(tokens.TemplateString, value, tokens.NewRange(l.filename, startPos, endPos))
+			}
 			return tokens.New(tokens.String, value, tokens.NewRange(l.filename, startPos, endPos))
 
 		default:
 			if unicode.IsLetter(l.current) || l.current == '_' {
-				value := l.readIdentifier()
-				if !l.identRegex.MatchString(value) {
+				value, err := l.readIdentifier(startPos)
+				if err != nil {
+					endPos := l.currentPosition()
+					return tokens.New(tokens.Error, err.Error(), tokens.NewRange(l.filename, startPos, endPos))
+				}
+				if !tokens.IsIdentifier(value) {
 					endPos := l.currentPosition()
 					return tokens.Err(tokens.NewRange(l.filename, startPos, endPos), "invalid identifier: "+value)
 				}
@@ -255,7 +325,11 @@ func (l *Lexer) NextToken() tokens.Instance {
 			}
 
 			if unicode.IsDigit(l.current) {
-				value, kind := l.readNumber()
+				value, kind, err := l.readNumber()
+				if err != nil {
+					endPos := l.currentPosition()
+					return tokens.New(tokens.Error, err.Error(), tokens.NewRange(l.filename, startPos, endPos))
+				}
 				endPos := l.currentPosition()
 				return tokens.New(kind, value, tokens.NewRange(l.filename, startPos, endPos))
 			}
@@ -293,6 +367,23 @@ func (l *Lexer) readRune() {
 		return
 	}
 
+	// a leading byte order mark is not part of the source
+	if r == '\uFEFF' && l.offset == 0 {
+		l.offset += size
+		l.readRune()
+		return
+	}
+
+	// "\r\n" and a lone "\r" are read as a single '\n'. The width covers every byte consumed, so
+	// offsets keep pointing into the original input.
+	if r == '\r' {
+		r = '\n'
+		if next, err := l.reader.Peek(1); err == nil && next[0] == '\n' {
+			_, _ = l.reader.ReadByte()
+			size++
+		}
+	}
+
 	l.current = r
 	l.currentWidth = size
 	l.offset += size
@@ -346,26 +437,58 @@ func (l *Lexer) skipWhitespace() {
 }
 
 // readIdentifier reads an identifier or keyword
-func (l *Lexer) readIdentifier() string {
+func (l *Lexer) readIdentifier(start tokens.Pos) (string, error) {
 	var result strings.Builder
 
+	length := 0
 	for unicode.IsLetter(l.current) || unicode.IsDigit(l.current) || l.current == '_' {
+		length++
+		if length > l.maxIdentifierLength {
+			return "", IdentifierTooLongError(l.filename, start, l.maxIdentifierLength)
+		}
 		result.WriteRune(l.current)
 		l.readRune()
 	}
 
-	return result.String()
+	return result.String(), nil
 }
 
-// readNumber reads an integer or float
-func (l *Lexer) readNumber() (string, tokens.Kind) {
+// readNumber reads an integer or float. Integers may be written in hex with a 0x prefix or in
+// binary with a 0b prefix, and digits may be grouped with single underscores, as in 1_000_000.
+// The underscores are dropped from the token value.
+func (l *Lexer) readNumber() (string, tokens.Kind, error) {
 	result := bytes.NewBufferString("")
 	kind := tokens.Int
 
-	// consume digits
-	for unicode.IsDigit(l.current) {
-		result.WriteRune(l.current)
-		l.readRune()
+	if l.current == '0' {
+		var isDigit func(rune) bool
+		switch l.peekAhead() {
+		case 'x', 'X':
+			isDigit = isHexDigit
+		case 'b', 'B':
+			isDigit = isBinaryDigit
+		}
+		if isDigit != nil {
+			result.WriteRune(l.current)
+			l.readRune()
+			result.WriteRune(l.current)
+			l.readRune()
+			n, err := l.readDigits(result, isDigit)
+			if err != nil {
+				return "", kind, err
+			}
+			if n == 0 {
+				return "", kind, MalformedNumberError(l.filename, l.currentPosition(), "expected digits after "+result.String())
+			}
+			if unicode.IsLetter(l.current) || unicode.IsDigit(l.current) {
+				return "", kind, MalformedNumberError(l.filename, l.currentPosition(), fmt.Sprintf("unexpected %q", l.current))
+			}
+			return result.String(), kind, nil
+		}
+	}
+
+	if _, err := l.readDigits(result, unicode.IsDigit); err != nil {
+		return "", kind, err
 	}
 
 	if l.current == '.' && unicode.IsDigit(l.peekAhead()) {
@@ -373,13 +496,45 @@ func (l *Lexer) readNumber() (string, tokens.Kind) {
 		result.WriteRune(l.current)
 		l.readRune() // consume '.'
 		// consume the rest of the digits
-		for unicode.IsDigit(l.current) {
-			result.WriteRune(l.current)
+		if _, err := l.readDigits(result, unicode.IsDigit); err != nil {
+			return "", kind, err
+		}
+	}
+
+	return result.String(), kind, nil
+}
+
+// readDigits copies a run of digits into result, dropping the underscores between them, and
+// returns the number of digits read. An underscore must sit between two digits.
+func (l *Lexer) readDigits(result *bytes.Buffer, isDigit func(rune) bool) (int, error) {
+	n := 0
+	underscore := false
+	for isDigit(l.current) || l.current == '_' {
+		if l.current == '_' {
+			if n == 0 || underscore {
+				return 0, MalformedNumberError(l.filename, l.currentPosition(), "an underscore must separate digits")
+			}
+			underscore = true
 			l.readRune()
+			continue
 		}
+		underscore = false
+		n++
+		result.WriteRune(l.current)
+		l.readRune()
+	}
+	if underscore {
+		return 0, MalformedNumberError(l.filename, l.currentPosition(), "an underscore must separate digits")
 	}
+	return n, nil
+}
 
-	return result.String(), kind
+func isHexDigit(r rune) bool {
+	return unicode.IsDigit(r) || ('a' <= r && r <= 'f') || ('A' <= r && r <= 'F')
+}
+
+func isBinaryDigit(r rune) bool {
+	return r == '0' || r == '1'
 }
 
 // readComment reads a line comment starting with --
@@ -409,14 +564,71 @@ func (l *Lexer) readComment() (tokens.Kind, string) {
 	return kind, strings.TrimSpace(result.String())
 }
 
-// readString reads a quoted string literal
-func (l *Lexer) readString() (string, error) {
+// readBlockComment reads a "/* ... */" comment starting at its '/'. Block comments nest. Like a
+// "--" comment, it is a trailing comment when code precedes it on its line, and a line comment
+// otherwise.
+func (l *Lexer) readBlockComment() (tokens.Kind, string, error) {
+	start := l.currentPosition()
+	// the opening is reported by its 1-based column, which is the length of the line read so far
+	start.Column = len(l.currentLine)
+	kind := tokens.LineComment
+	if slices.ContainsFunc(l.currentLine[:len(l.currentLine)-1], func(r rune) bool { return !unicode.IsSpace(r) }) {
+		kind = tokens.TrailingComment
+	}
+
+	l.readRune() // consume '/'
+	l.readRune() // consume '*'
+
+	result := bytes.NewBufferString("")
+	depth := 1
+	for l.current != 0 {
+		switch {
+		case l.current == '/' && l.peekAhead() == '*':
+			depth++
+			result.WriteString("/*")
+			l.readRune()
+		case l.current == '*' && l.peekAhead() == '/':
+			depth--
+			l.readRune()
+			if depth == 0 {
+				l.readRune()
+				return kind, strings.TrimSpace(result.String()), nil
+			}
+			result.WriteString("*/")
+		default:
+			result.WriteRune(l.current)
+		}
+		l.readRune()
+	}
+	return kind, "", UnterminatedCommentError(l.filename, start)
+}
+
+// readString reads a quoted string literal. A string holding an unescaped "${" is a template: it
+// is returned raw, escapes included, for the parser to split into text and interpolations.
+func (l *Lexer) readString() (string, bool, error) {
+	start := l.currentPosition()
 	l.readRune() // skip opening quote
 
-	var result strings.Builder
+	var result, raw strings.Builder
+	template := false
+	length := 0
 	for l.current != '"' && l.current != 0 {
+		if l.current == '$' && l.peekAhead() == '{' {
+			template = true
+			if err := l.readInterpolation(&raw, start, &length); err != nil {
+				return "", false, err
+			}
+			continue
+		}
+
+		length++
+		if length > l.maxStringLength {
+			return "", false, StringTooLongError(l.filename, start, l.maxStringLength)
+		}
+		raw.WriteRune(l.current)
 		if l.current == '\\' {
 			l.readRune()
+			raw.WriteRune(l.current)
 			switch l.current {
 			case '"', '\\', '/':
 				result.WriteRune(l.current)
@@ -440,11 +652,131 @@ func (l *Lexer) readString() (string, error) {
 	}
 
 	if l.current != '"' {
-		return "", UnterminatedStringError(l.filename, l.currentPosition())
+		return "", false, UnterminatedStringError(l.filename, l.currentPosition())
 	}
 	l.readRune() // skip closing quote
 
-	return result.String(), nil
+	if template {
+		return raw.String(), true, nil
+	}
+	return result.String(), false, nil
+}
+
+// readInterpolation copies a "${ ... }" interpolation into raw, starting at its '$'. The
+// expression may hold braces and string literals of its own, templates included; the
+// interpolation ends at the '}' matching its opening brace.
+func (l *Lexer) readInterpolation(raw *strings.Builder, start tokens.Pos, length *int) error {
+	take := func() error {
+		*length++
+		if *length > l.maxStringLength {
+			return StringTooLongError(l.filename, start, l.maxStringLength)
+		}
+		raw.WriteRune(l.current)
+		l.readRune()
+		return nil
+	}
+
+	// the '$' and the '{'
+	for range 2 {
+		if err := take(); err != nil {
+			return err
+		}
+	}
+
+	depth := 1
+	for l.current != 0 {
+		switch l.current {
+		case '"':
+			if err := l.readNestedString(raw, start, length, take); err != nil {
+				return err
+			}
+			continue
+		case '{':
+			depth++
+		case '}':
+			depth--
+			if depth == 0 {
+				return take()
+			}
+		}
+		if err := take(); err != nil {
+			return err
+		}
+	}
+	return UnterminatedStringError(l.filename, l.currentPosition())
+}
+
+// readNestedString copies a string literal inside an interpolation into raw, escapes and
+// interpolations included.
+func (l *Lexer) readNestedString(raw *strings.Builder, start tokens.Pos, length *int, take func() error) error {
+	if err := take(); err != nil { // opening quote
+		return err
+	}
+	for l.current != '"' && l.current != 0 {
+		if l.current == '$' && l.peekAhead() == '{' {
+			if err := l.readInterpolation(raw, start, length); err != nil {
+				return err
+			}
+			continue
+		}
+		if l.current == '\\' {
+			if err := take(); err != nil {
+				return err
+			}
+			if l.current == 0 {
+				break
+			}
+		}
+		if err := take(); err != nil {
+			return err
+		}
+	}
+	if l.current != '"' {
+		return UnterminatedStringError(l.filename, l.currentPosition())
+	}
+	return take() // closing quote
+}
+
+// readRawString reads a triple-quoted string starting at its first '"'. The content is kept
+// verbatim: backslashes, newlines and "${" carry no meaning. A run of more than three quotes ends
+// the string with its last three, so the content may end in quotes.
+func (l *Lexer) readRawString() (string, error) {
+	start := l.currentPosition()
+	for range 3 {
+		l.readRune() // opening quotes
+	}
+
+	var sb strings.Builder
+	length := 0
+	for l.current != 0 {
+		quotes := 0
+		for l.current == '"' {
+			quotes++
+			l.readRune()
+		}
+		if quotes >= 3 {
+			quotes -= 3
+			length += quotes
+			if length > l.maxStringLength {
+				return "", StringTooLongError(l.filename, start, l.maxStringLength)
+			}
+			sb.WriteString(strings.Repeat(`"`, quotes))
+			return sb.String(), nil
+		}
+		sb.WriteString(strings.Repeat(`"`, quotes))
+		length += quotes
+		if l.current == 0 {
+			break
+		}
+
+		length++
+		if length > l.maxStringLength {
+			return "", StringTooLongError(l.filename, start, l.maxStringLength)
+		}
+		sb.WriteRune(l.current)
+		l.readRune()
+	}
+	return "", UnterminatedStringError(l.filename, l.currentPosition())
 }
 
 // peekString peeks the next n bytes (ASCII use only, does not advance).
@@ -461,6 +793,7 @@ func (l *Lexer) peekString(n int) string {
 
 // readHereDoc reads a heredoc starting at the first '<' of '<<<'.
 func (l *Lexer) readHereDoc() (string, error) {
+	start := l.currentPosition()
 	// We are currently on the first '<'. Consume the 3 '<'.
 	l.readRune() // 1st '<'
 	if l.current != '<' {
@@ -485,7 +818,7 @@ func (l *Lexer) readHereDoc() (string, error) {
 		l.readRune()
 	}
 	tag := tagBuilder.String()
-	if tag == "" || !l.identRegex.MatchString(tag) {
+	if tag == "" || !tokens.IsIdentifier(tag) {
 		return "", fmt.Errorf("invalid heredoc tag: %w", InvalidHereDocSyntaxError(l.filename, l.currentPosition()))
 	}
 
@@ -504,10 +837,15 @@ func (l *Lexer) readHereDoc() (string, error) {
 
 	// Now collect lines until a line that is exactly == tag
 	var sb strings.Builder
+	length := 0
 	for {
 		// Capture the current line (without the trailing '\n')
 		var lineBuf []rune
 		for l.current != '\n' && l.current != 0 {
+			// a line longer than both the limit and the tag cannot be kept, nor end the heredoc
+			if len(lineBuf) > max(l.maxStringLength, len(tag)) {
+				return "", StringTooLongError(l.filename, start, l.maxStringLength)
+			}
 			lineBuf = append(lineBuf, l.current)
 			l.readRune()
 		}
@@ -523,6 +861,13 @@ func (l *Lexer) readHereDoc() (string, error) {
 		}
 
 		// Otherwise, append the line and restore newline if we had one.
+		length += len(lineBuf)
+		if l.current == '\n' {
+			length++
+		}
+		if length > l.maxStringLength {
+			return "", StringTooLongError(l.filename, start, l.maxStringLength)
+		}
 		sb.WriteString(line)
 		switch l.current {
 		case '\n':
@@ -160,3 +160,261 @@ func TestLexerPipelineHoleInsideCall(t *testing.T) {
 		}
 	}
 }
+
+func TestLexerNormalizesBOMAndLineEndings(t *testing.T) {
+	const lf = "namespace a\npolicy p {\n  rule r = default true\n}\n"
+	inputs := map[string]string{
+		"bom":  "\uFEFF" + lf,
+		"crlf": strings.ReplaceAll(lf, "\n", "\r\n"),
+		"cr":   strings.ReplaceAll(lf, "\n", "\r"),
+		"both": "\uFEFF" + strings.ReplaceAll(lf, "\n", "\r\n"),
+	}
+
+	want := NewLexer(strings.NewReader(lf), "test.sent")
+	var expected []tokens.Instance
+	for tok := want.NextToken(); tok.Kind != tokens.EOF; tok = want.NextToken() {
+		expected = append(expected, tok)
+	}
+
+	for name, src := range inputs {
+		t.Run(name, func(t *testing.T) {
+			l := NewLexer(strings.NewReader(src), "test.sent")
+			searchFrom := 0
+			for _, exp := range expected {
+				tok := l.NextToken()
+				if tok.Kind != exp.Kind || tok.Value != exp.Value {
+					t.Fatalf("expected %s(%q), got %s(%q)", exp.Kind, exp.Value, tok.Kind, tok.Value)
+				}
+				if tok.Range.From.Line != exp.Range.From.Line || tok.Range.From.Column != exp.Range.From.Column {
+					t.Fatalf("%q: expected %d:%d, got %d:%d", tok.Value, exp.Range.From.Line, exp.Range.From.Column, tok.Range.From.Line, tok.Range.From.Column)
+				}
+
+				// offsets point into the original bytes
+				at := strings.Index(src[searchFrom:], tok.Value) + searchFrom
+				if tok.Range.From.Offset != at {
+					t.Fatalf("%q: expected offset %d, got %d", tok.Value, at, tok.Range.From.Offset)
+				}
+				searchFrom = at + len(tok.Value)
+			}
+			if tok := l.NextToken(); tok.Kind != tokens.EOF {
+				t.Fatalf("expected EOF, got %s(%q)", tok.Kind, tok.Value)
+			}
+		})
+	}
+}
+
+func TestLexerReportsLineAndColumnAfterCRLF(t *testing.T) {
+	l := NewLexer(strings.NewReader("\uFEFFnamespace a\r\n\r\npolicy p"), "test.sent")
+
+	var policy tokens.Instance
+	for tok := l.NextToken(); tok.Kind != tokens.EOF; tok = l.NextToken() {
+		if tok.Value == "policy" {
+			policy = tok
+		}
+	}
+	// lines are 0-based
+	if policy.Range.From.Line != 2 || policy.Range.From.Column != 1 {
+		t.Fatalf("expected policy at 2:1, got %d:%d", policy.Range.From.Line, policy.Range.From.Column)
+	}
+	if policy.Range.From.Offset != 18 {
+		t.Fatalf("expected policy at offset 18, got %d", policy.Range.From.Offset)
+	}
+}
+
+func TestLexerHereDocWithCRLF(t *testing.T) {
+	l := NewLexer(strings.NewReader("<<<TAG\r\nline one\r\nline two\r\nTAG\r\n"), "test.sent")
+
+	tok := l.NextToken()
+	if tok.Kind != tokens.String || tok.Value != "line one\nline two\n" {
+		t.Fatalf("expected heredoc string, got %s(%q)", tok.Kind, tok.Value)
+	}
+}
+
+func TestLexerIdentifierLengthLimit(t *testing.T) {
+	atLimit := strings.Repeat("a", 8)
+	tok := NewLexer(strings.NewReader(atLimit+" x"), "test.sent", WithMaxIdentifierLength(8)).NextToken()
+	if tok.Kind != tokens.Ident || tok.Value != atLimit {
+		t.Fatalf("expected identifier at the limit, got %s(%q)", tok.Kind, tok.Value)
+	}
+
+	l := NewLexer(strings.NewReader("x\n  "+atLimit+"a"), "test.sent", WithMaxIdentifierLength(8))
+	l.NextToken()
+	tok = l.NextToken()
+	if tok.Kind != tokens.Error {
+		t.Fatalf("expected error token, got %s(%q)", tok.Kind, tok.Value)
+	}
+	if !strings.Contains(tok.Value, "identifier exceeds the maximum length of 8") || !strings.Contains(tok.Value, "test.sent:2:3") {
+		t.Fatalf("unexpected error: %q", tok.Value)
+	}
+}
+
+func TestLexerStringLengthLimit(t *testing.T) {
+	atLimit := strings.Repeat("s", 8)
+	tok := NewLexer(strings.NewReader(`"`+atLimit+`"`), "test.sent", WithMaxStringLength(8)).NextToken()
+	if tok.Kind != tokens.String || tok.Value != atLimit {
+		t.Fatalf("expected string at the limit, got %s(%q)", tok.Kind, tok.Value)
+	}
+
+	tok = NewLexer(strings.NewReader(`  "`+atLimit+`s"`), "test.sent", WithMaxStringLength(8)).NextToken()
+	if tok.Kind != tokens.Error {
+		t.Fatalf("expected error token, got %s(%q)", tok.Kind, tok.Value)
+	}
+	if !strings.Contains(tok.Value, "string literal exceeds the maximum length of 8") || !strings.Contains(tok.Value, "test.sent:1:2") {
+		t.Fatalf("unexpected error: %q", tok.Value)
+	}
+}
+
+func TestLexerHereDocLengthLimit(t *testing.T) {
+	// the content is "abc\nefg\n", 8 characters
+	src := "<<<TAG\nabc\nefg\nTAG\n"
+	tok := NewLexer(strings.NewReader(src), "test.sent", WithMaxStringLength(8)).NextToken()
+	if tok.Kind != tokens.String || tok.Value != "abc\nefg\n" {
+		t.Fatalf("expected heredoc at the limit, got %s(%q)", tok.Kind, tok.Value)
+	}
+
+	tok = NewLexer(strings.NewReader(src), "test.sent", WithMaxStringLength(7)).NextToken()
+	if tok.Kind != tokens.Error || !strings.Contains(tok.Value, "string literal exceeds the maximum length of 7") {
+		t.Fatalf("expected length error, got %s(%q)", tok.Kind, tok.Value)
+	}
+
+	tok = NewLexer(strings.NewReader("<<<TAG\n"+strings.Repeat("x", 100)), "test.sent", WithMaxStringLength(7)).NextToken()
+	if tok.Kind != tokens.Error || !strings.Contains(tok.Value, "string literal exceeds the maximum length of 7") {
+		t.Fatalf("expected length error for an unterminated line, got %s(%q)", tok.Kind, tok.Value)
+	}
+}
+
+func TestLexerTemplateString(t *testing.T) {
+	tok := NewLexer(strings.NewReader(`"hi ${user.name}!"`), "test.sent").NextToken()
+	if tok.Kind != tokens.TemplateString || tok.Value != `hi ${user.name}!` {
+		t.Fatalf("expected template string, got %s(%q)", tok.Kind, tok.Value)
+	}
+
+	// braces and strings inside an interpolation do not end it
+	tok = NewLexer(strings.NewReader(`"${ {"a": "}"}["a"] }x"`), "test.sent").NextToken()
+	if tok.Kind != tokens.TemplateString || tok.Value != `${ {"a": "}"}["a"] }x` {
+		t.Fatalf("expected nested template string, got %s(%q)", tok.Kind, tok.Value)
+	}
+
+	tok = NewLexer(strings.NewReader(`"cost: \${x}"`), "test.sent").NextToken()
+	if tok.Kind != tokens.String || tok.Value != "cost: ${x}" {
+		t.Fatalf("expected escaped interpolation to stay a string, got %s(%q)", tok.Kind, tok.Value)
+	}
+
+	tok = NewLexer(strings.NewReader(`"hi ${name"`), "test.sent").NextToken()
+	if tok.Kind != tokens.Error || !strings.Contains(tok.Value, "unterminated string") {
+		t.Fatalf("expected unterminated error, got %s(%q)", tok.Kind, tok.Value)
+	}
+}
+
+func TestLexerRawString(t *testing.T) {
+	src := "\"\"\"^\\d+$ \"quoted\" ${x}\n  \\n\"\"\""
+	tok := NewLexer(strings.NewReader(src), "test.sent").NextToken()
+	if tok.Kind != tokens.String || tok.Value != "^\\d+$ \"quoted\" ${x}\n  \\n" {
+		t.Fatalf("expected raw string, got %s(%q)", tok.Kind, tok.Value)
+	}
+
+	// a longer run of quotes closes with its last three
+	tok = NewLexer(strings.NewReader(`"""say "hi"""""`), "test.sent").NextToken()
+	if tok.Kind != tokens.String || tok.Value != `say "hi""` {
+		t.Fatalf("expected trailing quotes to be kept, got %s(%q)", tok.Kind, tok.Value)
+	}
+
+	l := NewLexer(strings.NewReader(`"" x`), "test.sent")
+	if tok = l.NextToken(); tok.Kind != tokens.String || tok.Value != "" {
+		t.Fatalf("expected empty string, got %s(%q)", tok.Kind, tok.Value)
+	}
+	if tok = l.NextToken(); tok.Kind != tokens.Ident {
+		t.Fatalf("expected identifier after empty string, got %s(%q)", tok.Kind, tok.Value)
+	}
+
+	tok = NewLexer(strings.NewReader(`"""never closed ""`), "test.sent").NextToken()
+	if tok.Kind != tokens.Error || !strings.Contains(tok.Value, "unterminated string") {
+		t.Fatalf("expected unterminated error, got %s(%q)", tok.Kind, tok.Value)
+	}
+
+	tok = NewLexer(strings.NewReader(`"""`+strings.Repeat("r", 9)+`"""`), "test.sent", WithMaxStringLength(8)).NextToken()
+	if tok.Kind != tokens.Error || !strings.Contains(tok.Value, "string literal exceeds the maximum length of 8") {
+		t.Fatalf("expected length error, got %s(%q)", tok.Kind, tok.Value)
+	}
+}
+
+func TestLexerNumberLiterals(t *testing.T) {
+	valid := map[string]struct {
+		kind  tokens.Kind
+		value string
+	}{
+		"1_000_000":  {tokens.Int, "1000000"},
+		"0xFF":       {tokens.Int, "0xFF"},
+		"0Xff_ff":    {tokens.Int, "0Xffff"},
+		"0b1010":     {tokens.Int, "0b1010"},
+		"0b1_0":      {tokens.Int, "0b10"},
+		"1_000.2_5":  {tokens.Float, "1000.25"},
+		"0":          {tokens.Int, "0"},
+		"007":        {tokens.Int, "007"},
+		"12.5":       {tokens.Float, "12.5"},
+		"3_3 ":       {tokens.Int, "33"},
+		"0xdead_BEE": {tokens.Int, "0xdeadBEE"},
+	}
+	for src, want := range valid {
+		tok := NewLexer(strings.NewReader(src), "test.sent").NextToken()
+		if tok.Kind != want.kind || tok.Value != want.value {
+			t.Errorf("%q: expected %s(%q), got %s(%q)", src, want.kind, want.value, tok.Kind, tok.Value)
+		}
+	}
+
+	malformed := map[string]string{
+		"1__000": "an underscore must separate digits",
+		"1000_":  "an underscore must separate digits",
+		"1_.5":   "an underscore must separate digits",
+		"1.5_":   "an underscore must separate digits",
+		"0x_FF":  "an underscore must separate digits",
+		"0x":     "expected digits after 0x",
+		"0b102":  "unexpected '2'",
+		"0xFG":   "unexpected 'G'",
+	}
+	for src, msg := range malformed {
+		tok := NewLexer(strings.NewReader(src), "test.sent").NextToken()
+		if tok.Kind != tokens.Error || !strings.Contains(tok.Value, msg) {
+			t.Errorf("%q: expected error containing %q, got %s(%q)", src, msg, tok.Kind, tok.Value)
+		}
+	}
+}
+
+func TestLexerBlockComments(t *testing.T) {
+	l := NewLexer(strings.NewReader("/* outer /* inner */ still outer */\nx /* after code */ y"), "test.sent")
+	tok := l.NextToken()
+	if tok.Kind != tokens.LineComment || tok.Value != "outer /* inner */ still outer" {
+		t.Fatalf("expected nested line comment, got %s(%q)", tok.Kind, tok.Value)
+	}
+	if tok = l.NextToken(); tok.Kind != tokens.Ident || tok.Value != "x" {
+		t.Fatalf("expected identifier, got %s(%q)", tok.Kind, tok.Value)
+	}
+	if tok = l.NextToken(); tok.Kind != tokens.TrailingComment || tok.Value != "after code" {
+		t.Fatalf("expected trailing comment, got %s(%q)", tok.Kind, tok.Value)
+	}
+	if tok = l.NextToken(); tok.Kind != tokens.Ident || tok.Value != "y" {
+		t.Fatalf("expected identifier, got %s(%q)", tok.Kind, tok.Value)
+	}
+
+	// a lone slash is still division
+	l = NewLexer(strings.NewReader("a / b"), "test.sent")
+	l.NextToken()
+	if tok = l.NextToken(); tok.Kind != tokens.TokenDiv {
+		t.Fatalf("expected division, got %s(%q)", tok.Kind, tok.Value)
+	}
+
+	tok = NewLexer(strings.NewReader(" /* one /* two */"), "test.sent").NextToken()
+	if tok.Kind != tokens.Error || !strings.Contains(tok.Value, "unterminated block comment opened at test.sent:1:2") {
+		t.Fatalf("expected unterminated error at the opening, got %s(%q)", tok.Kind, tok.Value)
+	}
+}
+
+func TestLexerOptionalChaining(t *testing.T) {
+	l := NewLexer(strings.NewReader("a?.b ? c : d"), "test.sent")
+	want := []tokens.Kind{tokens.Ident, tokens.TokenQuestionDot, tokens.Ident, tokens.TokenQuestion, tokens.Ident, tokens.PunctColon, tokens.Ident}
+	for _, kind := range want {
+		if tok := l.NextToken(); tok.Kind != kind {
+			t.Fatalf("expected %s, got %s(%q)", kind, tok.Kind, tok.Value)
+		}
+	}
+}
@@ -18,6 +18,8 @@ package loader
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"io/fs"
 	"os"
 	"path/filepath"
@@ -30,10 +32,38 @@ import (
 )
 
 func LoadPrograms(ctx context.Context, packFile *pack.PackFile) ([]*ast.Program, error) {
+	return loadProgramsFrom(ctx, packFile.Location, constants.PolicyFileExtension)
+}
+
+// LoadTests parses the test files of the pack. A test file declares a namespace and tests of the
+// policies in it, and nothing else.
+func LoadTests(ctx context.Context, packFile *pack.PackFile) ([]*ast.Program, error) {
+	programs, err := loadProgramsFrom(ctx, packFile.Location, constants.TestFileExtension)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, program := range programs {
+		for _, stmt := range program.Statements {
+			switch stmt.(type) {
+			case *ast.NamespaceStatement, *ast.TestStatement, *ast.CommentStatement:
+			default:
+				return nil, fmt.Errorf("only tests can be declared in test file %s, found %s at %s", program.Reference, stmt.Kind(), stmt.Span())
+			}
+		}
+	}
+
+	return programs, nil
+}
+
+func loadProgramsFrom(ctx context.Context, root, extension string) ([]*ast.Program, error) {
 	// walk the directory tree - starting from root
-	// if we find a .sentra file, we load it
+	// if we find a file with the extension, we load it
+	// a file that fails to parse does not stop the walk, so that the errors of every file are
+	// reported at once
 	programs := make([]*ast.Program, 0)
-	err := fs.WalkDir(os.DirFS(packFile.Location), ".", func(path string, d fs.DirEntry, err error) error {
+	var parseErrs []error
+	err := fs.WalkDir(os.DirFS(root), ".", func(path string, d fs.DirEntry, err error) error {
 		if err != nil {
 			return err
 		}
@@ -44,11 +74,11 @@ func LoadPrograms(ctx context.Context, packFile *pack.PackFile) ([]*ast.Program,
 			return nil
 		}
 
-		if !strings.HasSuffix(filepath.Ext(d.Name()), constants.PolicyFileExtension) {
+		if FileExtension(d.Name()) != extension {
 			return nil
 		}
 
-		path = filepath.Join(packFile.Location, path)
+		path = filepath.Join(root, path)
 		file, err := os.Open(path)
 		if err != nil {
 			return err
@@ -57,7 +87,8 @@ func LoadPrograms(ctx context.Context, packFile *pack.PackFile) ([]*ast.Program,
 		parser := parser.NewParser(file, path)
 		program, err := parser.ParseProgram(ctx)
 		if err != nil {
-			return err
+			parseErrs = append(parseErrs, err)
+			return nil
 		}
 		if program == nil {
 			return nil
@@ -68,5 +99,14 @@ func LoadPrograms(ctx context.Context, packFile *pack.PackFile) ([]*ast.Program,
 		return nil
 	})
 
-	return programs, err
+	return programs, errors.Join(append([]error{err}, parseErrs...)...)
+}
+
+// FileExtension returns the extension of a file name, without the leading dot. Test files keep the
+// policy extension behind their own, so they are told apart from policy files here.
+func FileExtension(name string) string {
+	if strings.HasSuffix(name, "."+constants.TestFileExtension) {
+		return constants.TestFileExtension
+	}
+	return strings.TrimPrefix(filepath.Ext(name), ".")
 }
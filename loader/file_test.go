@@ -0,0 +1,68 @@
+// SPDX-FileCopyrightText: © 2026 Binaek Sarkar <binaek89@gmail.com>
+// SPDX-License-Identifier: Apache-2.0
+
+package loader
+
+import (
+	"context"
+	"path/filepath"
+
+	"github.com/sentrie-sh/sentrie/ast"
+	"github.com/sentrie-sh/sentrie/parser"
+)
+
+func (s *LoaderTestSuite) TestLoadTestsReadsOnlyTestFiles() {
+	ctx := context.Background()
+	dir := s.writePolicyDir(s.writePackDir(rootsPackToml), map[string]string{
+		"auth.sentrie":      "namespace com/example\npolicy auth {}\n",
+		"auth.test.sentrie": "namespace com/example\n-- tests of auth\ntest \"t\" of auth { expect allow is true }\n",
+	})
+
+	p, err := LoadPack(ctx, dir)
+	s.Require().NoError(err)
+
+	tests, err := LoadTests(ctx, p)
+	s.Require().NoError(err)
+	s.Require().Len(tests, 1)
+	s.Equal("com/example", programNamespace(tests[0]))
+	s.IsType(&ast.TestStatement{}, tests[0].Statements[len(tests[0].Statements)-1])
+
+	// test files are not policy files
+	programs, err := LoadPrograms(ctx, p)
+	s.Require().NoError(err)
+	s.Equal([]string{"com/example/auth@" + filepath.Base(dir)}, policyNames(programs))
+}
+
+func (s *LoaderTestSuite) TestLoadTestsRejectsPoliciesInTestFiles() {
+	ctx := context.Background()
+	dir := s.writePolicyDir(s.writePackDir(rootsPackToml), map[string]string{
+		"auth.test.sentrie": "namespace com/example\npolicy auth {}\n",
+	})
+
+	p, err := LoadPack(ctx, dir)
+	s.Require().NoError(err)
+
+	_, err = LoadTests(ctx, p)
+	s.Require().Error(err)
+	s.ErrorContains(err, "only tests can be declared in test file")
+}
+
+func (s *LoaderTestSuite) TestLoadProgramsReportsTheErrorsOfEveryFile() {
+	ctx := context.Background()
+	dir := s.writePolicyDir(s.writePackDir(rootsPackToml), map[string]string{
+		"a.sentrie":    "namespace com/example\npolicy a { rule r = { yield 1 + } }\n",
+		"b.sentrie":    "namespace com/example\npolicy b { fact : string }\n",
+		"good.sentrie": "namespace com/example\npolicy good {}\n",
+	})
+
+	p, err := LoadPack(ctx, dir)
+	s.Require().NoError(err)
+
+	_, err = LoadPrograms(ctx, p)
+	s.Require().Error(err)
+
+	var parseErr *parser.ParseError
+	s.ErrorAs(err, &parseErr)
+	s.ErrorContains(err, "a.sentrie")
+	s.ErrorContains(err, "b.sentrie")
+}
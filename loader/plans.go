@@ -0,0 +1,97 @@
+// SPDX-FileCopyrightText: © 2026 Binaek Sarkar <binaek89@gmail.com>
+// SPDX-License-Identifier: Apache-2.0
+
+package loader
+
+import (
+	"encoding/gob"
+	"errors"
+	"fmt"
+	"io"
+	"sync"
+
+	"github.com/sentrie-sh/sentrie/ast"
+	"github.com/sentrie-sh/sentrie/pack"
+	"github.com/sentrie-sh/sentrie/version"
+)
+
+// ErrIncompatiblePlans is returned when a plans file was encoded under another schema of the
+// programs, or for another pack.
+var ErrIncompatiblePlans = errors.New("incompatible plans file")
+
+// PlansFormat is the version of the layout of a plans file. It must be bumped with any change
+// to plansHeader or to how the programs follow it.
+const PlansFormat = 1
+
+// plansHeader leads a plans file, ahead of the programs it holds.
+type plansHeader struct {
+	// Format is the PlansFormat the file was written in
+	Format int
+	// Binary is the version of the binary that wrote the file, see binaryVersion
+	Binary string
+	// Schema is the ast.EncodingSchema the programs were encoded under
+	Schema string
+	Pack   string
+}
+
+// binaryVersion identifies the running binary: its module version when it was built from a
+// tagged release, else the revision it was built from.
+var binaryVersion = sync.OnceValue(func() string {
+	info := version.GetVersionInfo()
+	switch {
+	case info.GitVersion != "":
+		return info.GitVersion
+	case info.GitCommit != "":
+		return info.GitCommit
+	default:
+		return "devel"
+	}
+})
+
+// newPlansHeader returns the header of a plans file for packFile written by this binary.
+func newPlansHeader(packFile *pack.PackFile) plansHeader {
+	return plansHeader{Format: PlansFormat, Binary: binaryVersion(), Schema: ast.EncodingSchema(), Pack: packFile.Location}
+}
+
+// WritePlans writes the parsed programs of packFile to w. Plans hold the programs as parsed:
+// they spare loading the pack from parsing its policies, but not from indexing and validating
+// them. The programs carry the absolute positions they were parsed at, so plans only load
+// against the pack location they were built from.
+func WritePlans(w io.Writer, packFile *pack.PackFile, programs []*ast.Program) error {
+	enc := gob.NewEncoder(w)
+	if err := enc.Encode(newPlansHeader(packFile)); err != nil {
+		return fmt.Errorf("write plans header: %w", err)
+	}
+	if err := enc.Encode(programs); err != nil {
+		return fmt.Errorf("write plans: %w", err)
+	}
+	return nil
+}
+
+// ReadPlans reads the programs written by WritePlans. Plans written in another format, by
+// another version of the binary, under a different schema of the programs, or for a different
+// pack location are rejected with ErrIncompatiblePlans.
+func ReadPlans(r io.Reader, packFile *pack.PackFile) ([]*ast.Program, error) {
+	dec := gob.NewDecoder(r)
+
+	var header plansHeader
+	if err := dec.Decode(&header); err != nil {
+		return nil, fmt.Errorf("read plans header: %w", err)
+	}
+	switch {
+	case header.Format != PlansFormat:
+		return nil, fmt.Errorf("%w: written in format %d, expected %d; compile them again", ErrIncompatiblePlans, header.Format, PlansFormat)
+	case header.Binary != binaryVersion():
+		return nil, fmt.Errorf("%w: written by version %q, this is %q; compile them again", ErrIncompatiblePlans, header.Binary, binaryVersion())
+	case header.Schema != ast.EncodingSchema():
+		return nil, fmt.Errorf("%w: encoded under schema %.12s, expected %.12s; compile them again", ErrIncompatiblePlans, header.Schema, ast.EncodingSchema())
+	case header.Pack != packFile.Location:
+		return nil, fmt.Errorf("%w: built for pack %q, loading %q", ErrIncompatiblePlans, header.Pack, packFile.Location)
+	}
+
+	var programs []*ast.Program
+	if err := dec.Decode(&programs); err != nil {
+		return nil, fmt.Errorf("read plans: %w", err)
+	}
+	return programs, nil
+}
@@ -0,0 +1,114 @@
+// SPDX-FileCopyrightText: © 2026 Binaek Sarkar <binaek89@gmail.com>
+// SPDX-License-Identifier: Apache-2.0
+
+package loader
+
+import (
+	"bytes"
+	"context"
+	"encoding/gob"
+	"errors"
+	"os"
+	"path/filepath"
+
+	"github.com/sentrie-sh/sentrie/ast"
+	"github.com/sentrie-sh/sentrie/constants"
+)
+
+func statementStrings(programs []*ast.Program) []string {
+	var out []string
+	for _, program := range programs {
+		for _, stmt := range program.Statements {
+			out = append(out, program.Reference+": "+stmt.String()+" @ "+stmt.Span().String())
+		}
+	}
+	return out
+}
+
+func (s *LoaderTestSuite) TestPlansRoundTripThePackPrograms() {
+	ctx := context.Background()
+	p, err := LoadPack(ctx, "../example_pack")
+	s.Require().NoError(err)
+	programs, err := LoadPrograms(ctx, p)
+	s.Require().NoError(err)
+	s.Require().NotEmpty(programs)
+
+	var buf bytes.Buffer
+	s.Require().NoError(WritePlans(&buf, p, programs))
+
+	read, err := ReadPlans(&buf, p)
+	s.Require().NoError(err)
+	s.Equal(statementStrings(programs), statementStrings(read))
+}
+
+func (s *LoaderTestSuite) TestPlansRoundTripEveryLanguageFixture() {
+	ctx := context.Background()
+	fixtures, err := filepath.Glob("../lang_test/*" + constants.PolicyFileExtension)
+	s.Require().NoError(err)
+	s.Require().NotEmpty(fixtures)
+
+	for _, fixture := range fixtures {
+		s.Run(filepath.Base(fixture), func() {
+			source, err := os.ReadFile(fixture)
+			s.Require().NoError(err)
+			dir := s.writePackDir(rootsPackToml)
+			s.Require().NoError(os.WriteFile(filepath.Join(dir, filepath.Base(fixture)), source, 0o644))
+
+			p, err := LoadPack(ctx, dir)
+			s.Require().NoError(err)
+			programs, err := LoadPrograms(ctx, p)
+			if err != nil {
+				// some fixtures still use syntax the parser has since dropped
+				s.T().Skipf("fixture does not parse: %v", err)
+			}
+
+			var buf bytes.Buffer
+			s.Require().NoError(WritePlans(&buf, p, programs))
+			read, err := ReadPlans(&buf, p)
+			s.Require().NoError(err)
+			s.Equal(statementStrings(programs), statementStrings(read))
+		})
+	}
+}
+
+func (s *LoaderTestSuite) TestReadPlansRejectsIncompatibleFiles() {
+	ctx := context.Background()
+	p, err := LoadPack(ctx, s.writePackDir(rootsPackToml))
+	s.Require().NoError(err)
+	other, err := LoadPack(ctx, s.writePackDir(rootsPackToml))
+	s.Require().NoError(err)
+
+	var buf bytes.Buffer
+	s.Require().NoError(WritePlans(&buf, p, nil))
+	plans := buf.Bytes()
+
+	_, err = ReadPlans(bytes.NewReader(plans), other)
+	s.True(errors.Is(err, ErrIncompatiblePlans))
+
+	stale := func(edit func(*plansHeader)) error {
+		header := newPlansHeader(p)
+		edit(&header)
+		var buf bytes.Buffer
+		enc := gob.NewEncoder(&buf)
+		s.Require().NoError(enc.Encode(header))
+		s.Require().NoError(enc.Encode([]*ast.Program{}))
+		_, err := ReadPlans(&buf, p)
+		return err
+	}
+
+	err = stale(func(h *plansHeader) { h.Format = PlansFormat + 1 })
+	s.True(errors.Is(err, ErrIncompatiblePlans))
+	s.ErrorContains(err, "format 2")
+
+	err = stale(func(h *plansHeader) { h.Binary = "v0.0.1-old" })
+	s.True(errors.Is(err, ErrIncompatiblePlans))
+	s.ErrorContains(err, `version "v0.0.1-old"`)
+
+	err = stale(func(h *plansHeader) { h.Schema = "0123456789abcdef" })
+	s.True(errors.Is(err, ErrIncompatiblePlans))
+	s.ErrorContains(err, "schema 0123456789ab")
+
+	_, err = ReadPlans(bytes.NewReader([]byte("not a plans file")), p)
+	s.Error(err)
+	s.False(errors.Is(err, ErrIncompatiblePlans))
+}
@@ -0,0 +1,96 @@
+// SPDX-FileCopyrightText: © 2026 Binaek Sarkar <binaek89@gmail.com>
+// SPDX-License-Identifier: Apache-2.0
+
+package loader
+
+import (
+	"context"
+	"log/slog"
+	"slices"
+
+	"github.com/sentrie-sh/sentrie/ast"
+	"github.com/sentrie-sh/sentrie/constants"
+	"github.com/sentrie-sh/sentrie/pack"
+	"github.com/sentrie-sh/sentrie/xerr"
+)
+
+// policyOrigin records where the policy currently in effect for an FQN was declared.
+type policyOrigin struct {
+	layer     int
+	program   *ast.Program
+	statement *ast.PolicyStatement
+}
+
+// LoadPolicyRoots loads the programs of the pack and then those of every root, in order. Each
+// root is a layer on top of the ones before it: a policy declared in a later layer replaces the
+// policy with the same FQN from an earlier layer, and everything else merges. With allowOverride
+// false, such a replacement is reported as a conflict instead.
+//
+// Policies declared twice within the same layer are left in place, so that indexing reports them
+// as conflicts like it always does.
+func LoadPolicyRoots(ctx context.Context, packFile *pack.PackFile, roots []string, allowOverride bool) ([]*ast.Program, error) {
+	programs, err := LoadPrograms(ctx, packFile)
+	if err != nil {
+		return nil, err
+	}
+
+	origins := make(map[string]policyOrigin)
+	record := func(layer int, program *ast.Program) error {
+		ns := programNamespace(program)
+		for _, stmt := range program.Statements {
+			policy, ok := stmt.(*ast.PolicyStatement)
+			if !ok {
+				continue
+			}
+			fqn := ns + ast.FQNSeparator + policy.Name
+			previous, seen := origins[fqn]
+			if seen && previous.layer != layer {
+				if !allowOverride {
+					return xerr.ErrConflict("policy declaration", policy.Span(), previous.statement.Span())
+				}
+				previous.program.Statements = slices.DeleteFunc(previous.program.Statements, func(s ast.Statement) bool {
+					return s == ast.Statement(previous.statement)
+				})
+				slog.InfoContext(ctx, "policy overridden",
+					slog.String("policy", fqn),
+					slog.String("by", program.Reference),
+					slog.String("replaces", previous.program.Reference),
+				)
+			}
+			if !seen || previous.layer != layer {
+				origins[fqn] = policyOrigin{layer: layer, program: program, statement: policy}
+			}
+		}
+		return nil
+	}
+
+	for _, program := range programs {
+		if err := record(0, program); err != nil {
+			return nil, err
+		}
+	}
+
+	for i, root := range roots {
+		layer, err := loadProgramsFrom(ctx, root, constants.PolicyFileExtension)
+		if err != nil {
+			return nil, err
+		}
+		for _, program := range layer {
+			if err := record(i+1, program); err != nil {
+				return nil, err
+			}
+		}
+		programs = append(programs, layer...)
+	}
+
+	return programs, nil
+}
+
+func programNamespace(program *ast.Program) string {
+	for _, stmt := range program.Statements {
+		if ns, ok := stmt.(*ast.NamespaceStatement); ok {
+			return ns.String()
+		}
+	}
+	return ""
+}
@@ -0,0 +1,95 @@
+// SPDX-FileCopyrightText: © 2026 Binaek Sarkar <binaek89@gmail.com>
+// SPDX-License-Identifier: Apache-2.0
+
+package loader
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+
+	"github.com/sentrie-sh/sentrie/ast"
+	"github.com/sentrie-sh/sentrie/xerr"
+)
+
+const rootsPackToml = `[schema]
+version = 1
+
+[pack]
+name = "test_pack"
+version = "0.1.0"
+`
+
+func (s *LoaderTestSuite) writePolicyDir(dir string, files map[string]string) string {
+	for name, content := range files {
+		s.Require().NoError(os.WriteFile(filepath.Join(dir, name), []byte(content), 0o644))
+	}
+	return dir
+}
+
+func policyNames(programs []*ast.Program) []string {
+	var names []string
+	for _, program := range programs {
+		ns := programNamespace(program)
+		for _, stmt := range program.Statements {
+			if policy, ok := stmt.(*ast.PolicyStatement); ok {
+				names = append(names, ns+"/"+policy.Name+"@"+filepath.Base(filepath.Dir(program.Reference)))
+			}
+		}
+	}
+	return names
+}
+
+func (s *LoaderTestSuite) TestLoadPolicyRootsOverridesEarlierRoot() {
+	ctx := context.Background()
+	base := s.writePolicyDir(s.writePackDir(rootsPackToml), map[string]string{
+		"base.sentrie": "namespace com/example\npolicy auth {}\npolicy audit {}\n",
+	})
+	overrides := s.writePolicyDir(s.T().TempDir(), map[string]string{
+		"auth.sentrie": "namespace com/example\npolicy auth {}\npolicy extra {}\n",
+	})
+
+	p, err := LoadPack(ctx, base)
+	s.Require().NoError(err)
+
+	programs, err := LoadPolicyRoots(ctx, p, []string{overrides}, true)
+	s.Require().NoError(err)
+	s.ElementsMatch([]string{
+		"com/example/audit@" + filepath.Base(base),
+		"com/example/auth@" + filepath.Base(overrides),
+		"com/example/extra@" + filepath.Base(overrides),
+	}, policyNames(programs))
+}
+
+func (s *LoaderTestSuite) TestLoadPolicyRootsNoOverrideConflicts() {
+	ctx := context.Background()
+	base := s.writePolicyDir(s.writePackDir(rootsPackToml), map[string]string{
+		"base.sentrie": "namespace com/example\npolicy auth {}\n",
+	})
+	overrides := s.writePolicyDir(s.T().TempDir(), map[string]string{
+		"auth.sentrie": "namespace com/example\npolicy auth {}\n",
+	})
+
+	p, err := LoadPack(ctx, base)
+	s.Require().NoError(err)
+
+	_, err = LoadPolicyRoots(ctx, p, []string{overrides}, false)
+	s.Require().Error(err)
+	var conflict xerr.ConflictError
+	s.ErrorAs(err, &conflict)
+}
+
+func (s *LoaderTestSuite) TestLoadPolicyRootsKeepsDuplicatesWithinARoot() {
+	ctx := context.Background()
+	base := s.writePolicyDir(s.writePackDir(rootsPackToml), map[string]string{
+		"a.sentrie": "namespace com/example\npolicy auth {}\n",
+		"b.sentrie": "namespace com/example\npolicy auth {}\n",
+	})
+
+	p, err := LoadPack(ctx, base)
+	s.Require().NoError(err)
+
+	programs, err := LoadPolicyRoots(ctx, p, nil, true)
+	s.Require().NoError(err)
+	s.Len(policyNames(programs), 2)
+}
@@ -25,6 +25,7 @@ import (
 	"os/signal"
 	"strings"
 	"sync"
+	"syscall"
 
 	"github.com/google/uuid"
 	"github.com/sentrie-sh/sentrie/cmd"
@@ -71,7 +72,8 @@ var versionOnce = sync.OnceValue(func() vers.Info {
 func main() {
 	ctx := context.Background()
 
-	ctx, stop := signal.NotifyContext(ctx, os.Interrupt, os.Kill)
+	// SIGTERM, as sent by process managers, shuts down as gracefully as an interrupt
+	ctx, stop := signal.NotifyContext(ctx, os.Interrupt, syscall.SIGTERM, os.Kill)
 	defer stop()
 
 	// set an exit code
@@ -84,8 +86,10 @@ func main() {
 	cli := cmd.Setup(ctx, vers)
 	if err := cmd.Execute(ctx, cli, os.Args); err != nil {
 		// pretty print the error in the forn <red>Error</red>: <error>
-		fmt.Printf("Error: %s\n", err)
-		exitCode = 1
+		if !cmd.Silent(err) {
+			fmt.Printf("Error: %s\n", err)
+		}
+		exitCode = cmd.ExitCode(err)
 	}
 	os.Exit(exitCode)
 }
@@ -25,31 +25,42 @@ import (
 
 func parseFieldAccessExpression(ctx context.Context, p *Parser, left ast.Expression, precedence Precedence) ast.Expression {
 	operatorToken := p.advance()
-	if !operatorToken.IsOfKind(tokens.TokenDot) {
+	if !operatorToken.IsOfKind(tokens.TokenDot) && !operatorToken.IsOfKind(tokens.TokenQuestionDot) {
 		return nil // Error in parsing field access
 	}
 
-	fieldName, found := p.advanceExpected(tokens.Ident)
+	fieldName, found := p.advanceName()
 	if !found {
 		return nil
 	}
 
-	return ast.NewFieldAccessExpression(left, fieldName.Value, tokens.Range{
+	access := ast.NewFieldAccessExpression(left, fieldName.Value, tokens.Range{
 		File: operatorToken.Range.File,
 		From: operatorToken.Range.From,
 		To:   fieldName.Range.To,
 	})
+	access.Optional = operatorToken.IsOfKind(tokens.TokenQuestionDot)
+	return access
 }
 
+// parseIndexAccessExpression parses `left[index]` and the slice forms `left[start:end]`,
+// `left[:end]`, `left[start:]` and `left[:]`.
 func parseIndexAccessExpression(ctx context.Context, p *Parser, left ast.Expression, precedence Precedence) ast.Expression {
 	lbracket, found := p.advanceExpected(tokens.PunctLeftBracket)
 	if !found {
 		return nil // Error in parsing index access
 	}
 
-	index := p.parseExpression(ctx, LOWEST)
-	if index == nil {
-		return nil // Error in parsing index expression
+	var index ast.Expression
+	if !p.canExpect(tokens.PunctColon) {
+		index = p.parseExpression(ctx, LOWEST)
+		if index == nil {
+			return nil // Error in parsing index expression
+		}
+	}
+
+	if p.canExpect(tokens.PunctColon) {
+		return parseSliceRemainder(ctx, p, left, index, lbracket)
 	}
 
 	rBracket, found := p.advanceExpected(tokens.PunctRightBracket)
@@ -63,3 +74,29 @@ func parseIndexAccessExpression(ctx context.Context, p *Parser, left ast.Express
 		To:   rBracket.Range.To,
 	})
 }
+
+// parseSliceRemainder parses the `:end]` of a slice whose (possibly omitted) start is already parsed
+func parseSliceRemainder(ctx context.Context, p *Parser, left ast.Expression, start ast.Expression, lbracket tokens.Instance) ast.Expression {
+	if !p.expect(tokens.PunctColon) {
+		return nil
+	}
+
+	var end ast.Expression
+	if !p.canExpect(tokens.PunctRightBracket) {
+		end = p.parseExpression(ctx, LOWEST)
+		if end == nil {
+			return nil // Error in parsing slice end
+		}
+	}
+
+	rBracket, found := p.advanceExpected(tokens.PunctRightBracket)
+	if !found {
+		return nil // Error in parsing slice
+	}
+
+	return ast.NewSliceExpression(left, start, end, tokens.Range{
+		File: rBracket.Range.File,
+		From: lbracket.Range.From,
+		To:   rBracket.Range.To,
+	})
+}
@@ -101,7 +101,7 @@ func parseMapLiteral(ctx context.Context, p *Parser) ast.Expression {
 				return nil
 			}
 		} else {
-			p.errorf("expected string or [expression] as map key, got %s at %s", p.current.Kind, p.current.Range.From)
+			p.errorf("expected string or [expression] as map key, got %s", p.current.Kind)
 			return nil
 		}
 
@@ -308,7 +308,7 @@ namespace com/example;
 	program, err := parser.ParseProgram(s.T().Context())
 	s.Error(err)
 	s.Nil(program)
-	s.Contains(err.Error(), "expected")
+	s.Contains(err.Error(), "unterminated block comment opened at test.sentra:2:1")
 }
 
 // TestParseWithCommentsInStrings tests parsing with comments in strings
@@ -592,3 +592,70 @@ export shape User
 	}
 	s.Equal(1, shapeExportCount, "Expected 1 shape export statement")
 }
+
+// TestParseWithBlockComments tests parsing with block comments, including nested ones
+func (s *ParserTestSuite) TestParseWithBlockComments() {
+	input := `
+/* header
+   spanning lines */
+namespace com/example
+policy test {
+    fact user: string
+    /*
+    rule disabled = default false {
+        /* nested */
+        yield user == "admin"
+    }
+    */
+    rule allow = default false { yield user /* trailing */ == "admin" }
+    export decision of allow
+}
+`
+	parser := NewParserFromString(input, "test.sentra")
+	program, err := parser.ParseProgram(s.T().Context())
+	s.Require().NoError(err)
+
+	comment, ok := program.Statements[0].(*ast.CommentStatement)
+	s.Require().True(ok)
+	s.Equal("header\n   spanning lines", comment.Content)
+
+	var policy *ast.PolicyStatement
+	for _, stmt := range program.Statements {
+		if ps, ok := stmt.(*ast.PolicyStatement); ok {
+			policy = ps
+		}
+	}
+	s.Require().NotNil(policy)
+
+	// the commented-out rule, nested comment included, is gone
+	var rules []string
+	for _, stmt := range policy.Statements {
+		if rule, ok := stmt.(*ast.RuleStatement); ok {
+			rules = append(rules, rule.RuleName)
+		}
+	}
+	s.Equal([]string{"allow"}, rules)
+}
+
+// TestParseUnterminatedBlockComment tests that an unterminated block comment reports where it opened
+func (s *ParserTestSuite) TestParseUnterminatedBlockComment() {
+	parser := NewParserFromString("namespace com/example\n  /* open /* nested */\n", "test.sentra")
+	_, err := parser.ParseProgram(s.T().Context())
+	s.Require().Error(err)
+	s.Contains(err.Error(), "unterminated block comment opened at test.sentra:2:3")
+}
+
+// TestParseUnterminatedBlockCommentInsideExpression tests that the lexer error, not the token the
+// parser expected, is reported when a block comment opened inside an expression never closes
+func (s *ParserTestSuite) TestParseUnterminatedBlockCommentInsideExpression() {
+	for _, input := range []string{
+		"namespace com/example\npolicy p {\n  rule r = default true { yield 1 + /* open }\n}\n",
+		"namespace com/example\npolicy p {\n  let xs = [1, /* open\n}\n",
+	} {
+		parser := NewParserFromString(input, "test.sentra")
+		_, err := parser.ParseProgram(s.T().Context())
+		s.Require().Error(err, input)
+		s.Contains(err.Error(), "unterminated block comment opened at test.sentra:3:", input)
+		s.NotContains(err.Error(), "got Error", input)
+	}
+}
@@ -0,0 +1,34 @@
+// SPDX-FileCopyrightText: © 2026 Binaek Sarkar <binaek89@gmail.com>
+// SPDX-License-Identifier: Apache-2.0
+
+package parser
+
+import (
+	"context"
+
+	"github.com/sentrie-sh/sentrie/ast"
+	"github.com/sentrie-sh/sentrie/tokens"
+)
+
+// 'defined' '(' @expr ')', the prefix form of '@expr is defined'
+func parseDefinedExpression(ctx context.Context, p *Parser) ast.Expression {
+	head := p.advance() // consume 'defined'
+
+	if !p.expect(tokens.PunctLeftParentheses) {
+		return nil
+	}
+
+	operand := p.parseExpression(ctx, LOWEST)
+	if operand == nil {
+		return nil
+	}
+
+	rparen, found := p.advanceExpected(tokens.PunctRightParentheses)
+	if !found {
+		return nil
+	}
+
+	rnge := head.Range
+	rnge.To = rparen.Range.To
+	return ast.NewIsDefinedExpression(operand, rnge)
+}
@@ -0,0 +1,74 @@
+// SPDX-FileCopyrightText: © 2026 Binaek Sarkar <binaek89@gmail.com>
+// SPDX-License-Identifier: Apache-2.0
+
+package parser
+
+import (
+	"context"
+	"slices"
+
+	"github.com/sentrie-sh/sentrie/ast"
+	"github.com/sentrie-sh/sentrie/tokens"
+)
+
+// parseDeriveStatement parses derive name(a, b) = expression. Like 'combining', 'derive' is
+// not a keyword, so that it stays usable as a name everywhere else.
+func parseDeriveStatement(ctx context.Context, p *Parser) ast.Statement {
+	head := p.advance() // consume 'derive'
+	rnge := head.Range
+
+	nameIdent, ok := p.advanceExpected(tokens.Ident)
+	if !ok {
+		return nil
+	}
+
+	if !p.expect(tokens.PunctLeftParentheses) {
+		return nil
+	}
+	params := []string{}
+	for !p.canExpect(tokens.PunctRightParentheses) {
+		if len(params) > 0 && !p.expect(tokens.PunctComma) {
+			return nil
+		}
+		param, ok := p.advanceExpected(tokens.Ident)
+		if !ok {
+			return nil
+		}
+		if slices.Contains(params, param.Value) {
+			p.errorf("duplicate parameter '%s' of derive '%s'", param.Value, nameIdent.Value)
+			return nil
+		}
+		params = append(params, param.Value)
+	}
+	if !p.expect(tokens.PunctRightParentheses) {
+		return nil
+	}
+
+	if !p.expect(tokens.TokenAssign) {
+		return nil
+	}
+
+	body := p.parseExpression(ctx, LOWEST)
+	if body == nil {
+		return nil
+	}
+	rnge.To = body.Span().To
+
+	return ast.NewDeriveStatement(nameIdent.Value, params, body, rnge)
+}
+
+func isDeriveStatement(p *Parser) bool {
+	return p.head().IsOfKind(tokens.Ident) && p.head().Value == "derive" && p.peek().IsOfKind(tokens.Ident)
+}
+
+// parseContextualPolicyStatement parses the policy statements that start with a contextual
+// keyword rather than a keyword.
+func parseContextualPolicyStatement(ctx context.Context, p *Parser) ast.Statement {
+	if isDeriveStatement(p) {
+		return parseDeriveStatement(ctx, p)
+	}
+	if isRequireStatement(p) {
+		return parseRequireStatement(ctx, p)
+	}
+	return parseCombiningStatement(ctx, p)
+}
@@ -16,6 +16,23 @@
 
 package parser
 
-import "errors"
+import (
+	"errors"
+	"fmt"
+
+	"github.com/sentrie-sh/sentrie/tokens"
+)
 
 var ErrParse = errors.New("parse error")
+
+// ParseError is a syntax error, located at the token the parser stopped at.
+type ParseError struct {
+	Range tokens.Range
+	Err   error
+}
+
+func (e *ParseError) Error() string {
+	return fmt.Sprintf("parsing error at %s: %s", e.Range.String(), e.Err)
+}
+
+func (e *ParseError) Unwrap() error { return e.Err }
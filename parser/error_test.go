@@ -235,3 +235,16 @@ func (s *ParserTestSuite) TestParseErrorEdgeCases() {
 		}
 	}
 }
+
+// TestParseErrorCarriesRange tests that syntax errors can be located without parsing the message
+func (s *ParserTestSuite) TestParseErrorCarriesRange() {
+	parser := NewParserFromString("namespace com/example\npolicy auth {\n  rule allow = default true { yield ) }\n}\n", "test.sentra")
+	_, err := parser.ParseProgram(s.T().Context())
+	s.Require().Error(err)
+
+	var parseErr *ParseError
+	s.Require().ErrorAs(err, &parseErr)
+	s.Equal("test.sentra", parseErr.Range.File)
+	s.Equal(2, parseErr.Range.From.Line)
+	s.Contains(err.Error(), "parsing error at test.sentra:3:")
+}
@@ -23,7 +23,7 @@ import (
 	"github.com/sentrie-sh/sentrie/tokens"
 )
 
-// 'export decision of @ident ( attach @ident as @expr )*'
+// 'export decision of @ident ( attach @ident as @expr | obligation @string = @expr | advice @string = @expr )*'
 func parseRuleExportStatement(ctx context.Context, p *Parser) ast.Statement {
 	head := p.head()
 
@@ -50,8 +50,13 @@ func parseRuleExportStatement(ctx context.Context, p *Parser) ast.Statement {
 	}
 
 	attachments := []*ast.AttachmentClause{}
-	for p.head().IsOfKind(tokens.KeywordAttach) {
-		attachment := parseAttachmentClause(ctx, p)
+	for p.head().IsOfKind(tokens.KeywordAttach) || isDirectiveClause(p) {
+		var attachment *ast.AttachmentClause
+		if p.head().IsOfKind(tokens.KeywordAttach) {
+			attachment = parseAttachmentClause(ctx, p)
+		} else {
+			attachment = parseDirectiveClause(ctx, p)
+		}
 		if attachment == nil {
 			return nil
 		}
@@ -89,3 +94,45 @@ func parseAttachmentClause(ctx context.Context, p *Parser) *ast.AttachmentClause
 		To:   asExpr.Span().To,
 	})
 }
+
+// 'obligation @string ( : @typeRef )? = @expr' or 'advice @string ( : @typeRef )? = @expr'.
+// Neither is a keyword, so that both stay usable as names everywhere else.
+func parseDirectiveClause(ctx context.Context, p *Parser) *ast.AttachmentClause {
+	head := p.advance() // consume 'obligation' or 'advice'
+
+	what, found := p.advanceExpected(tokens.String)
+	if !found {
+		return nil
+	}
+
+	var typeRef ast.TypeRef
+	if p.canExpect(tokens.PunctColon) {
+		p.advance() // consume ':'
+		typeRef = parseTypeRef(ctx, p)
+		if typeRef == nil {
+			return nil
+		}
+	}
+
+	if !p.expect(tokens.TokenAssign) {
+		return nil
+	}
+
+	value := p.parseExpression(ctx, LOWEST)
+	if value == nil {
+		return nil
+	}
+
+	return ast.NewDirectiveClause(head.Value, what.Value, typeRef, value, tokens.Range{
+		File: head.Range.File,
+		From: head.Range.From,
+		To:   value.Span().To,
+	})
+}
+
+func isDirectiveClause(p *Parser) bool {
+	head := p.head()
+	return head.IsOfKind(tokens.Ident) &&
+		(head.Value == ast.DirectiveObligation || head.Value == ast.DirectiveAdvice) &&
+		p.peek().IsOfKind(tokens.String)
+}
@@ -529,3 +529,82 @@ func (s *ParserTestSuite) TestParseExpressionEdgeCases() {
 		}
 	}
 }
+
+// TestParseExpressionIntegerLiteralForms tests parsing separated, hex and binary integer literals
+func (s *ParserTestSuite) TestParseExpressionIntegerLiteralForms() {
+	for input, want := range map[string]float64{
+		`1_000_000`: 1000000,
+		`0xFF`:      255,
+		`0x7f_ff`:   32767,
+		`0b1010`:    10,
+		`010`:       10,
+	} {
+		parser := NewParserFromString(input, "test.sentra")
+		expr := parser.parseExpression(s.T().Context(), LOWEST)
+		s.Require().NoError(parser.err, input)
+
+		intLit, ok := expr.(*ast.IntegerLiteral)
+		s.Require().True(ok, input)
+		s.Equal(want, intLit.Value, input)
+	}
+
+	parser := NewParserFromString(`0xFF + 1`, "test.sentra")
+	expr := parser.parseExpression(s.T().Context(), LOWEST)
+	s.Require().NoError(parser.err)
+	s.Equal("(255 + 1)", expr.String())
+
+	parser = NewParserFromString(`1__0`, "test.sentra")
+	parser.parseExpression(s.T().Context(), LOWEST)
+	s.Error(parser.err)
+}
+
+// TestParseExpressionDefined tests parsing the defined operator
+func (s *ParserTestSuite) TestParseExpressionDefined() {
+	parser := NewParserFromString(`defined(user?.profile) and user.allowed`, "test.sentra")
+
+	expr := parser.parseExpression(s.T().Context(), LOWEST)
+	s.Require().NoError(parser.err)
+
+	infix, ok := expr.(*ast.InfixExpression)
+	s.Require().True(ok)
+	defined, ok := infix.Left.(*ast.IsDefinedExpression)
+	s.Require().True(ok)
+	access, ok := defined.Left.(*ast.FieldAccessExpression)
+	s.Require().True(ok)
+	s.True(access.Optional)
+}
+
+// TestParseExpressionDefinedInvalid tests parsing malformed defined operators
+func (s *ParserTestSuite) TestParseExpressionDefinedInvalid() {
+	for _, input := range []string{`defined user`, `defined(user`, `defined()`} {
+		parser := NewParserFromString(input, "test.sentra")
+		s.Nil(parser.parseExpression(s.T().Context(), LOWEST), input)
+		s.Error(parser.err, input)
+	}
+}
+
+func (s *ParserTestSuite) TestParseExpressionParsesTheWholeInput() {
+	expr, err := NewParserFromString(`user.role == "admin" and count(grants) > 0;`, "repl").ParseExpression(s.T().Context())
+	s.Require().NoError(err)
+	s.IsType(&ast.InfixExpression{}, expr)
+
+	_, err = NewParserFromString(`1 + 2 3`, "repl").ParseExpression(s.T().Context())
+	var parseErr *ParseError
+	s.Require().ErrorAs(err, &parseErr)
+	s.Equal(6, parseErr.Range.From.Offset)
+	s.Contains(err.Error(), "unexpected '3'")
+
+	_, err = NewParserFromString(``, "repl").ParseExpression(s.T().Context())
+	s.ErrorIs(err, ErrParse)
+}
+
+func (s *ParserTestSuite) TestParseUseParsesASingleUseStatement() {
+	use, err := NewParserFromString(`use { trim, to_upper } from @sentrie/std as std`, "repl").ParseUse(s.T().Context())
+	s.Require().NoError(err)
+	s.Equal([]string{"trim", "to_upper"}, use.Modules)
+	s.Equal([]string{"sentrie", "std"}, use.LibFrom)
+	s.Equal("std", use.As)
+
+	_, err = NewParserFromString(`use { trim } from @sentrie/std as std 1`, "repl").ParseUse(s.T().Context())
+	s.Error(err)
+}
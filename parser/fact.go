@@ -23,8 +23,9 @@ import (
 	"github.com/sentrie-sh/sentrie/tokens"
 )
 
-// 'fact' @ident ('?'?) ':' <type> ( 'as' @ident )? ( 'default' <expression> )?
+// 'fact' 'sensitive'? @ident ('?'?) ':' <type> ( 'as' @ident )? ( 'default' <expression> )?
 // ? = optional (sets optional=true)
+// sensitive = the value is redacted in results, traces and errors
 // Type-level nullability is represented by <type>?.
 func parseFactStatement(ctx context.Context, p *Parser) ast.Statement {
 	start := p.head()
@@ -35,6 +36,12 @@ func parseFactStatement(ctx context.Context, p *Parser) ast.Statement {
 		return nil
 	}
 
+	sensitive := false
+	if isSensitiveFactModifier(p) {
+		p.advance() // consume 'sensitive'
+		sensitive = true
+	}
+
 	nameIdent, found := p.advanceExpected(tokens.Ident)
 	if !found {
 		return nil
@@ -92,5 +99,13 @@ func parseFactStatement(ctx context.Context, p *Parser) ast.Statement {
 		rnge.To = defaultExpr.Span().To
 	}
 
-	return ast.NewFactStatement(name, typ_, alias, defaultExpr, optional, rnge)
+	stmt := ast.NewFactStatement(name, typ_, alias, defaultExpr, optional, rnge)
+	stmt.Sensitive = sensitive
+	return stmt
+}
+
+// isSensitiveFactModifier reports whether the head is the contextual `sensitive` modifier of a fact.
+// It is not a keyword, so a fact named `sensitive` still parses.
+func isSensitiveFactModifier(p *Parser) bool {
+	return p.head().IsOfKind(tokens.Ident) && p.head().Value == "sensitive" && p.peek().IsOfKind(tokens.Ident)
 }
@@ -20,3 +20,25 @@ func (s *ParserTestSuite) TestParseFactNullableTypeRef() {
 	s.True(factStmt.Optional)
 	s.True(ast.IsNullableTypeRef(factStmt.Type))
 }
+
+func (s *ParserTestSuite) TestParseSensitiveFact() {
+	parser := NewParserFromString("fact sensitive token: string as apiToken", "test.sentra")
+	stmt := parseFactStatement(context.Background(), parser)
+	s.Require().NoError(parser.err)
+
+	factStmt, ok := stmt.(*ast.FactStatement)
+	s.Require().True(ok)
+	s.True(factStmt.Sensitive)
+	s.Equal("token", factStmt.Name)
+	s.Equal("apiToken", factStmt.Alias)
+
+	// `sensitive` is not a keyword, so it still names a fact
+	parser = NewParserFromString("fact sensitive: string", "test.sentra")
+	stmt = parseFactStatement(context.Background(), parser)
+	s.Require().NoError(parser.err)
+
+	factStmt, ok = stmt.(*ast.FactStatement)
+	s.Require().True(ok)
+	s.False(factStmt.Sensitive)
+	s.Equal("sensitive", factStmt.Name)
+}
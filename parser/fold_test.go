@@ -0,0 +1,72 @@
+// SPDX-FileCopyrightText: © 2026 Binaek Sarkar <binaek89@gmail.com>
+// SPDX-License-Identifier: Apache-2.0
+
+package parser
+
+import (
+	"github.com/sentrie-sh/sentrie/ast"
+)
+
+// foldedLet parses a let of the expression src and folds its constants.
+func (s *ParserTestSuite) foldedLet(src string) *ast.VarDeclaration {
+	parser := NewParserFromString(src, "test.sentra")
+	expr := parser.parseExpression(s.T().Context(), LOWEST)
+	s.Require().NoError(parser.err, src)
+	let := ast.NewVarDeclaration("x", nil, expr, expr.Span())
+	ast.FoldConstants(&ast.Program{Statements: []ast.Statement{let}})
+	return let
+}
+
+func (s *ParserTestSuite) TestFoldConstants() {
+	testCases := []struct {
+		input    string
+		expected string
+	}{
+		{"60 * 60 * 24", "86400"},
+		{"1 + 2 * 3", "7"},
+		{"7 / 2", "3.5"},
+		{"-7 % 3", "-1"},
+		{"-(2 * 3)", "-6"},
+		{`"time" + "out"`, `"timeout"`},
+		{"true and unknown", "unknown"},
+		{"false and unknown", "false"},
+		{"true xor false", "true"},
+		{"not false", "true"},
+		{"(1 + 1) * limit", "(2 * limit)"},
+		{"limit * 60 * 60", "((limit * 60) * 60)"},
+		{"[1 + 1, user.age - 1]", "[2, (user.age - 1)]"},
+		{"ok ? 60 * 60 : 0", "(ok ? 3600 : 0)"},
+	}
+
+	for _, tc := range testCases {
+		s.Equal(tc.expected, s.foldedLet(tc.input).Value.String(), tc.input)
+	}
+}
+
+func (s *ParserTestSuite) TestFoldConstantsLeavesWhatRuntimeMustDecide() {
+	for _, input := range []string{
+		"10 / 0",
+		"10 % 0",
+		"1 < 2",
+		"1 == 1",
+		`"n" + 1`,
+		"1 + unknown",
+	} {
+		let := s.foldedLet(input)
+		_, folded := let.Value.(*ast.InfixExpression)
+		s.True(folded, input)
+	}
+}
+
+func (s *ParserTestSuite) TestFoldConstantsKeepsTheOutermostSpan() {
+	parser := NewParserFromString("60 * 60 * 24", "test.sentra")
+	expr := parser.parseExpression(s.T().Context(), LOWEST)
+	s.Require().NoError(parser.err)
+	outermost := expr.Span()
+
+	let := ast.NewVarDeclaration("x", nil, expr, outermost)
+	ast.FoldConstants(&ast.Program{Statements: []ast.Statement{let}})
+	literal, ok := let.Value.(*ast.IntegerLiteral)
+	s.Require().True(ok)
+	s.Equal(outermost, literal.Span())
+}
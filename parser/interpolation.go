@@ -0,0 +1,156 @@
+// SPDX-FileCopyrightText: © 2026 Binaek Sarkar <binaek89@gmail.com>
+// SPDX-License-Identifier: Apache-2.0
+
+package parser
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/sentrie-sh/sentrie/ast"
+	"github.com/sentrie-sh/sentrie/lexer"
+	"github.com/sentrie-sh/sentrie/tokens"
+)
+
+// parseInterpolatedString splits a template string into runs of text and "${ ... }"
+// interpolations. The lexer hands the string over raw, so escapes are resolved here; an escaped
+// "\${" is text. Every interpolation is parsed by a parser of its own, started at the position of
+// the interpolation so that its ranges point into the enclosing file.
+func parseInterpolatedString(ctx context.Context, p *Parser) ast.Expression {
+	token := p.advance()
+	raw := []rune(token.Value)
+	file := token.Range.File
+
+	parts := []ast.Expression{}
+	var text strings.Builder
+	var textFrom tokens.Pos
+	pos := advancePos(token.Range.From, '"')
+
+	flush := func() {
+		if text.Len() > 0 {
+			parts = append(parts, ast.NewStringLiteral(text.String(), tokens.NewRange(file, textFrom, pos)))
+			text.Reset()
+		}
+	}
+
+	for i := 0; i < len(raw); {
+		if raw[i] == '$' && i+1 < len(raw) && raw[i+1] == '{' {
+			flush()
+			end := interpolationEnd(raw, i+2)
+			from := advancePos(advancePos(pos, '$'), '{')
+			expr := parseInterpolation(ctx, p, string(raw[i+2:end]), file, from)
+			if expr == nil {
+				return nil
+			}
+			parts = append(parts, expr)
+			for _, r := range raw[i : end+1] {
+				pos = advancePos(pos, r)
+			}
+			i = end + 1
+			continue
+		}
+
+		if text.Len() == 0 {
+			textFrom = pos
+		}
+		if raw[i] == '\\' && i+1 < len(raw) {
+			text.WriteRune(unescapeRune(raw[i+1]))
+			pos = advancePos(advancePos(pos, raw[i]), raw[i+1])
+			i += 2
+			continue
+		}
+		text.WriteRune(raw[i])
+		pos = advancePos(pos, raw[i])
+		i++
+	}
+	flush()
+
+	return ast.NewInterpolatedString(parts, token.Range)
+}
+
+// parseInterpolation parses the source of one interpolation, which must be a single expression.
+func parseInterpolation(ctx context.Context, p *Parser, src, file string, from tokens.Pos) ast.Expression {
+	if strings.TrimSpace(src) == "" {
+		p.errorf("empty interpolation in string at %s", tokens.NewRangeFromPos(file, from))
+		return nil
+	}
+
+	sub := NewParser(strings.NewReader(src), file, lexer.WithStartPosition(from))
+	expr := sub.parseExpression(ctx, LOWEST)
+	if sub.err != nil {
+		p.err = errors.Join(p.err, sub.err)
+		return nil
+	}
+	if !sub.current.IsOfKind(tokens.EOF) {
+		p.errorf("unexpected %s in string interpolation at %s", sub.current.Kind, sub.current.Range)
+		return nil
+	}
+	return expr
+}
+
+// interpolationEnd returns the index of the '}' closing the interpolation whose expression starts
+// at raw[start]. The lexer only produces template strings whose interpolations are closed.
+func interpolationEnd(raw []rune, start int) int {
+	depth := 1
+	for i := start; i < len(raw); i++ {
+		switch raw[i] {
+		case '"':
+			i = nestedStringEnd(raw, i)
+		case '{':
+			depth++
+		case '}':
+			depth--
+			if depth == 0 {
+				return i
+			}
+		}
+	}
+	return len(raw)
+}
+
+// nestedStringEnd returns the index of the quote closing the string literal opened at raw[start].
+func nestedStringEnd(raw []rune, start int) int {
+	for i := start + 1; i < len(raw); i++ {
+		switch {
+		case raw[i] == '\\':
+			i++
+		case raw[i] == '$' && i+1 < len(raw) && raw[i+1] == '{':
+			i = interpolationEnd(raw, i+2)
+		case raw[i] == '"':
+			return i
+		}
+	}
+	return len(raw)
+}
+
+// unescapeRune resolves the character following a backslash, as the lexer does for plain strings.
+func unescapeRune(r rune) rune {
+	switch r {
+	case 'n':
+		return '\n'
+	case 't':
+		return '\t'
+	case 'r':
+		return '\r'
+	case 'b':
+		return '\b'
+	case 'f':
+		return '\f'
+	default:
+		return r
+	}
+}
+
+// advancePos returns the position following r, counted the way the lexer counts it.
+func advancePos(pos tokens.Pos, r rune) tokens.Pos {
+	pos.Offset += utf8.RuneLen(r)
+	if r == '\n' {
+		pos.Line++
+		pos.Column = 1
+		return pos
+	}
+	pos.Column++
+	return pos
+}
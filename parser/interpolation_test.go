@@ -0,0 +1,78 @@
+// SPDX-FileCopyrightText: © 2026 Binaek Sarkar <binaek89@gmail.com>
+// SPDX-License-Identifier: Apache-2.0
+
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright 2026 Binaek Sarkar
+
+package parser
+
+import (
+	"github.com/sentrie-sh/sentrie/ast"
+)
+
+func (s *ParserTestSuite) TestParseInterpolatedString() {
+	p := NewParserFromString(`"hello ${user.name}, you are ${age + 1}\n"`, "test.sentra")
+	expr := p.parseExpression(s.T().Context(), LOWEST)
+	s.Require().NoError(p.err)
+
+	str, ok := expr.(*ast.InterpolatedString)
+	s.Require().True(ok)
+	s.Require().Len(str.Parts, 5)
+	s.Equal("hello ", str.Parts[0].(*ast.StringLiteral).Value)
+	s.IsType(&ast.FieldAccessExpression{}, str.Parts[1])
+	s.Equal(", you are ", str.Parts[2].(*ast.StringLiteral).Value)
+	s.IsType(&ast.InfixExpression{}, str.Parts[3])
+	s.Equal("\n", str.Parts[4].(*ast.StringLiteral).Value)
+}
+
+func (s *ParserTestSuite) TestParseInterpolatedStringNestedAndEscaped() {
+	p := NewParserFromString(`"\${literal} ${ {"k": "${x}"}["k"] }"`, "test.sentra")
+	expr := p.parseExpression(s.T().Context(), LOWEST)
+	s.Require().NoError(p.err)
+
+	str, ok := expr.(*ast.InterpolatedString)
+	s.Require().True(ok)
+	s.Require().Len(str.Parts, 2)
+	s.Equal("${literal} ", str.Parts[0].(*ast.StringLiteral).Value)
+
+	access, ok := str.Parts[1].(*ast.IndexAccessExpression)
+	s.Require().True(ok)
+	m, ok := access.Left.(*ast.MapLiteral)
+	s.Require().True(ok)
+	s.IsType(&ast.InterpolatedString{}, m.Entries[0].Value)
+}
+
+func (s *ParserTestSuite) TestParseInterpolatedStringPositions() {
+	p := NewParserFromString("\"a\nb ${ x }\"", "test.sentra")
+	expr := p.parseExpression(s.T().Context(), LOWEST)
+	s.Require().NoError(p.err)
+
+	str := expr.(*ast.InterpolatedString)
+	s.Require().Len(str.Parts, 2)
+	ident := str.Parts[1].(*ast.Identifier)
+	s.Equal(1, ident.Span().From.Line)
+	s.Equal(8, ident.Span().From.Offset)
+}
+
+func (s *ParserTestSuite) TestParseEscapedInterpolationIsAPlainString() {
+	p := NewParserFromString(`"\${x}"`, "test.sentra")
+	expr := p.parseExpression(s.T().Context(), LOWEST)
+	s.Require().NoError(p.err)
+
+	str, ok := expr.(*ast.StringLiteral)
+	s.Require().True(ok)
+	s.Equal("${x}", str.Value)
+}
+
+func (s *ParserTestSuite) TestParseInterpolatedStringErrors() {
+	for _, src := range []string{
+		`"${}"`,
+		`"${ x y }"`,
+		`"${ 1 + }"`,
+	} {
+		p := NewParserFromString(src, "test.sentra")
+		p.parseExpression(s.T().Context(), LOWEST)
+		s.Error(p.err, src)
+	}
+}
@@ -42,7 +42,7 @@ func parseConstraintLiteral(ctx context.Context, p *Parser) ast.Expression {
 	case tokens.PunctLeftCurly:
 		return parseConstraintMapLiteral(ctx, p)
 	default:
-		p.errorf("constraint arguments must be literals, got %s at %s", p.current.Kind, p.current.Range.From)
+		p.errorf("constraint arguments must be literals, got %s", p.current.Kind)
 		return nil
 	}
 }
@@ -99,7 +99,7 @@ func parseConstraintMapLiteral(ctx context.Context, p *Parser) ast.Expression {
 	for p.hasTokens() && p.current.Kind != tokens.PunctRightCurly {
 		// Parse key (must be string literal)
 		if !p.canExpect(tokens.String) {
-			p.errorf("map keys must be string literals, got %s at %s", p.current.Kind, p.current.Range.From)
+			p.errorf("map keys must be string literals, got %s", p.current.Kind)
 			return nil
 		}
 		keyToken, found := p.advanceExpected(tokens.String)
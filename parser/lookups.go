@@ -30,6 +30,8 @@ func (p *Parser) registerParseFns() {
 	p.registerPrefix(tokens.TokenMinus, parseUnaryExpression)
 	p.registerPrefix(tokens.TokenPlus, parseUnaryExpression)
 	p.registerPrefix(tokens.KeywordTransform, parseTransformExpression)
+	p.registerPrefix(tokens.KeywordDefined, parseDefinedExpression)
+	p.registerPrefix(tokens.TemplateString, parseInterpolatedString)
 
 	p.registerPrefix(tokens.PunctLeftParentheses, parseGroupedExpression)
 	p.registerPrefix(tokens.PunctLeftBracket, parseListLiteral)
@@ -49,6 +51,7 @@ func (p *Parser) registerParseFns() {
 
 	p.registerInfix(tokens.PunctLeftBracket, parseIndexAccessExpression)
 	p.registerInfix(tokens.TokenDot, parseFieldAccessExpression)
+	p.registerInfix(tokens.TokenQuestionDot, parseFieldAccessExpression)
 	p.registerInfix(tokens.PunctLeftParentheses, parseCallExpression)
 	p.registerInfix(tokens.TokenPipeForward, parsePipelineExpression)
 
@@ -79,6 +82,7 @@ func (p *Parser) registerParseFns() {
 	p.registerStatementHandler(tokens.KeywordPolicy, parseThePolicyStatement)
 	p.registerStatementHandler(tokens.KeywordShape, parseShapeStatement)
 	p.registerStatementHandler(tokens.KeywordExport, parseShapeExportStatement)
+	p.registerStatementHandler(tokens.Ident, parseTestStatement)
 
 	// policyStatementHandlers
 	p.policyStatementHandlers = make(map[tokens.Kind]statementParser)
@@ -88,6 +92,7 @@ func (p *Parser) registerParseFns() {
 	p.registerPolicyStatementHandler(tokens.KeywordDescription, parseDescriptionStatement)
 	p.registerPolicyStatementHandler(tokens.KeywordVersion, parseVersionStatement)
 	p.registerPolicyStatementHandler(tokens.KeywordTag, parseTagStatement)
+	p.registerPolicyStatementHandler(tokens.Ident, parseContextualPolicyStatement)
 	p.registerPolicyStatementHandler(tokens.KeywordRule, parseRuleStatement)
 	p.registerPolicyStatementHandler(tokens.KeywordFact, parseFactStatement)
 	p.registerPolicyStatementHandler(tokens.KeywordExport, parseRuleExportStatement)
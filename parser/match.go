@@ -0,0 +1,87 @@
+// SPDX-FileCopyrightText: © 2026 Binaek Sarkar <binaek89@gmail.com>
+// SPDX-License-Identifier: Apache-2.0
+
+package parser
+
+import (
+	"context"
+
+	"github.com/sentrie-sh/sentrie/ast"
+	"github.com/sentrie-sh/sentrie/tokens"
+)
+
+// 'match' '{' ( 'when' <expression> 'then' <expression> )+ 'else' <expression> '}'
+//
+// 'match', 'then' and 'else' are not keywords; they stay usable as names everywhere else.
+func parseMatchExpression(ctx context.Context, p *Parser) ast.Expression {
+	matchToken := p.advance() // consume 'match'
+	rnge := matchToken.Range
+
+	if !p.expect(tokens.PunctLeftCurly) {
+		return nil
+	}
+
+	skipComments := func() {
+		for p.canExpectAnyOf(tokens.TrailingComment, tokens.LineComment) {
+			p.advance()
+		}
+	}
+
+	arms := []ast.MatchArm{}
+	for skipComments(); p.canExpect(tokens.KeywordWhen); skipComments() {
+		p.advance()
+		condition := p.parseExpression(ctx, LOWEST)
+		if condition == nil {
+			return nil
+		}
+		if !isContextualKeyword(p, "then") {
+			p.errorf("expected 'then', got %s at %s", p.current.Kind, p.current.Range)
+			return nil
+		}
+		p.advance()
+		result := p.parseExpression(ctx, LOWEST)
+		if result == nil {
+			return nil
+		}
+		arms = append(arms, ast.MatchArm{Condition: condition, Result: result})
+	}
+
+	if len(arms) == 0 {
+		p.errorf("expected at least one 'when' arm in match, got %s", p.current.Kind)
+		return nil
+	}
+	if !isContextualKeyword(p, "else") {
+		p.errorf("expected 'when' or 'else' in match, got %s", p.current.Kind)
+		return nil
+	}
+	p.advance()
+
+	elseExpr := p.parseExpression(ctx, LOWEST)
+	if elseExpr == nil {
+		return nil
+	}
+	skipComments()
+
+	rightCurly, found := p.advanceExpected(tokens.PunctRightCurly)
+	if !found {
+		return nil
+	}
+	rnge.To = rightCurly.Range.To
+
+	return ast.NewMatchExpression(arms, elseExpr, rnge)
+}
+
+// isMatchExpression reports whether the head starts a match: 'match' followed by a '{' that
+// opens with 'when' or 'else', which no block or map can open with.
+func isMatchExpression(p *Parser) bool {
+	if !isContextualKeyword(p, "match") || !p.peek().IsOfKind(tokens.PunctLeftCurly) {
+		return false
+	}
+	first := p.peekPast()
+	return first.IsOfKind(tokens.KeywordWhen) || (first.IsOfKind(tokens.Ident) && first.Value == "else")
+}
+
+// isContextualKeyword reports whether the head is the identifier word.
+func isContextualKeyword(p *Parser, word string) bool {
+	return p.head().IsOfKind(tokens.Ident) && p.head().Value == word
+}
@@ -0,0 +1,75 @@
+// SPDX-FileCopyrightText: © 2026 Binaek Sarkar <binaek89@gmail.com>
+// SPDX-License-Identifier: Apache-2.0
+
+package parser
+
+import (
+	"github.com/sentrie-sh/sentrie/ast"
+)
+
+func (s *ParserTestSuite) TestParseMatchExpression() {
+	input := `match {
+  -- oldest bracket first
+  when age >= 65 then "senior"
+  when age >= 18 then "adult" -- voting age
+  else "minor"
+}`
+	parser := NewParserFromString(input, "test.sentra")
+	expr := parser.parseExpression(s.T().Context(), LOWEST)
+	s.Require().NoError(parser.err)
+	s.Require().NotNil(expr)
+
+	m, ok := expr.(*ast.MatchExpression)
+	s.Require().True(ok, "expected match expression, got %T", expr)
+	s.Require().Len(m.Arms, 2)
+	s.Equal("(age >= 65)", m.Arms[0].Condition.String())
+	s.Equal(`"senior"`, m.Arms[0].Result.String())
+	s.Equal("(age >= 18)", m.Arms[1].Condition.String())
+	s.Equal(`"minor"`, m.Else.String())
+	s.Equal(0, m.Span().From.Line)
+	s.Equal(5, m.Span().To.Line)
+}
+
+func (s *ParserTestSuite) TestParseMatchExpressionErrors() {
+	testCases := []struct {
+		input    string
+		expected string
+	}{
+		{`match { else 1 }`, "expected at least one 'when' arm in match"},
+		{`match { when a then 1 }`, "expected 'when' or 'else' in match"},
+		{`match { when a 1 else 2 }`, "expected 'then'"},
+		{`match { when a then 1 else 2`, "expected RightBrace"},
+	}
+
+	for _, tc := range testCases {
+		parser := NewParserFromString(tc.input, "test.sentra")
+		expr := parser.parseExpression(s.T().Context(), LOWEST)
+		s.Nil(expr, "expected parse failure for: %s", tc.input)
+		s.Require().Error(parser.err, "expected parser error for: %s", tc.input)
+		s.Contains(parser.err.Error(), tc.expected, "unexpected error for: %s", tc.input)
+	}
+}
+
+func (s *ParserTestSuite) TestParseContextualKeywordsAsNames() {
+	for input, expected := range map[string]string{
+		`match and then or else`:   "((match and then) or else)",
+		`project.id + date`:        "(project.id + date)",
+		`match[0]`:                 "match[0]",
+		`{ "then": require }`:      "{then: require}",
+		`conforms(match, project)`: "conforms(match, project)",
+	} {
+		parser := NewParserFromString(input, "test.sentra")
+		expr := parser.parseExpression(s.T().Context(), LOWEST)
+		s.Require().NoError(parser.err, input)
+		s.Require().NotNil(expr, input)
+		s.Equal(expected, expr.String(), input)
+	}
+}
+
+func (s *ParserTestSuite) TestParseFieldNamedLikeKeyword() {
+	parser := NewParserFromString(`regex.match("[a-z]+", name)`, "test.sentra")
+	expr := parser.parseExpression(s.T().Context(), LOWEST)
+	s.Require().NoError(parser.err)
+	s.Require().NotNil(expr)
+	s.Equal(`regex.match("[a-z]+", name)`, expr.String())
+}
@@ -18,6 +18,7 @@ package parser
 
 import (
 	"context"
+	"errors"
 	"fmt"
 
 	"github.com/sentrie-sh/sentrie/ast"
@@ -85,24 +86,26 @@ func (p *Parser) ParseProgram(ctx context.Context) (*ast.Program, error) {
 		prg.Statements = append(prg.Statements, ast.NewCommentStatement(comment.Value, comment.Range))
 	}
 
-	// Parse remaining statements
+	// Parse remaining statements. A statement that fails to parse does not stop the parse: its
+	// error is kept, and parsing resumes at the next top-level statement, so that every
+	// statement in error is reported at once.
+	var errs []error
 	for p.hasTokens() {
 		stmt := parseStatement(ctx, p)
-		if p.err != nil {
-			return nil, p.err
+		if p.err == nil && stmt == nil {
+			p.err = fmt.Errorf("failed to parse statement at line %d, column %d", p.current.Range.From.Line, p.current.Range.From.Column)
 		}
-		if stmt == nil {
-			err := fmt.Errorf("failed to parse statement at line %d, column %d", p.current.Range.From.Line, p.current.Range.From.Column)
-			p.err = err
-			return nil, err
+		if _, ok := stmt.(*ast.NamespaceStatement); ok && p.err == nil {
+			// this MUST not be a namespace statement
+			p.err = fmt.Errorf("namespace cannot be declared after namespace declaration at %s", stmt.Span())
 		}
-
-		// this MUST not be a namespace statement
-		_, ok := stmt.(*ast.NamespaceStatement)
-		if ok {
-			err := fmt.Errorf("namespace cannot be declared after namespace declaration at %s", stmt.Span())
-			p.err = err
-			return nil, err
+		if p.err != nil {
+			errs = append(errs, p.err)
+			p.err = nil
+			if !p.skipToStatement() {
+				break
+			}
+			continue
 		}
 
 		prg.Statements = append(prg.Statements, stmt)
@@ -118,5 +121,80 @@ func (p *Parser) ParseProgram(ctx context.Context) (*ast.Program, error) {
 		}
 	}
 
+	if len(errs) > 0 {
+		p.err = errors.Join(errs...)
+		return nil, p.err
+	}
 	return prg, nil
 }
+
+// skipToStatement skips the rest of a statement that failed to parse, up to the next token that
+// can start a top-level statement outside of any block. It reports false when there is no such
+// token, or when the input cannot be read past a lexer error.
+func (p *Parser) skipToStatement() bool {
+	for skipped := 0; p.hasTokens(); skipped++ {
+		if p.current.IsOfKind(tokens.Error) {
+			return false
+		}
+		if skipped > 0 && p.depth <= 0 && p.atTopLevelStatement() {
+			p.depth = 0
+			return true
+		}
+		p.advance()
+	}
+	return false
+}
+
+// atTopLevelStatement reports whether the head is a keyword starting a top-level statement.
+func (p *Parser) atTopLevelStatement() bool {
+	switch p.current.Kind {
+	case tokens.KeywordNamespace, tokens.KeywordPolicy, tokens.KeywordShape, tokens.KeywordExport:
+		return true
+	case tokens.Ident:
+		return p.current.Value == "test"
+	}
+	return false
+}
+
+// ParseExpression parses the whole input as a single expression, such as one typed at a prompt.
+// An optional semicolon may end it; anything else after the expression is a parse error.
+func (p *Parser) ParseExpression(ctx context.Context) (ast.Expression, error) {
+	if !p.hasTokens() {
+		err := &ParseError{Range: p.current.Range, Err: fmt.Errorf("expected an expression: %w", ErrParse)}
+		p.err = err
+		return nil, err
+	}
+
+	expr := p.parseExpression(ctx, LOWEST)
+	if err := p.expectInputEnd(); err != nil {
+		return nil, err
+	}
+	return expr, nil
+}
+
+// ParseUse parses the whole input as a single use statement, such as one typed at a prompt.
+func (p *Parser) ParseUse(ctx context.Context) (*ast.UseStatement, error) {
+	stmt, _ := parseUseStatement(ctx, p).(*ast.UseStatement)
+	if err := p.expectInputEnd(); err != nil {
+		return nil, err
+	}
+	return stmt, nil
+}
+
+// expectInputEnd returns the error of the parse so far, or reports what is left of the input
+// after an optional semicolon.
+func (p *Parser) expectInputEnd() error {
+	if p.err != nil {
+		return p.err
+	}
+	if p.canExpect(tokens.PunctSemicolon) {
+		p.advance()
+	}
+	for p.canExpect(tokens.TrailingComment) || p.canExpect(tokens.LineComment) {
+		p.advance()
+	}
+	if p.hasTokens() {
+		p.errorf("unexpected '%s' at %s", p.current.Value, p.current.Range)
+	}
+	return p.err
+}
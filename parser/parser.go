@@ -34,6 +34,9 @@ type Parser struct {
 
 	atEof bool // Indicates if the parser has reached the end of the file
 
+	// depth is how many of the braces consumed so far are open
+	depth int
+
 	err error
 
 	// Pratt parser function maps
@@ -43,9 +46,9 @@ type Parser struct {
 	policyStatementHandlers map[tokens.Kind]statementParser
 }
 
-// NewParser creates a new parser
-func NewParser(input io.Reader, filename string) *Parser {
-	lexer := lexer.NewLexer(input, filename)
+// NewParser creates a new parser. The lexer options bound what is accepted from the input.
+func NewParser(input io.Reader, filename string, opts ...lexer.Option) *Parser {
+	lexer := lexer.NewLexer(input, filename, opts...)
 	parser := &Parser{
 		lexer:     lexer,
 		reference: filename,
@@ -73,10 +76,16 @@ func (p *Parser) advance() tokens.Instance {
 		return tokens.Err(p.current.Range, "cannot advance, already at EOF")
 	}
 	if p.current.IsOfKind(tokens.Error) {
-		p.errorf(p.current.Value)
+		p.errorf("%s", p.current.Value)
 		return p.current
 	}
 	current := p.current
+	switch current.Kind {
+	case tokens.PunctLeftCurly:
+		p.depth++
+	case tokens.PunctRightCurly:
+		p.depth--
+	}
 	p.current = p.next
 	if p.current.Kind == tokens.EOF {
 		p.atEof = true
@@ -89,14 +98,29 @@ func (p *Parser) advance() tokens.Instance {
 func (p *Parser) advanceExpected(kind tokens.Kind) (tokens.Instance, bool) {
 	token := p.current
 	if !token.IsOfKind(kind) {
+		if p.reportLexerError() {
+			return p.current, false
+		}
 		p.errorf("expected %s, got %s at %s", kind, p.current.Kind, p.current.Range)
 		return tokens.Err(p.current.Range, fmt.Sprintf("expected %s, got %s", kind, p.current.Kind)), false
 	}
 	return p.advance(), true
 }
 
+// advanceName is advanceExpected(tokens.Ident) that also accepts a keyword, for names in
+// positions no keyword can take, such as the field in `regex.match`.
+func (p *Parser) advanceName() (tokens.Instance, bool) {
+	if kind, isKeyword := tokens.IsKeyword(p.current.Value); isKeyword && p.current.IsOfKind(kind) {
+		return p.advance(), true
+	}
+	return p.advanceExpected(tokens.Ident)
+}
+
 func (p *Parser) expect(kind tokens.Kind) bool {
 	if p.current.Kind != kind {
+		if p.reportLexerError() {
+			return false
+		}
 		p.errorf("expected '%s', got %s at %s", kind, p.current.Kind, p.current.Range)
 		return false
 	}
@@ -128,14 +152,42 @@ func (p *Parser) peek() tokens.Instance {
 	return p.next
 }
 
+// peekPast returns the first token after the peeked one that is not a comment, leaving the
+// token stream as it was. Contextual keywords use it where one token of lookahead cannot tell
+// them apart from an identifier.
+func (p *Parser) peekPast() tokens.Instance {
+	if p.atEof || p.next.IsOfKind(tokens.EOF) {
+		return tokens.Instance{Kind: tokens.EOF}
+	}
+	var read []tokens.Instance
+	for {
+		token := p.lexer.NextToken()
+		read = append(read, token)
+		if !token.IsOfKind(tokens.TrailingComment) && !token.IsOfKind(tokens.LineComment) {
+			break
+		}
+	}
+	for i := len(read) - 1; i >= 0; i-- {
+		p.lexer.PushBack(read[i])
+	}
+	return read[len(read)-1]
+}
+
+// reportLexerError reports the lexer error held by the head, if it holds one, in place of
+// whatever the parser expected there.
+func (p *Parser) reportLexerError() bool {
+	if !p.current.IsOfKind(tokens.Error) {
+		return false
+	}
+	p.errorf("%s", p.current.Value)
+	return true
+}
+
 // errorf adds a formatted error
 func (p *Parser) errorf(format string, args ...interface{}) {
-	format = "parsing error at %s: " + format
-	args = append([]any{p.current.Range.String()}, args...)
-
 	p.err = errors.Join(
 		p.err,
-		fmt.Errorf(format, args...),
+		&ParseError{Range: p.current.Range, Err: fmt.Errorf(format, args...)},
 	)
 }
 
@@ -148,5 +200,9 @@ func (p *Parser) registerInfix(tokenType tokens.Kind, fn infixParser) {
 }
 
 func (p *Parser) noPrefixParseFnError(t tokens.Instance) {
+	if t.IsOfKind(tokens.Error) {
+		p.errorf("%s", t.Value)
+		return
+	}
 	p.errorf("no prefix parse function found for '%s' at %s", t.Value, t.Range)
 }
@@ -36,7 +36,21 @@ func parsePipelineExpression(ctx context.Context, p *Parser, left ast.Expression
 		To:   right.Span().To,
 	}
 
-	const invalidPipelineRHS = "invalid pipeline target: right-hand side must be a call on an identifier or module-qualified field access"
+	const invalidPipelineRHS = "invalid pipeline target: right-hand side must be a function reference or a call on an identifier or module-qualified field access"
+
+	// a bare function reference (`x |> trim`, `x |> str.trim`) is called with the left side as its only argument
+	switch right.(type) {
+	case *ast.Identifier, *ast.FieldAccessExpression:
+		if !hasIdentifierRoot(right) {
+			p.errorf(invalidPipelineRHS)
+			return nil
+		}
+		if p.head().IsOfKind(tokens.TokenBang) {
+			p.errorf("invalid pipeline target: memoization requires call syntax, as in 'x |> f()!'")
+			return nil
+		}
+		return ast.NewCallExpression(right, []ast.Expression{left}, false, nil, pipelineRange)
+	}
 
 	rhs, ok := right.(*ast.CallExpression)
 	if !ok {
@@ -95,8 +109,12 @@ func containsPipelineHole(expr ast.Expression) bool {
 		return containsPipelineHole(t.Left)
 	case *ast.IndexAccessExpression:
 		return containsPipelineHole(t.Left) || containsPipelineHole(t.Index)
+	case *ast.SliceExpression:
+		return containsPipelineHole(t.Left) || containsPipelineHole(t.Start) || containsPipelineHole(t.End)
 	case *ast.ListLiteral:
 		return containsPipelineHoleInExprs(t.Values)
+	case *ast.InterpolatedString:
+		return containsPipelineHoleInExprs(t.Parts)
 	case *ast.MapLiteral:
 		for i := range t.Entries {
 			if containsPipelineHole(t.Entries[i].Key) || containsPipelineHole(t.Entries[i].Value) {
@@ -109,6 +127,13 @@ func containsPipelineHole(expr ast.Expression) bool {
 		return containsPipelineHole(t.Right)
 	case *ast.TernaryExpression:
 		return containsPipelineHole(t.Condition) || containsPipelineHole(t.ThenBranch) || containsPipelineHole(t.ElseBranch)
+	case *ast.MatchExpression:
+		for i := range t.Arms {
+			if containsPipelineHole(t.Arms[i].Condition) || containsPipelineHole(t.Arms[i].Result) {
+				return true
+			}
+		}
+		return containsPipelineHole(t.Else)
 	case *ast.CastExpression:
 		return containsPipelineHole(t.Expr)
 	case *ast.IsDefinedExpression:
@@ -117,6 +142,15 @@ func containsPipelineHole(expr ast.Expression) bool {
 		return containsPipelineHole(t.Left)
 	case *ast.TransformExpression:
 		return containsPipelineHole(t.Argument)
+	case *ast.ProjectExpression:
+		if containsPipelineHole(t.Source) {
+			return true
+		}
+		for i := range t.Entries {
+			if containsPipelineHole(t.Entries[i].Value) {
+				return true
+			}
+		}
 	case *ast.PrecedingCommentExpression:
 		return containsPipelineHole(t.Wrap)
 	case *ast.TrailingCommentExpression:
@@ -136,19 +170,34 @@ func substitutePipelineHoles(expr ast.Expression, replacement ast.Expression) as
 		}
 		return ast.NewCallExpression(substitutePipelineHoles(t.Callee, replacement), args, t.Memoized, t.MemoizeTTL, t.Span())
 	case *ast.FieldAccessExpression:
-		return ast.NewFieldAccessExpression(substitutePipelineHoles(t.Left, replacement), t.Field, t.Span())
+		access := ast.NewFieldAccessExpression(substitutePipelineHoles(t.Left, replacement), t.Field, t.Span())
+		access.Optional = t.Optional
+		return access
 	case *ast.IndexAccessExpression:
 		return ast.NewIndexAccessExpression(
 			substitutePipelineHoles(t.Left, replacement),
 			substitutePipelineHoles(t.Index, replacement),
 			t.Span(),
 		)
+	case *ast.SliceExpression:
+		return ast.NewSliceExpression(
+			substitutePipelineHoles(t.Left, replacement),
+			substitutePipelineHoles(t.Start, replacement),
+			substitutePipelineHoles(t.End, replacement),
+			t.Span(),
+		)
 	case *ast.ListLiteral:
 		values := make([]ast.Expression, len(t.Values))
 		for i := range t.Values {
 			values[i] = substitutePipelineHoles(t.Values[i], replacement)
 		}
 		return ast.NewListLiteral(values, t.Span())
+	case *ast.InterpolatedString:
+		parts := make([]ast.Expression, len(t.Parts))
+		for i := range t.Parts {
+			parts[i] = substitutePipelineHoles(t.Parts[i], replacement)
+		}
+		return ast.NewInterpolatedString(parts, t.Span())
 	case *ast.MapLiteral:
 		entries := make([]ast.MapEntry, len(t.Entries))
 		for i := range t.Entries {
@@ -174,6 +223,15 @@ func substitutePipelineHoles(expr ast.Expression, replacement ast.Expression) as
 			substitutePipelineHoles(t.ElseBranch, replacement),
 			t.Span(),
 		)
+	case *ast.MatchExpression:
+		arms := make([]ast.MatchArm, len(t.Arms))
+		for i := range t.Arms {
+			arms[i] = ast.MatchArm{
+				Condition: substitutePipelineHoles(t.Arms[i].Condition, replacement),
+				Result:    substitutePipelineHoles(t.Arms[i].Result, replacement),
+			}
+		}
+		return ast.NewMatchExpression(arms, substitutePipelineHoles(t.Else, replacement), t.Span())
 	case *ast.CastExpression:
 		return ast.NewCastExpression(substitutePipelineHoles(t.Expr, replacement), t.TargetType, t.Span())
 	case *ast.IsDefinedExpression:
@@ -182,6 +240,13 @@ func substitutePipelineHoles(expr ast.Expression, replacement ast.Expression) as
 		return ast.NewIsEmptyExpression(substitutePipelineHoles(t.Left, replacement), t.Span())
 	case *ast.TransformExpression:
 		return ast.NewTransformExpression(substitutePipelineHoles(t.Argument, replacement), t.Transformer, t.Span())
+	case *ast.ProjectExpression:
+		entries := make([]ast.ProjectEntry, len(t.Entries))
+		for i := range t.Entries {
+			entries[i] = t.Entries[i]
+			entries[i].Value = substitutePipelineHoles(t.Entries[i].Value, replacement)
+		}
+		return ast.NewProjectExpression(substitutePipelineHoles(t.Source, replacement), t.As, entries, t.Span())
 	case *ast.PrecedingCommentExpression:
 		return ast.NewPrecedingCommentExpression(t.CommentContent, substitutePipelineHoles(t.Wrap, replacement), t.Span())
 	case *ast.TrailingCommentExpression:
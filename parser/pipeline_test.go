@@ -29,6 +29,12 @@ func (s *ParserTestSuite) TestPipelineExpressionLowering() {
 		{"x |> f(g(#))", "f(g(x))"},
 		{"a + b |> len()", "len((a + b))"},
 		{"a ? b : c |> len()", "len((a ? b : c))"},
+		{"value |> len", "len(value)"},
+		{"value |> str.trim", "str.trim(value)"},
+		{"value |> mod.sub.fn", "mod.sub.fn(value)"},
+		{"value |> len |> math.abs", "math.abs(len(value))"},
+		{"value |> str.trim |> len()", "len(str.trim(value))"},
+		{"value |> str.trim |> str.replace(#, \" \", \"-\")", "str.replace(str.trim(value), \" \", \"-\")"},
 	}
 
 	for _, tc := range testCases {
@@ -98,11 +104,6 @@ func (s *ParserTestSuite) TestPipelineExpressionInvalidTargets() {
 		{"value |>", false},
 		{"value |> (a + b)", true},
 		{"value |> foo ? bar : baz", true},
-		{"value |> len", true},
-		{"value |> str.trim", true},
-		{"value |> mod.sub.fn", true},
-		{"value |> len |> math.abs", true},
-		{"value |> str.trim |> len", true},
 		{"x |> f!", true},
 		{"x |> f!10", true},
 		{"x |> mod.f!30", true},
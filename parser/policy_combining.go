@@ -0,0 +1,33 @@
+// SPDX-FileCopyrightText: © 2026 Binaek Sarkar <binaek89@gmail.com>
+// SPDX-License-Identifier: Apache-2.0
+
+package parser
+
+import (
+	"context"
+
+	"github.com/sentrie-sh/sentrie/ast"
+	"github.com/sentrie-sh/sentrie/tokens"
+)
+
+// parseCombiningStatement parses combining "…". 'combining' is not a keyword, so that it
+// stays usable as a name everywhere else.
+func parseCombiningStatement(ctx context.Context, p *Parser) ast.Statement {
+	_ = ctx
+	if !isCombiningStatement(p) {
+		p.errorf("unexpected token '%s'", p.head().Kind)
+		return nil
+	}
+	head := p.advance() // consume 'combining'
+	rnge := head.Range
+	strTok, ok := p.advanceExpected(tokens.String)
+	if !ok {
+		return nil
+	}
+	rnge.To = strTok.Range.To
+	return ast.NewCombiningStatement(strTok.Value, rnge)
+}
+
+func isCombiningStatement(p *Parser) bool {
+	return p.head().IsOfKind(tokens.Ident) && p.head().Value == "combining" && p.peek().IsOfKind(tokens.String)
+}
@@ -16,7 +16,8 @@ const (
 	XOR                   // xor
 	AND                   // and
 	EQUALITY              // == != is
-	COMPARISON            // > < >= <= matches contains in
+	MEMBERSHIP            // in contains
+	COMPARISON            // > < >= <= matches
 	SUM                   // + -
 	PRODUCT               // * / %
 	UNARY                 // !x -x +x not
@@ -38,9 +39,9 @@ var precedences = map[tokens.Kind]Precedence{
 	tokens.TokenGt:              COMPARISON,
 	tokens.TokenLte:             COMPARISON,
 	tokens.TokenGte:             COMPARISON,
-	tokens.KeywordIn:            COMPARISON,
+	tokens.KeywordIn:            MEMBERSHIP,
 	tokens.KeywordMatches:       COMPARISON,
-	tokens.KeywordContains:      COMPARISON,
+	tokens.KeywordContains:      MEMBERSHIP,
 	tokens.KeywordNot:           UNARY,
 	tokens.TokenBang:            UNARY,
 	tokens.TokenPlus:            SUM,
@@ -51,5 +52,6 @@ var precedences = map[tokens.Kind]Precedence{
 	tokens.PunctLeftParentheses: CALL,
 	tokens.KeywordCast:          CALL,
 	tokens.TokenDot:             INDEX,
+	tokens.TokenQuestionDot:     INDEX,
 	tokens.PunctLeftBracket:     INDEX,
 }
@@ -297,6 +297,51 @@ func (s *ParserTestSuite) TestPrecedenceEquality() {
 	})
 }
 
+// TestPrecedenceMembership tests that in and contains bind looser than comparison and tighter than equality
+func (s *ParserTestSuite) TestPrecedenceMembership() {
+	s.T().Run("InAboveEquality", func(t *testing.T) {
+		parser := NewParserFromString(`"admin" in roles == true`, "test.sentra")
+		expr := parser.parseExpression(s.T().Context(), LOWEST)
+		s.NotNil(expr, `Failed to parse: "admin" in roles == true`)
+		s.Equal(`(("admin" in roles) == true)`, expr.String())
+	})
+
+	s.T().Run("InBelowComparison", func(t *testing.T) {
+		parser := NewParserFromString(`1 < 2 in flags`, "test.sentra")
+		expr := parser.parseExpression(s.T().Context(), LOWEST)
+		s.NotNil(expr, `Failed to parse: 1 < 2 in flags`)
+		s.Equal(`((1 < 2) in flags)`, expr.String())
+	})
+
+	s.T().Run("InBelowArithmetic", func(t *testing.T) {
+		parser := NewParserFromString(`1 + 2 in xs`, "test.sentra")
+		expr := parser.parseExpression(s.T().Context(), LOWEST)
+		s.NotNil(expr, `Failed to parse: 1 + 2 in xs`)
+		s.Equal(`((1 + 2) in xs)`, expr.String())
+	})
+
+	s.T().Run("InAndIn", func(t *testing.T) {
+		parser := NewParserFromString(`"a" in xs and "b" in xs`, "test.sentra")
+		expr := parser.parseExpression(s.T().Context(), LOWEST)
+		s.NotNil(expr, `Failed to parse: "a" in xs and "b" in xs`)
+		s.Equal(`(("a" in xs) and ("b" in xs))`, expr.String())
+	})
+
+	s.T().Run("ContainsAboveEquality", func(t *testing.T) {
+		parser := NewParserFromString(`roles contains "admin" != false`, "test.sentra")
+		expr := parser.parseExpression(s.T().Context(), LOWEST)
+		s.NotNil(expr, `Failed to parse: roles contains "admin" != false`)
+		s.Equal(`((roles contains "admin") != false)`, expr.String())
+	})
+
+	s.T().Run("InChain", func(t *testing.T) {
+		parser := NewParserFromString(`x in xs in ys`, "test.sentra")
+		expr := parser.parseExpression(s.T().Context(), LOWEST)
+		s.NotNil(expr, `Failed to parse: x in xs in ys`)
+		s.Equal(`((x in xs) in ys)`, expr.String())
+	})
+}
+
 // TestPrecedenceTernary tests ternary operator precedence
 func (s *ParserTestSuite) TestPrecedenceTernary() {
 	s.T().Run("BasicTernary", func(t *testing.T) {
@@ -370,6 +415,44 @@ func (s *ParserTestSuite) TestPrecedenceTernary() {
 	})
 }
 
+// TestPrecedenceCoalesce tests the precedence of the unknown coalescing operator ?:
+func (s *ParserTestSuite) TestPrecedenceCoalesce() {
+	s.T().Run("BasicCoalesce", func(t *testing.T) {
+		parser := NewParserFromString("maybe ?: false", "test.sentra")
+		expr := parser.parseExpression(s.T().Context(), LOWEST)
+		s.NotNil(expr, "Failed to parse: maybe ?: false")
+		s.Equal("(maybe ?: false)", expr.String())
+	})
+
+	s.T().Run("OrCoalesce", func(t *testing.T) {
+		parser := NewParserFromString("a or b ?: false", "test.sentra")
+		expr := parser.parseExpression(s.T().Context(), LOWEST)
+		s.NotNil(expr, "Failed to parse: a or b ?: false")
+		s.Equal("((a or b) ?: false)", expr.String())
+	})
+
+	s.T().Run("CoalesceAnd", func(t *testing.T) {
+		parser := NewParserFromString("a ?: b and c", "test.sentra")
+		expr := parser.parseExpression(s.T().Context(), LOWEST)
+		s.NotNil(expr, "Failed to parse: a ?: b and c")
+		s.Equal("(a ?: (b and c))", expr.String())
+	})
+
+	s.T().Run("CoalesceChain", func(t *testing.T) {
+		parser := NewParserFromString("a ?: b ?: c", "test.sentra")
+		expr := parser.parseExpression(s.T().Context(), LOWEST)
+		s.NotNil(expr, "Failed to parse: a ?: b ?: c")
+		s.Equal("((a ?: b) ?: c)", expr.String())
+	})
+
+	s.T().Run("CoalesceUnknownLiteral", func(t *testing.T) {
+		parser := NewParserFromString("unknown ?: true", "test.sentra")
+		expr := parser.parseExpression(s.T().Context(), LOWEST)
+		s.NotNil(expr, "Failed to parse: unknown ?: true")
+		s.Equal("(unknown ?: true)", expr.String())
+	})
+}
+
 // TestPrecedenceUnary tests unary operator precedence
 func (s *ParserTestSuite) TestPrecedenceUnary() {
 	s.T().Run("NotTrue", func(t *testing.T) {
@@ -849,3 +932,162 @@ func (s *ParserTestSuite) TestPrecedenceAssociativity() {
 		s.Equal("((1 >= 2) >= 3)", expr.String())
 	})
 }
+
+// TestPrecedencePipeline tests that |> binds looser than every other operator and chains left to right.
+func (s *ParserTestSuite) TestPrecedencePipeline() {
+	s.T().Run("BareFunctionChain", func(t *testing.T) {
+		parser := NewParserFromString("x |> trim |> to_upper", "test.sentra")
+		expr := parser.parseExpression(s.T().Context(), LOWEST)
+		s.NotNil(expr, "Failed to parse: x |> trim |> to_upper")
+		s.Equal("to_upper(trim(x))", expr.String())
+	})
+
+	s.T().Run("ModuleFunctionChain", func(t *testing.T) {
+		parser := NewParserFromString("x |> std.trim |> std.to_upper", "test.sentra")
+		expr := parser.parseExpression(s.T().Context(), LOWEST)
+		s.NotNil(expr, "Failed to parse: x |> std.trim |> std.to_upper")
+		s.Equal("std.to_upper(std.trim(x))", expr.String())
+	})
+
+	s.T().Run("MixedBareAndCall", func(t *testing.T) {
+		parser := NewParserFromString("x |> trim |> replace(\"a\", \"b\")", "test.sentra")
+		expr := parser.parseExpression(s.T().Context(), LOWEST)
+		s.NotNil(expr, "Failed to parse: x |> trim |> replace(\"a\", \"b\")")
+		s.Equal("replace(trim(x), \"a\", \"b\")", expr.String())
+	})
+
+	s.T().Run("ArithmeticOnLeft", func(t *testing.T) {
+		parser := NewParserFromString("a + 1 * 2 |> f", "test.sentra")
+		expr := parser.parseExpression(s.T().Context(), LOWEST)
+		s.NotNil(expr, "Failed to parse: a + 1 * 2 |> f")
+		s.Equal("f((a + (1 * 2)))", expr.String())
+	})
+
+	s.T().Run("LogicalOnLeft", func(t *testing.T) {
+		parser := NewParserFromString("a and b or c |> f", "test.sentra")
+		expr := parser.parseExpression(s.T().Context(), LOWEST)
+		s.NotNil(expr, "Failed to parse: a and b or c |> f")
+		s.Equal("f(((a and b) or c))", expr.String())
+	})
+
+	s.T().Run("ComparisonOnLeft", func(t *testing.T) {
+		parser := NewParserFromString("a == b |> f", "test.sentra")
+		expr := parser.parseExpression(s.T().Context(), LOWEST)
+		s.NotNil(expr, "Failed to parse: a == b |> f")
+		s.Equal("f((a == b))", expr.String())
+	})
+
+	s.T().Run("GroupedPipelineInArithmetic", func(t *testing.T) {
+		parser := NewParserFromString("(x |> len) + 1", "test.sentra")
+		expr := parser.parseExpression(s.T().Context(), LOWEST)
+		s.NotNil(expr, "Failed to parse: (x |> len) + 1")
+		s.Equal("(len(x) + 1)", expr.String())
+	})
+}
+
+// TestPrecedenceMatch tests that match arms take full expressions and that a match composes as an operand.
+func (s *ParserTestSuite) TestPrecedenceMatch() {
+	s.T().Run("ArmsTakeFullExpressions", func(t *testing.T) {
+		parser := NewParserFromString(`match { when a > 1 and b then x + 1 * 2 when c or d then y ? 1 : 2 else z }`, "test.sentra")
+		expr := parser.parseExpression(s.T().Context(), LOWEST)
+		s.NotNil(expr, "Failed to parse match with compound arms")
+		s.Equal("match { when ((a > 1) and b) then (x + (1 * 2)) when (c or d) then (y ? 1 : 2) else z }", expr.String())
+	})
+
+	s.T().Run("MatchAsLeftOperand", func(t *testing.T) {
+		parser := NewParserFromString(`match { when a then 1 else 2 } + 3`, "test.sentra")
+		expr := parser.parseExpression(s.T().Context(), LOWEST)
+		s.NotNil(expr, "Failed to parse: match { when a then 1 else 2 } + 3")
+		s.Equal("(match { when a then 1 else 2 } + 3)", expr.String())
+	})
+
+	s.T().Run("MatchAsRightOperand", func(t *testing.T) {
+		parser := NewParserFromString(`3 * match { when a then 1 else 2 }`, "test.sentra")
+		expr := parser.parseExpression(s.T().Context(), LOWEST)
+		s.NotNil(expr, "Failed to parse: 3 * match { when a then 1 else 2 }")
+		s.Equal("(3 * match { when a then 1 else 2 })", expr.String())
+	})
+
+	s.T().Run("NestedMatch", func(t *testing.T) {
+		parser := NewParserFromString(`match { when a then match { when b then 1 else 2 } else 3 }`, "test.sentra")
+		expr := parser.parseExpression(s.T().Context(), LOWEST)
+		s.NotNil(expr, "Failed to parse nested match")
+		s.Equal("match { when a then match { when b then 1 else 2 } else 3 }", expr.String())
+	})
+
+	s.T().Run("MatchInPipeline", func(t *testing.T) {
+		parser := NewParserFromString(`match { when a then "x" else "y" } |> len`, "test.sentra")
+		expr := parser.parseExpression(s.T().Context(), LOWEST)
+		s.NotNil(expr, "Failed to parse match feeding a pipeline")
+		s.Equal(`len(match { when a then "x" else "y" })`, expr.String())
+	})
+}
+
+// TestPrecedenceSlice tests that slice bounds parse as grouped sub-expressions
+func (s *ParserTestSuite) TestPrecedenceSlice() {
+	s.T().Run("BoundsAreGrouped", func(t *testing.T) {
+		parser := NewParserFromString("arr[1+1:n]", "test.sentra")
+		expr := parser.parseExpression(s.T().Context(), LOWEST)
+		s.NotNil(expr, "Failed to parse: arr[1+1:n]")
+		s.Equal("arr[(1 + 1):n]", expr.String())
+	})
+
+	s.T().Run("OpenStart", func(t *testing.T) {
+		parser := NewParserFromString("arr[:n * 2]", "test.sentra")
+		expr := parser.parseExpression(s.T().Context(), LOWEST)
+		s.NotNil(expr, "Failed to parse: arr[:n * 2]")
+		s.Equal("arr[:(n * 2)]", expr.String())
+	})
+
+	s.T().Run("OpenEnd", func(t *testing.T) {
+		parser := NewParserFromString("arr[-1:]", "test.sentra")
+		expr := parser.parseExpression(s.T().Context(), LOWEST)
+		s.NotNil(expr, "Failed to parse: arr[-1:]")
+		s.Equal("arr[-1:]", expr.String())
+	})
+
+	s.T().Run("WholeList", func(t *testing.T) {
+		parser := NewParserFromString("arr[:]", "test.sentra")
+		expr := parser.parseExpression(s.T().Context(), LOWEST)
+		s.NotNil(expr, "Failed to parse: arr[:]")
+		s.Equal("arr[:]", expr.String())
+	})
+
+	s.T().Run("TernaryStart", func(t *testing.T) {
+		parser := NewParserFromString("arr[a ? 1 : 2:3]", "test.sentra")
+		expr := parser.parseExpression(s.T().Context(), LOWEST)
+		s.NotNil(expr, "Failed to parse: arr[a ? 1 : 2:3]")
+		s.Equal("arr[(a ? 1 : 2):3]", expr.String())
+	})
+
+	s.T().Run("SliceThenIndex", func(t *testing.T) {
+		parser := NewParserFromString("arr[1:3][0] + 1", "test.sentra")
+		expr := parser.parseExpression(s.T().Context(), LOWEST)
+		s.NotNil(expr, "Failed to parse: arr[1:3][0] + 1")
+		s.Equal("(arr[1:3][0] + 1)", expr.String())
+	})
+}
+
+// TestPrecedenceOptionalChaining tests that `?.` binds like `.` and mixes with it in a chain
+func (s *ParserTestSuite) TestPrecedenceOptionalChaining() {
+	s.T().Run("Chain", func(t *testing.T) {
+		parser := NewParserFromString("a?.b?.c", "test.sentra")
+		expr := parser.parseExpression(s.T().Context(), LOWEST)
+		s.NotNil(expr, "Failed to parse: a?.b?.c")
+		s.Equal("a?.b?.c", expr.String())
+	})
+
+	s.T().Run("MixedWithFieldAndIndex", func(t *testing.T) {
+		parser := NewParserFromString("a?.b.c[0] == 1", "test.sentra")
+		expr := parser.parseExpression(s.T().Context(), LOWEST)
+		s.NotNil(expr, "Failed to parse: a?.b.c[0] == 1")
+		s.Equal("(a?.b.c[0] == 1)", expr.String())
+	})
+
+	s.T().Run("NotConfusedWithTernary", func(t *testing.T) {
+		parser := NewParserFromString("a?.b ? 1 : 2", "test.sentra")
+		expr := parser.parseExpression(s.T().Context(), LOWEST)
+		s.NotNil(expr, "Failed to parse: a?.b ? 1 : 2")
+		s.Equal("(a?.b ? 1 : 2)", expr.String())
+	})
+}
@@ -29,6 +29,12 @@ func parseTrinaryLiteral(ctx context.Context, p *Parser) ast.Expression {
 }
 
 func parseIdentifier(ctx context.Context, p *Parser) ast.Expression {
+	switch {
+	case isMatchExpression(p):
+		return parseMatchExpression(ctx, p)
+	case isProjectExpression(p):
+		return parseProjectExpression(ctx, p)
+	}
 	token := p.advance()
 	return ast.NewIdentifier(token.Value, token.Range)
 }
@@ -40,9 +46,18 @@ func parsePipelineHoleExpression(ctx context.Context, p *Parser) ast.Expression
 
 func parseIntegerLiteral(ctx context.Context, p *Parser) ast.Expression {
 	token := p.advance()
-	value, err := strconv.ParseInt(token.Value, 10, 64)
+	digits, base := token.Value, 10
+	if len(digits) > 2 && digits[0] == '0' {
+		switch digits[1] {
+		case 'x', 'X':
+			digits, base = digits[2:], 16
+		case 'b', 'B':
+			digits, base = digits[2:], 2
+		}
+	}
+	value, err := strconv.ParseInt(digits, base, 64)
 	if err != nil {
-		p.errorf("invalid integer literal %q at %s: %w", token.Value, token.Range.From, err)
+		p.errorf("invalid integer literal %q at %s: %w", token.Value, token.Range, err)
 		return nil
 	}
 	return ast.NewIntegerLiteral(value, token.Range)
@@ -57,7 +72,7 @@ func parseFloatLiteral(ctx context.Context, p *Parser) ast.Expression {
 	token := p.advance()
 	value, err := strconv.ParseFloat(token.Value, 64)
 	if err != nil {
-		p.errorf("invalid float literal %q at %s: %w", token.Value, token.Range.From, err)
+		p.errorf("invalid float literal %q at %s: %w", token.Value, token.Range, err)
 		return nil
 	}
 	return ast.NewFloatLiteral(value, token.Range)
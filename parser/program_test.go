@@ -280,3 +280,33 @@ func (s *ParserTestSuite) TestParseProgramEdgeCases() {
 		}
 	}
 }
+
+// TestParseProgramReportsEveryStatementInError tests that a statement in error does not hide
+// those after it
+func (s *ParserTestSuite) TestParseProgramReportsEveryStatementInError() {
+	input := `namespace com/example
+policy first {
+  rule allow = { yield 1 + }
+}
+shape Valid { id: string }
+policy second {
+  fact : User
+  rule deny = { yield true }
+}
+policy third { rule ok = { yield true } export decision of ok }
+`
+	program, err := NewParserFromString(input, "test.sentrie").ParseProgram(s.T().Context())
+	s.Require().Error(err)
+	s.Nil(program)
+
+	var parseErrs []*ParseError
+	for _, e := range err.(interface{ Unwrap() []error }).Unwrap() {
+		var parseErr *ParseError
+		if s.ErrorAs(e, &parseErr) {
+			parseErrs = append(parseErrs, parseErr)
+		}
+	}
+	s.Require().Len(parseErrs, 2, err.Error())
+	s.Equal(2, parseErrs[0].Range.From.Line)
+	s.Equal(6, parseErrs[1].Range.From.Line)
+}
@@ -0,0 +1,88 @@
+// SPDX-FileCopyrightText: © 2026 Binaek Sarkar <binaek89@gmail.com>
+// SPDX-License-Identifier: Apache-2.0
+
+package parser
+
+import (
+	"context"
+
+	"github.com/sentrie-sh/sentrie/ast"
+	"github.com/sentrie-sh/sentrie/tokens"
+)
+
+// 'project' <expression> ( 'as' IDENT )? '{' ( <entry> ( ',' <entry> )* )? '}'
+// <entry> := ( IDENT | STRING ) ':' <expression> | '...' <expression>
+//
+// 'project' is not a keyword, so that it stays usable as a name everywhere else.
+func parseProjectExpression(ctx context.Context, p *Parser) ast.Expression {
+	projectToken := p.advance() // consume 'project'
+	rnge := projectToken.Range
+
+	source := p.parseExpression(ctx, LOWEST)
+	if source == nil {
+		return nil
+	}
+
+	as := ""
+	if p.canExpect(tokens.KeywordAs) {
+		p.advance()
+		name, found := p.advanceExpected(tokens.Ident)
+		if !found {
+			return nil
+		}
+		as = name.Value
+	}
+
+	if !p.expect(tokens.PunctLeftCurly) {
+		return nil
+	}
+
+	entries := []ast.ProjectEntry{}
+	for p.hasTokens() && !p.head().IsOfKind(tokens.PunctRightCurly) {
+		var entry ast.ProjectEntry
+		switch {
+		case p.canExpect(tokens.TokenDotDotDot):
+			p.advance()
+			entry.Spread = true
+		case p.canExpectAnyOf(tokens.Ident, tokens.String):
+			entry.Key = p.advance().Value
+			if !p.expect(tokens.PunctColon) {
+				return nil
+			}
+		default:
+			p.errorf("expected field name or '...' in projection, got %s", p.current.Kind)
+			return nil
+		}
+
+		entry.Value = p.parseExpression(ctx, LOWEST)
+		if entry.Value == nil {
+			return nil
+		}
+		entries = append(entries, entry)
+
+		if p.head().IsOfKind(tokens.PunctComma) {
+			p.advance()
+			continue
+		}
+		if !p.head().IsOfKind(tokens.PunctRightCurly) {
+			p.errorf("expected ',' or '}' in projection, got %s", p.current.Kind)
+			return nil
+		}
+	}
+
+	rightCurly, found := p.advanceExpected(tokens.PunctRightCurly)
+	if !found {
+		return nil
+	}
+	rnge.To = rightCurly.Range.To
+
+	return ast.NewProjectExpression(source, as, entries, rnge)
+}
+
+// isProjectExpression reports whether the head starts a projection: 'project' followed by an
+// identifier that is not itself followed by one, as it is in a statement such as
+// 'derive name(...)' that follows a let named project.
+func isProjectExpression(p *Parser) bool {
+	return isContextualKeyword(p, "project") && p.peek().IsOfKind(tokens.Ident) &&
+		!p.peekPast().IsOfKind(tokens.Ident)
+}
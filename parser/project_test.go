@@ -0,0 +1,67 @@
+// SPDX-FileCopyrightText: © 2026 Binaek Sarkar <binaek89@gmail.com>
+// SPDX-License-Identifier: Apache-2.0
+
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright 2026 Binaek Sarkar
+
+package parser
+
+import (
+	"github.com/sentrie-sh/sentrie/ast"
+)
+
+func (s *ParserTestSuite) TestParseProjectExpression() {
+	p := NewParserFromString(`project user { id: user.id, "full name": upper(user.name) }`, "test.sentra")
+	expr := p.parseExpression(s.T().Context(), LOWEST)
+	s.Require().NoError(p.err)
+	s.Require().NotNil(expr)
+
+	proj, ok := expr.(*ast.ProjectExpression)
+	s.Require().True(ok)
+	s.Equal("user", proj.Source.String())
+	s.Empty(proj.As)
+	s.Require().Len(proj.Entries, 2)
+	s.Equal("id", proj.Entries[0].Key)
+	s.Equal("full name", proj.Entries[1].Key)
+	s.IsType(&ast.CallExpression{}, proj.Entries[1].Value)
+}
+
+func (s *ParserTestSuite) TestParseProjectExpressionWithAliasAndSpread() {
+	p := NewParserFromString(`project user.profile as u { ...u, name: u.name, }`, "test.sentra")
+	expr := p.parseExpression(s.T().Context(), LOWEST)
+	s.Require().NoError(p.err)
+
+	proj, ok := expr.(*ast.ProjectExpression)
+	s.Require().True(ok)
+	s.Equal("u", proj.As)
+	s.Require().Len(proj.Entries, 2)
+	s.True(proj.Entries[0].Spread)
+	s.Equal("u", proj.Entries[0].Value.String())
+	s.False(proj.Entries[1].Spread)
+	s.Equal("name", proj.Entries[1].Key)
+}
+
+func (s *ParserTestSuite) TestParseProjectExpressionEmpty() {
+	p := NewParserFromString(`project user {}`, "test.sentra")
+	expr := p.parseExpression(s.T().Context(), LOWEST)
+	s.Require().NoError(p.err)
+
+	proj, ok := expr.(*ast.ProjectExpression)
+	s.Require().True(ok)
+	s.Empty(proj.Entries)
+}
+
+func (s *ParserTestSuite) TestParseProjectExpressionErrors() {
+	for _, src := range []string{
+		`project user { id user.id }`,
+		`project user { 1: user.id }`,
+		`project user { id: user.id name: user.name }`,
+		`project user as { id: 1 }`,
+	} {
+		p := NewParserFromString(src, "test.sentra")
+		expr := p.parseExpression(s.T().Context(), LOWEST)
+		s.Nil(expr, src)
+		s.Error(p.err, src)
+	}
+}
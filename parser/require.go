@@ -0,0 +1,42 @@
+// SPDX-FileCopyrightText: © 2026 Binaek Sarkar <binaek89@gmail.com>
+// SPDX-License-Identifier: Apache-2.0
+
+package parser
+
+import (
+	"context"
+
+	"github.com/sentrie-sh/sentrie/ast"
+	"github.com/sentrie-sh/sentrie/tokens"
+)
+
+// 'require' fact 'conforms' shapeFQN
+//
+// Like 'derive', 'require' and 'conforms' are not keywords.
+func parseRequireStatement(ctx context.Context, p *Parser) ast.Statement {
+	head := p.advance() // consume 'require'
+	rnge := head.Range
+
+	fact, found := p.advanceExpected(tokens.Ident)
+	if !found {
+		return nil
+	}
+
+	if !isContextualKeyword(p, "conforms") {
+		p.errorf("expected 'conforms', got %s at %s", p.current.Kind, p.current.Range)
+		return nil
+	}
+	p.advance()
+
+	shape := parseFQN(ctx, p)
+	if shape == nil {
+		return nil
+	}
+	rnge.To = shape.Span().To
+
+	return ast.NewRequireStatement(fact.Value, shape, rnge)
+}
+
+func isRequireStatement(p *Parser) bool {
+	return isContextualKeyword(p, "require") && p.peek().IsOfKind(tokens.Ident)
+}
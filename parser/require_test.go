@@ -0,0 +1,66 @@
+// SPDX-FileCopyrightText: © 2026 Binaek Sarkar <binaek89@gmail.com>
+// SPDX-License-Identifier: Apache-2.0
+
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright 2026 Binaek Sarkar
+
+package parser
+
+import (
+	"github.com/sentrie-sh/sentrie/ast"
+)
+
+func (s *ParserTestSuite) TestParseRequireStatement() {
+	input := `namespace com/example
+policy auth {
+  fact user: document
+  require user conforms shared/User
+  rule allow = default false { yield true }
+  export decision of allow
+}`
+	program, err := NewParserFromString(input, "test.sentra").ParseProgram(s.T().Context())
+	s.Require().NoError(err)
+
+	var req *ast.RequireStatement
+	for _, stmt := range program.Statements {
+		if policy, ok := stmt.(*ast.PolicyStatement); ok {
+			for _, ps := range policy.Statements {
+				if r, ok := ps.(*ast.RequireStatement); ok {
+					req = r
+				}
+			}
+		}
+	}
+	s.Require().NotNil(req)
+	s.Equal("user", req.Fact)
+	s.Equal("shared/User", req.Shape.String())
+	s.Equal("require user conforms shared/User", req.String())
+}
+
+func (s *ParserTestSuite) TestParseRequireStatementErrors() {
+	for _, body := range []string{
+		`require user UserShape`,
+		`require conforms UserShape`,
+		`require user conforms`,
+	} {
+		input := "namespace com/example\npolicy auth {\n  fact user: document\n  " + body + "\n}"
+		_, err := NewParserFromString(input, "test.sentra").ParseProgram(s.T().Context())
+		s.Error(err, body)
+	}
+}
+
+func (s *ParserTestSuite) TestParseContextualKeywordsAsDeclarationNames() {
+	input := `namespace com/example
+policy auth {
+  fact require: document
+  fact date: date
+  let project = require.project
+  let match = date
+  rule conforms = default false { yield project == match }
+  export decision of conforms
+}`
+	program, err := NewParserFromString(input, "test.sentra").ParseProgram(s.T().Context())
+	s.Require().NoError(err)
+	s.Require().Len(program.Statements, 2)
+}
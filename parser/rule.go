@@ -23,7 +23,7 @@ import (
 	"github.com/sentrie-sh/sentrie/tokens"
 )
 
-// rule <ident> = 'default' expr 'when' expr ( { expr } | importClause )
+// rule <ident> = ( 'permit' | 'deny' )* 'default' expr 'when' expr ( { expr } | importClause )
 func parseRuleStatement(ctx context.Context, parser *Parser) ast.Statement {
 	ruleToken, found := parser.advanceExpected(tokens.KeywordRule)
 	if !found {
@@ -47,6 +47,14 @@ func parseRuleStatement(ctx context.Context, parser *Parser) ast.Statement {
 	var whenExpr ast.Expression
 	var bodyExpr ast.Expression
 
+	// 'permit' and 'deny' are not keywords, they only declare an effect when what follows
+	// cannot continue an expression; the index rejects a rule declaring conflicting effects
+	var effects []*ast.EffectClause
+	for isEffectClause(parser) {
+		effectToken := parser.advance()
+		effects = append(effects, ast.NewEffectClause(effectToken.Value, effectToken.Range))
+	}
+
 	if parser.canExpect(tokens.KeywordDefault) {
 		parser.advance() // consume 'default'
 		defaultExpr = parser.parseExpression(ctx, LOWEST)
@@ -82,5 +90,20 @@ func parseRuleStatement(ctx context.Context, parser *Parser) ast.Statement {
 		rnge.To = expression.Span().To
 	}
 
-	return ast.NewRuleStatement(name, defaultExpr, whenExpr, bodyExpr, rnge)
+	stmt := ast.NewRuleStatement(name, defaultExpr, whenExpr, bodyExpr, rnge)
+	stmt.Effects = effects
+	return stmt
+}
+
+func isEffectClause(parser *Parser) bool {
+	head := parser.head()
+	if !head.IsOfKind(tokens.Ident) || (head.Value != "permit" && head.Value != "deny") {
+		return false
+	}
+	next := parser.peek()
+	if next.IsOfKind(tokens.Ident) {
+		return next.Value == "permit" || next.Value == "deny"
+	}
+	return next.IsOfKind(tokens.KeywordDefault) || next.IsOfKind(tokens.KeywordWhen) ||
+		next.IsOfKind(tokens.PunctLeftCurly) || next.IsOfKind(tokens.KeywordImport)
 }
@@ -41,7 +41,14 @@ func parseShapeStatement(ctx context.Context, p *Parser) ast.Statement {
 
 	var simpleTypeRef ast.TypeRef
 	var complexShape *ast.Cmplx
-	if p.canExpectAnyOf(tokens.PunctLeftCurly, tokens.KeywordWith) {
+	if isClosedShapeModifier(p) {
+		closedToken := p.advance()
+		complexShape = parseComplexShape(ctx, p)
+		if complexShape != nil {
+			complexShape.Range.From = closedToken.Range.From
+			complexShape.Closed = true
+		}
+	} else if p.canExpectAnyOf(tokens.PunctLeftCurly, tokens.KeywordWith) {
 		complexShape = parseComplexShape(ctx, p)
 	} else {
 		simpleTypeRef = parseTypeRef(ctx, p)
@@ -60,6 +67,23 @@ func parseShapeStatement(ctx context.Context, p *Parser) ast.Statement {
 	return ast.NewShapeStatement(name, simpleTypeRef, complexShape, rnge)
 }
 
+// isClosedShapeModifier reports whether the head is the contextual `closed` modifier of a complex shape.
+// `closed` is not a keyword, so it only counts when a complex shape body follows it.
+func isClosedShapeModifier(p *Parser) bool {
+	return p.head().IsOfKind(tokens.Ident) && p.head().Value == "closed" &&
+		(p.peek().IsOfKind(tokens.PunctLeftCurly) || p.peek().IsOfKind(tokens.KeywordWith))
+}
+
+// isOverrideFieldModifier reports whether the head is the contextual `override` modifier of a shape field.
+// Like `closed`, it is not a keyword, so a field named `override` still parses.
+func isOverrideFieldModifier(p *Parser) bool {
+	if !p.head().IsOfKind(tokens.Ident) || p.head().Value != "override" {
+		return false
+	}
+	kind, isKeyword := tokens.IsKeyword(p.peek().Value)
+	return p.peek().IsOfKind(tokens.Ident) || (isKeyword && p.peek().IsOfKind(kind))
+}
+
 func parseComplexShape(ctx context.Context, p *Parser) *ast.Cmplx {
 	stmt := &ast.Cmplx{
 		Range:  p.head().Range,
@@ -111,7 +135,13 @@ func parseShapeField(ctx context.Context, p *Parser) *ast.ShapeField {
 		Range: p.head().Range,
 	}
 
-	name, found := p.advanceExpected(tokens.Ident)
+	if isOverrideFieldModifier(p) {
+		p.advance()
+		field.Override = true
+	}
+
+	// only fields are declared in a shape, so a field may be named after a keyword
+	name, found := p.advanceName()
 	if !found {
 		return nil
 	}
@@ -147,5 +177,15 @@ func parseShapeField(ctx context.Context, p *Parser) *ast.ShapeField {
 
 	field.Range.To = field.Type.Span().To
 
+	// a 'default' followed by ':' or '?' names the next field instead
+	if p.canExpect(tokens.KeywordDefault) && !p.peek().IsOfKind(tokens.PunctColon) && !p.peek().IsOfKind(tokens.TokenQuestion) {
+		p.advance() // consume 'default'
+		field.Default = p.parseExpression(ctx, LOWEST)
+		if field.Default == nil {
+			return nil
+		}
+		field.Range.To = field.Default.Span().To
+	}
+
 	return field
 }
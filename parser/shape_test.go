@@ -110,6 +110,79 @@ func (s *ParserTestSuite) TestParseComplexShapeWithClauseAndInvalidWith() {
 	s.Error(invalid.err)
 }
 
+func (s *ParserTestSuite) TestParseClosedComplexShape() {
+	cases := map[string]bool{
+		"shape User closed { name: string }":               true,
+		"shape User closed with app/Base { name: string }": true,
+		"shape User { name: string }":                      false,
+	}
+	for input, closed := range cases {
+		parser := NewParserFromString(input, "test.sentra")
+		stmt := parseShapeStatement(context.Background(), parser)
+		s.Require().NoError(parser.err, input)
+
+		shapeStmt, ok := stmt.(*ast.ShapeStatement)
+		s.Require().True(ok, input)
+		s.Require().NotNil(shapeStmt.Complex, input)
+		s.Equal(closed, shapeStmt.Complex.Closed, input)
+	}
+
+	// without a shape body, `closed` is just a shape name
+	parser := NewParserFromString("shape User closed", "test.sentra")
+	stmt := parseShapeStatement(context.Background(), parser)
+	s.Require().NoError(parser.err)
+	shapeStmt, ok := stmt.(*ast.ShapeStatement)
+	s.Require().True(ok)
+	s.Nil(shapeStmt.Complex)
+	s.IsType(&ast.ShapeTypeRef{}, shapeStmt.Simple)
+}
+
+func (s *ParserTestSuite) TestParseOverrideShapeField() {
+	parser := NewParserFromString("shape User with app/Base { override id: string\n override?: int\n name: string }", "test.sentra")
+	stmt := parseShapeStatement(context.Background(), parser)
+	s.Require().NoError(parser.err)
+
+	shapeStmt, ok := stmt.(*ast.ShapeStatement)
+	s.Require().True(ok)
+	s.Require().Len(shapeStmt.Complex.Fields, 3)
+	s.True(shapeStmt.Complex.Fields["id"].Override)
+
+	// without a field name following it, `override` is just a field name
+	s.False(shapeStmt.Complex.Fields["override"].Override)
+	s.True(shapeStmt.Complex.Fields["override"].Optional)
+	s.False(shapeStmt.Complex.Fields["name"].Override)
+}
+
+func (s *ParserTestSuite) TestParseShapeFieldNamedAfterKeyword() {
+	parser := NewParserFromString("shape Doc with app/Base { version: number\n default?: string\n override list: string }", "test.sentra")
+	stmt := parseShapeStatement(context.Background(), parser)
+	s.Require().NoError(parser.err)
+
+	shapeStmt, ok := stmt.(*ast.ShapeStatement)
+	s.Require().True(ok)
+	s.Require().Len(shapeStmt.Complex.Fields, 3)
+	s.Contains(shapeStmt.Complex.Fields, "version")
+	s.True(shapeStmt.Complex.Fields["default"].Optional)
+	s.True(shapeStmt.Complex.Fields["list"].Override)
+}
+
+func (s *ParserTestSuite) TestParseShapeFieldDefault() {
+	parser := NewParserFromString("shape User { name: string\n role?: string default \"guest\"\n level?: number @min(1) default 1 + 1 }", "test.sentra")
+	stmt := parseShapeStatement(context.Background(), parser)
+	s.Require().NoError(parser.err)
+
+	shapeStmt, ok := stmt.(*ast.ShapeStatement)
+	s.Require().True(ok)
+	s.Nil(shapeStmt.Complex.Fields["name"].Default)
+	s.IsType(&ast.StringLiteral{}, shapeStmt.Complex.Fields["role"].Default)
+	s.IsType(&ast.InfixExpression{}, shapeStmt.Complex.Fields["level"].Default)
+	s.Equal(shapeStmt.Complex.Fields["level"].Default.Span().To, shapeStmt.Complex.Fields["level"].Range.To)
+
+	parser = NewParserFromString("shape User { role?: string default }", "test.sentra")
+	s.Nil(parseShapeStatement(context.Background(), parser))
+	s.Error(parser.err)
+}
+
 func (s *ParserTestSuite) TestParseTypeRefRejectsInvalidStartToken() {
 	parser := NewParserFromString("shape Person { name: ? }", "test.sentra")
 	stmt := parseShapeStatement(context.Background(), parser)
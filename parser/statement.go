@@ -36,6 +36,10 @@ func parseStatement(ctx context.Context, p *Parser) ast.Statement {
 	if handler, ok := p.statementHandlers[p.head().Kind]; ok {
 		return handler(ctx, p)
 	}
+	if p.head().IsOfKind(tokens.Error) {
+		p.advance() // reports the lexer error
+		return nil
+	}
 	p.errorf("unexpected token '%s'", p.head().Kind)
 	return nil
 }
@@ -126,6 +126,41 @@ func (s *ParserTestSuite) TestParseRuleStatement() {
 	}
 }
 
+// TestParseRuleStatementEffects tests parsing the effect clauses of rule statements
+func (s *ParserTestSuite) TestParseRuleStatementEffects() {
+	testCases := []struct {
+		input    string
+		effects  []string
+		hasWhen  bool
+		bodyKind string
+	}{
+		{"rule block = deny when user.blocked { yield true }", []string{"deny"}, true, "block"},
+		{"rule allow = permit default false { yield true }", []string{"permit"}, false, "block"},
+		{"rule allow = permit { yield true }", []string{"permit"}, false, "block"},
+		{"rule both = permit deny { yield true }", []string{"permit", "deny"}, false, "block"},
+		{"rule check = deny import decision rulename from com/example", []string{"deny"}, false, "import"},
+		// without a following clause, 'deny' is the name of another rule
+		{"rule negated = deny", nil, false, "identifier"},
+		{"rule negated = permit and deny", nil, false, "infix"},
+	}
+
+	for _, tc := range testCases {
+		parser := NewParserFromString(tc.input, "test.sentra")
+		stmt := parseRuleStatement(s.T().Context(), parser)
+		s.Require().NoError(parser.err, "Expected no error for: %s", tc.input)
+
+		ruleStmt, ok := stmt.(*ast.RuleStatement)
+		s.Require().True(ok, "Expected RuleStatement for: %s", tc.input)
+		var effects []string
+		for _, clause := range ruleStmt.Effects {
+			effects = append(effects, clause.Effect)
+		}
+		s.Equal(tc.effects, effects, tc.input)
+		s.Equal(tc.hasWhen, ruleStmt.When != nil, tc.input)
+		s.Equal(tc.bodyKind, ruleStmt.Body.Kind(), tc.input)
+	}
+}
+
 // TestParseRuleStatementInvalid tests parsing invalid rule statements
 func (s *ParserTestSuite) TestParseRuleStatementInvalid() {
 	testCases := []string{
@@ -279,12 +314,39 @@ func (s *ParserTestSuite) TestParseUseStatement() {
 	}
 }
 
+func (s *ParserTestSuite) TestParseShapeUseStatement() {
+	parser := NewParserFromString("use UserShape from com/example/shared as U", "test.sentra")
+	stmt := parseUseStatement(s.T().Context(), parser)
+	s.Require().NoError(parser.err)
+
+	useStmt, ok := stmt.(*ast.UseStatement)
+	s.Require().True(ok)
+	s.True(useStmt.IsShapeImport())
+	s.Equal([]string{"UserShape"}, useStmt.Modules)
+	s.Equal("com/example/shared", useStmt.ShapeFrom.String())
+	s.Equal("U", useStmt.As)
+	s.Equal("use UserShape from com/example/shared as U", useStmt.String())
+
+	// the alias defaults to the name of the shape
+	parser = NewParserFromString("use UserShape from com/example/shared", "test.sentra")
+	stmt = parseUseStatement(s.T().Context(), parser)
+	s.Require().NoError(parser.err)
+	s.Equal("UserShape", stmt.(*ast.UseStatement).As)
+
+	// module imports are not shape imports
+	parser = NewParserFromString("use {fn1} from @lib/name as alias", "test.sentra")
+	stmt = parseUseStatement(s.T().Context(), parser)
+	s.Require().NoError(parser.err)
+	s.False(stmt.(*ast.UseStatement).IsShapeImport())
+}
+
 // TestParseUseStatementInvalid tests parsing invalid use statements
 func (s *ParserTestSuite) TestParseUseStatementInvalid() {
 	testCases := []string{
-		// "use", // Missing everything
-		// "use fn1 from", // Missing module
-		// "use fn1 from @lib/name as", // Missing alias
+		"use",                          // Missing everything
+		"use fn1 from",                 // Missing module
+		"use fn1 from @lib/name as",    // A shape is imported from a namespace, not a module
+		"use UserShape from com/ex as", // Missing alias
 	}
 
 	for _, tc := range testCases {
@@ -335,6 +397,48 @@ func (s *ParserTestSuite) TestParseRuleExportStatementInvalid() {
 	}
 }
 
+func (s *ParserTestSuite) TestParseRuleExportStatementDirectives() {
+	parser := NewParserFromString(`export decision of allow
+  attach reason as "admins only"
+  obligation "log": LogEntry = { "action": "access" }
+  advice "hint" = "prefer read-only access"`, "test.sentra")
+	stmt := parseRuleExportStatement(s.T().Context(), parser)
+	s.Require().NoError(parser.err)
+
+	exportStmt, ok := stmt.(*ast.RuleExportStatement)
+	s.Require().True(ok)
+	s.Require().Len(exportStmt.Attachments, 3)
+
+	s.Empty(exportStmt.Attachments[0].Directive)
+	s.Equal("reason", exportStmt.Attachments[0].What)
+
+	obligation := exportStmt.Attachments[1]
+	s.Equal(ast.DirectiveObligation, obligation.Directive)
+	s.Equal("log", obligation.What)
+	s.IsType(&ast.ShapeTypeRef{}, obligation.Type)
+
+	advice := exportStmt.Attachments[2]
+	s.Equal(ast.DirectiveAdvice, advice.Directive)
+	s.Equal("hint", advice.What)
+	s.Nil(advice.Type)
+	s.Equal(`advice "hint" = "prefer read-only access"`, advice.String())
+}
+
+func (s *ParserTestSuite) TestParseRuleExportStatementDirectivesInvalid() {
+	testCases := []string{
+		`export decision of allow obligation "log"`,      // Missing value
+		`export decision of allow obligation "log": = 1`, // Missing type
+		`export decision of allow advice "hint" as 1`,    // Attachment syntax
+	}
+
+	for _, tc := range testCases {
+		parser := NewParserFromString(tc, "test.sentra")
+		stmt := parseRuleExportStatement(s.T().Context(), parser)
+		s.Error(parser.err, "Expected error for: %s", tc)
+		s.Nil(stmt, "Expected nil statement for: %s", tc)
+	}
+}
+
 // TestParseShapeExportStatement tests parsing shape export statements
 func (s *ParserTestSuite) TestParseShapeExportStatement() {
 	testCases := []struct {
@@ -487,6 +591,88 @@ policy p {
 	s.Equal(1, facts)
 }
 
+func (s *ParserTestSuite) TestParseCombiningStatement() {
+	src := `namespace com/example
+
+policy p {
+  combining "deny-overrides"
+  rule combining = { yield true }
+  export decision of combining
+}`
+	parser := NewParserFromString(src, "test.sentra")
+	prg, err := parser.ParseProgram(context.Background())
+	s.Require().NoError(err)
+	var pol *ast.PolicyStatement
+	for _, st := range prg.Statements {
+		if p, ok := st.(*ast.PolicyStatement); ok {
+			pol = p
+			break
+		}
+	}
+	s.Require().NotNil(pol)
+	combining, ok := pol.Statements[0].(*ast.CombiningStatement)
+	s.Require().True(ok)
+	s.Equal("deny-overrides", combining.Algorithm)
+	s.Equal(`combining deny-overrides`, combining.String())
+}
+
+func (s *ParserTestSuite) TestParseCombiningStatementInvalid() {
+	parser := NewParserFromString(`policy p { combining deny }`, "test.sentra")
+	_, err := parser.ParseProgram(context.Background())
+	s.Error(err)
+}
+
+func (s *ParserTestSuite) TestParseDeriveStatement() {
+	src := `namespace com/example
+
+policy p {
+  derive privileged(role, level) = role == "admin" or level > 3
+  derive always() = { yield true }
+  let derive = 1
+  rule allow = { yield privileged("user", derive) }
+  export decision of allow
+}`
+	parser := NewParserFromString(src, "test.sentra")
+	prg, err := parser.ParseProgram(context.Background())
+	s.Require().NoError(err)
+	var pol *ast.PolicyStatement
+	for _, st := range prg.Statements {
+		if p, ok := st.(*ast.PolicyStatement); ok {
+			pol = p
+			break
+		}
+	}
+	s.Require().NotNil(pol)
+
+	privileged, ok := pol.Statements[0].(*ast.DeriveStatement)
+	s.Require().True(ok)
+	s.Equal("privileged", privileged.Name)
+	s.Equal([]string{"role", "level"}, privileged.Params)
+	s.Equal(`derive privileged(role, level) = ((role == "admin") or (level > 3))`, privileged.String())
+
+	always, ok := pol.Statements[1].(*ast.DeriveStatement)
+	s.Require().True(ok)
+	s.Empty(always.Params)
+
+	let, ok := pol.Statements[2].(*ast.VarDeclaration)
+	s.Require().True(ok, "'derive' stays usable as a name")
+	s.Equal("derive", let.Name)
+}
+
+func (s *ParserTestSuite) TestParseDeriveStatementInvalid() {
+	for _, src := range []string{
+		`policy p { derive f = 1 }`,
+		`policy p { derive f(a a) = 1 }`,
+		`policy p { derive f(a, a) = 1 }`,
+		`policy p { derive f(a) }`,
+		`policy p { derive f(1) = 1 }`,
+	} {
+		parser := NewParserFromString(src, "test.sentra")
+		_, err := parser.ParseProgram(context.Background())
+		s.Error(err, src)
+	}
+}
+
 func (s *ParserTestSuite) TestParseTagStatementInvalid() {
 	parser := NewParserFromString(`policy p { tag "a" "b" }`, "test.sentra")
 	_, err := parser.ParseProgram(context.Background())
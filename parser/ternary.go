@@ -31,16 +31,18 @@ func parseTernaryExpression(ctx context.Context, p *Parser, condition ast.Expres
 		return nil
 	}
 
+	// 'x ?: y' coalesces an unknown x to y. It used to be the Elvis 'x ? x : y', which also took
+	// y for a false x
+	if p.canExpect(tokens.PunctColon) {
+		return parseCoalesceExpression(ctx, p, condition, precedence)
+	}
+
 	// Parse the true branch
-	// we default ot the condition expression itself
-	trueBranch := condition
-	if !p.canExpect(tokens.PunctColon) {
-		trueBranch = p.parseExpression(ctx, precedence)
-		if trueBranch == nil {
-			return nil
-		}
-		rnge.To = trueBranch.Span().To
+	trueBranch := p.parseExpression(ctx, precedence)
+	if trueBranch == nil {
+		return nil
 	}
+	rnge.To = trueBranch.Span().To
 
 	// Parse the ':' token
 	if !p.expect(tokens.PunctColon) {
@@ -56,3 +58,17 @@ func parseTernaryExpression(ctx context.Context, p *Parser, condition ast.Expres
 
 	return ast.NewTernaryExpression(condition, trueBranch, falseBranch, rnge)
 }
+
+// parseCoalesceExpression parses the ': @expr' of 'x ?: @expr', after its '?'.
+func parseCoalesceExpression(ctx context.Context, p *Parser, left ast.Expression, precedence Precedence) ast.Expression {
+	p.advance() // consume ':'
+
+	fallback := p.parseExpression(ctx, precedence)
+	if fallback == nil {
+		return nil
+	}
+
+	rnge := left.Span()
+	rnge.To = fallback.Span().To
+	return ast.NewInfixExpression(left, fallback, "?:", rnge)
+}
@@ -0,0 +1,148 @@
+// SPDX-FileCopyrightText: © 2026 Binaek Sarkar <binaek89@gmail.com>
+// SPDX-License-Identifier: Apache-2.0
+
+package parser
+
+import (
+	"context"
+
+	"github.com/sentrie-sh/sentrie/ast"
+	"github.com/sentrie-sh/sentrie/tokens"
+)
+
+// 'test' @string 'of' @ident '{' ( <test_fact> | <test_expectation> )* '}'
+// <test_fact>        = 'fact' @ident '=' <expression>
+// <test_expectation> = 'expect' @ident ( 'is' <trinary> | 'attach' @ident 'as' <expression> )
+// `test` and `expect` are not keywords, so identifiers with those names still parse elsewhere.
+func parseTestStatement(ctx context.Context, p *Parser) ast.Statement {
+	if !isTestStatement(p) {
+		p.errorf("unexpected token '%s'", p.head().Kind)
+		return nil
+	}
+
+	start := p.advance() // consume 'test'
+	rnge := start.Range
+
+	nameToken, found := p.advanceExpected(tokens.String)
+	if !found {
+		return nil
+	}
+
+	if !p.expect(tokens.KeywordOf) {
+		return nil
+	}
+
+	policyIdent, found := p.advanceExpected(tokens.Ident)
+	if !found {
+		return nil
+	}
+
+	if !p.expect(tokens.PunctLeftCurly) {
+		return nil
+	}
+
+	var facts []*ast.TestFact
+	var expectations []*ast.TestExpectation
+
+	for p.hasTokens() && !p.head().IsOfKind(tokens.PunctRightCurly) {
+		switch {
+		case p.head().IsOfKind(tokens.TrailingComment), p.head().IsOfKind(tokens.LineComment):
+			p.advance()
+		case p.head().IsOfKind(tokens.KeywordFact):
+			fact := parseTestFact(ctx, p)
+			if fact == nil {
+				return nil
+			}
+			facts = append(facts, fact)
+		case p.head().IsOfKind(tokens.Ident) && p.head().Value == "expect":
+			expectation := parseTestExpectation(ctx, p)
+			if expectation == nil {
+				return nil
+			}
+			expectations = append(expectations, expectation)
+		case p.head().IsOfKind(tokens.Error):
+			p.advance() // reports the lexer error
+			return nil
+		default:
+			p.errorf("expected 'fact' or 'expect' in test, got '%s'", p.head().Kind)
+			return nil
+		}
+	}
+
+	rCurly, found := p.advanceExpected(tokens.PunctRightCurly)
+	if !found {
+		return nil
+	}
+	rnge.To = rCurly.Range.To
+
+	return ast.NewTestStatement(nameToken.Value, policyIdent.Value, facts, expectations, rnge)
+}
+
+// 'fact' @ident '=' <expression>
+func parseTestFact(ctx context.Context, p *Parser) *ast.TestFact {
+	start := p.advance() // consume 'fact'
+
+	nameIdent, found := p.advanceExpected(tokens.Ident)
+	if !found {
+		return nil
+	}
+
+	if !p.expect(tokens.TokenAssign) {
+		return nil
+	}
+
+	value := p.parseExpression(ctx, LOWEST)
+	if value == nil {
+		return nil
+	}
+
+	return &ast.TestFact{
+		Range: tokens.Range{File: start.Range.File, From: start.Range.From, To: value.Span().To},
+		Name:  nameIdent.Value,
+		Value: value,
+	}
+}
+
+// 'expect' @ident ( 'is' <trinary> | 'attach' @ident 'as' <expression> )
+func parseTestExpectation(ctx context.Context, p *Parser) *ast.TestExpectation {
+	start := p.advance() // consume 'expect'
+
+	ruleIdent, found := p.advanceExpected(tokens.Ident)
+	if !found {
+		return nil
+	}
+
+	expectation := &ast.TestExpectation{
+		Range: start.Range,
+		Rule:  ruleIdent.Value,
+	}
+
+	switch {
+	case p.canExpect(tokens.KeywordIs):
+		p.advance() // consume 'is'
+		if !p.canExpectAnyOf(tokens.KeywordTrue, tokens.KeywordFalse, tokens.KeywordUnknown) {
+			p.errorf("expected 'true', 'false' or 'unknown' after 'expect %s is', got '%s'", ruleIdent.Value, p.head().Kind)
+			return nil
+		}
+		outcome := parseTrinaryLiteral(ctx, p).(*ast.TrinaryLiteral)
+		expectation.Outcome = outcome
+		expectation.Range.To = outcome.Span().To
+	case p.canExpect(tokens.KeywordAttach):
+		attachment := parseAttachmentClause(ctx, p)
+		if attachment == nil {
+			return nil
+		}
+		expectation.Attachment = attachment
+		expectation.Range.To = attachment.Span().To
+	default:
+		p.errorf("expected 'is' or 'attach' after 'expect %s', got '%s'", ruleIdent.Value, p.head().Kind)
+		return nil
+	}
+
+	return expectation
+}
+
+// isTestStatement reports whether the head is the contextual `test` keyword opening a test.
+func isTestStatement(p *Parser) bool {
+	return p.head().IsOfKind(tokens.Ident) && p.head().Value == "test" && p.peek().IsOfKind(tokens.String)
+}
@@ -0,0 +1,75 @@
+// SPDX-FileCopyrightText: © 2026 Binaek Sarkar <binaek89@gmail.com>
+// SPDX-License-Identifier: Apache-2.0
+
+package parser
+
+import (
+	"context"
+
+	"github.com/sentrie-sh/sentrie/ast"
+	"github.com/sentrie-sh/sentrie/trinary"
+)
+
+func (s *ParserTestSuite) TestParseTestStatement() {
+	parser := NewParserFromString(`test "admins are allowed" of auth {
+  -- the role under test
+  fact role = "admin"
+  fact tags = ["a", "b"]
+  expect allow is true
+  expect audit is unknown
+  expect allow attach reason as "role is admin"
+}`, "auth.test.sentrie")
+	stmt := parseStatement(context.Background(), parser)
+	s.Require().NoError(parser.err)
+
+	test, ok := stmt.(*ast.TestStatement)
+	s.Require().True(ok)
+	s.Equal("admins are allowed", test.Name)
+	s.Equal("auth", test.Policy)
+
+	s.Require().Len(test.Facts, 2)
+	s.Equal("role", test.Facts[0].Name)
+	s.IsType(&ast.StringLiteral{}, test.Facts[0].Value)
+	s.Equal("tags", test.Facts[1].Name)
+	s.IsType(&ast.ListLiteral{}, test.Facts[1].Value)
+
+	s.Require().Len(test.Expectations, 3)
+	s.Equal("allow", test.Expectations[0].Rule)
+	s.Equal(trinary.True, test.Expectations[0].Outcome.Value)
+	s.Nil(test.Expectations[0].Attachment)
+	s.Equal(trinary.Unknown, test.Expectations[1].Outcome.Value)
+	s.Nil(test.Expectations[2].Outcome)
+	s.Require().NotNil(test.Expectations[2].Attachment)
+	s.Equal("reason", test.Expectations[2].Attachment.What)
+	s.Equal(3, test.Expectations[2].Range.From.Column)
+}
+
+func (s *ParserTestSuite) TestParseTestStatementErrors() {
+	tests := map[string]string{
+		`test "t" of auth { expect allow is 1 }`:        "expected 'true', 'false' or 'unknown'",
+		`test "t" of auth { expect allow equals true }`: "expected 'is' or 'attach'",
+		`test "t" of auth { let x = 1 }`:                "expected 'fact' or 'expect' in test",
+		`test "t" { expect allow is true }`:             "expected 'of'",
+		`tests "t" of auth {}`:                          "unexpected token",
+	}
+	for src, msg := range tests {
+		parser := NewParserFromString(src, "auth.test.sentrie")
+		stmt := parseStatement(context.Background(), parser)
+		s.Nil(stmt, src)
+		s.Require().Error(parser.err, src)
+		s.ErrorContains(parser.err, msg, src)
+	}
+}
+
+func (s *ParserTestSuite) TestParseTestIsContextual() {
+	// `test` and `expect` are not keywords, so they still name facts and rules
+	parser := NewParserFromString(`namespace com/example
+policy auth {
+  fact test: string
+  rule expect = default false { yield test == "x" }
+  export decision of expect
+}`, "auth.sentrie")
+	program, err := parser.ParseProgram(context.Background())
+	s.Require().NoError(err)
+	s.Require().Len(program.Statements, 2)
+}
@@ -43,6 +43,11 @@ func parseTypeRef(ctx context.Context, p *Parser) ast.TypeRef {
 	case tokens.KeywordBoolean, tokens.KeywordTrinary:
 		ref = ast.NewTrinaryTypeRef(p.advance().Range)
 	case tokens.Ident:
+		// 'date' is not a keyword; it names the date type unless it starts a qualified name
+		if p.current.Value == "date" && !p.peek().IsOfKind(tokens.TokenDiv) {
+			ref = ast.NewDateTypeRef(p.advance().Range)
+			break
+		}
 		fqn := parseFQN(ctx, p)
 		if fqn == nil {
 			return nil
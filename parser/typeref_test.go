@@ -5,6 +5,7 @@ package parser
 
 import (
 	"context"
+	"regexp"
 
 	"github.com/sentrie-sh/sentrie/ast"
 )
@@ -40,6 +41,105 @@ func (s *ParserTestSuite) TestParseTypeRefConstraintAdditionalErrorBranches() {
 	s.Contains(nonLiteralArg.err.Error(), "constraint arguments must be literals")
 }
 
+func (s *ParserTestSuite) TestParseTypeRefOneOfArgumentKinds() {
+	valid := []string{
+		`string @one_of("a", "b")`,
+		`number @one_of(1, 2.5, 3)`,
+		`boolean @one_of(true, false)`,
+		`number? @one_of(1, 2)`,
+	}
+	for _, input := range valid {
+		p := NewParserFromString(input, "test.sentra")
+		ref := parseTypeRef(context.Background(), p)
+		s.Require().NoError(p.err, input)
+		s.Require().NotNil(ref, input)
+		s.Len(ref.GetConstraints(), 1, input)
+	}
+
+	invalid := []string{
+		`number @one_of(1, "two", 3)`,
+		`boolean @one_of(true, 1)`,
+		`string @not_one_of("a", 1)`,
+	}
+	for _, input := range invalid {
+		p := NewParserFromString(input, "test.sentra")
+		ref := parseTypeRef(context.Background(), p)
+		s.Nil(ref, input)
+		s.Require().Error(p.err, input)
+		s.Contains(p.err.Error(), "expects", input)
+	}
+}
+
+func (s *ParserTestSuite) TestParseTypeRefRegexpFlags() {
+	valid := []string{
+		`string @regexp("^foo$")`,
+		`string @regexp("^foo$", "i")`,
+		`string @regexp("^foo$", "ims")`,
+	}
+	for _, input := range valid {
+		p := NewParserFromString(input, "test.sentra")
+		ref := parseTypeRef(context.Background(), p)
+		s.Require().NoError(p.err, input)
+		s.Require().NotNil(ref, input)
+	}
+
+	invalid := map[string]string{
+		`string @regexp("^foo$", "q")`:      "unknown regexp flag",
+		`string @regexp("^foo$", 1)`:        "flags as a string literal",
+		`string @regexp("^foo$", "i", "m")`: "optional flags",
+	}
+	for input, msg := range invalid {
+		p := NewParserFromString(input, "test.sentra")
+		ref := parseTypeRef(context.Background(), p)
+		s.Nil(ref, input)
+		s.Require().Error(p.err, input)
+		s.Contains(p.err.Error(), msg, input)
+	}
+}
+
+func (s *ParserTestSuite) TestParseTypeRefRegexpRawString() {
+	p := NewParserFromString(`string @regexp("""^\d+$""")`, "test.sentra")
+	ref := parseTypeRef(context.Background(), p)
+	s.Require().NoError(p.err)
+	s.Require().Len(ref.GetConstraints(), 1)
+
+	pattern, ok := ref.GetConstraints()[0].Args[0].(*ast.StringLiteral)
+	s.Require().True(ok)
+	s.Equal(`^\d+$`, pattern.Value)
+	s.True(regexp.MustCompile(pattern.Value).MatchString("123"))
+
+	// the escaped form of the same pattern yields the same value
+	p = NewParserFromString(`string @regexp("^\\d+$")`, "test.sentra")
+	ref = parseTypeRef(context.Background(), p)
+	s.Require().NoError(p.err)
+	s.Equal(pattern.Value, ref.GetConstraints()[0].Args[0].(*ast.StringLiteral).Value)
+}
+
+func (s *ParserTestSuite) TestParseRawStringDisablesInterpolation() {
+	p := NewParserFromString(`"""hello ${name}"""`, "test.sentra")
+	expr := p.parseExpression(s.T().Context(), LOWEST)
+	s.Require().NoError(p.err)
+
+	str, ok := expr.(*ast.StringLiteral)
+	s.Require().True(ok)
+	s.Equal("hello ${name}", str.Value)
+}
+
+func (s *ParserTestSuite) TestParseTypeRefListElementConstraints() {
+	p := NewParserFromString(`list[string @email()] @minlength(1) @unique()`, "test.sentra")
+	ref := parseTypeRef(context.Background(), p)
+	s.Require().NoError(p.err)
+
+	list, ok := ref.(*ast.ListTypeRef)
+	s.Require().True(ok)
+	s.Len(list.GetConstraints(), 2)
+
+	elem, ok := list.ElemType.(*ast.StringTypeRef)
+	s.Require().True(ok)
+	s.Require().Len(elem.GetConstraints(), 1)
+	s.Equal("email", elem.GetConstraints()[0].Name)
+}
+
 func (s *ParserTestSuite) TestParseTypeRefDirectKindCoverage() {
 	cases := []struct {
 		input    string
@@ -59,6 +159,14 @@ func (s *ParserTestSuite) TestParseTypeRefDirectKindCoverage() {
 				s.True(ok)
 			},
 		},
+		{
+			input: `date @after("2020-01-01") @before("2030-01-01")`,
+			assertFn: func(ref ast.TypeRef) {
+				_, ok := ref.(*ast.DateTypeRef)
+				s.True(ok)
+				s.Len(ref.GetConstraints(), 2)
+			},
+		},
 		{
 			input: "document",
 			assertFn: func(ref ast.TypeRef) {
@@ -25,6 +25,7 @@ import (
 )
 
 // 'use' '{' func (',' func)* '}' 'from' moduleName 'as' alias
+// 'use' shape 'from' namespaceFQN ( 'as' alias )?
 func parseUseStatement(ctx context.Context, p *Parser) ast.Statement {
 	head, found := p.advanceExpected(tokens.KeywordUse)
 	if !found {
@@ -32,13 +33,17 @@ func parseUseStatement(ctx context.Context, p *Parser) ast.Statement {
 	}
 	rnge := head.Range
 
+	if p.canExpect(tokens.Ident) {
+		return parseShapeUse(ctx, p, rnge)
+	}
+
 	fns := []string{}
 
 	if !p.expect(tokens.PunctLeftCurly) {
 		return nil
 	}
 
-	firstModuleName, found := p.advanceExpected(tokens.Ident)
+	firstModuleName, found := p.advanceName()
 	if !found {
 		return nil
 	}
@@ -48,7 +53,7 @@ func parseUseStatement(ctx context.Context, p *Parser) ast.Statement {
 		if !p.expect(tokens.PunctComma) {
 			return nil
 		}
-		fn, found := p.advanceExpected(tokens.Ident)
+		fn, found := p.advanceName()
 		if !found {
 			return nil
 		}
@@ -127,3 +132,36 @@ func parseUseStatement(ctx context.Context, p *Parser) ast.Statement {
 
 	return ast.NewUseStatement(modules, relativeFrom, libFrom, alias, rnge)
 }
+
+// parseShapeUse parses the rest of the import of a shape by name, after 'use'. The alias
+// defaults to the name of the shape.
+func parseShapeUse(ctx context.Context, p *Parser, rnge tokens.Range) ast.Statement {
+	shape, found := p.advanceExpected(tokens.Ident)
+	if !found {
+		return nil
+	}
+
+	if !p.expect(tokens.KeywordFrom) {
+		return nil
+	}
+
+	from := parseFQN(ctx, p)
+	if from == nil {
+		return nil
+	}
+	rnge.To = from.Span().To
+
+	alias := shape.Value
+	if p.canExpect(tokens.KeywordAs) {
+		p.advance() // consume 'as'
+
+		asAlias, found := p.advanceExpected(tokens.Ident)
+		if !found {
+			return nil
+		}
+		alias = asAlias.Value
+		rnge.To = asAlias.Range.To
+	}
+
+	return ast.NewShapeUseStatement(shape.Value, from, alias, rnge)
+}
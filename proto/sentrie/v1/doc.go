@@ -0,0 +1,7 @@
+// SPDX-FileCopyrightText: © 2026 Binaek Sarkar <binaek89@gmail.com>
+// SPDX-License-Identifier: Apache-2.0
+
+// Package sentriev1 holds the gRPC evaluation service, generated from evaluation.proto.
+package sentriev1
+
+//go:generate protoc -I ../.. --go_out=../.. --go_opt=paths=source_relative --go-grpc_out=../.. --go-grpc_opt=paths=source_relative sentrie/v1/evaluation.proto
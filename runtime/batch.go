@@ -0,0 +1,249 @@
+// SPDX-FileCopyrightText: © 2026 Binaek Sarkar <binaek89@gmail.com>
+// SPDX-License-Identifier: Apache-2.0
+
+package runtime
+
+import (
+	"context"
+	"errors"
+	goruntime "runtime"
+	"sync"
+	"time"
+
+	"github.com/sentrie-sh/sentrie/trinary"
+)
+
+// ErrBatchTimeout is recorded on the items of a batch that had not finished when its budget ran out.
+var ErrBatchTimeout = errors.New("batch budget exhausted")
+
+// BatchItem is the evaluation of one input of a batch.
+type BatchItem struct {
+	Index    int               `json:"index"`
+	Outcome  trinary.Value     `json:"outcome"`
+	Outputs  []*ExecutorOutput `json:"outputs,omitempty"`
+	TimedOut bool              `json:"timed_out,omitempty"`
+	// Invalid is set when the facts of the input were not valid, see WithBatchFactValidation;
+	// such an input is not evaluated
+	Invalid bool  `json:"invalid,omitempty"`
+	Err     error `json:"-"`
+	// Error is the message of Err
+	Error string `json:"error,omitempty"`
+	// Combined is the combined decision of the policy, when it declares a combining algorithm
+	// and the whole policy was evaluated, see CombineDecisions
+	Combined *Decision `json:"-"`
+	// Duration is how long the evaluation of the input took
+	Duration time.Duration `json:"-"`
+}
+
+// BatchSummary counts the inputs of a batch by outcome. An input passes when every decision it
+// produced is true, fails when any is false, and is unknown otherwise; a policy declaring a
+// combining algorithm passes or fails on its combined decision instead.
+type BatchSummary struct {
+	Total           int   `json:"total"`
+	Passed          int   `json:"passed"`
+	Failed          int   `json:"failed"`
+	Unknown         int   `json:"unknown"`
+	Errored         int   `json:"errored"`
+	Invalid         int   `json:"invalid"`
+	TimedOut        int   `json:"timed_out"`
+	FailedIndices   []int `json:"failed_indices"`
+	ErroredIndices  []int `json:"errored_indices"`
+	InvalidIndices  []int `json:"invalid_indices"`
+	TimedOutIndices []int `json:"timed_out_indices"`
+}
+
+// BatchResult holds the per-input results of a batch, in input order, and their summary.
+type BatchResult struct {
+	Items   []*BatchItem  `json:"items"`
+	Summary *BatchSummary `json:"summary"`
+}
+
+// BatchOption configures a call to EvaluateBatch.
+type BatchOption func(*batchConfig)
+
+type batchConfig struct {
+	budget        time.Duration
+	validateFacts bool
+}
+
+// WithBatchBudget bounds the total time a batch may take. Items still running or not yet started
+// when the budget runs out are recorded as timed out; a budget of zero or less leaves the batch
+// bounded only by the deadline of its context.
+func WithBatchBudget(budget time.Duration) BatchOption {
+	return func(c *batchConfig) {
+		c.budget = budget
+	}
+}
+
+// WithBatchFactValidation validates the facts of every input against the fact declarations of the
+// policy before evaluating it, see Executor.ValidateFacts. An input with invalid facts is not
+// evaluated; it is recorded as invalid, with every violation, and the rest of the batch carries on.
+func WithBatchFactValidation() BatchOption {
+	return func(c *batchConfig) {
+		c.validateFacts = true
+	}
+}
+
+// EvaluateBatch evaluates the policy or rule named by fqn against every input, running at most
+// concurrency evaluations at once; a concurrency below 1 uses the number of CPUs. Evaluation
+// errors are recorded on the failing item and do not stop the batch. An error is returned only
+// when fqn does not resolve.
+//
+// Every item shares ctx. Once it is done, whether through its own deadline or the budget given
+// with WithBatchBudget, EvaluateBatch returns without waiting for the items still running; those
+// and the items not yet started carry ErrBatchTimeout, and items that had finished keep their
+// results.
+func EvaluateBatch(ctx context.Context, exec Executor, fqn string, inputs []map[string]any, concurrency int, opts ...BatchOption) (*BatchResult, error) {
+	namespace, policy, rule, err := exec.Index().ResolveSegments(fqn)
+	if err != nil {
+		return nil, err
+	}
+
+	cfg := &batchConfig{}
+	for _, opt := range opts {
+		opt(cfg)
+	}
+	if cfg.budget > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, cfg.budget)
+		defer cancel()
+	}
+
+	if concurrency < 1 {
+		concurrency = goruntime.NumCPU()
+	}
+
+	items := make([]*BatchItem, len(inputs))
+	sem := make(chan struct{}, concurrency)
+	var wg sync.WaitGroup
+dispatch:
+	for i, facts := range inputs {
+		if ctx.Err() != nil {
+			break
+		}
+		select {
+		case sem <- struct{}{}:
+		case <-ctx.Done():
+			break dispatch
+		}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			items[i] = awaitBatchItem(ctx, sem, exec, cfg, i, namespace, policy, rule, facts)
+		}()
+	}
+	wg.Wait()
+
+	for i, item := range items {
+		if item == nil {
+			items[i] = timedOutBatchItem(i)
+		}
+		if items[i].Err != nil {
+			items[i].Error = items[i].Err.Error()
+		}
+	}
+
+	summary := &BatchSummary{Total: len(items), FailedIndices: []int{}, ErroredIndices: []int{}, InvalidIndices: []int{}, TimedOutIndices: []int{}}
+	for _, item := range items {
+		switch {
+		case item.TimedOut:
+			summary.TimedOut++
+			summary.TimedOutIndices = append(summary.TimedOutIndices, item.Index)
+		case item.Invalid:
+			summary.Invalid++
+			summary.InvalidIndices = append(summary.InvalidIndices, item.Index)
+		case item.Err != nil:
+			summary.Errored++
+			summary.ErroredIndices = append(summary.ErroredIndices, item.Index)
+		case item.Outcome == trinary.True:
+			summary.Passed++
+		case item.Outcome == trinary.False:
+			summary.Failed++
+			summary.FailedIndices = append(summary.FailedIndices, item.Index)
+		default:
+			summary.Unknown++
+		}
+	}
+
+	return &BatchResult{Items: items, Summary: summary}, nil
+}
+
+// awaitBatchItem evaluates one item and releases its slot of sem once the evaluation returns. It
+// gives up waiting when ctx is done, so an evaluation that does not observe ctx cannot hold up the
+// batch; the abandoned evaluation keeps its slot until it returns.
+func awaitBatchItem(ctx context.Context, sem <-chan struct{}, exec Executor, cfg *batchConfig, i int, namespace, policy, rule string, facts map[string]any) *BatchItem {
+	done := make(chan *BatchItem, 1)
+	go func() {
+		defer func() { <-sem }()
+		if cfg.validateFacts {
+			if err := exec.ValidateFacts(ctx, namespace, policy, facts); err != nil {
+				done <- &BatchItem{Index: i, Outcome: trinary.Unknown, Invalid: true, Err: err}
+				return
+			}
+		}
+		done <- evaluateBatchItem(ctx, exec, i, namespace, policy, rule, facts)
+	}()
+
+	select {
+	case item := <-done:
+		if stoppedByBatch(ctx, item.Err) {
+			return timedOutBatchItem(i)
+		}
+		return item
+	case <-ctx.Done():
+		// prefer a result that raced the deadline, unless the deadline is what stopped it
+		select {
+		case item := <-done:
+			if !stoppedByBatch(ctx, item.Err) {
+				return item
+			}
+		default:
+		}
+		return timedOutBatchItem(i)
+	}
+}
+
+// stoppedByBatch reports whether err is the evaluation of an item giving up because ctx is done,
+// rather than an error of the item itself.
+func stoppedByBatch(ctx context.Context, err error) bool {
+	return err != nil && ctx.Err() != nil && errors.Is(err, ctx.Err())
+}
+
+func timedOutBatchItem(i int) *BatchItem {
+	return &BatchItem{Index: i, Outcome: trinary.Unknown, TimedOut: true, Err: ErrBatchTimeout}
+}
+
+func evaluateBatchItem(ctx context.Context, exec Executor, i int, namespace, policy, rule string, facts map[string]any) *BatchItem {
+	item := &BatchItem{Index: i, Outcome: trinary.Unknown}
+
+	start := time.Now()
+	if len(rule) == 0 {
+		item.Outputs, item.Err = exec.ExecPolicy(ctx, namespace, policy, facts)
+	} else {
+		output, err := exec.ExecRule(ctx, namespace, policy, rule, facts)
+		item.Err = err
+		if output != nil {
+			item.Outputs = []*ExecutorOutput{output}
+		}
+	}
+	item.Duration = time.Since(start)
+	if item.Err != nil {
+		return item
+	}
+
+	if len(rule) == 0 {
+		if p, err := exec.Index().ResolvePolicy(namespace, policy); err == nil {
+			if item.Combined = CombineDecisions(p, item.Outputs); item.Combined != nil {
+				item.Outcome = item.Combined.State
+				return item
+			}
+		}
+	}
+
+	outcome := trinary.True
+	for _, output := range item.Outputs {
+		outcome = outcome.And(output.Permits())
+	}
+	item.Outcome = outcome
+	return item
+}
@@ -0,0 +1,248 @@
+// SPDX-FileCopyrightText: © 2026 Binaek Sarkar <binaek89@gmail.com>
+// SPDX-License-Identifier: Apache-2.0
+
+package runtime
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync/atomic"
+	"time"
+
+	"github.com/sentrie-sh/sentrie/index"
+	"github.com/sentrie-sh/sentrie/pack"
+	"github.com/sentrie-sh/sentrie/parser"
+	"github.com/sentrie-sh/sentrie/trinary"
+)
+
+const batchPolicy = `namespace com/example
+policy auth {
+  fact role: string
+  fact age?: number
+  rule adult = default false { yield age >= 18 }
+  rule admin = default false { yield role == "admin" }
+  export decision of adult
+  export decision of admin
+}
+`
+
+// executorFromSource indexes a single program. The executor has no module registry, so the
+// program must not use any modules.
+func (s *RuntimeTestSuite) executorFromSource(src string) Executor {
+	ctx := context.Background()
+	program, err := parser.NewParserFromString(src, "batch.sentrie").ParseProgram(ctx)
+	s.Require().NoError(err)
+
+	idx := index.CreateIndex()
+	s.Require().NoError(idx.SetPack(ctx, &pack.PackFile{Location: s.T().TempDir()}))
+	s.Require().NoError(idx.AddProgram(ctx, program))
+	s.Require().NoError(idx.Validate(ctx))
+
+	return &executorImpl{index: viewOf(idx)}
+}
+
+func (s *RuntimeTestSuite) TestEvaluateBatchMixedOutcomes() {
+	exec := s.executorFromSource(batchPolicy)
+	inputs := []map[string]any{
+		{"role": "admin", "age": 30},
+		{"role": "user", "age": 30},
+		{"role": 42},
+		{"role": "admin"},
+		{"role": "admin", "age": 40},
+	}
+
+	result, err := EvaluateBatch(context.Background(), exec, "com/example/auth", inputs, 2)
+	s.Require().NoError(err)
+	s.Require().Len(result.Items, len(inputs))
+	for i, item := range result.Items {
+		s.Equal(i, item.Index)
+	}
+
+	s.Equal(trinary.True, result.Items[0].Outcome)
+	s.Len(result.Items[0].Outputs, 2)
+	s.Equal(trinary.False, result.Items[1].Outcome)
+	s.Error(result.Items[2].Err)
+	// the optional age is missing, so adult is unknown
+	s.NoError(result.Items[3].Err)
+	s.Equal(trinary.Unknown, result.Items[3].Outcome)
+	s.Equal(trinary.True, result.Items[4].Outcome)
+
+	summary := result.Summary
+	s.Equal(5, summary.Total)
+	s.Equal(2, summary.Passed)
+	s.Equal(1, summary.Failed)
+	s.Equal(1, summary.Unknown)
+	s.Equal(1, summary.Errored)
+	s.Equal([]int{1}, summary.FailedIndices)
+	s.Equal([]int{2}, summary.ErroredIndices)
+}
+
+func (s *RuntimeTestSuite) TestEvaluateBatchSingleRuleAndUnknownTarget() {
+	exec := s.executorFromSource(batchPolicy)
+
+	result, err := EvaluateBatch(context.Background(), exec, "com/example/auth/admin", []map[string]any{{"role": "admin"}, {"role": "user"}}, 0)
+	s.Require().NoError(err)
+	s.Equal(trinary.True, result.Items[0].Outcome)
+	s.Equal(trinary.False, result.Items[1].Outcome)
+	s.Equal([]int{1}, result.Summary.FailedIndices)
+
+	result, err = EvaluateBatch(context.Background(), exec, "com/example/auth", nil, 1)
+	s.Require().NoError(err)
+	s.Empty(result.Items)
+	s.Equal(0, result.Summary.Total)
+
+	_, err = EvaluateBatch(context.Background(), exec, "com/example/missing", nil, 1)
+	s.Error(err)
+}
+
+// slowExecutor stalls, without observing its context, on every input whose role is "slow".
+type slowExecutor struct {
+	Executor
+	stall time.Duration
+}
+
+func (e *slowExecutor) ExecPolicy(ctx context.Context, namespace, policy string, facts map[string]any) ([]*ExecutorOutput, error) {
+	if facts["role"] == "slow" {
+		time.Sleep(e.stall)
+		facts = map[string]any{"role": "user"}
+	}
+	return e.Executor.ExecPolicy(ctx, namespace, policy, facts)
+}
+
+func (s *RuntimeTestSuite) TestEvaluateBatchBudgetTimesOutRemainingItems() {
+	exec := &slowExecutor{Executor: s.executorFromSource(batchPolicy), stall: 2 * time.Second}
+	inputs := []map[string]any{
+		{"role": "admin", "age": 30},
+		{"role": "user", "age": 30},
+		{"role": "slow"},
+		{"role": "admin", "age": 30},
+		{"role": "admin", "age": 30},
+	}
+
+	start := time.Now()
+	result, err := EvaluateBatch(context.Background(), exec, "com/example/auth", inputs, 1, WithBatchBudget(100*time.Millisecond))
+	s.Require().NoError(err)
+	s.Less(time.Since(start), time.Second)
+
+	s.Require().Len(result.Items, len(inputs))
+	s.Equal(trinary.True, result.Items[0].Outcome)
+	s.Equal(trinary.False, result.Items[1].Outcome)
+	for i := 2; i < len(inputs); i++ {
+		s.Equal(i, result.Items[i].Index)
+		s.True(result.Items[i].TimedOut)
+		s.ErrorIs(result.Items[i].Err, ErrBatchTimeout)
+		s.Equal(trinary.Unknown, result.Items[i].Outcome)
+	}
+
+	summary := result.Summary
+	s.Equal(5, summary.Total)
+	s.Equal(1, summary.Passed)
+	s.Equal(1, summary.Failed)
+	s.Equal(0, summary.Errored)
+	s.Equal(3, summary.TimedOut)
+	s.Equal([]int{2, 3, 4}, summary.TimedOutIndices)
+}
+
+func (s *RuntimeTestSuite) TestStoppedByBatchTellsItemErrorsFromTimeouts() {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	s.True(stoppedByBatch(ctx, fmt.Errorf("evaluating: %w", context.Canceled)))
+	// an error of the item returned once the batch is done is still the item's own
+	s.False(stoppedByBatch(ctx, errors.New("identifier not found: role")))
+	s.False(stoppedByBatch(ctx, nil))
+	s.False(stoppedByBatch(context.Background(), context.Canceled))
+}
+
+func (s *RuntimeTestSuite) TestEvaluateBatchStopsWhenContextIsDone() {
+	exec := s.executorFromSource(batchPolicy)
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	result, err := EvaluateBatch(ctx, exec, "com/example/auth", []map[string]any{{"role": "admin", "age": 30}}, 1)
+	s.Require().NoError(err)
+	s.True(result.Items[0].TimedOut)
+	s.Equal([]int{0}, result.Summary.TimedOutIndices)
+}
+
+func (s *RuntimeTestSuite) TestEvaluateBatchValidatesFactsPerInput() {
+	exec := s.executorFromSource(batchPolicy)
+	inputs := []map[string]any{
+		{"role": "admin", "age": 30},
+		{"role": 42},
+		{"age": 30},
+		{"role": "user", "age": 30},
+	}
+
+	result, err := EvaluateBatch(context.Background(), exec, "com/example/auth", inputs, 2, WithBatchFactValidation())
+	s.Require().NoError(err)
+	s.Equal(trinary.True, result.Items[0].Outcome)
+	s.Equal(trinary.False, result.Items[3].Outcome)
+	for _, i := range []int{1, 2} {
+		item := result.Items[i]
+		s.True(item.Invalid)
+		s.Empty(item.Outputs)
+		s.Equal(trinary.Unknown, item.Outcome)
+		s.Require().Error(item.Err)
+		s.Equal(item.Err.Error(), item.Error)
+	}
+	s.Contains(result.Items[2].Error, "role")
+
+	summary := result.Summary
+	s.Equal(1, summary.Passed)
+	s.Equal(1, summary.Failed)
+	s.Equal(2, summary.Invalid)
+	s.Equal(0, summary.Errored)
+	s.Equal([]int{1, 2}, summary.InvalidIndices)
+}
+
+// staggeredExecutor delays every input by its "delay", in milliseconds, so that inputs finish in
+// an order other than theirs, and keeps the most inputs it evaluated at once.
+type staggeredExecutor struct {
+	Executor
+	running, peak atomic.Int32
+}
+
+func (e *staggeredExecutor) ExecPolicy(ctx context.Context, namespace, policy string, facts map[string]any) ([]*ExecutorOutput, error) {
+	running := e.running.Add(1)
+	defer e.running.Add(-1)
+	for {
+		peak := e.peak.Load()
+		if running <= peak || e.peak.CompareAndSwap(peak, running) {
+			break
+		}
+	}
+	time.Sleep(time.Duration(facts["delay"].(int)) * time.Millisecond)
+	return e.Executor.ExecPolicy(ctx, namespace, policy, facts)
+}
+
+func (s *RuntimeTestSuite) TestEvaluateBatchPreservesInputOrderUnderParallelEvaluation() {
+	exec := &staggeredExecutor{Executor: s.executorFromSource(batchPolicy)}
+	const n = 32
+	inputs := make([]map[string]any, n)
+	for i := range inputs {
+		role := "user"
+		if i%2 == 0 {
+			role = "admin"
+		}
+		// later inputs finish first
+		inputs[i] = map[string]any{"role": role, "age": i, "delay": n - i}
+	}
+
+	result, err := EvaluateBatch(context.Background(), exec, "com/example/auth", inputs, 8)
+	s.Require().NoError(err)
+	s.Greater(exec.peak.Load(), int32(1))
+	s.LessOrEqual(exec.peak.Load(), int32(8))
+
+	s.Require().Len(result.Items, n)
+	for i, item := range result.Items {
+		s.Equal(i, item.Index)
+		s.Require().NoError(item.Err)
+		want := trinary.False
+		if i%2 == 0 && i >= 18 {
+			want = trinary.True
+		}
+		s.Equal(want, item.Outcome, "input %d", i)
+	}
+}
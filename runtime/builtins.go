@@ -293,15 +293,20 @@ var Builtins = map[string]Builtin{
 	"all":            BuiltinAll,
 	"any":            BuiltinAny,
 	"as_list":        BuiltinAsList,
+	"changed_paths":  BuiltinChangedPaths,
 	"count":          BuiltinCount,
+	"diff":           BuiltinDiff,
 	"distinct":       BuiltinDistinct,
 	"error":          BuiltInError,
 	"filter":         BuiltinFilter,
 	"first":          BuiltinFirst,
 	"flatten":        BuiltinFlatten,
 	"flatten_deep":   BuiltinFlattenDeep,
+	"group_by":       BuiltinGroupBy,
 	"collect":        BuiltinCollect,
+	"map":            BuiltinMap,
 	"merge":          BuiltinMerge,
 	"normalise_list": BuiltinNormaliseList,
 	"reduce":         BuiltinReduce,
+	"sort_by":        BuiltinSortBy,
 }
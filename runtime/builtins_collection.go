@@ -17,14 +17,21 @@
 package runtime
 
 import (
+	"cmp"
 	"context"
 	"fmt"
+	"math"
 	"slices"
+	"strconv"
+	"strings"
 
 	"github.com/sentrie-sh/sentrie/box"
+	"github.com/sentrie-sh/sentrie/trinary"
 )
 
-// BuiltinAny reports whether any element satisfies the predicate callable.
+// BuiltinAny reports whether any element satisfies the predicate callable. Predicates are read
+// as trinaries: any true result makes the answer true, otherwise an unknown result makes it
+// unknown. An empty list yields false.
 func BuiltinAny(ctx context.Context, site *CallSite, args ...box.Value) (box.Value, error) {
 	if len(args) != 2 {
 		return box.Undefined(), fmt.Errorf("any requires 2 arguments")
@@ -45,6 +52,7 @@ func BuiltinAny(ctx context.Context, site *CallSite, args ...box.Value) (box.Val
 	if c.Arity() != 1 && c.Arity() != 2 {
 		return box.Undefined(), fmt.Errorf("any: callable must have arity 1 or 2")
 	}
+	result := trinary.False
 	for idx, item := range list {
 		callArgs, err := iterArgs(site, c, item, idx)
 		if err != nil {
@@ -54,14 +62,18 @@ func BuiltinAny(ctx context.Context, site *CallSite, args ...box.Value) (box.Val
 		if err != nil {
 			return box.Undefined(), err
 		}
-		if box.TrinaryFrom(res).IsTrue() {
+		t := box.TrinaryFrom(res)
+		if t == trinary.True {
 			return box.Bool(true), nil
 		}
+		result = result.Or(t)
 	}
-	return box.Bool(false), nil
+	return quantifierResult(result), nil
 }
 
-// BuiltinAll reports whether every element satisfies the predicate callable.
+// BuiltinAll reports whether every element satisfies the predicate callable. Predicates are read
+// as trinaries: any false result makes the answer false, otherwise an unknown result makes it
+// unknown. An empty list yields true.
 func BuiltinAll(ctx context.Context, site *CallSite, args ...box.Value) (box.Value, error) {
 	if len(args) != 2 {
 		return box.Undefined(), fmt.Errorf("all requires 2 arguments")
@@ -82,6 +94,7 @@ func BuiltinAll(ctx context.Context, site *CallSite, args ...box.Value) (box.Val
 	if c.Arity() != 1 && c.Arity() != 2 {
 		return box.Undefined(), fmt.Errorf("all: callable must have arity 1 or 2")
 	}
+	result := trinary.True
 	for idx, item := range list {
 		callArgs, err := iterArgs(site, c, item, idx)
 		if err != nil {
@@ -91,11 +104,21 @@ func BuiltinAll(ctx context.Context, site *CallSite, args ...box.Value) (box.Val
 		if err != nil {
 			return box.Undefined(), err
 		}
-		if !box.TrinaryFrom(res).IsTrue() {
+		t := box.TrinaryFrom(res)
+		if t == trinary.False {
 			return box.Bool(false), nil
 		}
+		result = result.And(t)
 	}
-	return box.Bool(true), nil
+	return quantifierResult(result), nil
+}
+
+// quantifierResult reports a settled quantifier as a bool and an undecided one as unknown.
+func quantifierResult(t trinary.Value) box.Value {
+	if t == trinary.Unknown {
+		return box.Trinary(t)
+	}
+	return box.Bool(t.IsTrue())
 }
 
 // BuiltinFirst returns the first item satisfying the predicate, or undefined.
@@ -175,13 +198,23 @@ func BuiltinFilter(ctx context.Context, site *CallSite, args ...box.Value) (box.
 
 // BuiltinCollect maps each list element through the callable.
 func BuiltinCollect(ctx context.Context, site *CallSite, args ...box.Value) (box.Value, error) {
+	return mapList(ctx, site, "collect", args)
+}
+
+// BuiltinMap maps each list element through the callable. It is the conventional name for collect.
+func BuiltinMap(ctx context.Context, site *CallSite, args ...box.Value) (box.Value, error) {
+	return mapList(ctx, site, "map", args)
+}
+
+// mapList implements collect and map, reporting errors under the name the builtin was called by.
+func mapList(ctx context.Context, site *CallSite, name string, args []box.Value) (box.Value, error) {
 	if len(args) != 2 {
-		return box.Undefined(), fmt.Errorf("collect requires 2 arguments")
+		return box.Undefined(), fmt.Errorf("%s requires 2 arguments", name)
 	}
 	col := args[0]
 	list, ok := col.ListValue()
 	if !ok {
-		return box.Undefined(), fmt.Errorf("collect: first argument must be a list")
+		return box.Undefined(), fmt.Errorf("%s: first argument must be a list", name)
 	}
 	fn := args[1]
 	c, err := callableFromValue(fn)
@@ -189,7 +222,7 @@ func BuiltinCollect(ctx context.Context, site *CallSite, args ...box.Value) (box
 		return box.Undefined(), err
 	}
 	if c.Arity() != 1 && c.Arity() != 2 {
-		return box.Undefined(), fmt.Errorf("collect: callable must have arity 1 or 2")
+		return box.Undefined(), fmt.Errorf("%s: callable must have arity 1 or 2", name)
 	}
 	out := make([]box.Value, 0, len(list))
 	for idx, item := range list {
@@ -265,11 +298,11 @@ func builtinDistinctDirect(col box.Value) (box.Value, error) {
 	seen := make(map[string]struct{}, len(list))
 	out := make([]box.Value, 0, len(list))
 	for _, item := range list {
-		k, err := scalarFingerprint(item)
+		k, comparable, err := scalarFingerprint(item)
 		if err != nil {
 			return box.Undefined(), err
 		}
-		if _, dup := seen[k]; dup {
+		if _, dup := seen[k]; comparable && dup {
 			continue
 		}
 		seen[k] = struct{}{}
@@ -304,11 +337,11 @@ func builtinDistinctSelector(ctx context.Context, site *CallSite, col, fn box.Va
 		if err != nil {
 			return box.Undefined(), err
 		}
-		k, err := scalarFingerprint(keyVal)
+		k, comparable, err := scalarFingerprint(keyVal)
 		if err != nil {
 			return box.Undefined(), fmt.Errorf("distinct key: %w", err)
 		}
-		if _, dup := seen[k]; dup {
+		if _, dup := seen[k]; comparable && dup {
 			continue
 		}
 		seen[k] = struct{}{}
@@ -317,26 +350,175 @@ func builtinDistinctSelector(ctx context.Context, site *CallSite, col, fn box.Va
 	return box.List(out), nil
 }
 
-// scalarFingerprint builds a stable dedupe key for supported scalar kinds.
-func scalarFingerprint(v box.Value) (string, error) {
-	switch v.Kind() {
-	case box.ValueUndefined:
-		return "undef:", nil
-	case box.ValueNull:
-		return "null:", nil
-	case box.ValueBool:
-		b, _ := v.BoolValue()
-		return fmt.Sprintf("bool:%v", b), nil
-	case box.ValueNumber:
-		n, _ := v.NumberValue()
-		return fmt.Sprintf("num:%.17g", n), nil
+// GroupByUnknownKey is the group_by bucket for elements whose key is unknown, null, undefined or
+// NaN, since a NaN key equals no other key. Every other bucket is named after the type of its key,
+// see groupKey, so no key can land in it.
+const GroupByUnknownKey = "<unknown>"
+
+// BuiltinSortBy returns a copy of the list stably sorted by the key the selector returns for each
+// element. Keys must be all numbers or all strings. Numeric keys follow the same rules as distinct:
+// -0 and 0 are equal, and NaN keys, being unordered, sort after every number.
+func BuiltinSortBy(ctx context.Context, site *CallSite, args ...box.Value) (box.Value, error) {
+	if len(args) != 2 {
+		return box.Undefined(), fmt.Errorf("sort_by requires 2 arguments")
+	}
+	list, keys, err := selectKeys(ctx, site, "sort_by", args[0], args[1])
+	if err != nil {
+		return box.Undefined(), err
+	}
+	if len(list) == 0 {
+		return box.List(nil), nil
+	}
+
+	keyKind := keys[0].Kind()
+	if keyKind != box.ValueNumber && keyKind != box.ValueString {
+		return box.Undefined(), fmt.Errorf("sort_by: key of kind %s is not orderable (expected number or string)", keyKind)
+	}
+	for _, k := range keys[1:] {
+		if k.Kind() != keyKind {
+			return box.Undefined(), fmt.Errorf("sort_by: keys are not mutually comparable: %s and %s", keyKind, k.Kind())
+		}
+	}
+
+	order := make([]int, len(list))
+	for i := range order {
+		order[i] = i
+	}
+	slices.SortStableFunc(order, func(a, b int) int {
+		if keyKind == box.ValueNumber {
+			x, _ := keys[a].NumberValue()
+			y, _ := keys[b].NumberValue()
+			return compareNumberKeys(x, y)
+		}
+		x, _ := keys[a].StringValue()
+		y, _ := keys[b].StringValue()
+		return strings.Compare(x, y)
+	})
+
+	out := make([]box.Value, 0, len(list))
+	for _, i := range order {
+		out = append(out, list[i])
+	}
+	return box.List(out), nil
+}
+
+// BuiltinGroupBy buckets list elements into a dict keyed by the selector's result, preserving
+// element order within each bucket. A bucket is named by the type and the value of its key, as in
+// "string:red" or "number:2", so that keys of different types never share one. Unknown, null and
+// undefined keys go to GroupByUnknownKey.
+func BuiltinGroupBy(ctx context.Context, site *CallSite, args ...box.Value) (box.Value, error) {
+	if len(args) != 2 {
+		return box.Undefined(), fmt.Errorf("group_by requires 2 arguments")
+	}
+	list, keys, err := selectKeys(ctx, site, "group_by", args[0], args[1])
+	if err != nil {
+		return box.Undefined(), err
+	}
+
+	groups := make(map[string][]box.Value)
+	for i, item := range list {
+		k, err := groupKey(keys[i])
+		if err != nil {
+			return box.Undefined(), err
+		}
+		groups[k] = append(groups[k], item)
+	}
+
+	out := make(map[string]box.Value, len(groups))
+	for k, items := range groups {
+		out[k] = box.List(items)
+	}
+	return box.Dict(out), nil
+}
+
+// selectKeys evaluates the selector for every element of the list.
+func selectKeys(ctx context.Context, site *CallSite, name string, col, fn box.Value) ([]box.Value, []box.Value, error) {
+	list, ok := col.ListValue()
+	if !ok {
+		return nil, nil, fmt.Errorf("%s: first argument must be a list", name)
+	}
+	c, err := callableFromValue(fn)
+	if err != nil {
+		return nil, nil, err
+	}
+	if c.Arity() != 1 && c.Arity() != 2 {
+		return nil, nil, fmt.Errorf("%s: selector must have arity 1 or 2", name)
+	}
+	keys := make([]box.Value, 0, len(list))
+	for idx, item := range list {
+		callArgs, err := iterArgs(site, c, item, idx)
+		if err != nil {
+			return nil, nil, err
+		}
+		k, err := invokeCallable(ctx, site, c, callArgs)
+		if err != nil {
+			return nil, nil, err
+		}
+		keys = append(keys, k)
+	}
+	return list, keys, nil
+}
+
+// compareNumberKeys orders sort_by keys, placing NaN after every number.
+func compareNumberKeys(x, y float64) int {
+	switch xNaN, yNaN := math.IsNaN(x), math.IsNaN(y); {
+	case xNaN && yNaN:
+		return 0
+	case xNaN:
+		return 1
+	case yNaN:
+		return -1
+	default:
+		return cmp.Compare(x, y)
+	}
+}
+
+// groupKey renders a group_by key as a dict key, prefixed with the type of the key.
+func groupKey(k box.Value) (string, error) {
+	switch k.Kind() {
 	case box.ValueString:
-		s, _ := v.StringValue()
-		return "str:" + s, nil
+		s, _ := k.StringValue()
+		return "string:" + s, nil
+	case box.ValueNumber:
+		n, _ := k.NumberValue()
+		if math.IsNaN(n) {
+			return GroupByUnknownKey, nil
+		}
+		if n == 0 {
+			n = 0 // -0 and 0 share a group
+		}
+		return "number:" + strconv.FormatFloat(n, 'f', -1, 64), nil
+	case box.ValueBool:
+		b, _ := k.BoolValue()
+		return "boolean:" + strconv.FormatBool(b), nil
 	case box.ValueTrinary:
-		t, _ := v.TrinaryValue()
-		return fmt.Sprintf("tri:%d", t), nil
+		t, _ := k.TrinaryValue()
+		if t == trinary.Unknown {
+			return GroupByUnknownKey, nil
+		}
+		return "trinary:" + t.String(), nil
+	case box.ValueUndefined, box.ValueNull:
+		return GroupByUnknownKey, nil
 	default:
-		return "", fmt.Errorf("unsupported key kind %s for distinct (expected string, number, bool, trinary, null, or undefined)", v.Kind())
+		return "", fmt.Errorf("group_by: unsupported key kind %s (expected string, number, bool, trinary, null, or undefined)", k.Kind())
 	}
 }
+
+// scalarFingerprint returns the key distinct compares elements by. Numbers compare by value: ints
+// and floats unify and -0 equals 0. NaN equals nothing, not even another NaN, so a NaN has no key
+// and comparable is false.
+func scalarFingerprint(v box.Value) (key string, comparable bool, err error) {
+	if isNaN(v) {
+		return "", false, nil
+	}
+	key, ok := box.ScalarKey(v)
+	if !ok {
+		return "", false, fmt.Errorf("unsupported key kind %s for distinct (expected string, number, bool, trinary, null, or undefined)", v.Kind())
+	}
+	return key, true, nil
+}
+
+func isNaN(v box.Value) bool {
+	n, ok := v.NumberValue()
+	return ok && math.IsNaN(n)
+}
@@ -7,6 +7,7 @@ package runtime
 import (
 	"context"
 	"errors"
+	"math"
 
 	"github.com/sentrie-sh/sentrie/ast"
 	"github.com/sentrie-sh/sentrie/box"
@@ -432,3 +433,213 @@ func (s *RuntimeTestSuite) TestEvalCallBoundaryBranches() {
 	hash := calculateHashKey(&ast.CallExpression{}, []box.Value{box.Callable(stubCallable{arity: 0})})
 	s.Equal("", hash)
 }
+
+func (s *RuntimeTestSuite) TestBuiltinsCollection_MapNamesItsErrors() {
+	site := s.builtinSite()
+	double := box.Callable(stubCallable{
+		arity: 1,
+		fn: func(args []box.Value) (box.Value, error) {
+			n, _ := args[0].NumberValue()
+			return box.Number(n * 2), nil
+		},
+	})
+
+	out, err := BuiltinMap(s.ctx, site, box.List([]box.Value{box.Number(1), box.Number(4)}), double)
+	s.Require().NoError(err)
+	doubled, ok := out.ListValue()
+	s.Require().True(ok)
+	s.Require().Len(doubled, 2)
+	x, _ := doubled[1].NumberValue()
+	s.Equal(8.0, x)
+
+	out, err = BuiltinMap(s.ctx, site, box.List(nil), double)
+	s.Require().NoError(err)
+	empty, ok := out.ListValue()
+	s.Require().True(ok)
+	s.Empty(empty)
+
+	_, err = BuiltinMap(s.ctx, site, box.String("nope"), double)
+	s.Require().ErrorContains(err, "map: first argument must be a list")
+	_, err = BuiltinMap(s.ctx, site, box.List(nil))
+	s.Require().ErrorContains(err, "map requires 2 arguments")
+	s.Contains(Builtins, "map")
+}
+
+func (s *RuntimeTestSuite) TestBuiltinsCollection_QuantifiersAreThreeValued() {
+	site := s.builtinSite()
+	identity := box.Callable(stubCallable{
+		arity: 1,
+		fn: func(args []box.Value) (box.Value, error) {
+			return args[0], nil
+		},
+	})
+	unknown := box.Trinary(trinary.Unknown)
+	list := func(xs ...box.Value) box.Value { return box.List(xs) }
+
+	for _, tc := range []struct {
+		name string
+		fn   Builtin
+		col  box.Value
+		want trinary.Value
+	}{
+		{"all empty", BuiltinAll, list(), trinary.True},
+		{"any empty", BuiltinAny, list(), trinary.False},
+		{"all true and unknown", BuiltinAll, list(box.Bool(true), unknown, box.Bool(true)), trinary.Unknown},
+		{"all false beats unknown", BuiltinAll, list(unknown, box.Bool(false)), trinary.False},
+		{"all true", BuiltinAll, list(box.Bool(true), box.Bool(true)), trinary.True},
+		{"any false and unknown", BuiltinAny, list(box.Bool(false), unknown), trinary.Unknown},
+		{"any true beats unknown", BuiltinAny, list(unknown, box.Bool(true)), trinary.True},
+		{"any false", BuiltinAny, list(box.Bool(false), box.Bool(false)), trinary.False},
+	} {
+		out, err := tc.fn(s.ctx, site, tc.col, identity)
+		s.Require().NoError(err, tc.name)
+		s.Equal(tc.want, box.TrinaryFrom(out), tc.name)
+	}
+}
+
+func (s *RuntimeTestSuite) TestBuiltinsCollection_SortByAndGroupBy() {
+	site := s.builtinSite()
+	field := func(name string) box.Value {
+		return box.Callable(stubCallable{
+			arity: 1,
+			fn: func(args []box.Value) (box.Value, error) {
+				m, _ := args[0].DictValue()
+				return m[name], nil
+			},
+		})
+	}
+	item := func(id string, rank box.Value, team box.Value) box.Value {
+		return box.Dict(map[string]box.Value{"id": box.String(id), "rank": rank, "team": team})
+	}
+	ids := func(v box.Value) []string {
+		xs, ok := v.ListValue()
+		s.Require().True(ok)
+		out := make([]string, 0, len(xs))
+		for _, x := range xs {
+			m, _ := x.DictValue()
+			id, _ := m["id"].StringValue()
+			out = append(out, id)
+		}
+		return out
+	}
+
+	items := box.List([]box.Value{
+		item("a", box.Number(2), box.String("red")),
+		item("b", box.Number(1), box.Trinary(trinary.Unknown)),
+		item("c", box.Number(2), box.String("blue")),
+		item("d", box.Number(0), box.String("red")),
+	})
+
+	out, err := BuiltinSortBy(s.ctx, site, items, field("rank"))
+	s.Require().NoError(err)
+	s.Equal([]string{"d", "b", "a", "c"}, ids(out))
+
+	out, err = BuiltinSortBy(s.ctx, site, box.List(nil), field("rank"))
+	s.Require().NoError(err)
+	s.Empty(ids(out))
+
+	mixed := box.List([]box.Value{item("a", box.Number(1), box.Null()), item("b", box.String("x"), box.Null())})
+	_, err = BuiltinSortBy(s.ctx, site, mixed, field("rank"))
+	s.Require().ErrorContains(err, "sort_by: keys are not mutually comparable: number and string")
+
+	_, err = BuiltinSortBy(s.ctx, site, box.String("x"), field("rank"))
+	s.Require().ErrorContains(err, "sort_by: first argument must be a list")
+
+	out, err = BuiltinGroupBy(s.ctx, site, items, field("team"))
+	s.Require().NoError(err)
+	groups, ok := out.DictValue()
+	s.Require().True(ok)
+	s.Len(groups, 3)
+	s.Equal([]string{"a", "d"}, ids(groups["string:red"]))
+	s.Equal([]string{"c"}, ids(groups["string:blue"]))
+	s.Equal([]string{"b"}, ids(groups[GroupByUnknownKey]))
+
+	out, err = BuiltinGroupBy(s.ctx, site, items, field("rank"))
+	s.Require().NoError(err)
+	groups, _ = out.DictValue()
+	s.Equal([]string{"a", "c"}, ids(groups["number:2"]))
+
+	s.Contains(Builtins, "sort_by")
+	s.Contains(Builtins, "group_by")
+}
+
+func (s *RuntimeTestSuite) TestBuiltinsCollection_GroupByKeepsKeyTypesApart() {
+	site := s.builtinSite()
+	identity := box.Callable(stubCallable{
+		arity: 1,
+		fn:    func(args []box.Value) (box.Value, error) { return args[0], nil },
+	})
+
+	keys := box.List([]box.Value{
+		box.String("1"), box.Number(1),
+		box.String("true"), box.Bool(true), box.Trinary(trinary.True),
+		box.String(GroupByUnknownKey), box.Null(),
+	})
+	out, err := BuiltinGroupBy(s.ctx, site, keys, identity)
+	s.Require().NoError(err)
+	groups, ok := out.DictValue()
+	s.Require().True(ok)
+	s.Len(groups, 7)
+	for key, want := range map[string]box.Value{
+		"string:1":                    box.String("1"),
+		"number:1":                    box.Number(1),
+		"string:true":                 box.String("true"),
+		"boolean:true":                box.Bool(true),
+		"trinary:true":                box.Trinary(trinary.True),
+		"string:" + GroupByUnknownKey: box.String(GroupByUnknownKey),
+		GroupByUnknownKey:             box.Null(),
+	} {
+		s.Equal(box.List([]box.Value{want}), groups[key], key)
+	}
+}
+
+func (s *RuntimeTestSuite) TestBuiltinsCollection_NaNAndNegativeZero() {
+	site := s.builtinSite()
+	nan := box.Number(math.NaN())
+	identity := box.Callable(stubCallable{
+		arity: 1,
+		fn:    func(args []box.Value) (box.Value, error) { return args[0], nil },
+	})
+	numbers := func(v box.Value) []float64 {
+		xs, ok := v.ListValue()
+		s.Require().True(ok)
+		out := make([]float64, 0, len(xs))
+		for _, x := range xs {
+			n, _ := x.NumberValue()
+			out = append(out, n)
+		}
+		return out
+	}
+
+	// NaN is distinct from every value, other NaNs included; -0 and 0 are the same value
+	list := box.List([]box.Value{nan, box.Number(0), nan, box.Number(math.Copysign(0, -1)), box.Number(1), box.Number(1.0)})
+	for _, out := range []func() (box.Value, error){
+		func() (box.Value, error) { return BuiltinDistinct(s.ctx, site, list) },
+		func() (box.Value, error) { return BuiltinDistinct(s.ctx, site, list, identity) },
+	} {
+		got, err := out()
+		s.Require().NoError(err)
+		vals := numbers(got)
+		s.Require().Len(vals, 4)
+		s.True(math.IsNaN(vals[0]))
+		s.Equal(0.0, vals[1])
+		s.True(math.IsNaN(vals[2]))
+		s.Equal(1.0, vals[3])
+	}
+
+	// NaN keys sort after every number, keeping their order; -0 and 0 keep theirs too
+	sorted, err := BuiltinSortBy(s.ctx, site, box.List([]box.Value{nan, box.Number(2), box.Number(math.Copysign(0, -1)), box.Number(0), box.Number(-1)}), identity)
+	s.Require().NoError(err)
+	vals := numbers(sorted)
+	s.Equal([]float64{-1, 0, 0, 2}, vals[:4])
+	s.True(math.Signbit(vals[1]))
+	s.False(math.Signbit(vals[2]))
+	s.True(math.IsNaN(vals[4]))
+
+	grouped, err := BuiltinGroupBy(s.ctx, site, box.List([]box.Value{nan, box.Number(math.Copysign(0, -1)), box.Number(0)}), identity)
+	s.Require().NoError(err)
+	groups, _ := grouped.DictValue()
+	s.Len(groups, 2)
+	s.Len(numbers(groups["number:0"]), 2)
+	s.Len(numbers(groups[GroupByUnknownKey]), 1)
+}
@@ -0,0 +1,175 @@
+// SPDX-FileCopyrightText: © 2026 Binaek Sarkar <binaek89@gmail.com>
+// SPDX-License-Identifier: Apache-2.0
+
+package runtime
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+	"strconv"
+
+	"github.com/sentrie-sh/sentrie/box"
+)
+
+// BuiltinDiff compares an old value with a new one and returns the delta as a dict whose `equal`
+// entry reports whether they are the same.
+//
+// Two dicts are compared key by key: `added` holds the new value of every key only the new dict
+// has, `removed` the old value of every key only the old dict has, and `changed` an old/new pair
+// for every key whose value differs. Two lists are compared element by element: `changed` lists
+// the index, old and new value of every differing position, and `added` and `removed` list the
+// positions past the end of the shorter list. Any other pair is compared as a whole and carries
+// `old` and `new`. Values are compared as `==` does, so integers and floats of the same value are
+// equal, and the delta does not descend into nested values. List entries are in index order.
+func BuiltinDiff(_ context.Context, _ *CallSite, args ...box.Value) (box.Value, error) {
+	if len(args) != 2 {
+		return box.Undefined(), fmt.Errorf("diff requires 2 arguments")
+	}
+	if isUndefinedV(args[0]) || isUndefinedV(args[1]) {
+		return box.Undefined(), nil
+	}
+	a, b := args[0], args[1]
+
+	if am, ok := a.DictValue(); ok {
+		if bm, ok := b.DictValue(); ok {
+			return diffDicts(am, bm), nil
+		}
+	}
+	if al, ok := a.ListValue(); ok {
+		if bl, ok := b.ListValue(); ok {
+			return diffLists(al, bl), nil
+		}
+	}
+	return box.Dict(map[string]box.Value{
+		"equal": box.Bool(box.EqualValues(a, b)),
+		"old":   a,
+		"new":   b,
+	}), nil
+}
+
+func diffDicts(a, b map[string]box.Value) box.Value {
+	added := map[string]box.Value{}
+	removed := map[string]box.Value{}
+	changed := map[string]box.Value{}
+	for k, old := range a {
+		nv, ok := b[k]
+		switch {
+		case !ok:
+			removed[k] = old
+		case !box.EqualValues(old, nv):
+			changed[k] = box.Dict(map[string]box.Value{"old": old, "new": nv})
+		}
+	}
+	for k, nv := range b {
+		if _, ok := a[k]; !ok {
+			added[k] = nv
+		}
+	}
+	return box.Dict(map[string]box.Value{
+		"equal":   box.Bool(len(added) == 0 && len(removed) == 0 && len(changed) == 0),
+		"added":   box.Dict(added),
+		"removed": box.Dict(removed),
+		"changed": box.Dict(changed),
+	})
+}
+
+func diffLists(a, b []box.Value) box.Value {
+	added := []box.Value{}
+	removed := []box.Value{}
+	changed := []box.Value{}
+	for i := range max(len(a), len(b)) {
+		switch {
+		case i >= len(b):
+			removed = append(removed, box.Dict(map[string]box.Value{"index": box.Number(i), "old": a[i]}))
+		case i >= len(a):
+			added = append(added, box.Dict(map[string]box.Value{"index": box.Number(i), "new": b[i]}))
+		case !box.EqualValues(a[i], b[i]):
+			changed = append(changed, box.Dict(map[string]box.Value{"index": box.Number(i), "old": a[i], "new": b[i]}))
+		}
+	}
+	return box.Dict(map[string]box.Value{
+		"equal":   box.Bool(len(added) == 0 && len(removed) == 0 && len(changed) == 0),
+		"added":   box.List(added),
+		"removed": box.List(removed),
+		"changed": box.List(changed),
+	})
+}
+
+// BuiltinChangedPaths returns the dotted paths at which two dicts, or two lists, differ, so that a
+// rule can check that none of a set of protected paths changed. Nested dicts and lists are
+// descended into, with list positions written as indices, e.g. "roles.0.name". A key or position
+// present on only one side, or whose values are not both dicts or both lists, is reported as a
+// whole. Values compare as in diff, and the paths come in order of a walk visiting dict keys in
+// sorted order and list positions in index order.
+func BuiltinChangedPaths(_ context.Context, _ *CallSite, args ...box.Value) (box.Value, error) {
+	if len(args) != 2 {
+		return box.Undefined(), fmt.Errorf("changed_paths requires 2 arguments")
+	}
+	if isUndefinedV(args[0]) || isUndefinedV(args[1]) {
+		return box.Undefined(), nil
+	}
+	if !isContainerPair(args[0], args[1]) {
+		return box.Undefined(), fmt.Errorf("changed_paths: arguments must both be dicts or both be lists")
+	}
+
+	paths := []box.Value{}
+	collectChangedPaths(args[0], args[1], "", &paths)
+	return box.List(paths), nil
+}
+
+func isContainerPair(a, b box.Value) bool {
+	_, aDict := a.DictValue()
+	_, bDict := b.DictValue()
+	_, aList := a.ListValue()
+	_, bList := b.ListValue()
+	return (aDict && bDict) || (aList && bList)
+}
+
+func collectChangedPaths(a, b box.Value, prefix string, paths *[]box.Value) {
+	at := func(segment string) string {
+		if prefix == "" {
+			return segment
+		}
+		return prefix + "." + segment
+	}
+
+	if !isContainerPair(a, b) {
+		if !box.EqualValues(a, b) {
+			*paths = append(*paths, box.String(prefix))
+		}
+		return
+	}
+
+	if am, ok := a.DictValue(); ok {
+		bm, _ := b.DictValue()
+		keys := slices.Collect(maps.Keys(am))
+		for k := range bm {
+			if _, ok := am[k]; !ok {
+				keys = append(keys, k)
+			}
+		}
+		slices.Sort(keys)
+		for _, k := range keys {
+			av, inA := am[k]
+			bv, inB := bm[k]
+			if !inA || !inB {
+				*paths = append(*paths, box.String(at(k)))
+				continue
+			}
+			collectChangedPaths(av, bv, at(k), paths)
+		}
+		return
+	}
+
+	al, _ := a.ListValue()
+	bl, _ := b.ListValue()
+	for i := range max(len(al), len(bl)) {
+		if i >= len(al) || i >= len(bl) {
+			*paths = append(*paths, box.String(at(strconv.Itoa(i))))
+			continue
+		}
+		collectChangedPaths(al[i], bl[i], at(strconv.Itoa(i)), paths)
+	}
+}
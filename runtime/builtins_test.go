@@ -18,6 +18,7 @@ package runtime
 
 import (
 	"github.com/sentrie-sh/sentrie/box"
+	"github.com/sentrie-sh/sentrie/trinary"
 )
 
 // Test BuiltinFlatten
@@ -711,3 +712,156 @@ func (s *RuntimeTestSuite) TestMerge_ErrorNonMapSecond() {
 	s.Error(err)
 	s.Contains(err.Error(), "second argument is not a dict")
 }
+
+// Test BuiltinDiff
+
+func (s *RuntimeTestSuite) diffOf(a, b any) map[string]any {
+	result, err := BuiltinDiff(s.ctx, s.builtinSite(), s.builtinArgs(a, b)...)
+	s.Require().NoError(err)
+	delta, ok := result.Any().(map[string]any)
+	s.Require().True(ok)
+	return delta
+}
+
+func (s *RuntimeTestSuite) TestDiff_MapFieldChange() {
+	delta := s.diffOf(map[string]any{"owner": "alice", "size": 1}, map[string]any{"owner": "bob", "size": 1.0})
+	s.Equal(false, delta["equal"])
+	s.Equal(map[string]any{"owner": map[string]any{"old": "alice", "new": "bob"}}, delta["changed"])
+	s.Empty(delta["added"])
+	s.Empty(delta["removed"])
+}
+
+func (s *RuntimeTestSuite) TestDiff_MapAddedAndRemovedKeys() {
+	delta := s.diffOf(map[string]any{"owner": "alice", "draft": true}, map[string]any{"owner": "alice", "labels": []any{"x"}})
+	s.Equal(false, delta["equal"])
+	s.Equal(map[string]any{"labels": []any{"x"}}, delta["added"])
+	s.Equal(map[string]any{"draft": true}, delta["removed"])
+	s.Empty(delta["changed"])
+
+	delta = s.diffOf(map[string]any{"owner": "alice"}, map[string]any{"owner": "alice"})
+	s.Equal(true, delta["equal"])
+}
+
+func (s *RuntimeTestSuite) TestDiff_ListElementChange() {
+	delta := s.diffOf([]any{"a", "b", "c"}, []any{"a", "x", "c", "d"})
+	s.Equal(false, delta["equal"])
+	s.Equal([]any{map[string]any{"index": 1.0, "old": "b", "new": "x"}}, delta["changed"])
+	s.Equal([]any{map[string]any{"index": 3.0, "new": "d"}}, delta["added"])
+	s.Empty(delta["removed"])
+
+	delta = s.diffOf([]any{"a", "b"}, []any{"a"})
+	s.Equal([]any{map[string]any{"index": 1.0, "old": "b"}}, delta["removed"])
+}
+
+func (s *RuntimeTestSuite) TestDiff_Scalars() {
+	s.Equal(map[string]any{"equal": true, "old": 1.0, "new": 1.0}, s.diffOf(1, 1.0))
+	s.Equal(map[string]any{"equal": false, "old": "a", "new": []any{"a"}}, s.diffOf("a", []any{"a"}))
+
+	result, err := BuiltinDiff(s.ctx, s.builtinSite(), box.Undefined(), box.Number(1))
+	s.NoError(err)
+	s.True(result.IsUndefined())
+
+	_, err = BuiltinDiff(s.ctx, s.builtinSite(), s.builtinArgs(1)...)
+	s.ErrorContains(err, "diff requires 2 arguments")
+}
+
+func (s *RuntimeTestSuite) TestDiff_GuardsAProtectedField() {
+	exec := s.executorFromSource(`namespace com/example
+policy change {
+  fact before: dict[string]
+  fact after: dict[string]
+  rule allow = default false { yield not ("owner" in diff(before, after).changed) }
+  export decision of allow
+}
+`)
+
+	outputs, err := exec.ExecPolicy(s.ctx, "com/example", "change", map[string]any{
+		"before": map[string]any{"owner": "alice", "title": "a"},
+		"after":  map[string]any{"owner": "alice", "title": "b"},
+	})
+	s.Require().NoError(err)
+	s.Equal(trinary.True, outputs[0].ToTrinary())
+
+	outputs, err = exec.ExecPolicy(s.ctx, "com/example", "change", map[string]any{
+		"before": map[string]any{"owner": "alice"},
+		"after":  map[string]any{"owner": "bob"},
+	})
+	s.Require().NoError(err)
+	s.Equal(trinary.False, outputs[0].ToTrinary())
+}
+
+// Test BuiltinChangedPaths
+
+func (s *RuntimeTestSuite) changedPaths(a, b any) []any {
+	result, err := BuiltinChangedPaths(s.ctx, s.builtinSite(), s.builtinArgs(a, b)...)
+	s.Require().NoError(err)
+	paths, ok := result.Any().([]any)
+	s.Require().True(ok)
+	return paths
+}
+
+func (s *RuntimeTestSuite) TestChangedPaths_NestedChanges() {
+	before := map[string]any{
+		"owner": map[string]any{"id": "u1", "team": "core"},
+		"roles": []any{map[string]any{"name": "admin"}, map[string]any{"name": "viewer"}},
+		"draft": true,
+		"size":  1,
+	}
+	after := map[string]any{
+		"owner": map[string]any{"id": "u2", "team": "core"},
+		"roles": []any{map[string]any{"name": "admin"}, map[string]any{"name": "editor"}, map[string]any{"name": "viewer"}},
+		"size":  1.0,
+		"title": "x",
+	}
+	s.Equal([]any{"draft", "owner.id", "roles.1.name", "roles.2", "title"}, s.changedPaths(before, after))
+
+	// a value turning into a dict is reported where it changed, not below it
+	s.Equal([]any{"owner"}, s.changedPaths(map[string]any{"owner": "u1"}, map[string]any{"owner": map[string]any{"id": "u1"}}))
+	s.Equal([]any{"1"}, s.changedPaths([]any{1, 2}, []any{1, 3}))
+}
+
+func (s *RuntimeTestSuite) TestChangedPaths_IdenticalObjects() {
+	doc := map[string]any{"owner": map[string]any{"id": "u1"}, "tags": []any{"a", "b"}}
+	s.Empty(s.changedPaths(doc, map[string]any{"owner": map[string]any{"id": "u1"}, "tags": []any{"a", "b"}}))
+	s.Empty(s.changedPaths(map[string]any{}, map[string]any{}))
+}
+
+func (s *RuntimeTestSuite) TestChangedPaths_Errors() {
+	_, err := BuiltinChangedPaths(s.ctx, s.builtinSite(), s.builtinArgs(map[string]any{}, []any{})...)
+	s.ErrorContains(err, "changed_paths: arguments must both be dicts or both be lists")
+
+	_, err = BuiltinChangedPaths(s.ctx, s.builtinSite(), s.builtinArgs(1)...)
+	s.ErrorContains(err, "changed_paths requires 2 arguments")
+
+	result, err := BuiltinChangedPaths(s.ctx, s.builtinSite(), box.Undefined(), box.Dict(nil))
+	s.NoError(err)
+	s.True(result.IsUndefined())
+}
+
+func (s *RuntimeTestSuite) TestChangedPaths_GuardsProtectedPaths() {
+	exec := s.executorFromSource(`namespace com/example
+policy change {
+  fact before: dict[document]
+  fact after: dict[document]
+  let protected = ["owner.id", "billing.plan"]
+  rule allow = default false {
+    yield not any(changed_paths(before, after), (p) => { yield p in protected })
+  }
+  export decision of allow
+}
+`)
+
+	outputs, err := exec.ExecPolicy(s.ctx, "com/example", "change", map[string]any{
+		"before": map[string]any{"owner": map[string]any{"id": "u1", "name": "a"}},
+		"after":  map[string]any{"owner": map[string]any{"id": "u1", "name": "b"}},
+	})
+	s.Require().NoError(err)
+	s.Equal(trinary.True, outputs[0].ToTrinary())
+
+	outputs, err = exec.ExecPolicy(s.ctx, "com/example", "change", map[string]any{
+		"before": map[string]any{"owner": map[string]any{"id": "u1"}},
+		"after":  map[string]any{"owner": map[string]any{"id": "u2"}},
+	})
+	s.Require().NoError(err)
+	s.Equal(trinary.False, outputs[0].ToTrinary())
+}
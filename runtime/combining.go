@@ -0,0 +1,125 @@
+// SPDX-FileCopyrightText: © 2026 Binaek Sarkar <binaek89@gmail.com>
+// SPDX-License-Identifier: Apache-2.0
+
+package runtime
+
+import (
+	"slices"
+
+	"github.com/sentrie-sh/sentrie/ast"
+	"github.com/sentrie-sh/sentrie/box"
+	"github.com/sentrie-sh/sentrie/index"
+	"github.com/sentrie-sh/sentrie/trinary"
+)
+
+// CombineDecisions combines the decisions of the exported rules of p, as evaluated into
+// outputs, with the combining algorithm p declares. It returns nil when p declares none,
+// leaving each exported decision to stand on its own.
+//
+// The algorithms compose the effects of the decisions rather than their states: a rule without
+// an effect permits when it decides true and denies when it decides false, while a permit or
+// deny rule deciding false does not apply.
+func CombineDecisions(p *index.Policy, outputs []*ExecutorOutput) *Decision {
+	if p == nil || p.Combining == index.CombiningNone {
+		return nil
+	}
+
+	byRule := make(map[string]decisionEffect, len(outputs))
+	for _, output := range outputs {
+		if output == nil || output.Decision == nil {
+			continue
+		}
+		byRule[output.RuleName] = effectOf(output.Effect, output.Decision.State)
+	}
+
+	// the effects in export declaration order, which first-applicable depends on
+	effects := make([]decisionEffect, 0, len(byRule))
+	for _, stmt := range p.Statements {
+		export, ok := stmt.(*ast.RuleExportStatement)
+		if !ok {
+			continue
+		}
+		if effect, ok := byRule[export.Of]; ok {
+			effects = append(effects, effect)
+		}
+	}
+
+	state := combineEffects(p.Combining, effects)
+	return &Decision{State: state, Value: box.Trinary(state)}
+}
+
+// decisionEffect is what a single decision contributes to a combined decision.
+type decisionEffect int
+
+const (
+	effectNotApplicable decisionEffect = iota
+	effectPermit
+	effectDeny
+	effectIndeterminate
+)
+
+func effectOf(effect index.RuleEffect, state trinary.Value) decisionEffect {
+	switch {
+	case state == trinary.Unknown:
+		return effectIndeterminate
+	case effect == index.RuleEffectDeny && state == trinary.True:
+		return effectDeny
+	case effect == index.RuleEffectNone && state == trinary.False:
+		return effectDeny
+	case state == trinary.True:
+		return effectPermit
+	}
+	return effectNotApplicable
+}
+
+// combineEffects combines effects with algorithm. When no decision applies, the combined
+// decision is unknown.
+func combineEffects(algorithm index.CombiningAlgorithm, effects []decisionEffect) trinary.Value {
+	switch algorithm {
+	case index.CombiningDenyOverrides:
+		// a deny wins over an indeterminate decision, which wins over a permit
+		return overriding(effects, effectDeny, effectPermit)
+	case index.CombiningPermitOverrides:
+		// a permit wins over an indeterminate decision, which wins over a deny
+		return overriding(effects, effectPermit, effectDeny)
+	case index.CombiningFirstApplicable:
+		// an indeterminate decision ahead of the first that applies might have applied, so it
+		// decides as unknown rather than letting a later decision through
+		for _, effect := range effects {
+			switch effect {
+			case effectPermit:
+				return trinary.True
+			case effectDeny:
+				return trinary.False
+			case effectIndeterminate:
+				return trinary.Unknown
+			}
+		}
+	}
+	return trinary.Unknown
+}
+
+// overriding is the state of winner when any effect is winner, else unknown when any effect is
+// indeterminate, else the state of loser when any effect is loser.
+func overriding(effects []decisionEffect, winner, loser decisionEffect) trinary.Value {
+	if slices.Contains(effects, winner) {
+		return stateOf(winner)
+	}
+	if slices.Contains(effects, effectIndeterminate) {
+		return trinary.Unknown
+	}
+	if slices.Contains(effects, loser) {
+		return stateOf(loser)
+	}
+	return trinary.Unknown
+}
+
+func stateOf(effect decisionEffect) trinary.Value {
+	switch effect {
+	case effectPermit:
+		return trinary.True
+	case effectDeny:
+		return trinary.False
+	}
+	return trinary.Unknown
+}
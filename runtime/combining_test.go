@@ -0,0 +1,157 @@
+// SPDX-FileCopyrightText: © 2026 Binaek Sarkar <binaek89@gmail.com>
+// SPDX-License-Identifier: Apache-2.0
+
+package runtime
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/sentrie-sh/sentrie/box"
+	"github.com/sentrie-sh/sentrie/index"
+	"github.com/sentrie-sh/sentrie/trinary"
+)
+
+// combiningPolicy exports three rules deciding the facts yes, no and maybe. A fact that is
+// missing leaves its rule unknown.
+func combiningPolicy(algorithm string) string {
+	header := ""
+	if algorithm != "" {
+		header = fmt.Sprintf("  combining %q\n", algorithm)
+	}
+	return `namespace com/example
+policy combined {
+` + header + `  fact yes?: boolean
+  fact no?: boolean
+  fact maybe?: boolean
+  rule a = { yield yes }
+  rule b = { yield no }
+  rule c = { yield maybe }
+  export decision of a
+  export decision of b
+  export decision of c
+}
+`
+}
+
+func (s *RuntimeTestSuite) combinedDecisionOf(algorithm string, facts map[string]any) *Decision {
+	exec := s.executorFromSource(combiningPolicy(algorithm))
+	outputs, err := exec.ExecPolicy(context.Background(), "com/example", "combined", facts)
+	s.Require().NoError(err)
+	s.Require().Len(outputs, 3)
+	p, err := exec.Index().ResolvePolicy("com/example", "combined")
+	s.Require().NoError(err)
+	return CombineDecisions(p, outputs)
+}
+
+func (s *RuntimeTestSuite) TestCombineDecisionsWithoutAlgorithmIsNil() {
+	s.Nil(s.combinedDecisionOf("", map[string]any{"yes": true, "no": false}))
+}
+
+func (s *RuntimeTestSuite) TestCombineDecisionsStrategies() {
+	cases := []struct {
+		name      string
+		algorithm string
+		facts     map[string]any
+		want      trinary.Value
+	}{
+		{"deny-overrides all true", "deny-overrides", map[string]any{"yes": true, "no": true, "maybe": true}, trinary.True},
+		{"deny-overrides false wins over unknown", "deny-overrides", map[string]any{"yes": true, "no": false}, trinary.False},
+		{"deny-overrides unknown wins over true", "deny-overrides", map[string]any{"yes": true, "no": true}, trinary.Unknown},
+		{"permit-overrides all false", "permit-overrides", map[string]any{"yes": false, "no": false, "maybe": false}, trinary.False},
+		{"permit-overrides true wins over unknown", "permit-overrides", map[string]any{"yes": false, "no": true}, trinary.True},
+		{"permit-overrides unknown wins over false", "permit-overrides", map[string]any{"yes": false, "no": false}, trinary.Unknown},
+		{"first-applicable first decides", "first-applicable", map[string]any{"yes": false, "no": true, "maybe": true}, trinary.False},
+		{"first-applicable stops at unknown", "first-applicable", map[string]any{"no": true, "maybe": false}, trinary.Unknown},
+		{"first-applicable all unknown", "first-applicable", map[string]any{}, trinary.Unknown},
+	}
+	for _, tc := range cases {
+		s.Run(tc.name, func() {
+			decision := s.combinedDecisionOf(tc.algorithm, tc.facts)
+			s.Require().NotNil(decision)
+			s.Equal(tc.want, decision.State)
+			s.Equal(box.Trinary(tc.want), decision.Value)
+		})
+	}
+}
+
+func (s *RuntimeTestSuite) TestCombineDecisionsWithoutOutputsIsUnknown() {
+	p := &index.Policy{Combining: index.CombiningPermitOverrides}
+	decision := CombineDecisions(p, nil)
+	s.Require().NotNil(decision)
+	s.Equal(trinary.Unknown, decision.State)
+}
+
+func (s *RuntimeTestSuite) TestEvaluateBatchUsesCombinedDecision() {
+	exec := s.executorFromSource(combiningPolicy("permit-overrides"))
+	result, err := EvaluateBatch(context.Background(), exec, "com/example/combined", []map[string]any{
+		{"yes": true, "no": false, "maybe": false},
+	}, 1)
+	s.Require().NoError(err)
+	s.Require().Len(result.Items, 1)
+	s.Equal(trinary.True, result.Items[0].Outcome)
+}
+
+// effectsPolicy exports a permit rule for admins and a deny rule for blocked users.
+func effectsPolicy(algorithm string) string {
+	header := ""
+	if algorithm != "" {
+		header = fmt.Sprintf("  combining %q\n", algorithm)
+	}
+	return `namespace com/example
+policy guarded {
+` + header + `  fact role?: string
+  fact blocked?: boolean
+  rule admin = permit { yield role == "admin" }
+  rule block = deny { yield blocked }
+  export decision of admin
+  export decision of block
+}
+`
+}
+
+func (s *RuntimeTestSuite) TestCombineDecisionsComposesEffects() {
+	cases := []struct {
+		name      string
+		algorithm string
+		facts     map[string]any
+		want      trinary.Value
+	}{
+		{"deny-overrides permit applies", "deny-overrides", map[string]any{"role": "admin", "blocked": false}, trinary.True},
+		{"deny-overrides deny wins", "deny-overrides", map[string]any{"role": "admin", "blocked": true}, trinary.False},
+		{"deny-overrides nothing applies", "deny-overrides", map[string]any{"role": "user", "blocked": false}, trinary.Unknown},
+		{"deny-overrides indeterminate wins over permit", "deny-overrides", map[string]any{"role": "admin"}, trinary.Unknown},
+		{"permit-overrides permit wins", "permit-overrides", map[string]any{"role": "admin", "blocked": true}, trinary.True},
+		{"permit-overrides deny applies", "permit-overrides", map[string]any{"role": "user", "blocked": true}, trinary.False},
+		{"first-applicable skips not applicable", "first-applicable", map[string]any{"role": "user", "blocked": true}, trinary.False},
+	}
+	for _, tc := range cases {
+		s.Run(tc.name, func() {
+			exec := s.executorFromSource(effectsPolicy(tc.algorithm))
+			outputs, err := exec.ExecPolicy(context.Background(), "com/example", "guarded", tc.facts)
+			s.Require().NoError(err)
+			p, err := exec.Index().ResolvePolicy("com/example", "guarded")
+			s.Require().NoError(err)
+			decision := CombineDecisions(p, outputs)
+			s.Require().NotNil(decision)
+			s.Equal(tc.want, decision.State)
+		})
+	}
+}
+
+func (s *RuntimeTestSuite) TestExecutorOutputPermitsUnderEffect() {
+	exec := s.executorFromSource(effectsPolicy(""))
+	output, err := exec.ExecRule(context.Background(), "com/example", "guarded", "block", map[string]any{"blocked": true})
+	s.Require().NoError(err)
+	s.Equal(index.RuleEffectDeny, output.Effect)
+	s.Equal(trinary.True, output.ToTrinary())
+	s.Equal(trinary.False, output.Permits())
+
+	// without a combining algorithm, a batch item fails when a deny rule decides true
+	result, err := EvaluateBatch(context.Background(), exec, "com/example/guarded", []map[string]any{
+		{"role": "admin", "blocked": true},
+	}, 1)
+	s.Require().NoError(err)
+	s.Require().Len(result.Items, 1)
+	s.Equal(trinary.False, result.Items[0].Outcome)
+}
@@ -0,0 +1,313 @@
+// SPDX-FileCopyrightText: © 2026 Binaek Sarkar <binaek89@gmail.com>
+// SPDX-License-Identifier: Apache-2.0
+
+package runtime
+
+import (
+	"context"
+
+	"github.com/sentrie-sh/sentrie/ast"
+	"github.com/sentrie-sh/sentrie/box"
+	"github.com/sentrie-sh/sentrie/index"
+	"github.com/sentrie-sh/sentrie/runtime/trace"
+	"github.com/sentrie-sh/sentrie/trinary"
+)
+
+// WithCompileAfter compiles the rule expressions of a policy once it has been evaluated more
+// than evaluations times. A compiled expression is a flat list of operations run by a small
+// stack machine, which evaluates to the same values as walking the tree but records a single
+// trace node for the whole expression, unless the context asks for the full trace with
+// WithFullTrace. Zero, the default, never compiles.
+func WithCompileAfter(evaluations int) NewExecutorOption {
+	return func(e *executorImpl) {
+		e.compileAfter = int64(evaluations)
+	}
+}
+
+type fullTraceKey struct{}
+
+// WithFullTrace returns a context under which rule expressions are always evaluated by walking
+// the tree, so that the trace has a node for every sub-expression, and the expressions skipped by
+// evaluation appear in it as not-evaluated nodes.
+func WithFullTrace(ctx context.Context) context.Context {
+	return context.WithValue(ctx, fullTraceKey{}, true)
+}
+
+func wantsFullTrace(ctx context.Context) bool {
+	full, _ := ctx.Value(fullTraceKey{}).(bool)
+	return full
+}
+
+// attachNotEvaluated attaches a not-evaluated node for each of the skipped expressions to n, when
+// ctx asks for the full trace.
+func attachNotEvaluated(ctx context.Context, n *trace.Node, skipped ...ast.Node) {
+	if !wantsFullTrace(ctx) {
+		return
+	}
+	for _, expr := range skipped {
+		n.Attach(trace.NotEvaluated(expr))
+	}
+}
+
+// evalRuleExpression evaluates expr, the when, default or body of a rule of p: compiled once p
+// is hot, and otherwise by walking the tree.
+func evalRuleExpression(ctx context.Context, ec *ExecutionContext, exec *executorImpl, p *index.Policy, expr ast.Expression) (box.Value, *trace.Node, error) {
+	if exec.compileAfter <= 0 || p.Evaluations() <= exec.compileAfter || wantsFullTrace(ctx) {
+		return eval(ctx, ec, exec, p, expr)
+	}
+	compiled := p.Compiled(expr, func() any { return compileExpression(expr) }).(*compiledExpression)
+
+	ctx, n, done := trace.New(ctx, expr, "compiled", map[string]any{})
+	defer done()
+	v, err := compiled.run(ctx, ec, exec, p)
+	if err != nil {
+		return v, n.SetErr(err), err
+	}
+	return v, n.SetResult(v), nil
+}
+
+// opcode is an operation of a compiled expression.
+type opcode uint8
+
+const (
+	// opPush pushes value
+	opPush opcode = iota
+	// opIdent pushes the value of the identifier expr
+	opIdent
+	// opEval pushes the value of expr, evaluated by walking the tree
+	opEval
+	// opUnary replaces the top of the stack with unary applied to it
+	opUnary
+	// opInfix replaces the top two values of the stack with infix applied to them
+	opInfix
+	// opCoalesce jumps to target, keeping the top of the stack, when 'top ?: …' is top, and
+	// otherwise pops it
+	opCoalesce
+	// opShortCircuit replaces the top of the stack with the value of infix and jumps to target
+	// when the top decides infix
+	opShortCircuit
+	// opBranch pops a condition and continues when it is true, jumps to target when it is false,
+	// and otherwise pushes unknown and jumps to end
+	opBranch
+	// opJump jumps to target
+	opJump
+	// opEnterBlock enters a child context declaring the lets of block
+	opEnterBlock
+	// opLeaveBlock returns to the parent context
+	opLeaveBlock
+)
+
+type instruction struct {
+	op     opcode
+	value  box.Value
+	expr   ast.Expression
+	unary  *ast.UnaryExpression
+	infix  *ast.InfixExpression
+	block  *ast.BlockExpression
+	target int
+	end    int
+
+	// errValue is what the expression evaluates to when the instruction fails, which is decided
+	// by the outermost expression enclosing the instruction that replaces the value of a failed
+	// operand. Without one, passthrough is set and the expression evaluates to the value the
+	// instruction failed with.
+	errValue    box.Value
+	passthrough bool
+}
+
+// compiledExpression is an expression lowered to a flat list of instructions.
+type compiledExpression struct {
+	instructions []instruction
+}
+
+// compiler lowers an expression to instructions.
+type compiler struct {
+	instructions []instruction
+	// errValue is the errValue of the instructions being emitted, nil for passthrough
+	errValue *box.Value
+}
+
+// compileExpression lowers expr to instructions. Literals, identifiers, unary, infix and ternary
+// expressions and blocks are lowered; every other expression is evaluated by walking its tree.
+func compileExpression(expr ast.Expression) *compiledExpression {
+	expressionCompiled(expr)
+	c := &compiler{}
+	c.compile(expr)
+	return &compiledExpression{instructions: c.instructions}
+}
+
+// expressionCompiled is called every time an expression is compiled. Overridable in tests.
+var expressionCompiled = func(expr ast.Expression) {}
+
+// emit appends in and returns its position.
+func (c *compiler) emit(in instruction) int {
+	if c.errValue == nil {
+		in.passthrough = true
+	} else {
+		in.errValue = *c.errValue
+	}
+	c.instructions = append(c.instructions, in)
+	return len(c.instructions) - 1
+}
+
+// enclose compiles the operands of an expression evaluating to errValue when an operand fails,
+// unless an expression enclosing it already decides that.
+func (c *compiler) enclose(errValue box.Value, compile func()) {
+	if c.errValue != nil {
+		compile()
+		return
+	}
+	c.errValue = &errValue
+	defer func() { c.errValue = nil }()
+	compile()
+}
+
+func (c *compiler) compile(expr ast.Expression) {
+	switch t := expr.(type) {
+	case *ast.PrecedingCommentExpression:
+		c.compile(t.Wrap)
+	case *ast.TrailingCommentExpression:
+		c.compile(t.Wrap)
+	case *ast.NullLiteral:
+		c.emit(instruction{op: opPush, value: box.Null()})
+	case *ast.TrinaryLiteral:
+		c.emit(instruction{op: opPush, value: box.Trinary(t.Value)})
+	case *ast.IntegerLiteral:
+		c.emit(instruction{op: opPush, value: box.Number(t.Value)})
+	case *ast.FloatLiteral:
+		c.emit(instruction{op: opPush, value: box.Number(t.Value)})
+	case *ast.StringLiteral:
+		c.emit(instruction{op: opPush, value: box.String(t.Value)})
+	case *ast.Identifier:
+		c.emit(instruction{op: opIdent, expr: t})
+	case *ast.UnaryExpression:
+		c.enclose(box.Value{}, func() { c.compile(t.Right) })
+		c.emit(instruction{op: opUnary, unary: t})
+	case *ast.InfixExpression:
+		c.compileInfix(t)
+	case *ast.TernaryExpression:
+		c.enclose(box.Value{}, func() { c.compile(t.Condition) })
+		branch := c.emit(instruction{op: opBranch})
+		c.compile(t.ThenBranch)
+		jump := c.emit(instruction{op: opJump})
+		c.instructions[branch].target = len(c.instructions)
+		c.compile(t.ElseBranch)
+		c.instructions[jump].target = len(c.instructions)
+		c.instructions[branch].end = len(c.instructions)
+	case *ast.BlockExpression:
+		if t.Yield == nil {
+			c.emit(instruction{op: opEval, expr: t})
+			return
+		}
+		c.emit(instruction{op: opEnterBlock, block: t})
+		c.enclose(box.Undefined(), func() { c.compile(t.Yield) })
+		c.emit(instruction{op: opLeaveBlock})
+	default:
+		c.emit(instruction{op: opEval, expr: expr})
+	}
+}
+
+func (c *compiler) compileInfix(in *ast.InfixExpression) {
+	switch in.Operator {
+	case "?:":
+		var coalesce int
+		c.enclose(box.Undefined(), func() {
+			c.compile(in.Left)
+			coalesce = c.emit(instruction{op: opCoalesce})
+			c.compile(in.Right)
+		})
+		c.instructions[coalesce].target = len(c.instructions)
+	case "and", "or":
+		var shortCircuit int
+		c.enclose(box.Undefined(), func() {
+			c.compile(in.Left)
+			shortCircuit = c.emit(instruction{op: opShortCircuit, infix: in})
+			c.compile(in.Right)
+		})
+		c.emit(instruction{op: opInfix, infix: in})
+		c.instructions[shortCircuit].target = len(c.instructions)
+	default:
+		c.enclose(box.Undefined(), func() {
+			c.compile(in.Left)
+			c.compile(in.Right)
+		})
+		c.emit(instruction{op: opInfix, infix: in})
+	}
+}
+
+// run evaluates the compiled expression. It evaluates to the same value and error as walking
+// the tree would, as every operation is carried out by the functions the tree-walker uses.
+func (c *compiledExpression) run(ctx context.Context, ec *ExecutionContext, exec *executorImpl, p *index.Policy) (box.Value, error) {
+	stack := make([]box.Value, 0, 8)
+	for pc := 0; pc < len(c.instructions); pc++ {
+		in := &c.instructions[pc]
+		var v box.Value
+		var err error
+		switch in.op {
+		case opPush:
+			stack = append(stack, in.value)
+		case opIdent:
+			if v, _, err = evalIdent(ctx, ec, exec, p, in.expr.(*ast.Identifier)); err == nil {
+				stack = append(stack, v)
+			}
+		case opEval:
+			if v, _, err = eval(ctx, ec, exec, p, in.expr); err == nil {
+				stack = append(stack, v)
+			}
+		case opUnary:
+			top := len(stack) - 1
+			if v, err = applyUnary(in.unary, stack[top]); err == nil {
+				stack[top] = v
+			}
+		case opInfix:
+			top := len(stack) - 1
+			if v, err = applyInfix(exec, in.infix, stack[top-1], stack[top]); err == nil {
+				stack = stack[:top]
+				stack[top-1] = v
+			}
+		case opCoalesce:
+			if coalesced(stack[len(stack)-1]) {
+				pc = in.target - 1
+			} else {
+				stack = stack[:len(stack)-1]
+			}
+		case opShortCircuit:
+			if out, ok := shortCircuit(in.infix.Operator, stack[len(stack)-1]); ok {
+				stack[len(stack)-1] = out
+				pc = in.target - 1
+			}
+		case opBranch:
+			condition := stack[len(stack)-1]
+			stack = stack[:len(stack)-1]
+			switch box.TrinaryFrom(condition) {
+			case trinary.True:
+			case trinary.False:
+				pc = in.target - 1
+			default:
+				stack = append(stack, box.Trinary(trinary.Unknown))
+				pc = in.end - 1
+			}
+		case opJump:
+			pc = in.target - 1
+		case opEnterBlock:
+			ec = ec.AttachedChildContext()
+			v = box.Undefined()
+			for _, stmt := range in.block.Statements {
+				if let, ok := stmt.(*ast.VarDeclaration); ok {
+					if err = ec.InjectLet(let.Name, let); err != nil {
+						break
+					}
+				}
+			}
+		case opLeaveBlock:
+			ec = ec.parent
+		}
+		if err != nil {
+			if in.passthrough {
+				return v, err
+			}
+			return in.errValue, err
+		}
+	}
+	return stack[0], nil
+}
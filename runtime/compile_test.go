@@ -0,0 +1,224 @@
+// SPDX-FileCopyrightText: © 2026 Binaek Sarkar <binaek89@gmail.com>
+// SPDX-License-Identifier: Apache-2.0
+
+package runtime
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"math/rand"
+	"slices"
+	"testing"
+
+	"github.com/sentrie-sh/sentrie/ast"
+	"github.com/sentrie-sh/sentrie/box"
+	"github.com/sentrie-sh/sentrie/runtime/trace"
+	"github.com/sentrie-sh/sentrie/trinary"
+)
+
+// compileFacts are the facts the randomized expressions of compileSource refer to.
+var compileFacts = []string{"a", "b", "c"}
+
+// compileSource builds random expressions over compileFacts, and random values for them.
+type compileSource struct {
+	rnd *rand.Rand
+}
+
+func (g *compileSource) value() box.Value {
+	switch g.rnd.Intn(8) {
+	case 0:
+		return box.Undefined()
+	case 1:
+		return box.Null()
+	case 2:
+		return box.Trinary(trinary.Value(g.rnd.Intn(3) - 1))
+	case 3:
+		return box.String(fmt.Sprintf("s%d", g.rnd.Intn(3)))
+	case 4:
+		return box.Number(g.rnd.Float64()*10 - 5)
+	default:
+		return box.Number(float64(g.rnd.Intn(7) - 3))
+	}
+}
+
+func (g *compileSource) literal() ast.Expression {
+	switch g.rnd.Intn(6) {
+	case 0:
+		return ast.NewNullLiteral(stubRange())
+	case 1:
+		return ast.NewTrinaryLiteral(trinary.Value(g.rnd.Intn(3)-1), stubRange())
+	case 2:
+		return ast.NewStringLiteral(fmt.Sprintf("s%d", g.rnd.Intn(3)), stubRange())
+	case 3:
+		return ast.NewFloatLiteral(float64(g.rnd.Intn(40))/8, stubRange())
+	default:
+		return ast.NewIntegerLiteral(int64(g.rnd.Intn(7)-3), stubRange())
+	}
+}
+
+var compileOperators = []string{
+	"+", "-", "*", "/", "%", "==", "!=", "<", "<=", ">", ">=", "and", "or", "xor", "?:", "is",
+}
+
+// expression returns a random expression of at most depth levels, with lets holding the names
+// a block declares around it.
+func (g *compileSource) expression(depth int, lets []string) ast.Expression {
+	if depth == 0 || g.rnd.Intn(5) == 0 {
+		switch g.rnd.Intn(8) {
+		case 0, 1, 2:
+			return g.literal()
+		case 3:
+			// an identifier nothing declares
+			if g.rnd.Intn(4) == 0 {
+				return ast.NewIdentifier("missing", stubRange())
+			}
+			fallthrough
+		default:
+			names := append(append([]string{}, compileFacts...), lets...)
+			return ast.NewIdentifier(names[g.rnd.Intn(len(names))], stubRange())
+		}
+	}
+	sub := func() ast.Expression { return g.expression(depth-1, lets) }
+	switch g.rnd.Intn(9) {
+	case 0:
+		return ast.NewUnaryExpression([]string{"!", "not", "-", "+"}[g.rnd.Intn(4)], sub(), stubRange())
+	case 1:
+		return ast.NewTernaryExpression(sub(), sub(), sub(), stubRange())
+	case 2:
+		name := fmt.Sprintf("l%d", depth)
+		let := ast.NewVarDeclaration(name, nil, sub(), stubRange())
+		yield := g.expression(depth-1, append(append([]string{}, lets...), name))
+		return ast.NewBlockExpression([]ast.Statement{let}, yield, stubRange())
+	case 3:
+		// evaluated by walking the tree even when compiled
+		return ast.NewListLiteral([]ast.Expression{sub(), sub()}, stubRange())
+	default:
+		return ast.NewInfixExpression(sub(), sub(), compileOperators[g.rnd.Intn(len(compileOperators))], stubRange())
+	}
+}
+
+func (s *RuntimeTestSuite) TestCompiledExpressionsEvaluateAsTheTree() {
+	g := &compileSource{rnd: rand.New(rand.NewSource(556))}
+	p := newEvalTestPolicy()
+	exec := &executorImpl{}
+	errors := 0
+
+	for i := range 3000 {
+		expr := g.expression(5, nil)
+		compiled := compileExpression(expr)
+		for range 4 {
+			facts := map[string]box.Value{}
+			for _, name := range compileFacts {
+				facts[name] = g.value()
+			}
+			context := func() *ExecutionContext {
+				ec := NewExecutionContext(p, exec)
+				for name, v := range facts {
+					s.Require().NoError(ec.InjectFact(s.T().Context(), name, v, false, nil))
+				}
+				return ec
+			}
+
+			want, _, wantErr := eval(s.T().Context(), context(), exec, p, expr)
+			got, gotErr := compiled.run(s.T().Context(), context(), exec, p)
+
+			msg := fmt.Sprintf("#%d %s with %v", i, expr, facts)
+			if wantErr != nil {
+				errors++
+				s.Require().Error(gotErr, msg)
+				s.Require().Equal(wantErr.Error(), gotErr.Error(), msg)
+			} else {
+				s.Require().NoError(gotErr, msg)
+			}
+			s.Require().Equal(want.Kind(), got.Kind(), msg)
+			s.Require().Equal(want.String(), got.String(), msg)
+			if n, ok := want.NumberValue(); ok {
+				m, _ := got.NumberValue()
+				s.Require().Equal(math.Float64bits(n), math.Float64bits(m), msg)
+			}
+		}
+	}
+	s.Positive(errors, "no expression failed")
+}
+
+const compilePolicy = `namespace com/example
+policy access {
+  fact role: string
+  fact level: number
+  rule allowed = default false when level > 0 { yield role == "admin" or (level >= 3 and role != "guest") }
+  export decision of allowed
+}
+`
+
+func (s *RuntimeTestSuite) TestRulesAreCompiledOnceThePolicyIsHot() {
+	compilations := 0
+	original := expressionCompiled
+	expressionCompiled = func(ast.Expression) { compilations++ }
+	s.T().Cleanup(func() { expressionCompiled = original })
+
+	exec := s.executorFromSource(compilePolicy).(*executorImpl)
+	WithCompileAfter(2)(exec)
+
+	// each expression is compiled the first time it is evaluated once the policy is hot: the
+	// when and the body on the third evaluation, and the default on the fourth
+	for i, tc := range []struct {
+		facts        map[string]any
+		compilations int
+	}{
+		{map[string]any{"role": "admin", "level": 1}, 0},
+		{map[string]any{"role": "dev", "level": 3}, 0},
+		{map[string]any{"role": "guest", "level": 5}, 2},
+		{map[string]any{"role": "dev", "level": 0}, 3},
+		{map[string]any{"role": "admin", "level": 2}, 3},
+	} {
+		facts := tc.facts
+		output, err := exec.ExecRule(s.T().Context(), "com/example", "access", "allowed", facts)
+		s.Require().NoError(err)
+		want := facts["level"].(int) > 0 && (facts["role"] == "admin" || facts["level"].(int) >= 3 && facts["role"] != "guest")
+		s.Equal(trinary.From(want), output.ToTrinary(), "%v", facts)
+
+		s.Equal(tc.compilations, compilations, "evaluation %d", i+1)
+		s.Equal(i >= 2, hasCompiledNode(output.RuleNode), "evaluation %d", i+1)
+	}
+
+	// an explained evaluation traces every sub-expression
+	output, err := exec.ExecRule(WithFullTrace(s.T().Context()), "com/example", "access", "allowed", map[string]any{"role": "dev", "level": 4})
+	s.Require().NoError(err)
+	s.Equal(trinary.True, output.ToTrinary())
+	s.False(hasCompiledNode(output.RuleNode))
+	s.Equal(3, compilations)
+}
+
+func hasCompiledNode(n *trace.Node) bool {
+	if n == nil {
+		return false
+	}
+	if n.Op == "compiled" {
+		return true
+	}
+	return slices.ContainsFunc(n.Children, hasCompiledNode)
+}
+
+func BenchmarkCompiledRule(b *testing.B) {
+	benchmarkRule(b, 1)
+}
+
+// BenchmarkCompiledRuleTreeBaseline walks the tree of the rule on every evaluation.
+func BenchmarkCompiledRuleTreeBaseline(b *testing.B) {
+	benchmarkRule(b, 0)
+}
+
+func benchmarkRule(b *testing.B, compileAfter int) {
+	exec := benchmarkExecutor(b, compilePolicy).(*executorImpl)
+	WithCompileAfter(compileAfter)(exec)
+	facts := map[string]any{"role": "dev", "level": 3}
+	ctx := context.Background()
+
+	b.ReportAllocs()
+	for b.Loop() {
+		if _, err := exec.ExecRule(ctx, "com/example", "access", "allowed", facts); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
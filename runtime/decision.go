@@ -32,6 +32,13 @@ func (d Decision) ToTrinary() trinary.Value {
 
 type DecisionAttachments map[string]box.Value
 
+// Directive is an evaluated obligation or advice of an exported decision. An obligation must
+// be fulfilled by the caller enforcing the decision, an advice may be acted upon.
+type Directive struct {
+	Name  string    `json:"name"`
+	Value box.Value `json:"value"`
+}
+
 // Behaviour:
 // - nil           → Unknown
 // - *Decision     → as-is
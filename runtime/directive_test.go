@@ -0,0 +1,73 @@
+// SPDX-FileCopyrightText: © 2026 Binaek Sarkar <binaek89@gmail.com>
+// SPDX-License-Identifier: Apache-2.0
+
+package runtime
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/sentrie-sh/sentrie/box"
+)
+
+const directivesPolicy = `namespace com/example
+policy audited {
+  fact user: document
+  shape LogEntry {
+    action: string
+    level: number
+  }
+  rule allow = { yield user.role == "admin" }
+  export decision of allow
+    attach reason as "admins only"
+    obligation "log": LogEntry = { "action": "access", "level": user.level }
+    advice "hint" = "prefer read-only access"
+}
+`
+
+func (s *RuntimeTestSuite) TestExportedRuleSeparatesObligationsAndAdvice() {
+	exec := s.executorFromSource(directivesPolicy)
+	output, err := exec.ExecRule(context.Background(), "com/example", "audited", "allow", map[string]any{
+		"user": map[string]any{"role": "admin", "level": 2},
+	})
+	s.Require().NoError(err)
+
+	s.Len(output.Attachments, 1)
+	s.Contains(output.Attachments, "reason")
+
+	outcome := NewResult([]*ExecutorOutput{output}, nil, false, false).Outcomes[0]
+	raw, err := json.Marshal(outcome)
+	s.Require().NoError(err)
+	s.JSONEq(`{
+		"namespace": "com/example",
+		"policy": "audited",
+		"rule": "allow",
+		"decision": {"state": "true", "value": true},
+		"attachments": {"reason": "admins only"},
+		"obligations": [{"name": "log", "value": {"action": "access", "level": 2}}],
+		"advice": [{"name": "hint", "value": "prefer read-only access"}]
+	}`, string(raw))
+}
+
+func (s *RuntimeTestSuite) TestObligationNotConformingToItsShapeFails() {
+	exec := s.executorFromSource(directivesPolicy)
+	_, err := exec.ExecRule(context.Background(), "com/example", "audited", "allow", map[string]any{
+		"user": map[string]any{"role": "admin", "level": "high"},
+	})
+	s.Require().Error(err)
+}
+
+func (s *RuntimeTestSuite) TestDirectivesAreRedactedWhenSensitive() {
+	exec := s.executorFromSource(`namespace com/example
+policy audited {
+  fact sensitive token: string
+  rule allow = { yield true }
+  export decision of allow
+    obligation "forward" = token
+}
+`)
+	output, err := exec.ExecRule(context.Background(), "com/example", "audited", "allow", map[string]any{"token": "s3cret"})
+	s.Require().NoError(err)
+	s.Require().Len(output.Obligations, 1)
+	s.Equal(box.String(Redacted), output.Obligations[0].Value)
+}
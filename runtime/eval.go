@@ -62,6 +62,9 @@ func eval(ctx context.Context, ec *ExecutionContext, exec *executorImpl, p *inde
 		n.SetResult(v)
 		return v, n, nil
 
+	case *ast.InterpolatedString:
+		return evalInterpolatedString(ctx, ec, exec, p, t)
+
 	case *ast.ListLiteral:
 		ctx, n, done := trace.New(ctx, t, "literal", map[string]any{"type": "list"})
 		defer done()
@@ -118,6 +121,9 @@ func eval(ctx context.Context, ec *ExecutionContext, exec *executorImpl, p *inde
 	case *ast.UnaryExpression:
 		return evalUnary(ctx, ec, exec, p, t)
 
+	case *ast.IsDefinedExpression:
+		return evalIsDefined(ctx, ec, exec, p, t)
+
 	case *ast.InfixExpression:
 		return evalInfix(ctx, ec, exec, p, t)
 
@@ -130,6 +136,9 @@ func eval(ctx context.Context, ec *ExecutionContext, exec *executorImpl, p *inde
 	case *ast.IndexAccessExpression:
 		return evalIndexAccess(ctx, ec, exec, p, t)
 
+	case *ast.SliceExpression:
+		return evalSlice(ctx, ec, exec, p, t)
+
 	case *ast.CallExpression:
 		return evalCall(ctx, ec, exec, p, t)
 
@@ -138,6 +147,8 @@ func eval(ctx context.Context, ec *ExecutionContext, exec *executorImpl, p *inde
 
 	case *ast.TernaryExpression:
 		return evalTernary(ctx, ec, exec, p, t)
+	case *ast.MatchExpression:
+		return evalMatch(ctx, ec, exec, p, t)
 
 	case *ast.LambdaExpression:
 		return evalLambda(ctx, ec, exec, p, t)
@@ -145,6 +156,9 @@ func eval(ctx context.Context, ec *ExecutionContext, exec *executorImpl, p *inde
 	case *ast.TransformExpression:
 		return evalTransform(ctx, ec, exec, p, t)
 
+	case *ast.ProjectExpression:
+		return evalProject(ctx, ec, exec, p, t)
+
 	default:
 		err := fmt.Errorf("unsupported expression node: %T", t)
 		return box.Undefined(), trace.UnsupportedExpression(t).SetErr(err), err
@@ -27,40 +27,111 @@ import (
 )
 
 func evalFieldAccess(ctx context.Context, ec *ExecutionContext, exec *executorImpl, p *index.Policy, t *ast.FieldAccessExpression) (box.Value, *trace.Node, error) {
+	out, node, _, err := evalFieldAccessLink(ctx, ec, exec, p, t)
+	return out, node, err
+}
+
+func evalIndexAccess(ctx context.Context, ec *ExecutionContext, exec *executorImpl, p *index.Policy, t *ast.IndexAccessExpression) (box.Value, *trace.Node, error) {
+	out, node, _, err := evalIndexAccessLink(ctx, ec, exec, p, t)
+	return out, node, err
+}
+
+// evalChainReceiver evaluates the receiver of a field or index access. When the receiver is
+// itself an access whose optional `?.` link short-circuited, shorted is true and the caller
+// yields null without accessing anything, so `a?.b.c` is null when a is.
+func evalChainReceiver(ctx context.Context, ec *ExecutionContext, exec *executorImpl, p *index.Policy, expr ast.Expression) (box.Value, *trace.Node, bool, error) {
+	switch t := expr.(type) {
+	case *ast.FieldAccessExpression:
+		return evalFieldAccessLink(ctx, ec, exec, p, t)
+	case *ast.IndexAccessExpression:
+		return evalIndexAccessLink(ctx, ec, exec, p, t)
+	}
+	out, node, err := eval(ctx, ec, exec, p, expr)
+	return out, node, false, err
+}
+
+func evalFieldAccessLink(ctx context.Context, ec *ExecutionContext, exec *executorImpl, p *index.Policy, t *ast.FieldAccessExpression) (box.Value, *trace.Node, bool, error) {
 	ctx, node, done := trace.New(ctx, t, "field_access", map[string]any{
-		"field": t.Field,
+		"field":    t.Field,
+		"optional": t.Optional,
 	})
 	defer done()
 
-	recv, rn, err := eval(ctx, ec, exec, p, t.Left)
+	recv, rn, shorted, err := evalChainReceiver(ctx, ec, exec, p, t.Left)
 	if err != nil {
-		return box.Value{}, node.SetErr(err), err
+		return box.Value{}, node.SetErr(err), false, err
 	}
 	node.Attach(rn)
+	if shorted || (t.Optional && (recv.IsNull() || recv.IsUndefined())) {
+		node.SetResult(box.Null())
+		return box.Null(), node, true, nil
+	}
 	out, err := accessField(ctx, recv, t.Field)
 	node.SetResult(out).SetErr(err)
-	return out, node, err
+	return out, node, false, err
 }
 
-func evalIndexAccess(ctx context.Context, ec *ExecutionContext, exec *executorImpl, p *index.Policy, t *ast.IndexAccessExpression) (box.Value, *trace.Node, error) {
+func evalIndexAccessLink(ctx context.Context, ec *ExecutionContext, exec *executorImpl, p *index.Policy, t *ast.IndexAccessExpression) (box.Value, *trace.Node, bool, error) {
 	ctx, node, done := trace.New(ctx, t, "index_access", map[string]any{
 		"index": t.Index,
 	})
 	defer done()
 
-	col, cn, err := eval(ctx, ec, exec, p, t.Left)
+	col, cn, shorted, err := evalChainReceiver(ctx, ec, exec, p, t.Left)
 	if err != nil {
-		return box.Value{}, node.SetErr(err), err
+		return box.Value{}, node.SetErr(err), false, err
 	}
 	node.Attach(cn)
+	if shorted {
+		node.SetResult(box.Null())
+		return box.Null(), node, true, nil
+	}
 
 	idx, in, err := eval(ctx, ec, exec, p, t.Index)
 	node.Attach(in)
 	if err != nil {
-		return box.Value{}, node.SetErr(err), err
+		return box.Value{}, node.SetErr(err), false, err
 	}
 	out, err := accessIndex(ctx, col, idx)
 	node.SetResult(out).SetErr(err)
+	return out, node, false, err
+}
+
+func evalSlice(ctx context.Context, ec *ExecutionContext, exec *executorImpl, p *index.Policy, t *ast.SliceExpression) (box.Value, *trace.Node, error) {
+	ctx, node, done := trace.New(ctx, t, "slice", map[string]any{
+		"start": t.Start,
+		"end":   t.End,
+	})
+	defer done()
+
+	col, cn, err := eval(ctx, ec, exec, p, t.Left)
+	if err != nil {
+		return box.Value{}, node.SetErr(err), err
+	}
+	node.Attach(cn)
+
+	// an omitted bound is the start or the end of the list
+	bounds := [2]*int{}
+	for i, expr := range []ast.Expression{t.Start, t.End} {
+		if expr == nil {
+			continue
+		}
+		v, bn, err := eval(ctx, ec, exec, p, expr)
+		node.Attach(bn)
+		if err != nil {
+			return box.Value{}, node.SetErr(err), err
+		}
+		n, ok := v.NumberValue()
+		if !ok {
+			err := fmt.Errorf("slice bound must be a number, got %s", v.Kind())
+			return box.Value{}, node.SetErr(err), err
+		}
+		b := int(n)
+		bounds[i] = &b
+	}
+
+	out, err := sliceList(ctx, col, bounds[0], bounds[1])
+	node.SetResult(out).SetErr(err)
 	return out, node, err
 }
 
@@ -131,3 +202,46 @@ func accessIndex(_ context.Context, col box.Value, idx box.Value) (box.Value, er
 	}
 	return box.Value{}, fmt.Errorf("index access not supported on %T", col)
 }
+
+// sliceList returns the elements of col from start up to but excluding end. A nil start or
+// end is the beginning or the end of the list, a negative bound counts from the end, and a
+// bound past either end is clamped to it, so an out-of-range slice is empty rather than an error.
+func sliceList(_ context.Context, col box.Value, start, end *int) (box.Value, error) {
+	if col.IsUndefined() {
+		return box.Undefined(), nil
+	}
+	if c, ok := col.ListValue(); ok {
+		from, to := sliceBounds(len(c), start, end)
+		return box.List(append([]box.Value(nil), c[from:to]...)), nil
+	}
+	if ref, ok := col.ObjectRef(); ok {
+		if c, ok := ref.([]any); ok {
+			from, to := sliceBounds(len(c), start, end)
+			out := make([]box.Value, 0, to-from)
+			for _, v := range c[from:to] {
+				out = append(out, box.FromBoundaryAny(v))
+			}
+			return box.List(out), nil
+		}
+	}
+	return box.Value{}, fmt.Errorf("slice not supported on %s", col.Kind())
+}
+
+// sliceBounds resolves optional, possibly negative, slice bounds against a list of length n
+func sliceBounds(n int, start, end *int) (int, int) {
+	resolve := func(b *int, dflt int) int {
+		if b == nil {
+			return dflt
+		}
+		i := *b
+		if i < 0 {
+			i += n
+		}
+		return min(max(i, 0), n)
+	}
+	from, to := resolve(start, 0), resolve(end, n)
+	if from > to {
+		from = to
+	}
+	return from, to
+}
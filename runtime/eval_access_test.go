@@ -19,6 +19,7 @@ package runtime
 import (
 	"context"
 
+	"github.com/sentrie-sh/sentrie/ast"
 	"github.com/sentrie-sh/sentrie/box"
 )
 
@@ -46,3 +47,98 @@ func (s *RuntimeTestSuite) TestAccessIndexMapAnyMissingKeyReturnsUndefined() {
 	s.Require().NoError(err)
 	s.Require().True(out.IsUndefined())
 }
+
+func (s *RuntimeTestSuite) TestSliceListBounds() {
+	col := box.List([]box.Value{box.Number(0), box.Number(1), box.Number(2), box.Number(3), box.Number(4)})
+	bound := func(i int) *int { return &i }
+
+	cases := []struct {
+		name       string
+		start, end *int
+		want       []any
+	}{
+		{"closed", bound(1), bound(3), []any{1.0, 2.0}},
+		{"open start", nil, bound(2), []any{0.0, 1.0}},
+		{"open end", bound(3), nil, []any{3.0, 4.0}},
+		{"whole", nil, nil, []any{0.0, 1.0, 2.0, 3.0, 4.0}},
+		{"negative start", bound(-2), nil, []any{3.0, 4.0}},
+		{"negative end", nil, bound(-1), []any{0.0, 1.0, 2.0, 3.0}},
+		{"end past length clamps", bound(3), bound(99), []any{3.0, 4.0}},
+		{"start before zero clamps", bound(-99), bound(1), []any{0.0}},
+		{"start after end is empty", bound(4), bound(2), []any{}},
+	}
+	for _, tc := range cases {
+		out, err := sliceList(context.Background(), col, tc.start, tc.end)
+		s.Require().NoError(err, tc.name)
+		s.Equal(tc.want, out.Any(), tc.name)
+	}
+}
+
+func (s *RuntimeTestSuite) TestSliceListOverBoundaryList() {
+	start := 1
+	out, err := sliceList(context.Background(), box.Object([]any{"a", "b", "c"}), &start, nil)
+	s.Require().NoError(err)
+	s.Equal([]any{"b", "c"}, out.Any())
+}
+
+func (s *RuntimeTestSuite) TestSliceListUndefinedAndUnsupported() {
+	out, err := sliceList(context.Background(), box.Undefined(), nil, nil)
+	s.Require().NoError(err)
+	s.True(out.IsUndefined())
+
+	_, err = sliceList(context.Background(), box.Number(1), nil, nil)
+	s.Require().Error(err)
+}
+
+// fieldChain builds `root<op>f1<op>f2...`, using `?.` for the links marked optional
+func fieldChain(root string, links ...any) ast.Expression {
+	var expr ast.Expression = ast.NewIdentifier(root, stubRange())
+	for i := 0; i < len(links); i += 2 {
+		access := ast.NewFieldAccessExpression(expr, links[i].(string), stubRange())
+		access.Optional = links[i+1].(bool)
+		expr = access
+	}
+	return expr
+}
+
+func (s *RuntimeTestSuite) TestEvalOptionalChainAbsentField() {
+	ctx := context.Background()
+	p := newEvalTestPolicy()
+	ec := NewExecutionContext(p, &executorImpl{})
+	s.Require().NoError(ec.InjectFact(ctx, "a", box.Dict(map[string]box.Value{}), false, nil))
+
+	// a?.b?.c where b is absent
+	out, _, err := eval(ctx, ec, &executorImpl{}, p, fieldChain("a", "b", true, "c", true))
+	s.Require().NoError(err)
+	s.True(out.IsNull())
+}
+
+func (s *RuntimeTestSuite) TestEvalOptionalChainShortCircuitsRestOfChain() {
+	ctx := context.Background()
+	p := newEvalTestPolicy()
+	ec := NewExecutionContext(p, &executorImpl{})
+	s.Require().NoError(ec.InjectFact(ctx, "a", box.Null(), false, nil))
+
+	// a.b errors on a null receiver
+	_, _, err := eval(ctx, ec, &executorImpl{}, p, fieldChain("a", "b", false))
+	s.Require().Error(err)
+
+	// a?.b.c[0] stops at the first link
+	chain := ast.NewIndexAccessExpression(fieldChain("a", "b", true, "c", false), ast.NewIntegerLiteral(0, stubRange()), stubRange())
+	out, _, err := eval(ctx, ec, &executorImpl{}, p, chain)
+	s.Require().NoError(err)
+	s.True(out.IsNull())
+}
+
+func (s *RuntimeTestSuite) TestEvalOptionalChainPresentValue() {
+	ctx := context.Background()
+	p := newEvalTestPolicy()
+	ec := NewExecutionContext(p, &executorImpl{})
+	s.Require().NoError(ec.InjectFact(ctx, "a", box.Dict(map[string]box.Value{
+		"b": box.Dict(map[string]box.Value{"c": box.String("x")}),
+	}), false, nil))
+
+	out, _, err := eval(ctx, ec, &executorImpl{}, p, fieldChain("a", "b", true, "c", true))
+	s.Require().NoError(err)
+	s.Equal("x", out.Any())
+}
@@ -95,7 +95,7 @@ func evalCall(ctx context.Context, ec *ExecutionContext, exec *executorImpl, p *
 			// if this error is injected from code, we revert to the error message
 			return box.Undefined(), n.SetErr(err), err
 		}
-		err = fmt.Errorf("failed to call function '%s': %w", t.Callee.String(), err)
+		err = fmt.Errorf("failed to call function '%s' at %s: %w", t.Callee.String(), t.Span(), err)
 		return box.Undefined(), n.SetErr(err), err
 	}
 	return out, n.SetResult(out), nil
@@ -130,6 +130,13 @@ func calculateHashKey(node *ast.CallExpression, args []box.Value) string {
 func getTarget(_ context.Context, ec *ExecutionContext, exec *executorImpl, p *index.Policy, c *ast.CallExpression) (func(context.Context, ...box.Value) (box.Value, error), error) {
 	callee := c.Callee.String()
 
+	// a derive of the policy takes precedence over a builtin of the same name
+	if derive, ok := p.Derives[callee]; ok {
+		return func(ctx context.Context, args ...box.Value) (box.Value, error) {
+			return callDerive(ctx, ec, exec, p, derive, args)
+		}, nil
+	}
+
 	if builtin, ok := Builtins[callee]; ok {
 		return func(ctx context.Context, args ...box.Value) (box.Value, error) {
 			site := &CallSite{EC: ec, Exec: exec, Policy: p}
@@ -10,6 +10,8 @@ import (
 	"github.com/sentrie-sh/sentrie/ast"
 	"github.com/sentrie-sh/sentrie/box"
 	"github.com/sentrie-sh/sentrie/index"
+	"github.com/sentrie-sh/sentrie/pack"
+	"github.com/sentrie-sh/sentrie/parser"
 	"github.com/sentrie-sh/sentrie/tokens"
 )
 
@@ -133,3 +135,34 @@ func (s *RuntimeTestSuite) TestPipelineHoleOutsidePipelineErrors() {
 	s.Require().Error(err)
 	s.Require().Contains(err.Error(), "pipeline placeholder '#'")
 }
+
+func (s *RuntimeTestSuite) TestPipelineBareReferencesCallStdFunctions() {
+	ctx := context.Background()
+	src := `namespace com/example/pipe
+policy names {
+  fact name: string
+
+  use { trim, to_upper } from @sentrie/std as std
+
+  rule shout = default "" {
+    yield name |> std.trim |> std.to_upper
+  }
+
+  export decision of shout
+}`
+	program, err := parser.NewParserFromString(src, "pipe.sentrie").ParseProgram(ctx)
+	s.Require().NoError(err)
+
+	idx := index.CreateIndex()
+	s.Require().NoError(idx.SetPack(ctx, &pack.PackFile{Location: s.T().TempDir()}))
+	s.Require().NoError(idx.AddProgram(ctx, program))
+	s.Require().NoError(idx.Validate(ctx))
+
+	exec, err := NewExecutor(viewOf(idx))
+	s.Require().NoError(err)
+
+	outputs, err := exec.ExecPolicy(ctx, "com/example/pipe", "names", map[string]any{"name": "  sentrie  "})
+	s.Require().NoError(err)
+	s.Require().Len(outputs, 1)
+	s.Equal("SENTRIE", outputs[0].Decision.Value.Any())
+}
@@ -231,9 +231,6 @@ func (s *RuntimeTestSuite) TestEvalInfixAndUnaryUnsupportedAndInvalidOperands()
 func (s *RuntimeTestSuite) TestImportDecisionSuccessWithWithInjection() {
 	ctx := context.Background()
 	idx := index.CreateIndex()
-	exec := &executorImpl{
-		index: idx,
-	}
 
 	nsFQN := ast.NewFQN([]string{"test", "ns"}, stubRange())
 	ns := &index.Namespace{
@@ -279,6 +276,9 @@ func (s *RuntimeTestSuite) TestImportDecisionSuccessWithWithInjection() {
 		Uses:        map[string]*ast.UseStatement{},
 		Shapes:      map[string]*index.Shape{},
 	}
+	exec := &executorImpl{
+		index: viewOf(idx),
+	}
 	ec := NewExecutionContext(callerPolicy, exec)
 
 	imp := ast.NewImportClause(
@@ -0,0 +1,30 @@
+// SPDX-FileCopyrightText: © 2026 Binaek Sarkar <binaek89@gmail.com>
+// SPDX-License-Identifier: Apache-2.0
+
+package runtime
+
+import (
+	"context"
+
+	"github.com/sentrie-sh/sentrie/ast"
+	"github.com/sentrie-sh/sentrie/box"
+	"github.com/sentrie-sh/sentrie/index"
+	"github.com/sentrie-sh/sentrie/runtime/trace"
+)
+
+// evalIsDefined is true when the operand evaluates to a value that is neither undefined nor
+// null. An operand that cannot be evaluated, such as a field of a null fact, is not defined,
+// so that this never fails.
+func evalIsDefined(ctx context.Context, ec *ExecutionContext, exec *executorImpl, p *index.Policy, d *ast.IsDefinedExpression) (box.Value, *trace.Node, error) {
+	ctx, node, done := trace.New(ctx, d, "defined", nil)
+	defer done()
+
+	v, child, err := eval(ctx, ec, exec, p, d.Left)
+	node.Attach(child)
+	if err != nil && ctx.Err() != nil {
+		return box.Undefined(), node.SetErr(err), err
+	}
+
+	out := box.Bool(err == nil && !v.IsUndefined() && !v.IsNull())
+	return out, node.SetResult(out), nil
+}
@@ -0,0 +1,83 @@
+// SPDX-FileCopyrightText: © 2026 Binaek Sarkar <binaek89@gmail.com>
+// SPDX-License-Identifier: Apache-2.0
+
+package runtime
+
+import (
+	"context"
+
+	"github.com/sentrie-sh/sentrie/runtime/trace"
+	"github.com/sentrie-sh/sentrie/trinary"
+)
+
+const definedPolicy = `namespace com/example
+policy gated {
+  fact user?: document
+  rule allow = { yield defined(user) and user.allowed }
+  rule named = { yield defined(user?.profile.name) }
+  rule malformed = { yield defined(user.name.first) }
+  rule absent = { yield user is not defined }
+  export decision of allow
+  export decision of named
+  export decision of malformed
+  export decision of absent
+}
+`
+
+func (s *RuntimeTestSuite) definedDecisions(facts map[string]any) map[string]*ExecutorOutput {
+	exec := s.executorFromSource(definedPolicy)
+	outputs, err := exec.ExecPolicy(WithFullTrace(context.Background()), "com/example", "gated", facts)
+	s.Require().NoError(err)
+	byRule := map[string]*ExecutorOutput{}
+	for _, output := range outputs {
+		byRule[output.RuleName] = output
+	}
+	return byRule
+}
+
+func (s *RuntimeTestSuite) TestDefinedOfAbsentOptionalFactShortCircuits() {
+	outputs := s.definedDecisions(map[string]any{})
+
+	allow := outputs["allow"]
+	s.Equal(trinary.False, allow.Decision.State)
+	// the field access on the right of 'and' is skipped
+	infix := firstTraceNodeOfKind(allow.RuleNode, "infix")
+	s.Require().NotNil(infix)
+	s.Require().Len(infix.Children, 2)
+	s.Equal("is_defined", infix.Children[0].Kind)
+	s.Equal("not-evaluated", infix.Children[1].Kind)
+
+	s.Equal(trinary.False, outputs["named"].Decision.State)
+	s.Equal(trinary.True, outputs["absent"].Decision.State)
+}
+
+func (s *RuntimeTestSuite) TestDefinedOfPresentOptionalFact() {
+	outputs := s.definedDecisions(map[string]any{
+		"user": map[string]any{"allowed": true, "name": "ada", "profile": map[string]any{"name": "Ada"}},
+	})
+
+	s.Equal(trinary.True, outputs["allow"].Decision.State)
+	s.Equal(trinary.True, outputs["named"].Decision.State)
+	s.Equal(trinary.False, outputs["absent"].Decision.State)
+}
+
+func (s *RuntimeTestSuite) TestDefinedNeverFails() {
+	// a field of a string cannot be accessed, which is not defined rather than an error
+	outputs := s.definedDecisions(map[string]any{"user": map[string]any{"name": "ada"}})
+
+	s.Empty(outputs["malformed"].RuleNode.Err)
+	s.Equal(trinary.False, outputs["malformed"].Decision.State)
+}
+
+// firstTraceNodeOfKind returns the first node of kind in a depth-first walk of n.
+func firstTraceNodeOfKind(n *trace.Node, kind string) *trace.Node {
+	if n == nil || n.Kind == kind {
+		return n
+	}
+	for _, child := range n.Children {
+		if found := firstTraceNodeOfKind(child, kind); found != nil {
+			return found
+		}
+	}
+	return nil
+}
@@ -0,0 +1,129 @@
+// SPDX-FileCopyrightText: © 2026 Binaek Sarkar <binaek89@gmail.com>
+// SPDX-License-Identifier: Apache-2.0
+
+package runtime
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/sentrie-sh/sentrie/ast"
+	"github.com/sentrie-sh/sentrie/box"
+	"github.com/sentrie-sh/sentrie/index"
+)
+
+// callDerive evaluates derive with args. A derive is pure, so within one request its result is
+// memoized by the FQN of the derive and the canonical encoding of args. Arguments without a
+// canonical encoding, such as callables and host documents, are evaluated on every call.
+func callDerive(ctx context.Context, ec *ExecutionContext, exec *executorImpl, p *index.Policy, derive *ast.DeriveStatement, args []box.Value) (box.Value, error) {
+	if len(args) != len(derive.Params) {
+		return box.Undefined(), fmt.Errorf("derive '%s' takes %d arguments, got %d", derive.Name, len(derive.Params), len(args))
+	}
+
+	// A derive may not call itself, directly or through others. Check before waiting for the
+	// result, which a cycle would wait on forever
+	if err := ec.PushRefStack(ast.CreateFQN(p.FQN, derive.Name).String()); err != nil {
+		return box.Undefined(), err
+	}
+	defer ec.PopRefStack()
+
+	key, ok := deriveKey(p, derive, args)
+	if !ok {
+		return evalDerive(ctx, ec, exec, p, derive, args)
+	}
+	return ec.deriveValues.get(ctx, key, func() (box.Value, error) {
+		return evalDerive(ctx, ec, exec, p, derive, args)
+	})
+}
+
+// deriveEvaluated is called every time the body of a derive is evaluated. Overridable in tests.
+var deriveEvaluated = func(d *ast.DeriveStatement) {}
+
+// evalDerive evaluates the body of derive with its parameters bound to args.
+func evalDerive(ctx context.Context, ec *ExecutionContext, exec *executorImpl, p *index.Policy, derive *ast.DeriveStatement, args []box.Value) (box.Value, error) {
+	deriveEvaluated(derive)
+	child := ec.DeriveContext()
+	defer child.Dispose()
+	for i, name := range derive.Params {
+		child.SetLocal(name, args[i], true)
+	}
+	v, _, err := eval(ctx, child, exec, p, derive.Body)
+	return v, err
+}
+
+// deriveKey returns the memoization key of a call of derive with args, or false if an argument
+// has no canonical encoding.
+func deriveKey(p *index.Policy, derive *ast.DeriveStatement, args []box.Value) (string, bool) {
+	var b strings.Builder
+	b.WriteString(ast.CreateFQN(p.FQN, derive.Name).String())
+	b.WriteByte('(')
+	for i, arg := range args {
+		if i > 0 {
+			b.WriteByte(',')
+		}
+		if !writeCanonicalValue(&b, arg) {
+			return "", false
+		}
+	}
+	b.WriteByte(')')
+	return b.String(), true
+}
+
+// writeCanonicalValue writes an encoding of v to b that is equal for two values exactly when
+// they are indistinguishable to an expression: kinds are kept apart, so 1 and "1", null and
+// undefined differ, -0 and 0 differ, and the keys of a dict are written in order. It reports
+// false for the kinds that have no such encoding.
+func writeCanonicalValue(b *strings.Builder, v box.Value) bool {
+	switch v.Kind() {
+	case box.ValueUndefined:
+		b.WriteString("undefined")
+	case box.ValueNull:
+		b.WriteString("null")
+	case box.ValueBool:
+		x, _ := v.BoolValue()
+		b.WriteString(strconv.FormatBool(x))
+	case box.ValueNumber:
+		x, _ := v.NumberValue()
+		b.WriteString(strconv.FormatFloat(x, 'g', -1, 64))
+	case box.ValueString:
+		x, _ := v.StringValue()
+		b.WriteString(strconv.Quote(x))
+	case box.ValueTrinary:
+		x, _ := v.TrinaryValue()
+		b.WriteString("trinary:")
+		b.WriteString(x.String())
+	case box.ValueList:
+		xs, _ := v.ListValue()
+		b.WriteByte('[')
+		for i, x := range xs {
+			if i > 0 {
+				b.WriteByte(',')
+			}
+			if !writeCanonicalValue(b, x) {
+				return false
+			}
+		}
+		b.WriteByte(']')
+	case box.ValueDict:
+		m, _ := v.DictValue()
+		b.WriteByte('{')
+		for i, k := range slices.Sorted(maps.Keys(m)) {
+			if i > 0 {
+				b.WriteByte(',')
+			}
+			b.WriteString(strconv.Quote(k))
+			b.WriteByte(':')
+			if !writeCanonicalValue(b, m[k]) {
+				return false
+			}
+		}
+		b.WriteByte('}')
+	default:
+		return false
+	}
+	return true
+}
@@ -0,0 +1,262 @@
+// SPDX-FileCopyrightText: © 2026 Binaek Sarkar <binaek89@gmail.com>
+// SPDX-License-Identifier: Apache-2.0
+
+package runtime
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sync"
+	"testing"
+
+	"github.com/sentrie-sh/sentrie/ast"
+	"github.com/sentrie-sh/sentrie/box"
+	"github.com/sentrie-sh/sentrie/index"
+	"github.com/sentrie-sh/sentrie/pack"
+	"github.com/sentrie-sh/sentrie/parser"
+	"github.com/sentrie-sh/sentrie/trinary"
+)
+
+const derivePolicy = `namespace com/example
+policy access {
+  fact roles: list[string]
+  let grants = [{"role": "admin", "level": 5}, {"role": "ops", "level": 4}, {"role": "dev", "level": 2}]
+  derive privileged(role) = any(grants, (g) => { yield g.role == role and g.level > 3 })
+  rule allowed = { yield all(roles, (r) => { yield privileged(r) or r == "guest" }) }
+  rule privileges = { yield count(filter(roles, (r) => { yield privileged(r) })) }
+  export decision of allowed
+  export decision of privileges
+}
+`
+
+// inlinedDerivePolicy is derivePolicy with the body of the derive written out at every call,
+// which is evaluated on every call.
+const inlinedDerivePolicy = `namespace com/example
+policy access {
+  fact roles: list[string]
+  let grants = [{"role": "admin", "level": 5}, {"role": "ops", "level": 4}, {"role": "dev", "level": 2}]
+  rule allowed = { yield all(roles, (r) => { yield any(grants, (g) => { yield g.role == r and g.level > 3 }) or r == "guest" }) }
+  rule privileges = { yield count(filter(roles, (r) => { yield any(grants, (g) => { yield g.role == r and g.level > 3 }) })) }
+  export decision of allowed
+  export decision of privileges
+}
+`
+
+// countDeriveEvaluations counts the evaluations of every derive by name until the test ends.
+func (s *RuntimeTestSuite) countDeriveEvaluations() func(name string) int {
+	var mu sync.Mutex
+	counts := map[string]int{}
+	original := deriveEvaluated
+	deriveEvaluated = func(d *ast.DeriveStatement) {
+		mu.Lock()
+		defer mu.Unlock()
+		counts[d.Name]++
+	}
+	s.T().Cleanup(func() { deriveEvaluated = original })
+	return func(name string) int {
+		mu.Lock()
+		defer mu.Unlock()
+		return counts[name]
+	}
+}
+
+func (s *RuntimeTestSuite) TestDeriveIsEvaluatedOncePerArgumentsPerRequest() {
+	count := s.countDeriveEvaluations()
+	exec := s.executorFromSource(derivePolicy)
+	facts := map[string]any{"roles": []any{"admin", "dev", "admin", "ops", "dev", "admin"}}
+
+	outputs, err := exec.ExecPolicy(context.Background(), "com/example", "access", facts)
+	s.Require().NoError(err)
+	s.Require().Len(outputs, 2)
+	for _, output := range outputs {
+		if output.RuleName == "allowed" {
+			s.Equal(trinary.False, output.ToTrinary())
+		} else {
+			s.Equal(box.Number(4), output.Decision.Value)
+		}
+	}
+	// both rules call the derive for every role, but there are only three distinct roles
+	s.Equal(3, count("privileged"))
+
+	// another request evaluates it again
+	_, err = exec.ExecRule(context.Background(), "com/example", "access", "privileges", facts)
+	s.Require().NoError(err)
+	s.Equal(6, count("privileged"))
+}
+
+func (s *RuntimeTestSuite) TestDeriveMemoizationMatchesUnmemoizedEvaluation() {
+	memoized := s.executorFromSource(derivePolicy)
+	inlined := s.executorFromSource(inlinedDerivePolicy)
+
+	for _, roles := range [][]any{
+		{},
+		{"guest"},
+		{"admin", "ops", "admin"},
+		{"admin", "dev", "guest", "dev"},
+		{"ops", "ops", "ops", "nobody"},
+	} {
+		facts := map[string]any{"roles": roles}
+		for _, rule := range []string{"allowed", "privileges"} {
+			want, err := inlined.ExecRule(context.Background(), "com/example", "access", rule, facts)
+			s.Require().NoError(err)
+			got, err := memoized.ExecRule(context.Background(), "com/example", "access", rule, facts)
+			s.Require().NoError(err)
+			s.Equal(want.Decision, got.Decision, "%s of %v", rule, roles)
+		}
+	}
+}
+
+func (s *RuntimeTestSuite) TestDeriveKeyKeepsDistinguishableArgumentsApart() {
+	p := &index.Policy{FQN: ast.NewFQN([]string{"com", "example", "access"}, stubRange())}
+	derive := ast.NewDeriveStatement("f", []string{"x"}, ast.NewIdentifier("x", stubRange()), stubRange())
+	key := func(v box.Value) string {
+		k, ok := deriveKey(p, derive, []box.Value{v})
+		s.Require().True(ok, v.String())
+		return k
+	}
+
+	s.Equal(`com/example/access/f("a")`, key(box.String("a")))
+	for _, pair := range [][2]box.Value{
+		{box.Null(), box.Undefined()},
+		{box.Number(0), box.Number(math.Copysign(0, -1))},
+		{box.Number(1), box.String("1")},
+		{box.Bool(true), box.Trinary(trinary.True)},
+		{box.List([]box.Value{box.String("a")}), box.String(`["a"]`)},
+	} {
+		s.NotEqual(key(pair[0]), key(pair[1]), "%v and %v", pair[0], pair[1])
+	}
+	s.Equal(
+		key(box.Dict(map[string]box.Value{"a": box.Number(1), "b": box.Number(2)})),
+		key(box.Dict(map[string]box.Value{"b": box.Number(2), "a": box.Number(1)})),
+	)
+
+	_, ok := deriveKey(p, derive, []box.Value{box.Callable(newLambdaCallable(nil, nil))})
+	s.False(ok, "a callable has no canonical encoding")
+}
+
+func (s *RuntimeTestSuite) TestDeriveWithCallableArgumentsIsNotMemoized() {
+	count := s.countDeriveEvaluations()
+	exec := s.executorFromSource(`namespace com/example
+policy access {
+  fact roles: list[string]
+  derive matching(fn) = count(filter(roles, fn))
+  rule admins = { yield matching((r) => { yield r == "admin" }) + matching((r) => { yield r == "admin" }) }
+  export decision of admins
+}`)
+
+	output, err := exec.ExecRule(context.Background(), "com/example", "access", "admins", map[string]any{"roles": []any{"admin", "dev"}})
+	s.Require().NoError(err)
+	s.Equal(box.Number(2), output.Decision.Value)
+	s.Equal(2, count("matching"))
+}
+
+func (s *RuntimeTestSuite) TestDeriveDoesNotSeeTheLocalsOfItsCaller() {
+	exec := s.executorFromSource(`namespace com/example
+policy access {
+  fact roles: list[string]
+  derive leaked() = r
+  rule allowed = { yield all(roles, (r) => { yield leaked() == r }) }
+  export decision of allowed
+}`)
+
+	_, err := exec.ExecRule(context.Background(), "com/example", "access", "allowed", map[string]any{"roles": []any{"admin"}})
+	s.Require().Error(err)
+	s.Contains(err.Error(), "identifier not found: r")
+}
+
+func (s *RuntimeTestSuite) TestDeriveCallingItselfIsReported() {
+	ctx := context.Background()
+	for _, body := range []string{"f(x)", "f(x + 1)", "g(x)"} {
+		program, err := parser.NewParserFromString(fmt.Sprintf(`namespace com/example
+policy access {
+  derive f(x) = %s
+  derive g(x) = f(x)
+  rule allowed = { yield f(1) > 0 }
+  export decision of allowed
+}`, body), "access.sentrie").ParseProgram(ctx)
+		s.Require().NoError(err)
+
+		idx := index.CreateIndex()
+		s.Require().NoError(idx.SetPack(ctx, &pack.PackFile{Location: s.T().TempDir()}))
+		s.Require().NoError(idx.AddProgram(ctx, program))
+
+		// the index rejects the cycle
+		err = idx.Validate(ctx)
+		s.Require().Error(err, body)
+		s.Contains(err.Error(), "infinite recursion", body)
+
+		// and evaluation reports it, rather than waiting on the call forever
+		p, err := idx.ResolvePolicy("com/example", "access")
+		s.Require().NoError(err)
+		exec := &executorImpl{}
+		_, _, err = eval(ctx, NewExecutionContext(p, exec), exec, p, s.parseExpression("f(1)"))
+		s.Require().Error(err, body)
+		s.Contains(err.Error(), "references itself", body)
+	}
+}
+
+func (s *RuntimeTestSuite) TestDeriveRejectsTheWrongNumberOfArguments() {
+	exec := s.executorFromSource(`namespace com/example
+policy access {
+  fact n: number
+  derive f(x) = x
+  rule allowed = { yield f(n, n) > 0 }
+  export decision of allowed
+}`)
+
+	_, err := exec.ExecRule(context.Background(), "com/example", "access", "allowed", map[string]any{"n": 1})
+	s.Require().Error(err)
+	s.Contains(err.Error(), "derive 'f' takes 1 arguments, got 2")
+}
+
+// benchmarkExecutor indexes src for a benchmark.
+func benchmarkExecutor(b *testing.B, src string) Executor {
+	ctx := context.Background()
+	program, err := parser.NewParserFromString(src, "bench.sentrie").ParseProgram(ctx)
+	if err != nil {
+		b.Fatal(err)
+	}
+	idx := index.CreateIndex()
+	if err := idx.SetPack(ctx, &pack.PackFile{Location: b.TempDir()}); err != nil {
+		b.Fatal(err)
+	}
+	if err := idx.AddProgram(ctx, program); err != nil {
+		b.Fatal(err)
+	}
+	if err := idx.Validate(ctx); err != nil {
+		b.Fatal(err)
+	}
+	return &executorImpl{index: viewOf(idx)}
+}
+
+// benchmarkRoles are 400 roles of which only four are distinct.
+func benchmarkRoles() map[string]any {
+	roles := make([]any, 0, 400)
+	for i := range 400 {
+		roles = append(roles, []string{"admin", "ops", "dev", "guest"}[i%4])
+	}
+	return map[string]any{"roles": roles}
+}
+
+func benchmarkDerivePolicy(b *testing.B, src string) {
+	exec := benchmarkExecutor(b, src)
+	facts := benchmarkRoles()
+
+	b.ReportAllocs()
+	for b.Loop() {
+		if _, err := exec.ExecPolicy(context.Background(), "com/example", "access", facts); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkDeriveMemoized(b *testing.B) {
+	benchmarkDerivePolicy(b, derivePolicy)
+}
+
+// BenchmarkDeriveUnmemoizedBaseline evaluates the body of the derive on every call, which is
+// what writing it out at every call site did before derives were memoized.
+func BenchmarkDeriveUnmemoizedBaseline(b *testing.B) {
+	benchmarkDerivePolicy(b, inlinedDerivePolicy)
+}
@@ -18,6 +18,7 @@ package runtime
 
 import (
 	"context"
+	"math"
 
 	"github.com/sentrie-sh/sentrie/ast"
 )
@@ -41,6 +42,25 @@ func (s *RuntimeTestSuite) TestEvalDistinctDirectScalars() {
 	s.Equal([]any{float64(1), float64(2), float64(3)}, result.Any())
 }
 
+func (s *RuntimeTestSuite) TestEvalDistinctUnifiesIntegersAndFloats() {
+	ctx := context.Background()
+	p := newEvalTestPolicy()
+	ec := NewExecutionContext(p, &executorImpl{})
+	exec := &executorImpl{}
+
+	list := ast.NewListLiteral([]ast.Expression{
+		ast.NewIntegerLiteral(1, stubRange()),
+		ast.NewFloatLiteral(1.0, stubRange()),
+		ast.NewFloatLiteral(math.Copysign(0, -1), stubRange()),
+		ast.NewIntegerLiteral(0, stubRange()),
+		ast.NewFloatLiteral(1.5, stubRange()),
+	}, stubRange())
+	call := ast.NewCallExpression(ast.NewIdentifier("distinct", stubRange()), []ast.Expression{list}, false, nil, stubRange())
+	result, _, err := eval(ctx, ec, exec, p, call)
+	s.NoError(err)
+	s.Equal([]any{float64(1), float64(0), float64(1.5)}, result.Any())
+}
+
 func (s *RuntimeTestSuite) TestEvalDistinctSelectorKey() {
 	ctx := context.Background()
 	p := newEvalTestPolicy()
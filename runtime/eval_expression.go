@@ -0,0 +1,59 @@
+// SPDX-FileCopyrightText: © 2026 Binaek Sarkar <binaek89@gmail.com>
+// SPDX-License-Identifier: Apache-2.0
+
+package runtime
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/sentrie-sh/sentrie/ast"
+	"github.com/sentrie-sh/sentrie/box"
+	"github.com/sentrie-sh/sentrie/index"
+	"github.com/sentrie-sh/sentrie/runtime/trace"
+	"github.com/sentrie-sh/sentrie/xerr"
+)
+
+// ExpressionEvaluator evaluates expressions in the scope of a policy. The executors built by
+// NewExecutor are ExpressionEvaluators.
+type ExpressionEvaluator interface {
+	EvalExpression(ctx context.Context, namespace, policy string, facts map[string]any, uses []*ast.UseStatement, expr ast.Expression) (box.Value, *trace.Node, error)
+}
+
+var _ ExpressionEvaluator = (*executorImpl)(nil)
+
+// EvalExpression evaluates expr in the scope of a policy, as if it were the body of one of its
+// rules. The facts are bound the same as for an exported rule, and the modules of uses are bound
+// next to those the policy uses, shadowing them. Shape imports cannot be added this way.
+func (e *executorImpl) EvalExpression(ctx context.Context, namespace, policy string, facts map[string]any, uses []*ast.UseStatement, expr ast.Expression) (box.Value, *trace.Node, error) {
+	p, err := e.index.ResolvePolicy(namespace, policy)
+	if err != nil {
+		return box.Undefined(), nil, err
+	}
+
+	r := e.sensitiveRedactor(p, facts)
+	v, node, err := e.evalInPolicy(ctx, r, p, facts, uses, expr)
+	return r.expression(expr, v, node), node, r.err(err)
+}
+
+func (e *executorImpl) evalInPolicy(ctx context.Context, r *redactor, p *index.Policy, facts map[string]any, uses []*ast.UseStatement, expr ast.Expression) (box.Value, *trace.Node, error) {
+	ec := NewExecutionContext(p, e)
+	defer ec.Dispose()
+
+	if err := e.bindPolicy(ctx, ec, r, p, facts); err != nil {
+		return box.Undefined(), nil, err
+	}
+	for _, use := range uses {
+		if use.IsShapeImport() {
+			return box.Undefined(), nil, fmt.Errorf("cannot import shape '%s' into an expression: %w", use.As, xerr.ErrInvalidInvocation(""))
+		}
+		if err := e.bindUse(ctx, ec, p, use.As, use); err != nil {
+			return box.Undefined(), nil, err
+		}
+	}
+	if err := e.validateBoundFacts(ctx, ec, p); err != nil {
+		return box.Undefined(), nil, err
+	}
+
+	return eval(ctx, ec, e, p, expr)
+}
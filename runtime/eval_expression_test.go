@@ -0,0 +1,88 @@
+// SPDX-FileCopyrightText: © 2026 Binaek Sarkar <binaek89@gmail.com>
+// SPDX-License-Identifier: Apache-2.0
+
+package runtime
+
+import (
+	"github.com/sentrie-sh/sentrie/ast"
+	"github.com/sentrie-sh/sentrie/parser"
+	"github.com/sentrie-sh/sentrie/trinary"
+	"github.com/sentrie-sh/sentrie/xerr"
+)
+
+const evalExpressionPolicy = `namespace com/example
+policy access {
+  fact user: dict[any]
+  fact sensitive token: string
+  fact region?: string default "eu"
+  let adult = user.age >= 18
+  rule allow = default false { yield adult and region == "eu" }
+  export decision of allow
+}
+`
+
+func (s *RuntimeTestSuite) parseExpression(src string) ast.Expression {
+	expr, err := parser.NewParserFromString(src, "repl").ParseExpression(s.T().Context())
+	s.Require().NoError(err)
+	return expr
+}
+
+func (s *RuntimeTestSuite) TestEvalExpressionSeesFactsLetsAndRules() {
+	exec := s.executorFromSource(evalExpressionPolicy).(ExpressionEvaluator)
+	facts := map[string]any{"user": map[string]any{"age": 30}, "token": "s3cr3t"}
+
+	v, node, err := exec.EvalExpression(s.T().Context(), "com/example", "access", facts, nil, s.parseExpression(`user.age + 1`))
+	s.Require().NoError(err)
+	s.Equal(float64(31), v.Any())
+	s.NotNil(node)
+
+	v, _, err = exec.EvalExpression(s.T().Context(), "com/example", "access", facts, nil, s.parseExpression(`adult and region == "eu"`))
+	s.Require().NoError(err)
+	s.Equal(trinary.True, v.Any())
+
+	v, _, err = exec.EvalExpression(s.T().Context(), "com/example", "access", facts, nil, s.parseExpression(`allow`))
+	s.Require().NoError(err)
+	s.Equal("true", v.String())
+}
+
+func (s *RuntimeTestSuite) TestEvalExpressionRedactsSensitiveFacts() {
+	exec := s.executorFromSource(evalExpressionPolicy).(ExpressionEvaluator)
+	facts := map[string]any{"user": map[string]any{"age": 30}, "token": "s3cr3t"}
+
+	v, node, err := exec.EvalExpression(s.T().Context(), "com/example", "access", facts, nil, s.parseExpression(`"token: " + token`))
+	s.Require().NoError(err)
+	s.Equal(Redacted, v.Any())
+	s.NotContains(node.Render(), "s3cr3t")
+
+	v, node, err = exec.EvalExpression(s.T().Context(), "com/example", "access", facts, nil, s.parseExpression(`map(["a", "b"], (t) => { yield t + token })`))
+	s.Require().NoError(err)
+	s.Equal(Redacted, v.Any())
+	s.NotContains(node.Render(), "s3cr3t")
+
+	// a value equal to the secret but not computed from it is kept
+	v, _, err = exec.EvalExpression(s.T().Context(), "com/example", "access", facts, nil, s.parseExpression(`"s3cr3t"`))
+	s.Require().NoError(err)
+	s.Equal("s3cr3t", v.Any())
+}
+
+func (s *RuntimeTestSuite) TestEvalExpressionRequiresFacts() {
+	exec := s.executorFromSource(evalExpressionPolicy).(ExpressionEvaluator)
+
+	_, _, err := exec.EvalExpression(s.T().Context(), "com/example", "access", map[string]any{"token": "x"}, nil, s.parseExpression(`1`))
+	s.Require().Error(err)
+	s.Contains(err.Error(), "required fact not found: user")
+
+	_, _, err = exec.EvalExpression(s.T().Context(), "com/example", "missing", nil, nil, s.parseExpression(`1`))
+	s.ErrorIs(err, xerr.NotFoundError{})
+}
+
+func (s *RuntimeTestSuite) TestEvalExpressionRejectsShapeImports() {
+	exec := s.executorFromSource(evalExpressionPolicy).(ExpressionEvaluator)
+	use, err := parser.NewParserFromString(`use User from com/other as User`, "repl").ParseUse(s.T().Context())
+	s.Require().NoError(err)
+
+	facts := map[string]any{"user": map[string]any{"age": 30}, "token": "x"}
+	_, _, err = exec.EvalExpression(s.T().Context(), "com/example", "access", facts, []*ast.UseStatement{use}, s.parseExpression(`1`))
+	s.Require().Error(err)
+	s.Contains(err.Error(), "cannot import shape 'User'")
+}
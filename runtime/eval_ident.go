@@ -42,27 +42,34 @@ func evalIdent(ctx context.Context, ec *ExecutionContext, exec *executorImpl, p
 
 	// we couldn't find anything yet - look for a let declaration in the ExecutionContext
 	if v, ok := ec.GetLet(i.Value); ok {
-		// Check for infinite recursion before evaluating the let declaration
+		// Check for infinite recursion before evaluating the let declaration, and before waiting
+		// for its value, which a cycle would wait on forever
 		if err := ec.PushRefStack(i.Value); err != nil {
 			return box.Undefined(), n.SetErr(err), err
 		}
 		defer ec.PopRefStack()
 
-		// we found a let declaration - evaluate it and set the local
-		val, letEvalNode, err := eval(ctx, ec, exec, p, v.Value)
-		n.Attach(letEvalNode)
+		var val box.Value
+		var err error
+		if p.Lets[i.Value] == v {
+			// a policy let is evaluated once per request, in the scope of the policy, so that the
+			// locals of whichever expression references it first cannot shadow what it refers to
+			val, err = ec.letValues.get(ctx, i.Value, func() (box.Value, error) {
+				letCtx := ec.DeriveContext()
+				defer letCtx.Dispose()
+				val, letEvalNode, err := evalLet(ctx, letCtx, exec, p, v)
+				n.Attach(letEvalNode)
+				return val, err
+			})
+		} else {
+			var letEvalNode *trace.Node
+			val, letEvalNode, err = evalLet(ctx, ec, exec, p, v)
+			n.Attach(letEvalNode)
+		}
 		if err != nil {
 			return box.Undefined(), n.SetErr(err), err
 		}
 
-		// check the type of the let declaration
-		if v.Type != nil {
-			if err := validateValueAgainstTypeRef(ctx, ec, exec, p, val, v.Type, v.Value.Span()); err != nil {
-				wrappedErr := fmt.Errorf("invalid value for let declaration %s: %w", i, err)
-				return box.Undefined(), n.SetErr(wrappedErr), wrappedErr
-			}
-		}
-
 		ec.SetLocal(i.Value, val, false)
 		return val, n.SetResult(val), nil
 	}
@@ -77,6 +84,31 @@ func evalIdent(ctx context.Context, ec *ExecutionContext, exec *executorImpl, p
 		return decision.Value, n.SetResult(decision.Value), nil
 	}
 
+	// an optional fact that was not supplied is unknown rather than missing
+	if f, found := p.Facts[i.Value]; found && f.Optional {
+		return box.Undefined(), n.SetResult(box.Undefined()), nil
+	}
+
 	err := fmt.Errorf("identifier not found: %s", i.Value)
 	return box.Undefined(), n.SetErr(err), err
 }
+
+// letEvaluated is called every time the value of a let is evaluated. Overridable in tests.
+var letEvaluated = func(v *ast.VarDeclaration) {}
+
+// evalLet evaluates the value of the let declaration v and checks it against its type.
+func evalLet(ctx context.Context, ec *ExecutionContext, exec *executorImpl, p *index.Policy, v *ast.VarDeclaration) (box.Value, *trace.Node, error) {
+	letEvaluated(v)
+	val, node, err := eval(ctx, ec, exec, p, v.Value)
+	if err != nil {
+		return box.Undefined(), node, err
+	}
+
+	// check the type of the let declaration
+	if v.Type != nil {
+		if err := validateValueAgainstTypeRef(ctx, ec, exec, p, val, v.Type, v.Value.Span()); err != nil {
+			return box.Undefined(), node, fmt.Errorf("invalid value for let declaration %s: %w", v.Name, err)
+		}
+	}
+	return val, node, nil
+}
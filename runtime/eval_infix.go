@@ -25,6 +25,7 @@ import (
 	"github.com/sentrie-sh/sentrie/box"
 	"github.com/sentrie-sh/sentrie/index"
 	"github.com/sentrie-sh/sentrie/runtime/trace"
+	"github.com/sentrie-sh/sentrie/trinary"
 )
 
 func evalInfix(ctx context.Context, ec *ExecutionContext, exec *executorImpl, p *index.Policy, in *ast.InfixExpression) (box.Value, *trace.Node, error) {
@@ -38,136 +39,222 @@ func evalInfix(ctx context.Context, ec *ExecutionContext, exec *executorImpl, p
 	if err != nil {
 		return box.Undefined(), node.SetErr(err), err
 	}
+	// 'x ?: y' is x unless x is unknown, and only then evaluates y
+	if in.Operator == "?:" {
+		if coalesced(l) {
+			attachNotEvaluated(ctx, node, in.Right)
+			return l, node.SetResult(l), nil
+		}
+		r, rn, err := eval(ctx, ec, exec, p, in.Right)
+		node.Attach(rn)
+		if err != nil {
+			return box.Undefined(), node.SetErr(err), err
+		}
+		return r, node.SetResult(r), nil
+	}
+	// 'and' and 'or' are decided once the left operand decides them, and then the right one
+	// is not evaluated, so that it can rely on the left, as in defined(user) and user.active
+	if out, ok := shortCircuit(in.Operator, l); ok {
+		attachNotEvaluated(ctx, node, in.Right)
+		return out, node.SetResult(out), nil
+	}
 	r, rn, err := eval(ctx, ec, exec, p, in.Right)
 	node.Attach(rn)
 	if err != nil {
 		return box.Undefined(), node.SetErr(err), err
 	}
 
+	out, err := applyInfix(exec, in, l, r)
+	if err != nil {
+		return box.Undefined(), node.SetErr(err), err
+	}
+	return out, node.SetResult(out), nil
+}
+
+// applyInfix applies the operator of in to its evaluated operands l and r. Only 'and', 'or' and
+// '?:' may skip their right operand; see shortCircuit and coalesced.
+func applyInfix(exec *executorImpl, in *ast.InfixExpression, l, r box.Value) (box.Value, error) {
+	// the logical operators follow Kleene logic, where an undefined operand is unknown
+	if out, ok := evalLogical(in.Operator, l, r); ok {
+		return out, nil
+	}
+
+	if propagatesUnknown(in.Operator) && (isUnknownOperand(l) || isUnknownOperand(r)) {
+		if exec.strictUnknown {
+			return box.Undefined(), fmt.Errorf("unknown operand to '%s' at %s", in.Operator, in.Span())
+		}
+		// an undefined operand stays undefined, which reads as unknown wherever a decision is made
+		if l.IsUndefined() || r.IsUndefined() {
+			return box.Undefined(), nil
+		}
+		return box.Trinary(trinary.Unknown), nil
+	}
+
 	if l.IsUndefined() || r.IsUndefined() {
-		return box.Undefined(), node.SetResult(box.Undefined()), nil
+		return box.Undefined(), nil
 	}
 
 	switch in.Operator {
 	case "+":
 		if ls, ok := l.StringValue(); ok {
-			out := box.String(ls + r.String())
-			return out, node.SetResult(out), nil
+			return box.String(ls + r.String()), nil
 		}
 		if rs, ok := r.StringValue(); ok {
-			out := box.String(l.String() + rs)
-			return out, node.SetResult(out), nil
+			return box.String(l.String() + rs), nil
 		}
 		ln, rn, err := box.MustNumbers(l, r)
 		if err != nil {
-			return box.Undefined(), node.SetErr(err), err
+			return box.Undefined(), err
 		}
-		out := box.Number(ln + rn)
-		return out, node.SetResult(out), nil
+		return box.Number(ln + rn), nil
 	case "-":
 		ln, rn, err := box.MustNumbers(l, r)
 		if err != nil {
-			return box.Undefined(), node.SetErr(err), err
+			return box.Undefined(), err
 		}
-		out := box.Number(ln - rn)
-		return out, node.SetResult(out), nil
+		return box.Number(ln - rn), nil
 	case "*":
 		ln, rn, err := box.MustNumbers(l, r)
 		if err != nil {
-			return box.Undefined(), node.SetErr(err), err
+			return box.Undefined(), err
 		}
-		out := box.Number(ln * rn)
-		return out, node.SetResult(out), nil
+		return box.Number(ln * rn), nil
 	case "/":
 		ln, rn, err := box.MustNumbers(l, r)
 		if err != nil {
-			return box.Undefined(), node.SetErr(err), err
+			return box.Undefined(), err
 		}
 		if rn == 0 {
-			err := fmt.Errorf("divide by zero")
-			return box.Undefined(), node.SetErr(err), err
+			return box.Undefined(), fmt.Errorf("divide by zero")
 		}
-		out := box.Number(ln / rn)
-		return out, node.SetResult(out), nil
+		return box.Number(ln / rn), nil
 	case "%":
 		ln, rn, err := box.MustNumbers(l, r)
 		if err != nil {
-			return box.Undefined(), node.SetErr(err), err
+			return box.Undefined(), err
 		}
 		if rn == 0 {
-			err := fmt.Errorf("divide by zero")
-			return box.Undefined(), node.SetErr(err), err
+			return box.Undefined(), fmt.Errorf("divide by zero")
 		}
-		out := box.Number(math.Mod(ln, rn))
-		return out, node.SetResult(out), nil
+		return box.Number(math.Mod(ln, rn)), nil
 
 	case "==", "is":
-		out := box.Bool(box.EqualValues(l, r))
-		return out, node.SetResult(out), nil
+		return box.Bool(equalOperands(l, r)), nil
 	case "!=":
-		out := box.Bool(!box.EqualValues(l, r))
-		return out, node.SetResult(out), nil
+		return box.Bool(!equalOperands(l, r)), nil
 	case "<":
+		if c, ok := box.CompareDates(l, r); ok {
+			return box.Bool(c < 0), nil
+		}
 		ln, rn, err := box.MustNumbers(l, r)
 		if err != nil {
-			return box.Undefined(), node.SetErr(err), err
+			return box.Undefined(), err
 		}
-		out := box.Bool(ln < rn)
-		return out, node.SetResult(out), nil
+		return box.Bool(ln < rn), nil
 	case "<=":
+		if c, ok := box.CompareDates(l, r); ok {
+			return box.Bool(c <= 0), nil
+		}
 		ln, rn, err := box.MustNumbers(l, r)
 		if err != nil {
-			return box.Undefined(), node.SetErr(err), err
+			return box.Undefined(), err
 		}
-		out := box.Bool(ln <= rn)
-		return out, node.SetResult(out), nil
+		return box.Bool(ln <= rn), nil
 	case ">":
+		if c, ok := box.CompareDates(l, r); ok {
+			return box.Bool(c > 0), nil
+		}
 		ln, rn, err := box.MustNumbers(l, r)
 		if err != nil {
-			return box.Undefined(), node.SetErr(err), err
+			return box.Undefined(), err
 		}
-		out := box.Bool(ln > rn)
-		return out, node.SetResult(out), nil
+		return box.Bool(ln > rn), nil
 	case ">=":
+		if c, ok := box.CompareDates(l, r); ok {
+			return box.Bool(c >= 0), nil
+		}
 		ln, rn, err := box.MustNumbers(l, r)
 		if err != nil {
-			return box.Undefined(), node.SetErr(err), err
+			return box.Undefined(), err
 		}
-		out := box.Bool(ln >= rn)
-		return out, node.SetResult(out), nil
-
-	case "and":
-		out := box.Trinary(box.TrinaryFrom(l).And(box.TrinaryFrom(r)))
-		return out, node.SetResult(out), nil
-
-	case "or":
-		out := box.Trinary(box.TrinaryFrom(l).Or(box.TrinaryFrom(r)))
-		return out, node.SetResult(out), nil
-
-	case "xor":
-		left := box.TrinaryFrom(l)
-		right := box.TrinaryFrom(r)
-		out := box.Trinary(left.Or(right).And(left.And(right).Not()))
-		return out, node.SetResult(out), nil
+		return box.Bool(ln >= rn), nil
 
 	case "in":
-		out := box.Bool(box.ContainsValue(r, l))
-		return out, node.SetResult(out), nil
+		return box.Bool(box.ContainsValue(r, l)), nil
 
 	case "contains":
-		out := box.Bool(box.ContainsValue(l, r))
-		return out, node.SetResult(out), nil
+		return box.Bool(box.ContainsValue(l, r)), nil
 
 	case "matches":
 		out, err := box.MatchesValue(l, r)
 		if err != nil {
-			return box.Undefined(), node.SetErr(err), err
+			return box.Undefined(), err
 		}
-		b := box.Bool(out)
-		return b, node.SetResult(b), nil
+		return box.Bool(out), nil
 
 	default:
-		err := fmt.Errorf("unsupported infix op: %s", in.Operator)
-		return box.Undefined(), node.SetErr(err), err
+		return box.Undefined(), fmt.Errorf("unsupported infix op: %s", in.Operator)
+	}
+}
+
+// equalOperands reports whether l and r are equal. Two dates are equal when they are the same
+// instant, however they are written, so that equality agrees with the ordering of dates.
+func equalOperands(l, r box.Value) bool {
+	if c, ok := box.CompareDates(l, r); ok {
+		return c == 0
+	}
+	return box.EqualValues(l, r)
+}
+
+// shortCircuit returns the value of an 'and' whose left operand is false, or of an 'or' whose
+// left operand is true.
+func shortCircuit(op string, l box.Value) (box.Value, bool) {
+	switch left := box.TrinaryFrom(l); {
+	case op == "and" && left == trinary.False:
+		return box.Trinary(trinary.False), true
+	case op == "or" && left == trinary.True:
+		return box.Trinary(trinary.True), true
+	}
+	return box.Value{}, false
+}
+
+// evalLogical returns the value of an 'and', 'or' or 'xor' of l and r.
+func evalLogical(op string, l, r box.Value) (box.Value, bool) {
+	left, right := box.TrinaryFrom(l), box.TrinaryFrom(r)
+	switch op {
+	case "and":
+		return box.Trinary(left.And(right)), true
+	case "or":
+		return box.Trinary(left.Or(right)), true
+	case "xor":
+		return box.Trinary(left.Xor(right)), true
+	}
+	return box.Value{}, false
+}
+
+// propagatesUnknown reports whether op yields unknown when either operand is unknown. These are
+// the arithmetic operators (+ - * / %) and the comparisons (== != < <= > >=): a value compared to
+// or combined with something unknown is itself unknown, so "unknown == unknown" is unknown. The
+// logical operators follow Kleene logic instead, and "is" is the identity test that answers
+// definitely, so that "x is unknown" can be asked.
+func propagatesUnknown(op string) bool {
+	switch op {
+	case "+", "-", "*", "/", "%", "==", "!=", "<", "<=", ">", ">=":
+		return true
+	}
+	return false
+}
+
+// coalesced reports whether 'l ?: r' is l, which it is unless l is unknown or null.
+func coalesced(l box.Value) bool {
+	return !isUnknownOperand(l) && !l.IsNull()
+}
+
+// isUnknownOperand reports whether v is undefined or the trinary unknown.
+func isUnknownOperand(v box.Value) bool {
+	if v.IsUndefined() {
+		return true
 	}
+	t, ok := v.TrinaryValue()
+	return ok && t == trinary.Unknown
 }
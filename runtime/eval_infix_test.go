@@ -391,3 +391,198 @@ func (s *RuntimeTestSuite) TestEvalInfixMembershipAndComparisonAliases() {
 		})
 	}
 }
+
+func (s *RuntimeTestSuite) TestEvalInfixOrdersDates() {
+	ctx := context.Background()
+	p := newEvalTestPolicy()
+	ec := NewExecutionContext(p, &executorImpl{})
+
+	compare := func(l, op, r string) (box.Value, error) {
+		in := ast.NewInfixExpression(ast.NewStringLiteral(l, stubRange()), ast.NewStringLiteral(r, stubRange()), op, stubRange())
+		v, _, err := evalInfix(ctx, ec, &executorImpl{}, p, in)
+		return v, err
+	}
+
+	for _, tc := range []struct {
+		l, op, r string
+		want     bool
+	}{
+		{"2020-01-01", "<", "2020-01-02T00:00:00Z", true},
+		{"2020-01-01T02:00:00+02:00", "<=", "2020-01-01", true},
+		{"2020-01-01T02:00:00+02:00", "<", "2020-01-01", false},
+		{"2021-01-01", ">", "2020-12-31T23:59:59Z", true},
+		{"2020-01-01", ">=", "2020-01-01T00:00:01Z", false},
+	} {
+		out, err := compare(tc.l, tc.op, tc.r)
+		s.Require().NoError(err)
+		b, ok := out.BoolValue()
+		s.Require().True(ok)
+		s.Equal(tc.want, b, "%s %s %s", tc.l, tc.op, tc.r)
+	}
+
+	_, err := compare("2020-01-01", "<", "later")
+	s.Require().ErrorContains(err, "left operand is not a number")
+}
+
+func (s *RuntimeTestSuite) TestEvalInfixEqualityAgreesWithDateOrdering() {
+	ctx := context.Background()
+	p := newEvalTestPolicy()
+	ec := NewExecutionContext(p, &executorImpl{})
+
+	compare := func(l, op, r string) bool {
+		in := ast.NewInfixExpression(ast.NewStringLiteral(l, stubRange()), ast.NewStringLiteral(r, stubRange()), op, stubRange())
+		v, _, err := evalInfix(ctx, ec, &executorImpl{}, p, in)
+		s.Require().NoError(err)
+		b, ok := v.BoolValue()
+		s.Require().True(ok)
+		return b
+	}
+
+	for _, pair := range [][2]string{
+		{"2020-01-01T00:00:00Z", "2020-01-01"},
+		{"2020-01-01T02:00:00+02:00", "2020-01-01T00:00:00Z"},
+		{"2020-01-01", "2020-01-02"},
+	} {
+		l, r := pair[0], pair[1]
+		same := compare(l, "<=", r) && compare(l, ">=", r)
+		s.Equal(same, compare(l, "==", r), "%s == %s", l, r)
+		s.Equal(same, compare(l, "is", r), "%s is %s", l, r)
+		s.Equal(!same, compare(l, "!=", r), "%s != %s", l, r)
+	}
+	s.True(compare("2020-01-01T00:00:00Z", "==", "2020-01-01"))
+	s.False(compare("2020-01-01", "==", "later"))
+}
+
+func (s *RuntimeTestSuite) TestEvalInfixUnknownOperandsYieldUnknown() {
+	p := newEvalTestPolicy()
+	ec := NewExecutionContext(p, &executorImpl{})
+	unknown := func() ast.Expression { return ast.NewTrinaryLiteral(trinary.Unknown, stubRange()) }
+	five := func() ast.Expression { return ast.NewIntegerLiteral(5, stubRange()) }
+
+	for _, op := range []string{"+", "-", "*", "/", "%", "==", "!=", "<", "<=", ">", ">="} {
+		for _, operands := range [][2]ast.Expression{
+			{unknown(), five()},
+			{five(), unknown()},
+			{unknown(), unknown()},
+		} {
+			expr := ast.NewInfixExpression(operands[0], operands[1], op, stubRange())
+			got, _, err := evalInfix(context.Background(), ec, &executorImpl{}, p, expr)
+			s.Require().NoError(err, expr.String())
+			s.Equal(trinary.Unknown, got.Any(), expr.String())
+		}
+	}
+
+	// "is" answers definitely, so an unknown can be tested for
+	got, _, err := evalInfix(context.Background(), ec, &executorImpl{}, p, ast.NewInfixExpression(unknown(), unknown(), "is", stubRange()))
+	s.Require().NoError(err)
+	s.Equal(true, got.Any())
+}
+
+func (s *RuntimeTestSuite) TestEvalInfixAndOrShortCircuit() {
+	p := newEvalTestPolicy()
+	ec := NewExecutionContext(p, &executorImpl{})
+
+	for op, left := range map[string]trinary.Value{"and": trinary.False, "or": trinary.True} {
+		expr := ast.NewInfixExpression(ast.NewTrinaryLiteral(left, stubRange()), failingBranch(), op, stubRange())
+		got, node, err := evalInfix(WithFullTrace(context.Background()), ec, &executorImpl{}, p, expr)
+		s.Require().NoError(err, expr.String())
+		s.Equal(left, got.Any(), expr.String())
+		s.Require().Len(node.Children, 2)
+		s.Equal("not-evaluated", node.Children[1].Kind)
+	}
+
+	// a left operand that does not decide the operator evaluates the right one
+	for op, left := range map[string]trinary.Value{"and": trinary.True, "or": trinary.Unknown} {
+		expr := ast.NewInfixExpression(ast.NewTrinaryLiteral(left, stubRange()), failingBranch(), op, stubRange())
+		_, _, err := evalInfix(context.Background(), ec, &executorImpl{}, p, expr)
+		s.Require().ErrorContains(err, "unary + requires number", expr.String())
+	}
+}
+
+func (s *RuntimeTestSuite) TestEvalInfixCoalescesUnknown() {
+	p := newEvalTestPolicy()
+	ec := NewExecutionContext(p, &executorImpl{})
+	s.Require().NoError(ec.InjectFact(s.T().Context(), "missing", box.Null(), false, nil))
+	fallback := func() ast.Expression { return ast.NewTrinaryLiteral(trinary.False, stubRange()) }
+
+	for _, left := range []ast.Expression{
+		ast.NewTrinaryLiteral(trinary.Unknown, stubRange()),
+		ast.NewNullLiteral(stubRange()),
+		ast.NewIdentifier("missing", stubRange()),
+	} {
+		expr := ast.NewInfixExpression(left, fallback(), "?:", stubRange())
+		got, _, err := evalInfix(context.Background(), ec, &executorImpl{}, p, expr)
+		s.Require().NoError(err, expr.String())
+		s.Equal(trinary.False, got.Any(), expr.String())
+	}
+
+	// a known left operand is kept, even when false, and the fallback is not evaluated
+	for _, left := range []trinary.Value{trinary.True, trinary.False} {
+		expr := ast.NewInfixExpression(ast.NewTrinaryLiteral(left, stubRange()), failingBranch(), "?:", stubRange())
+		got, node, err := evalInfix(WithFullTrace(context.Background()), ec, &executorImpl{}, p, expr)
+		s.Require().NoError(err, expr.String())
+		s.Equal(left, got.Any(), expr.String())
+		s.Equal("not-evaluated", node.Children[1].Kind)
+	}
+}
+
+func (s *RuntimeTestSuite) TestCoalescedOptionalFactDecidesFailClosedOrOpen() {
+	exec := s.executorFromSource(`namespace com/example
+policy gated {
+  fact maybe?: boolean
+  rule closed = { yield maybe ?: false }
+  rule open = { yield maybe ?: true }
+  export decision of closed
+  export decision of open
+}
+`)
+	decide := func(facts map[string]any) (trinary.Value, trinary.Value) {
+		closed, err := exec.ExecRule(context.Background(), "com/example", "gated", "closed", facts)
+		s.Require().NoError(err)
+		open, err := exec.ExecRule(context.Background(), "com/example", "gated", "open", facts)
+		s.Require().NoError(err)
+		return closed.Decision.State, open.Decision.State
+	}
+
+	closed, open := decide(map[string]any{})
+	s.Equal(trinary.False, closed)
+	s.Equal(trinary.True, open)
+
+	closed, open = decide(map[string]any{"maybe": false})
+	s.Equal(trinary.False, closed)
+	s.Equal(trinary.False, open)
+}
+
+func (s *RuntimeTestSuite) TestEvalInfixStrictUnknownErrors() {
+	p := newEvalTestPolicy()
+	ec := NewExecutionContext(p, &executorImpl{})
+	exec := &executorImpl{strictUnknown: true}
+
+	expr := ast.NewInfixExpression(ast.NewTrinaryLiteral(trinary.Unknown, stubRange()), ast.NewIntegerLiteral(1, stubRange()), "+", stubRange())
+	_, _, err := evalInfix(context.Background(), ec, exec, p, expr)
+	s.Require().ErrorContains(err, "unknown operand to '+'")
+
+	// logical operators are not affected
+	expr = ast.NewInfixExpression(ast.NewTrinaryLiteral(trinary.Unknown, stubRange()), ast.NewTrinaryLiteral(trinary.False, stubRange()), "and", stubRange())
+	got, _, err := evalInfix(context.Background(), ec, exec, p, expr)
+	s.Require().NoError(err)
+	s.Equal(trinary.False, got.Any())
+}
+
+func (s *RuntimeTestSuite) TestMissingOptionalFactComparesAsUnknown() {
+	exec := s.executorFromSource(`namespace com/example
+policy limits {
+  fact amount?: number
+  rule large = default false { yield amount > 10 }
+  export decision of large
+}
+`)
+
+	out, err := exec.ExecRule(context.Background(), "com/example", "limits", "large", map[string]any{})
+	s.Require().NoError(err)
+	s.Equal(trinary.Unknown, out.ToTrinary())
+
+	out, err = exec.ExecRule(context.Background(), "com/example", "limits", "large", map[string]any{"amount": 20})
+	s.Require().NoError(err)
+	s.Equal(trinary.True, out.ToTrinary())
+}
@@ -0,0 +1,33 @@
+// SPDX-FileCopyrightText: © 2026 Binaek Sarkar <binaek89@gmail.com>
+// SPDX-License-Identifier: Apache-2.0
+
+package runtime
+
+import (
+	"context"
+	"strings"
+
+	"github.com/sentrie-sh/sentrie/ast"
+	"github.com/sentrie-sh/sentrie/box"
+	"github.com/sentrie-sh/sentrie/index"
+	"github.com/sentrie-sh/sentrie/runtime/trace"
+)
+
+// evalInterpolatedString concatenates the parts of an interpolated string. Interpolated values are
+// converted the way a cast to string converts them.
+func evalInterpolatedString(ctx context.Context, ec *ExecutionContext, exec *executorImpl, p *index.Policy, s *ast.InterpolatedString) (box.Value, *trace.Node, error) {
+	ctx, n, done := trace.New(ctx, s, "interpolated_string", map[string]any{"parts": len(s.Parts)})
+	defer done()
+
+	var sb strings.Builder
+	for _, part := range s.Parts {
+		v, child, err := eval(ctx, ec, exec, p, part)
+		n.Attach(child)
+		if err != nil {
+			return box.Undefined(), n.SetErr(err), err
+		}
+		sb.WriteString(v.String())
+	}
+	out := box.String(sb.String())
+	return out, n.SetResult(out), nil
+}
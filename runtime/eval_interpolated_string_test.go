@@ -0,0 +1,33 @@
+// SPDX-FileCopyrightText: © 2026 Binaek Sarkar <binaek89@gmail.com>
+// SPDX-License-Identifier: Apache-2.0
+
+package runtime
+
+import (
+	"context"
+)
+
+const interpolationPolicy = `namespace com/example
+policy greet {
+  fact name: string
+  fact age: number
+  fact tags: list[string]
+  rule greeting = default "" { yield "hello ${name}, next year you are ${age + 1}" }
+  rule summary = default "" { yield "${name}: ${tags} ${age > 18} \${age}" }
+  export decision of greeting
+  export decision of summary
+}
+`
+
+func (s *RuntimeTestSuite) TestEvalInterpolatedString() {
+	exec := s.executorFromSource(interpolationPolicy)
+	facts := map[string]any{"name": "alice", "age": 41, "tags": []any{"a", "b"}}
+
+	out, err := exec.ExecRule(context.Background(), "com/example", "greet", "greeting", facts)
+	s.Require().NoError(err)
+	s.Equal("hello alice, next year you are 42", out.Decision.Value.Any())
+
+	out, err = exec.ExecRule(context.Background(), "com/example", "greet", "summary", facts)
+	s.Require().NoError(err)
+	s.Equal("alice: [a b] true ${age}", out.Decision.Value.Any())
+}
@@ -0,0 +1,103 @@
+// SPDX-FileCopyrightText: © 2026 Binaek Sarkar <binaek89@gmail.com>
+// SPDX-License-Identifier: Apache-2.0
+
+package runtime
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/sentrie-sh/sentrie/ast"
+	"github.com/sentrie-sh/sentrie/box"
+	"github.com/sentrie-sh/sentrie/trinary"
+)
+
+type logicTruthTableRow struct {
+	left, right, want trinary.Value
+}
+
+// logicTruthTables lists, per operator, the expected value of 'left op right' for every pair
+// of trinary operands, following Kleene logic.
+func logicTruthTables() map[string][]logicTruthTableRow {
+	const (
+		T = trinary.True
+		F = trinary.False
+		U = trinary.Unknown
+	)
+	return map[string][]logicTruthTableRow{
+		"and": {
+			{T, T, T}, {T, F, F}, {T, U, U},
+			{F, T, F}, {F, F, F}, {F, U, F},
+			{U, T, U}, {U, F, F}, {U, U, U},
+		},
+		"or": {
+			{T, T, T}, {T, F, T}, {T, U, T},
+			{F, T, T}, {F, F, F}, {F, U, U},
+			{U, T, T}, {U, F, U}, {U, U, U},
+		},
+		"xor": {
+			{T, T, F}, {T, F, T}, {T, U, U},
+			{F, T, T}, {F, F, F}, {F, U, U},
+			{U, T, U}, {U, F, U}, {U, U, U},
+		},
+	}
+}
+
+func (s *RuntimeTestSuite) TestLogicalOperatorsTruthTables() {
+	p := newEvalTestPolicy()
+	ec := NewExecutionContext(p, &executorImpl{})
+
+	for op, table := range logicTruthTables() {
+		for _, row := range table {
+			expr := ast.NewInfixExpression(ast.NewTrinaryLiteral(row.left, stubRange()), ast.NewTrinaryLiteral(row.right, stubRange()), op, stubRange())
+			got, _, err := evalInfix(context.Background(), ec, &executorImpl{}, p, expr)
+			s.Require().NoError(err, expr.String())
+			s.Equal(row.want, got.Any(), expr.String())
+		}
+	}
+}
+
+func (s *RuntimeTestSuite) TestLogicalOperatorsTreatUndefinedAsUnknown() {
+	p := newEvalTestPolicy()
+	ec := NewExecutionContext(p, &executorImpl{})
+	s.Require().NoError(ec.InjectFact(s.T().Context(), "missing", box.Undefined(), false, nil))
+
+	// an operand that is undefined, rather than the unknown literal, decides the same
+	operand := func(v trinary.Value) ast.Expression {
+		if v == trinary.Unknown {
+			return ast.NewIdentifier("missing", stubRange())
+		}
+		return ast.NewTrinaryLiteral(v, stubRange())
+	}
+	for op, table := range logicTruthTables() {
+		for _, row := range table {
+			expr := ast.NewInfixExpression(operand(row.left), operand(row.right), op, stubRange())
+			got, _, err := evalInfix(context.Background(), ec, &executorImpl{}, p, expr)
+			s.Require().NoError(err, expr.String())
+			s.Equal(row.want, box.TrinaryFrom(got), expr.String())
+		}
+	}
+}
+
+func (s *RuntimeTestSuite) TestLogicalOperatorsShortCircuit() {
+	p := newEvalTestPolicy()
+	ec := NewExecutionContext(p, &executorImpl{})
+
+	for op, table := range logicTruthTables() {
+		for _, row := range table {
+			decided := (op == "and" && row.left == trinary.False) || (op == "or" && row.left == trinary.True)
+			name := fmt.Sprintf("%s %s <failing>", row.left, op)
+
+			expr := ast.NewInfixExpression(ast.NewTrinaryLiteral(row.left, stubRange()), failingBranch(), op, stubRange())
+			got, node, err := evalInfix(WithFullTrace(context.Background()), ec, &executorImpl{}, p, expr)
+			if !decided {
+				// the right operand is needed, so its failure surfaces
+				s.Require().Error(err, name)
+				continue
+			}
+			s.Require().NoError(err, name)
+			s.Equal(row.want, got.Any(), name)
+			s.Equal("not-evaluated", node.Children[1].Kind, name)
+		}
+	}
+}
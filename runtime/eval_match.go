@@ -0,0 +1,49 @@
+// SPDX-FileCopyrightText: © 2026 Binaek Sarkar <binaek89@gmail.com>
+// SPDX-License-Identifier: Apache-2.0
+
+package runtime
+
+import (
+	"context"
+
+	"github.com/sentrie-sh/sentrie/ast"
+	"github.com/sentrie-sh/sentrie/box"
+	"github.com/sentrie-sh/sentrie/index"
+	"github.com/sentrie-sh/sentrie/runtime/trace"
+	"github.com/sentrie-sh/sentrie/trinary"
+)
+
+// evalMatch tries the arms top to bottom and evaluates the result of the first arm whose
+// condition is true. An unknown condition is not a match: it falls through to the next arm,
+// the same as false. Conditions after the taken arm and results of untaken arms are never
+// evaluated.
+func evalMatch(ctx context.Context, ec *ExecutionContext, exec *executorImpl, p *index.Policy, m *ast.MatchExpression) (box.Value, *trace.Node, error) {
+	ctx, n, done := trace.New(ctx, m, "match", map[string]any{})
+	defer done()
+
+	// the trace lists every condition and result in source order, the skipped ones as not evaluated
+	result, taken := m.Else, -1
+	for i, arm := range m.Arms {
+		c, cn, err := eval(ctx, ec, exec, p, arm.Condition)
+		n.Attach(cn)
+		if err != nil {
+			return box.Value{}, n.SetErr(err), err
+		}
+		if box.TrinaryFrom(c) == trinary.True {
+			result, taken = arm.Result, i
+			break
+		}
+		attachNotEvaluated(ctx, n, arm.Result)
+	}
+
+	v, rn, err := eval(ctx, ec, exec, p, result)
+	n.Attach(rn)
+	if taken >= 0 {
+		for _, arm := range m.Arms[taken+1:] {
+			attachNotEvaluated(ctx, n, arm.Condition, arm.Result)
+		}
+		attachNotEvaluated(ctx, n, m.Else)
+	}
+	n.SetResult(v)
+	return v, n, err
+}
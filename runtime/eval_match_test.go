@@ -0,0 +1,117 @@
+// SPDX-FileCopyrightText: © 2026 Binaek Sarkar <binaek89@gmail.com>
+// SPDX-License-Identifier: Apache-2.0
+
+package runtime
+
+import (
+	"github.com/sentrie-sh/sentrie/ast"
+	"github.com/sentrie-sh/sentrie/trinary"
+)
+
+func matchArm(cond trinary.Value, result ast.Expression) ast.MatchArm {
+	return ast.MatchArm{Condition: ast.NewTrinaryLiteral(cond, stubRange()), Result: result}
+}
+
+func (s *RuntimeTestSuite) TestEvalMatchFirstTrueArmWins() {
+	p := newEvalTestPolicy()
+	ec := NewExecutionContext(p, &executorImpl{})
+
+	m := ast.NewMatchExpression([]ast.MatchArm{
+		matchArm(trinary.False, failingBranch()),
+		matchArm(trinary.True, ast.NewIntegerLiteral(1, stubRange())),
+		// neither the condition nor the result of a later arm is evaluated
+		{Condition: failingBranch(), Result: failingBranch()},
+		matchArm(trinary.True, failingBranch()),
+	}, failingBranch(), stubRange())
+
+	got, node, err := evalMatch(WithFullTrace(s.T().Context()), ec, &executorImpl{}, p, m)
+	s.Require().NoError(err)
+	s.Equal(1.0, got.Any())
+	// every condition and result in source order, the skipped ones not evaluated
+	kinds := make([]string, len(node.Children))
+	for i, child := range node.Children {
+		kinds[i] = child.Kind
+	}
+	s.Equal([]string{
+		"trinary_literal", "not-evaluated",
+		"trinary_literal", "integer_literal",
+		"not-evaluated", "not-evaluated",
+		"not-evaluated", "not-evaluated",
+		"not-evaluated",
+	}, kinds)
+}
+
+func (s *RuntimeTestSuite) TestEvalMatchUnknownFallsThrough() {
+	p := newEvalTestPolicy()
+	ec := NewExecutionContext(p, &executorImpl{})
+
+	m := ast.NewMatchExpression([]ast.MatchArm{
+		matchArm(trinary.Unknown, failingBranch()),
+		matchArm(trinary.True, ast.NewIntegerLiteral(2, stubRange())),
+	}, failingBranch(), stubRange())
+
+	got, _, err := evalMatch(s.T().Context(), ec, &executorImpl{}, p, m)
+	s.Require().NoError(err)
+	s.Equal(2.0, got.Any())
+}
+
+func (s *RuntimeTestSuite) TestEvalMatchElseWhenNoArmIsTaken() {
+	p := newEvalTestPolicy()
+	ec := NewExecutionContext(p, &executorImpl{})
+
+	m := ast.NewMatchExpression([]ast.MatchArm{
+		matchArm(trinary.False, failingBranch()),
+		matchArm(trinary.Unknown, failingBranch()),
+	}, ast.NewIntegerLiteral(3, stubRange()), stubRange())
+
+	got, _, err := evalMatch(s.T().Context(), ec, &executorImpl{}, p, m)
+	s.Require().NoError(err)
+	s.Equal(3.0, got.Any())
+}
+
+func (s *RuntimeTestSuite) TestEvalMatchConditionErrorStopsEvaluation() {
+	p := newEvalTestPolicy()
+	ec := NewExecutionContext(p, &executorImpl{})
+
+	m := ast.NewMatchExpression([]ast.MatchArm{
+		{Condition: failingBranch(), Result: ast.NewIntegerLiteral(1, stubRange())},
+	}, ast.NewIntegerLiteral(2, stubRange()), stubRange())
+
+	_, node, err := evalMatch(s.T().Context(), ec, &executorImpl{}, p, m)
+	s.Require().ErrorContains(err, "unary + requires number")
+	s.NotEmpty(node.Err)
+}
+
+func (s *RuntimeTestSuite) TestMatchExpressionInPolicy() {
+	exec := s.executorFromSource(`namespace com/example/brackets
+policy bracket {
+  fact age?: number
+
+  rule label = default "" {
+    yield match {
+      when age >= 65 then "senior"
+      when age >= 18 then "adult"
+      else "minor"
+    }
+  }
+
+  export decision of label
+}`)
+
+	testCases := []struct {
+		facts    map[string]any
+		expected string
+	}{
+		{map[string]any{"age": 70}, "senior"},
+		{map[string]any{"age": 30}, "adult"},
+		{map[string]any{"age": 10}, "minor"},
+		// a missing age makes every condition unknown, so the else arm is taken
+		{map[string]any{}, "minor"},
+	}
+	for _, tc := range testCases {
+		outputs, err := exec.ExecPolicy(s.T().Context(), "com/example/brackets", "bracket", tc.facts)
+		s.Require().NoError(err)
+		s.Require().Len(outputs, 1)
+		s.Equal(tc.expected, outputs[0].Decision.Value.Any())
+	}
+}
@@ -0,0 +1,61 @@
+// SPDX-FileCopyrightText: © 2026 Binaek Sarkar <binaek89@gmail.com>
+// SPDX-License-Identifier: Apache-2.0
+
+package runtime
+
+import (
+	"context"
+	"fmt"
+	"maps"
+
+	"github.com/sentrie-sh/sentrie/ast"
+	"github.com/sentrie-sh/sentrie/box"
+	"github.com/sentrie-sh/sentrie/index"
+	"github.com/sentrie-sh/sentrie/runtime/trace"
+	"github.com/sentrie-sh/sentrie/xerr"
+)
+
+// evalProject builds a dict from the entries of a projection, in order, so that a field set after
+// a spread overrides the spread one. Spreading null or undefined contributes no fields.
+func evalProject(ctx context.Context, ec *ExecutionContext, exec *executorImpl, p *index.Policy, t *ast.ProjectExpression) (box.Value, *trace.Node, error) {
+	ctx, n, done := trace.New(ctx, t, "project", map[string]any{"as": t.As})
+	defer done()
+
+	source, child, err := eval(ctx, ec, exec, p, t.Source)
+	n.Attach(child)
+	if err != nil {
+		return box.Undefined(), n.SetErr(err), err
+	}
+
+	if t.As != "" {
+		ec = ec.AttachedChildContext()
+		defer ec.Dispose()
+		ec.SetLocal(t.As, source, true)
+	}
+
+	out := map[string]box.Value{}
+	for _, entry := range t.Entries {
+		v, child, err := eval(ctx, ec, exec, p, entry.Value)
+		n.Attach(child)
+		if err != nil {
+			return box.Undefined(), n.SetErr(err), err
+		}
+
+		if !entry.Spread {
+			out[entry.Key] = v
+			continue
+		}
+		if v.IsUndefined() || v.IsNull() {
+			continue
+		}
+		fields, ok := v.DictValue()
+		if !ok {
+			err := fmt.Errorf("cannot spread %s into a projection at %s: %w", v.Kind(), entry.Value.Span(), xerr.ErrInvalidType(v.Kind().String(), "dict"))
+			return box.Undefined(), n.SetErr(err), err
+		}
+		maps.Copy(out, fields)
+	}
+
+	result := box.Dict(out)
+	return result, n.SetResult(result), nil
+}
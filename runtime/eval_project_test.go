@@ -0,0 +1,102 @@
+// SPDX-FileCopyrightText: © 2026 Binaek Sarkar <binaek89@gmail.com>
+// SPDX-License-Identifier: Apache-2.0
+
+package runtime
+
+import (
+	"context"
+
+	"github.com/sentrie-sh/sentrie/ast"
+	"github.com/sentrie-sh/sentrie/box"
+	"github.com/sentrie-sh/sentrie/trinary"
+)
+
+func (s *RuntimeTestSuite) evalProjectOver(user box.Value, as string, entries ...ast.ProjectEntry) (box.Value, error) {
+	p := newEvalTestPolicy()
+	ec := NewExecutionContext(p, &executorImpl{})
+	s.Require().NoError(ec.InjectFact(context.Background(), "user", user, false, nil))
+
+	expr := ast.NewProjectExpression(ast.NewIdentifier("user", stubRange()), as, entries, stubRange())
+	out, _, err := eval(context.Background(), ec, &executorImpl{}, p, expr)
+	return out, err
+}
+
+func projectField(key string, value ast.Expression) ast.ProjectEntry {
+	return ast.ProjectEntry{Key: key, Value: value}
+}
+
+func projectSpread(value ast.Expression) ast.ProjectEntry {
+	return ast.ProjectEntry{Value: value, Spread: true}
+}
+
+func userField(of, field string) ast.Expression {
+	return ast.NewFieldAccessExpression(ast.NewIdentifier(of, stubRange()), field, stubRange())
+}
+
+func (s *RuntimeTestSuite) TestEvalProjectReshapesSource() {
+	user := box.Dict(map[string]box.Value{
+		"id":       box.Number(7),
+		"name":     box.String("alice"),
+		"password": box.String("hunter2"),
+	})
+
+	// project user { id: user.id, handle: user.name }
+	out, err := s.evalProjectOver(user, "",
+		projectField("id", userField("user", "id")),
+		projectField("handle", userField("user", "name")),
+	)
+	s.Require().NoError(err)
+	s.Equal(map[string]any{"id": float64(7), "handle": "alice"}, out.Any())
+}
+
+func (s *RuntimeTestSuite) TestEvalProjectSpreadThenOverride() {
+	user := box.Dict(map[string]box.Value{
+		"id":       box.Number(7),
+		"name":     box.String("alice"),
+		"password": box.String("hunter2"),
+	})
+
+	// project user as u { ...u, password: "<redacted>", admin: false }
+	out, err := s.evalProjectOver(user, "u",
+		projectSpread(ast.NewIdentifier("u", stubRange())),
+		projectField("password", ast.NewStringLiteral("<redacted>", stubRange())),
+		projectField("admin", ast.NewTrinaryLiteral(trinary.False, stubRange())),
+	)
+	s.Require().NoError(err)
+	s.Equal(map[string]any{
+		"id":       float64(7),
+		"name":     "alice",
+		"password": "<redacted>",
+		"admin":    trinary.False,
+	}, out.Any())
+}
+
+func (s *RuntimeTestSuite) TestEvalProjectAliasIsScopedToEntries() {
+	p := newEvalTestPolicy()
+	ec := NewExecutionContext(p, &executorImpl{})
+	expr := ast.NewProjectExpression(ast.NewIntegerLiteral(1, stubRange()), "u", []ast.ProjectEntry{
+		{Key: "v", Value: ast.NewIdentifier("u", stubRange())},
+	}, stubRange())
+
+	out, _, err := eval(context.Background(), ec, &executorImpl{}, p, expr)
+	s.Require().NoError(err)
+	s.Equal(map[string]any{"v": float64(1)}, out.Any())
+
+	_, ok := ec.GetLocal("u")
+	s.False(ok)
+}
+
+func (s *RuntimeTestSuite) TestEvalProjectSpreadingNonDictErrors() {
+	_, err := s.evalProjectOver(box.Dict(map[string]box.Value{"id": box.Number(7)}), "",
+		projectSpread(userField("user", "id")),
+	)
+	s.Require().Error(err)
+	s.Contains(err.Error(), "cannot spread")
+
+	out, err := s.evalProjectOver(box.Dict(map[string]box.Value{}), "",
+		projectSpread(userField("user", "missing")),
+		projectField("id", ast.NewIntegerLiteral(1, stubRange())),
+	)
+	s.Require().NoError(err)
+	s.Equal(map[string]any{"id": float64(1)}, out.Any())
+}
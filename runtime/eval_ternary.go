@@ -23,6 +23,7 @@ import (
 	"github.com/sentrie-sh/sentrie/box"
 	"github.com/sentrie-sh/sentrie/index"
 	"github.com/sentrie-sh/sentrie/runtime/trace"
+	"github.com/sentrie-sh/sentrie/trinary"
 )
 
 func evalTernary(ctx context.Context, ec *ExecutionContext, exec *executorImpl, p *index.Policy, t *ast.TernaryExpression) (box.Value, *trace.Node, error) {
@@ -34,14 +35,24 @@ func evalTernary(ctx context.Context, ec *ExecutionContext, exec *executorImpl,
 	if err != nil {
 		return box.Value{}, n.SetErr(err), err
 	}
-	if box.TrinaryFrom(c).IsTrue() {
-		v, tn, err := eval(ctx, ec, exec, p, t.ThenBranch)
-		n.Attach(tn)
+
+	// only the taken branch is evaluated; an unknown condition takes neither
+	switch box.TrinaryFrom(c) {
+	case trinary.True:
+		v, bn, err := eval(ctx, ec, exec, p, t.ThenBranch)
+		n.Attach(bn)
+		attachNotEvaluated(ctx, n, t.ElseBranch)
+		n.SetResult(v)
+		return v, n, err
+	case trinary.False:
+		attachNotEvaluated(ctx, n, t.ThenBranch)
+		v, bn, err := eval(ctx, ec, exec, p, t.ElseBranch)
+		n.Attach(bn)
 		n.SetResult(v)
 		return v, n, err
+	default:
+		attachNotEvaluated(ctx, n, t.ThenBranch, t.ElseBranch)
+		out := box.Trinary(trinary.Unknown)
+		return out, n.SetResult(out), nil
 	}
-	v, en, err := eval(ctx, ec, exec, p, t.ElseBranch)
-	n.Attach(en)
-	n.SetResult(v)
-	return v, n, err
 }
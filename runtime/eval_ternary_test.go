@@ -0,0 +1,62 @@
+// SPDX-FileCopyrightText: © 2026 Binaek Sarkar <binaek89@gmail.com>
+// SPDX-License-Identifier: Apache-2.0
+
+package runtime
+
+import (
+	"github.com/sentrie-sh/sentrie/ast"
+	"github.com/sentrie-sh/sentrie/box"
+	"github.com/sentrie-sh/sentrie/trinary"
+)
+
+// failingBranch errors when evaluated, so a test passes only if the branch is skipped.
+func failingBranch() ast.Expression {
+	return ast.NewUnaryExpression("+", ast.NewStringLiteral("not a number", stubRange()), stubRange())
+}
+
+func (s *RuntimeTestSuite) TestEvalTernaryEvaluatesOnlyTheTakenBranch() {
+	p := newEvalTestPolicy()
+	ec := NewExecutionContext(p, &executorImpl{})
+
+	then := ast.NewTernaryExpression(ast.NewTrinaryLiteral(trinary.True, stubRange()), ast.NewIntegerLiteral(1, stubRange()), failingBranch(), stubRange())
+	got, node, err := evalTernary(WithFullTrace(s.T().Context()), ec, &executorImpl{}, p, then)
+	s.Require().NoError(err)
+	s.Equal(1.0, got.Any())
+	s.Require().Len(node.Children, 3)
+	s.Equal("not-evaluated", node.Children[2].Kind)
+
+	// the skipped branch is only traced when the full trace is asked for
+	_, node, err = evalTernary(s.T().Context(), ec, &executorImpl{}, p, then)
+	s.Require().NoError(err)
+	s.Len(node.Children, 2)
+
+	otherwise := ast.NewTernaryExpression(ast.NewTrinaryLiteral(trinary.False, stubRange()), failingBranch(), ast.NewIntegerLiteral(2, stubRange()), stubRange())
+	got, node, err = evalTernary(WithFullTrace(s.T().Context()), ec, &executorImpl{}, p, otherwise)
+	s.Require().NoError(err)
+	s.Equal(2.0, got.Any())
+	s.Require().Len(node.Children, 3)
+	s.Equal("not-evaluated", node.Children[1].Kind)
+
+	// the branches still fail when taken
+	_, _, err = evalTernary(s.T().Context(), ec, &executorImpl{}, p, ast.NewTernaryExpression(ast.NewTrinaryLiteral(trinary.True, stubRange()), failingBranch(), ast.NewIntegerLiteral(2, stubRange()), stubRange()))
+	s.Require().ErrorContains(err, "unary + requires number")
+}
+
+func (s *RuntimeTestSuite) TestEvalTernaryUnknownConditionTakesNeitherBranch() {
+	p := newEvalTestPolicy()
+	ec := NewExecutionContext(p, &executorImpl{})
+	s.Require().NoError(ec.InjectFact(s.T().Context(), "missing", box.Null(), false, nil))
+
+	for _, cond := range []ast.Expression{
+		ast.NewTrinaryLiteral(trinary.Unknown, stubRange()),
+		ast.NewIdentifier("missing", stubRange()),
+	} {
+		expr := ast.NewTernaryExpression(cond, failingBranch(), failingBranch(), stubRange())
+		got, node, err := evalTernary(WithFullTrace(s.T().Context()), ec, &executorImpl{}, p, expr)
+		s.Require().NoError(err)
+		s.Equal(trinary.Unknown, got.Any())
+		s.Require().Len(node.Children, 3)
+		s.Equal("not-evaluated", node.Children[1].Kind)
+		s.Equal("not-evaluated", node.Children[2].Kind)
+	}
+}
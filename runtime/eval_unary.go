@@ -42,28 +42,35 @@ func evalUnary(ctx context.Context, ec *ExecutionContext, exec *executorImpl, p
 		return box.Undefined(), node, nil
 	}
 
+	out, err := applyUnary(u, v)
+	if err != nil {
+		return box.Value{}, node.SetErr(err), err
+	}
+	return out, node.SetResult(out), nil
+}
+
+// applyUnary applies the operator of u to its evaluated operand v.
+func applyUnary(u *ast.UnaryExpression, v box.Value) (box.Value, error) {
+	if v.IsUndefined() {
+		return box.Undefined(), nil
+	}
+
 	switch u.Operator {
 	case "!", "not":
-		out := box.Trinary(box.TrinaryFrom(v).Not())
-		return out, node.SetResult(out), nil
+		return box.Trinary(box.TrinaryFrom(v).Not()), nil
 	case "+":
 		num, ok := v.NumberValue()
 		if !ok {
-			err := fmt.Errorf("unary + requires number")
-			return box.Value{}, node.SetErr(err), err
+			return box.Value{}, fmt.Errorf("unary + requires number")
 		}
-		out := box.Number(num)
-		return out, node.SetResult(out), nil
+		return box.Number(num), nil
 	case "-":
 		num, ok := v.NumberValue()
 		if !ok {
-			err := fmt.Errorf("unary - requires number")
-			return box.Value{}, node.SetErr(err), err
+			return box.Value{}, fmt.Errorf("unary - requires number")
 		}
-		out := box.Number(-num)
-		return out, node.SetResult(out), nil
+		return box.Number(-num), nil
 	default:
-		err := fmt.Errorf("unsupported unary op: %s", u.Operator)
-		return box.Value{}, node.SetErr(err), err
+		return box.Value{}, fmt.Errorf("unsupported unary op: %s", u.Operator)
 	}
 }
@@ -74,3 +74,45 @@ func (s *RuntimeTestSuite) TestEvalUnaryUndefinedPassthrough() {
 	s.Require().NoError(err)
 	s.Require().True(got.IsUndefined())
 }
+
+func (s *RuntimeTestSuite) TestEvalUnaryNotDeMorganUnderTrinaryLogic() {
+	p := newEvalTestPolicy()
+	ec := NewExecutionContext(p, &executorImpl{})
+	values := []trinary.Value{trinary.True, trinary.False, trinary.Unknown}
+
+	lit := func(v trinary.Value) ast.Expression { return ast.NewTrinaryLiteral(v, stubRange()) }
+	not := func(e ast.Expression) ast.Expression { return ast.NewUnaryExpression("not", e, stubRange()) }
+	infix := func(l ast.Expression, op string, r ast.Expression) ast.Expression {
+		return ast.NewInfixExpression(l, r, op, stubRange())
+	}
+	evalTo := func(e ast.Expression) any {
+		got, _, err := eval(s.T().Context(), ec, &executorImpl{}, p, e)
+		s.Require().NoError(err)
+		return got.Any()
+	}
+
+	for _, a := range values {
+		for _, b := range values {
+			// not (a and b) == not a or not b
+			s.Equal(evalTo(infix(not(lit(a)), "or", not(lit(b)))), evalTo(not(infix(lit(a), "and", lit(b)))), "not (%s and %s)", a, b)
+			// not (a or b) == not a and not b
+			s.Equal(evalTo(infix(not(lit(a)), "and", not(lit(b)))), evalTo(not(infix(lit(a), "or", lit(b)))), "not (%s or %s)", a, b)
+		}
+		// double negation is the identity, unknown included
+		s.Equal(a, evalTo(not(not(lit(a)))))
+	}
+
+	s.Equal(trinary.Unknown, evalTo(not(infix(lit(trinary.True), "and", lit(trinary.Unknown)))))
+	s.Equal(trinary.True, evalTo(not(infix(lit(trinary.False), "and", lit(trinary.Unknown)))))
+}
+
+func (s *RuntimeTestSuite) TestEvalUnaryNotOfComparison() {
+	p := newEvalTestPolicy()
+	ec := NewExecutionContext(p, &executorImpl{})
+
+	// not (3 is 4)
+	cmp := ast.NewInfixExpression(ast.NewIntegerLiteral(3, stubRange()), ast.NewIntegerLiteral(4, stubRange()), "is", stubRange())
+	got, _, err := evalUnary(s.T().Context(), ec, &executorImpl{}, p, ast.NewUnaryExpression("not", cmp, stubRange()))
+	s.Require().NoError(err)
+	s.Equal(trinary.True, got.Any())
+}
@@ -53,6 +53,9 @@ type ExecutionContext struct {
 	facts map[string]injectedFact        // injected via WITH
 	lets  map[string]*ast.VarDeclaration // policy-scoped lets
 
+	letValues    *letCache // values of the policy lets, shared by the rules of one request
+	deriveValues *letCache // results of derive calls by derive and arguments, shared likewise
+
 	locals map[string]box.Value // evaluated local values
 
 	modules map[string]*ModuleBinding // alias -> module binding (for `use`)
@@ -69,15 +72,17 @@ func (ec *ExecutionContext) IsLetInjected(name string) bool {
 
 func NewExecutionContext(policy *index.Policy, executor Executor) *ExecutionContext {
 	return &ExecutionContext{
-		parent:    nil,
-		createdAt: time.Now(),
-		policy:    policy,
-		refStack:  make([]string, 0), // reference stack
-		facts:     make(map[string]injectedFact),
-		locals:    make(map[string]box.Value),
-		lets:      make(map[string]*ast.VarDeclaration),
-		modules:   make(map[string]*ModuleBinding),
-		executor:  executor,
+		parent:       nil,
+		createdAt:    time.Now(),
+		policy:       policy,
+		refStack:     make([]string, 0), // reference stack
+		facts:        make(map[string]injectedFact),
+		locals:       make(map[string]box.Value),
+		lets:         make(map[string]*ast.VarDeclaration),
+		letValues:    newLetCache(),
+		deriveValues: newLetCache(),
+		modules:      make(map[string]*ModuleBinding),
+		executor:     executor,
 	}
 }
 
@@ -94,16 +99,31 @@ func (ec *ExecutionContext) AttachedChildContext() *ExecutionContext {
 	copy(stack, ec.refStack)
 
 	return &ExecutionContext{
-		parent:    ec,
-		createdAt: ec.createdAt,
-		refStack:  stack,                                // inherit the call stack from the parent
-		policy:    ec.policy,                            // inherit the policy from the parent
-		modules:   ec.modules,                           // inherit the module bindings from the parent
-		executor:  ec.executor,                          // inherit the executor from the parent
-		facts:     nil,                                  // a child context should not have facts at all
-		locals:    make(map[string]box.Value),           // local values
-		lets:      make(map[string]*ast.VarDeclaration), // local let declarations
+		parent:       ec,
+		createdAt:    ec.createdAt,
+		refStack:     stack,                                // inherit the call stack from the parent
+		policy:       ec.policy,                            // inherit the policy from the parent
+		modules:      ec.modules,                           // inherit the module bindings from the parent
+		letValues:    ec.letValues,                         // inherit the values of the policy lets from the parent
+		deriveValues: ec.deriveValues,                      // inherit the results of derive calls from the parent
+		executor:     ec.executor,                          // inherit the executor from the parent
+		facts:        nil,                                  // a child context should not have facts at all
+		locals:       make(map[string]box.Value),           // local values
+		lets:         make(map[string]*ast.VarDeclaration), // local let declarations
+	}
+}
+
+// DeriveContext creates a child context of the root context for the body of a derive or a
+// policy let, which sees the facts, lets and rules of the policy but none of the locals between
+// the root and ec. The reference stack of ec is inherited, so that a cycle is detected.
+func (ec *ExecutionContext) DeriveContext() *ExecutionContext {
+	root := ec
+	for root.parent != nil {
+		root = root.parent
 	}
+	child := root.AttachedChildContext()
+	child.refStack = ec.GetRefStack()
+	return child
 }
 
 func (ec *ExecutionContext) CreatedAt() time.Time {
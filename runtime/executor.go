@@ -20,8 +20,11 @@ import (
 	"context"
 	stdErr "errors"
 	"fmt"
+	"maps"
 	"path/filepath"
+	"slices"
 	"sync"
+	"time"
 
 	"github.com/binaek/perch"
 	"github.com/dop251/goja"
@@ -40,7 +43,14 @@ type NewExecutorOption func(*executorImpl)
 // The number of Megabytes to allocate for the call memoize cache
 func WithCallMemoizeCacheSize(size int) NewExecutorOption {
 	return func(e *executorImpl) {
-		e.callMemoizePerch = perch.New[any](size << 20 /* size in megabytes */)
+		e.callMemoizeCacheSize = size
+	}
+}
+
+// The number of Megabytes to allocate for the module binding cache
+func WithModuleBindingCacheSize(size int) NewExecutorOption {
+	return func(e *executorImpl) {
+		e.moduleBindingCacheSize = size
 	}
 }
 
@@ -50,58 +60,97 @@ type ExecutorOutput struct {
 	RuleName    string              `json:"rule"`
 	Decision    *Decision           `json:"decision"`
 	Attachments DecisionAttachments `json:"attachments"`
+	Obligations []Directive         `json:"obligations,omitempty"`
+	Advice      []Directive         `json:"advice,omitempty"`
 	RuleNode    *trace.Node         `json:"trace"`
+	// Effect is the declared effect of the rule, see index.RuleEffect
+	Effect index.RuleEffect `json:"effect,omitempty"`
+	// Metadata is the declared metadata of the evaluated policy
+	Metadata index.PolicyMetadata `json:"-"`
 }
 
 func (e *ExecutorOutput) ToTrinary() trinary.Value {
 	return e.Decision.State
 }
 
+// Permits is whether the decision permits under the effect of the rule: a deny rule deciding
+// true denies and one deciding false permits, other rules permit when they decide true.
+func (e *ExecutorOutput) Permits() trinary.Value {
+	if e.Effect == index.RuleEffectDeny {
+		return e.Decision.State.Not()
+	}
+	return e.Decision.State
+}
+
 type Executor interface {
 	ExecPolicy(ctx context.Context, namespace, policy string, facts map[string]any) ([]*ExecutorOutput, error)
 	ExecRule(ctx context.Context, namespace, policy, rule string, facts map[string]any) (*ExecutorOutput, error)
-	Index() *index.Index
+	ValidateFacts(ctx context.Context, namespace, policy string, facts map[string]any) error
+	Index() *index.IndexView
 }
 
 // executorImpl ties together the index, JS loader, and evaluation.
 type executorImpl struct {
-	index              *index.Index
+	index              *index.IndexView
 	jsRegistry         *js.Registry
 	moduleBindingPerch *perch.Perch[*ModuleBinding] // --> (policy.useAlias) -> module binding
 	callMemoizePerch   *perch.Perch[any]
+	// moduleBindingCacheSize and callMemoizeCacheSize are the megabytes reserved for the caches
+	moduleBindingCacheSize int
+	callMemoizeCacheSize   int
+	// strictUnknown makes an unknown operand to an arithmetic or comparison operator an error
+	strictUnknown bool
+	// ruleParallelism is the most exported rules of a policy evaluated at once by ExecPolicy,
+	// see WithRuleParallelism
+	ruleParallelism int
+	// compileAfter is the number of evaluations of a policy after which its rule expressions are
+	// compiled, see WithCompileAfter
+	compileAfter int64
+	// resultCache, when set, holds rule outputs across calls and across executors
+	resultCache *ResultCache
+	// resultCacheScopes holds the resultCacheScope of each policy, by FQN
+	resultCacheScopes sync.Map
+	// sensitiveScopes holds the sensitiveScope of each policy, by FQN
+	sensitiveScopes     sync.Map
+	packModulesOnce     sync.Once
+	packModulesHash     string
+	packModulesVolatile bool
+	packModulesErr      error
+}
+
+// WithStrictUnknown makes an unknown or undefined operand to an arithmetic or comparison operator
+// an evaluation error instead of yielding unknown.
+func WithStrictUnknown() NewExecutorOption {
+	return func(e *executorImpl) {
+		e.strictUnknown = true
+	}
+}
+
+// WithRuleParallelism evaluates at most n exported rules of a policy at once in ExecPolicy; 1
+// evaluates them one after the other. Zero, the default, evaluates all of them at once.
+func WithRuleParallelism(n int) NewExecutorOption {
+	return func(e *executorImpl) {
+		e.ruleParallelism = n
+	}
 }
 
 // NewExecutor builds an Executor with built-in @sentra/* modules registered.
-func NewExecutor(idx *index.Index, opts ...NewExecutorOption) (Executor, error) {
+// The view is obtained from a committed index with index.Index.View.
+func NewExecutor(idx *index.IndexView, opts ...NewExecutorOption) (Executor, error) {
 	exec := &executorImpl{
-		index:              idx,
-		jsRegistry:         js.NewRegistry(idx.Pack.Location),
-		moduleBindingPerch: perch.New[*ModuleBinding](100 << 20 /* 100 MB */), // --> (policy.useAlias) -> module binding
-		callMemoizePerch:   perch.New[any](10 << 20 /* 10 MB */),
-	}
-
-	exec.jsRegistry.RegisterGoBuiltin("uuid", js.BuiltinUuidGo)
-	exec.jsRegistry.RegisterGoBuiltin("crypto", js.BuiltinCryptoGo)
-	exec.jsRegistry.RegisterGoBuiltin("time", js.BuiltinTimeGo)
-	exec.jsRegistry.RegisterGoBuiltin("encoding", js.BuiltinEncodingGo)
-	exec.jsRegistry.RegisterGoBuiltin("collection", js.BuiltinCollectionGo)
-	exec.jsRegistry.RegisterGoBuiltin("jwt", js.BuiltinJwtGo)
-	exec.jsRegistry.RegisterGoBuiltin("regex", js.BuiltinRegexGo)
-	exec.jsRegistry.RegisterGoBuiltin("net", js.BuiltinNetGo)
-	exec.jsRegistry.RegisterGoBuiltin("hash", js.BuiltinHashGo)
-	exec.jsRegistry.RegisterGoBuiltin("url", js.BuiltinUrlGo)
-	exec.jsRegistry.RegisterGoBuiltin("string", js.BuiltinStringGo)
-	exec.jsRegistry.RegisterGoBuiltin("json", js.BuiltinJsonGo)
-	exec.jsRegistry.RegisterGoBuiltin("semver", js.BuiltinSemverGo)
-	exec.jsRegistry.RegisterGoBuiltin("math", js.BuiltinMathGo)
-
-	// Register TypeScript builtin module for JavaScript globals
-	exec.jsRegistry.RegisterTSBuiltin("js", string(js.BuiltinJSTS))
+		index:                  idx,
+		jsRegistry:             newBuiltinRegistry(idx.Pack().Location),
+		moduleBindingCacheSize: 100,
+		callMemoizeCacheSize:   10,
+	}
 
 	for _, opt := range opts {
 		opt(exec)
 	}
 
+	exec.moduleBindingPerch = perch.New[*ModuleBinding](exec.moduleBindingCacheSize << 20 /* size in megabytes */) // --> (policy.useAlias) -> module binding
+	exec.callMemoizePerch = perch.New[any](exec.callMemoizeCacheSize << 20 /* size in megabytes */)
+
 	// Reserve the cache slots
 	exec.moduleBindingPerch.Reserve()
 
@@ -110,51 +159,117 @@ func NewExecutor(idx *index.Index, opts ...NewExecutorOption) (Executor, error)
 	return exec, nil
 }
 
-func (e *executorImpl) Index() *index.Index {
+// newBuiltinRegistry returns a module registry for the pack at location with the @sentrie/*
+// builtin modules registered.
+func newBuiltinRegistry(location string) *js.Registry {
+	registry := js.NewRegistry(location)
+
+	registry.RegisterGoBuiltin("uuid", js.BuiltinUuidGo, js.Volatile)
+	registry.RegisterGoBuiltin("crypto", js.BuiltinCryptoGo, js.Pure)
+	registry.RegisterGoBuiltin("time", js.BuiltinTimeGo, js.Volatile)
+	registry.RegisterGoBuiltin("encoding", js.BuiltinEncodingGo, js.Pure)
+	registry.RegisterGoBuiltin("collection", js.BuiltinCollectionGo, js.Pure)
+	registry.RegisterGoBuiltin("jwt", js.BuiltinJwtGo, js.Pure)
+	registry.RegisterGoBuiltin("regex", js.BuiltinRegexGo, js.Pure)
+	registry.RegisterGoBuiltin("net", js.BuiltinNetGo, js.Pure)
+	registry.RegisterGoBuiltin("hash", js.BuiltinHashGo, js.Pure)
+	registry.RegisterGoBuiltin("url", js.BuiltinUrlGo, js.Pure)
+	registry.RegisterGoBuiltin("string", js.BuiltinStringGo, js.Pure)
+	registry.RegisterGoBuiltin("json", js.BuiltinJsonGo, js.Pure)
+	registry.RegisterGoBuiltin("semver", js.BuiltinSemverGo, js.Pure)
+	registry.RegisterGoBuiltin("math", js.BuiltinMathGo, js.Pure)
+	registry.RegisterGoBuiltin("std", js.BuiltinStdGo, js.Pure)
+	registry.RegisterVolatileFunctions("std", "now")
+
+	// Register TypeScript builtin module for JavaScript globals; random and now make it volatile
+	registry.RegisterTSBuiltin("js", string(js.BuiltinJSTS), js.Volatile)
+
+	return registry
+}
+
+func (e *executorImpl) Index() *index.IndexView {
 	return e.index
 }
 
-// ExecPolicy executes all exported rules and returns the results
+// ExecPolicy executes all exported rules and returns the results, ordered by rule name. The
+// rules are evaluated concurrently, at most as many at once as set with WithRuleParallelism.
+// Each rule is evaluated in an execution context of its own, and the rules only share the
+// values of the lets, the results of derive calls, which are safe for concurrent use, and the
+// start time of the request.
 func (e *executorImpl) ExecPolicy(ctx context.Context, namespace, policy string, facts map[string]any) ([]*ExecutorOutput, error) {
 	p, err := e.index.ResolvePolicy(namespace, policy)
 	if err != nil {
 		return nil, err
 	}
 
-	theLock := &sync.Mutex{}
-	var compositeErr error
-	outputs := make([]*ExecutorOutput, 0, len(p.RuleExports))
-	wg := &sync.WaitGroup{}
-	for _, ruleExport := range p.RuleExports {
-		wg.Go(func() {
-			defer func() {
-				if r := recover(); r != nil {
-					compositeErr = stdErr.New("panic in ExecRule: " + fmt.Sprintf("%v", r))
-				}
-			}()
-
-			output, err := e.ExecRule(ctx, namespace, policy, ruleExport.RuleName, facts)
-
-			// now that we have the output, we can add it to the outputs slice,
-			// but we need to lock the mutex to avoid race conditions
-			theLock.Lock()
-			defer theLock.Unlock()
-			if err != nil {
-				compositeErr = stdErr.Join(compositeErr, err)
-				return
+	// the exported rules share the values of the lets and the results of derive calls, so that
+	// each is evaluated once, and all of them see the same now()
+	req := newRuleRequest()
+
+	names := slices.Sorted(maps.Keys(p.RuleExports))
+	outputs := make([]*ExecutorOutput, len(names))
+	errs := make([]error, len(names))
+	exec := func(i int) {
+		defer func() {
+			if r := recover(); r != nil {
+				errs[i] = stdErr.New("panic in ExecRule: " + fmt.Sprintf("%v", r))
 			}
+		}()
+		outputs[i], errs[i] = e.execRequestRule(ctx, req, namespace, policy, p.RuleExports[names[i]].RuleName, facts)
+	}
 
-			// add the output to the outputs slice
-			outputs = append(outputs, output)
-		})
+	workers := e.ruleParallelism
+	if workers <= 0 || workers > len(names) {
+		workers = len(names)
+	}
+	if workers <= 1 {
+		for i := range names {
+			exec(i)
+		}
+	} else {
+		sem := make(chan struct{}, workers)
+		wg := &sync.WaitGroup{}
+		for i := range names {
+			sem <- struct{}{}
+			wg.Go(func() {
+				defer func() { <-sem }()
+				exec(i)
+			})
+		}
+		wg.Wait()
 	}
-	wg.Wait()
 
-	return outputs, compositeErr
+	// aggregate in the order of the rules, whichever finished first
+	var compositeErr error
+	results := make([]*ExecutorOutput, 0, len(names))
+	for i := range names {
+		if errs[i] != nil {
+			compositeErr = stdErr.Join(compositeErr, errs[i])
+			continue
+		}
+		results = append(results, outputs[i])
+	}
+	return results, compositeErr
 }
 
 // ExecRule executes an exported rule and returns the result
 func (e *executorImpl) ExecRule(ctx context.Context, namespace, policy, rule string, injectedFacts map[string]any) (*ExecutorOutput, error) {
+	return e.execRequestRule(ctx, newRuleRequest(), namespace, policy, rule, injectedFacts)
+}
+
+// ruleRequest is what the exported rules evaluated for one request share: the values of the
+// lets of the policy, the results of its derive calls, and the time the request started.
+type ruleRequest struct {
+	lets, derives *letCache
+	startedAt     time.Time
+}
+
+func newRuleRequest() *ruleRequest {
+	return &ruleRequest{lets: newLetCache(), derives: newLetCache(), startedAt: time.Now()}
+}
+
+// execRequestRule executes an exported rule as part of req.
+func (e *executorImpl) execRequestRule(ctx context.Context, req *ruleRequest, namespace, policy, rule string, injectedFacts map[string]any) (*ExecutorOutput, error) {
 	// Validate exported
 	p, err := e.index.ResolvePolicy(namespace, policy)
 	if err != nil {
@@ -164,26 +279,93 @@ func (e *executorImpl) ExecRule(ctx context.Context, namespace, policy, rule str
 		return nil, err
 	}
 
+	if e.resultCache != nil {
+		if scope := e.resultCacheScope(p); scope.cacheable {
+			if key, ok := resultCacheKey(p.ContentHash(), scope.modulesHash, rule, wantsFullTrace(ctx), injectedFacts); ok {
+				return e.resultCache.get(ctx, key, func() (*ExecutorOutput, error) {
+					return e.execExportedRule(ctx, req, p, namespace, policy, rule, injectedFacts)
+				})
+			}
+		}
+	}
+	return e.execExportedRule(ctx, req, p, namespace, policy, rule, injectedFacts)
+}
+
+// execExportedRule evaluates the exported rule of p, redacting the values of its sensitive facts
+// from the output and the error.
+func (e *executorImpl) execExportedRule(ctx context.Context, req *ruleRequest, p *index.Policy, namespace, policy, rule string, injectedFacts map[string]any) (*ExecutorOutput, error) {
+	r := e.sensitiveRedactor(p, injectedFacts)
+	output, err := e.evalExportedRule(ctx, r, req, p, namespace, policy, rule, injectedFacts)
+	return r.output(output), r.err(err)
+}
+
+// exportedRuleEvaluating is called every time an exported rule is about to be evaluated.
+// Overridable in tests.
+var exportedRuleEvaluating = func(p *index.Policy, rule string) {}
+
+// evalExportedRule binds the facts, lets and modules of p and evaluates its exported rule.
+// The defaults of sensitive facts are added to r as they are evaluated, the values of the lets,
+// the results of derive calls and the start time are taken from req.
+func (e *executorImpl) evalExportedRule(ctx context.Context, r *redactor, req *ruleRequest, p *index.Policy, namespace, policy, rule string, injectedFacts map[string]any) (*ExecutorOutput, error) {
+	exportedRuleEvaluating(p, rule)
+	if e.compileAfter > 0 {
+		p.CountEvaluation()
+	}
+
 	ec := NewExecutionContext(p, e)
+	ec.createdAt = req.startedAt
+	ec.letValues = req.lets
+	ec.deriveValues = req.derives
 	defer ec.Dispose()
 
+	if err := e.bindPolicy(ctx, ec, r, p, injectedFacts); err != nil {
+		return nil, err
+	}
+
+	decision, attachments, ruleNode, err := e.execRule(ctx, ec, namespace, policy, rule)
+	if err != nil && decision == nil {
+		decision = DecisionOf(box.Trinary(trinary.Unknown))
+	}
+	var effect index.RuleEffect
+	if theRule, ok := p.Rules[rule]; ok {
+		effect = theRule.Effect
+	}
+	attachments, obligations, advice := splitDirectives(p.RuleExports[rule], attachments)
+	return &ExecutorOutput{
+		PolicyName:  policy,
+		Namespace:   namespace,
+		RuleName:    rule,
+		Decision:    decision,
+		Effect:      effect,
+		Attachments: attachments,
+		Obligations: obligations,
+		Advice:      advice,
+		RuleNode:    ruleNode,
+		Metadata:    p.Metadata(),
+	}, err
+}
+
+// bindPolicy binds the facts, lets and used modules of p into ec and checks its requires. A
+// missing fact, or a missing field of a shape a fact is typed with, takes its default, and the
+// defaults of sensitive facts are added to r as they are evaluated.
+func (e *executorImpl) bindPolicy(ctx context.Context, ec *ExecutionContext, r *redactor, p *index.Policy, injectedFacts map[string]any) error {
 	for factName, factStatement := range p.Facts {
 		// look for a value for this fact in the passed in facts map
 		factValue, ok := injectedFacts[factName]
 
 		// we do not have a value for this fact, and it is required - error
 		if !ok && !factStatement.Optional {
-			return nil, xerr.ErrRequiredFact(factName)
+			return xerr.ErrRequiredFact(factName)
 		}
 
 		if ok {
 			decodedFactValue := box.FromBoundaryAny(factValue)
 			if decodedFactValue.IsNull() && !ast.IsNullableTypeRef(factStatement.Type) {
-				return nil, fmt.Errorf("fact '%s' cannot be null: %w", factName, xerr.ErrInvalidInvocation(""))
+				return fmt.Errorf("fact '%s' cannot be null: %w", factName, xerr.ErrInvalidInvocation(""))
 			}
 			err := ec.InjectFact(ctx, factName, decodedFactValue, false, factStatement.Type)
 			if err != nil {
-				return nil, err
+				return err
 			}
 			continue // move on to the next fact
 		}
@@ -193,16 +375,20 @@ func (e *executorImpl) ExecRule(ctx context.Context, namespace, policy, rule str
 			// evaluate the default value, this will be injected into the context
 			val, _, err := eval(ctx, ec, e, p, factStatement.Default)
 			if err != nil {
-				return nil, fmt.Errorf("%s: %w", err.Error(), xerr.ErrUnresolvableFact(factName))
+				return fmt.Errorf("%s: %w", err.Error(), xerr.ErrUnresolvableFact(factName))
 			}
 
 			if val.IsNull() && !ast.IsNullableTypeRef(factStatement.Type) {
-				return nil, fmt.Errorf("fact '%s' cannot have null default value: %w", factName, xerr.ErrInvalidInvocation(""))
+				return fmt.Errorf("fact '%s' cannot have null default value: %w", factName, xerr.ErrInvalidInvocation(""))
+			}
+
+			if factStatement.Sensitive {
+				r.add(val)
 			}
 
 			// inject the default value
 			if err := ec.InjectFact(ctx, factStatement.Name, val, true, factStatement.Type); err != nil {
-				return nil, err
+				return err
 			}
 		}
 	}
@@ -210,27 +396,74 @@ func (e *executorImpl) ExecRule(ctx context.Context, namespace, policy, rule str
 	// bind lets
 	for k, v := range p.Lets {
 		if err := ec.InjectLet(k, v); err != nil {
-			return nil, err
+			return err
 		}
 	}
 
 	// Bind `use` modules
 	if err := e.bindUses(ctx, ec, p); err != nil {
-		return nil, err
+		return err
 	}
 
-	decision, attachments, ruleNode, err := e.execRule(ctx, ec, namespace, policy, rule)
-	if err != nil && decision == nil {
-		decision = DecisionOf(box.Trinary(trinary.Unknown))
+	// shape fields missing from the facts take their defaults, which may refer to lets and modules
+	for name, fact := range ec.facts {
+		if fact.typeRef == nil {
+			continue
+		}
+		hydrated, err := withShapeDefaults(ctx, ec, e, p, fact.value, fact.typeRef)
+		if err != nil {
+			return err
+		}
+		if err := ec.InjectFact(ctx, name, hydrated, fact.isDefault, fact.typeRef); err != nil {
+			return err
+		}
 	}
-	return &ExecutorOutput{
-		PolicyName:  policy,
-		Namespace:   namespace,
-		RuleName:    rule,
-		Decision:    decision,
-		Attachments: attachments,
-		RuleNode:    ruleNode,
-	}, err
+
+	if violations := e.checkRequires(ctx, ec, p); len(violations) > 0 {
+		return violations[0]
+	}
+	return nil
+}
+
+// splitDirectives takes the obligations and the advice of an exported rule out of its
+// evaluated attachments, in declaration order.
+func splitDirectives(ex *index.ExportedRule, evaluated DecisionAttachments) (DecisionAttachments, []Directive, []Directive) {
+	if ex == nil || evaluated == nil {
+		return evaluated, nil, nil
+	}
+	var obligations, advice []Directive
+	for _, attachment := range ex.Attachments {
+		v, ok := evaluated[attachment.Name]
+		if !ok {
+			continue
+		}
+		switch attachment.Directive {
+		case ast.DirectiveObligation:
+			obligations = append(obligations, Directive{Name: attachment.Name, Value: v})
+		case ast.DirectiveAdvice:
+			advice = append(advice, Directive{Name: attachment.Name, Value: v})
+		default:
+			continue
+		}
+		delete(evaluated, attachment.Name)
+	}
+	return evaluated, obligations, advice
+}
+
+// validateBoundFacts validates the facts bound into ec against their declared types.
+func (e *executorImpl) validateBoundFacts(ctx context.Context, ec *ExecutionContext, p *index.Policy) error {
+	for name, fact := range ec.facts {
+		if fact.typeRef == nil {
+			// if there's no shape indication, we skip validation
+			continue
+		}
+		stmt := p.Facts[name]
+		// validate the value against the type
+		if err := validateValueAgainstTypeRef(ctx, ec, e, p, fact.value, fact.typeRef, stmt.Span()); err != nil {
+			return err
+		}
+	}
+	return nil
 }
 
 func (e *executorImpl) execRule(ctx context.Context, ec *ExecutionContext, namespace, policy, rule string) (*Decision, DecisionAttachments, *trace.Node, error) {
@@ -258,17 +491,8 @@ func (e *executorImpl) execRule(ctx context.Context, ec *ExecutionContext, names
 	})
 	defer done()
 
-	// validate the facts against the type
-	for name, fact := range ec.facts {
-		if fact.typeRef == nil {
-			// if there's no shape indication, we skip validation
-			continue
-		}
-		stmt := thePolicy.Facts[name]
-		// validate the value against the type
-		if err := validateValueAgainstTypeRef(ctx, ec, e, thePolicy, fact.value, fact.typeRef, stmt.Span()); err != nil {
-			return nil, nil, nil, err
-		}
+	if err := e.validateBoundFacts(ctx, ec, thePolicy); err != nil {
+		return nil, nil, nil, err
 	}
 
 	d, node, err := evaluateRuleOutcome(ctx, ec, e, thePolicy, theRule)
@@ -283,13 +507,21 @@ func (e *executorImpl) execRule(ctx context.Context, ec *ExecutionContext, names
 	attachments := map[string]box.Value{}
 	if ex, ok := thePolicy.RuleExports[rule]; ok {
 		for _, attachment := range ex.Attachments {
-			ctx, attachmentNode, done := trace.New(ctx, attachment.Value, "attachment", map[string]any{
+			kind := "attachment"
+			if attachment.Directive != "" {
+				kind = attachment.Directive
+			}
+			ctx, attachmentNode, done := trace.New(ctx, attachment.Value, kind, map[string]any{
 				"name": attachment.Name,
 			})
 			defer done()
 
 			v, node, err := eval(ctx, ec, e, thePolicy, attachment.Value)
 			attachmentNode.Attach(node)
+			if err == nil && attachment.Type != nil {
+				// the value of a directive must conform to the shape it declares
+				err = validateValueAgainstTypeRef(ctx, ec, e, thePolicy, v, attachment.Type, attachment.Value.Span())
+			}
 			if err != nil {
 				attachmentNode.SetErr(err)
 				return d, attachments, ruleNode, err
@@ -307,7 +539,7 @@ func (e *executorImpl) execRule(ctx context.Context, ec *ExecutionContext, names
 func (e *executorImpl) jsBindingConstructor(ctx context.Context, use *ast.UseStatement, ms *js.ModuleSpec) (*JSInstance, error) {
 	// Per-alias VM with require cache
 	ar := js.NewAliasRuntime(e.jsRegistry, ms.Dir)
-	if err := ar.SetupStdLib(ctx, e.index.Pack); err != nil {
+	if err := ar.SetupStdLib(ctx, e.index.Pack()); err != nil {
 		return nil, err
 	}
 
@@ -340,25 +572,34 @@ func (e *executorImpl) jsBindingConstructor(ctx context.Context, use *ast.UseSta
 }
 
 func (e *executorImpl) bindUses(ctx context.Context, ec *ExecutionContext, p *index.Policy) error {
+	for alias, use := range p.Uses {
+		if err := e.bindUse(ctx, ec, p, alias, use); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// bindUse binds the module of use into ec as alias. A relative module is resolved from the
+// directory of the file declaring p.
+func (e *executorImpl) bindUse(ctx context.Context, ec *ExecutionContext, p *index.Policy, alias string, use *ast.UseStatement) error {
 	fileDir, err := filepath.Abs(filepath.Dir(p.FilePath))
 	if err != nil {
 		return err
 	}
 
-	for alias, use := range p.Uses {
-		ms, err := e.jsRegistry.PrepareUse(use.RelativeFrom, use.LibFrom, fileDir)
-		if err != nil {
-			return err
-		}
-
-		// Resolve and ensure program exists
-		binding, _, err := e.getModuleBinding(ctx, use, ms)
-		if err != nil {
-			return err
-		}
+	ms, err := e.jsRegistry.PrepareUse(use.RelativeFrom, use.LibFrom, fileDir)
+	if err != nil {
+		return err
+	}
 
-		ec.BindModule(alias, binding)
+	// Resolve and ensure program exists
+	binding, _, err := e.getModuleBinding(ctx, use, ms)
+	if err != nil {
+		return err
 	}
+
+	ec.BindModule(alias, binding)
 	return nil
 }
 
@@ -411,7 +652,7 @@ func evaluateRuleOutcome(ctx context.Context, ec *ExecutionContext, e *executorI
 		ctx, wn, done := trace.New(ctx, r.When, "rule-when", map[string]any{})
 		defer done()
 
-		cond, condNode, err := eval(ctx, ec, e, p, r.When)
+		cond, condNode, err := evalRuleExpression(ctx, ec, e, p, r.When)
 		wn.Attach(condNode)
 		if err != nil {
 			wn.SetErr(err)
@@ -431,19 +672,24 @@ func evaluateRuleOutcome(ctx context.Context, ec *ExecutionContext, e *executorI
 			defer done()
 
 			// evaluate the default expression
-			val, defNode, err := eval(ctx, ec, e, p, r.Default)
+			val, defNode, err := evalRuleExpression(ctx, ec, e, p, r.Default)
 			dn.Attach(defNode).SetResult(val).SetErr(err)
 
 			theDefault = DecisionOf(val)
 			rn.Attach(dn)
 		}
+		attachNotEvaluated(ctx, rn, r.Body)
 		return theDefault, rn, nil
 	}
 
+	if r.Default != nil {
+		attachNotEvaluated(ctx, rn, r.Default)
+	}
+
 	ctx, rb, done := trace.New(ctx, r.Body, "rule-body", map[string]any{})
 	defer done()
 
-	val, bodyNode, err := eval(ctx, ec, e, p, r.Body)
+	val, bodyNode, err := evalRuleExpression(ctx, ec, e, p, r.Body)
 	rb.Attach(bodyNode).SetResult(val).SetErr(err)
 	rn.Attach(rb)
 
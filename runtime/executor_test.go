@@ -18,8 +18,12 @@ package runtime
 
 import (
 	"context"
+	"fmt"
 	"path/filepath"
 	"runtime"
+	"strings"
+	"sync/atomic"
+	"time"
 
 	"github.com/binaek/perch"
 	"github.com/sentrie-sh/sentrie/ast"
@@ -28,6 +32,7 @@ import (
 	"github.com/sentrie-sh/sentrie/index"
 	"github.com/sentrie-sh/sentrie/loader"
 	"github.com/sentrie-sh/sentrie/pack"
+	"github.com/sentrie-sh/sentrie/parser"
 	"github.com/sentrie-sh/sentrie/runtime/js"
 	"github.com/sentrie-sh/sentrie/trinary"
 	"github.com/sentrie-sh/sentrie/xerr"
@@ -91,7 +96,7 @@ func newExecutorAndPolicyWithFact(fact *ast.FactStatement) (*executorImpl, *inde
 	p.RuleExports["allow"] = &index.ExportedRule{RuleName: "allow"}
 	ns.Policies["pol"] = p
 
-	return &executorImpl{index: idx}, p
+	return &executorImpl{index: viewOf(idx)}, p
 }
 
 func (s *RuntimeTestSuite) TestExecRuleFactNullBranchesWrapInvalidInvocation() {
@@ -176,7 +181,7 @@ func (s *RuntimeTestSuite) TestExecRuleInternalRuleLookupFailureBranch() {
 	ns.Policies = map[string]*index.Policy{p.Name: p}
 	idx.Namespaces[ns.FQN.String()] = ns
 
-	exec := &executorImpl{index: idx}
+	exec := &executorImpl{index: viewOf(idx)}
 	ec := NewExecutionContext(p, exec)
 	_, _, _, err := exec.execRule(context.Background(), ec, ns.FQN.String(), p.Name, "missing")
 	s.Require().Error(err)
@@ -230,7 +235,9 @@ func (s *RuntimeTestSuite) TestEvaluateRuleOutcomeWhenEvaluationFailureReturnsEr
 func (s *RuntimeTestSuite) TestWithCallMemoizeCacheSizeAndToTrinaryHelpers() {
 	exec := &executorImpl{}
 	WithCallMemoizeCacheSize(2)(exec)
-	s.NotNil(exec.callMemoizePerch)
+	WithModuleBindingCacheSize(3)(exec)
+	s.Equal(2, exec.callMemoizeCacheSize)
+	s.Equal(3, exec.moduleBindingCacheSize)
 
 	out := &ExecutorOutput{Decision: DecisionOf(box.Trinary(trinary.True))}
 	s.Equal(trinary.True, out.ToTrinary())
@@ -263,25 +270,149 @@ func (s *RuntimeTestSuite) TestExecPolicyRecoversPanicFromExecRule() {
 		Uses:        map[string]*ast.UseStatement{},
 		Shapes:      map[string]*index.Shape{},
 	}
-	p.RuleExports["panicRule"] = nil
 	ns.Policies[p.Name] = p
 
-	exec := &executorImpl{index: idx}
+	exec := &executorImpl{index: viewOf(idx)}
+	// policies are shared between the index and its views - corrupt this one only
+	// after the commit, so that validation passes and ExecRule panics
+	p.RuleExports["panicRule"] = nil
 	_, err := exec.ExecPolicy(context.Background(), nsFQN.String(), p.Name, map[string]any{})
 	s.Require().Error(err)
 	s.Contains(err.Error(), "panic in ExecRule")
 }
 
+// slowRulesPolicy exports n independent rules reading the same fact and let.
+func slowRulesPolicy(n int) string {
+	var b strings.Builder
+	b.WriteString("namespace com/example\npolicy slow {\n  fact level: number\n  let threshold = level * 2\n")
+	for i := range n {
+		fmt.Fprintf(&b, "  rule r%02d = { yield threshold > %d }\n  export decision of r%02d\n", i, i, i)
+	}
+	b.WriteString("}\n")
+	return b.String()
+}
+
+// slowExportedRules makes every exported rule take delay to evaluate until the test ends, and
+// returns the most rules that were evaluated at once.
+func (s *RuntimeTestSuite) slowExportedRules(delay time.Duration) func() int64 {
+	var running, most atomic.Int64
+	original := exportedRuleEvaluating
+	exportedRuleEvaluating = func(*index.Policy, string) {
+		now := running.Add(1)
+		defer running.Add(-1)
+		for m := most.Load(); now > m && !most.CompareAndSwap(m, now); m = most.Load() {
+		}
+		time.Sleep(delay)
+	}
+	s.T().Cleanup(func() { exportedRuleEvaluating = original })
+	return func() int64 { return most.Swap(0) }
+}
+
+func (s *RuntimeTestSuite) TestExecPolicySharesNowAcrossRules() {
+	original := exportedRuleEvaluating
+	exportedRuleEvaluating = func(_ *index.Policy, rule string) {
+		if rule == "later" {
+			// now() has a resolution of a second
+			time.Sleep(1100 * time.Millisecond)
+		}
+	}
+	s.T().Cleanup(func() { exportedRuleEvaluating = original })
+
+	program, err := parser.NewParserFromString(`namespace com/example
+policy clock {
+  use { now } from @sentrie/std as std
+  rule earlier = default "" { yield std.now() }
+  rule later = default "" { yield std.now() }
+  export decision of earlier
+  export decision of later
+}`, "clock.sentrie").ParseProgram(s.T().Context())
+	s.Require().NoError(err)
+	idx := index.CreateIndex()
+	s.Require().NoError(idx.SetPack(s.T().Context(), &pack.PackFile{Location: s.T().TempDir()}))
+	s.Require().NoError(idx.AddProgram(s.T().Context(), program))
+	s.Require().NoError(idx.Validate(s.T().Context()))
+	exec, err := NewExecutor(viewOf(idx), WithRuleParallelism(1), WithModuleBindingCacheSize(1), WithCallMemoizeCacheSize(1))
+	s.Require().NoError(err)
+
+	outputs, err := exec.ExecPolicy(s.T().Context(), "com/example", "clock", map[string]any{})
+	s.Require().NoError(err)
+	s.Require().Len(outputs, 2)
+	s.Equal(outputs[0].Decision, outputs[1].Decision)
+}
+
+func (s *RuntimeTestSuite) TestExecPolicyEvaluatesRulesInParallel() {
+	most := s.slowExportedRules(20 * time.Millisecond)
+	src := slowRulesPolicy(16)
+	facts := map[string]any{"level": 4}
+
+	sequential := s.executorFromSource(src).(*executorImpl)
+	WithRuleParallelism(1)(sequential)
+	start := time.Now()
+	want, err := sequential.ExecPolicy(s.T().Context(), "com/example", "slow", facts)
+	sequentialTime := time.Since(start)
+	s.Require().NoError(err)
+	s.Equal(int64(1), most())
+
+	parallel := s.executorFromSource(src).(*executorImpl)
+	WithRuleParallelism(4)(parallel)
+	start = time.Now()
+	got, err := parallel.ExecPolicy(s.T().Context(), "com/example", "slow", facts)
+	parallelTime := time.Since(start)
+	s.Require().NoError(err)
+	s.Equal(int64(4), most(), "at most 4 rules at once")
+	s.Less(parallelTime, sequentialTime/2)
+
+	s.Require().Len(got, 16)
+	s.Require().Len(want, 16)
+	for i := range want {
+		s.Equal(fmt.Sprintf("r%02d", i), got[i].RuleName)
+		s.Equal(want[i].RuleName, got[i].RuleName)
+		s.Equal(want[i].Decision, got[i].Decision, want[i].RuleName)
+	}
+}
+
+func (s *RuntimeTestSuite) TestExecPolicyOrdersOutputsAndErrorsByRule() {
+	exec := s.executorFromSource(`namespace com/example
+policy mixed {
+  fact n: number
+  rule c = { yield n > 1 }
+  rule b = { yield n / 0 }
+  rule a = { yield n > 0 }
+  rule d = { yield n - "x" }
+  export decision of c
+  export decision of b
+  export decision of a
+  export decision of d
+}`)
+
+	var first string
+	for range 10 {
+		outputs, err := exec.ExecPolicy(s.T().Context(), "com/example", "mixed", map[string]any{"n": 1})
+		s.Require().Error(err)
+		if first == "" {
+			first = err.Error()
+		}
+		s.Equal(first, err.Error(), "the errors are joined in the order of the rules")
+		s.Require().Len(outputs, 2)
+		s.Equal("a", outputs[0].RuleName)
+		s.Equal("c", outputs[1].RuleName)
+		joined, ok := err.(interface{ Unwrap() []error })
+		s.Require().True(ok)
+		s.Require().Len(joined.Unwrap(), 2)
+	}
+}
+
 func testExecutorForModuleBinding() *executorImpl {
 	idx := index.CreateIndex()
 	idx.Pack = &pack.PackFile{Location: "."}
 	exec := &executorImpl{
-		index:              idx,
+		index:              viewOf(idx),
 		jsRegistry:         js.NewRegistry("."),
 		moduleBindingPerch: perch.New[*ModuleBinding](1 << 20),
 		callMemoizePerch:   perch.New[any](1 << 20),
 	}
-	exec.jsRegistry.RegisterGoBuiltin("hash", js.BuiltinHashGo)
+	exec.jsRegistry.RegisterGoBuiltin("hash", js.BuiltinHashGo, js.Pure)
+	exec.jsRegistry.RegisterGoBuiltin("std", js.BuiltinStdGo, js.Volatile)
 	exec.moduleBindingPerch.Reserve()
 	exec.callMemoizePerch.Reserve()
 	return exec
@@ -335,6 +466,89 @@ func (s *RuntimeTestSuite) TestBindUsesBindsPreparedModule() {
 	s.NotNil(binding)
 }
 
+func (s *RuntimeTestSuite) TestModuleCallSurfacesReturnedGoErrorsWithSpan() {
+	exec := testExecutorForModuleBinding()
+	use := ast.NewUseStatement([]string{"sqrt"}, "", []string{constants.APPNAME, "std"}, "std", stubRange())
+	policy := newEvalTestPolicy()
+	policy.Uses = map[string]*ast.UseStatement{"std": use}
+	ec := NewExecutionContext(policy, exec)
+	s.Require().NoError(exec.bindUses(context.Background(), ec, policy))
+
+	call := func(arg float64) (box.Value, error) {
+		expr := ast.NewCallExpression(ast.NewIdentifier("std.sqrt", stubRange()), []ast.Expression{ast.NewFloatLiteral(arg, stubRange())}, false, nil, stubRange())
+		out, _, err := eval(context.Background(), ec, exec, policy, expr)
+		return out, err
+	}
+
+	out, err := call(4)
+	s.Require().NoError(err)
+	n, _ := out.NumberValue()
+	s.Equal(2.0, n)
+
+	_, err = call(-1)
+	s.Require().Error(err)
+	s.Contains(err.Error(), "failed to call function 'std.sqrt' at "+stubRange().String())
+	s.Contains(err.Error(), "sqrt: result of [-1] is not a finite number")
+}
+
+func (s *RuntimeTestSuite) TestModuleCallJSONParse() {
+	exec := testExecutorForModuleBinding()
+	use := ast.NewUseStatement([]string{"json_parse"}, "", []string{constants.APPNAME, "std"}, "std", stubRange())
+	policy := newEvalTestPolicy()
+	policy.Uses = map[string]*ast.UseStatement{"std": use}
+	ec := NewExecutionContext(policy, exec)
+	s.Require().NoError(exec.bindUses(context.Background(), ec, policy))
+
+	call := func(doc string) (box.Value, error) {
+		expr := ast.NewCallExpression(ast.NewIdentifier("std.json_parse", stubRange()), []ast.Expression{ast.NewStringLiteral(doc, stubRange())}, false, nil, stubRange())
+		out, _, err := eval(context.Background(), ec, exec, policy, expr)
+		return out, err
+	}
+
+	out, err := call(`{"id": 7, "roles": ["admin"]}`)
+	s.Require().NoError(err)
+	s.Equal(map[string]any{"id": float64(7), "roles": []any{"admin"}}, out.Any())
+
+	_, err = call(`{"id": `)
+	s.Require().Error(err)
+	s.Contains(err.Error(), "failed to call function 'std.json_parse' at "+stubRange().String())
+	s.Contains(err.Error(), "json_parse: unexpected end of JSON input")
+}
+
+func (s *RuntimeTestSuite) TestModuleCallBase64DecodeRejectsInvalidInput() {
+	exec := testExecutorForModuleBinding()
+	use := ast.NewUseStatement([]string{"base64_decode", "sha256_hex"}, "", []string{constants.APPNAME, "std"}, "std", stubRange())
+	policy := newEvalTestPolicy()
+	policy.Uses = map[string]*ast.UseStatement{"std": use}
+	ec := NewExecutionContext(policy, exec)
+	s.Require().NoError(exec.bindUses(context.Background(), ec, policy))
+
+	call := func(fn, arg string) (box.Value, error) {
+		expr := ast.NewCallExpression(ast.NewIdentifier("std."+fn, stubRange()), []ast.Expression{ast.NewStringLiteral(arg, stubRange())}, false, nil, stubRange())
+		out, _, err := eval(context.Background(), ec, exec, policy, expr)
+		return out, err
+	}
+
+	out, err := call("sha256_hex", "user:7")
+	s.Require().NoError(err)
+	first, _ := out.StringValue()
+	out, err = call("sha256_hex", "user:7")
+	s.Require().NoError(err)
+	second, _ := out.StringValue()
+	s.Len(first, 64)
+	s.Equal(first, second)
+
+	out, err = call("base64_decode", "dXNlcjo3")
+	s.Require().NoError(err)
+	s.Equal("user:7", out.Any())
+
+	out, err = call("base64_decode", "dXNlcjo3!")
+	s.Require().Error(err)
+	s.True(out.IsUndefined())
+	s.Contains(err.Error(), "failed to call function 'std.base64_decode' at "+stubRange().String())
+	s.Contains(err.Error(), "base64_decode: illegal base64 data")
+}
+
 func examplePackDir() string {
 	_, current, _, _ := runtime.Caller(0)
 	return filepath.Join(filepath.Dir(current), "..", "example_pack")
@@ -357,7 +571,10 @@ func (s *RuntimeTestSuite) TestExamplePackExecPolicySmoke() {
 	}
 	s.Require().NoError(idx.Validate(ctx))
 
-	exec, err := NewExecutor(idx)
+	view, err := idx.View(ctx)
+	s.Require().NoError(err)
+
+	exec, err := NewExecutor(view)
 	s.Require().NoError(err)
 
 	testCases := []struct {
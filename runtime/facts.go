@@ -0,0 +1,80 @@
+// SPDX-FileCopyrightText: © 2026 Binaek Sarkar <binaek89@gmail.com>
+// SPDX-License-Identifier: Apache-2.0
+
+package runtime
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+
+	"github.com/sentrie-sh/sentrie/ast"
+	"github.com/sentrie-sh/sentrie/box"
+	"github.com/sentrie-sh/sentrie/xerr"
+)
+
+// ValidateFacts checks the facts against the fact declarations of a policy without evaluating any
+// of its rules: required facts must be present, and every supplied fact must satisfy its declared
+// type and constraints and conform to the shapes its require statements name. Every violation is
+// reported, facts in name order and then requires in declaration order, joined into one error.
+// Fact defaults are not evaluated, and facts the policy does not declare are ignored. The values of
+// sensitive facts are redacted from the error.
+func (e *executorImpl) ValidateFacts(ctx context.Context, namespace, policy string, facts map[string]any) error {
+	p, err := e.index.ResolvePolicy(namespace, policy)
+	if err != nil {
+		return err
+	}
+
+	r := e.sensitiveRedactor(p, facts)
+	ec := NewExecutionContext(p, e)
+	defer ec.Dispose()
+
+	var violations []error
+	supplied := map[string]box.Value{}
+	for _, name := range slices.Sorted(maps.Keys(p.Facts)) {
+		stmt := p.Facts[name]
+		raw, ok := facts[name]
+		if !ok {
+			if !stmt.Optional {
+				violations = append(violations, xerr.ErrRequiredFact(name))
+			}
+			continue
+		}
+
+		v := box.FromBoundaryAny(raw)
+		if v.IsNull() && !ast.IsNullableTypeRef(stmt.Type) {
+			violations = append(violations, fmt.Errorf("fact '%s' cannot be null: %w", name, xerr.ErrInvalidInvocation("")))
+			continue
+		}
+		if err := ec.InjectFact(ctx, name, v, false, stmt.Type); err != nil {
+			return r.err(err)
+		}
+		supplied[name] = v
+	}
+
+	// constraint arguments may refer to lets and used modules
+	for k, v := range p.Lets {
+		if err := ec.InjectLet(k, v); err != nil {
+			return r.err(err)
+		}
+	}
+	if err := e.bindUses(ctx, ec, p); err != nil {
+		return r.err(err)
+	}
+
+	for _, name := range slices.Sorted(maps.Keys(supplied)) {
+		stmt := p.Facts[name]
+		if stmt.Type == nil {
+			continue
+		}
+		if err := validateValueAgainstTypeRef(ctx, ec, e, p, supplied[name], stmt.Type, stmt.Span()); err != nil {
+			violations = append(violations, wrapViolations(err, "fact '%s' is not valid", name)...)
+		}
+	}
+
+	violations = append(violations, e.checkRequires(ctx, ec, p)...)
+
+	return r.err(errors.Join(violations...))
+}
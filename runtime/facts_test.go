@@ -0,0 +1,170 @@
+// SPDX-FileCopyrightText: © 2026 Binaek Sarkar <binaek89@gmail.com>
+// SPDX-License-Identifier: Apache-2.0
+
+package runtime
+
+import (
+	"context"
+
+	"github.com/sentrie-sh/sentrie/index"
+	"github.com/sentrie-sh/sentrie/pack"
+	"github.com/sentrie-sh/sentrie/parser"
+	"github.com/sentrie-sh/sentrie/xerr"
+)
+
+const factContractPolicy = `namespace com/example
+shape User {
+  id: string @uuid()
+  age: number @min(0)
+}
+policy onboarding {
+  fact user: User
+  fact region: string @one_of("eu", "us")
+  fact note?: string @maxlength(5)
+  rule allow = default false { yield user.age >= 18 }
+  export decision of allow
+}
+`
+
+func (s *RuntimeTestSuite) TestValidateFactsAcceptsValidFacts() {
+	exec := s.executorFromSource(factContractPolicy)
+	err := exec.ValidateFacts(context.Background(), "com/example", "onboarding", map[string]any{
+		"user":   map[string]any{"id": "7c9e6679-7425-40de-944b-e07fc1f90ae7", "age": 30},
+		"region": "eu",
+	})
+	s.NoError(err)
+}
+
+func (s *RuntimeTestSuite) TestValidateFactsReportsEveryViolation() {
+	exec := s.executorFromSource(factContractPolicy)
+	err := exec.ValidateFacts(context.Background(), "com/example", "onboarding", map[string]any{
+		"region": "apac",
+		"note":   "far too long",
+	})
+	s.Require().Error(err)
+	s.ErrorIs(err, xerr.InvalidInvocationError{})
+	s.Contains(err.Error(), "required fact not found: user")
+	s.Contains(err.Error(), "fact 'region' is not valid")
+	s.Contains(err.Error(), "fact 'note' is not valid")
+
+	err = exec.ValidateFacts(context.Background(), "com/example", "onboarding", map[string]any{
+		"user":   map[string]any{"id": "not-a-uuid", "age": 30},
+		"region": nil,
+	})
+	s.Require().Error(err)
+	s.Contains(err.Error(), "fact 'region' cannot be null")
+	s.Contains(err.Error(), "fact 'user' is not valid")
+}
+
+func (s *RuntimeTestSuite) TestValidateFactsReportsEveryShapeFieldInOrder() {
+	exec := s.executorFromSource(`namespace com/example
+shape Address {
+  street: string
+  zip: string @length(5)
+}
+shape Customer {
+  name: string
+  age: number @min(0)
+  address: Address
+  email: string @email()
+}
+policy signup {
+  fact customer: Customer
+  rule allow = default false { yield customer.age >= 18 }
+  export decision of allow
+}
+`)
+	facts := map[string]any{
+		"customer": map[string]any{"name": "ada", "age": -1, "address": map[string]any{"zip": "1"}, "email": "nope"},
+	}
+	for range 5 {
+		err := exec.ValidateFacts(context.Background(), "com/example", "signup", facts)
+		s.Require().Error(err)
+		joined, ok := err.(interface{ Unwrap() []error })
+		s.Require().True(ok)
+
+		var messages []string
+		for _, violation := range joined.Unwrap() {
+			messages = append(messages, violation.Error())
+		}
+		s.Require().Len(messages, 4)
+		s.Contains(messages[0], "fact 'customer' is not valid: field 'address' is not valid: field street is required")
+		s.Contains(messages[1], "fact 'customer' is not valid: field 'address' is not valid: field 'zip' is not valid")
+		s.Contains(messages[2], "fact 'customer' is not valid: field 'age' is not valid")
+		s.Contains(messages[3], "fact 'customer' is not valid: field 'email' is not valid")
+	}
+}
+
+func (s *RuntimeTestSuite) TestValidateFactsUsesTheOverridingFieldType() {
+	exec := s.executorFromSource(`namespace com/example
+shape Base {
+  id: string
+  name: string
+}
+shape Account with Base {
+  override id: number
+}
+policy accounts {
+  fact account: Account
+  rule allow = default false { yield account.id > 0 }
+  export decision of allow
+}
+`)
+	err := exec.ValidateFacts(context.Background(), "com/example", "accounts", map[string]any{
+		"account": map[string]any{"id": 42, "name": "ops"},
+	})
+	s.NoError(err)
+
+	err = exec.ValidateFacts(context.Background(), "com/example", "accounts", map[string]any{
+		"account": map[string]any{"id": "42", "name": "ops"},
+	})
+	s.Require().Error(err)
+	s.Contains(err.Error(), "fact 'account' is not valid")
+}
+
+func (s *RuntimeTestSuite) TestValidateFactsUnknownPolicy() {
+	exec := s.executorFromSource(factContractPolicy)
+	err := exec.ValidateFacts(context.Background(), "com/example", "missing", map[string]any{})
+	s.Require().Error(err)
+	s.ErrorIs(err, xerr.NotFoundError{})
+}
+
+func (s *RuntimeTestSuite) TestExampleFactsSatisfyTheFactContract() {
+	ctx := context.Background()
+	program, err := parser.NewParserFromString(`namespace com/example
+shape Address {
+  city: string @not_empty()
+  zip: string @length(5) @numeric()
+}
+shape User {
+  id: string @uuid()
+  email: string @email()
+  role: string @one_of("admin", "viewer")
+  age: number @min(18) @max(120)
+  address: Address
+  manager?: User
+}
+policy onboarding {
+  fact user: User
+  fact tags: list[string @starts_with("t-")] @minlength(3) @unique()
+  fact region: string @not_one_of("example") @uppercase()
+  fact since: date @after("2025-06-01")
+  fact score: number @gt(10) @lt(100) @multiple_of(4)
+  fact note?: string @maxlength(3)
+  rule allow = default false { yield user.age >= 21 }
+  export decision of allow
+}
+`, "example.sentrie").ParseProgram(ctx)
+	s.Require().NoError(err)
+
+	idx := index.CreateIndex()
+	s.Require().NoError(idx.SetPack(ctx, &pack.PackFile{Location: s.T().TempDir()}))
+	s.Require().NoError(idx.AddProgram(ctx, program))
+	s.Require().NoError(idx.Validate(ctx))
+
+	facts, err := idx.ExampleFacts("com/example/onboarding")
+	s.Require().NoError(err)
+
+	exec := &executorImpl{index: viewOf(idx)}
+	s.NoError(exec.ValidateFacts(ctx, "com/example", "onboarding", facts))
+}
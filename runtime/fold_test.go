@@ -0,0 +1,66 @@
+// SPDX-FileCopyrightText: © 2026 Binaek Sarkar <binaek89@gmail.com>
+// SPDX-License-Identifier: Apache-2.0
+
+package runtime
+
+import (
+	"context"
+
+	"github.com/sentrie-sh/sentrie/ast"
+	"github.com/sentrie-sh/sentrie/parser"
+)
+
+func (s *RuntimeTestSuite) TestFoldedConstantsEvaluateAsUnfolded() {
+	p := newEvalTestPolicy()
+	ec := NewExecutionContext(p, &executorImpl{})
+
+	for _, src := range []string{
+		`namespace com/example
+policy p { let x = 60 * 60 * 24 }`,
+		`namespace com/example
+policy p { let x = 0.1 + 0.2 }`,
+		`namespace com/example
+policy p { let x = -7 % 3 }`,
+		`namespace com/example
+policy p { let x = 1 / 3 * 3 }`,
+		`namespace com/example
+policy p { let x = -(0 * 1) }`,
+		`namespace com/example
+policy p { let x = "time" + "out" }`,
+		`namespace com/example
+policy p { let x = unknown or true xor false }`,
+		`namespace com/example
+policy p { let x = not unknown and false }`,
+	} {
+		unfolded := s.letOf(src)
+		folded := s.letOf(src)
+		ast.FoldConstants(&ast.Program{Statements: []ast.Statement{folded}})
+		_, isInfix := folded.Value.(*ast.InfixExpression)
+		_, isUnary := folded.Value.(*ast.UnaryExpression)
+		s.Require().False(isInfix || isUnary, "%s was not folded", unfolded.Value)
+
+		want, _, err := eval(context.Background(), ec, &executorImpl{}, p, unfolded.Value)
+		s.Require().NoError(err, unfolded.Value.String())
+		got, _, err := eval(context.Background(), ec, &executorImpl{}, p, folded.Value)
+		s.Require().NoError(err, folded.Value.String())
+		s.Equal(want.Kind(), got.Kind(), unfolded.Value.String())
+		s.Equal(want.String(), got.String(), unfolded.Value.String())
+	}
+}
+
+// letOf parses src and returns the let of its policy.
+func (s *RuntimeTestSuite) letOf(src string) *ast.VarDeclaration {
+	program, err := parser.NewParserFromString(src, "fold.sentrie").ParseProgram(context.Background())
+	s.Require().NoError(err)
+	for _, stmt := range program.Statements {
+		if policy, ok := stmt.(*ast.PolicyStatement); ok {
+			for _, stmt := range policy.Statements {
+				if let, ok := stmt.(*ast.VarDeclaration); ok {
+					return let
+				}
+			}
+		}
+	}
+	s.FailNow("no let in " + src)
+	return nil
+}
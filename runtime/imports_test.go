@@ -80,7 +80,7 @@ func (s *RuntimeTestSuite) TestImportDecisionResolvePolicyFailure() {
 
 	idx := index.CreateIndex()
 	idx.Pack = &pack.PackFile{}
-	exec := &executorImpl{index: idx}
+	exec := &executorImpl{index: viewOf(idx)}
 
 	ec := NewExecutionContext(newEvalTestPolicy(), exec)
 	val, _, err := ImportDecision(s.T().Context(), exec, ec, newEvalTestPolicy(), imp)
@@ -0,0 +1,565 @@
+// SPDX-FileCopyrightText: © 2026 Binaek Sarkar <binaek89@gmail.com>
+// SPDX-License-Identifier: Apache-2.0
+
+package js
+
+import (
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"reflect"
+	"slices"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/dop251/goja"
+	"github.com/sentrie-sh/sentrie/box"
+	"github.com/sentrie-sh/sentrie/constants"
+)
+
+// BuiltinStdGo provides @sentrie/std. Dates are exchanged as ISO-8601 strings so that they
+// satisfy `date` type refs and order with the comparison operators. Dates returned by these
+// functions are normalized to UTC.
+//
+// now() is pinned to the start of the evaluation: every call within one evaluation returns the
+// same timestamp, at second precision.
+var BuiltinStdGo = func(vm *goja.Runtime) (*goja.Object, error) {
+	ex := vm.NewObject()
+
+	formatDate := func(t time.Time) goja.Value {
+		return vm.ToValue(t.UTC().Format(time.RFC3339Nano))
+	}
+
+	dateArg := func(fn string, call goja.FunctionCall, i int) (time.Time, error) {
+		s := call.Argument(i).String()
+		t, ok := box.ParseDate(s)
+		if !ok {
+			return time.Time{}, fmt.Errorf("%s: %q is not an RFC3339 / ISO-8601 date", fn, s)
+		}
+		return t, nil
+	}
+
+	_ = ex.Set("now", func(call goja.FunctionCall) goja.Value {
+		if len(call.Arguments) > 0 {
+			return vm.NewGoError(errors.New("now requires exactly 0 arguments"))
+		}
+		timestampVal := vm.Get(constants.ExecutionStartTimeUnixKey)
+		if timestampVal == nil || goja.IsUndefined(timestampVal) || goja.IsNull(timestampVal) {
+			return vm.NewGoError(errors.New("now: evaluation start time is not set"))
+		}
+		return formatDate(time.Unix(timestampVal.ToInteger(), 0))
+	})
+
+	_ = ex.Set("parse_date", func(call goja.FunctionCall) goja.Value {
+		if len(call.Arguments) != 1 {
+			return vm.NewGoError(errors.New("parse_date requires exactly 1 argument"))
+		}
+		t, err := dateArg("parse_date", call, 0)
+		if err != nil {
+			return vm.NewGoError(err)
+		}
+		return formatDate(t)
+	})
+
+	// date_add takes the duration either as a Go duration string ("24h", "-1h30m") or as a
+	// number of seconds.
+	_ = ex.Set("date_add", func(call goja.FunctionCall) goja.Value {
+		if len(call.Arguments) != 2 {
+			return vm.NewGoError(errors.New("date_add requires exactly 2 arguments"))
+		}
+		t, err := dateArg("date_add", call, 0)
+		if err != nil {
+			return vm.NewGoError(err)
+		}
+
+		var d time.Duration
+		switch arg := call.Argument(1).Export().(type) {
+		case string:
+			d, err = time.ParseDuration(arg)
+			if err != nil {
+				return vm.NewGoError(fmt.Errorf("date_add: %w", err))
+			}
+		case int64:
+			d = time.Duration(arg) * time.Second
+		case float64:
+			d = time.Duration(arg * float64(time.Second))
+		default:
+			return vm.NewGoError(fmt.Errorf("date_add: duration must be a duration string or a number of seconds, got %v", call.Argument(1)))
+		}
+		return formatDate(t.Add(d))
+	})
+
+	// date_diff returns a - b in seconds.
+	_ = ex.Set("date_diff", func(call goja.FunctionCall) goja.Value {
+		if len(call.Arguments) != 2 {
+			return vm.NewGoError(errors.New("date_diff requires exactly 2 arguments"))
+		}
+		a, err := dateArg("date_diff", call, 0)
+		if err != nil {
+			return vm.NewGoError(err)
+		}
+		b, err := dateArg("date_diff", call, 1)
+		if err != nil {
+			return vm.NewGoError(err)
+		}
+		return vm.ToValue(a.Sub(b).Seconds())
+	})
+
+	numbersArg := func(fn string, call goja.FunctionCall) ([]float64, error) {
+		list, ok := call.Argument(0).Export().([]any)
+		if !ok {
+			return nil, fmt.Errorf("%s: value %v is not a list", fn, call.Argument(0))
+		}
+		nums := make([]float64, 0, len(list))
+		for _, item := range list {
+			n, ok := stdNumber(item)
+			if !ok {
+				return nil, fmt.Errorf("%s: value %v is not a number", fn, item)
+			}
+			nums = append(nums, n)
+		}
+		return nums, nil
+	}
+
+	// The aggregates work on lists of numbers and always return floats, so that integer and
+	// decimal inputs aggregate the same way. sum and count of an empty list are 0; avg, min and
+	// max of an empty list are errors.
+	aggregate := func(fn string, emptyOK bool, reduce func([]float64) float64) {
+		_ = ex.Set(fn, func(call goja.FunctionCall) goja.Value {
+			if len(call.Arguments) != 1 {
+				return vm.NewGoError(fmt.Errorf("%s requires exactly 1 argument", fn))
+			}
+			nums, err := numbersArg(fn, call)
+			if err != nil {
+				return vm.NewGoError(err)
+			}
+			if len(nums) == 0 && !emptyOK {
+				return vm.NewGoError(fmt.Errorf("%s: list is empty", fn))
+			}
+			return vm.ToValue(reduce(nums))
+		})
+	}
+
+	sum := func(nums []float64) float64 {
+		total := 0.0
+		for _, n := range nums {
+			total += n
+		}
+		return total
+	}
+
+	aggregate("sum", true, sum)
+	aggregate("count", true, func(nums []float64) float64 { return float64(len(nums)) })
+	aggregate("avg", false, func(nums []float64) float64 { return sum(nums) / float64(len(nums)) })
+	aggregate("min", false, func(nums []float64) float64 { return slices.Min(nums) })
+	aggregate("max", false, func(nums []float64) float64 { return slices.Max(nums) })
+
+	stringArg := func(fn string, call goja.FunctionCall, i int) (string, error) {
+		str, ok := call.Argument(i).Export().(string)
+		if !ok {
+			return "", fmt.Errorf("%s: value %v is not a string", fn, call.Argument(i))
+		}
+		return str, nil
+	}
+
+	intArg := func(fn string, call goja.FunctionCall, i int) (int, error) {
+		switch n := call.Argument(i).Export().(type) {
+		case int64:
+			return int(n), nil
+		case float64:
+			return int(n), nil
+		default:
+			return 0, fmt.Errorf("%s: value %v is not a number", fn, call.Argument(i))
+		}
+	}
+
+	// stringFunc registers a function whose arguments are all strings.
+	stringFunc := func(fn string, arity int, impl func(args []string) any) {
+		_ = ex.Set(fn, func(call goja.FunctionCall) goja.Value {
+			if len(call.Arguments) != arity {
+				return vm.NewGoError(fmt.Errorf("%s requires exactly %d argument(s)", fn, arity))
+			}
+			args := make([]string, arity)
+			for i := range args {
+				str, err := stringArg(fn, call, i)
+				if err != nil {
+					return vm.NewGoError(err)
+				}
+				args[i] = str
+			}
+			return vm.ToValue(impl(args))
+		})
+	}
+
+	stringFunc("split", 2, func(args []string) any {
+		parts := strings.Split(args[0], args[1])
+		out := make([]any, len(parts))
+		for i, part := range parts {
+			out[i] = part
+		}
+		return out
+	})
+	stringFunc("trim", 1, func(args []string) any { return strings.TrimSpace(args[0]) })
+	stringFunc("to_upper", 1, func(args []string) any { return strings.ToUpper(args[0]) })
+	stringFunc("to_lower", 1, func(args []string) any { return strings.ToLower(args[0]) })
+	stringFunc("replace", 3, func(args []string) any { return strings.ReplaceAll(args[0], args[1], args[2]) })
+
+	_ = ex.Set("join", func(call goja.FunctionCall) goja.Value {
+		if len(call.Arguments) != 2 {
+			return vm.NewGoError(errors.New("join requires exactly 2 arguments"))
+		}
+		list, ok := call.Argument(0).Export().([]any)
+		if !ok {
+			return vm.NewGoError(fmt.Errorf("join: value %v is not a list", call.Argument(0)))
+		}
+		sep, err := stringArg("join", call, 1)
+		if err != nil {
+			return vm.NewGoError(err)
+		}
+		parts := make([]string, 0, len(list))
+		for _, item := range list {
+			str, ok := item.(string)
+			if !ok {
+				return vm.NewGoError(fmt.Errorf("join: value %v is not a string", item))
+			}
+			parts = append(parts, str)
+		}
+		return vm.ToValue(strings.Join(parts, sep))
+	})
+
+	// substring(s, start[, end]) counts in characters, not bytes. Indices outside the string are
+	// clamped to it, and an end before the start yields an empty string.
+	_ = ex.Set("substring", func(call goja.FunctionCall) goja.Value {
+		if len(call.Arguments) != 2 && len(call.Arguments) != 3 {
+			return vm.NewGoError(errors.New("substring requires 2 or 3 arguments"))
+		}
+		str, err := stringArg("substring", call, 0)
+		if err != nil {
+			return vm.NewGoError(err)
+		}
+		runes := []rune(str)
+		start, err := intArg("substring", call, 1)
+		if err != nil {
+			return vm.NewGoError(err)
+		}
+		end := len(runes)
+		if len(call.Arguments) == 3 {
+			if end, err = intArg("substring", call, 2); err != nil {
+				return vm.NewGoError(err)
+			}
+		}
+		start = min(max(start, 0), len(runes))
+		end = min(max(end, start), len(runes))
+		return vm.ToValue(string(runes[start:end]))
+	})
+
+	// contains(haystack, needle) and index_of(haystack, needle) look for a substring in a string,
+	// or for an element in a list. List elements compare by value, with integers and floats of the
+	// same value being equal. index_of counts characters in strings and returns -1 when absent.
+	indexOf := func(fn string, call goja.FunctionCall) (int, error) {
+		if len(call.Arguments) != 2 {
+			return 0, fmt.Errorf("%s requires exactly 2 arguments", fn)
+		}
+		needle := call.Argument(1).Export()
+		switch haystack := call.Argument(0).Export().(type) {
+		case string:
+			n, ok := needle.(string)
+			if !ok {
+				return 0, fmt.Errorf("%s: value %v is not a string", fn, call.Argument(1))
+			}
+			at := strings.Index(haystack, n)
+			if at < 0 {
+				return -1, nil
+			}
+			return utf8.RuneCountInString(haystack[:at]), nil
+		case []any:
+			for i, item := range haystack {
+				if stdEqual(item, needle) {
+					return i, nil
+				}
+			}
+			return -1, nil
+		default:
+			return 0, fmt.Errorf("%s: value %v is not a string or a list", fn, call.Argument(0))
+		}
+	}
+
+	_ = ex.Set("contains", func(call goja.FunctionCall) goja.Value {
+		at, err := indexOf("contains", call)
+		if err != nil {
+			return vm.NewGoError(err)
+		}
+		return vm.ToValue(at >= 0)
+	})
+
+	_ = ex.Set("index_of", func(call goja.FunctionCall) goja.Value {
+		at, err := indexOf("index_of", call)
+		if err != nil {
+			return vm.NewGoError(err)
+		}
+		return vm.ToValue(at)
+	})
+
+	numberArg := func(fn string, call goja.FunctionCall, i int) (float64, error) {
+		n, ok := stdNumber(call.Argument(i).Export())
+		if !ok {
+			return 0, fmt.Errorf("%s: value %v is not a number", fn, call.Argument(i))
+		}
+		return n, nil
+	}
+
+	// mathFunc registers a function of numbers returning a float. A result that is not a finite
+	// number is an error, so NaN and infinities never reach a policy.
+	mathFunc := func(fn string, minArgs, maxArgs int, impl func(args []float64) float64) {
+		_ = ex.Set(fn, func(call goja.FunctionCall) goja.Value {
+			if len(call.Arguments) < minArgs || len(call.Arguments) > maxArgs {
+				if minArgs == maxArgs {
+					return vm.NewGoError(fmt.Errorf("%s requires exactly %d argument(s)", fn, minArgs))
+				}
+				return vm.NewGoError(fmt.Errorf("%s requires %d to %d arguments", fn, minArgs, maxArgs))
+			}
+			args := make([]float64, len(call.Arguments))
+			for i := range args {
+				n, err := numberArg(fn, call, i)
+				if err != nil {
+					return vm.NewGoError(err)
+				}
+				args[i] = n
+			}
+			out := impl(args)
+			if math.IsNaN(out) || math.IsInf(out, 0) {
+				return vm.NewGoError(fmt.Errorf("%s: result of %v is not a finite number", fn, call.Arguments))
+			}
+			return vm.ToValue(out)
+		})
+	}
+
+	mathFunc("abs", 1, 1, func(args []float64) float64 { return math.Abs(args[0]) })
+	mathFunc("ceil", 1, 1, func(args []float64) float64 { return math.Ceil(args[0]) })
+	mathFunc("floor", 1, 1, func(args []float64) float64 { return math.Floor(args[0]) })
+	mathFunc("pow", 2, 2, func(args []float64) float64 { return math.Pow(args[0], args[1]) })
+	mathFunc("sqrt", 1, 1, func(args []float64) float64 { return math.Sqrt(args[0]) })
+
+	// round(n[, digits]) rounds half away from zero to the given number of decimal digits, 0 by
+	// default. Negative digits round to tens, hundreds and so on.
+	mathFunc("round", 1, 2, func(args []float64) float64 {
+		if len(args) == 1 {
+			return math.Round(args[0])
+		}
+		scale := math.Pow(10, math.Trunc(args[1]))
+		return math.Round(args[0]*scale) / scale
+	})
+
+	// json_parse decodes a JSON document into maps, lists and scalars, all numbers as floats.
+	_ = ex.Set("json_parse", func(call goja.FunctionCall) goja.Value {
+		if len(call.Arguments) != 1 {
+			return vm.NewGoError(errors.New("json_parse requires exactly 1 argument"))
+		}
+		str, err := stringArg("json_parse", call, 0)
+		if err != nil {
+			return vm.NewGoError(err)
+		}
+		var out any
+		if err := json.Unmarshal([]byte(str), &out); err != nil {
+			return vm.NewGoError(fmt.Errorf("json_parse: %w", err))
+		}
+		return vm.ToValue(out)
+	})
+
+	// json_stringify encodes a value as compact JSON, with map keys in sorted order.
+	_ = ex.Set("json_stringify", func(call goja.FunctionCall) goja.Value {
+		if len(call.Arguments) != 1 {
+			return vm.NewGoError(errors.New("json_stringify requires exactly 1 argument"))
+		}
+		out, err := json.Marshal(call.Argument(0).Export())
+		if err != nil {
+			return vm.NewGoError(fmt.Errorf("json_stringify: %w", err))
+		}
+		return vm.ToValue(string(out))
+	})
+
+	// sha256_hex, base64_encode and base64_decode work on the UTF-8 bytes of a string, for deriving
+	// stable keys. base64_decode uses the standard padded alphabet and rejects invalid input whole,
+	// as well as input that does not decode to valid UTF-8.
+	stringFunc("sha256_hex", 1, func(args []string) any {
+		sum := sha256.Sum256([]byte(args[0]))
+		return hex.EncodeToString(sum[:])
+	})
+	stringFunc("base64_encode", 1, func(args []string) any { return base64.StdEncoding.EncodeToString([]byte(args[0])) })
+
+	_ = ex.Set("base64_decode", func(call goja.FunctionCall) goja.Value {
+		if len(call.Arguments) != 1 {
+			return vm.NewGoError(errors.New("base64_decode requires exactly 1 argument"))
+		}
+		str, err := stringArg("base64_decode", call, 0)
+		if err != nil {
+			return vm.NewGoError(err)
+		}
+		decoded, err := base64.StdEncoding.DecodeString(str)
+		if err != nil {
+			return vm.NewGoError(fmt.Errorf("base64_decode: %w", err))
+		}
+		if !utf8.Valid(decoded) {
+			return vm.NewGoError(fmt.Errorf("base64_decode: %q does not decode to a UTF-8 string", str))
+		}
+		return vm.ToValue(string(decoded))
+	})
+
+	// template(tmpl) compiles a template interpolating `${path}` over a data map, where path is a
+	// dotted path of keys and `$$` is a literal `$`. The compiled template is a plain value, so it
+	// can be held in a let and applied any number of times with render(tmpl, data) without being
+	// parsed again. Strings are rendered as they are and any other value as JSON.
+	_ = ex.Set("template", func(call goja.FunctionCall) goja.Value {
+		if len(call.Arguments) != 1 {
+			return vm.NewGoError(errors.New("template requires exactly 1 argument"))
+		}
+		str, err := stringArg("template", call, 0)
+		if err != nil {
+			return vm.NewGoError(err)
+		}
+		parts, err := compileStdTemplate(str)
+		if err != nil {
+			return vm.NewGoError(fmt.Errorf("template: %w", err))
+		}
+		return vm.ToValue(map[string]any{"source": str, "parts": parts})
+	})
+
+	_ = ex.Set("render", func(call goja.FunctionCall) goja.Value {
+		if len(call.Arguments) != 2 {
+			return vm.NewGoError(errors.New("render requires exactly 2 arguments"))
+		}
+		tmpl, ok := call.Argument(0).Export().(map[string]any)
+		if !ok {
+			return vm.NewGoError(fmt.Errorf("render: value %v is not a compiled template", call.Argument(0)))
+		}
+		parts, ok := tmpl["parts"].([]any)
+		if !ok {
+			return vm.NewGoError(fmt.Errorf("render: value %v is not a compiled template", call.Argument(0)))
+		}
+		data, ok := call.Argument(1).Export().(map[string]any)
+		if !ok {
+			return vm.NewGoError(fmt.Errorf("render: value %v is not a map", call.Argument(1)))
+		}
+		out, err := renderStdTemplate(parts, data)
+		if err != nil {
+			return vm.NewGoError(fmt.Errorf("render: %w", err))
+		}
+		return vm.ToValue(out)
+	})
+
+	return ex, nil
+}
+
+// compileStdTemplate splits a template into its literal text and the key paths it interpolates.
+func compileStdTemplate(src string) ([]any, error) {
+	var parts []any
+	var text strings.Builder
+	flush := func() {
+		if text.Len() > 0 {
+			parts = append(parts, map[string]any{"text": text.String()})
+			text.Reset()
+		}
+	}
+
+	for i := 0; i < len(src); i++ {
+		if src[i] != '$' {
+			text.WriteByte(src[i])
+			continue
+		}
+		switch {
+		case strings.HasPrefix(src[i:], "$$"):
+			text.WriteByte('$')
+			i++
+		case strings.HasPrefix(src[i:], "${"):
+			end := strings.IndexByte(src[i:], '}')
+			if end < 0 {
+				return nil, fmt.Errorf("unterminated '${' at offset %d", i)
+			}
+			expr := strings.TrimSpace(src[i+2 : i+end])
+			path := []any{}
+			for key := range strings.SplitSeq(expr, ".") {
+				if key = strings.TrimSpace(key); key == "" {
+					return nil, fmt.Errorf("invalid path %q at offset %d", expr, i)
+				}
+				path = append(path, key)
+			}
+			flush()
+			parts = append(parts, map[string]any{"path": path})
+			i += end
+		default:
+			text.WriteByte('$')
+		}
+	}
+	flush()
+	return parts, nil
+}
+
+// renderStdTemplate applies the parts of a compiled template to data.
+func renderStdTemplate(parts []any, data map[string]any) (string, error) {
+	var out strings.Builder
+	for _, part := range parts {
+		p, ok := part.(map[string]any)
+		if !ok {
+			return "", fmt.Errorf("value %v is not a template part", part)
+		}
+		if text, ok := p["text"].(string); ok {
+			out.WriteString(text)
+			continue
+		}
+		path, ok := p["path"].([]any)
+		if !ok {
+			return "", fmt.Errorf("value %v is not a template part", part)
+		}
+
+		var v any = data
+		keys := make([]string, 0, len(path))
+		for _, key := range path {
+			k, _ := key.(string)
+			keys = append(keys, k)
+			m, ok := v.(map[string]any)
+			if !ok {
+				return "", fmt.Errorf("path '%s' is not in the data", strings.Join(keys, "."))
+			}
+			if v, ok = m[k]; !ok {
+				return "", fmt.Errorf("path '%s' is not in the data", strings.Join(keys, "."))
+			}
+		}
+
+		if str, ok := v.(string); ok {
+			out.WriteString(str)
+			continue
+		}
+		encoded, err := json.Marshal(v)
+		if err != nil {
+			return "", err
+		}
+		out.Write(encoded)
+	}
+	return out.String(), nil
+}
+
+// stdEqual compares exported values, treating integers and floats of the same value as equal.
+func stdEqual(a, b any) bool {
+	af, aNum := stdNumber(a)
+	bf, bNum := stdNumber(b)
+	if aNum || bNum {
+		return aNum && bNum && af == bf
+	}
+	return reflect.DeepEqual(a, b)
+}
+
+func stdNumber(v any) (float64, bool) {
+	switch n := v.(type) {
+	case int64:
+		return float64(n), true
+	case float64:
+		return n, true
+	default:
+		return 0, false
+	}
+}
@@ -0,0 +1,231 @@
+// SPDX-FileCopyrightText: © 2026 Binaek Sarkar <binaek89@gmail.com>
+// SPDX-License-Identifier: Apache-2.0
+
+package js
+
+import (
+	"time"
+
+	"github.com/dop251/goja"
+	"github.com/sentrie-sh/sentrie/constants"
+)
+
+func (s *JSTestSuite) stdCall(vm *goja.Runtime, ex *goja.Object, fn string, args ...any) goja.Value {
+	f, ok := goja.AssertFunction(ex.Get(fn))
+	s.Require().True(ok, fn)
+	vals := make([]goja.Value, 0, len(args))
+	for _, a := range args {
+		vals = append(vals, vm.ToValue(a))
+	}
+	out, err := f(goja.Undefined(), vals...)
+	s.Require().NoError(err)
+	return out
+}
+
+func (s *JSTestSuite) TestBuiltinStdNowIsPinnedToEvaluationStart() {
+	vm := goja.New()
+	ex, err := BuiltinStdGo(vm)
+	s.Require().NoError(err)
+
+	start := time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC)
+	s.Require().NoError(vm.Set(constants.ExecutionStartTimeUnixKey, start.Unix()))
+
+	first := s.stdCall(vm, ex, "now").String()
+	s.Equal("2024-05-01T10:00:00Z", first)
+	s.Equal(first, s.stdCall(vm, ex, "now").String())
+}
+
+func (s *JSTestSuite) TestBuiltinStdDates() {
+	vm := goja.New()
+	ex, err := BuiltinStdGo(vm)
+	s.Require().NoError(err)
+
+	s.Equal("2024-05-01T08:00:00Z", s.stdCall(vm, ex, "parse_date", "2024-05-01T10:00:00+02:00").String())
+	s.Equal("2024-05-01T00:00:00Z", s.stdCall(vm, ex, "parse_date", "2024-05-01").String())
+
+	s.Equal("2024-05-02T00:00:00Z", s.stdCall(vm, ex, "date_add", "2024-05-01", "24h").String())
+	s.Equal("2024-04-30T23:00:00Z", s.stdCall(vm, ex, "date_add", "2024-05-01", -3600).String())
+	s.Equal("2024-05-01T00:00:01.5Z", s.stdCall(vm, ex, "date_add", "2024-05-01", 1.5).String())
+
+	s.Equal(int64(86400), s.stdCall(vm, ex, "date_diff", "2024-05-02", "2024-05-01").ToInteger())
+	s.Equal(float64(-1.5), s.stdCall(vm, ex, "date_diff", "2024-05-01", "2024-05-01T00:00:01.5Z").ToFloat())
+
+	bad := s.stdCall(vm, ex, "parse_date", "tomorrow")
+	s.Contains(bad.String(), "not an RFC3339 / ISO-8601 date")
+	bad = s.stdCall(vm, ex, "date_add", "2024-05-01", "a day")
+	s.Contains(bad.String(), "date_add")
+}
+
+func (s *JSTestSuite) TestBuiltinStdAggregates() {
+	vm := goja.New()
+	ex, err := BuiltinStdGo(vm)
+	s.Require().NoError(err)
+
+	nums := []any{int64(3), 1.5, int64(-2)}
+	s.Equal(2.5, s.stdCall(vm, ex, "sum", nums).ToFloat())
+	s.Equal(int64(3), s.stdCall(vm, ex, "count", nums).ToInteger())
+	s.InDelta(0.8333, s.stdCall(vm, ex, "avg", nums).ToFloat(), 0.0001)
+	s.Equal(-2.0, s.stdCall(vm, ex, "min", nums).ToFloat())
+	s.Equal(3.0, s.stdCall(vm, ex, "max", nums).ToFloat())
+
+	empty := []any{}
+	s.Equal(0.0, s.stdCall(vm, ex, "sum", empty).ToFloat())
+	s.Equal(int64(0), s.stdCall(vm, ex, "count", empty).ToInteger())
+	for _, fn := range []string{"avg", "min", "max"} {
+		s.Contains(s.stdCall(vm, ex, fn, empty).String(), fn+": list is empty")
+	}
+
+	s.Contains(s.stdCall(vm, ex, "sum", []any{int64(1), "two"}).String(), "value two is not a number")
+	s.Contains(s.stdCall(vm, ex, "max", "nope").String(), "is not a list")
+}
+
+func (s *JSTestSuite) TestBuiltinStdStrings() {
+	vm := goja.New()
+	ex, err := BuiltinStdGo(vm)
+	s.Require().NoError(err)
+
+	s.Equal([]any{"a", "b", "c"}, s.stdCall(vm, ex, "split", "a,b,c", ",").Export())
+	s.Equal("a-b-c", s.stdCall(vm, ex, "join", []any{"a", "b", "c"}, "-").String())
+	s.Equal("admin", s.stdCall(vm, ex, "trim", "  admin\n").String())
+	s.Equal("ADMIN", s.stdCall(vm, ex, "to_upper", "admin").String())
+	s.Equal("admin", s.stdCall(vm, ex, "to_lower", "AdMiN").String())
+	s.Equal("org:team:user", s.stdCall(vm, ex, "replace", "org/team/user", "/", ":").String())
+
+	s.Equal("team", s.stdCall(vm, ex, "substring", "org/team", 4).String())
+	s.Equal("org", s.stdCall(vm, ex, "substring", "org/team", 0, 3).String())
+	s.Equal("é", s.stdCall(vm, ex, "substring", "café", 3, 4).String())
+
+	s.Contains(s.stdCall(vm, ex, "to_upper", 42).String(), "to_upper: value 42 is not a string")
+	s.Contains(s.stdCall(vm, ex, "join", []any{"a", int64(1)}, ",").String(), "join: value 1 is not a string")
+	s.Contains(s.stdCall(vm, ex, "substring", "abc", "x").String(), "substring: value x is not a number")
+}
+
+func (s *JSTestSuite) TestBuiltinStdTemplateCompilesOnceAndRendersMany() {
+	vm := goja.New()
+	ex, err := BuiltinStdGo(vm)
+	s.Require().NoError(err)
+
+	// the compiled template crosses the boundary as a plain value, as it would through a let
+	tmpl := s.stdCall(vm, ex, "template", "${user.name} may ${ action } for $$${cost}").Export()
+
+	s.Equal("alice may read for $3", s.stdCall(vm, ex, "render", tmpl, map[string]any{
+		"user": map[string]any{"name": "alice"}, "action": "read", "cost": int64(3),
+	}).String())
+	s.Equal("bob may write for $2.5", s.stdCall(vm, ex, "render", tmpl, map[string]any{
+		"user": map[string]any{"name": "bob"}, "action": "write", "cost": 2.5,
+	}).String())
+
+	s.Contains(s.stdCall(vm, ex, "render", tmpl, map[string]any{"user": "carol"}).String(), "render: path 'user.name' is not in the data")
+	s.Contains(s.stdCall(vm, ex, "render", "${user}", map[string]any{}).String(), "is not a compiled template")
+}
+
+func (s *JSTestSuite) TestBuiltinStdTemplateRejectsInvalidTemplates() {
+	vm := goja.New()
+	ex, err := BuiltinStdGo(vm)
+	s.Require().NoError(err)
+
+	s.Contains(s.stdCall(vm, ex, "template", "hello ${user").String(), "template: unterminated '${' at offset 6")
+	s.Contains(s.stdCall(vm, ex, "template", "hello ${}").String(), `template: invalid path "" at offset 6`)
+	s.Contains(s.stdCall(vm, ex, "template", "${user..name}").String(), `template: invalid path "user..name"`)
+	s.Contains(s.stdCall(vm, ex, "template", 42).String(), "template: value 42 is not a string")
+}
+
+func (s *JSTestSuite) TestBuiltinStdSubstringClampsIndices() {
+	vm := goja.New()
+	ex, err := BuiltinStdGo(vm)
+	s.Require().NoError(err)
+
+	s.Equal("abc", s.stdCall(vm, ex, "substring", "abc", -5).String())
+	s.Equal("abc", s.stdCall(vm, ex, "substring", "abc", 0, 99).String())
+	s.Equal("", s.stdCall(vm, ex, "substring", "abc", 10).String())
+	s.Equal("", s.stdCall(vm, ex, "substring", "abc", 2, 1).String())
+	s.Equal("", s.stdCall(vm, ex, "substring", "", 0, 1).String())
+}
+
+func (s *JSTestSuite) TestBuiltinStdContainsAndIndexOf() {
+	vm := goja.New()
+	ex, err := BuiltinStdGo(vm)
+	s.Require().NoError(err)
+
+	roles := []any{"viewer", "admin", int64(3)}
+	s.True(s.stdCall(vm, ex, "contains", roles, "admin").ToBoolean())
+	s.False(s.stdCall(vm, ex, "contains", roles, "owner").ToBoolean())
+	s.True(s.stdCall(vm, ex, "contains", roles, 3.0).ToBoolean())
+	s.Equal(int64(1), s.stdCall(vm, ex, "index_of", roles, "admin").ToInteger())
+	s.Equal(int64(2), s.stdCall(vm, ex, "index_of", roles, 3.0).ToInteger())
+	s.Equal(int64(-1), s.stdCall(vm, ex, "index_of", roles, "3").ToInteger())
+
+	s.True(s.stdCall(vm, ex, "contains", "org/admins", "admin").ToBoolean())
+	s.False(s.stdCall(vm, ex, "contains", "org/admins", "owner").ToBoolean())
+	s.Equal(int64(4), s.stdCall(vm, ex, "index_of", "café/x", "/").ToInteger())
+	s.Equal(int64(-1), s.stdCall(vm, ex, "index_of", "org", "x").ToInteger())
+
+	s.Contains(s.stdCall(vm, ex, "contains", "org", 1).String(), "contains: value 1 is not a string")
+	s.Contains(s.stdCall(vm, ex, "index_of", int64(5), 1).String(), "index_of: value 5 is not a string or a list")
+}
+
+func (s *JSTestSuite) TestBuiltinStdMath() {
+	vm := goja.New()
+	ex, err := BuiltinStdGo(vm)
+	s.Require().NoError(err)
+
+	s.Equal(3.0, s.stdCall(vm, ex, "abs", int64(-3)).ToFloat())
+	s.Equal(2.5, s.stdCall(vm, ex, "abs", -2.5).ToFloat())
+	s.Equal(2.0, s.stdCall(vm, ex, "ceil", 1.2).ToFloat())
+	s.Equal(-2.0, s.stdCall(vm, ex, "floor", -1.2).ToFloat())
+	s.Equal(1024.0, s.stdCall(vm, ex, "pow", int64(2), int64(10)).ToFloat())
+	s.Equal(3.0, s.stdCall(vm, ex, "sqrt", int64(9)).ToFloat())
+
+	s.Equal(3.0, s.stdCall(vm, ex, "round", 2.5).ToFloat())
+	s.Equal(-3.0, s.stdCall(vm, ex, "round", -2.5).ToFloat())
+	s.Equal(3.14, s.stdCall(vm, ex, "round", 3.14159, int64(2)).ToFloat())
+	s.Equal(1200.0, s.stdCall(vm, ex, "round", 1234.5, int64(-2)).ToFloat())
+
+	s.Contains(s.stdCall(vm, ex, "sqrt", int64(-1)).String(), "sqrt: result of [-1] is not a finite number")
+	s.Contains(s.stdCall(vm, ex, "pow", 10.0, 400.0).String(), "pow: result of [10 400] is not a finite number")
+	s.Contains(s.stdCall(vm, ex, "abs", "x").String(), "abs: value x is not a number")
+	s.Contains(s.stdCall(vm, ex, "round", 1.0, 2.0, 3.0).String(), "round requires 1 to 2 arguments")
+}
+
+func (s *JSTestSuite) TestBuiltinStdJSON() {
+	vm := goja.New()
+	ex, err := BuiltinStdGo(vm)
+	s.Require().NoError(err)
+
+	parsed := s.stdCall(vm, ex, "json_parse", `{"user":{"id":7,"roles":["admin"],"ratio":0.5,"active":true,"manager":null}}`).Export()
+	s.Equal(map[string]any{
+		"user": map[string]any{
+			"id":      float64(7),
+			"roles":   []any{"admin"},
+			"ratio":   0.5,
+			"active":  true,
+			"manager": nil,
+		},
+	}, parsed)
+	s.Equal([]any{float64(1), "two"}, s.stdCall(vm, ex, "json_parse", `[1, "two"]`).Export())
+	s.Equal("x", s.stdCall(vm, ex, "json_parse", `"x"`).Export())
+
+	s.Equal(`{"a":[1,"b"],"z":true}`, s.stdCall(vm, ex, "json_stringify", map[string]any{"z": true, "a": []any{int64(1), "b"}}).String())
+	s.Equal(`"x"`, s.stdCall(vm, ex, "json_stringify", "x").String())
+
+	s.Contains(s.stdCall(vm, ex, "json_parse", `{"user":`).String(), "json_parse: unexpected end of JSON input")
+	s.Contains(s.stdCall(vm, ex, "json_parse", int64(1)).String(), "json_parse: value 1 is not a string")
+}
+
+func (s *JSTestSuite) TestBuiltinStdHashAndBase64() {
+	vm := goja.New()
+	ex, err := BuiltinStdGo(vm)
+	s.Require().NoError(err)
+
+	s.Equal("e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855", s.stdCall(vm, ex, "sha256_hex", "").String())
+	s.Equal("2cf24dba5fb0a30e26e83b2ac5b9e29e1b161e5c1fa7425e73043362938b9824", s.stdCall(vm, ex, "sha256_hex", "hello").String())
+
+	s.Equal("aMOpbGxv", s.stdCall(vm, ex, "base64_encode", "héllo").String())
+	s.Equal("héllo", s.stdCall(vm, ex, "base64_decode", "aMOpbGxv").String())
+	s.Equal("", s.stdCall(vm, ex, "base64_decode", "").String())
+
+	s.Contains(s.stdCall(vm, ex, "base64_decode", "aGVsbG8").String(), "base64_decode: illegal base64 data at input byte 4")
+	s.Contains(s.stdCall(vm, ex, "base64_decode", "//8=").String(), `base64_decode: "//8=" does not decode to a UTF-8 string`)
+	s.Contains(s.stdCall(vm, ex, "sha256_hex", int64(1)).String(), "sha256_hex: value 1 is not a string")
+	s.Contains(s.stdCall(vm, ex, "base64_encode").String(), "base64_encode requires exactly 1 argument(s)")
+}
@@ -19,8 +19,10 @@ package js
 import (
 	"encoding/json"
 	"fmt"
+	"maps"
 	"os"
 	"path/filepath"
+	"slices"
 	"strings"
 	"sync"
 
@@ -51,11 +53,23 @@ type ModuleSpec struct {
 	err             error
 }
 
+// Purity declares whether the functions of a builtin module always answer the same for the same arguments.
+type Purity bool
+
+const (
+	// Pure functions depend on their arguments only.
+	Pure Purity = true
+	// Volatile functions can answer differently from one call to the next, e.g. by reading the clock or a random source.
+	Volatile Purity = false
+)
+
 type Registry struct {
 	PackRoot string
 
 	goBuiltins map[string]ModuleProvider // name -> Go module provider
 	tsBuiltins map[string]string         // name -> TypeScript source
+	volatile   map[string]struct{}       // names of the volatile builtins
+	volatileFn map[string][]string       // name -> the volatile functions of a pure builtin
 
 	modsMu sync.RWMutex
 	mods   map[string]*ModuleSpec
@@ -66,16 +80,58 @@ func NewRegistry(packRoot string) *Registry {
 		PackRoot:   packRoot,
 		goBuiltins: map[string]ModuleProvider{},
 		tsBuiltins: map[string]string{},
+		volatile:   map[string]struct{}{},
+		volatileFn: map[string][]string{},
 		mods:       map[string]*ModuleSpec{},
 	}
 }
 
-func (r *Registry) RegisterGoBuiltin(name string, provider ModuleProvider) {
+func (r *Registry) RegisterGoBuiltin(name string, provider ModuleProvider, purity Purity) {
 	r.goBuiltins[fmt.Sprintf("@%s/%s", constants.APPNAME, name)] = provider
+	r.setPurity(name, purity)
 }
 
-func (r *Registry) RegisterTSBuiltin(name, tsSource string) {
+func (r *Registry) RegisterTSBuiltin(name, tsSource string, purity Purity) {
 	r.tsBuiltins[fmt.Sprintf("@%s/%s", constants.APPNAME, name)] = tsSource
+	r.setPurity(name, purity)
+}
+
+func (r *Registry) setPurity(name string, purity Purity) {
+	if purity == Volatile {
+		r.volatile[name] = struct{}{}
+	} else {
+		delete(r.volatile, name)
+	}
+}
+
+// RegisterVolatileFunctions marks functions of the builtin module name as volatile, e.g. now()
+// among date helpers, so that using the other functions of a Pure module keeps it pure.
+func (r *Registry) RegisterVolatileFunctions(name string, functions ...string) {
+	r.volatileFn[name] = append(r.volatileFn[name], functions...)
+}
+
+// IsVolatile reports whether using functions of the builtin module name is volatile: the module
+// was registered as Volatile, or one of functions was registered with RegisterVolatileFunctions.
+func (r *Registry) IsVolatile(name string, functions ...string) bool {
+	if _, ok := r.volatile[name]; ok {
+		return true
+	}
+	return slices.ContainsFunc(functions, func(fn string) bool {
+		return slices.Contains(r.volatileFn[name], fn)
+	})
+}
+
+// VolatileBuiltins returns the names of the builtin modules registered as Volatile, or with
+// functions registered with RegisterVolatileFunctions.
+func (r *Registry) VolatileBuiltins() []string {
+	names := slices.Collect(maps.Keys(r.volatile))
+	for name := range r.volatileFn {
+		if !slices.Contains(names, name) {
+			names = append(names, name)
+		}
+	}
+	slices.Sort(names)
+	return names
 }
 
 // Resolve a "use" style reference into a canonical registry key + filesystem path.
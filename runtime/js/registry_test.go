@@ -21,7 +21,7 @@ import "github.com/dop251/goja"
 func (s *JSTestSuite) TestRegisterTSBuiltin() {
 	reg := NewRegistry("/tmp/test")
 	tsSource := `export const test = () => 42;`
-	reg.RegisterTSBuiltin("test", tsSource)
+	reg.RegisterTSBuiltin("test", tsSource, Pure)
 	key := "@sentrie/test"
 	s.Contains(reg.tsBuiltins, key)
 	s.Equal(tsSource, reg.tsBuiltins[key])
@@ -30,7 +30,7 @@ func (s *JSTestSuite) TestRegisterTSBuiltin() {
 func (s *JSTestSuite) TestGetOrCreateModule_TSBuiltin() {
 	reg := NewRegistry("/tmp/test")
 	tsSource := `export const test = () => 42;`
-	reg.RegisterTSBuiltin("test", tsSource)
+	reg.RegisterTSBuiltin("test", tsSource, Pure)
 	mod := reg.getOrCreateModule("@sentrie/test", "", "", true)
 	s.Require().NotNil(mod)
 	s.True(mod.Builtin)
@@ -43,9 +43,9 @@ func (s *JSTestSuite) TestGetOrCreateModule_GoProviderTakesPrecedence() {
 	goProvider := func(vm *goja.Runtime) (*goja.Object, error) {
 		return vm.NewObject(), nil
 	}
-	reg.RegisterGoBuiltin("test", goProvider)
+	reg.RegisterGoBuiltin("test", goProvider, Pure)
 	tsSource := `export const test = () => 42;`
-	reg.RegisterTSBuiltin("test", tsSource)
+	reg.RegisterTSBuiltin("test", tsSource, Pure)
 	mod := reg.getOrCreateModule("@sentrie/test", "", "", true)
 	s.Require().NotNil(mod)
 	s.NotNil(mod.BuiltInProvider)
@@ -55,7 +55,7 @@ func (s *JSTestSuite) TestGetOrCreateModule_GoProviderTakesPrecedence() {
 func (s *JSTestSuite) TestPrepareUse_TSBuiltin() {
 	reg := NewRegistry("/tmp/test")
 	tsSource := `export const test = () => 42;`
-	reg.RegisterTSBuiltin("test", tsSource)
+	reg.RegisterTSBuiltin("test", tsSource, Pure)
 	mod, err := reg.PrepareUse("", []string{"sentrie", "test"}, "/tmp/test")
 	s.Require().NoError(err)
 	s.Require().NotNil(mod)
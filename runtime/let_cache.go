@@ -0,0 +1,59 @@
+// SPDX-FileCopyrightText: © 2026 Binaek Sarkar <binaek89@gmail.com>
+// SPDX-License-Identifier: Apache-2.0
+
+package runtime
+
+import (
+	"context"
+	"sync"
+
+	"github.com/sentrie-sh/sentrie/box"
+)
+
+// letCache memoizes the values of the lets of a policy within one request, so that a let is
+// evaluated once however many rules reference it. It is keyed by let name, and only shared by
+// the executions of one policy against the same facts. The results of derive calls are
+// memoized the same way in a cache of their own, keyed by derive and arguments.
+type letCache struct {
+	mu      sync.Mutex
+	entries map[string]*letCacheEntry
+}
+
+// letCacheEntry is the value of a let, or the error evaluating it; done is closed once either
+// is set.
+type letCacheEntry struct {
+	done  chan struct{}
+	value box.Value
+	err   error
+}
+
+func newLetCache() *letCache {
+	return &letCache{entries: make(map[string]*letCacheEntry)}
+}
+
+// get returns the value of the let named name, evaluating it with evaluate on first reference.
+// Concurrent references wait for the first one to finish, so evaluate runs at most once. The
+// caller must rule out cyclic references before calling get, as a cycle would wait on itself;
+// a wait is still given up when ctx is done.
+func (c *letCache) get(ctx context.Context, name string, evaluate func() (box.Value, error)) (box.Value, error) {
+	c.mu.Lock()
+	entry, found := c.entries[name]
+	if !found {
+		entry = &letCacheEntry{done: make(chan struct{})}
+		c.entries[name] = entry
+	}
+	c.mu.Unlock()
+
+	if found {
+		select {
+		case <-entry.done:
+			return entry.value, entry.err
+		case <-ctx.Done():
+			return box.Undefined(), ctx.Err()
+		}
+	}
+
+	defer close(entry.done)
+	entry.value, entry.err = evaluate()
+	return entry.value, entry.err
+}
@@ -0,0 +1,163 @@
+// SPDX-FileCopyrightText: © 2026 Binaek Sarkar <binaek89@gmail.com>
+// SPDX-License-Identifier: Apache-2.0
+
+package runtime
+
+import (
+	"context"
+	"sync"
+
+	"github.com/sentrie-sh/sentrie/ast"
+	"github.com/sentrie-sh/sentrie/box"
+	"github.com/sentrie-sh/sentrie/index"
+	"github.com/sentrie-sh/sentrie/pack"
+	"github.com/sentrie-sh/sentrie/parser"
+	"github.com/sentrie-sh/sentrie/tokens"
+	"github.com/sentrie-sh/sentrie/trinary"
+)
+
+const letCachePolicy = `namespace com/example
+policy limits {
+  fact amount: number
+  let limit = 100 * 10
+  rule small = { yield amount < limit }
+  rule large = { yield amount >= limit and amount < limit * 10 }
+  rule doubled = { yield collect([1, 2, 3], (item) => { let scaled = item * 2
+    yield scaled }) == [2, 4, 6] }
+  export decision of small
+  export decision of large
+  export decision of doubled
+}
+`
+
+// countLetEvaluations counts the evaluations of every let by name until the test ends.
+func (s *RuntimeTestSuite) countLetEvaluations() func(name string) int {
+	var mu sync.Mutex
+	counts := map[string]int{}
+	original := letEvaluated
+	letEvaluated = func(v *ast.VarDeclaration) {
+		mu.Lock()
+		defer mu.Unlock()
+		counts[v.Name]++
+	}
+	s.T().Cleanup(func() { letEvaluated = original })
+	return func(name string) int {
+		mu.Lock()
+		defer mu.Unlock()
+		return counts[name]
+	}
+}
+
+func (s *RuntimeTestSuite) TestPolicyLetIsEvaluatedOncePerRequest() {
+	count := s.countLetEvaluations()
+	exec := s.executorFromSource(letCachePolicy)
+
+	outputs, err := exec.ExecPolicy(context.Background(), "com/example", "limits", map[string]any{"amount": 5000})
+	s.Require().NoError(err)
+	s.Require().Len(outputs, 3)
+	for _, output := range outputs {
+		if output.RuleName == "small" {
+			s.Equal(trinary.False, output.ToTrinary())
+		} else {
+			s.Equal(trinary.True, output.ToTrinary())
+		}
+	}
+	// both rules reference the let, and one of them twice
+	s.Equal(1, count("limit"))
+	// a block-local let is evaluated every time its block is
+	s.Equal(3, count("scaled"))
+
+	// another request evaluates it again
+	_, err = exec.ExecRule(context.Background(), "com/example", "limits", "large", map[string]any{"amount": 5000})
+	s.Require().NoError(err)
+	s.Equal(2, count("limit"))
+}
+
+func (s *RuntimeTestSuite) TestPolicyLetIsEvaluatedInThePolicyScope() {
+	exec := s.executorFromSource(`namespace com/example
+policy scoped {
+  fact amount: number
+  let doubled = amount * 2
+  rule first = { yield collect([5], (amount) => { yield doubled }) == [2] }
+  export decision of first
+}
+`)
+
+	// the lambda parameter referencing the let first does not shadow the fact in it
+	output, err := exec.ExecRule(context.Background(), "com/example", "scoped", "first", map[string]any{"amount": 1})
+	s.Require().NoError(err)
+	s.Equal(trinary.True, output.ToTrinary())
+}
+
+func (s *RuntimeTestSuite) TestLetCacheEvaluatesOnceUnderConcurrentReferences() {
+	cache := newLetCache()
+	var mu sync.Mutex
+	evaluations := 0
+	var wg sync.WaitGroup
+	for range 16 {
+		wg.Go(func() {
+			val, err := cache.get(context.Background(), "limit", func() (box.Value, error) {
+				mu.Lock()
+				defer mu.Unlock()
+				evaluations++
+				return box.Number(1000), nil
+			})
+			s.NoError(err)
+			s.Equal(box.Number(1000), val)
+		})
+	}
+	wg.Wait()
+	s.Equal(1, evaluations)
+}
+
+func (s *RuntimeTestSuite) TestLetCacheWaitEndsWithTheContext() {
+	cache := newLetCache()
+	started, release := make(chan struct{}), make(chan struct{})
+	go func() {
+		_, _ = cache.get(context.Background(), "limit", func() (box.Value, error) {
+			close(started)
+			<-release
+			return box.Number(1), nil
+		})
+	}()
+	defer close(release)
+	<-started
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err := cache.get(ctx, "limit", func() (box.Value, error) { return box.Number(2), nil })
+	s.ErrorIs(err, context.Canceled)
+}
+
+func (s *RuntimeTestSuite) TestCyclicLetsAreReportedWithTheCyclePath() {
+	ctx := context.Background()
+	program, err := parser.NewParserFromString(`namespace com/example
+policy cyclic {
+  let a = b + 1
+  let b = a + 1
+  rule r = { yield a > 0 }
+  export decision of r
+}`, "cyclic.sentrie").ParseProgram(ctx)
+	s.Require().NoError(err)
+
+	idx := index.CreateIndex()
+	s.Require().NoError(idx.SetPack(ctx, &pack.PackFile{Location: s.T().TempDir()}))
+	s.Require().NoError(idx.AddProgram(ctx, program))
+
+	// the index rejects the cycle
+	err = idx.Validate(ctx)
+	s.Require().Error(err)
+	s.Regexp(`infinite recursion: (a -> b -> a|b -> a -> b)`, err.Error())
+
+	// and evaluation reports it with the path, rather than waiting on the let forever
+	p, err := idx.ResolvePolicy("com/example", "cyclic")
+	s.Require().NoError(err)
+	exec := &executorImpl{}
+	ec := NewExecutionContext(p, exec)
+	for name, let := range p.Lets {
+		s.Require().NoError(ec.InjectLet(name, let))
+	}
+	_, _, err = eval(ctx, ec, exec, p, ast.NewIdentifier("a", tokens.Range{}))
+	s.Require().Error(err)
+	s.Contains(err.Error(), "infinite recursion: a -> b -> a")
+}
@@ -112,6 +112,15 @@ func (m ModuleBinding) Call(ctx context.Context, ec *ExecutionContext, fn string
 		return nil, err
 	}
 
+	// Go builtins report failures by returning a GoError rather than throwing it
+	if obj, ok := out.(*goja.Object); ok {
+		if value := obj.Get("value"); value != nil {
+			if goErr, ok := value.Export().(error); ok {
+				return nil, goErr
+			}
+		}
+	}
+
 	acceptedReturnTypes := []reflect.Kind{
 		reflect.Map,
 		reflect.Slice,
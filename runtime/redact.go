@@ -0,0 +1,524 @@
+// SPDX-FileCopyrightText: © 2026 Binaek Sarkar <binaek89@gmail.com>
+// SPDX-License-Identifier: Apache-2.0
+
+package runtime
+
+import (
+	"cmp"
+	"math"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/sentrie-sh/sentrie/ast"
+	"github.com/sentrie-sh/sentrie/box"
+	"github.com/sentrie-sh/sentrie/index"
+	"github.com/sentrie-sh/sentrie/runtime/trace"
+)
+
+// Redacted stands in for the value of a sensitive fact wherever it would surface.
+const Redacted = "***"
+
+// redactor hides the values of sensitive facts from what leaves an evaluation: decisions,
+// attachments, traces and error messages. Values are hidden by provenance: a value computed from
+// a sensitive fact is replaced whole, whatever it holds, and no other value is touched. Booleans,
+// nulls and trinaries are kept in decisions and attachments, as decisions are made of them, but
+// not in traces, which only keep the state of a decision. Error messages are text, so the
+// secrets themselves are replaced in them.
+type redactor struct {
+	scope *sensitiveScope
+
+	// strs and numbers are the secrets replaced in error messages, matched by strsPattern and
+	// numbersPattern
+	strs           []string
+	numbers        []float64
+	strsPattern    *regexp.Regexp
+	numbersPattern *regexp.Regexp
+}
+
+// spanPattern matches the source spans error messages carry, e.g. "policy.sentrie:3:12-14",
+// whose line and column numbers are positions rather than values.
+var spanPattern = regexp.MustCompile(`[^\s:]*:\d+:\d+(?:-\d+(?::\d+)?)?`)
+
+// sensitiveRedactor returns a redactor for p, hiding the sensitive facts supplied in facts from
+// error messages.
+func (e *executorImpl) sensitiveRedactor(p *index.Policy, facts map[string]any) *redactor {
+	r := &redactor{scope: e.sensitiveScope(p)}
+	for name, stmt := range p.Facts {
+		if v, ok := facts[name]; ok && stmt.Sensitive {
+			r.add(box.FromBoundaryAny(v))
+		}
+	}
+	return r
+}
+
+// add makes the string and number leaves of v secrets of error messages.
+func (r *redactor) add(v box.Value) {
+	r.collect(v)
+	r.strsPattern, r.numbersPattern = nil, nil
+}
+
+// compile builds the patterns matching the string and the number secrets in text, nil when
+// there are none. Strings are matched the longest first, so that a secret containing another
+// is replaced whole.
+func (r *redactor) compile() (strs, numbers *regexp.Regexp) {
+	if r.strsPattern != nil || r.numbersPattern != nil {
+		return r.strsPattern, r.numbersPattern
+	}
+	if len(r.strs) > 0 {
+		slices.SortFunc(r.strs, func(a, b string) int { return cmp.Compare(len(b), len(a)) })
+		alternatives := make([]string, 0, len(r.strs))
+		for _, s := range r.strs {
+			alternatives = append(alternatives, regexp.QuoteMeta(s))
+		}
+		r.strsPattern = regexp.MustCompile(strings.Join(alternatives, "|"))
+	}
+	if len(r.numbers) > 0 {
+		alternatives := make([]string, 0, len(r.numbers))
+		for _, n := range r.numbers {
+			// a sign is not part of a word, so only the digits of a negative number are matched
+			alternatives = append(alternatives, `\b`+regexp.QuoteMeta(box.Number(math.Abs(n)).String())+`\b`)
+		}
+		r.numbersPattern = regexp.MustCompile(strings.Join(alternatives, "|"))
+	}
+	return r.strsPattern, r.numbersPattern
+}
+
+func (r *redactor) collect(v box.Value) {
+	if s, ok := v.StringValue(); ok {
+		// error messages often quote values, escaping them on the way
+		quoted := strconv.Quote(s)
+		for _, secret := range []string{s, quoted[1 : len(quoted)-1]} {
+			if secret != "" && !slices.Contains(r.strs, secret) {
+				r.strs = append(r.strs, secret)
+			}
+		}
+		return
+	}
+	if n, ok := v.NumberValue(); ok {
+		if !slices.Contains(r.numbers, n) {
+			r.numbers = append(r.numbers, n)
+		}
+		return
+	}
+	if xs, ok := v.ListValue(); ok {
+		for _, x := range xs {
+			r.collect(x)
+		}
+		return
+	}
+	if m, ok := v.DictValue(); ok {
+		for _, x := range m {
+			r.collect(x)
+		}
+	}
+}
+
+// text returns the message s with every secret replaced by Redacted. Numbers are not replaced
+// within the source spans of s, where a small secret would otherwise hide a line or column.
+func (r *redactor) text(s string) string {
+	if len(r.strs) == 0 && len(r.numbers) == 0 || s == "" {
+		return s
+	}
+	strs, numbers := r.compile()
+	if strs != nil {
+		s = strs.ReplaceAllLiteralString(s, Redacted)
+	}
+	if numbers == nil {
+		return s
+	}
+	var b strings.Builder
+	last := 0
+	for _, span := range spanPattern.FindAllStringIndex(s, -1) {
+		b.WriteString(numbers.ReplaceAllLiteralString(s[last:span[0]], Redacted))
+		b.WriteString(s[span[0]:span[1]])
+		last = span[1]
+	}
+	b.WriteString(numbers.ReplaceAllLiteralString(s[last:], Redacted))
+	return b.String()
+}
+
+// err returns err with its message redacted. The redacted error still unwraps to err, so
+// that callers can classify it.
+func (r *redactor) err(err error) error {
+	if err == nil {
+		return err
+	}
+	msg := err.Error()
+	if redacted := r.text(msg); redacted != msg {
+		return &redactedError{msg: redacted, err: err}
+	}
+	return err
+}
+
+// expression returns the value v of expr, hidden when expr reads a sensitive fact, and redacts
+// the trace n of its evaluation.
+func (r *redactor) expression(expr ast.Expression, v box.Value, n *trace.Node) box.Value {
+	if len(r.scope.names) == 0 {
+		return v
+	}
+	scope := r.scope.including(expr)
+	r.trace(scope, n)
+	if scope.reads(expr) {
+		return hidden(v)
+	}
+	return v
+}
+
+// trace redacts the results, errors and metadata of every node of the tree rooted at n, hiding
+// the results of the nodes whose expression reads a sensitive fact of scope. Below an import,
+// the nodes belong to the imported policy and are redacted in its scope.
+func (r *redactor) trace(scope *sensitiveScope, n *trace.Node) {
+	if n == nil {
+		return
+	}
+	n.Err = r.text(n.Err)
+	for k, v := range n.Meta {
+		if s, ok := v.(string); ok {
+			n.Meta[k] = r.text(s)
+		}
+	}
+	if clause, ok := n.Node.(*ast.ImportClause); ok {
+		if scope.reads(clause) {
+			r.hideTrace(n)
+			return
+		}
+		if target := scope.imported(clause); target != nil {
+			scope = target
+		}
+	} else if n.Node != nil && scope.reads(n.Node) {
+		n.Result = hiddenResult(n.Result)
+	}
+	for _, child := range n.Children {
+		r.trace(scope, child)
+	}
+}
+
+// hideTrace hides the results of every node of the tree rooted at n.
+func (r *redactor) hideTrace(n *trace.Node) {
+	n.Result = hiddenResult(n.Result)
+	n.Err = r.text(n.Err)
+	for _, child := range n.Children {
+		r.hideTrace(child)
+	}
+}
+
+// output hides the decision, attachments and directives of o that are computed from a sensitive
+// fact, and redacts its trace, in place.
+func (r *redactor) output(o *ExecutorOutput) *ExecutorOutput {
+	if o == nil || len(r.scope.names) == 0 {
+		return o
+	}
+	scope := r.scope
+	if o.Decision != nil && scope.has(o.RuleName) {
+		o.Decision = &Decision{State: o.Decision.State, Value: hidden(o.Decision.Value)}
+	}
+	if export := scope.policy.RuleExports[o.RuleName]; export != nil {
+		sensitive := map[string]bool{}
+		for _, attachment := range export.Attachments {
+			sensitive[attachment.Name] = scope.reads(attachment.Value)
+		}
+		if o.Attachments != nil {
+			attachments := make(DecisionAttachments, len(o.Attachments))
+			for k, v := range o.Attachments {
+				if sensitive[k] {
+					v = hidden(v)
+				}
+				attachments[k] = v
+			}
+			o.Attachments = attachments
+		}
+		o.Obligations = hiddenDirectives(o.Obligations, sensitive)
+		o.Advice = hiddenDirectives(o.Advice, sensitive)
+	}
+	r.trace(scope, o.RuleNode)
+	return o
+}
+
+// hiddenDirectives returns a copy of directives with the values of the sensitive ones hidden.
+func hiddenDirectives(directives []Directive, sensitive map[string]bool) []Directive {
+	if directives == nil {
+		return nil
+	}
+	redacted := make([]Directive, len(directives))
+	for i, d := range directives {
+		if sensitive[d.Name] {
+			d.Value = hidden(d.Value)
+		}
+		redacted[i] = d
+	}
+	return redacted
+}
+
+// hidden returns Redacted in place of v, keeping the booleans, nulls and trinaries and the
+// state of a decision.
+func hidden(v box.Value) box.Value {
+	switch v.Kind() {
+	case box.ValueInvalid, box.ValueUndefined, box.ValueNull, box.ValueBool, box.ValueTrinary:
+		return v
+	}
+	if ref, ok := v.DocumentRef(); ok {
+		if d, ok := ref.(*Decision); ok {
+			return box.Object(&Decision{State: d.State, Value: hidden(d.Value)})
+		}
+	}
+	return box.String(Redacted)
+}
+
+// hiddenResult returns Redacted in place of the result v of a trace node, whatever its kind,
+// keeping only the state of a decision.
+func hiddenResult(v box.Value) box.Value {
+	if v.Kind() == box.ValueInvalid {
+		return v
+	}
+	if ref, ok := v.DocumentRef(); ok {
+		if d, ok := ref.(*Decision); ok {
+			return box.Object(&Decision{State: d.State, Value: box.String(Redacted)})
+		}
+	}
+	return box.String(Redacted)
+}
+
+// sensitiveScope holds the names of a policy that may hold a value computed from one of its
+// sensitive facts: the sensitive facts, the facts, lets, rules and derives reading one, directly
+// or through another, and the lambda and derive parameters, block lets and projection bindings
+// of expressions reading one. Shadowing is not told apart, so a name holds a sensitive value
+// wherever it is bound.
+type sensitiveScope struct {
+	exec   *executorImpl
+	policy *index.Policy
+	names  map[string]struct{}
+
+	// reading memoizes reads by node, once names is complete
+	reading sync.Map
+}
+
+// sensitiveScope returns the sensitiveScope of p, computed once per executor.
+func (e *executorImpl) sensitiveScope(p *index.Policy) *sensitiveScope {
+	return e.sensitiveScopeOf(p, map[string]struct{}{})
+}
+
+func (e *executorImpl) sensitiveScopeOf(p *index.Policy, seen map[string]struct{}) *sensitiveScope {
+	if scope, ok := e.sensitiveScopes.Load(p.FQN.String()); ok {
+		return scope.(*sensitiveScope)
+	}
+	if _, ok := seen[p.FQN.String()]; ok {
+		// an import cycle, which evaluation reports; nothing is read through it
+		return &sensitiveScope{exec: e, policy: p, names: map[string]struct{}{}}
+	}
+	seen[p.FQN.String()] = struct{}{}
+
+	scope := &sensitiveScope{exec: e, policy: p, names: map[string]struct{}{}}
+	for name, fact := range p.Facts {
+		if fact.Sensitive {
+			scope.names[name] = struct{}{}
+		}
+	}
+
+	imports := map[*ast.ImportClause]*sensitiveScope{}
+	for _, rule := range p.Rules {
+		if clause, ok := rule.Body.(*ast.ImportClause); ok {
+			if target := e.importedPolicy(clause); target != nil {
+				imports[clause] = e.sensitiveScopeOf(target, seen)
+			}
+		}
+	}
+	readsImport := func(clause *ast.ImportClause) bool {
+		target := imports[clause]
+		return target != nil && target.has(clause.RuleToImport)
+	}
+
+	roots := sensitiveRoots(p)
+	for changed := true; changed; {
+		changed = false
+		mark := func(names ...string) {
+			for _, name := range names {
+				if !scope.has(name) {
+					scope.names[name] = struct{}{}
+					changed = true
+				}
+			}
+		}
+		reads := func(n ast.Node) bool { return scope.readsWith(n, readsImport) }
+
+		for name, fact := range p.Facts {
+			if fact.Default != nil && reads(fact.Default) {
+				mark(name)
+			}
+		}
+		for name, let := range p.Lets {
+			if reads(let.Value) {
+				mark(name)
+			}
+		}
+		for name, derive := range p.Derives {
+			if reads(derive.Body) {
+				mark(name)
+			}
+		}
+		for name, rule := range p.Rules {
+			if reads(rule.Default) || reads(rule.When) || reads(rule.Body) {
+				mark(name)
+			}
+		}
+		for _, root := range roots {
+			if reads(root) {
+				mark(localNames(root)...)
+			}
+			// a derive parameter holds what a call passes it
+			ast.Inspect(root, func(n ast.Node) bool {
+				call, ok := n.(*ast.CallExpression)
+				if !ok {
+					return true
+				}
+				callee, ok := call.Callee.(*ast.Identifier)
+				if !ok {
+					return true
+				}
+				if derive, ok := p.Derives[callee.Value]; ok && slices.ContainsFunc(call.Arguments, func(arg ast.Expression) bool { return reads(arg) }) {
+					mark(derive.Params...)
+				}
+				return true
+			})
+		}
+	}
+
+	actual, _ := e.sensitiveScopes.LoadOrStore(p.FQN.String(), scope)
+	return actual.(*sensitiveScope)
+}
+
+// sensitiveRoots returns the expressions evaluated on behalf of p.
+func sensitiveRoots(p *index.Policy) []ast.Node {
+	var roots []ast.Node
+	for _, fact := range p.Facts {
+		roots = append(roots, fact.Default)
+	}
+	for _, let := range p.Lets {
+		roots = append(roots, let.Value)
+	}
+	for _, derive := range p.Derives {
+		roots = append(roots, derive.Body)
+	}
+	for _, rule := range p.Rules {
+		roots = append(roots, rule.Default, rule.When, rule.Body)
+	}
+	for _, export := range p.RuleExports {
+		for _, attachment := range export.Attachments {
+			roots = append(roots, attachment.Value)
+		}
+	}
+	return roots
+}
+
+// localNames returns the names bound inside root: lambda parameters, block lets and projection
+// bindings.
+func localNames(root ast.Node) []string {
+	var names []string
+	ast.Inspect(root, func(n ast.Node) bool {
+		switch n := n.(type) {
+		case *ast.LambdaExpression:
+			names = append(names, n.Params...)
+		case *ast.BlockExpression:
+			for _, stmt := range n.Statements {
+				if let, ok := stmt.(*ast.VarDeclaration); ok {
+					names = append(names, let.Name)
+				}
+			}
+		case *ast.ProjectExpression:
+			if n.As != "" {
+				names = append(names, n.As)
+			}
+		}
+		return true
+	})
+	return names
+}
+
+// importedPolicy resolves the policy clause imports from, or returns nil when it does not
+// resolve, which evaluation reports.
+func (e *executorImpl) importedPolicy(clause *ast.ImportClause) *index.Policy {
+	if clause.FromPolicyFQN == nil || len(clause.FromPolicyFQN.Parts) < 2 {
+		return nil
+	}
+	target, err := e.index.ResolvePolicy(clause.FromPolicyFQN.Parent().String(), clause.FromPolicyFQN.LastSegment())
+	if err != nil {
+		return nil
+	}
+	return target
+}
+
+// has reports whether name may hold a value computed from a sensitive fact.
+func (s *sensitiveScope) has(name string) bool {
+	_, ok := s.names[name]
+	return ok
+}
+
+// reads reports whether evaluating n may read a sensitive fact.
+func (s *sensitiveScope) reads(n ast.Node) bool {
+	if len(s.names) == 0 {
+		return false
+	}
+	if reading, ok := s.reading.Load(n); ok {
+		return reading.(bool)
+	}
+	reading := s.readsWith(n, func(clause *ast.ImportClause) bool {
+		target := s.imported(clause)
+		return target != nil && target.has(clause.RuleToImport)
+	})
+	s.reading.Store(n, reading)
+	return reading
+}
+
+func (s *sensitiveScope) readsWith(n ast.Node, readsImport func(*ast.ImportClause) bool) bool {
+	found := false
+	ast.Inspect(n, func(n ast.Node) bool {
+		switch n := n.(type) {
+		case *ast.Identifier:
+			found = s.has(n.Value)
+		case *ast.ImportClause:
+			found = readsImport(n)
+		}
+		return !found
+	})
+	return found
+}
+
+// imported returns the scope of the policy clause imports from, or nil when it does not resolve.
+func (s *sensitiveScope) imported(clause *ast.ImportClause) *sensitiveScope {
+	target := s.exec.importedPolicy(clause)
+	if target == nil {
+		return nil
+	}
+	return s.exec.sensitiveScope(target)
+}
+
+// including returns s extended with the names bound inside expr, when expr reads a sensitive
+// fact; expr is evaluated in the scope of the policy without being one of its expressions.
+func (s *sensitiveScope) including(expr ast.Expression) *sensitiveScope {
+	if !s.reads(expr) {
+		return s
+	}
+	locals := localNames(expr)
+	if len(locals) == 0 {
+		return s
+	}
+	extended := &sensitiveScope{exec: s.exec, policy: s.policy, names: make(map[string]struct{}, len(s.names)+len(locals))}
+	for name := range s.names {
+		extended.names[name] = struct{}{}
+	}
+	for _, name := range locals {
+		extended.names[name] = struct{}{}
+	}
+	return extended
+}
+
+// redactedError is an error whose message had secrets removed.
+type redactedError struct {
+	msg string
+	err error
+}
+
+func (e *redactedError) Error() string { return e.msg }
+func (e *redactedError) Unwrap() error { return e.err }
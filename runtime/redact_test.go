@@ -0,0 +1,179 @@
+// SPDX-FileCopyrightText: © 2026 Binaek Sarkar <binaek89@gmail.com>
+// SPDX-License-Identifier: Apache-2.0
+
+package runtime
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/sentrie-sh/sentrie/box"
+	"github.com/sentrie-sh/sentrie/trinary"
+)
+
+const sensitivePolicy = `namespace com/example
+policy login {
+  fact sensitive token: string @regexp("^tok_")
+  fact user: string
+  rule allow = default false { yield token != "" and user == "ops" }
+  rule greeting = default "" { yield "bearer " + token }
+  export decision of allow attach seen as token attach who as user
+  export decision of greeting
+}
+`
+
+func (s *RuntimeTestSuite) TestSensitiveFactIsRedactedFromOutputs() {
+	exec := s.executorFromSource(sensitivePolicy)
+
+	outputs, err := exec.ExecPolicy(context.Background(), "com/example", "login", map[string]any{"token": "tok_s3cr3t", "user": "ops"})
+	s.Require().NoError(err)
+	s.Require().Len(outputs, 2)
+
+	for _, output := range outputs {
+		raw, err := json.Marshal(NewResult([]*ExecutorOutput{output}, nil, true, false))
+		s.Require().NoError(err)
+		s.NotContains(string(raw), "s3cr3t", "the explain tree of %s", output.RuleName)
+		s.Contains(string(raw), Redacted)
+
+		switch output.RuleName {
+		case "allow":
+			// the fact is still usable in rules
+			s.Equal(trinary.True, output.Decision.State)
+			s.Equal(box.String(Redacted), output.Attachments["seen"])
+			s.Equal(box.String("ops"), output.Attachments["who"])
+		case "greeting":
+			// a value computed from the fact is hidden whole
+			s.Equal(box.String(Redacted), output.Decision.Value)
+		}
+	}
+}
+
+func (s *RuntimeTestSuite) TestSensitiveFactIsRedactedFromErrors() {
+	exec := s.executorFromSource(`namespace com/example
+policy login {
+  fact sensitive pin: number
+  rule allow = default false { yield pin > 0 }
+  export decision of allow
+}
+`)
+	facts := map[string]any{"pin": "s3cr3t"}
+
+	_, err := exec.ExecRule(context.Background(), "com/example", "login", "allow", facts)
+	s.Require().Error(err)
+	s.Contains(err.Error(), "value "+Redacted+" is not a number")
+	s.NotContains(err.Error(), "s3cr3t")
+
+	err = exec.ValidateFacts(context.Background(), "com/example", "login", facts)
+	s.Require().Error(err)
+	s.Contains(err.Error(), "fact 'pin' is not valid: value "+Redacted+" is not a number")
+	s.NotContains(err.Error(), "s3cr3t")
+
+	// the redacted error still unwraps to the original
+	var redacted *redactedError
+	s.Require().ErrorAs(err, &redacted)
+	s.Contains(errors.Unwrap(err).Error(), "s3cr3t")
+}
+
+func (s *RuntimeTestSuite) TestSensitiveNumberIsNotRedactedFromSpans() {
+	exec := s.executorFromSource(`namespace com/example
+policy login {
+  fact sensitive pins: list[string]
+  rule allow = default false { yield count(pins) > 0 }
+  export decision of allow
+}
+`)
+
+	err := exec.ValidateFacts(context.Background(), "com/example", "login", map[string]any{"pins": []any{3}})
+	s.Require().Error(err)
+	s.Contains(err.Error(), "value "+Redacted+" is not valid at batch.sentrie:3:")
+	s.NotContains(err.Error(), "value 3")
+}
+
+func (s *RuntimeTestSuite) TestSensitiveRedactionFollowsProvenance() {
+	exec := s.executorFromSource(`namespace com/example
+policy login {
+  fact sensitive pin: number
+  fact role: string
+  let masked = {
+    let digits = pin
+    yield digits % 100
+  }
+  rule allow = default "deny" { yield role }
+  rule limit = default 0 { yield 4821 }
+  rule hint = default 0 { yield masked }
+  rule valid = default false { yield pin > 0 }
+  export decision of allow attach pin_copy as pin
+  export decision of limit
+  export decision of hint
+  export decision of valid
+}
+`)
+	outputs, err := exec.ExecPolicy(context.Background(), "com/example", "login", map[string]any{"pin": 4821, "role": "a"})
+	s.Require().NoError(err)
+	byRule := map[string]*ExecutorOutput{}
+	for _, output := range outputs {
+		byRule[output.RuleName] = output
+	}
+
+	// values not computed from the fact are kept, even when they hold the secret
+	s.Equal(box.String("a"), byRule["allow"].Decision.Value)
+	s.Equal(box.Number(4821), byRule["limit"].Decision.Value)
+	s.Equal(box.String(Redacted), byRule["allow"].Attachments["pin_copy"])
+	s.Equal(box.String(Redacted), byRule["hint"].Decision.Value)
+	s.Equal(box.Bool(true), byRule["valid"].Decision.Value, "booleans are kept")
+
+	raw, err := json.Marshal(NewResult([]*ExecutorOutput{byRule["hint"]}, nil, true, false))
+	s.Require().NoError(err)
+	// durations in the trace may hold the same digits, so only values are looked at
+	s.NotContains(string(raw), `"result":4821`)
+	s.NotContains(string(raw), `"value":4821`)
+	s.NotContains(string(raw), `"result":21`, "the block let holding the fact is hidden too")
+}
+
+func (s *RuntimeTestSuite) TestSensitiveBooleanIsRedactedFromTrace() {
+	exec := s.executorFromSource(`namespace com/example
+policy screening {
+  fact sensitive hivPositive: boolean
+  fact age: number
+  rule r = default false { yield age > 18 and not hivPositive }
+  export decision of r
+}
+`)
+	output, err := exec.ExecRule(WithFullTrace(context.Background()), "com/example", "screening", "r", map[string]any{"hivPositive": true, "age": 30})
+	s.Require().NoError(err)
+	s.Equal(trinary.False, output.Decision.State)
+
+	raw, err := json.Marshal(NewResult([]*ExecutorOutput{output}, nil, true, false))
+	s.Require().NoError(err)
+	s.Contains(string(raw), `"meta":{"name":"hivPositive"},"result":"`+Redacted+`"`)
+	s.NotContains(string(raw), `"meta":{"name":"hivPositive"},"result":true`)
+	s.Contains(string(raw), `"meta":{"name":"age"},"result":30`, "other facts are kept")
+
+	s.Equal(trinary.False, output.RuleNode.Result.Any().(*Decision).State, "the decision state is kept")
+}
+
+func (s *RuntimeTestSuite) TestRedactorRedactsSecretsFromMessages() {
+	r := &redactor{}
+	r.add(box.Dict(map[string]box.Value{
+		"pin":  box.Number(4821),
+		"tags": box.List([]box.Value{box.String("alpha"), box.Bool(true)}),
+	}))
+
+	s.Equal("pin "+Redacted+" at 14821:3", r.text("pin 4821 at 14821:3"))
+	s.Equal(Redacted+"-beta", r.text("alpha-beta"))
+
+	// the line and column numbers of a span are positions, not values
+	r.add(box.Number(3))
+	s.Equal("policy.sentrie:3:3-4:3: value "+Redacted+" is not a string", r.text("policy.sentrie:3:3-4:3: value 3 is not a string"))
+	s.Equal("value "+Redacted+" at policy.sentrie:12:3-14, not "+Redacted, r.text("value 3 at policy.sentrie:12:3-14, not 3"))
+
+	r.add(box.String(`say "hi"`))
+	s.Equal(`value "`+Redacted+`" is invalid`, r.text(fmt.Sprintf("value %q is invalid", `say "hi"`)))
+
+	var nothing redactor
+	s.Equal("alpha", nothing.text("alpha"))
+	err := errors.New("alpha")
+	s.Same(err, nothing.err(err))
+}
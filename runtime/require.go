@@ -0,0 +1,27 @@
+// SPDX-FileCopyrightText: © 2026 Binaek Sarkar <binaek89@gmail.com>
+// SPDX-License-Identifier: Apache-2.0
+
+package runtime
+
+import (
+	"context"
+
+	"github.com/sentrie-sh/sentrie/ast"
+	"github.com/sentrie-sh/sentrie/index"
+)
+
+// checkRequires validates the facts named by the require statements of p against their shapes,
+// in declaration order. An optional fact that was not supplied has nothing to check.
+func (e *executorImpl) checkRequires(ctx context.Context, ec *ExecutionContext, p *index.Policy) []error {
+	var violations []error
+	for _, req := range p.Requires {
+		v, ok := ec.GetFact(req.Fact)
+		if !ok {
+			continue
+		}
+		if err := validateValueAgainstTypeRef(ctx, ec, e, p, v, ast.NewShapeTypeRef(req.Shape, req.Span()), req.Span()); err != nil {
+			violations = append(violations, wrapViolations(err, "fact '%s' does not conform to shape '%s'", req.Fact, req.Shape)...)
+		}
+	}
+	return violations
+}
@@ -0,0 +1,75 @@
+// SPDX-FileCopyrightText: © 2026 Binaek Sarkar <binaek89@gmail.com>
+// SPDX-License-Identifier: Apache-2.0
+
+package runtime
+
+import (
+	"context"
+
+	"github.com/sentrie-sh/sentrie/trinary"
+)
+
+const requirePolicy = `namespace com/example
+shape Address {
+  city: string
+  zip: string @length(5)
+}
+shape User {
+  name: string
+  address: Address
+}
+policy onboarding {
+  fact user: document
+  fact manager?: document
+  require user conforms User
+  require manager conforms User
+  rule allow = default false { yield user.name != "" }
+  export decision of allow
+}
+`
+
+func (s *RuntimeTestSuite) TestRequireConformingFactPasses() {
+	exec := s.executorFromSource(requirePolicy)
+	user := map[string]any{"name": "alice", "address": map[string]any{"city": "Paris", "zip": "75001"}}
+
+	out, err := exec.ExecRule(context.Background(), "com/example", "onboarding", "allow", map[string]any{"user": user})
+	s.Require().NoError(err)
+	s.Equal(trinary.True, out.ToTrinary())
+
+	s.NoError(exec.ValidateFacts(context.Background(), "com/example", "onboarding", map[string]any{"user": user}))
+}
+
+func (s *RuntimeTestSuite) TestRequireNonConformingFactFails() {
+	exec := s.executorFromSource(requirePolicy)
+	user := map[string]any{"name": "alice", "address": map[string]any{"city": "Paris", "zip": "7500"}}
+
+	_, err := exec.ExecRule(context.Background(), "com/example", "onboarding", "allow", map[string]any{"user": user})
+	s.Require().Error(err)
+	s.Contains(err.Error(), "fact 'user' does not conform to shape 'User'")
+	s.Contains(err.Error(), "field 'address' is not valid: field 'zip' is not valid")
+
+	// the optional manager is checked only when supplied
+	manager := map[string]any{"name": "bob"}
+	err = exec.ValidateFacts(context.Background(), "com/example", "onboarding", map[string]any{"user": user, "manager": manager})
+	s.Require().Error(err)
+	s.Contains(err.Error(), "fact 'user' does not conform")
+	s.Contains(err.Error(), "fact 'manager' does not conform to shape 'User': field address is required")
+}
+
+func (s *RuntimeTestSuite) TestRequireUnresolvedShapeErrors() {
+	exec := s.executorFromSource(requirePolicy)
+	p, err := exec.Index().ResolvePolicy("com/example", "onboarding")
+	s.Require().NoError(err)
+
+	// an index that skipped validation can still carry a dangling shape
+	missing := *p.Requires[0]
+	missing.Shape = p.Requires[0].Shape.Ptr()
+	missing.Shape.Parts = []string{"Missing"}
+	requires := p.Requires
+	p.Requires = append(p.Requires[:0:0], &missing)
+	defer func() { p.Requires = requires }()
+
+	_, err = exec.ExecRule(context.Background(), "com/example", "onboarding", "allow", map[string]any{"user": map[string]any{"name": "alice"}})
+	s.Require().Error(err)
+	s.Contains(err.Error(), "shape 'Missing' not found")
+}
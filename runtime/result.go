@@ -0,0 +1,98 @@
+// SPDX-FileCopyrightText: © 2026 Binaek Sarkar <binaek89@gmail.com>
+// SPDX-License-Identifier: Apache-2.0
+
+package runtime
+
+import (
+	"cmp"
+	"slices"
+
+	"github.com/sentrie-sh/sentrie/index"
+	"github.com/sentrie-sh/sentrie/runtime/trace"
+)
+
+// ResultSchemaVersion identifies the field layout of a serialized Result.
+// Any change to the fields of Result or Outcome must bump this version.
+const ResultSchemaVersion = 5
+
+// Result is the stable, versioned envelope for the outcomes of an evaluation.
+// Outcomes are ordered by namespace, policy and rule.
+type Result struct {
+	SchemaVersion int        `json:"schema_version"`
+	Outcomes      []*Outcome `json:"outcomes"`
+	Warnings      []string   `json:"warnings"`
+	// Decision is the combined decision of the policy, when it declares a combining algorithm
+	// and the whole policy was evaluated, see CombineDecisions
+	Decision *Decision `json:"decision,omitempty"`
+}
+
+// Outcome is the serialized form of a single rule evaluation.
+type Outcome struct {
+	Namespace   string              `json:"namespace"`
+	Policy      string              `json:"policy"`
+	Rule        string              `json:"rule"`
+	Decision    *Decision           `json:"decision"`
+	Attachments DecisionAttachments `json:"attachments"`
+	// Obligations must be fulfilled by the caller enforcing the decision; always present
+	Obligations []Directive `json:"obligations"`
+	// Advice may be acted upon by the caller; always present
+	Advice  []Directive `json:"advice"`
+	Explain *trace.Node `json:"explain,omitempty"`
+	// Effect is the declared effect of the rule, empty for a rule without one
+	Effect index.RuleEffect `json:"effect,omitempty"`
+	// Metadata is the provenance of the decision, see index.PolicyMetadata
+	Metadata *index.PolicyMetadata `json:"metadata,omitempty"`
+}
+
+// NewResult builds a Result from executor outputs and the warnings of the policy they
+// were evaluated from. The evaluation trace is only carried over as the outcome's
+// explain when withExplain is set, and the policy metadata only when withMetadata is.
+func NewResult(outputs []*ExecutorOutput, warnings []index.Diagnostic, withExplain, withMetadata bool) *Result {
+	result := &Result{
+		SchemaVersion: ResultSchemaVersion,
+		Outcomes:      make([]*Outcome, 0, len(outputs)),
+		Warnings:      make([]string, 0, len(warnings)),
+	}
+	for _, warning := range warnings {
+		result.Warnings = append(result.Warnings, warning.String())
+	}
+	for _, output := range outputs {
+		if output == nil {
+			continue
+		}
+		outcome := &Outcome{
+			Namespace:   output.Namespace,
+			Policy:      output.PolicyName,
+			Rule:        output.RuleName,
+			Decision:    output.Decision,
+			Effect:      output.Effect,
+			Attachments: output.Attachments,
+			Obligations: output.Obligations,
+			Advice:      output.Advice,
+		}
+		if outcome.Attachments == nil {
+			outcome.Attachments = DecisionAttachments{}
+		}
+		if outcome.Obligations == nil {
+			outcome.Obligations = []Directive{}
+		}
+		if outcome.Advice == nil {
+			outcome.Advice = []Directive{}
+		}
+		if withExplain {
+			outcome.Explain = output.RuleNode
+		}
+		if withMetadata {
+			outcome.Metadata = &output.Metadata
+		}
+		result.Outcomes = append(result.Outcomes, outcome)
+	}
+	slices.SortStableFunc(result.Outcomes, func(a, b *Outcome) int {
+		return cmp.Or(
+			cmp.Compare(a.Namespace, b.Namespace),
+			cmp.Compare(a.Policy, b.Policy),
+			cmp.Compare(a.Rule, b.Rule),
+		)
+	})
+	return result
+}
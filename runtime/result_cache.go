@@ -0,0 +1,226 @@
+// SPDX-FileCopyrightText: © 2026 Binaek Sarkar <binaek89@gmail.com>
+// SPDX-License-Identifier: Apache-2.0
+
+package runtime
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io/fs"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/binaek/perch"
+	"github.com/mitchellh/hashstructure/v2"
+	"github.com/sentrie-sh/sentrie/ast"
+	"github.com/sentrie-sh/sentrie/constants"
+	"github.com/sentrie-sh/sentrie/index"
+)
+
+// ResultCache holds the outputs of exported rules, keyed by the content hash of the policy,
+// the rule, and the facts it was evaluated with.
+//
+// A policy's content hash changes whenever the policy, a shape it refers to, or a policy it
+// imports from changes. One cache can therefore be shared by the executors built for successive
+// versions of a pack: after a reload, changed policies are evaluated afresh while unchanged
+// policies keep answering from the cache.
+//
+// The content hash does not cover JavaScript modules, so the outputs of a policy that uses a
+// module of the pack are also keyed by the sources of the pack's modules. A policy whose outcome
+// can change between two evaluations with the same facts is never cached: one that uses, directly
+// or through the policies it imports from, a builtin module reading the clock or a random source,
+// or a pack module that may do so.
+type ResultCache struct {
+	perch *perch.Perch[*cachedResult]
+	ttl   time.Duration
+}
+
+type cachedResult struct {
+	output *ExecutorOutput
+	err    error
+}
+
+// NewResultCache creates a cache of sizeMB megabytes whose entries expire after ttl.
+// A ttl of zero keeps entries until they are evicted.
+func NewResultCache(sizeMB int, ttl time.Duration) *ResultCache {
+	c := &ResultCache{
+		perch: perch.New[*cachedResult](sizeMB << 20 /* size in megabytes */),
+		ttl:   ttl,
+	}
+	c.perch.Reserve()
+	return c
+}
+
+// WithResultCache makes the executor answer ExecRule and ExecPolicy from cache when it can.
+// The same cache may be given to several executors.
+func WithResultCache(cache *ResultCache) NewExecutorOption {
+	return func(e *executorImpl) {
+		e.resultCache = cache
+	}
+}
+
+// volatileGlobals are the JavaScript globals that mark a pack module as possibly reading the
+// clock or a random source by itself. Requiring a builtin registered as js.Volatile does too.
+var volatileGlobals = []string{"Date(", "Date.now", "Math.random"}
+
+// resultCacheKey returns the key the output of rule in a policy with contentHash, using pack
+// modules with modulesHash, is cached under, or false when the facts cannot be hashed. The trace
+// of an output depends on whether the full trace was asked for, so fullTrace is part of the key.
+func resultCacheKey(contentHash, modulesHash, rule string, fullTrace bool, facts map[string]any) (string, bool) {
+	factHash, err := hashstructure.Hash(facts, hashstructure.FormatV2, nil)
+	if err != nil {
+		return "", false
+	}
+	return fmt.Sprintf("%s/%s/%s/%t/%d", contentHash, modulesHash, rule, fullTrace, factHash), true
+}
+
+// resultCacheScope is what the outputs of a policy depend on besides its content hash and facts.
+type resultCacheScope struct {
+	// cacheable is false when the outputs can change between evaluations with the same facts
+	cacheable bool
+	// modulesHash is the hash of the pack's module sources, when the policy uses any of them
+	modulesHash string
+}
+
+// resultCacheScope returns the cache scope of p, working it out on first use.
+func (e *executorImpl) resultCacheScope(p *index.Policy) resultCacheScope {
+	if scope, ok := e.resultCacheScopes.Load(p.FQN.String()); ok {
+		return scope.(resultCacheScope)
+	}
+
+	scope := resultCacheScope{cacheable: true}
+	usesPackModules, volatile := e.policyModules(p, map[string]struct{}{})
+	if volatile {
+		scope.cacheable = false
+	} else if usesPackModules {
+		modulesHash, volatile, err := e.packModules()
+		scope.cacheable = err == nil && !volatile
+		scope.modulesHash = modulesHash
+	}
+	e.resultCacheScopes.Store(p.FQN.String(), scope)
+	return scope
+}
+
+// policyModules reports whether p, or a policy it imports from, uses a module of the pack, and
+// whether one of them uses a volatile builtin. Policies that do not resolve are left to evaluation.
+func (e *executorImpl) policyModules(p *index.Policy, seen map[string]struct{}) (usesPackModules, volatile bool) {
+	if _, ok := seen[p.FQN.String()]; ok {
+		return false, false
+	}
+	seen[p.FQN.String()] = struct{}{}
+
+	for _, use := range p.Uses {
+		if len(use.LibFrom) == 0 || use.LibFrom[0] != constants.APPNAME {
+			usesPackModules = true
+			continue
+		}
+		if len(use.LibFrom) == 2 && e.jsRegistry.IsVolatile(use.LibFrom[1], use.Modules...) {
+			return usesPackModules, true
+		}
+	}
+
+	for _, rule := range p.Rules {
+		importClause, ok := rule.Body.(*ast.ImportClause)
+		if !ok || len(importClause.FromPolicyFQN.Parts) < 2 {
+			continue
+		}
+		target, err := e.index.ResolvePolicy(importClause.FromPolicyFQN.Parent().String(), importClause.FromPolicyFQN.LastSegment())
+		if err != nil {
+			continue
+		}
+		targetUsesPackModules, targetVolatile := e.policyModules(target, seen)
+		usesPackModules = usesPackModules || targetUsesPackModules
+		if targetVolatile {
+			return usesPackModules, true
+		}
+	}
+	return usesPackModules, false
+}
+
+// packModules hashes the JavaScript and TypeScript sources of the pack, once per executor, and
+// reports whether any of them may be volatile. Modules can require any other module of the pack,
+// so all of them are covered.
+func (e *executorImpl) packModules() (string, bool, error) {
+	e.packModulesOnce.Do(func() {
+		sources := map[string][]byte{}
+		root := e.index.Pack().Location
+		e.packModulesErr = filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+			if err != nil || d.IsDir() {
+				return err
+			}
+			if ext := filepath.Ext(path); ext != ".js" && ext != ".ts" {
+				return nil
+			}
+			content, err := os.ReadFile(path)
+			if err != nil {
+				return err
+			}
+			rel, err := filepath.Rel(root, path)
+			if err != nil {
+				return err
+			}
+			sources[filepath.ToSlash(rel)] = content
+			return nil
+		})
+
+		volatileSources := slices.Clone(volatileGlobals)
+		for _, name := range e.jsRegistry.VolatileBuiltins() {
+			volatileSources = append(volatileSources, "@"+constants.APPNAME+"/"+name)
+		}
+
+		h := sha256.New()
+		for _, rel := range slices.Sorted(maps.Keys(sources)) {
+			fmt.Fprintf(h, "%s:%d:", rel, len(sources[rel]))
+			h.Write(sources[rel])
+			for _, volatile := range volatileSources {
+				if strings.Contains(string(sources[rel]), volatile) {
+					e.packModulesVolatile = true
+				}
+			}
+		}
+		e.packModulesHash = hex.EncodeToString(h.Sum(nil))
+	})
+	return e.packModulesHash, e.packModulesVolatile, e.packModulesErr
+}
+
+// get returns a copy of the cached output for key, running load on a miss. Errors are handed
+// to callers waiting on the same load but are not kept.
+func (c *ResultCache) get(ctx context.Context, key string, load func() (*ExecutorOutput, error)) (*ExecutorOutput, error) {
+	res, _, err := c.perch.Get(ctx, key, c.ttl, func(ctx context.Context, key string) (*cachedResult, error) {
+		output, err := load()
+		return &cachedResult{output: output, err: err}, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	if res.err != nil {
+		c.perch.Delete(key)
+	}
+	return res.output.clone(), res.err
+}
+
+// clone copies the output so that callers of the cache do not share it. Boxed values and the
+// evaluation trace are not modified once evaluation is done, so they are shared.
+func (o *ExecutorOutput) clone() *ExecutorOutput {
+	if o == nil {
+		return nil
+	}
+	c := *o
+	if o.Decision != nil {
+		decision := *o.Decision
+		c.Decision = &decision
+	}
+	if o.Attachments != nil {
+		c.Attachments = maps.Clone(o.Attachments)
+	}
+	c.Obligations = slices.Clone(o.Obligations)
+	c.Advice = slices.Clone(o.Advice)
+	c.Metadata.Tags = slices.Clone(o.Metadata.Tags)
+	return &c
+}
@@ -0,0 +1,263 @@
+// SPDX-FileCopyrightText: © 2026 Binaek Sarkar <binaek89@gmail.com>
+// SPDX-License-Identifier: Apache-2.0
+
+package runtime
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/sentrie-sh/sentrie/box"
+	"github.com/sentrie-sh/sentrie/index"
+	"github.com/sentrie-sh/sentrie/pack"
+	"github.com/sentrie-sh/sentrie/parser"
+	"github.com/sentrie-sh/sentrie/runtime/trace"
+	"github.com/sentrie-sh/sentrie/trinary"
+)
+
+const cachedPricingV1 = `namespace com/example/shop
+policy pricing {
+  fact qty: number
+  rule total = default 0 {
+    yield qty * 10
+  }
+  export decision of total
+}`
+
+// cachedPricingV2 changes the price
+const cachedPricingV2 = `namespace com/example/shop
+policy pricing {
+  fact qty: number
+  rule total = default 0 {
+    yield qty * 12
+  }
+  export decision of total
+}`
+
+const cachedShipping = `namespace com/example/shop
+policy shipping {
+  fact qty: number
+  rule free = default false {
+    yield qty > 3
+  }
+  export decision of free
+}`
+
+// viewFromSources indexes and commits the programs in srcs.
+func (s *RuntimeTestSuite) viewFromSources(srcs ...string) *index.IndexView {
+	return s.viewFromSourcesAt(s.T().TempDir(), srcs...)
+}
+
+// viewFromSourcesAt is viewFromSources for a pack located at dir.
+func (s *RuntimeTestSuite) viewFromSourcesAt(dir string, srcs ...string) *index.IndexView {
+	ctx := context.Background()
+	idx := index.CreateIndex()
+	s.Require().NoError(idx.SetPack(ctx, &pack.PackFile{Location: dir}))
+	for _, src := range srcs {
+		program, err := parser.NewParserFromString(src, filepath.Join(dir, "cache.sentrie")).ParseProgram(ctx)
+		s.Require().NoError(err)
+		s.Require().NoError(idx.AddProgram(ctx, program))
+	}
+	return viewOf(idx)
+}
+
+func (s *RuntimeTestSuite) TestResultCacheReturnsCachedOutput() {
+	cache := NewResultCache(1, 0)
+	exec := &executorImpl{index: s.viewFromSources(cachedPricingV1), resultCache: cache}
+
+	first, err := exec.ExecRule(s.T().Context(), "com/example/shop", "pricing", "total", map[string]any{"qty": 2})
+	s.Require().NoError(err)
+	s.Equal(20.0, first.Decision.Value.Any())
+
+	again, err := exec.ExecRule(s.T().Context(), "com/example/shop", "pricing", "total", map[string]any{"qty": 2})
+	s.Require().NoError(err)
+	s.Equal(first, again)
+	s.NotSame(first, again, "callers get their own copy of a cached output")
+	s.Same(first.RuleNode, again.RuleNode, "the output was answered from cache")
+
+	other, err := exec.ExecRule(s.T().Context(), "com/example/shop", "pricing", "total", map[string]any{"qty": 3})
+	s.Require().NoError(err)
+	s.NotSame(first, other)
+	s.Equal(30.0, other.Decision.Value.Any())
+}
+
+func (s *RuntimeTestSuite) TestResultCacheSurvivesReloadForUnchangedPolicies() {
+	cache := NewResultCache(1, 0)
+	facts := map[string]any{"qty": 5}
+
+	before := &executorImpl{index: s.viewFromSources(cachedPricingV1, cachedShipping), resultCache: cache}
+	pricing, err := before.ExecRule(s.T().Context(), "com/example/shop", "pricing", "total", facts)
+	s.Require().NoError(err)
+	s.Equal(50.0, pricing.Decision.Value.Any())
+	shipping, err := before.ExecRule(s.T().Context(), "com/example/shop", "shipping", "free", facts)
+	s.Require().NoError(err)
+
+	// the reloaded pack changes pricing and leaves shipping alone
+	after := &executorImpl{index: s.viewFromSources(cachedPricingV2, cachedShipping), resultCache: cache}
+	repriced, err := after.ExecRule(s.T().Context(), "com/example/shop", "pricing", "total", facts)
+	s.Require().NoError(err)
+	s.Equal(60.0, repriced.Decision.Value.Any())
+	s.NotSame(pricing, repriced)
+
+	reshipped, err := after.ExecRule(s.T().Context(), "com/example/shop", "shipping", "free", facts)
+	s.Require().NoError(err)
+	s.Same(shipping.RuleNode, reshipped.RuleNode)
+}
+
+func (s *RuntimeTestSuite) TestResultCacheKeepsFullTracesApart() {
+	cache := NewResultCache(1, 0)
+	exec := &executorImpl{index: s.viewFromSources(`namespace com/example/shop
+policy shipping {
+  fact qty: number
+  rule free = default false {
+    yield qty > 3 and qty < 100
+  }
+  export decision of free
+}`), resultCache: cache}
+	facts := map[string]any{"qty": 1}
+
+	plain, err := exec.ExecRule(s.T().Context(), "com/example/shop", "shipping", "free", facts)
+	s.Require().NoError(err)
+
+	full, err := exec.ExecRule(WithFullTrace(s.T().Context()), "com/example/shop", "shipping", "free", facts)
+	s.Require().NoError(err)
+	s.NotSame(plain.RuleNode, full.RuleNode, "a full trace is not answered with a cached plain one")
+	s.Equal(plain.Decision, full.Decision)
+	s.Contains(traceKinds(full.RuleNode), "not-evaluated")
+	s.NotContains(traceKinds(plain.RuleNode), "not-evaluated")
+
+	again, err := exec.ExecRule(WithFullTrace(s.T().Context()), "com/example/shop", "shipping", "free", facts)
+	s.Require().NoError(err)
+	s.Same(full.RuleNode, again.RuleNode, "full traces are cached too")
+}
+
+// traceKinds returns the kinds of the nodes of the trace rooted at n.
+func traceKinds(n *trace.Node) []string {
+	kinds := []string{n.Kind}
+	for _, child := range n.Children {
+		kinds = append(kinds, traceKinds(child)...)
+	}
+	return kinds
+}
+
+func (s *RuntimeTestSuite) TestResultCacheDoesNotKeepErrors() {
+	cache := NewResultCache(1, 0)
+	exec := &executorImpl{index: s.viewFromSources(cachedPricingV1), resultCache: cache}
+
+	_, err := exec.ExecRule(s.T().Context(), "com/example/shop", "pricing", "total", map[string]any{"qty": "many"})
+	s.Require().Error(err)
+
+	p, err := exec.index.ResolvePolicy("com/example/shop", "pricing")
+	s.Require().NoError(err)
+	key, ok := resultCacheKey(p.ContentHash(), "", "total", false, map[string]any{"qty": "many"})
+	s.Require().True(ok)
+	_, cached := cache.perch.Peek(key)
+	s.False(cached)
+}
+
+func (s *RuntimeTestSuite) TestResultCacheEntriesExpire() {
+	cache := NewResultCache(1, time.Millisecond)
+	exec := &executorImpl{index: s.viewFromSources(cachedPricingV1), resultCache: cache}
+
+	first, err := exec.ExecRule(s.T().Context(), "com/example/shop", "pricing", "total", map[string]any{"qty": 1})
+	s.Require().NoError(err)
+	time.Sleep(5 * time.Millisecond)
+	again, err := exec.ExecRule(s.T().Context(), "com/example/shop", "pricing", "total", map[string]any{"qty": 1})
+	s.Require().NoError(err)
+	s.NotSame(first.RuleNode, again.RuleNode)
+}
+
+func (s *RuntimeTestSuite) TestResultCacheCopiesAreIndependent() {
+	cache := NewResultCache(1, 0)
+	exec := &executorImpl{index: s.viewFromSources(cachedPricingV1), resultCache: cache}
+
+	first, err := exec.ExecRule(s.T().Context(), "com/example/shop", "pricing", "total", map[string]any{"qty": 2})
+	s.Require().NoError(err)
+	first.Decision.State = trinary.False
+	first.Attachments["tampered"] = box.Trinary(trinary.True)
+
+	again, err := exec.ExecRule(s.T().Context(), "com/example/shop", "pricing", "total", map[string]any{"qty": 2})
+	s.Require().NoError(err)
+	s.Equal(trinary.True, again.Decision.State)
+	s.NotContains(again.Attachments, "tampered")
+}
+
+func (s *RuntimeTestSuite) TestResultCacheSkipsVolatilePolicies() {
+	clock := `namespace com/example/clock
+policy stamp {
+  use { now } from @sentrie/std as std
+  rule at = default "" { yield std.now() }
+  export decision of at
+}`
+	relay := `namespace com/example/relay
+policy relay {
+  rule at = import decision at from com/example/clock/stamp
+  export decision of at
+}`
+	dice := `namespace com/example/dice
+policy roll {
+  use { random } from @sentrie/js as js
+  rule face = default 0 { yield js.random() }
+  export decision of face
+}`
+	exec := s.cacheExecutor(s.viewFromSources(clock, relay, dice, cachedShipping))
+	for _, target := range [][2]string{{"com/example/clock", "stamp"}, {"com/example/relay", "relay"}, {"com/example/dice", "roll"}} {
+		p, err := exec.index.ResolvePolicy(target[0], target[1])
+		s.Require().NoError(err)
+		s.False(exec.resultCacheScope(p).cacheable, target[1])
+	}
+
+	p, err := exec.index.ResolvePolicy("com/example/shop", "shipping")
+	s.Require().NoError(err)
+	s.Equal(resultCacheScope{cacheable: true}, exec.resultCacheScope(p))
+}
+
+func (s *RuntimeTestSuite) TestResultCacheKeepsPureStdHelpers() {
+	exec := s.cacheExecutor(s.viewFromSources(`namespace com/example/names
+policy shout {
+  fact name: string
+  use { trim, to_upper } from @sentrie/std as std
+  rule loud = default "" { yield std.to_upper(std.trim(name)) }
+  export decision of loud
+}`))
+	p, err := exec.index.ResolvePolicy("com/example/names", "shout")
+	s.Require().NoError(err)
+	s.True(exec.resultCacheScope(p).cacheable)
+}
+
+func (s *RuntimeTestSuite) TestResultCacheKeysOnPackModuleSources() {
+	policy := `namespace com/example/shop
+policy discount {
+  fact qty: number
+  use { rate } from "./discount.ts" as discount
+  rule pct = default 0 { yield discount.rate(qty) }
+  export decision of pct
+}`
+	scopeWith := func(module string) resultCacheScope {
+		dir := s.T().TempDir()
+		s.Require().NoError(os.WriteFile(filepath.Join(dir, "discount.ts"), []byte(module), 0o600))
+		view := s.viewFromSourcesAt(dir, policy)
+		exec := s.cacheExecutor(view)
+		p, err := view.ResolvePolicy("com/example/shop", "discount")
+		s.Require().NoError(err)
+		return exec.resultCacheScope(p)
+	}
+
+	v1 := scopeWith("export function rate(qty: number) { return qty > 10 ? 5 : 0 }")
+	v2 := scopeWith("export function rate(qty: number) { return qty > 10 ? 7 : 0 }")
+	s.True(v1.cacheable)
+	s.True(v2.cacheable)
+	s.NotEmpty(v1.modulesHash)
+	s.NotEqual(v1.modulesHash, v2.modulesHash)
+
+	s.False(scopeWith("export function rate(qty: number) { return Date.now() % 2 }").cacheable)
+	s.False(scopeWith(`const uuid = require("@sentrie/uuid"); export function rate(qty: number) { return uuid.v4().length }`).cacheable)
+}
+
+// cacheExecutor builds an executor over view with only the builtin modules registered, which is
+// all resultCacheScope needs; it leaves out the caches NewExecutor reserves.
+func (s *RuntimeTestSuite) cacheExecutor(view *index.IndexView) *executorImpl {
+	return &executorImpl{index: view, jsRegistry: newBuiltinRegistry(view.Pack().Location)}
+}
@@ -0,0 +1,161 @@
+// SPDX-FileCopyrightText: © 2026 Binaek Sarkar <binaek89@gmail.com>
+// SPDX-License-Identifier: Apache-2.0
+
+package runtime
+
+import (
+	"encoding/json"
+
+	"github.com/sentrie-sh/sentrie/box"
+	"github.com/sentrie-sh/sentrie/index"
+	"github.com/sentrie-sh/sentrie/runtime/trace"
+	"github.com/sentrie-sh/sentrie/tokens"
+	"github.com/sentrie-sh/sentrie/trinary"
+)
+
+func (s *RuntimeTestSuite) TestResultSerializedShape() {
+	result := NewResult([]*ExecutorOutput{
+		{
+			Namespace:  "com/example",
+			PolicyName: "auth",
+			RuleName:   "allow",
+			Decision:   &Decision{State: trinary.True, Value: box.Trinary(trinary.True)},
+			RuleNode:   &trace.Node{Kind: "rule"},
+		},
+	}, []index.Diagnostic{{
+		Severity: index.SeverityWarning,
+		Message:  "fact 'user' is never referenced",
+		Range:    tokens.Range{File: "policy.sentrie", From: tokens.Pos{Line: 3, Column: 5}, To: tokens.Pos{Line: 3, Column: 9}},
+	}}, false, false)
+
+	raw, err := json.Marshal(result)
+	s.Require().NoError(err)
+	s.Require().JSONEq(`{
+		"schema_version": 5,
+		"outcomes": [{
+			"namespace": "com/example",
+			"policy": "auth",
+			"rule": "allow",
+			"decision": {"state": "true", "value": "true"},
+			"attachments": {},
+			"obligations": [],
+			"advice": []
+		}],
+		"warnings": ["warning: fact 'user' is never referenced at policy.sentrie:4:5-9"]
+	}`, string(raw))
+}
+
+func (s *RuntimeTestSuite) TestResultOutcomesAreOrdered() {
+	output := func(namespace, policy, rule string) *ExecutorOutput {
+		return &ExecutorOutput{
+			Namespace:  namespace,
+			PolicyName: policy,
+			RuleName:   rule,
+			Decision:   &Decision{State: trinary.True, Value: box.Trinary(trinary.True)},
+		}
+	}
+	result := NewResult([]*ExecutorOutput{
+		output("com/example", "b", "allow"),
+		output("com/example", "a", "deny"),
+		output("com/acme", "z", "allow"),
+		output("com/example", "a", "allow"),
+	}, nil, false, false)
+
+	order := make([]string, 0, len(result.Outcomes))
+	for _, outcome := range result.Outcomes {
+		order = append(order, outcome.Namespace+"/"+outcome.Policy+"/"+outcome.Rule)
+	}
+	s.Require().Equal([]string{
+		"com/acme/z/allow",
+		"com/example/a/allow",
+		"com/example/a/deny",
+		"com/example/b/allow",
+	}, order)
+}
+
+func (s *RuntimeTestSuite) TestResultSchemaVersionAlwaysPresent() {
+	raw, err := json.Marshal(NewResult(nil, nil, false, false))
+	s.Require().NoError(err)
+
+	var decoded map[string]any
+	s.Require().NoError(json.Unmarshal(raw, &decoded))
+	s.Require().Contains(decoded, "schema_version")
+	s.Require().EqualValues(ResultSchemaVersion, decoded["schema_version"])
+	s.Require().Equal([]any{}, decoded["outcomes"])
+	s.Require().Equal([]any{}, decoded["warnings"])
+}
+
+func (s *RuntimeTestSuite) TestResultExplainIsOptional() {
+	outputs := []*ExecutorOutput{
+		{
+			Namespace:  "com/example",
+			PolicyName: "auth",
+			RuleName:   "allow",
+			Decision:   &Decision{State: trinary.False, Value: box.Trinary(trinary.False)},
+			RuleNode:   &trace.Node{Kind: "rule"},
+		},
+	}
+
+	without := NewResult(outputs, nil, false, false)
+	s.Require().Nil(without.Outcomes[0].Explain)
+
+	with := NewResult(outputs, nil, true, false)
+	s.Require().NotNil(with.Outcomes[0].Explain)
+
+	raw, err := json.Marshal(with)
+	s.Require().NoError(err)
+	s.Require().Contains(string(raw), `"explain"`)
+}
+
+func (s *RuntimeTestSuite) TestResultMetadataIsOptional() {
+	outputs := []*ExecutorOutput{
+		{
+			Namespace:  "com/example",
+			PolicyName: "auth",
+			RuleName:   "allow",
+			Decision:   &Decision{State: trinary.True, Value: box.Trinary(trinary.True)},
+			Metadata: index.PolicyMetadata{
+				Title:   "Auth",
+				Version: "1.0.0",
+				Tags:    []index.PolicyTagPair{{Key: "owner", Value: "iam"}},
+			},
+		},
+	}
+
+	without, err := json.Marshal(NewResult(outputs, nil, false, false))
+	s.Require().NoError(err)
+	s.Require().NotContains(string(without), `"metadata"`)
+
+	with, err := json.Marshal(NewResult(outputs, nil, false, true))
+	s.Require().NoError(err)
+	s.Require().JSONEq(`{
+		"schema_version": 5,
+		"outcomes": [{
+			"namespace": "com/example",
+			"policy": "auth",
+			"rule": "allow",
+			"decision": {"state": "true", "value": "true"},
+			"attachments": {},
+			"obligations": [],
+			"advice": [],
+			"metadata": {"title": "Auth", "version": "1.0.0", "tags": [{"key": "owner", "value": "iam"}]}
+		}],
+		"warnings": []
+	}`, string(with))
+}
+
+func (s *RuntimeTestSuite) TestResultOutcomeCarriesEffect() {
+	result := NewResult([]*ExecutorOutput{{
+		Namespace:  "com/example",
+		PolicyName: "auth",
+		RuleName:   "block",
+		Decision:   &Decision{State: trinary.True, Value: box.Trinary(trinary.True)},
+		Effect:     index.RuleEffectDeny,
+	}}, nil, false, false)
+
+	raw, err := json.Marshal(result.Outcomes[0])
+	s.Require().NoError(err)
+	var decoded map[string]any
+	s.Require().NoError(json.Unmarshal(raw, &decoded))
+	s.Equal("deny", decoded["effect"])
+}
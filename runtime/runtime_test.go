@@ -64,6 +64,15 @@ func (s *RuntimeTestSuite) builtinArgs(parts ...any) []box.Value {
 	return out
 }
 
+// viewOf commits a hand-built index and returns its read-only view for executors under test.
+func viewOf(idx *index.Index) *index.IndexView {
+	view, err := idx.View(context.Background())
+	if err != nil {
+		panic(err)
+	}
+	return view
+}
+
 func TestRuntimeTestSuite(t *testing.T) {
 	suite.Run(t, new(RuntimeTestSuite))
 }
@@ -0,0 +1,167 @@
+// SPDX-FileCopyrightText: © 2026 Binaek Sarkar <binaek89@gmail.com>
+// SPDX-License-Identifier: Apache-2.0
+
+package runtime
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/sentrie-sh/sentrie/ast"
+	"github.com/sentrie-sh/sentrie/box"
+	"github.com/sentrie-sh/sentrie/index"
+)
+
+// TestResult is the outcome of one test.
+type TestResult struct {
+	Name   string `json:"name"`
+	Policy string `json:"policy"`
+	Span   string `json:"span"`
+	Passed bool   `json:"passed"`
+	// Error is set when the test could not be run at all, such as when its policy does not resolve
+	Error    string         `json:"error,omitempty"`
+	Failures []*TestFailure `json:"failures,omitempty"`
+}
+
+// TestFailure is an expectation of a test that did not hold. Expected and Actual are trinary
+// values for an outcome, and boxed values for an attachment.
+type TestFailure struct {
+	Rule       string `json:"rule"`
+	Attachment string `json:"attachment,omitempty"`
+	Expected   any    `json:"expected"`
+	Actual     any    `json:"actual,omitempty"`
+	Error      string `json:"error,omitempty"`
+	Span       string `json:"span"`
+}
+
+// TestRunner runs the tests declared in programs. The executors built by NewExecutor are
+// TestRunners.
+type TestRunner interface {
+	RunTests(ctx context.Context, programs []*ast.Program) []*TestResult
+}
+
+var _ TestRunner = (*executorImpl)(nil)
+
+// RunTests runs the tests declared in the programs, in order. A test passes when each of its
+// expectations holds; a rule that fails to evaluate fails every expectation on it.
+func (e *executorImpl) RunTests(ctx context.Context, programs []*ast.Program) []*TestResult {
+	var results []*TestResult
+	for _, program := range programs {
+		namespace := ""
+		for _, stmt := range program.Statements {
+			switch stmt := stmt.(type) {
+			case *ast.NamespaceStatement:
+				namespace = stmt.Name.String()
+			case *ast.TestStatement:
+				results = append(results, e.runTest(ctx, namespace, stmt))
+			}
+		}
+	}
+	return results
+}
+
+func (e *executorImpl) runTest(ctx context.Context, namespace string, stmt *ast.TestStatement) *TestResult {
+	policyFQN := namespace + ast.FQNSeparator + stmt.Policy
+	result := &TestResult{
+		Name:   stmt.Name,
+		Policy: policyFQN,
+		Span:   stmt.Span().String(),
+	}
+
+	p, err := e.index.ResolvePolicy(namespace, stmt.Policy)
+	if err != nil {
+		result.Error = err.Error()
+		return result
+	}
+
+	ec := NewExecutionContext(p, e)
+	defer ec.Dispose()
+
+	facts := make(map[string]any, len(stmt.Facts))
+	for _, fact := range stmt.Facts {
+		v, err := e.evalTestValue(ctx, ec, p, fact.Value)
+		if err != nil {
+			result.Error = fmt.Sprintf("fact '%s' at %s: %s", fact.Name, fact.Range, err)
+			return result
+		}
+		facts[fact.Name], err = box.TryToBoundaryAny(v)
+		if err != nil {
+			result.Error = fmt.Sprintf("fact '%s' at %s: %s", fact.Name, fact.Range, err)
+			return result
+		}
+	}
+
+	outputs := map[string]*ExecutorOutput{}
+	errs := map[string]error{}
+	for _, expectation := range stmt.Expectations {
+		failure := &TestFailure{
+			Rule: policyFQN + ast.FQNSeparator + expectation.Rule,
+			Span: expectation.Range.String(),
+		}
+		if expectation.Attachment != nil {
+			failure.Attachment = expectation.Attachment.What
+		}
+
+		expected, err := e.expectedOf(ctx, ec, p, expectation)
+		if err != nil {
+			failure.Error = err.Error()
+			result.Failures = append(result.Failures, failure)
+			continue
+		}
+		failure.Expected = expected
+
+		output, seen := outputs[expectation.Rule]
+		if !seen && errs[expectation.Rule] == nil {
+			output, err = e.ExecRule(ctx, namespace, stmt.Policy, expectation.Rule, facts)
+			if err != nil {
+				errs[expectation.Rule] = err
+			} else {
+				outputs[expectation.Rule] = output
+			}
+		}
+		if err := errs[expectation.Rule]; err != nil {
+			failure.Error = err.Error()
+			result.Failures = append(result.Failures, failure)
+			continue
+		}
+
+		if expectation.Attachment == nil {
+			actual := output.ToTrinary()
+			if actual != expected {
+				failure.Actual = actual
+				result.Failures = append(result.Failures, failure)
+			}
+			continue
+		}
+
+		actual, ok := output.Attachments[expectation.Attachment.What]
+		if !ok {
+			actual = box.Undefined()
+		}
+		if !box.EqualValues(expected.(box.Value), actual) {
+			failure.Actual = actual
+			result.Failures = append(result.Failures, failure)
+		}
+	}
+
+	result.Passed = len(result.Failures) == 0
+	return result
+}
+
+// expectedOf is the trinary outcome or the attachment value an expectation expects.
+func (e *executorImpl) expectedOf(ctx context.Context, ec *ExecutionContext, p *index.Policy, expectation *ast.TestExpectation) (any, error) {
+	if expectation.Attachment == nil {
+		return expectation.Outcome.Value, nil
+	}
+	return e.evalTestValue(ctx, ec, p, expectation.Attachment.As)
+}
+
+// evalTestValue evaluates a value written in a test. It is evaluated in the scope of the policy
+// under test, but without any of its facts or lets.
+func (e *executorImpl) evalTestValue(ctx context.Context, ec *ExecutionContext, p *index.Policy, expr ast.Expression) (box.Value, error) {
+	v, _, err := eval(ctx, ec, e, p, expr)
+	if err != nil {
+		return box.Undefined(), err
+	}
+	return v, nil
+}
@@ -0,0 +1,103 @@
+// SPDX-FileCopyrightText: © 2026 Binaek Sarkar <binaek89@gmail.com>
+// SPDX-License-Identifier: Apache-2.0
+
+package runtime
+
+import (
+	"context"
+
+	"github.com/sentrie-sh/sentrie/ast"
+	"github.com/sentrie-sh/sentrie/box"
+	"github.com/sentrie-sh/sentrie/parser"
+	"github.com/sentrie-sh/sentrie/trinary"
+)
+
+const testRunnerPolicy = `namespace com/example
+policy auth {
+  fact role: string
+  fact tags?: list[string]
+  rule admin = default false { yield role == "admin" }
+  rule tagged = default false { yield count(tags) > 0 }
+  export decision of admin attach reason as role == "admin" ? "is admin" : "role must be admin"
+  export decision of tagged attach count as count(tags)
+}
+`
+
+func (s *RuntimeTestSuite) runTestsFromSource(src string) []*TestResult {
+	s.T().Helper()
+	exec := s.executorFromSource(testRunnerPolicy).(TestRunner)
+	program, err := parser.NewParserFromString(src, "auth.test.sentrie").ParseProgram(context.Background())
+	s.Require().NoError(err)
+	return exec.RunTests(context.Background(), []*ast.Program{program})
+}
+
+func (s *RuntimeTestSuite) TestRunTestsPasses() {
+	results := s.runTestsFromSource(`namespace com/example
+test "admins" of auth {
+  fact role = "admin"
+  fact tags = ["a", "b"]
+  expect admin is true
+  expect admin attach reason as "is admin"
+  expect tagged is true
+  expect tagged attach count as 2
+}`)
+	s.Require().Len(results, 1)
+	s.True(results[0].Passed, "%+v", results[0].Failures)
+	s.Equal("admins", results[0].Name)
+	s.Equal("com/example/auth", results[0].Policy)
+	s.Empty(results[0].Failures)
+}
+
+func (s *RuntimeTestSuite) TestRunTestsReportsFailedExpectations() {
+	results := s.runTestsFromSource(`namespace com/example
+test "users" of auth {
+  fact role = "user"
+  expect admin is true
+  expect admin attach reason as "is admin"
+  expect admin attach missing as 1
+  expect tagged is unknown
+}`)
+	s.Require().Len(results, 1)
+	s.False(results[0].Passed)
+	s.Empty(results[0].Error)
+
+	failures := results[0].Failures
+	s.Require().Len(failures, 3)
+	s.Equal("com/example/auth/admin", failures[0].Rule)
+	s.Equal(trinary.True, failures[0].Expected)
+	s.Equal(trinary.False, failures[0].Actual)
+	s.Equal("auth.test.sentrie:4:3-4:0", failures[0].Span)
+
+	s.Equal("reason", failures[1].Attachment)
+	s.True(box.EqualValues(box.String("role must be admin"), failures[1].Actual.(box.Value)))
+
+	s.Equal("missing", failures[2].Attachment)
+	s.True(failures[2].Actual.(box.Value).IsUndefined())
+}
+
+func (s *RuntimeTestSuite) TestRunTestsReportsErrors() {
+	results := s.runTestsFromSource(`namespace com/example
+test "no such policy" of nope { expect admin is true }
+test "no such rule" of auth {
+  fact role = "admin"
+  expect nope is true
+  expect nope attach reason as "x"
+}
+test "bad fact" of auth {
+  fact role = undefined_name
+  expect admin is true
+}`)
+	s.Require().Len(results, 3)
+
+	s.False(results[0].Passed)
+	s.NotEmpty(results[0].Error)
+
+	s.False(results[1].Passed)
+	s.Require().Len(results[1].Failures, 2)
+	s.Equal("com/example/auth/nope", results[1].Failures[0].Rule)
+	s.NotEmpty(results[1].Failures[0].Error)
+	s.NotEmpty(results[1].Failures[1].Error)
+
+	s.False(results[2].Passed)
+	s.Contains(results[2].Error, "fact 'role'")
+}
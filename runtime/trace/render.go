@@ -0,0 +1,72 @@
+// SPDX-FileCopyrightText: © 2026 Binaek Sarkar <binaek89@gmail.com>
+// SPDX-License-Identifier: Apache-2.0
+
+package trace
+
+import (
+	"strings"
+	"unicode/utf8"
+
+	"github.com/sentrie-sh/sentrie/box"
+)
+
+// maxRenderedSource is the number of characters of an expression's source shown on its line.
+const maxRenderedSource = 60
+
+// Render prints the tree rooted at n, one node per line, each child indented below its parent.
+// A line shows the node's step, the source of its expression and where it is, followed by the
+// value the expression produced, the error it failed with, or that it was not evaluated.
+func (n *Node) Render() string {
+	var b strings.Builder
+	n.render(&b, "")
+	return b.String()
+}
+
+func (n *Node) render(b *strings.Builder, indent string) {
+	if n == nil {
+		return
+	}
+
+	b.WriteString(indent)
+	if n.Op != "" {
+		b.WriteString(n.Op)
+	} else {
+		b.WriteString(n.Kind)
+	}
+	if n.Node != nil {
+		b.WriteString(" " + renderSource(n.Node.String()) + " [" + n.Node.Span().String() + "]")
+	}
+	switch {
+	case n.Kind == "not-evaluated":
+	case n.Err != "":
+		b.WriteString(" => error: " + n.Err)
+	case n.Result.IsValid():
+		b.WriteString(" => " + renderResult(n))
+	}
+	b.WriteString("\n")
+
+	for _, child := range n.Children {
+		child.render(b, indent+"  ")
+	}
+}
+
+// renderSource puts the source of an expression on a single line, shortened when it is long.
+func renderSource(s string) string {
+	s = strings.Join(strings.Fields(s), " ")
+	if utf8.RuneCountInString(s) <= maxRenderedSource {
+		return s
+	}
+	return string([]rune(s)[:maxRenderedSource-3]) + "..."
+}
+
+// renderResult prints a string or collection result as JSON and any other result as itself, so
+// that the string "true" can be told apart from the boolean.
+func renderResult(n *Node) string {
+	switch n.Result.Kind() {
+	case box.ValueString, box.ValueList, box.ValueDict, box.ValueDocument:
+		if out, err := n.Result.MarshalJSON(); err == nil {
+			return string(out)
+		}
+	}
+	return n.Result.String()
+}
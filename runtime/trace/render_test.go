@@ -0,0 +1,44 @@
+// SPDX-FileCopyrightText: © 2026 Binaek Sarkar <binaek89@gmail.com>
+// SPDX-License-Identifier: Apache-2.0
+
+package trace
+
+import (
+	"errors"
+
+	"github.com/sentrie-sh/sentrie/ast"
+	"github.com/sentrie-sh/sentrie/box"
+	"github.com/sentrie-sh/sentrie/tokens"
+)
+
+func (s *TraceTestSuite) TestRenderIndentsChildren() {
+	at := func(col int) tokens.Range {
+		return tokens.Range{File: "p.sentrie", From: tokens.Pos{Line: 0, Column: col}, To: tokens.Pos{Line: 0, Column: col + 1}}
+	}
+	name := ast.NewIdentifier("name", at(0))
+	literal := ast.NewStringLiteral("root", at(8))
+	missing := ast.NewIdentifier("missing", at(16))
+
+	root := (&Node{Kind: "infix", Op: "infix", Node: ast.NewInfixExpression(name, literal, "==", at(0))}).
+		SetResult(box.Bool(true)).
+		Attach(
+			(&Node{Kind: "identifier", Node: name}).SetResult(box.String("root")),
+			(&Node{Kind: "literal", Node: literal}).SetErr(errors.New("boom")),
+			NotEvaluated(missing),
+			&Node{Kind: "rule"},
+		)
+
+	s.Equal(`infix (name == "root") [p.sentrie:1:0-1] => true
+  identifier name [p.sentrie:1:0-1] => "root"
+  literal "root" [p.sentrie:1:8-9] => error: boom
+  not-evaluated missing [p.sentrie:1:16-17]
+  rule
+`, root.Render())
+}
+
+func (s *TraceTestSuite) TestRenderShortensLongSource() {
+	long := ast.NewIdentifier("a_very_long_identifier_that_goes_on_and_on_well_past_sixty_characters", tokens.Range{File: "p.sentrie"})
+	out := (&Node{Kind: "identifier", Node: long}).Render()
+	s.Contains(out, "identifier a_very_long_identifier_that_goes_on_and_on_well_past_sixt... [")
+	s.Empty((*Node)(nil).Render())
+}
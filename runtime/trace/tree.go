@@ -29,11 +29,12 @@ import (
 type Node struct {
 	// Kind is a high-level category: "literal", "identifier", "unary", "infix",
 	// "block", "field", "index", "call", "import", "ternary", "quantifier",
-	// "reduce", "transform", "rule", "policy"
+	// "reduce", "transform", "rule", "policy", or "not-evaluated" for a skipped expression
 	Kind string `json:"kind"`
 
 	// Op is the operator or sub-kind (e.g., "not", "+", "any", "collect", "filter"),
-	// or rule/policy name for those node kinds.
+	// or rule/policy name for those node kinds, or "compiled" for a compiled rule expression,
+	// which has no children.
 	Op string `json:"op,omitempty"`
 
 	// Duration is the time taken to evaluate the node.
@@ -75,6 +76,12 @@ func UnsupportedExpression(n ast.Node) *Node {
 	return &Node{Kind: "unsupported", Op: "", Node: n, Meta: map[string]any{"type": fmt.Sprintf("%T", n)}}
 }
 
+// NotEvaluated stands in for an expression that evaluation skipped, such as the branch of a
+// ternary that was not taken.
+func NotEvaluated(n ast.Node) *Node {
+	return &Node{Kind: "not-evaluated", Op: "", Node: n, Meta: map[string]any{"type": fmt.Sprintf("%T", n)}}
+}
+
 // Attach adds children and returns self for chaining.
 func (n *Node) Attach(children ...*Node) *Node {
 	if len(children) == 0 {
@@ -25,7 +25,7 @@ import (
 	"github.com/sentrie-sh/sentrie/tokens"
 )
 
-func validateValueAgainstTypeRef(ctx context.Context, ec *ExecutionContext, exec Executor, p *index.Policy, v box.Value, typeRef ast.TypeRef, valueRange tokens.Range) error {
+func validateValueAgainstTypeRef(ctx context.Context, ec *ExecutionContext, exec *executorImpl, p *index.Policy, v box.Value, typeRef ast.TypeRef, valueRange tokens.Range) error {
 	if ast.IsNullableTypeRef(typeRef) {
 		if v.IsNull() {
 			return nil
@@ -38,6 +38,8 @@ func validateValueAgainstTypeRef(ctx context.Context, ec *ExecutionContext, exec
 		return validateAgainstStringTypeRef(ctx, ec, exec, p, v, t, valueRange)
 	case *ast.TrinaryTypeRef:
 		return validateAgainstTrinaryTypeRef(ctx, ec, exec, p, v, t, valueRange)
+	case *ast.DateTypeRef:
+		return validateAgainstDateTypeRef(ctx, ec, exec, p, v, t, valueRange)
 	case *ast.NumberTypeRef:
 		return validateAgainstNumberTypeRef(ctx, ec, exec, p, v, t, valueRange)
 	case *ast.ListTypeRef:
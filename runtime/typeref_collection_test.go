@@ -25,16 +25,16 @@ import (
 func (r *RuntimeTestSuite) TestValidateAgainstListTypeRef() {
 	typeRef := ast.NewListTypeRef(ast.NewStringTypeRef(stubRange()), stubRange())
 
-	r.Run("rejects non-array inputs", func() {
+	r.Run("rejects non-list inputs", func() {
 		err := validateAgainstListTypeRef(r.T().Context(), &ExecutionContext{}, &executorImpl{}, &index.Policy{}, box.String("not-an-array"), typeRef, stubRange())
 		r.Error(err)
-		r.Contains(err.Error(), "is not an array")
+		r.Contains(err.Error(), "is not a list")
 	})
 
 	r.Run("rejects array item with invalid type", func() {
 		err := validateAgainstListTypeRef(r.T().Context(), &ExecutionContext{}, &executorImpl{}, &index.Policy{}, box.FromAny([]any{"ok", 2.0}), typeRef, stubRange())
 		r.Error(err)
-		r.Contains(err.Error(), "item is not valid")
+		r.Contains(err.Error(), "element 1: value 2 is not valid at "+stubRange().String())
 	})
 
 	r.Run("accepts valid list item types", func() {
@@ -43,6 +43,52 @@ func (r *RuntimeTestSuite) TestValidateAgainstListTypeRef() {
 	})
 }
 
+func (r *RuntimeTestSuite) TestValidateAgainstListTypeRefElementConstraints() {
+	elemType := ast.NewStringTypeRef(stubRange())
+	r.Require().NoError(elemType.AddConstraint(ast.NewTypeRefConstraint("email", nil, stubRange())))
+	typeRef := ast.NewListTypeRef(elemType, stubRange())
+
+	r.Run("accepts an empty list", func() {
+		err := validateAgainstListTypeRef(r.T().Context(), &ExecutionContext{}, &executorImpl{}, &index.Policy{}, box.FromAny([]any{}), typeRef, stubRange())
+		r.NoError(err)
+	})
+
+	r.Run("accepts elements satisfying the element constraints", func() {
+		err := validateAgainstListTypeRef(r.T().Context(), &ExecutionContext{}, &executorImpl{}, &index.Policy{}, box.FromAny([]any{"a@example.com", "b@example.com"}), typeRef, stubRange())
+		r.NoError(err)
+	})
+
+	r.Run("reports the index of the failing element", func() {
+		err := validateAgainstListTypeRef(r.T().Context(), &ExecutionContext{}, &executorImpl{}, &index.Policy{}, box.FromAny([]any{"a@example.com", "b@example.com", "foo"}), typeRef, stubRange())
+		r.Error(err)
+		r.Contains(err.Error(), `element 2: value "foo" is not valid at `+stubRange().String())
+		r.Contains(err.Error(), "constraint failed: 'email'")
+	})
+}
+
+func (r *RuntimeTestSuite) TestValidateAgainstListTypeRefLengthConstraints() {
+	typeRef := ast.NewListTypeRef(ast.NewNumberTypeRef(stubRange()), stubRange())
+	r.Require().NoError(typeRef.AddConstraint(ast.NewTypeRefConstraint("minlength", []ast.Expression{ast.NewIntegerLiteral(1, stubRange())}, stubRange())))
+	r.Require().NoError(typeRef.AddConstraint(ast.NewTypeRefConstraint("maxlength", []ast.Expression{ast.NewIntegerLiteral(3, stubRange())}, stubRange())))
+
+	r.Run("accepts a list within bounds", func() {
+		err := validateAgainstListTypeRef(r.T().Context(), &ExecutionContext{}, &executorImpl{}, &index.Policy{}, box.FromAny([]any{1.0, 2.0}), typeRef, stubRange())
+		r.NoError(err)
+	})
+
+	r.Run("rejects an empty list", func() {
+		err := validateAgainstListTypeRef(r.T().Context(), &ExecutionContext{}, &executorImpl{}, &index.Policy{}, box.FromAny([]any{}), typeRef, stubRange())
+		r.Error(err)
+		r.Contains(err.Error(), "constraint failed")
+	})
+
+	r.Run("rejects a list that is too long", func() {
+		err := validateAgainstListTypeRef(r.T().Context(), &ExecutionContext{}, &executorImpl{}, &index.Policy{}, box.FromAny([]any{1.0, 2.0, 3.0, 4.0}), typeRef, stubRange())
+		r.Error(err)
+		r.Contains(err.Error(), "constraint failed")
+	})
+}
+
 func (r *RuntimeTestSuite) TestValidateAgainstMapTypeRef() {
 	typeRef := ast.NewDictTypeRef(ast.NewNumberTypeRef(stubRange()), stubRange())
 
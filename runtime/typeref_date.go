@@ -0,0 +1,41 @@
+// SPDX-FileCopyrightText: © 2026 Binaek Sarkar <binaek89@gmail.com>
+// SPDX-License-Identifier: Apache-2.0
+
+package runtime
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/sentrie-sh/sentrie/ast"
+	"github.com/sentrie-sh/sentrie/box"
+	"github.com/sentrie-sh/sentrie/constraints"
+	"github.com/sentrie-sh/sentrie/index"
+	"github.com/sentrie-sh/sentrie/tokens"
+)
+
+func validateAgainstDateTypeRef(ctx context.Context, ec *ExecutionContext, exec *executorImpl, p *index.Policy, v box.Value, typeRef *ast.DateTypeRef, valueRange tokens.Range) error {
+	if _, ok := v.DateValue(); !ok {
+		return fmt.Errorf("value '%v' is not a date at %s - expected an RFC3339 / ISO-8601 date such as 2006-01-02 or 2006-01-02T15:04:05Z", v, valueRange)
+	}
+
+	for _, constraint := range typeRef.GetConstraints() {
+		args := make([]box.Value, len(constraint.Args))
+		for i, argExpr := range constraint.Args {
+			csArg, _, err := eval(ctx, ec, exec, p, argExpr)
+			if err != nil {
+				return err
+			}
+			args[i] = csArg
+		}
+		checker, ok := constraints.DateConstraintCheckers[constraint.Name]
+		if !ok {
+			return ErrUnknownConstraint(constraint)
+		}
+
+		if err := checker.Checker(ctx, p, v, args); err != nil {
+			return ErrConstraintFailed(valueRange, constraint, err)
+		}
+	}
+	return nil
+}
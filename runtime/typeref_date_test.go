@@ -0,0 +1,51 @@
+// SPDX-FileCopyrightText: © 2026 Binaek Sarkar <binaek89@gmail.com>
+// SPDX-License-Identifier: Apache-2.0
+
+package runtime
+
+import (
+	"fmt"
+
+	"github.com/sentrie-sh/sentrie/ast"
+	"github.com/sentrie-sh/sentrie/box"
+	"github.com/sentrie-sh/sentrie/index"
+)
+
+func (r *RuntimeTestSuite) TestValidateAgainstDateTypeRef() {
+	validate := func(v box.Value, typeRef *ast.DateTypeRef) error {
+		return validateAgainstDateTypeRef(r.T().Context(), &ExecutionContext{}, &executorImpl{}, &index.Policy{}, v, typeRef, stubRange())
+	}
+
+	r.Run("accepts RFC3339 timestamps and bare dates", func() {
+		typeRef := ast.NewDateTypeRef(stubRange())
+		r.NoError(validate(box.String("2024-05-01T10:00:00Z"), typeRef))
+		r.NoError(validate(box.String("2024-05-01T10:00:00.123+05:30"), typeRef))
+		r.NoError(validate(box.String("2024-05-01"), typeRef))
+	})
+
+	r.Run("rejects strings that are not dates", func() {
+		err := validate(box.String("next tuesday"), ast.NewDateTypeRef(stubRange()))
+		r.Require().Error(err)
+		r.Contains(err.Error(), fmt.Sprintf("value 'next tuesday' is not a date at %s", stubRange()))
+	})
+
+	r.Run("rejects non-strings", func() {
+		r.Error(validate(box.Number(20240501), ast.NewDateTypeRef(stubRange())))
+	})
+
+	r.Run("applies after and before constraints", func() {
+		typeRef := ast.NewDateTypeRef(stubRange())
+		r.Require().NoError(typeRef.AddConstraint(ast.NewTypeRefConstraint("after", []ast.Expression{ast.NewStringLiteral("2020-01-01", stubRange())}, stubRange())))
+		r.Require().NoError(typeRef.AddConstraint(ast.NewTypeRefConstraint("before", []ast.Expression{ast.NewStringLiteral("2030-01-01", stubRange())}, stubRange())))
+
+		r.NoError(validate(box.String("2024-05-01"), typeRef))
+
+		err := validate(box.String("2019-05-01"), typeRef)
+		r.Require().Error(err)
+		r.Contains(err.Error(), "is not after")
+
+		err = validate(box.String("2031-05-01"), typeRef)
+		r.Require().Error(err)
+		r.Contains(err.Error(), "is not before")
+	})
+}
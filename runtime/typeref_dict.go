@@ -27,7 +27,7 @@ import (
 	"github.com/sentrie-sh/sentrie/tokens"
 )
 
-func validateAgainstDictTypeRef(ctx context.Context, ec *ExecutionContext, exec Executor, p *index.Policy, v box.Value, typeRef *ast.DictTypeRef, pos tokens.Range) error {
+func validateAgainstDictTypeRef(ctx context.Context, ec *ExecutionContext, exec *executorImpl, p *index.Policy, v box.Value, typeRef *ast.DictTypeRef, pos tokens.Range) error {
 	if _, ok := v.DictValue(); !ok {
 		return fmt.Errorf("value %v is not a dict", v)
 	}
@@ -35,7 +35,7 @@ func validateAgainstDictTypeRef(ctx context.Context, ec *ExecutionContext, exec
 	for _, constraint := range typeRef.GetConstraints() {
 		args := make([]box.Value, len(constraint.Args))
 		for i, argExpr := range constraint.Args {
-			csArg, _, err := eval(ctx, ec, exec.(*executorImpl), p, argExpr)
+			csArg, _, err := eval(ctx, ec, exec, p, argExpr)
 			if err != nil {
 				return err
 			}
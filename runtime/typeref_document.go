@@ -27,7 +27,7 @@ import (
 	"github.com/sentrie-sh/sentrie/tokens"
 )
 
-func validateAgainstDocumentTypeRef(ctx context.Context, ec *ExecutionContext, exec Executor, p *index.Policy, v box.Value, typeRef *ast.DocumentTypeRef, pos tokens.Range) error {
+func validateAgainstDocumentTypeRef(ctx context.Context, ec *ExecutionContext, exec *executorImpl, p *index.Policy, v box.Value, typeRef *ast.DocumentTypeRef, pos tokens.Range) error {
 	// just validate that it's a map
 	if _, ok := v.DictValue(); !ok {
 		return fmt.Errorf("value %v is not a document at %s - expected document", v, pos)
@@ -36,7 +36,7 @@ func validateAgainstDocumentTypeRef(ctx context.Context, ec *ExecutionContext, e
 	for _, constraint := range typeRef.GetConstraints() {
 		args := make([]box.Value, len(constraint.Args))
 		for i, argExpr := range constraint.Args {
-			csArg, _, err := eval(ctx, ec, exec.(*executorImpl), p, argExpr)
+			csArg, _, err := eval(ctx, ec, exec, p, argExpr)
 			if err != nil {
 				return err
 			}
@@ -27,22 +27,23 @@ import (
 	"github.com/sentrie-sh/sentrie/tokens"
 )
 
-func validateAgainstListTypeRef(ctx context.Context, ec *ExecutionContext, exec Executor, p *index.Policy, v box.Value, typeRef *ast.ListTypeRef, pos tokens.Range) error {
+func validateAgainstListTypeRef(ctx context.Context, ec *ExecutionContext, exec *executorImpl, p *index.Policy, v box.Value, typeRef *ast.ListTypeRef, pos tokens.Range) error {
 	items, ok := v.ListValue()
 	if !ok {
-		return fmt.Errorf("value %v is not an array at %s - expected array", v, pos)
+		return fmt.Errorf("value %v is not a list at %s - expected list", v, pos)
 	}
 
-	for _, item := range items {
+	// the element type carries its own constraints, e.g. list[string @email()]
+	for i, item := range items {
 		if err := validateValueAgainstTypeRef(ctx, ec, exec, p, item, typeRef.ElemType, pos); err != nil {
-			return fmt.Errorf("item is not valid at %s: %w", pos, err) // TODO: improve this error message
+			return fmt.Errorf("element %d: value %s is not valid at %s: %w", i, describeListElement(item), pos, err)
 		}
 	}
 
 	for _, constraint := range typeRef.GetConstraints() {
 		args := make([]box.Value, len(constraint.Args))
 		for i, argExpr := range constraint.Args {
-			csArg, _, err := eval(ctx, ec, exec.(*executorImpl), p, argExpr)
+			csArg, _, err := eval(ctx, ec, exec, p, argExpr)
 			if err != nil {
 				return err
 			}
@@ -60,3 +61,11 @@ func validateAgainstListTypeRef(ctx context.Context, ec *ExecutionContext, exec
 
 	return nil
 }
+
+// describeListElement renders a list element for error messages, quoting strings.
+func describeListElement(v box.Value) string {
+	if s, ok := v.StringValue(); ok {
+		return fmt.Sprintf("%q", s)
+	}
+	return v.String()
+}
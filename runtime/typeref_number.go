@@ -27,7 +27,7 @@ import (
 	"github.com/sentrie-sh/sentrie/tokens"
 )
 
-func validateAgainstNumberTypeRef(ctx context.Context, ec *ExecutionContext, exec Executor, p *index.Policy, v box.Value, typeRef *ast.NumberTypeRef, pos tokens.Range) error {
+func validateAgainstNumberTypeRef(ctx context.Context, ec *ExecutionContext, exec *executorImpl, p *index.Policy, v box.Value, typeRef *ast.NumberTypeRef, pos tokens.Range) error {
 	if _, ok := v.NumberValue(); !ok {
 		return fmt.Errorf("value %v is not a number", v)
 	}
@@ -35,7 +35,7 @@ func validateAgainstNumberTypeRef(ctx context.Context, ec *ExecutionContext, exe
 	for _, constraint := range typeRef.GetConstraints() {
 		args := make([]box.Value, len(constraint.Args))
 		for i, argExpr := range constraint.Args {
-			csArg, _, err := eval(ctx, ec, exec.(*executorImpl), p, argExpr)
+			csArg, _, err := eval(ctx, ec, exec, p, argExpr)
 			if err != nil {
 				return err
 			}
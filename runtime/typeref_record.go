@@ -27,7 +27,7 @@ import (
 	"github.com/sentrie-sh/sentrie/tokens"
 )
 
-func validateAgainstRecordTypeRef(ctx context.Context, ec *ExecutionContext, exec Executor, p *index.Policy, v box.Value, typeRef *ast.RecordTypeRef, pos tokens.Range) error {
+func validateAgainstRecordTypeRef(ctx context.Context, ec *ExecutionContext, exec *executorImpl, p *index.Policy, v box.Value, typeRef *ast.RecordTypeRef, pos tokens.Range) error {
 	var entries []box.Value
 	if arr, ok := v.ListValue(); ok {
 		entries = arr
@@ -48,7 +48,7 @@ func validateAgainstRecordTypeRef(ctx context.Context, ec *ExecutionContext, exe
 	for _, constraint := range typeRef.GetConstraints() {
 		args := make([]box.Value, len(constraint.Args))
 		for i, argExpr := range constraint.Args {
-			csArg, _, err := eval(ctx, ec, exec.(*executorImpl), p, argExpr)
+			csArg, _, err := eval(ctx, ec, exec, p, argExpr)
 			if err != nil {
 				return err
 			}
@@ -18,7 +18,10 @@ package runtime
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"maps"
+	"slices"
 
 	"github.com/sentrie-sh/sentrie/ast"
 	"github.com/sentrie-sh/sentrie/box"
@@ -28,9 +31,8 @@ import (
 	"github.com/sentrie-sh/sentrie/xerr"
 )
 
-func validateAgainstShapeTypeRef(ctx context.Context, ec *ExecutionContext, exec Executor, p *index.Policy, v box.Value, typeRef *ast.ShapeTypeRef, pos tokens.Range) error {
-	var shape *index.Shape
-
+// resolveShape finds the shape typeRef refers to from p.
+func resolveShape(exec *executorImpl, p *index.Policy, typeRef *ast.ShapeTypeRef) (*index.Shape, error) {
 	shapeFqn := typeRef.Ref.String()
 
 	// look for the shape in the policy - this will override any shape that may have been defined in the namespace
@@ -38,40 +40,38 @@ func validateAgainstShapeTypeRef(ctx context.Context, ec *ExecutionContext, exec
 
 	// couldn't find the shape in the policy - check if it's in the namespace of the policy
 	if !ok {
-		s, o := p.Namespace.Shapes[shapeFqn]
-		if o {
-			shape = s
-		}
-		ok = o
+		shape, ok = p.Namespace.Shapes[shapeFqn]
 	}
 
-	// we couldn't find the shape in the policy - go global.
-	// lookup the index with the shape
-	if !ok && len(typeRef.Ref.Parts) > 2 {
-		ns := typeRef.Ref.Parent()
-		name := typeRef.Ref.LastSegment()
-
-		// get the namespace
-		namespace, err := exec.Index().ResolveNamespace(ns.String())
-		if err != nil {
-			return err
-		}
-		if namespace == nil {
-			return xerr.ErrNamespaceNotFound(ns.String())
+	// resolve relative to the namespace, then the imports, then as an absolute FQN - see index.ResolveShapeRef
+	if !ok && exec.Index() != nil {
+		ns, s, err := exec.Index().ResolveShapeRef(p.Namespace, p, *typeRef.Ref)
+		if err != nil && !errors.Is(err, xerr.NotFoundError{}) {
+			return nil, err
 		}
-		if err := namespace.VerifyShapeExported(name); err != nil {
-			return err
-		}
-
-		shape, err = exec.Index().ResolveShape(ns.String(), name)
-		if err != nil {
-			return err
+		if s != nil {
+			// shapes from other namespaces are only visible when exported
+			if ns.FQN.String() != p.Namespace.FQN.String() {
+				if err := ns.VerifyShapeExported(s.Name); err != nil {
+					return nil, err
+				}
+			}
+			shape = s
 		}
 	}
 
 	// if we still don't have a shape, return an error
 	if shape == nil {
-		return xerr.ErrShapeNotFound(fmt.Sprintf("shape '%s' not found at %s", shapeFqn, typeRef.Span()))
+		return nil, xerr.ErrShapeNotFound(fmt.Sprintf("shape '%s' not found at %s", shapeFqn, typeRef.Span()))
+	}
+	return shape, nil
+}
+
+func validateAgainstShapeTypeRef(ctx context.Context, ec *ExecutionContext, exec *executorImpl, p *index.Policy, v box.Value, typeRef *ast.ShapeTypeRef, pos tokens.Range) error {
+	shapeFqn := typeRef.Ref.String()
+	shape, err := resolveShape(exec, p, typeRef)
+	if err != nil {
+		return err
 	}
 
 	// a simple shape is an alias to another typeref
@@ -86,29 +86,52 @@ func validateAgainstShapeTypeRef(ctx context.Context, ec *ExecutionContext, exec
 		return fmt.Errorf("value %v is not a shape at %s - expected shape", v, pos)
 	}
 
-	// check the fields
-	for _, field := range shape.Model.Fields {
+	// every field is checked, in name order, so that all violations are reported and always alike
+	var violations []error
+	for _, name := range slices.Sorted(maps.Keys(shape.Model.Fields)) {
+		field := shape.Model.Fields[name]
 		fieldValue, ok := vm[field.Name]
 		if !ok {
-			if field.Optional {
+			if !field.Optional {
+				violations = append(violations, fmt.Errorf("field %s is required at %s - expected field", field.Name, pos))
 				continue
 			}
-			return fmt.Errorf("field %s is required at %s - expected field", field.Name, pos)
+			if field.Default == nil {
+				continue
+			}
+			// the default is applied when the value is bound, but must itself satisfy the field's type
+			if _, err := evalShapeFieldDefault(ctx, ec, exec, p, field); err != nil {
+				violations = append(violations, err)
+			}
+			continue
 		}
 
 		if fieldValue.IsUndefined() {
-			return fmt.Errorf("field %s cannot be undefined at %s - expected field value", field.Name, pos)
+			violations = append(violations, fmt.Errorf("field %s cannot be undefined at %s - expected field value", field.Name, pos))
+			continue
 		}
 
 		if err := validateValueAgainstTypeRef(ctx, ec, exec, p, fieldValue, field.TypeRef, pos); err != nil {
-			return fmt.Errorf("field '%s' is not valid: %w", field.Name, err)
+			violations = append(violations, wrapViolations(err, "field '%s' is not valid", field.Name)...)
+		}
+	}
+
+	// extra fields are allowed unless the shape is closed
+	if shape.Model.Closed {
+		for _, name := range slices.Sorted(maps.Keys(vm)) {
+			if _, declared := shape.Model.Fields[name]; !declared {
+				violations = append(violations, fmt.Errorf("field %s is not declared by closed shape '%s' at %s", name, shapeFqn, pos))
+			}
 		}
 	}
+	if len(violations) > 0 {
+		return errors.Join(violations...)
+	}
 
 	for _, constraint := range typeRef.GetConstraints() {
 		args := make([]box.Value, len(constraint.Args))
 		for i, argExpr := range constraint.Args {
-			csArg, _, err := eval(ctx, ec, exec.(*executorImpl), p, argExpr)
+			csArg, _, err := eval(ctx, ec, exec, p, argExpr)
 			if err != nil {
 				return err
 			}
@@ -126,3 +149,106 @@ func validateAgainstShapeTypeRef(ctx context.Context, ec *ExecutionContext, exec
 
 	return nil
 }
+
+// wrapViolations prefixes err with the message format and args. When err joins several
+// violations, as a shape reports them, each is prefixed on its own, so that they stay apart.
+func wrapViolations(err error, format string, args ...any) []error {
+	prefix := fmt.Sprintf(format, args...)
+	joined, ok := err.(interface{ Unwrap() []error })
+	if !ok {
+		return []error{fmt.Errorf("%s: %w", prefix, err)}
+	}
+	var violations []error
+	for _, violation := range joined.Unwrap() {
+		violations = append(violations, fmt.Errorf("%s: %w", prefix, violation))
+	}
+	return violations
+}
+
+// evalShapeFieldDefault evaluates the default of field and checks it against the field's type.
+func evalShapeFieldDefault(ctx context.Context, ec *ExecutionContext, exec *executorImpl, p *index.Policy, field *index.ShapeModelField) (box.Value, error) {
+	dv, _, err := eval(ctx, ec, exec, p, field.Default)
+	if err != nil {
+		return box.Undefined(), fmt.Errorf("default of field '%s' cannot be evaluated: %w", field.Name, err)
+	}
+	if err := validateValueAgainstTypeRef(ctx, ec, exec, p, dv, field.TypeRef, field.Default.Span()); err != nil {
+		return box.Undefined(), fmt.Errorf("default of field '%s' is not valid: %w", field.Name, err)
+	}
+	return dv, nil
+}
+
+// withShapeDefaults returns a copy of v in which the missing optional fields of every shape
+// typeRef describes take their defaults. v itself is never modified. Values that do not match
+// typeRef are returned as they are, for validation to report.
+func withShapeDefaults(ctx context.Context, ec *ExecutionContext, exec *executorImpl, p *index.Policy, v box.Value, typeRef ast.TypeRef) (box.Value, error) {
+	typeRef = ast.UnwrapNullableTypeRef(typeRef)
+
+	switch t := typeRef.(type) {
+	case *ast.ListTypeRef:
+		items, ok := v.ListValue()
+		if !ok {
+			return v, nil
+		}
+		hydrated := make([]box.Value, len(items))
+		for i, item := range items {
+			hv, err := withShapeDefaults(ctx, ec, exec, p, item, t.ElemType)
+			if err != nil {
+				return v, err
+			}
+			hydrated[i] = hv
+		}
+		return box.List(hydrated), nil
+
+	case *ast.DictTypeRef:
+		entries, ok := v.DictValue()
+		if !ok {
+			return v, nil
+		}
+		hydrated := make(map[string]box.Value, len(entries))
+		for k, entry := range entries {
+			hv, err := withShapeDefaults(ctx, ec, exec, p, entry, t.ValueType)
+			if err != nil {
+				return v, err
+			}
+			hydrated[k] = hv
+		}
+		return box.Dict(hydrated), nil
+
+	case *ast.ShapeTypeRef:
+		shape, err := resolveShape(exec, p, t)
+		if err != nil {
+			// an unknown shape is reported by validation
+			return v, nil
+		}
+		if shape.AliasOf != nil {
+			return withShapeDefaults(ctx, ec, exec, p, v, shape.AliasOf)
+		}
+		vm, ok := v.DictValue()
+		if !ok {
+			return v, nil
+		}
+		hydrated := maps.Clone(vm)
+		for _, field := range shape.Model.Fields {
+			fieldValue, ok := vm[field.Name]
+			if !ok {
+				if !field.Optional || field.Default == nil {
+					continue
+				}
+				dv, err := evalShapeFieldDefault(ctx, ec, exec, p, field)
+				if err != nil {
+					return v, err
+				}
+				hydrated[field.Name] = dv
+				continue
+			}
+			hv, err := withShapeDefaults(ctx, ec, exec, p, fieldValue, field.TypeRef)
+			if err != nil {
+				return v, err
+			}
+			hydrated[field.Name] = hv
+		}
+		return box.Dict(hydrated), nil
+	}
+
+	return v, nil
+}
@@ -9,6 +9,9 @@ import (
 	"github.com/sentrie-sh/sentrie/ast"
 	"github.com/sentrie-sh/sentrie/box"
 	"github.com/sentrie-sh/sentrie/index"
+	"github.com/sentrie-sh/sentrie/pack"
+	"github.com/sentrie-sh/sentrie/parser"
+	"github.com/sentrie-sh/sentrie/trinary"
 )
 
 func (r *RuntimeTestSuite) TestValidateAgainstShapeTypeRef_FieldPresenceAndNullabilityMatrix() {
@@ -117,6 +120,55 @@ func (r *RuntimeTestSuite) TestValidateAgainstShapeTypeRef_FieldPresenceAndNulla
 	}
 }
 
+func (r *RuntimeTestSuite) TestValidateAgainstShapeTypeRefClosedShapes() {
+	typeRef := ast.NewShapeTypeRef(ast.NewFQN([]string{"User"}, stubRange()).Ptr(), stubRange())
+	newPolicy := func(closed bool) *index.Policy {
+		return &index.Policy{
+			Shapes: map[string]*index.Shape{
+				"User": {
+					Model: &index.ShapeModel{
+						Closed: closed,
+						Fields: map[string]*index.ShapeModelField{
+							"name": {Name: "name", TypeRef: ast.NewStringTypeRef(stubRange())},
+							"age":  {Name: "age", Optional: true, TypeRef: ast.NewNumberTypeRef(stubRange())},
+						},
+					},
+				},
+			},
+			Namespace: &index.Namespace{Shapes: map[string]*index.Shape{}},
+		}
+	}
+	withExtra := box.FromAny(map[string]any{"name": "ann", "role": "admin"})
+
+	r.Run("open shapes allow extra fields", func() {
+		err := validateAgainstShapeTypeRef(r.T().Context(), &ExecutionContext{}, &executorImpl{}, newPolicy(false), withExtra, typeRef, stubRange())
+		r.NoError(err)
+	})
+
+	r.Run("closed shapes reject extra fields", func() {
+		err := validateAgainstShapeTypeRef(r.T().Context(), &ExecutionContext{}, &executorImpl{}, newPolicy(true), withExtra, typeRef, stubRange())
+		r.Require().Error(err)
+		r.Contains(err.Error(), "field role is not declared by closed shape 'User'")
+	})
+
+	r.Run("closed shapes accept declared fields only", func() {
+		err := validateAgainstShapeTypeRef(r.T().Context(), &ExecutionContext{}, &executorImpl{}, newPolicy(true), box.FromAny(map[string]any{"name": "ann", "age": 3.0}), typeRef, stubRange())
+		r.NoError(err)
+	})
+
+	r.Run("missing required fields are reported by name", func() {
+		err := validateAgainstShapeTypeRef(r.T().Context(), &ExecutionContext{}, &executorImpl{}, newPolicy(true), box.FromAny(map[string]any{"age": 3.0}), typeRef, stubRange())
+		r.Require().Error(err)
+		r.Contains(err.Error(), "field name is required")
+	})
+
+	r.Run("nested field types are validated", func() {
+		err := validateAgainstShapeTypeRef(r.T().Context(), &ExecutionContext{}, &executorImpl{}, newPolicy(false), box.FromAny(map[string]any{"name": "ann", "age": "three"}), typeRef, stubRange())
+		r.Require().Error(err)
+		r.Contains(err.Error(), "field 'age' is not valid")
+	})
+}
+
 func (s *RuntimeTestSuite) TestValidateAgainstShapeTypeRefFieldErrorBranches() {
 	typeRef := ast.NewShapeTypeRef(ast.NewFQN([]string{"UserShape"}, stubRange()).Ptr(), stubRange())
 	policy := &index.Policy{
@@ -170,7 +222,7 @@ func (s *RuntimeTestSuite) TestValidateAgainstShapeTypeRefGlobalResolutionBranch
 		Namespace: &index.Namespace{Shapes: map[string]*index.Shape{}},
 	}
 	idx := index.CreateIndex()
-	exec := &executorImpl{index: idx}
+	exec := &executorImpl{index: viewOf(idx)}
 
 	err := validateAgainstShapeTypeRef(context.Background(), &ExecutionContext{}, exec, policy, box.FromAny(map[string]any{}), typeRef, stubRange())
 	s.Require().Error(err)
@@ -180,17 +232,19 @@ func (s *RuntimeTestSuite) TestValidateAgainstShapeTypeRefGlobalResolutionBranch
 	ns := &index.Namespace{
 		FQN:          nsFQN,
 		Policies:     map[string]*index.Policy{},
-		Shapes:       map[string]*index.Shape{"User": {Model: &index.ShapeModel{Fields: map[string]*index.ShapeModelField{}}}},
+		Shapes:       map[string]*index.Shape{"User": {Name: "User", Model: &index.ShapeModel{Fields: map[string]*index.ShapeModelField{}}}},
 		ShapeExports: map[string]*index.ExportedShape{},
 		Children:     []*index.Namespace{},
 	}
 	idx.Namespaces[nsFQN.String()] = ns
+	exec = &executorImpl{index: viewOf(idx)}
 
 	err = validateAgainstShapeTypeRef(context.Background(), &ExecutionContext{}, exec, policy, box.FromAny(map[string]any{}), typeRef, stubRange())
 	s.Require().Error(err)
 	s.Contains(err.Error(), "is not exported")
 
 	ns.ShapeExports["User"] = &index.ExportedShape{Name: "User"}
+	exec = &executorImpl{index: viewOf(idx)}
 	err = validateAgainstShapeTypeRef(context.Background(), &ExecutionContext{}, exec, policy, box.FromAny(map[string]any{}), typeRef, stubRange())
 	s.Require().NoError(err)
 }
@@ -212,3 +266,129 @@ func (s *RuntimeTestSuite) TestValidateAgainstShapeTypeRefConstraintBranches() {
 	s.Require().Error(err)
 	s.Contains(err.Error(), "is not a shape")
 }
+
+func (s *RuntimeTestSuite) TestValidateAgainstShapeTypeRefRelativeToNamespace() {
+	typeRef := ast.NewShapeTypeRef(ast.NewFQN([]string{"models", "User"}, stubRange()).Ptr(), stubRange())
+	current := &index.Namespace{FQN: ast.NewFQN([]string{"com", "example"}, stubRange()), Shapes: map[string]*index.Shape{}}
+	models := &index.Namespace{
+		FQN: ast.NewFQN([]string{"com", "example", "models"}, stubRange()),
+		Shapes: map[string]*index.Shape{"User": {Name: "User", Model: &index.ShapeModel{Fields: map[string]*index.ShapeModelField{
+			"name": {Name: "name", TypeRef: ast.NewStringTypeRef(stubRange())},
+		}}}},
+		ShapeExports: map[string]*index.ExportedShape{"User": {Name: "User"}},
+	}
+	policy := &index.Policy{Shapes: map[string]*index.Shape{}, Namespace: current}
+	idx := index.CreateIndex()
+	idx.Namespaces[current.FQN.String()] = current
+	idx.Namespaces[models.FQN.String()] = models
+	exec := &executorImpl{index: viewOf(idx)}
+
+	s.Require().NoError(validateAgainstShapeTypeRef(context.Background(), &ExecutionContext{}, exec, policy, box.FromAny(map[string]any{"name": "alice"}), typeRef, stubRange()))
+
+	err := validateAgainstShapeTypeRef(context.Background(), &ExecutionContext{}, exec, policy, box.FromAny(map[string]any{}), typeRef, stubRange())
+	s.Require().Error(err)
+	s.Contains(err.Error(), "field name is required")
+}
+
+func (s *RuntimeTestSuite) TestShapeFieldDefaultHydratesMissingField() {
+	exec := s.executorFromSource(`namespace com/example
+shape User {
+  name: string
+  role?: string default "guest"
+}
+policy auth {
+  fact user: User
+  rule guest = default false { yield user.role == "guest" }
+  export decision of guest
+}
+`)
+
+	outputs, err := exec.ExecPolicy(context.Background(), "com/example", "auth", map[string]any{"user": map[string]any{"name": "alice"}})
+	s.Require().NoError(err)
+	s.Require().Len(outputs, 1)
+	s.Equal(trinary.True, outputs[0].ToTrinary())
+
+	// a supplied value is kept
+	outputs, err = exec.ExecPolicy(context.Background(), "com/example", "auth", map[string]any{"user": map[string]any{"name": "alice", "role": "admin"}})
+	s.Require().NoError(err)
+	s.Equal(trinary.False, outputs[0].ToTrinary())
+}
+
+func (s *RuntimeTestSuite) TestShapeFieldDefaultMustSatisfyTheFieldType() {
+	exec := s.executorFromSource(`namespace com/example
+shape User {
+  name: string
+  level?: number @min(1) default 0
+}
+policy auth {
+  fact user: User
+  rule allow = default false { yield user.level > 0 }
+  export decision of allow
+}
+`)
+
+	_, err := exec.ExecPolicy(context.Background(), "com/example", "auth", map[string]any{"user": map[string]any{"name": "alice"}})
+	s.Require().Error(err)
+	s.Contains(err.Error(), "default of field 'level' is not valid")
+
+	_, err = exec.ExecPolicy(context.Background(), "com/example", "auth", map[string]any{"user": map[string]any{"name": "alice", "level": 2}})
+	s.NoError(err)
+}
+
+func (s *RuntimeTestSuite) TestShapeImportValidatesFacts() {
+	ctx := context.Background()
+	idx := index.CreateIndex()
+	s.Require().NoError(idx.SetPack(ctx, &pack.PackFile{Location: s.T().TempDir()}))
+	for name, src := range map[string]string{
+		"shared.sentrie": `namespace com/example/shared
+shape UserShape { name: string role: string }
+export shape UserShape
+`,
+		"app.sentrie": `namespace com/example/app
+policy auth {
+  fact user: U
+  use UserShape from com/example/shared as U
+  rule admin = default false { yield user.role == "admin" }
+  export decision of admin
+}
+`,
+	} {
+		program, err := parser.NewParserFromString(src, name).ParseProgram(ctx)
+		s.Require().NoError(err)
+		s.Require().NoError(idx.AddProgram(ctx, program))
+	}
+	s.Require().NoError(idx.Validate(ctx))
+	exec := &executorImpl{index: viewOf(idx)}
+
+	outputs, err := exec.ExecPolicy(ctx, "com/example/app", "auth", map[string]any{"user": map[string]any{"name": "alice", "role": "admin"}})
+	s.Require().NoError(err)
+	s.Equal(trinary.True, outputs[0].ToTrinary())
+
+	_, err = exec.ExecPolicy(ctx, "com/example/app", "auth", map[string]any{"user": map[string]any{"name": "alice"}})
+	s.Require().Error(err)
+	s.Contains(err.Error(), "role")
+}
+
+func (s *RuntimeTestSuite) TestShapeFieldDefaultsDoNotModifyTheValue() {
+	typeRef := ast.NewShapeTypeRef(ast.NewFQN([]string{"User"}, stubRange()).Ptr(), stubRange())
+	policy := &index.Policy{
+		Shapes: map[string]*index.Shape{
+			"User": {Model: &index.ShapeModel{Fields: map[string]*index.ShapeModelField{
+				"role": {Name: "role", Optional: true, TypeRef: ast.NewStringTypeRef(stubRange()), Default: ast.NewStringLiteral("guest", stubRange())},
+			}}},
+		},
+		Namespace: &index.Namespace{Shapes: map[string]*index.Shape{}},
+	}
+	exec := &executorImpl{}
+	user := map[string]box.Value{}
+	users := box.List([]box.Value{box.Dict(user)})
+
+	// validation checks the default without applying it
+	s.Require().NoError(validateAgainstShapeTypeRef(context.Background(), &ExecutionContext{}, exec, policy, box.Dict(user), typeRef, stubRange()))
+	s.Empty(user)
+
+	hydrated, err := withShapeDefaults(context.Background(), &ExecutionContext{}, exec, policy, users, ast.NewListTypeRef(typeRef, stubRange()))
+	s.Require().NoError(err)
+	s.Equal([]any{map[string]any{"role": "guest"}}, hydrated.Any())
+	s.Empty(user)
+}
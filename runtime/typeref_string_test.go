@@ -950,3 +950,67 @@ func (r *RuntimeTestSuite) TestValidateAgainstStringTypeRefOneOfConstraint() {
 		r.Contains(err.Error(), "constraint failed")
 	})
 }
+
+func (r *RuntimeTestSuite) TestValidateAgainstStringTypeRefNotOneOfConstraint() {
+	rng := tokens.Range{File: "test.sentra", From: tokens.Pos{Line: 1, Column: 1, Offset: 0}, To: tokens.Pos{Line: 1, Column: 1, Offset: 0}}
+	typeRef := ast.NewStringTypeRef(rng)
+
+	constraint := ast.NewTypeRefConstraint(
+		"not_one_of",
+		[]ast.Expression{
+			ast.NewStringLiteral("admin", rng),
+			ast.NewStringLiteral("root", rng),
+			ast.NewStringLiteral("system", rng),
+		},
+		rng,
+	)
+	r.Require().NoError(typeRef.AddConstraint(constraint))
+
+	r.Run("should pass when string is not in the blocklist", func() {
+		err := validateAgainstStringTypeRef(r.T().Context(), &ExecutionContext{}, &executorImpl{}, &index.Policy{}, box.FromAny("alice"), typeRef, rng)
+
+		r.NoError(err)
+	})
+
+	r.Run("should fail and name the rejected value", func() {
+		err := validateAgainstStringTypeRef(r.T().Context(), &ExecutionContext{}, &executorImpl{}, &index.Policy{}, box.FromAny("root"), typeRef, rng)
+
+		r.Error(err)
+		r.Contains(err.Error(), "constraint failed")
+		r.Contains(err.Error(), `"root"`)
+	})
+
+	r.Run("should reject an empty blocklist at validation time", func() {
+		empty := ast.NewTypeRefConstraint("not_one_of", []ast.Expression{}, rng)
+		err := ast.NewStringTypeRef(rng).AddConstraint(empty)
+
+		r.Error(err)
+		r.Contains(err.Error(), "requires at least 1 argument")
+	})
+}
+
+func (r *RuntimeTestSuite) TestValidateAgainstStringTypeRefSemverConstraint() {
+	rng := tokens.Range{File: "test.sentra", From: tokens.Pos{Line: 1, Column: 1, Offset: 0}, To: tokens.Pos{Line: 1, Column: 1, Offset: 0}}
+	typeRef := ast.NewStringTypeRef(rng)
+	r.Require().NoError(typeRef.AddConstraint(ast.NewTypeRefConstraint("semver", []ast.Expression{}, rng)))
+
+	r.Run("should pass for a full semantic version", func() {
+		err := validateAgainstStringTypeRef(r.T().Context(), &ExecutionContext{}, &executorImpl{}, &index.Policy{}, box.FromAny("1.2.0-rc.1+build.5"), typeRef, rng)
+
+		r.NoError(err)
+	})
+
+	r.Run("should pass for a partial version, like a policy version header", func() {
+		err := validateAgainstStringTypeRef(r.T().Context(), &ExecutionContext{}, &executorImpl{}, &index.Policy{}, box.FromAny("v1.2"), typeRef, rng)
+
+		r.NoError(err)
+	})
+
+	r.Run("should fail for a non-version and embed the value", func() {
+		err := validateAgainstStringTypeRef(r.T().Context(), &ExecutionContext{}, &executorImpl{}, &index.Policy{}, box.FromAny("1.2.x"), typeRef, rng)
+
+		r.Error(err)
+		r.Contains(err.Error(), "constraint failed")
+		r.Contains(err.Error(), `"1.2.x"`)
+	})
+}
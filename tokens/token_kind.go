@@ -3,6 +3,8 @@
 
 package tokens
 
+import "regexp"
+
 type Kind string
 
 const (
@@ -19,6 +21,10 @@ const (
 	Float  Kind = "Float"
 	Bool   Kind = "Bool"
 
+	// TemplateString is a double-quoted string holding "${ ... }" interpolations. Its value is the
+	// raw text between the quotes.
+	TemplateString Kind = "TemplateString"
+
 	// Keywords
 	KeywordNull Kind = "null"
 
@@ -94,6 +100,7 @@ const (
 
 	TokenPipelineHole Kind = "PipelineHole"
 	TokenPipeForward  Kind = "PipeForward"
+	TokenQuestionDot  Kind = "QuestionDot" // optional chaining `?.`
 
 	// Punctuation
 	PunctComma            Kind = "Comma"
@@ -110,6 +117,14 @@ const (
 	TrailingComment Kind = "TrailingComment"
 )
 
+var identifierPattern = regexp.MustCompile(`^[a-zA-Z_][a-zA-Z0-9_]*$`)
+
+// IsIdentifier reports whether str is a valid identifier: an ASCII letter or underscore
+// followed by ASCII letters, digits or underscores.
+func IsIdentifier(str string) bool {
+	return identifierPattern.MatchString(str)
+}
+
 func IsKeyword(str string) (Kind, bool) {
 	kind, exists := keywords[str]
 	return kind, exists
@@ -125,6 +125,21 @@ func (s *TrinaryTestSuite) TestOr() {
 	s.Equal(Unknown, Unknown.Or(invalidValue))    // Unknown OR invalid = Unknown (default case)
 }
 
+// TestXor tests the Xor() method with comprehensive truth table
+func (s *TrinaryTestSuite) TestXor() {
+	s.Equal(False, True.Xor(True))
+	s.Equal(True, True.Xor(False))
+	s.Equal(Unknown, True.Xor(Unknown))
+
+	s.Equal(True, False.Xor(True))
+	s.Equal(False, False.Xor(False))
+	s.Equal(Unknown, False.Xor(Unknown))
+
+	s.Equal(Unknown, Unknown.Xor(True))
+	s.Equal(Unknown, Unknown.Xor(False))
+	s.Equal(Unknown, Unknown.Xor(Unknown))
+}
+
 // TestEquals tests the Equals() method
 func (s *TrinaryTestSuite) TestEquals() {
 	s.True(True.Equals(True))
@@ -133,6 +133,16 @@ func (r Value) Or(other Value) Value {
 	}
 }
 
+// Xor implements tri-state XOR using Kleene logic; it is unknown whenever either side is.
+// | **XOR**     | **True** | **False** | **Unknown** |
+// | ----------- | -------- | --------- | ----------- |
+// | **True**    | False    | True      | Unknown     |
+// | **False**   | True     | False     | Unknown     |
+// | **Unknown** | Unknown  | Unknown   | Unknown     |
+func (r Value) Xor(other Value) Value {
+	return r.Or(other).And(r.And(other).Not())
+}
+
 func (r Value) Equals(other Value) bool {
 	return r == other
 }
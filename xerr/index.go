@@ -16,7 +16,30 @@
 
 package xerr
 
-import "errors"
+import (
+	"errors"
+
+	"github.com/sentrie-sh/sentrie/tokens"
+)
 
 // ErrIndex is the root sentinel for static index construction and validation failures.
 var ErrIndex = errors.New("index error")
+
+// LocatedError is an index error raised at a range of the source, such as a reference that does
+// not resolve. Its message is that of the error it wraps.
+type LocatedError struct {
+	where tokens.Range
+	err   error
+}
+
+func (e LocatedError) Error() string { return e.err.Error() }
+
+func (e LocatedError) Unwrap() error { return e.err }
+
+// Where returns the range of the source the error was raised at.
+func (e LocatedError) Where() tokens.Range { return e.where }
+
+// ErrAt locates err at where.
+func ErrAt(where tokens.Range, err error) error {
+	return LocatedError{where: where, err: err}
+}
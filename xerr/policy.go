@@ -24,4 +24,5 @@ var (
 	ErrPolicyInvalidVersion     = wrapCategory(ErrIndex, `Invalid policy version: expected SemVer string (e.g., "1.2.3").`)
 	ErrPolicyEmptyTitle         = wrapCategory(ErrIndex, "policy title must not be empty or whitespace-only.")
 	ErrPolicyEmptyTagKey        = wrapCategory(ErrIndex, "tag key must not be empty or whitespace-only.")
+	ErrPolicyUnknownCombining   = wrapCategory(ErrIndex, `Unknown combining algorithm: expected "deny-overrides", "permit-overrides" or "first-applicable".`)
 )
@@ -72,6 +72,9 @@ func (e ConflictError) Error() string {
 	return fmt.Sprintf("conflict: %s at %s with %s", e.what, e.where.String(), e.with.String())
 }
 
+// Where returns the range of the conflicting declaration.
+func (e ConflictError) Where() tokens.Range { return e.where }
+
 func ErrConflict(what string, where, with tokens.Range) error {
 	return ConflictError{what: what, where: where, with: with}
 }
@@ -124,6 +127,10 @@ func ErrShapeNotFound(name string) error {
 	return wrapCategoryf(NotFoundError{}, "shape: %s", name)
 }
 
+func ErrAmbiguousReference(ref string, candidates []string) error {
+	return wrapCategoryf(AmbiguousReferenceError{}, "%s matches %s", ref, strings.Join(candidates, ", "))
+}
+
 func ErrNotExported(fqn string) error {
 	return wrapCategory(NotExportedError{}, fqn)
 }
@@ -148,6 +155,12 @@ func (e NotFoundError) Error() string {
 	return "not found"
 }
 
+type AmbiguousReferenceError struct{}
+
+func (e AmbiguousReferenceError) Error() string {
+	return "ambiguous reference"
+}
+
 type NotExportedError struct{}
 
 func (e NotExportedError) Error() string { return "rule is not exported" }